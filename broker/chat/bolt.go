@@ -271,6 +271,9 @@ func (h *boltdbimpl) CountMessages(room *chat.ChatRoom) (count int, e error) {
 
 func (h *boltdbimpl) ListMessages(request *chat.ListMessagesRequest) (messages []*chat.ChatMessage, e error) {
 
+	if request.RoomUuid == "" && request.ByAuthor != "" {
+		return h.listMessagesByAuthor(request.ByAuthor)
+	}
 	bounds := request.Limit > 0 || request.Offset > 0
 	e = h.DB().View(func(tx *bolt.Tx) error {
 
@@ -291,6 +294,9 @@ func (h *boltdbimpl) ListMessages(request *chat.ListMessagesRequest) (messages [
 				if err := json.Unmarshal(v, &msg); err != nil {
 					continue
 				}
+				if request.ByAuthor != "" && msg.Author != request.ByAuthor {
+					continue
+				}
 				if request.Limit > 0 && int64(len(messages)) >= request.Limit {
 					break
 				}
@@ -305,6 +311,9 @@ func (h *boltdbimpl) ListMessages(request *chat.ListMessagesRequest) (messages [
 				if err != nil {
 					return err
 				}
+				if request.ByAuthor != "" && msg.Author != request.ByAuthor {
+					return nil
+				}
 				messages = append(messages, &msg)
 				return nil
 			})
@@ -321,6 +330,35 @@ func (h *boltdbimpl) ListMessages(request *chat.ListMessagesRequest) (messages [
 
 	return messages, e
 }
+
+// listMessagesByAuthor browses all rooms messages and returns the ones posted by a given user.
+func (h *boltdbimpl) listMessagesByAuthor(author string) (result []*chat.ChatMessage, e error) {
+	e = h.DB().View(func(tx *bolt.Tx) error {
+		mainBucket := tx.Bucket([]byte(messages))
+		return mainBucket.ForEach(func(k, v []byte) error {
+			if v != nil {
+				return nil
+			}
+			// Rooms are stored in the same bucket, under their type name
+			if _, isType := chat.RoomType_value[string(k)]; isType {
+				return nil
+			}
+			return mainBucket.Bucket(k).ForEach(func(mk, mv []byte) error {
+				if mv == nil {
+					return nil
+				}
+				var msg chat.ChatMessage
+				if err := json.Unmarshal(mv, &msg); err != nil || msg.Author != author {
+					return nil
+				}
+				result = append(result, &msg)
+				return nil
+			})
+		})
+	})
+	return
+}
+
 func (h *boltdbimpl) PostMessage(msg *chat.ChatMessage) (*chat.ChatMessage, error) {
 
 	if msg.Uuid == "" {
@@ -21,9 +21,13 @@
 package rest
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/emicklei/go-restful"
 
 	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/auth/claim"
 	"github.com/pydio/cells/common/proto/log"
 	"github.com/pydio/cells/common/proto/rest"
 	"github.com/pydio/cells/common/registry"
@@ -51,6 +55,10 @@ func (h *Handler) Syslog(req *restful.Request, rsp *restful.Response) {
 		return
 	}
 	ctx := req.Request.Context()
+	// Non-admin users (e.g. delegated administrators) can only see their own logs
+	if claims, ok := ctx.Value(claim.ContextKey).(claim.Claims); ok && claims.Profile != common.PydioProfileAdmin {
+		input.Query = strings.TrimSpace(fmt.Sprintf("+UserUuid:\"%s\" %s", claims.Subject, input.Query))
+	}
 
 	c := log.NewLogRecorderClient(registry.GetClient(common.ServiceLog))
 
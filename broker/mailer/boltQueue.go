@@ -109,6 +109,16 @@ func (b *BoltQueue) Push(email *mailer.Mail) error {
 	})
 }
 
+// Len returns the number of mails waiting in the queue.
+func (b *BoltQueue) Len() int {
+	var l int
+	b.db.View(func(tx *bolt.Tx) error {
+		l = tx.Bucket(bucketName).Stats().KeyN
+		return nil
+	})
+	return l
+}
+
 // Consume acquires the lock and send mails that are in the queue by batches,
 // sending at most 100 mails by batch.
 func (b *BoltQueue) Consume(sendHandler func(email *mailer.Mail) error) error {
@@ -43,6 +43,7 @@ import (
 type Queue interface {
 	Push(email *mailer.Mail) error
 	Consume(func(email *mailer.Mail) error) error
+	Len() int
 	Close() error
 }
 
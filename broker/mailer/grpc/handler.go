@@ -40,6 +40,7 @@ import (
 	"github.com/pydio/cells/common/log"
 	proto "github.com/pydio/cells/common/proto/mailer"
 	servicecontext "github.com/pydio/cells/common/service/context"
+	"github.com/pydio/cells/common/service/metrics"
 	"github.com/pydio/cells/x/configx"
 )
 
@@ -161,6 +162,7 @@ func (h *Handler) SendMail(ctx context.Context, req *proto.SendMailRequest, rsp
 				log.Logger(ctx).Error(fmt.Sprintf("cannot put mail in queue: %s", e.Error()), log.DangerouslyZapSmallSlice("to", tt), zap.Any("from", m.From), zap.Any("subject", m.Subject))
 				return e
 			}
+			h.reportQueueLength()
 		} else {
 			log.Logger(ctx).Info("SendMail: sending email", log.DangerouslyZapSmallSlice("to", tt), zap.Any("from", m.From), zap.Any("subject", m.Subject))
 			if e := h.sender.Send(m); e != nil {
@@ -172,6 +174,14 @@ func (h *Handler) SendMail(ctx context.Context, req *proto.SendMailRequest, rsp
 	return nil
 }
 
+// reportQueueLength updates the number of mails waiting in the queue
+func (h *Handler) reportQueueLength() {
+	if !metrics.Enabled() {
+		return
+	}
+	metrics.GetMetricsForService(common.ServiceGrpcNamespace_ + common.ServiceMailer).Gauge("mailer_queue_length").Update(float64(h.queue.Len()))
+}
+
 // ConsumeQueue browses current queue for emails to be sent
 func (h *Handler) ConsumeQueue(ctx context.Context, req *proto.ConsumeQueueRequest, rsp *proto.ConsumeQueueResponse) error {
 
@@ -188,6 +198,7 @@ func (h *Handler) ConsumeQueue(ctx context.Context, req *proto.ConsumeQueueReque
 	}
 
 	e := h.queue.Consume(c)
+	h.reportQueueLength()
 	if e != nil {
 		return e
 	}
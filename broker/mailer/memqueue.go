@@ -42,6 +42,16 @@ func (m memQueue) Push(email *mailer.Mail) error {
 	return nil
 }
 
+func (m memQueue) Len() int {
+	var l int
+	for _, em := range m.list {
+		if em != nil {
+			l++
+		}
+	}
+	return l
+}
+
 func (m memQueue) Consume(mh func(email *mailer.Mail) error) error {
 	var i int = 0
 	for i = range m.list {
@@ -0,0 +1,284 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	bolt "github.com/etcd-io/bbolt"
+	"github.com/spf13/cobra"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/backup"
+	"github.com/pydio/cells/common/boltdb"
+	"github.com/pydio/cells/common/config"
+	"github.com/pydio/cells/common/dao"
+	"github.com/pydio/cells/common/sql"
+)
+
+var (
+	backupOutput  string
+	backupEncrypt bool
+)
+
+var backupCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a backup archive of the installation",
+	Long: `
+DESCRIPTION
+
+  Write an archive containing the configuration, the vault and its master key, a dump of all configured
+  databases and the services data files (BoltDB and Bleve indexes). The manifest refers to databases by
+  their configuration keys, connection strings are only saved within the configuration files.
+
+  The archive must be created while Cells is stopped: BoltDB files and Bleve indexes are held by the running
+  services and databases are dumped table by table, so that a running installation cannot be captured in a
+  consistent state. The command checks that no BoltDB file or Bleve index is locked before writing anything.
+
+EXAMPLES
+
+  1. Create a plain archive
+  $ ` + os.Args[0] + ` admin backup create --output /var/backups/cells.tar.gz
+
+  2. Create an encrypted archive, the password is asked interactively
+  $ ` + os.Args[0] + ` admin backup create --output /var/backups/cells.cbk --encrypt
+
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if backupOutput == "" {
+			backupOutput = fmt.Sprintf("cells-backup-%s.tar.gz", time.Now().Format("20060102-150405"))
+		}
+		if backupEncrypt && backupPassword == "" {
+			var e error
+			if backupPassword, e = promptBackupPassword(true); e != nil {
+				return e
+			}
+		}
+
+		if e := backupCheckStopped(); e != nil {
+			return e
+		}
+
+		out, e := os.OpenFile(backupOutput, os.O_CREATE|os.O_WRONLY|os.O_EXCL, 0600)
+		if e != nil {
+			return e
+		}
+		hostname, _ := os.Hostname()
+		manifest := &backup.Manifest{
+			CellsVersion: common.Version().String(),
+			PackageType:  common.PackageType,
+			Hostname:     hostname,
+			Created:      time.Now().UTC(),
+		}
+		w, e := backup.NewWriter(out, backupPassword, manifest)
+		if e == nil {
+			e = backupWrite(cmd, w)
+		}
+		if e == nil {
+			e = w.Close()
+		}
+		if ce := out.Close(); e == nil {
+			e = ce
+		}
+		if e != nil {
+			os.Remove(backupOutput)
+			return e
+		}
+		cmd.Printf("Backup written to %s (%d entries)\n", backupOutput, len(manifest.Entries))
+		return nil
+	},
+}
+
+// backupCheckStopped fails if a BoltDB file or a Bleve index is locked, i.e. if Cells is running.
+func backupCheckStopped() error {
+	var files []string
+	for _, name := range backupConfigFiles {
+		if isBackupBoltFile(name) {
+			files = append(files, backupConfigPath(name))
+		}
+	}
+	servicesDir := config.ApplicationWorkingDir(config.ApplicationDirServices)
+	e := filepath.Walk(servicesDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && path == servicesDir {
+				return nil
+			}
+			return err
+		}
+		if info.Mode().IsRegular() && isBackupBoltFile(path) {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if e != nil {
+		return e
+	}
+	for _, f := range files {
+		if boltdb.IsLocked(f) {
+			return fmt.Errorf("%s is locked by a running service, please stop Cells before creating a backup", f)
+		}
+	}
+	return nil
+}
+
+// isBackupBoltFile tells if a file is a BoltDB database, including the stores of the Bleve indexes.
+func isBackupBoltFile(path string) bool {
+	return strings.HasSuffix(path, ".db") || (filepath.Base(path) == "store" && strings.HasSuffix(filepath.Dir(path), ".bleve"))
+}
+
+// backupDatabase is a database connection shared by one or more configuration keys.
+type backupDatabase struct {
+	driver string
+	dsn    string
+	keys   []string
+}
+
+// backupDatabases lists the distinct databases declared in the configuration.
+func backupDatabases() []*backupDatabase {
+	keys := []string{"default"}
+	for k := range config.Get("databases").Map() {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var dbs []*backupDatabase
+	byDsn := map[string]*backupDatabase{}
+	for _, k := range keys {
+		driver, dsn := config.GetDatabase(k)
+		if driver == "" || dsn == "" {
+			continue
+		}
+		id := driver + "|" + dsn
+		if db, ok := byDsn[id]; ok {
+			db.keys = append(db.keys, k)
+			continue
+		}
+		db := &backupDatabase{driver: driver, dsn: dsn, keys: []string{k}}
+		byDsn[id] = db
+		dbs = append(dbs, db)
+	}
+	return dbs
+}
+
+// backupWrite adds configuration files, databases dumps and services data files to the archive.
+func backupWrite(cmd *cobra.Command, w *backup.Writer) error {
+	ctx := context.Background()
+	done := map[string]bool{}
+	servicesDir := config.ApplicationWorkingDir(config.ApplicationDirServices)
+
+	for _, name := range backupConfigFiles {
+		src := backupConfigPath(name)
+		if _, e := os.Stat(src); e != nil {
+			continue
+		}
+		entry := &backup.Entry{Name: "config/" + name, Kind: backup.KindConfig, Target: name}
+		var e error
+		if isBackupBoltFile(name) {
+			e = w.AddStream(entry, func(out io.Writer) error {
+				return boltdb.NewFileBackuper(src).Backup(ctx, out)
+			})
+		} else {
+			e = w.AddFile(entry, src)
+		}
+		if e != nil {
+			return fmt.Errorf("cannot backup %s: %v", name, e)
+		}
+		done[src] = true
+		cmd.Println("Added configuration file " + name)
+	}
+
+	for i, db := range backupDatabases() {
+		switch db.driver {
+		case "boltdb":
+			if done[db.dsn] {
+				continue
+			}
+			entry := &backup.Entry{Name: fmt.Sprintf("bolt/%d-%s", i, filepath.Base(db.dsn)), Kind: backup.KindBolt, Driver: db.driver, Services: db.keys}
+			if rel, e := filepath.Rel(servicesDir, db.dsn); e == nil && !strings.HasPrefix(rel, "..") {
+				entry.Target = filepath.ToSlash(rel)
+			}
+			if e := w.AddStream(entry, func(out io.Writer) error {
+				return boltdb.NewFileBackuper(db.dsn).Backup(ctx, out)
+			}); e != nil {
+				return fmt.Errorf("cannot backup %s: %v", db.dsn, e)
+			}
+			done[db.dsn] = true
+		default:
+			d, ok := sql.NewDAO(db.driver, db.dsn, "").(dao.Backuper)
+			if !ok {
+				return fmt.Errorf("cannot connect to database %s", strings.Join(db.keys, ", "))
+			}
+			entry := &backup.Entry{Name: fmt.Sprintf("databases/%d-%s.jsonl", i, db.driver), Kind: backup.KindDatabase, Driver: db.driver, Services: db.keys}
+			e := w.AddStream(entry, func(out io.Writer) error {
+				return d.Backup(ctx, out)
+			})
+			d.(dao.DAO).CloseConn()
+			if e != nil {
+				return fmt.Errorf("cannot dump database %s: %v", strings.Join(db.keys, ", "), e)
+			}
+		}
+		cmd.Println("Added database used by " + strings.Join(db.keys, ", "))
+	}
+
+	return filepath.Walk(servicesDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && path == servicesDir {
+				return nil
+			}
+			return err
+		}
+		if info.IsDir() || !info.Mode().IsRegular() || done[path] {
+			return nil
+		}
+		rel, e := filepath.Rel(servicesDir, path)
+		if e != nil {
+			return e
+		}
+		entry := &backup.Entry{Name: "services/" + filepath.ToSlash(rel), Kind: backup.KindFile, Target: filepath.ToSlash(rel)}
+		bolted := isBackupBoltFile(path)
+		if bolted {
+			e = w.AddStream(entry, func(out io.Writer) error {
+				return boltdb.NewFileBackuper(path).Backup(ctx, out)
+			})
+		}
+		if !bolted || e == bolt.ErrInvalid || e == bolt.ErrVersionMismatch {
+			// Not a BoltDB file: copy it as is
+			e = w.AddFile(entry, path)
+		}
+		if e != nil {
+			return fmt.Errorf("cannot backup %s: %v", path, e)
+		}
+		cmd.Println("Added services file " + filepath.ToSlash(rel))
+		return nil
+	})
+}
+
+func init() {
+	backupCreateCmd.Flags().StringVarP(&backupOutput, "output", "o", "", "Path of the archive to create (default cells-backup-<date>.tar.gz)")
+	backupCreateCmd.Flags().BoolVar(&backupEncrypt, "encrypt", false, "Encrypt the archive, asking for a password if --password is not set")
+	BackupCmd.AddCommand(backupCreateCmd)
+}
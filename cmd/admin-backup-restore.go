@@ -0,0 +1,202 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pydio/cells/common/backup"
+	"github.com/pydio/cells/common/boltdb"
+	"github.com/pydio/cells/common/config"
+	"github.com/pydio/cells/common/dao"
+	"github.com/pydio/cells/common/sql"
+)
+
+var backupForce bool
+
+var backupRestoreCmd = &cobra.Command{
+	Use:   "restore",
+	Short: "Restore an installation from a backup archive",
+	Long: `
+DESCRIPTION
+
+  Restore the configuration, the vault, the databases and the services data files from a backup archive.
+  The archive is fully verified before anything is written.
+
+  This command is meant to be run on a fresh machine where Cells is installed but not configured, and
+  must be run while Cells is stopped. Databases are reloaded at the location declared in the restored
+  configuration: the database servers must be reachable, tables of the archive are dropped and recreated.
+  Use --force to restore over an existing installation.
+
+EXAMPLE
+
+  $ ` + os.Args[0] + ` admin backup restore /var/backups/cells.tar.gz
+
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if driver, _ := config.GetDatabase("default"); driver != "" && !backupForce {
+			return fmt.Errorf("an installation is already configured in %s, use --force to overwrite it", config.PydioConfigDir)
+		}
+		staging, e := ioutil.TempDir("", "cells-restore-")
+		if e != nil {
+			return e
+		}
+		defer os.RemoveAll(staging)
+
+		manifest, e := backupExtract(args[0], staging)
+		if e != nil {
+			return e
+		}
+		cmd.Printf("Restoring archive created on %s by Cells %s\n", manifest.Created.Format("2006-01-02 15:04:05"), manifest.CellsVersion)
+
+		// Configuration files come first, databases are then resolved from the restored configuration
+		for _, entry := range manifest.Entries {
+			if entry.Kind != backup.KindConfig {
+				continue
+			}
+			mode := os.FileMode(0600)
+			if entry.Target == "cells-vault-key" {
+				mode = 0400
+			}
+			src := filepath.Join(staging, filepath.FromSlash(entry.Name))
+			if e := backupRestoreFile(src, backupConfigPath(filepath.Base(entry.Target)), mode); e != nil {
+				return fmt.Errorf("cannot restore %s: %v", entry.Name, e)
+			}
+			cmd.Printf("Restored %s\n", entry.Name)
+		}
+		initConfig()
+
+		servicesDir := config.ApplicationWorkingDir(config.ApplicationDirServices)
+		for _, entry := range manifest.Entries {
+			src := filepath.Join(staging, filepath.FromSlash(entry.Name))
+			switch entry.Kind {
+			case backup.KindConfig:
+				continue
+			case backup.KindBolt:
+				var target string
+				if entry.Target != "" {
+					target, e = entry.TargetPath(servicesDir)
+				} else {
+					_, target, e = backupConfiguredDatabase(entry)
+				}
+				if e == nil {
+					e = backupRestoreWith(boltdb.NewFileBackuper(target), src)
+				}
+			case backup.KindFile:
+				var target string
+				if target, e = entry.TargetPath(servicesDir); e == nil {
+					e = backupRestoreFile(src, target, 0600)
+				}
+			case backup.KindDatabase:
+				driver, dsn, er := backupConfiguredDatabase(entry)
+				if er != nil {
+					e = er
+					break
+				}
+				d, ok := sql.NewDAO(driver, dsn, "").(dao.Backuper)
+				if !ok {
+					e = fmt.Errorf("cannot connect to database")
+					break
+				}
+				e = backupRestoreWith(d, src)
+				d.(dao.DAO).CloseConn()
+			default:
+				e = fmt.Errorf("unknown entry kind %s", entry.Kind)
+			}
+			if e != nil {
+				return fmt.Errorf("cannot restore %s: %v", entry.Name, e)
+			}
+			if len(entry.Services) > 0 {
+				cmd.Printf("Restored %s (%s)\n", entry.Name, strings.Join(entry.Services, ", "))
+			} else {
+				cmd.Printf("Restored %s\n", entry.Name)
+			}
+		}
+		cmd.Println("Restore complete, you can now start Cells")
+		return nil
+	},
+}
+
+// backupConfiguredDatabase reads the driver and DSN of the database of an entry in the current configuration.
+func backupConfiguredDatabase(entry *backup.Entry) (string, string, error) {
+	if len(entry.Services) == 0 {
+		return "", "", fmt.Errorf("no configuration key for this database")
+	}
+	driver, dsn := config.GetDatabase(entry.Services[0])
+	if driver == "" || dsn == "" {
+		return "", "", fmt.Errorf("database %s is not configured", entry.Services[0])
+	} else if driver != entry.Driver {
+		return "", "", fmt.Errorf("database %s now uses driver %s instead of %s", entry.Services[0], driver, entry.Driver)
+	}
+	return driver, dsn, nil
+}
+
+// backupRestoreWith reloads a backuper from the extracted file src.
+func backupRestoreWith(b dao.Backuper, src string) error {
+	f, e := os.Open(src)
+	if e != nil {
+		return e
+	}
+	defer f.Close()
+	return b.Restore(context.Background(), f)
+}
+
+// backupRestoreFile moves an extracted file to its target location.
+func backupRestoreFile(src, target string, mode os.FileMode) error {
+	if e := os.MkdirAll(filepath.Dir(target), 0755); e != nil {
+		return e
+	}
+	if _, e := os.Stat(target); e == nil {
+		// The vault key may be read-only
+		os.Chmod(target, 0600)
+	}
+	in, e := os.Open(src)
+	if e != nil {
+		return e
+	}
+	defer in.Close()
+	out, e := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if e != nil {
+		return e
+	}
+	if _, e := io.Copy(out, in); e != nil {
+		out.Close()
+		return e
+	}
+	if e := out.Close(); e != nil {
+		return e
+	}
+	return os.Chmod(target, mode)
+}
+
+func init() {
+	backupRestoreCmd.Flags().BoolVarP(&backupForce, "force", "f", false, "Restore over an existing installation")
+	BackupCmd.AddCommand(backupRestoreCmd)
+}
@@ -0,0 +1,90 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"github.com/pydio/cells/common/backup"
+)
+
+var backupVerifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Check the integrity of a backup archive",
+	Long: `
+DESCRIPTION
+
+  Read a backup archive, check the checksum of every entry against its manifest and list its content.
+
+EXAMPLE
+
+  $ ` + os.Args[0] + ` admin backup verify /var/backups/cells.tar.gz
+
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		manifest, e := backupExtract(args[0], "")
+		if e != nil {
+			return e
+		}
+		cmd.Printf("Archive created on %s by Cells %s (%s) on host %s\n", manifest.Created.Format(time.RFC3339), manifest.CellsVersion, manifest.PackageType, manifest.Hostname)
+		table := tablewriter.NewWriter(cmd.OutOrStdout())
+		table.SetHeader([]string{"Entry", "Kind", "Size", "Used by"})
+		for _, entry := range manifest.Entries {
+			table.Append([]string{entry.Name, entry.Kind, fmt.Sprintf("%d", entry.Size), strings.Join(entry.Services, ", ")})
+		}
+		table.Render()
+		cmd.Println("Archive is valid")
+		return nil
+	},
+}
+
+// backupExtract opens and extracts an archive to dir (or only verifies it if dir is empty),
+// asking for the password if the archive is encrypted and none was given.
+func backupExtract(filename, dir string) (*backup.Manifest, error) {
+	for {
+		f, e := os.Open(filename)
+		if e != nil {
+			return nil, e
+		}
+		manifest, e := backup.Extract(f, backupPassword, dir)
+		f.Close()
+		if e == backup.ErrPasswordRequired && backupPassword == "" {
+			if backupPassword, e = promptBackupPassword(false); e != nil {
+				return nil, e
+			} else if backupPassword != "" {
+				continue
+			}
+			return nil, backup.ErrPasswordRequired
+		}
+		return manifest, e
+	}
+}
+
+func init() {
+	BackupCmd.AddCommand(backupVerifyCmd)
+}
@@ -0,0 +1,89 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+
+	"github.com/pydio/cells/common/config"
+)
+
+// Names of the configuration files, as stored in the configuration directory and in backup archives.
+var backupConfigFiles = []string{config.PydioConfigFile, "pydio-vault.json", "cells-vault-key", "configs-versions.db"}
+
+var backupPassword string
+
+// BackupCmd groups the commands used to backup and restore a whole installation.
+var BackupCmd = &cobra.Command{
+	Use:   "backup",
+	Short: "Backup and restore a whole installation",
+	Long: `
+DESCRIPTION
+
+  Create a consistent archive of a stopped installation (configuration, vault, databases and services data files),
+  verify it and restore it on a fresh machine. Datasources storages are not included and must be
+  backed up separately.
+
+  Archives can be encrypted with a password: it is asked interactively or passed with --password.
+
+`,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		bindViperFlags(cmd.Flags(), map[string]string{})
+
+		initConfig()
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Help()
+	},
+}
+
+// promptBackupPassword asks for the archive password, with confirmation when creating an archive.
+func promptBackupPassword(confirm bool) (string, error) {
+	p := promptui.Prompt{Label: "Archive password", Mask: '*'}
+	pwd, e := p.Run()
+	if e != nil || !confirm {
+		return pwd, e
+	}
+	c := promptui.Prompt{Label: "Confirm password", Mask: '*', Validate: func(s string) error {
+		if s != pwd {
+			return fmt.Errorf("passwords do not match")
+		}
+		return nil
+	}}
+	if _, e := c.Run(); e != nil {
+		return "", e
+	}
+	return pwd, nil
+}
+
+// backupConfigPath returns the location of a configuration file in the configuration directory.
+func backupConfigPath(name string) string {
+	return filepath.Join(config.PydioConfigDir, name)
+}
+
+func init() {
+	BackupCmd.PersistentFlags().StringVar(&backupPassword, "password", "", "Password used to encrypt or decrypt the archive")
+	AdminCmd.AddCommand(BackupCmd)
+}
@@ -0,0 +1,100 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package cmd
+
+import (
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"github.com/pydio/cells/discovery/update/rolling"
+	json "github.com/pydio/cells/x/jsonx"
+)
+
+var upgradeStatusJSON bool
+
+var clusterUpgradeStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Display the progress of the last cluster upgrade",
+	Long: `
+DESCRIPTION
+
+  Display the progress of the last upgrade started with the start command, node by node.
+  The command exits with status 1 if the upgrade failed.
+
+EXAMPLES
+
+  1. Display the status as a table
+  $ ` + os.Args[0] + ` admin cluster upgrade status
+
+  2. Output the status in JSON
+  $ ` + os.Args[0] + ` admin cluster upgrade status --json
+
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		s, e := rolling.LoadStatus()
+		if e != nil {
+			return e
+		}
+		if s == nil {
+			cmd.Println("No cluster upgrade was run")
+			return nil
+		}
+
+		if upgradeStatusJSON {
+			data, _ := json.MarshalIndent(s, "", "  ")
+			cmd.Println(string(data))
+		} else {
+			cmd.Println("Upgrade to " + s.Version + ": " + string(s.Phase))
+			cmd.Println("Started " + s.StartedAt.Format(time.RFC3339) + ", last update " + s.UpdatedAt.Format(time.RFC3339))
+			if s.Migrated {
+				cmd.Println("Configuration migrations applied")
+			}
+			if s.Error != "" {
+				cmd.Println("Error: " + s.Error)
+			}
+			table := tablewriter.NewWriter(cmd.OutOrStdout())
+			table.SetHeader([]string{"Node", "From", "Processes", "State", "Updated", "Error"})
+			for _, n := range s.Nodes {
+				updated := ""
+				if !n.UpdatedAt.IsZero() {
+					updated = n.UpdatedAt.Format(time.RFC3339)
+				}
+				table.Append([]string{n.Hostname, n.From, strconv.Itoa(n.Processes), string(n.State), updated, n.Error})
+			}
+			table.SetAlignment(tablewriter.ALIGN_LEFT)
+			table.Render()
+		}
+
+		if s.Phase == rolling.PhaseFailed {
+			os.Exit(1)
+		}
+		return nil
+	},
+}
+
+func init() {
+	clusterUpgradeStatusCmd.Flags().BoolVar(&upgradeStatusJSON, "json", false, "Output the status in JSON")
+	clusterUpgradeCmd.AddCommand(clusterUpgradeStatusCmd)
+}
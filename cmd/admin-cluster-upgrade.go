@@ -0,0 +1,133 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package cmd
+
+import (
+	"os"
+	"time"
+
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/discovery/update/rolling"
+)
+
+var (
+	upgradeDownload    bool
+	upgradeDrain       time.Duration
+	upgradeNodeTimeout time.Duration
+	upgradeYes         bool
+)
+
+// clusterUpgradeCmd groups the commands of rolling upgrades.
+var clusterUpgradeCmd = &cobra.Command{
+	Use:   "upgrade",
+	Short: "Upgrade the nodes of a cluster one by one",
+	Long: `
+DESCRIPTION
+
+  Upgrade a cluster without interrupting the service: nodes are restarted one by one with the new
+  binary, the others keep serving requests.
+
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Help()
+	},
+}
+
+var clusterUpgradeStartCmd = &cobra.Command{
+	Use:   "start",
+	Short: "Upgrade all nodes to the version of this binary",
+	Long: `
+DESCRIPTION
+
+  Upgrade all the nodes of the cluster to the version of the binary running this command:
+
+  1. The versions of all nodes are checked: rolling upgrades are supported one minor version at a time.
+  2. The configuration migrations are run once, under a cluster lock.
+  3. Each node is asked to restart, then the next one is processed once all its processes are registered
+     again with the new version and are ready. The upgrade stops at the first node failing to do so.
+
+  The new binary must be installed on each node, or downloaded from the update server with --download.
+  Before stopping, nodes report themselves as not ready on /readyz for the --drain period, so that load
+  balancers stop sending them new requests. They then stop gracefully and exit with status 75, relying on
+  their supervisor (e.g. systemd with Restart=on-failure, docker or kubernetes) to be started again.
+
+  Progress can be followed from any node with the status command.
+
+EXAMPLES
+
+  1. Upgrade the nodes where the new binary was installed
+  $ ` + os.Args[0] + ` admin cluster upgrade start
+
+  2. Let the nodes download the binary, giving each of them 5 minutes to restart
+  $ ` + os.Args[0] + ` admin cluster upgrade start --download --node-timeout 5m
+
+`,
+	PreRun: func(cmd *cobra.Command, args []string) {
+		handleBroker()
+		handleTransport()
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if s, _ := rolling.LoadStatus(); s != nil && s.Running() {
+			cmd.Println("An upgrade to " + s.Version + " was started at " + s.StartedAt.Format(time.RFC3339) + " and did not finish")
+		}
+		if !upgradeYes {
+			p := promptui.Prompt{Label: "Upgrade all nodes to " + common.Version().String(), IsConfirm: true, Default: "N"}
+			if _, e := p.Run(); e != nil {
+				cmd.Println("Aborting operation")
+				return nil
+			}
+		}
+
+		states := make(map[string]rolling.NodeState)
+		var phase rolling.Phase
+		c := &rolling.Coordinator{
+			Version:     common.Version(),
+			Download:    upgradeDownload,
+			Drain:       upgradeDrain,
+			NodeTimeout: upgradeNodeTimeout,
+			Progress: func(s *rolling.Status) {
+				if s.Phase != phase {
+					phase = s.Phase
+					cmd.Println("Upgrade " + string(phase))
+				}
+				for _, n := range s.Nodes {
+					if states[n.Hostname] != n.State {
+						states[n.Hostname] = n.State
+						cmd.Println(" - " + n.Hostname + ": " + string(n.State) + " " + n.Error)
+					}
+				}
+			},
+		}
+		return c.Run(cmd.Context())
+	},
+}
+
+func init() {
+	clusterUpgradeStartCmd.Flags().BoolVar(&upgradeDownload, "download", false, "Nodes download the binary from the update server before restarting")
+	clusterUpgradeStartCmd.Flags().DurationVar(&upgradeDrain, "drain", 15*time.Second, "Time given to each node to complete running requests before stopping")
+	clusterUpgradeStartCmd.Flags().DurationVar(&upgradeNodeTimeout, "node-timeout", 10*time.Minute, "Maximum time given to each node to restart, after its drain period")
+	clusterUpgradeStartCmd.Flags().BoolVarP(&upgradeYes, "yes", "y", false, "Do not ask for confirmation")
+	clusterUpgradeCmd.AddCommand(clusterUpgradeStartCmd)
+	AdminClusterCmd.AddCommand(clusterUpgradeCmd)
+}
@@ -0,0 +1,88 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package cmd
+
+import (
+	"os"
+
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+
+	"github.com/pydio/cells/discovery/config/declarative"
+)
+
+var configCodeYes bool
+
+var configApplyCmd = &cobra.Command{
+	Use:   "apply",
+	Short: "Apply a YAML document to the current configuration",
+	Long: `
+DESCRIPTION
+
+  Bring the configuration in line with a YAML document produced by the export command. Changes are
+  displayed and confirmed, then performed through the services owning each resource, so that the
+  usual events are emitted.
+
+  Resources that are not declared in the document are left untouched, unless --prune is set.
+
+EXAMPLES
+
+  1. Apply a document, deleting resources that it does not declare
+  $ ` + os.Args[0] + ` admin config apply --file cells.yaml --prune
+
+  2. Apply without confirmation, e.g. in a deployment pipeline
+  $ ` + os.Args[0] + ` admin config apply --file cells.yaml --yes
+
+`,
+	PreRun: func(cmd *cobra.Command, args []string) {
+		handleBroker()
+		handleTransport()
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		changes, e := configCodeChanges()
+		if e != nil {
+			return e
+		}
+		printConfigCodeChanges(cmd, changes)
+		if len(changes) == 0 {
+			return nil
+		}
+		if !configCodeYes {
+			p := promptui.Prompt{Label: "Apply these changes", IsConfirm: true, Default: "N"}
+			if _, e := p.Run(); e != nil {
+				cmd.Println("Aborting operation")
+				return nil
+			}
+		}
+		if e := declarative.Apply(configCodeContext(), changes); e != nil {
+			return e
+		}
+		cmd.Println("Configuration applied")
+		return nil
+	},
+}
+
+func init() {
+	configApplyCmd.Flags().StringVarP(&configCodeFile, "file", "f", "", "YAML document describing the desired configuration")
+	configApplyCmd.Flags().BoolVar(&configCodePrune, "prune", false, "Delete resources that are not declared in the document")
+	configApplyCmd.Flags().BoolVarP(&configCodeYes, "yes", "y", false, "Do not ask for confirmation")
+	ConfigCmd.AddCommand(configApplyCmd)
+}
@@ -0,0 +1,107 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pydio/cells/discovery/config/declarative"
+)
+
+var configCodePrune bool
+
+var configDiffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Compare a YAML document with the current configuration",
+	Long: `
+DESCRIPTION
+
+  Compute the changes that the apply command would perform to bring the configuration in line with
+  a YAML document produced by the export command. Nothing is modified.
+
+  Resources that are not declared in the document are left untouched, unless --prune is set.
+
+EXAMPLE
+
+  $ ` + os.Args[0] + ` admin config diff --file cells.yaml --prune
+
+`,
+	PreRun: func(cmd *cobra.Command, args []string) {
+		handleBroker()
+		handleTransport()
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		changes, e := configCodeChanges()
+		if e != nil {
+			return e
+		}
+		printConfigCodeChanges(cmd, changes)
+		return nil
+	},
+}
+
+// configCodeChanges loads the document and computes the changes against the current configuration.
+func configCodeChanges() ([]*declarative.Change, error) {
+	if configCodeFile == "" {
+		return nil, fmt.Errorf("please provide a document with --file")
+	}
+	data, e := ioutil.ReadFile(configCodeFile)
+	if e != nil {
+		return nil, e
+	}
+	desired, e := declarative.UnmarshalYAML(data)
+	if e != nil {
+		return nil, fmt.Errorf("invalid document %s: %v", configCodeFile, e)
+	}
+	current, e := declarative.Export(configCodeContext())
+	if e != nil {
+		return nil, e
+	}
+	return declarative.Diff(current, desired, configCodePrune)
+}
+
+func printConfigCodeChanges(cmd *cobra.Command, changes []*declarative.Change) {
+	if len(changes) == 0 {
+		cmd.Println("No differences found, configuration is up to date")
+		return
+	}
+	signs := map[declarative.Operation]string{declarative.OpCreate: "+", declarative.OpUpdate: "~", declarative.OpDelete: "-"}
+	for _, c := range changes {
+		cmd.Printf("%s %s %s\n", signs[c.Op], c.Kind, c.Key)
+		if details, e := c.Details(); e == nil && details != "" {
+			for _, line := range strings.Split(strings.TrimRight(details, "\n"), "\n") {
+				cmd.Println("    " + line)
+			}
+		}
+	}
+	cmd.Printf("%d change(s)\n", len(changes))
+}
+
+func init() {
+	configDiffCmd.Flags().StringVarP(&configCodeFile, "file", "f", "", "YAML document describing the desired configuration")
+	configDiffCmd.Flags().BoolVar(&configCodePrune, "prune", false, "Also list resources that are not declared in the document for deletion")
+	ConfigCmd.AddCommand(configDiffCmd)
+}
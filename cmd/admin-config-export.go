@@ -0,0 +1,95 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package cmd
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pydio/cells/common"
+	context2 "github.com/pydio/cells/common/utils/context"
+	"github.com/pydio/cells/discovery/config/declarative"
+)
+
+var configCodeFile string
+
+var configExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the configuration as a YAML document",
+	Long: `
+DESCRIPTION
+
+  Export datasources, workspaces, roles, security policies, versioning policies, jobs and sites
+  as a YAML document that can be stored in a git repository, then compared to and applied on another
+  environment with the diff and apply commands.
+
+  Roles ACLs are exported with their workspaces identified by slug and their nodes by path. ACLs on
+  cells are not exported.
+
+  Users, groups and their roles are not exported. Datasources secrets are not exported: the secrets
+  of the target environment are kept unless an ApiSecret is set in the document.
+
+EXAMPLES
+
+  1. Print the configuration
+  $ ` + os.Args[0] + ` admin config export
+
+  2. Write the configuration to a file
+  $ ` + os.Args[0] + ` admin config export --file cells.yaml
+
+`,
+	PreRun: func(cmd *cobra.Command, args []string) {
+		handleBroker()
+		handleTransport()
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		state, e := declarative.Export(configCodeContext())
+		if e != nil {
+			return e
+		}
+		data, e := state.MarshalYAML()
+		if e != nil {
+			return e
+		}
+		if configCodeFile == "" {
+			cmd.Print(string(data))
+			return nil
+		}
+		if e := ioutil.WriteFile(configCodeFile, data, 0600); e != nil {
+			return e
+		}
+		cmd.Println("Configuration exported to " + configCodeFile)
+		return nil
+	},
+}
+
+// configCodeContext returns a context for calling services as the system user.
+func configCodeContext() context.Context {
+	return context2.WithUserNameMetadata(context.Background(), common.PydioSystemUsername)
+}
+
+func init() {
+	configExportCmd.Flags().StringVarP(&configCodeFile, "file", "f", "", "Write the document to this file instead of the standard output")
+	ConfigCmd.AddCommand(configExportCmd)
+}
@@ -22,7 +22,6 @@ package cmd
 
 import (
 	"fmt"
-	"log"
 	"sort"
 
 	"github.com/olekukonko/tablewriter"
@@ -43,10 +42,8 @@ DESCRIPTION
 `,
 	Run: func(cmd *cobra.Command, args []string) {
 
-		var m map[string]map[string]interface{}
-		if err := config.Get("services").Scan(&m); err != nil {
-			log.Fatal(err)
-		}
+		// Secrets are masked, references are displayed unresolved
+		m, _ := config.Redact(config.Get("services").Map(), "services").(map[string]interface{})
 
 		table := tablewriter.NewWriter(cmd.OutOrStdout())
 		table.SetHeader([]string{"Service", "Configuration", "Value"})
@@ -59,13 +56,17 @@ DESCRIPTION
 		sort.Strings(skeys)
 
 		for _, sk := range skeys {
+			sm, ok := m[sk].(map[string]interface{})
+			if !ok {
+				continue
+			}
 			var ckeys []string
-			for k := range m[sk] {
+			for k := range sm {
 				ckeys = append(ckeys, k)
 			}
 			sort.Strings(ckeys)
 			for _, ck := range ckeys {
-				table.Append([]string{sk, ck, fmt.Sprintf("%v", m[sk][ck])})
+				table.Append([]string{sk, ck, fmt.Sprintf("%v", sm[ck])})
 			}
 		}
 
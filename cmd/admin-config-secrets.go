@@ -0,0 +1,215 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/config"
+	"github.com/pydio/cells/x/configx"
+)
+
+var (
+	configSecretsDir    string
+	configSecretsEnv    bool
+	configSecretsDryRun bool
+
+	configSecretsNameRegexp = regexp.MustCompile(`[^a-zA-Z0-9]+`)
+)
+
+// configSecret is a plain secret found in the configuration
+type configSecret struct {
+	Label string
+	Name  string
+	Value string
+	// Store replaces the plain secret by a reference
+	Store func(ref string) error
+}
+
+var configSecretsCmd = &cobra.Command{
+	Use:   "secrets",
+	Short: "Migrate plain secrets of the configuration to file or environment references",
+	Long: `
+DESCRIPTION
+
+  Find the secrets stored in plain text in the configuration and in the vault (databases DSNs with
+  a password, datasources secret keys, mailer password, etc.) and replace them by references that
+  are resolved when the configuration is read:
+
+  - ${file:/path/to/file} reads the secret from a file, e.g. a docker or kubernetes secret
+  - ${env:NAME} reads the secret from an environment variable
+
+  With --dir, each secret is written to its own file in the given directory. With --env, the
+  variables to define are printed as shell exports: they must be set in the environment of
+  all the nodes before restarting.
+
+EXAMPLES
+
+  1. List the plain secrets
+  $ ` + os.Args[0] + ` admin config secrets --dry-run
+
+  2. Move the secrets to files
+  $ ` + os.Args[0] + ` admin config secrets --dir /run/secrets
+
+  3. Move the secrets to environment variables
+  $ ` + os.Args[0] + ` admin config secrets --env > cells-secrets.env
+
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		secrets := listConfigSecrets()
+
+		if configSecretsDryRun {
+			table := tablewriter.NewWriter(cmd.OutOrStdout())
+			table.SetHeader([]string{"Secret", "File", "Environment"})
+			for _, s := range secrets {
+				table.Append([]string{s.Label, s.Name, configSecretEnvName(s.Name)})
+			}
+			table.SetAlignment(tablewriter.ALIGN_LEFT)
+			table.Render()
+			return nil
+		}
+
+		if (configSecretsDir == "") == !configSecretsEnv {
+			return fmt.Errorf("please use either --dir or --env")
+		}
+		if len(secrets) == 0 {
+			cmd.PrintErrln("No plain secrets found in the configuration")
+			return nil
+		}
+		if configSecretsDir != "" {
+			if e := os.MkdirAll(configSecretsDir, 0700); e != nil {
+				return e
+			}
+		}
+
+		for _, s := range secrets {
+			var ref string
+			if configSecretsEnv {
+				name := configSecretEnvName(s.Name)
+				ref = configx.SecretReference("env", name)
+				cmd.Printf("export %s='%s'\n", name, strings.Replace(s.Value, "'", `'\''`, -1))
+			} else {
+				target, e := filepath.Abs(filepath.Join(configSecretsDir, s.Name))
+				if e != nil {
+					return e
+				}
+				if e := ioutil.WriteFile(target, []byte(s.Value), 0600); e != nil {
+					return e
+				}
+				ref = configx.SecretReference("file", target)
+			}
+			if e := s.Store(ref); e != nil {
+				return fmt.Errorf("cannot store reference for %s: %v", s.Label, e)
+			}
+			cmd.PrintErrln("Replaced " + s.Label + " by " + ref)
+		}
+
+		return config.Save(common.PydioSystemUsername, "Migrate secrets to references")
+	},
+}
+
+// listConfigSecrets finds all secrets that are not references yet
+func listConfigSecrets() (secrets []*configSecret) {
+
+	// Databases connections with a password
+	databases := config.Get("databases").Map()
+	var ids []string
+	for id := range databases {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		db, ok := databases[id].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		driver, _ := db["driver"].(string)
+		dsn, _ := db["dsn"].(string)
+		if dsn == "" || configx.IsReference(dsn) || config.Redact(dsn, "dsn") == dsn {
+			continue
+		}
+		dbID := id
+		secrets = append(secrets, &configSecret{
+			Label: "database " + dbID,
+			Name:  configSecretFileName("db", dbID, "dsn"),
+			Value: dsn,
+			Store: func(ref string) error {
+				return config.SetDatabase(dbID, driver, ref)
+			},
+		})
+	}
+
+	// Secrets stored in the vault are replaced by references inside the vault
+	vault := config.Vault().Val().Map()
+	seen := make(map[string]bool)
+	vaultSecret := func(label, name, uuid string) {
+		value, ok := vault[uuid].(string)
+		if !ok || value == "" || configx.IsReference(value) || seen[uuid] {
+			return
+		}
+		seen[uuid] = true
+		secrets = append(secrets, &configSecret{
+			Label: label,
+			Name:  name,
+			Value: value,
+			Store: func(ref string) error {
+				return config.Vault().Val(uuid).Set(ref)
+			},
+		})
+	}
+
+	for _, name := range config.SourceNamesForDataServices(common.ServiceDataObjects) {
+		uuid := config.Get("services", common.ServiceGrpcNamespace_+common.ServiceDataObjects_+name, "ApiSecret").String()
+		vaultSecret("datasource "+name+" secret key", configSecretFileName("datasource", name, "secret"), uuid)
+	}
+	for _, key := range config.VaultKeys() {
+		vaultSecret(key, configSecretFileName(key), config.Get(key).String())
+	}
+
+	return
+}
+
+// configSecretFileName builds a file name from the secret path
+func configSecretFileName(parts ...string) string {
+	return strings.Trim(configSecretsNameRegexp.ReplaceAllString(strings.Join(parts, "_"), "_"), "_")
+}
+
+// configSecretEnvName builds an environment variable name from the secret file name
+func configSecretEnvName(name string) string {
+	return "CELLS_" + strings.ToUpper(name)
+}
+
+func init() {
+	configSecretsCmd.Flags().StringVar(&configSecretsDir, "dir", "", "Write each secret to a file in this directory")
+	configSecretsCmd.Flags().BoolVar(&configSecretsEnv, "env", false, "Reference environment variables and print their definitions")
+	configSecretsCmd.Flags().BoolVar(&configSecretsDryRun, "dry-run", false, "Only list the plain secrets")
+	ConfigCmd.AddCommand(configSecretsCmd)
+}
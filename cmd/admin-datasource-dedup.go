@@ -0,0 +1,103 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"github.com/pydio/cells/common"
+	defaults "github.com/pydio/cells/common/micro"
+	"github.com/pydio/cells/common/proto/tree"
+	context2 "github.com/pydio/cells/common/utils/context"
+	"github.com/pydio/cells/data/source/index"
+)
+
+var (
+	dedupDsName string
+)
+
+var dsDedupCmd = &cobra.Command{
+	Use:   "dedup-report",
+	Short: "Report space saved by content deduplication on a flat datasource",
+	Long: `
+DESCRIPTION
+
+  Display deduplication statistics for a flat datasource configured with the "dedup" storage option:
+  number of files, number of distinct contents actually stored, and space saved.
+
+EXAMPLES
+
+  $ ` + os.Args[0] + ` admin datasource dedup-report --datasource=pydiods1
+
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if dedupDsName == "" {
+			cmd.Println("Please provide a datasource name (--datasource)")
+			cmd.Help()
+			return
+		}
+		cli := tree.NewNodeProviderClient(common.ServiceGrpcNamespace_+common.ServiceDataIndex_+dedupDsName, defaults.NewClient())
+		c, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+		defer cancel()
+		c = context2.WithUserNameMetadata(c, common.PydioSystemUsername)
+		resp, err := cli.ReadNode(c, &tree.ReadNodeRequest{Node: &tree.Node{Path: "/"}, WithExtendedStats: true})
+		if err != nil {
+			cmd.Println("Cannot read datasource index: " + err.Error())
+			return
+		}
+		stats := &index.BlobsStats{}
+		if !resp.GetNode().HasMetaKey("DedupStats") {
+			cmd.Println("Deduplication is not enabled on datasource " + dedupDsName)
+			return
+		}
+		if e := resp.GetNode().GetMeta("DedupStats", stats); e != nil {
+			cmd.Println("Cannot decode deduplication stats: " + e.Error())
+			return
+		}
+		ratio := "-"
+		if stats.LogicalSize > 0 {
+			ratio = fmt.Sprintf("%.1f%%", float64(stats.Saved())*100/float64(stats.LogicalSize))
+		}
+		table := tablewriter.NewWriter(cmd.OutOrStdout())
+		table.SetHeader([]string{"Files", "Stored Blobs", "Logical Size", "Stored Size", "Saved", "Ratio"})
+		table.Append([]string{
+			fmt.Sprintf("%d", stats.References),
+			fmt.Sprintf("%d", stats.Blobs),
+			humanize.Bytes(uint64(stats.LogicalSize)),
+			humanize.Bytes(uint64(stats.StoredSize)),
+			humanize.Bytes(uint64(stats.Saved())),
+			ratio,
+		})
+		table.Render()
+	},
+}
+
+func init() {
+	dsDedupCmd.PersistentFlags().StringVarP(&dedupDsName, "datasource", "d", "", "Name of the flat datasource")
+	DataSourceCmd.AddCommand(dsDedupCmd)
+}
@@ -0,0 +1,153 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package cmd
+
+import (
+	"context"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	defaults "github.com/pydio/cells/common/micro"
+	"github.com/pydio/cells/common/registry"
+	"github.com/pydio/cells/common/service/health"
+	json "github.com/pydio/cells/x/jsonx"
+)
+
+var (
+	healthTimeout time.Duration
+	healthJSON    bool
+)
+
+var healthCmd = &cobra.Command{
+	Use:   "health",
+	Short: "Run health and readiness checks of all running services",
+	Long: `
+DESCRIPTION
+
+  Query every running process of the cluster and display the result of their health checks: database connections
+  and migrations, broker connection, datasources buckets, etc. A service is ready once it is started and healthy.
+  The command exits with status 1 if any service is not healthy.
+  Only processes started with the --enable_health flag expose their checks, other processes are ignored.
+
+EXAMPLES
+
+  1. Display the report as a table
+  $ ` + os.Args[0] + ` admin health
+
+  2. Output the full report in JSON, giving at most 2s to each check
+  $ ` + os.Args[0] + ` admin health --json --timeout 2s
+
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		report := collectHealth(healthTimeout)
+
+		if healthJSON {
+			data, _ := json.MarshalIndent(report, "", "  ")
+			cmd.Println(string(data))
+		} else {
+			table := tablewriter.NewWriter(cmd.OutOrStdout())
+			table.SetHeader([]string{"Process", "Host", "Service", "Ready", "Healthy", "Details"})
+			for _, p := range report.Processes {
+				if p.Error != "" {
+					table.Append([]string{p.PID, p.Hostname, "", "", "no", p.Error})
+					continue
+				}
+				for _, c := range p.Checks {
+					table.Append([]string{p.PID, p.Hostname, "(process) " + c.Name, "", yesNo(c.Healthy), c.Error})
+				}
+				for _, s := range p.Services {
+					table.Append([]string{p.PID, p.Hostname, s.Name, yesNo(s.Ready), yesNo(s.Healthy), checkErrors(s.Checks)})
+				}
+			}
+			table.SetAlignment(tablewriter.ALIGN_LEFT)
+			table.Render()
+		}
+
+		if !report.Healthy {
+			os.Exit(1)
+		}
+	},
+}
+
+// collectHealth fetches the reports of all processes publishing their health port in the registry.
+func collectHealth(timeout time.Duration) *health.ClusterReport {
+	processes := map[string]*registry.Process{}
+	services, _ := defaults.Registry().ListServices()
+	for _, s := range services {
+		full, err := defaults.Registry().GetService(s.Name)
+		if err != nil {
+			continue
+		}
+		for _, srv := range full {
+			for _, node := range srv.Nodes {
+				if p := registry.NewProcess(node); p != nil {
+					processes[p.Id] = p
+				}
+			}
+		}
+	}
+	return health.Collect(context.Background(), registry.HealthTargets(processes), timeout)
+}
+
+// unhealthyServices lists the services failing their checks in any process, with the reason.
+func unhealthyServices(report *health.ClusterReport) map[string]string {
+	unhealthy := make(map[string]string)
+	for _, p := range report.Processes {
+		for _, s := range p.Services {
+			if s.Healthy {
+				continue
+			}
+			reason := checkErrors(s.Checks)
+			if reason == "" {
+				reason = checkErrors(p.Checks)
+			}
+			unhealthy[s.Name] = reason
+		}
+	}
+	return unhealthy
+}
+
+func checkErrors(checks []*health.CheckResult) string {
+	var errs []string
+	for _, c := range checks {
+		if !c.Healthy {
+			errs = append(errs, c.Name+": "+c.Error)
+		}
+	}
+	return strings.Join(errs, ", ")
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
+
+func init() {
+	healthCmd.Flags().DurationVar(&healthTimeout, "timeout", health.DefaultTimeout, "Maximum duration of each check")
+	healthCmd.Flags().BoolVar(&healthJSON, "json", false, "Output the full report in JSON")
+	AdminCmd.AddCommand(healthCmd)
+}
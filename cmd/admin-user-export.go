@@ -0,0 +1,71 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pydio/cells/common/etl/stores/file"
+)
+
+var userExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export users, groups and roles to a CSV or LDIF file",
+	Long: `
+DESCRIPTION
+
+  Export users, groups and roles to a CSV or LDIF file, using the same format 
+  as the import command. Passwords are never exported.
+
+  If the file already exists, it is updated with the differences found in Cells.
+
+EXAMPLES
+
+  1. Export all users to a CSV file
+  $ ` + os.Args[0] + ` admin user export -f users.csv
+
+  2. Export to an LDIF file with a custom base DN
+  $ ` + os.Args[0] + ` admin user export -f users.ldif --base-dn "dc=example,dc=com"
+
+`,
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if userEtlFile == "" {
+			return fmt.Errorf("missing file argument")
+		}
+		_, e := file.ParseSyncType(userEtlSync)
+		return e
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, e := userEtlFileFormat()
+		if e != nil {
+			return e
+		}
+		return runUserEtl(cmd, "cells-local", format)
+	},
+}
+
+func init() {
+	addUserEtlFlags(userExportCmd)
+	UserCmd.AddCommand(userExportCmd)
+}
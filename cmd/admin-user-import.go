@@ -0,0 +1,324 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/etl"
+	"github.com/pydio/cells/common/etl/models"
+	"github.com/pydio/cells/common/etl/stores"
+	"github.com/pydio/cells/common/etl/stores/file"
+	"github.com/pydio/cells/common/proto/idm"
+	"github.com/pydio/cells/common/proto/jobs"
+)
+
+var (
+	userEtlFile   string
+	userEtlFormat string
+	userEtlOrigin string
+	userEtlSync   string
+	userEtlBaseDN string
+	userEtlHashed bool
+	userEtlDryRun bool
+)
+
+var userImportCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Import users, groups and roles from a CSV or LDIF file",
+	Long: `
+DESCRIPTION
+
+  Import users, groups and roles from a CSV or LDIF file into Cells.
+
+  The differences between the file and the users directory are displayed 
+  before being applied. Use --dry-run to only display them.
+
+  CSV files must start with a header line. Known columns are type (user, group 
+  or role, defaults to user), login, uuid, groupPath, label, password and roles 
+  (role UUIDs separated by semicolons). Any other column is stored as a user attribute.
+
+  LDIF files use organizationalUnit entries for groups, inetOrgPerson entries for
+  users and organizationalRole entries for roles, users referencing their roles 
+  with memberOf.
+
+  By default, users that are not in the file are kept. Use --sync full with an 
+  --origin to delete users previously imported with the same origin.
+
+EXAMPLES
+
+  1. Preview the import of a CSV file
+  $ ` + os.Args[0] + ` admin user import -f users.csv --dry-run
+
+  2. Import an LDIF file, tagging the users with an origin 
+  $ ` + os.Args[0] + ` admin user import -f users.ldif --base-dn "dc=example,dc=com" --origin hr
+
+`,
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if userEtlFile == "" {
+			return fmt.Errorf("missing file argument")
+		}
+		if _, e := file.ParseSyncType(userEtlSync); e != nil {
+			return e
+		}
+		if userEtlSync == "full" && userEtlOrigin == "" {
+			return fmt.Errorf("a full synchronisation requires an origin, otherwise all local users would be deleted")
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if _, e := os.Stat(userEtlFile); e != nil {
+			return e
+		}
+		format, e := userEtlFileFormat()
+		if e != nil {
+			return e
+		}
+		return runUserEtl(cmd, format, "cells-local")
+	},
+}
+
+// userEtlFileFormat finds the store type from the format flag or from the file extension.
+func userEtlFileFormat() (string, error) {
+	format := userEtlFormat
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(userEtlFile)), ".")
+	}
+	if format != "csv" && format != "ldif" {
+		return "", fmt.Errorf("cannot guess file format, please use --format csv or --format ldif")
+	}
+	return format, nil
+}
+
+// runUserEtl computes the differences between two stores, displays them and applies them unless in dry-run mode.
+func runUserEtl(cmd *cobra.Command, leftType, rightType string) error {
+
+	ctx := context.Background()
+	params := map[string]string{
+		"path":     userEtlFile,
+		"syncType": userEtlSync,
+		"origin":   userEtlOrigin,
+		"baseDN":   userEtlBaseDN,
+	}
+	if userEtlHashed {
+		params["passwordHashed"] = "true"
+	}
+	options := stores.CreateOptions(ctx, params, jobs.ActionMessage{})
+	left, e := stores.LoadReadableStore(leftType, options)
+	if e != nil {
+		return e
+	}
+	right, e := stores.LoadWritableStore(rightType, options)
+	if e != nil {
+		return e
+	}
+	merger := etl.NewMerger(left, right, options.MergeOptions)
+	defer merger.Close()
+
+	usersDiff, rolesDiff, e := merger.LoadAndDiffUsers(ctx, nil)
+	if e != nil {
+		return e
+	}
+	for k, u := range usersDiff.Create {
+		if isTechnicalUser(u) {
+			delete(usersDiff.Create, k)
+		}
+	}
+	for k, u := range usersDiff.Update {
+		if isTechnicalUser(u) {
+			delete(usersDiff.Update, k)
+		}
+	}
+	for k, u := range usersDiff.Delete {
+		if isTechnicalUser(u) {
+			delete(usersDiff.Delete, k)
+		}
+	}
+	declaredRoles, e := merger.LoadAndDiffRoles(ctx, map[string]interface{}{})
+	if e != nil {
+		return e
+	}
+	for k, r := range declaredRoles.Creates {
+		rolesDiff.Creates[k] = r
+	}
+	// Do not override roles that already exist
+	targetRoles, e := right.ListRoles(ctx, right, map[string]interface{}{})
+	if e != nil {
+		return e
+	}
+	for _, r := range targetRoles {
+		delete(rolesDiff.Creates, r.Uuid)
+	}
+	switch merger.Options.SyncType {
+	case models.CREATEONLYSYNC:
+		usersDiff.Update = nil
+		usersDiff.Delete = nil
+		rolesDiff.Updates = nil
+		rolesDiff.Deletes = nil
+	case models.NODELETESYNC:
+		usersDiff.Delete = nil
+		rolesDiff.Deletes = nil
+	}
+
+	newGroups, e := diffGroups(ctx, left, right)
+	if e != nil {
+		return e
+	}
+
+	printUserEtlDiff(cmd, usersDiff, rolesDiff, newGroups)
+	if userEtlDryRun {
+		cmd.Println("Dry-run mode, no changes were applied")
+		return nil
+	}
+
+	var errors int
+	for _, g := range newGroups {
+		if e := right.PutGroup(ctx, g); e != nil {
+			cmd.Println("Error while creating group " + path.Join(g.GroupPath, g.GroupLabel) + ": " + e.Error())
+			errors++
+		}
+	}
+	progress := make(chan etl.MergeOperation)
+	done := make(chan bool)
+	go func() {
+		for op := range progress {
+			if op.Error != nil {
+				cmd.Println(op.Description + ": " + op.Error.Error())
+				errors++
+			} else {
+				cmd.Println(op.Description)
+			}
+		}
+		close(done)
+	}()
+	merger.SaveUsers(ctx, usersDiff, rolesDiff, progress)
+	close(progress)
+	<-done
+
+	if e := merger.Close(); e != nil {
+		return e
+	}
+	if errors > 0 {
+		return fmt.Errorf("%d operation(s) failed", errors)
+	}
+	cmd.Println("Done")
+	return nil
+}
+
+// isTechnicalUser detects users that must never be imported or exported.
+func isTechnicalUser(u *idm.User) bool {
+	return u.Login == common.PydioS3AnonUsername || u.Uuid == "ROOT_GROUP" || u.Attributes[idm.UserAttrHidden] == "true"
+}
+
+// diffGroups lists the groups of the source that are missing in the target. Returned groups GroupPath is the parent path.
+func diffGroups(ctx context.Context, source models.ReadableStore, target models.WritableStore) ([]*idm.User, error) {
+	sourceGroups, e := source.ListGroups(ctx, nil)
+	if e != nil {
+		return nil, e
+	}
+	targetGroups, e := target.ListGroups(ctx, nil)
+	if e != nil {
+		return nil, e
+	}
+	existing := make(map[string]bool, len(targetGroups))
+	for _, g := range targetGroups {
+		existing["/"+strings.Trim(g.GroupPath, "/")] = true
+	}
+	var res []*idm.User
+	for _, g := range sourceGroups {
+		full := "/" + strings.Trim(g.GroupPath, "/")
+		if full == "/" || existing[full] {
+			continue
+		}
+		res = append(res, &idm.User{
+			Uuid:       g.Uuid,
+			IsGroup:    true,
+			GroupPath:  path.Dir(full),
+			GroupLabel: path.Base(full),
+			Attributes: g.Attributes,
+		})
+	}
+	// Parents first
+	sort.Slice(res, func(i, j int) bool {
+		return path.Join(res[i].GroupPath, res[i].GroupLabel) < path.Join(res[j].GroupPath, res[j].GroupLabel)
+	})
+	return res, nil
+}
+
+func printUserEtlDiff(cmd *cobra.Command, usersDiff *models.UserDiff, rolesDiff *models.RoleDiff, groups []*idm.User) {
+	section := func(title string, lines []string) {
+		cmd.Printf("%s: %d\n", title, len(lines))
+		sort.Strings(lines)
+		for _, l := range lines {
+			cmd.Println("  " + l)
+		}
+	}
+	users := func(m map[string]*idm.User) (lines []string) {
+		for _, u := range m {
+			lines = append(lines, fmt.Sprintf("%s (%s)", u.Login, u.GroupPath))
+		}
+		return
+	}
+	roles := func(m map[string]*idm.Role) (lines []string) {
+		for _, r := range m {
+			lines = append(lines, fmt.Sprintf("%s (%s)", r.Uuid, r.Label))
+		}
+		return
+	}
+	var groupLines []string
+	for _, g := range groups {
+		groupLines = append(groupLines, path.Join(g.GroupPath, g.GroupLabel))
+	}
+	cmd.Println("")
+	section("Groups to create", groupLines)
+	section("Users to create", users(usersDiff.Create))
+	section("Users to update", users(usersDiff.Update))
+	section("Users to delete", users(usersDiff.Delete))
+	section("Roles to create", roles(rolesDiff.Creates))
+	section("Roles to delete", roles(rolesDiff.Deletes))
+	cmd.Println("")
+}
+
+// addUserEtlFlags registers the flags shared by the import and export commands.
+func addUserEtlFlags(c *cobra.Command) {
+	c.Flags().StringVarP(&userEtlFile, "file", "f", "", "Path to the CSV or LDIF file")
+	c.Flags().StringVar(&userEtlFormat, "format", "", "File format (csv or ldif), guessed from the file extension by default")
+	c.Flags().StringVar(&userEtlSync, "sync", "no-delete", "Synchronisation type: full, no-delete or create-only")
+	c.Flags().StringVar(&userEtlBaseDN, "base-dn", "dc=cells", "Base DN of LDIF entries")
+	c.Flags().BoolVar(&userEtlDryRun, "dry-run", false, "Only display the changes, without applying them")
+}
+
+func init() {
+	addUserEtlFlags(userImportCmd)
+	userImportCmd.Flags().StringVar(&userEtlOrigin, "origin", "", "Origin set on imported users, used to find users to delete on a full synchronisation")
+	userImportCmd.Flags().BoolVar(&userEtlHashed, "hashed-passwords", false, "Passwords found in the file are already hashed")
+
+	UserCmd.AddCommand(userImportCmd)
+}
@@ -95,7 +95,7 @@ DESCRIPTION
 				}
 			}
 
-			table.Append([]string{dsn.(string), driver.(string), strings.Join(services, ",")})
+			table.Append([]string{config.Redact(dsn, "dsn").(string), driver.(string), strings.Join(services, ",")})
 		}
 
 		table.SetAlignment(tablewriter.ALIGN_LEFT)
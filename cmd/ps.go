@@ -31,6 +31,7 @@ import (
 	"regexp"
 	"text/tabwriter"
 	"text/template"
+	"time"
 
 	micro "github.com/micro/go-micro"
 	"github.com/spf13/cobra"
@@ -47,6 +48,7 @@ var (
 	filterListTags    []string
 	filterListExclude []string
 	runningServices   []string
+	unhealthy         map[string]string
 
 	tmpl = `
 	{{- block "keys" .}}
@@ -59,7 +61,7 @@ var (
 				{{- ""}} {{"#"}} {{$subcategory.Name}}	{{""}}	{{"\n"}}
 				{{- end}}
 				{{- range .Services}}
-					{{- ""}} {{.Name}}	[{{if .IsRunning}}X{{else}} {{end}}]  {{.RunningNodes}}	{{"\n"}}
+					{{- ""}} {{.Name}}	[{{if .IsRunning}}X{{else}} {{end}}]  {{.RunningNodes}}{{with .Unhealthy}}  UNHEALTHY ({{.}}){{end}}	{{"\n"}}
 				{{- end}}
 			{{- end}}
 			{{- ""}} {{""}}	{{""}}	{{"\n"}}
@@ -123,6 +125,9 @@ EXAMPLE
 			runningServices = append(runningServices, srv.Name)
 		}
 
+		// Marking services failing their health checks
+		unhealthy = unhealthyServices(collectHealth(3 * time.Second))
+
 		// Removing install services
 		registry.Default.Filter(func(s registry.Service) bool {
 			re := regexp.MustCompile(common.ServiceInstall)
@@ -279,3 +284,8 @@ func (s *runningService) IsRunning() bool {
 func (s *runningService) RunningNodes() string {
 	return s.nodes
 }
+
+// Unhealthy returns the failing health checks of the service, if any.
+func (s *runningService) Unhealthy() string {
+	return unhealthy[s.name]
+}
@@ -50,6 +50,7 @@ import (
 	"github.com/pydio/cells/common/log"
 	context_wrapper "github.com/pydio/cells/common/log/context-wrapper"
 	"github.com/pydio/cells/common/registry"
+	sql2 "github.com/pydio/cells/common/sql"
 	"github.com/pydio/cells/common/utils/net"
 	"github.com/pydio/cells/x/filex"
 
@@ -313,6 +314,17 @@ func initConfig() (new bool) {
 		}
 	}
 
+	// Nodes sharing the configuration apply the migrations one at a time, like the cluster upgrade coordinator
+	if migrations.UpgradeRequired(defaultConfig.Val(), common.Version()) {
+		if driver, dsn := config.GetDatabase("default"); driver != "" && dsn != "" {
+			unlock, err := sql2.LockClusterDatabase(context.Background(), driver, dsn, migrations.ClusterLock, migrations.ClusterLockTimeout)
+			if err != nil {
+				log.Fatal("Could not lock config migrations", zap.Error(err))
+			}
+			defer unlock()
+		}
+	}
+
 	// Need to do something for the versions
 	if save, err := migrations.UpgradeConfigsIfRequired(defaultConfig.Val(), common.Version()); err == nil && save {
 		if err := config.Save(common.PydioSystemUsername, "Configs upgrades applied"); err != nil {
@@ -26,23 +26,34 @@ import (
 	"path/filepath"
 	"regexp"
 	"strings"
+	"sync/atomic"
+	"syscall"
 	"time"
 
 	"github.com/pydio/cells/common"
 
 	microregistry "github.com/micro/go-micro/registry"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 
 	"github.com/pydio/cells/common/config"
 	"github.com/pydio/cells/common/plugins"
 	"github.com/pydio/cells/common/registry"
+	servicecontext "github.com/pydio/cells/common/service/context"
+	"github.com/pydio/cells/common/service/health"
 	"github.com/pydio/cells/common/service/metrics"
+	"github.com/pydio/cells/common/service/tracing"
+	"github.com/pydio/cells/common/utils/net"
+	"github.com/pydio/cells/discovery/update/rolling"
 	"github.com/pydio/cells/x/filex"
 )
 
 var (
 	FilterStartTags    []string
 	FilterStartExclude []string
+
+	// restartForUpgrade is set when the node stops to be restarted by the cluster upgrade coordinator
+	restartForUpgrade int32
 )
 
 // StartCmd represents the start command
@@ -157,8 +168,34 @@ ENVIRONMENT
 		initStartingToolsOnce.Do(func() {
 			initLogLevel()
 
+			if viper.GetBool("enable_metrics") {
+				// Forks always use a random port, they are discovered through the registry
+				port := viper.GetInt("metrics_port")
+				if IsFork {
+					port = 0
+				}
+				if e := metrics.RegisterPrometheus(net.DefaultAdvertiseAddress, port); e != nil {
+					fmt.Println("[ERROR] Cannot expose metrics: " + e.Error())
+				} else {
+					metrics.RegisterHandler(metrics.PrometheusRoute+"/sd", registry.MetricsDiscoveryHandler())
+				}
+			}
+
+			if viper.GetBool("enable_health") {
+				// Forks always use a random port, they are discovered through the registry
+				healthPort := viper.GetInt("health_port")
+				if IsFork {
+					healthPort = 0
+				}
+				if e := health.Serve(net.DefaultAdvertiseAddress, healthPort); e != nil {
+					fmt.Println("[ERROR] Cannot expose health checks: " + e.Error())
+				}
+			}
+
 			metrics.Init()
 
+			tracing.Init()
+
 			// Initialise the default registry
 			handleRegistry()
 
@@ -184,6 +221,11 @@ ENVIRONMENT
 				"Do you want to reset the initial configuration", cmd, args)
 		}
 
+		// Reverse proxies whose X-Forwarded-For header is used to find the client address
+		if e := servicecontext.SetTrustedProxies(config.Get("defaults", "trustedProxies").StringArray()); e != nil {
+			fmt.Println("[ERROR] Ignoring trusted proxies: " + e.Error())
+		}
+
 		plugins.Init(cmd.Context(), "main")
 
 		// Filtering out services by exclusion
@@ -275,6 +317,18 @@ ENVIRONMENT
 	},
 
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if !IsFork {
+			// The cluster upgrade coordinator asks the node to stop gracefully, it is then restarted by its supervisor
+			if e := rolling.Listen(cmd.Context(), func() {
+				atomic.StoreInt32(&restartForUpgrade, 1)
+				if p, e := os.FindProcess(os.Getpid()); e == nil {
+					p.Signal(syscall.SIGTERM)
+				}
+			}); e != nil {
+				fmt.Println("[ERROR] Cannot listen to cluster upgrade commands: " + e.Error())
+			}
+		}
+
 		// Start services that have not been deregistered via flags and filtering.
 		for _, service := range allServices {
 			if !IsFork && service.RequiresFork() {
@@ -307,6 +361,11 @@ ENVIRONMENT
 	},
 
 	PostRunE: func(cmd *cobra.Command, args []string) error {
+		defer func() {
+			if atomic.LoadInt32(&restartForUpgrade) == 1 {
+				os.Exit(rolling.RestartExitCode)
+			}
+		}()
 		reg := registry.GetCurrentProcess()
 		if reg == nil {
 			return nil
@@ -351,9 +410,12 @@ func init() {
 	StartCmd.Flags().Bool("log_json", false, "Sets the log output format to JSON instead of text")
 	StartCmd.Flags().Bool("log_to_file", common.MustLogFileDefaultValue(), "Write logs on-file in CELLS_LOG_DIR")
 	StartCmd.Flags().BoolVar(&IsFork, "fork", false, "Used internally by application when forking processes")
-	StartCmd.Flags().Bool("enable_metrics", false, "Instrument code to expose internal metrics")
+	StartCmd.Flags().Bool("enable_metrics", false, "Instrument code to expose internal metrics on the advertised address of each process")
+	StartCmd.Flags().Int("metrics_port", 0, "Port exposing Prometheus metrics of the main process (random if not set), each process exposes /metrics and /metrics/sd")
 	StartCmd.Flags().Bool("enable_pprof", false, "Enable pprof remote debugging")
 	StartCmd.Flags().Int("healthcheck", 0, "Healthcheck port number")
+	StartCmd.Flags().Bool("enable_health", false, "Expose /healthz and /readyz on the advertised address of each process")
+	StartCmd.Flags().Int("health_port", 0, "Port exposing /healthz and /readyz for the main process (random if not set), forks are discovered through the registry")
 
 	// Additional Flags
 	StartCmd.Flags().String("bind", "", "Internal IP|DOMAIN:PORT on which the main proxy will bind. Self-signed SSL will be used by default")
@@ -0,0 +1,950 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package auth
+
+import (
+	"bytes"
+	"compress/flate"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/beevik/etree"
+	dlog "github.com/dexidp/dex/pkg/log"
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	dsig "github.com/russellhaering/goxmldsig"
+	"github.com/russellhaering/goxmldsig/etreeutils"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/config"
+	"github.com/pydio/cells/common/proto/idm"
+)
+
+const (
+	// SamlAttrNameID stores the NameID sent by the IdP for a user provisioned by a SAML connector.
+	SamlAttrNameID = idm.UserAttrPrivatePrefix + "samlNameID"
+	// SamlAttrNameIDFormat stores the format of the NameID, as it must be sent back in LogoutRequests.
+	SamlAttrNameIDFormat = idm.UserAttrPrivatePrefix + "samlNameIDFormat"
+	// SamlAttrSessionIndex stores the IdP session index of the last SAML login.
+	SamlAttrSessionIndex = idm.UserAttrPrivatePrefix + "samlSessionIndex"
+	// SamlAttrRoles keeps track of the roles granted by the mapping rules at last login.
+	SamlAttrRoles = idm.UserAttrPrivatePrefix + "samlRoles"
+
+	samlProtocolNS      = "urn:oasis:names:tc:SAML:2.0:protocol"
+	samlAssertionNS     = "urn:oasis:names:tc:SAML:2.0:assertion"
+	samlMetadataNS      = "urn:oasis:names:tc:SAML:2.0:metadata"
+	samlBindingPOST     = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST"
+	samlBindingRedirect = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect"
+	samlStatusSuccess   = "urn:oasis:names:tc:SAML:2.0:status:Success"
+	samlMethodBearer    = "urn:oasis:names:tc:SAML:2.0:cm:bearer"
+	samlSigAlgRSASHA1   = "http://www.w3.org/2000/09/xmldsig#rsa-sha1"
+	samlSigAlgRSASHA256 = "http://www.w3.org/2001/04/xmldsig-more#rsa-sha256"
+	samlTimeFormat      = "2006-01-02T15:04:05Z"
+
+	// samlClockSkew is the tolerance applied when checking assertion validity windows
+	samlClockSkew = 3 * time.Minute
+)
+
+var (
+	_ SAMLConnector = (*SamlConnector)(nil)
+)
+
+func init() {
+	RegisterConnectorType("saml", func(data proto.Message) (Opener, error) {
+		if data == nil {
+			return nil, fmt.Errorf("missing configuration for saml connector")
+		}
+		s, err := (&jsonpb.Marshaler{}).MarshalToString(data)
+		if err != nil {
+			return nil, err
+		}
+		conf := new(SamlConfig)
+		if err := json.Unmarshal([]byte(s), conf); err != nil {
+			return nil, err
+		}
+		return conf, nil
+	})
+}
+
+// SamlConfig is the configuration of a SAML 2.0 Service Provider connector.
+// Certificates and keys can be passed either as PEM content or as a path to a PEM file.
+type SamlConfig struct {
+	// SsoURL is the IdP endpoint receiving AuthnRequests through the HTTP-POST binding
+	SsoURL string `json:"ssoURL"`
+	// SloURL is the IdP single logout endpoint (HTTP-Redirect binding). Leave empty to disable SLO.
+	SloURL string `json:"sloURL"`
+	// SsoIssuer is the IdP entity ID. When set, the Issuer of responses and assertions must match.
+	SsoIssuer string `json:"ssoIssuer"`
+	// CA holds the certificate(s) used by the IdP to sign responses or assertions
+	CA string `json:"ca"`
+
+	// EntityIssuer is the SP entity ID, defaults to the metadata URL
+	EntityIssuer string `json:"entityIssuer"`
+	// RedirectURI is the Assertion Consumer Service URL, defaults to the acs endpoint of this connector
+	RedirectURI string `json:"redirectURI"`
+	// LogoutURI is the SP single logout endpoint, defaults to the slo endpoint of this connector
+	LogoutURI string `json:"logoutURI"`
+	// SpCertificate and SpPrivateKey are used to sign AuthnRequests and LogoutRequests
+	SpCertificate string `json:"spCertificate"`
+	SpPrivateKey  string `json:"spPrivateKey"`
+	// NameIDPolicyFormat is sent in the AuthnRequest NameIDPolicy, if set
+	NameIDPolicyFormat string `json:"nameIDPolicyFormat"`
+
+	// UsernameAttr is the attribute used as login, the NameID is used if empty
+	UsernameAttr    string `json:"usernameAttr"`
+	EmailAttr       string `json:"emailAttr"`
+	DisplayNameAttr string `json:"displayNameAttr"`
+	GroupsAttr      string `json:"groupsAttr"`
+
+	// GroupPath is the group where users are created at first login
+	GroupPath string `json:"groupPath"`
+	// DefaultProfile is applied to new users unless a mapping rule targets the profile
+	DefaultProfile string `json:"defaultProfile"`
+	// MappingRules map assertion attributes to user attributes, profile, roles or group path
+	MappingRules []MappingRule `json:"mappingRules"`
+
+	// InsecureSkipSignatureValidation disables all signature checks. Never use it in production.
+	InsecureSkipSignatureValidation bool `json:"insecureSkipSignatureValidation"`
+}
+
+// Open checks the configuration and loads the certificates.
+func (c *SamlConfig) Open(id string, _ dlog.Logger) (Connector, error) {
+	if c.SsoURL == "" {
+		return nil, fmt.Errorf("saml connector %s: ssoURL is required", id)
+	}
+
+	conf := *c
+	if conf.EntityIssuer == "" || conf.RedirectURI == "" || conf.LogoutURI == "" {
+		base := strings.TrimRight(config.GetDefaultSiteURL(), "/") + "/oidc/saml/" + id
+		if conf.EntityIssuer == "" {
+			conf.EntityIssuer = base + "/metadata"
+		}
+		if conf.RedirectURI == "" {
+			conf.RedirectURI = base + "/acs"
+		}
+		if conf.LogoutURI == "" {
+			conf.LogoutURI = base + "/slo"
+		}
+	}
+	if conf.GroupPath == "" {
+		conf.GroupPath = "/"
+	}
+	if conf.DefaultProfile == "" {
+		conf.DefaultProfile = common.PydioProfileStandard
+	}
+
+	sc := &SamlConnector{
+		id:   id,
+		conf: &conf,
+		now:  time.Now,
+	}
+
+	if !conf.InsecureSkipSignatureValidation {
+		if conf.CA == "" {
+			return nil, fmt.Errorf("saml connector %s: ca is required to validate assertions", id)
+		}
+		certs, err := parseSamlCertificates(conf.CA)
+		if err != nil {
+			return nil, fmt.Errorf("saml connector %s: cannot load ca: %v", id, err)
+		}
+		sc.idpCerts = certs
+		sc.validator = dsig.NewDefaultValidationContext(&dsig.MemoryX509CertificateStore{Roots: certs})
+	}
+
+	if conf.SpPrivateKey != "" {
+		if conf.SpCertificate == "" {
+			return nil, fmt.Errorf("saml connector %s: spCertificate is required along with spPrivateKey", id)
+		}
+		key, err := parseSamlPrivateKey(conf.SpPrivateKey)
+		if err != nil {
+			return nil, fmt.Errorf("saml connector %s: cannot load spPrivateKey: %v", id, err)
+		}
+		certs, err := parseSamlCertificates(conf.SpCertificate)
+		if err != nil {
+			return nil, fmt.Errorf("saml connector %s: cannot load spCertificate: %v", id, err)
+		}
+		sc.key = key
+		sc.cert = certs[0]
+		signer := dsig.NewDefaultSigningContext(&samlKeyStore{key: key, cert: certs[0].Raw})
+		signer.Canonicalizer = dsig.MakeC14N10ExclusiveCanonicalizerWithPrefixList("")
+		sc.signer = signer
+	}
+
+	return sc, nil
+}
+
+// SamlConnector implements SP-initiated SSO with the HTTP-POST binding,
+// and single logout with the HTTP-Redirect binding.
+type SamlConnector struct {
+	id   string
+	conf *SamlConfig
+
+	idpCerts  []*x509.Certificate
+	validator *dsig.ValidationContext
+
+	key    *rsa.PrivateKey
+	cert   *x509.Certificate
+	signer *dsig.SigningContext
+
+	now func() time.Time
+}
+
+// SamlUser describes how an authenticated identity is provisioned as a Cells user.
+type SamlUser struct {
+	Login      string
+	GroupPath  string
+	Attributes map[string]string
+	Roles      []string
+}
+
+// SamlLogoutRequest is a LogoutRequest received from the IdP.
+type SamlLogoutRequest struct {
+	ID           string
+	NameID       string
+	SessionIndex string
+}
+
+// GetSamlConnector finds a registered SAML connector by its ID.
+func GetSamlConnector(id string) (*SamlConnector, bool) {
+	for _, c := range GetConnectors() {
+		if c.ID() != id {
+			continue
+		}
+		sc, ok := c.Conn().(*SamlConnector)
+		return sc, ok
+	}
+	return nil, false
+}
+
+// NewSamlID generates a random identifier usable as a SAML ID attribute.
+func NewSamlID() string {
+	b := make([]byte, 20)
+	_, _ = rand.Read(b)
+	return "_" + hex.EncodeToString(b)
+}
+
+// ID returns the connector identifier, also used as AuthSource for provisioned users.
+func (c *SamlConnector) ID() string {
+	return c.id
+}
+
+// POSTData builds a signed AuthnRequest for the HTTP-POST binding.
+func (c *SamlConnector) POSTData(s Scopes, requestID string) (string, string, error) {
+	doc := etree.NewDocument()
+	req := doc.CreateElement("samlp:AuthnRequest")
+	req.CreateAttr("xmlns:samlp", samlProtocolNS)
+	req.CreateAttr("xmlns:saml", samlAssertionNS)
+	req.CreateAttr("ID", requestID)
+	req.CreateAttr("Version", "2.0")
+	req.CreateAttr("IssueInstant", c.now().UTC().Format(samlTimeFormat))
+	req.CreateAttr("Destination", c.conf.SsoURL)
+	req.CreateAttr("ProtocolBinding", samlBindingPOST)
+	req.CreateAttr("AssertionConsumerServiceURL", c.conf.RedirectURI)
+	req.CreateElement("saml:Issuer").SetText(c.conf.EntityIssuer)
+	policy := req.CreateElement("samlp:NameIDPolicy")
+	policy.CreateAttr("AllowCreate", "true")
+	if c.conf.NameIDPolicyFormat != "" {
+		policy.CreateAttr("Format", c.conf.NameIDPolicyFormat)
+	}
+
+	if err := c.signEnveloped(doc); err != nil {
+		return "", "", err
+	}
+	data, err := doc.WriteToBytes()
+	if err != nil {
+		return "", "", err
+	}
+
+	return c.conf.SsoURL, base64.StdEncoding.EncodeToString(data), nil
+}
+
+// HandlePOST decodes and validates a SAML Response, and maps its assertion to an Identity.
+func (c *SamlConnector) HandlePOST(s Scopes, samlResponse, inResponseTo string) (Identity, error) {
+	raw, err := base64.StdEncoding.DecodeString(samlResponse)
+	if err != nil {
+		return Identity{}, fmt.Errorf("cannot decode saml response: %v", err)
+	}
+
+	data, rootTrusted, err := c.verifyResponse(raw)
+	if err != nil {
+		return Identity{}, err
+	}
+
+	var resp samlResponseXML
+	if err := xml.Unmarshal(data, &resp); err != nil {
+		return Identity{}, fmt.Errorf("cannot parse saml response: %v", err)
+	}
+
+	// Only check the Response attributes when they are covered by a signature
+	if rootTrusted {
+		if resp.Destination != "" && resp.Destination != c.conf.RedirectURI {
+			return Identity{}, fmt.Errorf("wrong response destination %s", resp.Destination)
+		}
+		if inResponseTo != "" && resp.InResponseTo != inResponseTo {
+			return Identity{}, fmt.Errorf("response is not related to the current request")
+		}
+		if err := c.checkIssuer(resp.Issuer); err != nil {
+			return Identity{}, err
+		}
+		if resp.Status.StatusCode.Value != samlStatusSuccess {
+			return Identity{}, fmt.Errorf("authentication failed with status %s %s", resp.Status.StatusCode.Value, resp.Status.StatusMessage)
+		}
+	}
+
+	a := resp.Assertion
+	if a == nil {
+		return Identity{}, fmt.Errorf("response does not contain any assertion")
+	}
+	if err := c.checkIssuer(a.Issuer); err != nil {
+		return Identity{}, err
+	}
+	now := c.now()
+	if err := c.checkSubject(a, inResponseTo, now); err != nil {
+		return Identity{}, err
+	}
+	if err := c.checkConditions(a, now); err != nil {
+		return Identity{}, err
+	}
+
+	attrs := make(map[string][]string, len(a.Attributes))
+	for _, at := range a.Attributes {
+		var values []string
+		for _, v := range at.Values {
+			values = append(values, strings.TrimSpace(v))
+		}
+		attrs[at.Name] = append(attrs[at.Name], values...)
+		if at.FriendlyName != "" && at.FriendlyName != at.Name {
+			attrs[at.FriendlyName] = append(attrs[at.FriendlyName], values...)
+		}
+	}
+
+	nameID := strings.TrimSpace(a.Subject.NameID.Value)
+	ident := Identity{
+		UserID:   nameID,
+		Username: nameID,
+		Claims:   make(map[string]interface{}, len(attrs)),
+	}
+	for k, v := range attrs {
+		ident.Claims[k] = v
+	}
+	if c.conf.UsernameAttr != "" {
+		if v := attrs[c.conf.UsernameAttr]; len(v) > 0 {
+			ident.Username = v[0]
+		} else {
+			return Identity{}, fmt.Errorf("assertion does not contain the username attribute %s", c.conf.UsernameAttr)
+		}
+	}
+	if ident.Username == "" {
+		return Identity{}, fmt.Errorf("assertion does not contain any NameID")
+	}
+	if c.conf.EmailAttr != "" {
+		if v := attrs[c.conf.EmailAttr]; len(v) > 0 {
+			ident.Email = v[0]
+			ident.EmailVerified = true
+		}
+	}
+	if c.conf.GroupsAttr != "" {
+		ident.Groups = attrs[c.conf.GroupsAttr]
+	}
+
+	cd := samlConnectorData{
+		NameID:       nameID,
+		NameIDFormat: a.Subject.NameID.Format,
+	}
+	if a.AuthnStatement != nil {
+		cd.SessionIndex = a.AuthnStatement.SessionIndex
+	}
+	if ident.ConnectorData, err = json.Marshal(cd); err != nil {
+		return Identity{}, err
+	}
+
+	return ident, nil
+}
+
+// MapIdentity applies the connector mapping rules to an identity returned by HandlePOST.
+func (c *SamlConnector) MapIdentity(ident Identity) *SamlUser {
+	u := &SamlUser{
+		Login:     ident.Username,
+		GroupPath: c.conf.GroupPath,
+		Attributes: map[string]string{
+			idm.UserAttrAuthSource: c.id,
+			idm.UserAttrProfile:    c.conf.DefaultProfile,
+		},
+	}
+	if ident.Email != "" {
+		u.Attributes[idm.UserAttrEmail] = ident.Email
+	}
+	if c.conf.DisplayNameAttr != "" {
+		if v := claimValues(ident.Claims, c.conf.DisplayNameAttr); len(v) > 0 {
+			u.Attributes[idm.UserAttrDisplayName] = v[0]
+		}
+	}
+
+	for _, rule := range c.conf.MappingRules {
+		values := rule.ApplyValues(claimValues(ident.Claims, rule.LeftAttribute))
+		if len(values) == 0 {
+			continue
+		}
+		switch rule.RightAttribute {
+		case MappingRuleRoles:
+			u.Roles = append(u.Roles, values...)
+		case MappingRuleGroupPath:
+			// Cleaning the value first so that it cannot escape the configured root
+			u.GroupPath = path.Join(c.conf.GroupPath, path.Join("/", values[0]))
+		case idm.UserAttrProfile:
+			for _, p := range common.PydioUserProfiles {
+				if p == values[0] && p != common.PydioProfileAnon {
+					u.Attributes[idm.UserAttrProfile] = p
+				}
+			}
+		default:
+			u.Attributes[rule.RightAttribute] = strings.Join(values, ",")
+		}
+	}
+
+	var cd samlConnectorData
+	if len(ident.ConnectorData) > 0 && json.Unmarshal(ident.ConnectorData, &cd) == nil {
+		u.Attributes[SamlAttrNameID] = cd.NameID
+		u.Attributes[SamlAttrNameIDFormat] = cd.NameIDFormat
+		u.Attributes[SamlAttrSessionIndex] = cd.SessionIndex
+	}
+
+	return u
+}
+
+// Metadata returns the SP metadata document to be registered on the IdP side.
+func (c *SamlConnector) Metadata() ([]byte, error) {
+	doc := etree.NewDocument()
+	doc.CreateProcInst("xml", `version="1.0" encoding="UTF-8"`)
+	ed := doc.CreateElement("md:EntityDescriptor")
+	ed.CreateAttr("xmlns:md", samlMetadataNS)
+	ed.CreateAttr("entityID", c.conf.EntityIssuer)
+
+	sp := ed.CreateElement("md:SPSSODescriptor")
+	sp.CreateAttr("AuthnRequestsSigned", fmt.Sprintf("%t", c.signer != nil))
+	sp.CreateAttr("WantAssertionsSigned", fmt.Sprintf("%t", !c.conf.InsecureSkipSignatureValidation))
+	sp.CreateAttr("protocolSupportEnumeration", samlProtocolNS)
+	if c.cert != nil {
+		kd := sp.CreateElement("md:KeyDescriptor")
+		kd.CreateAttr("use", "signing")
+		ki := kd.CreateElement("ds:KeyInfo")
+		ki.CreateAttr("xmlns:ds", dsig.Namespace)
+		ki.CreateElement("ds:X509Data").CreateElement("ds:X509Certificate").SetText(base64.StdEncoding.EncodeToString(c.cert.Raw))
+	}
+	if c.conf.SloURL != "" {
+		slo := sp.CreateElement("md:SingleLogoutService")
+		slo.CreateAttr("Binding", samlBindingRedirect)
+		slo.CreateAttr("Location", c.conf.LogoutURI)
+	}
+	if c.conf.NameIDPolicyFormat != "" {
+		sp.CreateElement("md:NameIDFormat").SetText(c.conf.NameIDPolicyFormat)
+	}
+	acs := sp.CreateElement("md:AssertionConsumerService")
+	acs.CreateAttr("Binding", samlBindingPOST)
+	acs.CreateAttr("Location", c.conf.RedirectURI)
+	acs.CreateAttr("index", "0")
+	acs.CreateAttr("isDefault", "true")
+
+	doc.Indent(2)
+	return doc.WriteToBytes()
+}
+
+// UserLogoutURL builds the URL sending a LogoutRequest to the IdP for a user provisioned by this connector.
+// It returns an empty string if single logout is not configured or the user has no SAML session.
+func (c *SamlConnector) UserLogoutURL(u *idm.User, relayState string) (string, error) {
+	nameID := u.GetAttributes()[SamlAttrNameID]
+	if c.conf.SloURL == "" || nameID == "" {
+		return "", nil
+	}
+
+	doc := etree.NewDocument()
+	req := doc.CreateElement("samlp:LogoutRequest")
+	req.CreateAttr("xmlns:samlp", samlProtocolNS)
+	req.CreateAttr("xmlns:saml", samlAssertionNS)
+	req.CreateAttr("ID", NewSamlID())
+	req.CreateAttr("Version", "2.0")
+	req.CreateAttr("IssueInstant", c.now().UTC().Format(samlTimeFormat))
+	req.CreateAttr("Destination", c.conf.SloURL)
+	req.CreateElement("saml:Issuer").SetText(c.conf.EntityIssuer)
+	nid := req.CreateElement("saml:NameID")
+	if f := u.GetAttributes()[SamlAttrNameIDFormat]; f != "" {
+		nid.CreateAttr("Format", f)
+	}
+	nid.SetText(nameID)
+	if si := u.GetAttributes()[SamlAttrSessionIndex]; si != "" {
+		req.CreateElement("samlp:SessionIndex").SetText(si)
+	}
+
+	return c.redirectBinding(c.conf.SloURL, "SAMLRequest", doc, relayState)
+}
+
+// ParseLogoutRequest reads and verifies a LogoutRequest sent by the IdP with the HTTP-Redirect binding.
+func (c *SamlConnector) ParseLogoutRequest(rawQuery string) (*SamlLogoutRequest, error) {
+	values, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return nil, err
+	}
+	encoded := values.Get("SAMLRequest")
+	if encoded == "" {
+		return nil, fmt.Errorf("missing SAMLRequest parameter")
+	}
+	if !c.conf.InsecureSkipSignatureValidation {
+		if err := c.verifyRedirectSignature(rawQuery, "SAMLRequest"); err != nil {
+			return nil, err
+		}
+	}
+
+	compressed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode logout request: %v", err)
+	}
+	data, err := ioutil.ReadAll(flate.NewReader(bytes.NewReader(compressed)))
+	if err != nil {
+		return nil, fmt.Errorf("cannot inflate logout request: %v", err)
+	}
+
+	var lr samlLogoutRequestXML
+	if err := xml.Unmarshal(data, &lr); err != nil {
+		return nil, fmt.Errorf("cannot parse logout request: %v", err)
+	}
+	if err := c.checkIssuer(lr.Issuer); err != nil {
+		return nil, err
+	}
+	out := &SamlLogoutRequest{
+		ID:     lr.ID,
+		NameID: strings.TrimSpace(lr.NameID),
+	}
+	if len(lr.SessionIndexes) > 0 {
+		out.SessionIndex = lr.SessionIndexes[0]
+	}
+
+	return out, nil
+}
+
+// LogoutResponseURL builds the URL acknowledging an IdP-initiated LogoutRequest.
+func (c *SamlConnector) LogoutResponseURL(inResponseTo, relayState string) (string, error) {
+	if c.conf.SloURL == "" {
+		return "", fmt.Errorf("no sloURL configured for connector %s", c.id)
+	}
+
+	doc := etree.NewDocument()
+	resp := doc.CreateElement("samlp:LogoutResponse")
+	resp.CreateAttr("xmlns:samlp", samlProtocolNS)
+	resp.CreateAttr("xmlns:saml", samlAssertionNS)
+	resp.CreateAttr("ID", NewSamlID())
+	resp.CreateAttr("Version", "2.0")
+	resp.CreateAttr("IssueInstant", c.now().UTC().Format(samlTimeFormat))
+	resp.CreateAttr("Destination", c.conf.SloURL)
+	resp.CreateAttr("InResponseTo", inResponseTo)
+	resp.CreateElement("saml:Issuer").SetText(c.conf.EntityIssuer)
+	resp.CreateElement("samlp:Status").CreateElement("samlp:StatusCode").CreateAttr("Value", samlStatusSuccess)
+
+	return c.redirectBinding(c.conf.SloURL, "SAMLResponse", doc, relayState)
+}
+
+// verifyResponse checks the signature of the response, or of its assertion if only the latter is signed.
+// In that case, all unsigned children of the response are dropped and the returned boolean is false.
+func (c *SamlConnector) verifyResponse(raw []byte) ([]byte, bool, error) {
+	if c.conf.InsecureSkipSignatureValidation {
+		return raw, true, nil
+	}
+
+	doc := etree.NewDocument()
+	if err := doc.ReadFromBytes(raw); err != nil {
+		return nil, false, fmt.Errorf("cannot parse saml response: %v", err)
+	}
+	root := doc.Root()
+	if root == nil {
+		return nil, false, fmt.Errorf("empty saml response")
+	}
+
+	validated, err := c.validator.Validate(root)
+	if err == nil {
+		doc.SetRoot(validated)
+		data, err := doc.WriteToBytes()
+		return data, true, err
+	} else if err != dsig.ErrMissingSignature {
+		return nil, false, fmt.Errorf("invalid response signature: %v", err)
+	}
+
+	// Response is not signed, look for a signed assertion. NSSelectOne copies
+	// the namespaces declared on the response down to the assertion.
+	assertion, err := etreeutils.NSSelectOne(root, samlAssertionNS, "Assertion")
+	if err != nil {
+		return nil, false, err
+	}
+	if assertion == nil {
+		if encrypted, _ := etreeutils.NSSelectOne(root, samlAssertionNS, "EncryptedAssertion"); encrypted != nil {
+			return nil, false, fmt.Errorf("encrypted assertions are not supported")
+		}
+		return nil, false, fmt.Errorf("response is neither signed nor contains any assertion")
+	}
+	validatedAssertion, err := c.validator.Validate(assertion)
+	if err != nil {
+		return nil, false, fmt.Errorf("invalid assertion signature: %v", err)
+	}
+
+	for _, el := range root.ChildElements() {
+		root.RemoveChild(el)
+	}
+	root.AddChild(validatedAssertion)
+	data, err := doc.WriteToBytes()
+	return data, false, err
+}
+
+func (c *SamlConnector) checkIssuer(issuer string) error {
+	if c.conf.SsoIssuer != "" && strings.TrimSpace(issuer) != c.conf.SsoIssuer {
+		return fmt.Errorf("unexpected issuer %s", issuer)
+	}
+	return nil
+}
+
+func (c *SamlConnector) checkSubject(a *samlAssertionXML, inResponseTo string, now time.Time) error {
+	for _, sc := range a.Subject.SubjectConfirmations {
+		if sc.Method != samlMethodBearer {
+			continue
+		}
+		d := sc.Data
+		if inResponseTo != "" && d.InResponseTo != inResponseTo {
+			return fmt.Errorf("assertion is not related to the current request")
+		}
+		if d.Recipient != "" && d.Recipient != c.conf.RedirectURI {
+			return fmt.Errorf("wrong subject confirmation recipient %s", d.Recipient)
+		}
+		if d.NotOnOrAfter != "" {
+			t, err := time.Parse(time.RFC3339Nano, d.NotOnOrAfter)
+			if err != nil {
+				return fmt.Errorf("cannot parse subject confirmation NotOnOrAfter: %v", err)
+			}
+			if !now.Before(t.Add(samlClockSkew)) {
+				return fmt.Errorf("subject confirmation has expired")
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("assertion does not contain any bearer subject confirmation")
+}
+
+func (c *SamlConnector) checkConditions(a *samlAssertionXML, now time.Time) error {
+	cond := a.Conditions
+	if cond == nil {
+		return nil
+	}
+	if cond.NotBefore != "" {
+		t, err := time.Parse(time.RFC3339Nano, cond.NotBefore)
+		if err != nil {
+			return fmt.Errorf("cannot parse conditions NotBefore: %v", err)
+		}
+		if now.Add(samlClockSkew).Before(t) {
+			return fmt.Errorf("assertion is not valid yet")
+		}
+	}
+	if cond.NotOnOrAfter != "" {
+		t, err := time.Parse(time.RFC3339Nano, cond.NotOnOrAfter)
+		if err != nil {
+			return fmt.Errorf("cannot parse conditions NotOnOrAfter: %v", err)
+		}
+		if !now.Before(t.Add(samlClockSkew)) {
+			return fmt.Errorf("assertion has expired")
+		}
+	}
+	for _, ar := range cond.AudienceRestrictions {
+		found := false
+		for _, aud := range ar.Audiences {
+			if strings.TrimSpace(aud) == c.conf.EntityIssuer {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("assertion audience does not include %s", c.conf.EntityIssuer)
+		}
+	}
+	return nil
+}
+
+// signEnveloped signs the document root if an SP key is configured. The schema
+// requires the Signature to come right after the Issuer, whereas goxmldsig appends it.
+func (c *SamlConnector) signEnveloped(doc *etree.Document) error {
+	if c.signer == nil {
+		return nil
+	}
+	signed, err := c.signer.SignEnveloped(doc.Root())
+	if err != nil {
+		return err
+	}
+	children := signed.ChildElements()
+	if len(children) > 2 {
+		sig := children[len(children)-1]
+		signed.RemoveChild(sig)
+		signed.InsertChild(children[1], sig)
+	}
+	doc.SetRoot(signed)
+	return nil
+}
+
+// redirectBinding deflates and encodes a message as query parameters, signing the query if an SP key is configured.
+func (c *SamlConnector) redirectBinding(target, param string, doc *etree.Document, relayState string) (string, error) {
+	raw, err := doc.WriteToBytes()
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	w, err := flate.NewWriter(&buf, flate.BestCompression)
+	if err != nil {
+		return "", err
+	}
+	if _, err := w.Write(raw); err != nil {
+		return "", err
+	}
+	if err := w.Close(); err != nil {
+		return "", err
+	}
+
+	query := param + "=" + url.QueryEscape(base64.StdEncoding.EncodeToString(buf.Bytes()))
+	if relayState != "" {
+		query += "&RelayState=" + url.QueryEscape(relayState)
+	}
+	if c.key != nil {
+		query += "&SigAlg=" + url.QueryEscape(samlSigAlgRSASHA256)
+		h := sha256.Sum256([]byte(query))
+		sig, err := rsa.SignPKCS1v15(rand.Reader, c.key, crypto.SHA256, h[:])
+		if err != nil {
+			return "", err
+		}
+		query += "&Signature=" + url.QueryEscape(base64.StdEncoding.EncodeToString(sig))
+	}
+
+	sep := "?"
+	if strings.Contains(target, "?") {
+		sep = "&"
+	}
+	return target + sep + query, nil
+}
+
+// verifyRedirectSignature checks the signature of an HTTP-Redirect binding message.
+// It must be computed on the parameters exactly as they were encoded by the sender.
+func (c *SamlConnector) verifyRedirectSignature(rawQuery, param string) error {
+	rawValues := make(map[string]string)
+	for _, part := range strings.Split(rawQuery, "&") {
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) == 2 {
+			rawValues[kv[0]] = kv[1]
+		}
+	}
+	if rawValues["Signature"] == "" || rawValues["SigAlg"] == "" {
+		return fmt.Errorf("message is not signed")
+	}
+
+	signed := param + "=" + rawValues[param]
+	if rs, ok := rawValues["RelayState"]; ok {
+		signed += "&RelayState=" + rs
+	}
+	signed += "&SigAlg=" + rawValues["SigAlg"]
+
+	sigAlg, err := url.QueryUnescape(rawValues["SigAlg"])
+	if err != nil {
+		return err
+	}
+	encodedSig, err := url.QueryUnescape(rawValues["Signature"])
+	if err != nil {
+		return err
+	}
+	sig, err := base64.StdEncoding.DecodeString(encodedSig)
+	if err != nil {
+		return fmt.Errorf("cannot decode signature: %v", err)
+	}
+
+	var hashed []byte
+	var hash crypto.Hash
+	switch sigAlg {
+	case samlSigAlgRSASHA256:
+		h := sha256.Sum256([]byte(signed))
+		hashed, hash = h[:], crypto.SHA256
+	case samlSigAlgRSASHA1:
+		h := sha1.Sum([]byte(signed))
+		hashed, hash = h[:], crypto.SHA1
+	default:
+		return fmt.Errorf("unsupported signature algorithm %s", sigAlg)
+	}
+
+	for _, cert := range c.idpCerts {
+		if pub, ok := cert.PublicKey.(*rsa.PublicKey); ok {
+			if rsa.VerifyPKCS1v15(pub, hash, hashed, sig) == nil {
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("invalid message signature")
+}
+
+func claimValues(claims map[string]interface{}, name string) []string {
+	switch v := claims[name].(type) {
+	case []string:
+		return v
+	case string:
+		return []string{v}
+	}
+	return nil
+}
+
+func readSamlPEM(value string) ([]byte, error) {
+	if strings.HasPrefix(strings.TrimSpace(value), "-----BEGIN") {
+		return []byte(value), nil
+	}
+	return ioutil.ReadFile(value)
+}
+
+func parseSamlCertificates(value string) ([]*x509.Certificate, error) {
+	data, err := readSamlPEM(value)
+	if err != nil {
+		return nil, err
+	}
+	var certs []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("no certificate found")
+	}
+	return certs, nil
+}
+
+func parseSamlPrivateKey(value string) (*rsa.PrivateKey, error) {
+	data, err := readSamlPEM(value)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no private key found")
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("only RSA keys are supported")
+	}
+	return rsaKey, nil
+}
+
+type samlKeyStore struct {
+	key  *rsa.PrivateKey
+	cert []byte
+}
+
+func (s *samlKeyStore) GetKeyPair() (*rsa.PrivateKey, []byte, error) {
+	return s.key, s.cert, nil
+}
+
+type samlConnectorData struct {
+	NameID       string `json:"nameID"`
+	NameIDFormat string `json:"nameIDFormat,omitempty"`
+	SessionIndex string `json:"sessionIndex,omitempty"`
+}
+
+type samlResponseXML struct {
+	XMLName      xml.Name `xml:"urn:oasis:names:tc:SAML:2.0:protocol Response"`
+	ID           string   `xml:"ID,attr"`
+	InResponseTo string   `xml:"InResponseTo,attr"`
+	Destination  string   `xml:"Destination,attr"`
+	Issuer       string   `xml:"Issuer"`
+	Status       struct {
+		StatusCode struct {
+			Value string `xml:"Value,attr"`
+		} `xml:"StatusCode"`
+		StatusMessage string `xml:"StatusMessage"`
+	} `xml:"Status"`
+	Assertion *samlAssertionXML `xml:"urn:oasis:names:tc:SAML:2.0:assertion Assertion"`
+}
+
+type samlAssertionXML struct {
+	Issuer  string `xml:"Issuer"`
+	Subject struct {
+		NameID struct {
+			Format string `xml:"Format,attr"`
+			Value  string `xml:",chardata"`
+		} `xml:"NameID"`
+		SubjectConfirmations []struct {
+			Method string `xml:"Method,attr"`
+			Data   struct {
+				InResponseTo string `xml:"InResponseTo,attr"`
+				Recipient    string `xml:"Recipient,attr"`
+				NotOnOrAfter string `xml:"NotOnOrAfter,attr"`
+			} `xml:"SubjectConfirmationData"`
+		} `xml:"SubjectConfirmation"`
+	} `xml:"Subject"`
+	Conditions *struct {
+		NotBefore            string `xml:"NotBefore,attr"`
+		NotOnOrAfter         string `xml:"NotOnOrAfter,attr"`
+		AudienceRestrictions []struct {
+			Audiences []string `xml:"Audience"`
+		} `xml:"AudienceRestriction"`
+	} `xml:"Conditions"`
+	AuthnStatement *struct {
+		SessionIndex string `xml:"SessionIndex,attr"`
+	} `xml:"AuthnStatement"`
+	Attributes []struct {
+		Name         string   `xml:"Name,attr"`
+		FriendlyName string   `xml:"FriendlyName,attr"`
+		Values       []string `xml:"AttributeValue"`
+	} `xml:"AttributeStatement>Attribute"`
+}
+
+type samlLogoutRequestXML struct {
+	XMLName        xml.Name `xml:"urn:oasis:names:tc:SAML:2.0:protocol LogoutRequest"`
+	ID             string   `xml:"ID,attr"`
+	Issuer         string   `xml:"Issuer"`
+	NameID         string   `xml:"NameID"`
+	SessionIndexes []string `xml:"SessionIndex"`
+}
@@ -0,0 +1,302 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package auth
+
+import (
+	"bytes"
+	"compress/flate"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/beevik/etree"
+	dsig "github.com/russellhaering/goxmldsig"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/pydio/cells/common/proto/idm"
+)
+
+const (
+	testSamlACS    = "https://cells.example.com/oidc/saml/idp/acs"
+	testSamlEntity = "https://cells.example.com/oidc/saml/idp/metadata"
+	testSamlIdP    = "https://idp.example.com"
+)
+
+func testSamlKeyPair() (*rsa.PrivateKey, string, string) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic(err)
+	}
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(24 * time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		panic(err)
+	}
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	return key, string(certPEM), string(keyPEM)
+}
+
+type testSamlIdPSigner struct {
+	ctx *dsig.SigningContext
+}
+
+func newTestSamlIdPSigner(certPEM, keyPEM string) *testSamlIdPSigner {
+	key, _ := parseSamlPrivateKey(keyPEM)
+	certs, _ := parseSamlCertificates(certPEM)
+	ctx := dsig.NewDefaultSigningContext(&samlKeyStore{key: key, cert: certs[0].Raw})
+	ctx.Canonicalizer = dsig.MakeC14N10ExclusiveCanonicalizerWithPrefixList("")
+	return &testSamlIdPSigner{ctx: ctx}
+}
+
+type testSamlResponseOpts struct {
+	inResponseTo string
+	audience     string
+	notOnOrAfter time.Time
+	signResponse bool
+	signNothing  bool
+	tamper       bool
+}
+
+func (s *testSamlIdPSigner) response(o testSamlResponseOpts) string {
+	now := time.Now().UTC()
+	if o.audience == "" {
+		o.audience = testSamlEntity
+	}
+	if o.notOnOrAfter.IsZero() {
+		o.notOnOrAfter = now.Add(5 * time.Minute)
+	}
+
+	a := etree.NewElement("saml:Assertion")
+	a.CreateAttr("xmlns:saml", samlAssertionNS)
+	a.CreateAttr("ID", "_assertion")
+	a.CreateAttr("Version", "2.0")
+	a.CreateAttr("IssueInstant", now.Format(samlTimeFormat))
+	a.CreateElement("saml:Issuer").SetText(testSamlIdP)
+	sub := a.CreateElement("saml:Subject")
+	nid := sub.CreateElement("saml:NameID")
+	nid.CreateAttr("Format", "urn:oasis:names:tc:SAML:1.1:nameid-format:unspecified")
+	nid.SetText("jdoe")
+	sc := sub.CreateElement("saml:SubjectConfirmation")
+	sc.CreateAttr("Method", samlMethodBearer)
+	scd := sc.CreateElement("saml:SubjectConfirmationData")
+	scd.CreateAttr("InResponseTo", o.inResponseTo)
+	scd.CreateAttr("Recipient", testSamlACS)
+	scd.CreateAttr("NotOnOrAfter", o.notOnOrAfter.Format(samlTimeFormat))
+	cond := a.CreateElement("saml:Conditions")
+	cond.CreateAttr("NotBefore", now.Add(-time.Minute).Format(samlTimeFormat))
+	cond.CreateAttr("NotOnOrAfter", o.notOnOrAfter.Format(samlTimeFormat))
+	cond.CreateElement("saml:AudienceRestriction").CreateElement("saml:Audience").SetText(o.audience)
+	a.CreateElement("saml:AuthnStatement").CreateAttr("SessionIndex", "_session")
+	as := a.CreateElement("saml:AttributeStatement")
+	for name, values := range map[string][]string{
+		"mail":         {"jdoe@example.com"},
+		"displayName":  {"John Doe"},
+		"memberOf":     {"cn=staff,dc=example,dc=com", "cn=admins,dc=example,dc=com"},
+		"department":   {"research"},
+		"cellsProfile": {"admin"},
+	} {
+		at := as.CreateElement("saml:Attribute")
+		at.CreateAttr("Name", name)
+		for _, v := range values {
+			at.CreateElement("saml:AttributeValue").SetText(v)
+		}
+	}
+	if !o.signResponse && !o.signNothing {
+		a, _ = s.ctx.SignEnveloped(a)
+	}
+
+	doc := etree.NewDocument()
+	r := doc.CreateElement("samlp:Response")
+	r.CreateAttr("xmlns:samlp", samlProtocolNS)
+	r.CreateAttr("xmlns:saml", samlAssertionNS)
+	r.CreateAttr("ID", "_response")
+	r.CreateAttr("Version", "2.0")
+	r.CreateAttr("InResponseTo", o.inResponseTo)
+	r.CreateAttr("Destination", testSamlACS)
+	r.CreateElement("saml:Issuer").SetText(testSamlIdP)
+	r.CreateElement("samlp:Status").CreateElement("samlp:StatusCode").CreateAttr("Value", samlStatusSuccess)
+	r.AddChild(a)
+	if o.signResponse {
+		signed, _ := s.ctx.SignEnveloped(r)
+		doc.SetRoot(signed)
+	}
+	if o.tamper {
+		doc.FindElement("//NameID").SetText("admin")
+	}
+
+	data, _ := doc.WriteToBytes()
+	return base64.StdEncoding.EncodeToString(data)
+}
+
+func TestSamlConnector(t *testing.T) {
+
+	_, idpCert, idpKey := testSamlKeyPair()
+	_, spCert, spKey := testSamlKeyPair()
+	idp := newTestSamlIdPSigner(idpCert, idpKey)
+
+	conf := &SamlConfig{
+		SsoURL:          "https://idp.example.com/sso",
+		SloURL:          "https://idp.example.com/slo",
+		SsoIssuer:       testSamlIdP,
+		CA:              idpCert,
+		EntityIssuer:    testSamlEntity,
+		RedirectURI:     testSamlACS,
+		LogoutURI:       "https://cells.example.com/oidc/saml/idp/slo",
+		SpCertificate:   spCert,
+		SpPrivateKey:    spKey,
+		EmailAttr:       "mail",
+		DisplayNameAttr: "displayName",
+		GroupPath:       "/saml",
+		MappingRules: []MappingRule{
+			{LeftAttribute: "memberOf", RightAttribute: MappingRuleRoles, RolePrefix: "saml_"},
+			{LeftAttribute: "cellsProfile", RightAttribute: idm.UserAttrProfile, RuleString: "standard,admin"},
+			{LeftAttribute: "department", RightAttribute: MappingRuleGroupPath},
+		},
+	}
+	opened, err := conf.Open("idp", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := opened.(*SamlConnector)
+
+	Convey("Test AuthnRequest is signed", t, func() {
+		ssoURL, encoded, err := c.POSTData(Scopes{}, "_request")
+		So(err, ShouldBeNil)
+		So(ssoURL, ShouldEqual, conf.SsoURL)
+		raw, err := base64.StdEncoding.DecodeString(encoded)
+		So(err, ShouldBeNil)
+
+		doc := etree.NewDocument()
+		So(doc.ReadFromBytes(raw), ShouldBeNil)
+		children := doc.Root().ChildElements()
+		So(children, ShouldHaveLength, 3)
+		So(children[0].Tag, ShouldEqual, "Issuer")
+		So(children[1].Tag, ShouldEqual, "Signature")
+
+		spCerts, _ := parseSamlCertificates(spCert)
+		v := dsig.NewDefaultValidationContext(&dsig.MemoryX509CertificateStore{Roots: spCerts})
+		_, err = v.Validate(doc.Root())
+		So(err, ShouldBeNil)
+	})
+
+	Convey("Test valid responses", t, func() {
+		for _, signResponse := range []bool{false, true} {
+			ident, err := c.HandlePOST(Scopes{}, idp.response(testSamlResponseOpts{inResponseTo: "_request", signResponse: signResponse}), "_request")
+			So(err, ShouldBeNil)
+			So(ident.Username, ShouldEqual, "jdoe")
+			So(ident.Email, ShouldEqual, "jdoe@example.com")
+
+			u := c.MapIdentity(ident)
+			So(u.Login, ShouldEqual, "jdoe")
+			So(u.GroupPath, ShouldEqual, "/saml/research")
+			So(u.Roles, ShouldResemble, []string{"saml_staff", "saml_admins"})
+			So(u.Attributes[idm.UserAttrProfile], ShouldEqual, "admin")
+			So(u.Attributes[idm.UserAttrDisplayName], ShouldEqual, "John Doe")
+			So(u.Attributes[idm.UserAttrAuthSource], ShouldEqual, "idp")
+			So(u.Attributes[SamlAttrNameID], ShouldEqual, "jdoe")
+			So(u.Attributes[SamlAttrSessionIndex], ShouldEqual, "_session")
+		}
+	})
+
+	Convey("Test invalid responses", t, func() {
+		_, err := c.HandlePOST(Scopes{}, idp.response(testSamlResponseOpts{inResponseTo: "_request", signNothing: true}), "_request")
+		So(err, ShouldNotBeNil)
+
+		_, err = c.HandlePOST(Scopes{}, idp.response(testSamlResponseOpts{inResponseTo: "_request", tamper: true}), "_request")
+		So(err, ShouldNotBeNil)
+
+		_, err = c.HandlePOST(Scopes{}, idp.response(testSamlResponseOpts{inResponseTo: "_other"}), "_request")
+		So(err, ShouldNotBeNil)
+
+		_, err = c.HandlePOST(Scopes{}, idp.response(testSamlResponseOpts{inResponseTo: "_request", audience: "https://other.example.com"}), "_request")
+		So(err, ShouldNotBeNil)
+
+		_, err = c.HandlePOST(Scopes{}, idp.response(testSamlResponseOpts{inResponseTo: "_request", notOnOrAfter: time.Now().Add(-time.Hour)}), "_request")
+		So(err, ShouldNotBeNil)
+
+		_, other, otherKey := testSamlKeyPair()
+		_, err = c.HandlePOST(Scopes{}, newTestSamlIdPSigner(other, otherKey).response(testSamlResponseOpts{inResponseTo: "_request"}), "_request")
+		So(err, ShouldNotBeNil)
+	})
+
+	Convey("Test metadata", t, func() {
+		data, err := c.Metadata()
+		So(err, ShouldBeNil)
+		So(string(data), ShouldContainSubstring, `entityID="`+testSamlEntity+`"`)
+		So(string(data), ShouldContainSubstring, `Location="`+testSamlACS+`"`)
+		So(string(data), ShouldContainSubstring, `AuthnRequestsSigned="true"`)
+	})
+
+	Convey("Test single logout", t, func() {
+		u := &idm.User{Login: "jdoe", Attributes: map[string]string{SamlAttrNameID: "jdoe", SamlAttrSessionIndex: "_session"}}
+		logoutURL, err := c.UserLogoutURL(u, "/")
+		So(err, ShouldBeNil)
+		So(logoutURL, ShouldStartWith, conf.SloURL+"?SAMLRequest=")
+		So(logoutURL, ShouldContainSubstring, "&Signature=")
+
+		parsed, _ := url.Parse(logoutURL)
+		compressed, _ := base64.StdEncoding.DecodeString(parsed.Query().Get("SAMLRequest"))
+		data, _ := ioutil.ReadAll(flate.NewReader(bytes.NewReader(compressed)))
+		So(string(data), ShouldContainSubstring, ">_session</samlp:SessionIndex>")
+
+		// Simulate an IdP-initiated logout, signed with the IdP key
+		idpSide := &SamlConnector{conf: &SamlConfig{SloURL: "https://cells.example.com/oidc/saml/idp/slo", EntityIssuer: testSamlIdP}, now: time.Now}
+		idpSide.key, _ = parseSamlPrivateKey(idpKey)
+		doc := etree.NewDocument()
+		lr := doc.CreateElement("samlp:LogoutRequest")
+		lr.CreateAttr("xmlns:samlp", samlProtocolNS)
+		lr.CreateAttr("xmlns:saml", samlAssertionNS)
+		lr.CreateAttr("ID", "_logout")
+		lr.CreateElement("saml:Issuer").SetText(testSamlIdP)
+		lr.CreateElement("saml:NameID").SetText("jdoe")
+		idpURL, err := idpSide.redirectBinding(idpSide.conf.SloURL, "SAMLRequest", doc, "state")
+		So(err, ShouldBeNil)
+		rawQuery := idpURL[strings.Index(idpURL, "?")+1:]
+
+		req, err := c.ParseLogoutRequest(rawQuery)
+		So(err, ShouldBeNil)
+		So(req.ID, ShouldEqual, "_logout")
+		So(req.NameID, ShouldEqual, "jdoe")
+
+		_, err = c.ParseLogoutRequest(strings.Replace(rawQuery, "RelayState=state", "RelayState=other", 1))
+		So(err, ShouldNotBeNil)
+
+		respURL, err := c.LogoutResponseURL(req.ID, "state")
+		So(err, ShouldBeNil)
+		So(respURL, ShouldStartWith, conf.SloURL+"?SAMLResponse=")
+	})
+}
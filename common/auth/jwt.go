@@ -26,6 +26,7 @@ import (
 	"net/url"
 	"sort"
 	"strings"
+	"time"
 
 	errors2 "github.com/micro/go-micro/errors"
 	"go.uber.org/zap"
@@ -218,7 +219,7 @@ func (j *JWTVerifier) loadClaims(ctx context.Context, token IDToken, claims *cla
 	}
 
 	var roles []string
-	for _, role := range user.Roles {
+	for _, role := range user.ActiveRoles(time.Now()) {
 		roles = append(roles, role.Uuid)
 	}
 
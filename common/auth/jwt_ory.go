@@ -147,6 +147,16 @@ func (p *oryprovider) PasswordCredentialsCode(ctx context.Context, userName stri
 		}
 	}
 
+	if err := allowLogin(ctx, userName); err != nil {
+		return "", err
+	}
+	failed := false
+	defer func() {
+		if !failed {
+			releasedLogin(ctx, userName)
+		}
+	}()
+
 	var identity Identity
 	var valid bool
 	var err error
@@ -179,6 +189,8 @@ func (p *oryprovider) PasswordCredentialsCode(ctx context.Context, userName stri
 	}
 
 	if err != nil {
+		failed = true
+		failedLogin(ctx, userName)
 		return "", err
 	}
 
@@ -245,6 +257,16 @@ func (p *oryprovider) PasswordCredentialsCode(ctx context.Context, userName stri
 }
 
 func (p *oryprovider) PasswordCredentialsToken(ctx context.Context, userName string, password string) (*goauth.Token, error) {
+	if err := allowLogin(ctx, userName); err != nil {
+		return nil, err
+	}
+	failed := false
+	defer func() {
+		if !failed {
+			releasedLogin(ctx, userName)
+		}
+	}()
+
 	// Getting or creating challenge
 	c, err := hydra.CreateLogin(ctx, config.DefaultOAuthClientID, []string{"openid", "profile", "offline"}, []string{})
 	if err != nil {
@@ -291,6 +313,8 @@ func (p *oryprovider) PasswordCredentialsToken(ctx context.Context, userName str
 	}
 
 	if err != nil {
+		failed = true
+		failedLogin(ctx, userName)
 		return nil, err
 	}
 
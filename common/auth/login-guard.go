@@ -0,0 +1,64 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package auth
+
+import (
+	"context"
+)
+
+// LoginGuard is consulted by the password grants before and after the credentials are checked.
+// It is used to slow down or refuse brute-force attempts.
+type LoginGuard interface {
+	// Allow returns an error if a new attempt for this login must be refused now.
+	Allow(ctx context.Context, login string) error
+	// Failed records an attempt that did not match any user or password.
+	Failed(ctx context.Context, login string)
+	// Released is called instead of Failed for an allowed attempt that did not fail on the credentials.
+	Released(ctx context.Context, login string)
+}
+
+var loginGuards []LoginGuard
+
+// RegisterLoginGuard adds a LoginGuard to the password grants.
+func RegisterLoginGuard(g LoginGuard) {
+	loginGuards = append(loginGuards, g)
+}
+
+func allowLogin(ctx context.Context, login string) error {
+	for _, g := range loginGuards {
+		if e := g.Allow(ctx, login); e != nil {
+			return e
+		}
+	}
+	return nil
+}
+
+func failedLogin(ctx context.Context, login string) {
+	for _, g := range loginGuards {
+		g.Failed(ctx, login)
+	}
+}
+
+func releasedLogin(ctx context.Context, login string) {
+	for _, g := range loginGuards {
+		g.Released(ctx, login)
+	}
+}
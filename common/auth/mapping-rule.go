@@ -25,6 +25,13 @@ import (
 	"strings"
 )
 
+const (
+	// MappingRuleRoles is the reserved RightAttribute mapping values to role IDs
+	MappingRuleRoles = "Roles"
+	// MappingRuleGroupPath is the reserved RightAttribute mapping a value to the user group path
+	MappingRuleGroupPath = "GroupPath"
+)
+
 type MappingRule struct {
 	RuleName string
 
@@ -169,3 +176,17 @@ func (m MappingRule) FilterList(list []string, strs []string) []string {
 	}
 	return strs
 }
+
+// ApplyValues runs the whole rule on the values read from the LeftAttribute: values are trimmed,
+// reduced to their first RDN if they are DNs, filtered by the RuleString and finally prefixed by RolePrefix.
+func (m MappingRule) ApplyValues(strs []string) []string {
+	values := m.ConvertDNtoName(m.SanitizeValues(strs))
+	if m.RuleString != "" {
+		if strings.HasPrefix(m.RuleString, "preg:") {
+			values = m.FilterPreg(m.RuleString, values)
+		} else {
+			values = m.FilterList(m.SanitizeValues(strings.Split(m.RuleString, ",")), values)
+		}
+	}
+	return m.AddPrefix(m.RolePrefix, values)
+}
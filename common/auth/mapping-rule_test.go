@@ -119,6 +119,23 @@ func TestMappingRule_FilterPreg(t *testing.T) {
 	}
 }
 
+func TestMappingRule_ApplyValues(t *testing.T) {
+	m := getMappingRuleConfig()
+	m.RightAttribute = MappingRuleRoles
+	m.RuleString = "teacher, researcher"
+	m.RolePrefix = "saml_"
+	rightValues := []string{"cn=teacher,dc=vpydio,dc=fr", " student", "researcher "}
+	correctLeftValues := []string{"saml_teacher", "saml_researcher"}
+	if !testEq(correctLeftValues, m.ApplyValues(rightValues)) {
+		t.Errorf("Error")
+	}
+
+	m.RuleString = "preg:^stu"
+	if !testEq([]string{"saml_student"}, m.ApplyValues(rightValues)) {
+		t.Errorf("Error")
+	}
+}
+
 func TestMappingRule_IsDnFormat(t *testing.T) {
 	m := getMappingRuleConfig()
 	DN := "cn=test,cn=abc,dc=com,dc=test"
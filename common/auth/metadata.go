@@ -23,6 +23,7 @@ package auth
 import (
 	"context"
 	"strings"
+	"time"
 
 	json "github.com/pydio/cells/x/jsonx"
 
@@ -91,7 +92,7 @@ func ClaimsFromMetadata(ctx context.Context) (c claim.Claims, o bool) {
 			return c, false
 		}
 		var roles []string
-		for _, role := range u.Roles {
+		for _, role := range u.ActiveRoles(time.Now()) {
 			roles = append(roles, role.Uuid)
 		}
 		//fmt.Println("Reloaded Roles to Claims", len(roles))
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/micro/go-micro/errors"
 	"github.com/ory/ladon"
@@ -20,10 +21,19 @@ import (
 // CheckOIDCPolicies builds a local policies checker by loading "oidc"-resource policies and putting them in
 // an in-memory ladon.Manager. It reloads policies every 1mn.
 func checkOIDCPolicies(ctx context.Context, user *idm.User) error {
+	return CheckOIDCPoliciesWithContext(ctx, user, nil)
+}
+
+// CheckOIDCPoliciesWithContext checks the "oidc"-resource policies for the login action, adding the
+// extra keys to the policy context built from the request metadata.
+func CheckOIDCPoliciesWithContext(ctx context.Context, user *idm.User, extra map[string]string) error {
 
 	subjects := permissions.PolicyRequestSubjectsFromUser(user)
 	policyContext := make(map[string]string)
 	permissions.PolicyContextFromMetadata(policyContext, ctx)
+	for k, v := range extra {
+		policyContext[k] = v
+	}
 
 	checker, err := permissions.CachedPoliciesChecker(ctx, "oidc")
 	if err != nil {
@@ -99,7 +109,7 @@ func SubjectsForResourcePolicyQuery(ctx context.Context, q *rest.ResourcePolicyQ
 						break
 					}
 				}
-				for _, r := range u.Roles {
+				for _, r := range u.ActiveRoles(time.Now()) {
 					subjects = append(subjects, "role:"+r.Uuid)
 				}
 			} else {
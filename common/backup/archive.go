@@ -0,0 +1,241 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package backup
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Writer writes an archive: entries are added one by one and the manifest is written on Close.
+type Writer struct {
+	manifest *Manifest
+	enc      *encryptWriter
+	gz       *gzip.Writer
+	tw       *tar.Writer
+}
+
+// NewWriter creates an archive writing to w. If password is not empty, the archive is encrypted.
+func NewWriter(w io.Writer, password string, manifest *Manifest) (*Writer, error) {
+	aw := &Writer{manifest: manifest}
+	if password != "" {
+		enc, err := newEncryptWriter(w, password)
+		if err != nil {
+			return nil, err
+		}
+		aw.enc = enc
+		w = enc
+	}
+	manifest.Format = FormatVersion
+	manifest.Encrypted = password != ""
+	aw.gz = gzip.NewWriter(w)
+	aw.tw = tar.NewWriter(aw.gz)
+	return aw, nil
+}
+
+// AddFile adds the content of a local file.
+func (w *Writer) AddFile(entry *Entry, filename string) error {
+	f, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	st, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	return w.add(entry, f, st.Size())
+}
+
+// AddStream adds the content written by dump. As the size of each tar entry must be known
+// beforehand, the content is first written to a temporary file.
+func (w *Writer) AddStream(entry *Entry, dump func(io.Writer) error) error {
+	tmp, err := ioutil.TempFile("", "cells-backup-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+	if err := dump(tmp); err != nil {
+		return err
+	}
+	size, err := tmp.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	return w.add(entry, tmp, size)
+}
+
+func (w *Writer) add(entry *Entry, r io.Reader, size int64) error {
+	if !validName(entry.Name) || entry.Name == ManifestName {
+		return fmt.Errorf("invalid entry name %s", entry.Name)
+	}
+	if err := w.tw.WriteHeader(&tar.Header{Name: entry.Name, Mode: 0600, Size: size, ModTime: time.Now()}); err != nil {
+		return err
+	}
+	h := sha256.New()
+	n, err := io.Copy(w.tw, io.TeeReader(r, h))
+	if err != nil {
+		return err
+	}
+	if n != size {
+		return fmt.Errorf("%s changed while being archived", entry.Name)
+	}
+	entry.Size = n
+	entry.SHA256 = hex.EncodeToString(h.Sum(nil))
+	w.manifest.Entries = append(w.manifest.Entries, entry)
+	return nil
+}
+
+// Close writes the manifest and flushes the archive. It does not close the underlying writer.
+func (w *Writer) Close() error {
+	data, err := json.MarshalIndent(w.manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := w.tw.WriteHeader(&tar.Header{Name: ManifestName, Mode: 0600, Size: int64(len(data)), ModTime: time.Now()}); err != nil {
+		return err
+	}
+	if _, err := w.tw.Write(data); err != nil {
+		return err
+	}
+	if err := w.tw.Close(); err != nil {
+		return err
+	}
+	if err := w.gz.Close(); err != nil {
+		return err
+	}
+	if w.enc != nil {
+		return w.enc.Close()
+	}
+	return nil
+}
+
+// ErrPasswordRequired is returned when reading an encrypted archive without password.
+var ErrPasswordRequired = fmt.Errorf("archive is encrypted, a password is required")
+
+// Extract reads an archive and writes its entries under dir, or only checks them if dir is empty.
+// All checksums are verified against the manifest, which is returned.
+func Extract(r io.Reader, password, dir string) (*Manifest, error) {
+	encrypted, r, err := IsEncrypted(r)
+	if err != nil {
+		return nil, err
+	}
+	if encrypted {
+		if password == "" {
+			return nil, ErrPasswordRequired
+		}
+		if r, err = newDecryptReader(r, password); err != nil {
+			return nil, err
+		}
+	}
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+	tr := tar.NewReader(gz)
+
+	sums := map[string]string{}
+	var manifest *Manifest
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		if hdr.Name == ManifestName {
+			manifest = &Manifest{}
+			if err := json.NewDecoder(tr).Decode(manifest); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if !validName(hdr.Name) {
+			return nil, fmt.Errorf("invalid entry name %s", hdr.Name)
+		}
+		if sums[hdr.Name], err = extractEntry(tr, dir, hdr.Name); err != nil {
+			return nil, err
+		}
+	}
+	if manifest == nil {
+		return nil, fmt.Errorf("manifest not found, archive is incomplete")
+	}
+	if manifest.Format > FormatVersion {
+		return nil, fmt.Errorf("archive format %d is not supported by this version", manifest.Format)
+	}
+	for _, e := range manifest.Entries {
+		sum, ok := sums[e.Name]
+		if !ok {
+			return nil, fmt.Errorf("entry %s is missing", e.Name)
+		}
+		if sum != e.SHA256 {
+			return nil, fmt.Errorf("checksum mismatch for entry %s", e.Name)
+		}
+		delete(sums, e.Name)
+	}
+	for name := range sums {
+		return nil, fmt.Errorf("entry %s is not listed in the manifest", name)
+	}
+	return manifest, nil
+}
+
+func extractEntry(r io.Reader, dir, name string) (string, error) {
+	w := ioutil.Discard
+	if dir != "" {
+		target := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(target), 0700); err != nil {
+			return "", err
+		}
+		f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+		if err != nil {
+			return "", err
+		}
+		defer f.Close()
+		w = f
+	}
+	h := sha256.New()
+	if _, err := io.Copy(w, io.TeeReader(r, h)); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// validName rejects absolute names and names escaping the extraction directory.
+func validName(name string) bool {
+	clean := path.Clean(name)
+	return name != "" && clean == name && !path.IsAbs(name) && clean != ".." && !strings.HasPrefix(clean, "../")
+}
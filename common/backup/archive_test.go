@@ -0,0 +1,118 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package backup
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func writeArchive(password string) []byte {
+	buf := &bytes.Buffer{}
+	w, e := NewWriter(buf, password, &Manifest{CellsVersion: "2.2.0"})
+	So(e, ShouldBeNil)
+	So(w.AddStream(&Entry{Name: "databases/default.jsonl", Kind: KindDatabase, Driver: "mysql"}, func(out io.Writer) error {
+		_, e := out.Write(bytes.Repeat([]byte("row\n"), 40000))
+		return e
+	}), ShouldBeNil)
+	So(w.AddStream(&Entry{Name: "config/pydio.json", Kind: KindConfig}, func(out io.Writer) error {
+		_, e := out.Write([]byte(`{"version":"2.2.0"}`))
+		return e
+	}), ShouldBeNil)
+	So(w.Close(), ShouldBeNil)
+	return buf.Bytes()
+}
+
+func TestArchive(t *testing.T) {
+
+	Convey("Test plain archive is extracted and verified", t, func() {
+		data := writeArchive("")
+		dir, _ := ioutil.TempDir("", "backup-test")
+		defer os.RemoveAll(dir)
+
+		m, e := Extract(bytes.NewReader(data), "", dir)
+		So(e, ShouldBeNil)
+		So(m.Encrypted, ShouldBeFalse)
+		So(m.Entries, ShouldHaveLength, 2)
+		So(m.Find("databases/default.jsonl").Size, ShouldEqual, 160000)
+		content, _ := ioutil.ReadFile(filepath.Join(dir, "config", "pydio.json"))
+		So(string(content), ShouldEqual, `{"version":"2.2.0"}`)
+	})
+
+	Convey("Test encrypted archive requires the right password", t, func() {
+		data := writeArchive("secret")
+		So(bytes.Contains(data, []byte("manifest.json")), ShouldBeFalse)
+
+		_, e := Extract(bytes.NewReader(data), "", "")
+		So(e, ShouldEqual, ErrPasswordRequired)
+		_, e = Extract(bytes.NewReader(data), "wrong", "")
+		So(e, ShouldNotBeNil)
+
+		m, e := Extract(bytes.NewReader(data), "secret", "")
+		So(e, ShouldBeNil)
+		So(m.Encrypted, ShouldBeTrue)
+		So(m.CellsVersion, ShouldEqual, "2.2.0")
+
+		// Truncated archive is detected
+		_, e = Extract(bytes.NewReader(data[:len(data)-40]), "secret", "")
+		So(e, ShouldNotBeNil)
+	})
+
+	Convey("Test corrupted content is detected", t, func() {
+		buf := &bytes.Buffer{}
+		w, _ := NewWriter(buf, "", &Manifest{})
+		So(w.AddStream(&Entry{Name: "config/pydio.json", Kind: KindConfig}, func(out io.Writer) error {
+			_, e := out.Write([]byte(`{}`))
+			return e
+		}), ShouldBeNil)
+		w.manifest.Entries[0].SHA256 = strings.Repeat("0", 64)
+		So(w.Close(), ShouldBeNil)
+		_, e := Extract(bytes.NewReader(buf.Bytes()), "", "")
+		So(e, ShouldNotBeNil)
+		So(e.Error(), ShouldContainSubstring, "checksum mismatch")
+	})
+
+	Convey("Test entry names cannot escape the extraction directory", t, func() {
+		So(validName("services/pydio.grpc.jobs/jobs.db"), ShouldBeTrue)
+		So(validName("../etc/passwd"), ShouldBeFalse)
+		So(validName("/etc/passwd"), ShouldBeFalse)
+		So(validName("services/../../x"), ShouldBeFalse)
+	})
+
+	Convey("Test entry targets are confined to the restore directory", t, func() {
+		root := filepath.FromSlash("/var/cells/services")
+		p, e := (&Entry{Name: "services/a", Target: "pydio.grpc.jobs/jobs.db"}).TargetPath(root)
+		So(e, ShouldBeNil)
+		So(p, ShouldEqual, filepath.Join(root, "pydio.grpc.jobs", "jobs.db"))
+		for _, target := range []string{"", "/etc/passwd", "../pydio.json", "pydio.grpc.jobs/../../pydio.json", "..\\pydio.json"} {
+			_, e = (&Entry{Name: "services/a", Target: target}).TargetPath(root)
+			So(e, ShouldNotBeNil)
+		}
+	})
+
+}
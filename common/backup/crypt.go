@@ -0,0 +1,200 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package backup
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/pbkdf2"
+
+	"github.com/pydio/cells/common/crypto"
+)
+
+// Encrypted archives start with a magic header and a random salt used to derive the key from the password.
+// The content is then split into chunks sealed with AES-GCM, each prefixed by its length. The nonce is the
+// chunk counter, and the last chunk is authenticated as such so that a truncated archive is detected.
+var magic = []byte("CELLSBK1")
+
+const (
+	saltSize   = 16
+	chunkSize  = 64 * 1024
+	iterations = 100000
+)
+
+var (
+	chunkData = []byte{0}
+	chunkLast = []byte{1}
+)
+
+func deriveKey(password string, salt []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(pbkdf2.Key([]byte(password), salt, iterations, 32, sha256.New))
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func chunkNonce(counter uint64, size int) []byte {
+	nonce := make([]byte, size)
+	binary.BigEndian.PutUint64(nonce[size-8:], counter)
+	return nonce
+}
+
+type encryptWriter struct {
+	w       io.Writer
+	aead    cipher.AEAD
+	buf     []byte
+	counter uint64
+}
+
+func newEncryptWriter(w io.Writer, password string) (*encryptWriter, error) {
+	salt, err := crypto.RandomBytes(saltSize)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := deriveKey(password, salt)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(append(append([]byte{}, magic...), salt...)); err != nil {
+		return nil, err
+	}
+	return &encryptWriter{w: w, aead: aead, buf: make([]byte, 0, chunkSize)}, nil
+}
+
+func (e *encryptWriter) Write(p []byte) (int, error) {
+	written := 0
+	for len(p) > 0 {
+		n := chunkSize - len(e.buf)
+		if n > len(p) {
+			n = len(p)
+		}
+		e.buf = append(e.buf, p[:n]...)
+		p = p[n:]
+		written += n
+		if len(e.buf) == chunkSize {
+			if err := e.seal(chunkData); err != nil {
+				return written, err
+			}
+		}
+	}
+	return written, nil
+}
+
+func (e *encryptWriter) seal(ad []byte) error {
+	sealed := e.aead.Seal(nil, chunkNonce(e.counter, e.aead.NonceSize()), e.buf, ad)
+	e.counter++
+	e.buf = e.buf[:0]
+	header := make([]byte, 4)
+	binary.BigEndian.PutUint32(header, uint32(len(sealed)))
+	if _, err := e.w.Write(header); err != nil {
+		return err
+	}
+	_, err := e.w.Write(sealed)
+	return err
+}
+
+// Close seals the remaining data as the last chunk. It does not close the underlying writer.
+func (e *encryptWriter) Close() error {
+	return e.seal(chunkLast)
+}
+
+type decryptReader struct {
+	r       io.Reader
+	aead    cipher.AEAD
+	buf     []byte
+	counter uint64
+	last    bool
+}
+
+func newDecryptReader(r io.Reader, password string) (*decryptReader, error) {
+	header := make([]byte, len(magic)+saltSize)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(header[:len(magic)], magic) {
+		return nil, fmt.Errorf("archive is not encrypted")
+	}
+	aead, err := deriveKey(password, header[len(magic):])
+	if err != nil {
+		return nil, err
+	}
+	return &decryptReader{r: r, aead: aead}, nil
+}
+
+func (d *decryptReader) Read(p []byte) (int, error) {
+	for len(d.buf) == 0 {
+		if d.last {
+			return 0, io.EOF
+		}
+		if err := d.open(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, d.buf)
+	d.buf = d.buf[n:]
+	return n, nil
+}
+
+func (d *decryptReader) open() error {
+	header := make([]byte, 4)
+	if _, err := io.ReadFull(d.r, header); err != nil {
+		if err == io.EOF {
+			return fmt.Errorf("archive is truncated")
+		}
+		return err
+	}
+	sealed := make([]byte, binary.BigEndian.Uint32(header))
+	if _, err := io.ReadFull(d.r, sealed); err != nil {
+		return err
+	}
+	nonce := chunkNonce(d.counter, d.aead.NonceSize())
+	d.counter++
+	if plain, err := d.aead.Open(nil, nonce, sealed, chunkData); err == nil {
+		d.buf = plain
+		return nil
+	}
+	plain, err := d.aead.Open(nil, nonce, sealed, chunkLast)
+	if err != nil {
+		return fmt.Errorf("cannot decrypt archive, the password is probably wrong")
+	}
+	d.buf = plain
+	d.last = true
+	return nil
+}
+
+// IsEncrypted tells whether the archive starts with the encryption header. The returned reader must be used
+// in place of r as the header is peeked from it.
+func IsEncrypted(r io.Reader) (bool, io.Reader, error) {
+	br := bufio.NewReader(r)
+	head, err := br.Peek(len(magic))
+	if err != nil && err != io.EOF {
+		return false, br, err
+	}
+	return bytes.Equal(head, magic), br, nil
+}
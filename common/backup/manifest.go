@@ -0,0 +1,91 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+// Package backup reads and writes installation backups: a tar archive, optionally encrypted, holding
+// configuration files, database dumps and services data files, described by a manifest.
+package backup
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	// FormatVersion is the version of the archive layout.
+	FormatVersion = 1
+	// ManifestName is the name of the manifest entry, always written last in the archive.
+	ManifestName = "manifest.json"
+)
+
+const (
+	// KindConfig entries are configuration files restored in the configuration directory.
+	KindConfig = "config"
+	// KindDatabase entries are SQL dumps produced by a dao.Backuper, reloaded through the same interface
+	// in the database currently configured for their services.
+	KindDatabase = "database"
+	// KindBolt entries are BoltDB files declared as databases, restored under the services directory or,
+	// if they have no target, at the location currently configured for their services.
+	KindBolt = "bolt"
+	// KindFile entries are services data files restored under the services directory.
+	KindFile = "file"
+)
+
+// Entry describes a file of the archive.
+type Entry struct {
+	Name     string   `json:"name"`
+	Kind     string   `json:"kind"`
+	Size     int64    `json:"size"`
+	SHA256   string   `json:"sha256"`
+	Driver   string   `json:"driver,omitempty"`
+	Target   string   `json:"target,omitempty"`
+	Services []string `json:"services,omitempty"`
+}
+
+// Manifest describes the content of an archive and the installation it was taken from.
+type Manifest struct {
+	Format       int       `json:"format"`
+	CellsVersion string    `json:"cellsVersion"`
+	PackageType  string    `json:"packageType"`
+	Hostname     string    `json:"hostname"`
+	Created      time.Time `json:"created"`
+	Encrypted    bool      `json:"encrypted"`
+	Entries      []*Entry  `json:"entries"`
+}
+
+// TargetPath returns the location of the entry Target under root. It fails if the target is absolute
+// or contains ".." components, so that an archive cannot write outside of root.
+func (e *Entry) TargetPath(root string) (string, error) {
+	if !validName(e.Target) || strings.Contains(e.Target, "\\") || filepath.IsAbs(filepath.FromSlash(e.Target)) {
+		return "", fmt.Errorf("invalid target %s for entry %s", e.Target, e.Name)
+	}
+	return filepath.Join(root, filepath.FromSlash(e.Target)), nil
+}
+
+// Find returns the entry with the given name, or nil.
+func (m *Manifest) Find(name string) *Entry {
+	for _, e := range m.Entries {
+		if e.Name == name {
+			return e
+		}
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package boltdb
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	bolt "github.com/etcd-io/bbolt"
+
+	"github.com/pydio/cells/common/dao"
+)
+
+// FileBackuper implements dao.Backuper for a BoltDB file that may be held by a running service.
+type FileBackuper struct {
+	Path string
+}
+
+// NewFileBackuper creates a backuper for the BoltDB file at path.
+func NewFileBackuper(path string) dao.Backuper {
+	return &FileBackuper{Path: path}
+}
+
+// Backup copies the database inside a read transaction. It fails if the file is locked by a running service.
+func (f *FileBackuper) Backup(ctx context.Context, w io.Writer) error {
+	db, err := bolt.Open(f.Path, 0400, &bolt.Options{ReadOnly: true, Timeout: time.Second})
+	if err == bolt.ErrTimeout {
+		return fmt.Errorf("%s is locked by a running service", f.Path)
+	} else if err != nil {
+		return err
+	}
+	defer db.Close()
+	return db.View(func(tx *bolt.Tx) error {
+		_, e := tx.WriteTo(w)
+		return e
+	})
+}
+
+// IsLocked tells if the BoltDB file at path is currently held by a running service.
+func IsLocked(path string) bool {
+	db, err := bolt.Open(path, 0400, &bolt.Options{ReadOnly: true, Timeout: time.Second})
+	if err == bolt.ErrTimeout {
+		return true
+	} else if err == nil {
+		db.Close()
+	}
+	return false
+}
+
+// Restore replaces the database file. The owning service must not be running.
+func (f *FileBackuper) Restore(ctx context.Context, r io.Reader) error {
+	if err := os.MkdirAll(filepath.Dir(f.Path), 0755); err != nil {
+		return err
+	}
+	tmp := f.Path + ".restore"
+	out, err := os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, r); err != nil {
+		out.Close()
+		os.Remove(tmp)
+		return err
+	}
+	if err := out.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, f.Path)
+}
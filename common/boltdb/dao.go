@@ -150,3 +150,13 @@ func copyValuesOrBucket(bW, bR *bolt.Bucket) error {
 		}
 	})
 }
+
+// CheckHealth verifies that the database file is still present and can be read.
+func CheckHealth(db *bolt.DB) error {
+	if _, err := os.Stat(db.Path()); err != nil {
+		return err
+	}
+	return db.View(func(tx *bolt.Tx) error {
+		return nil
+	})
+}
@@ -189,6 +189,9 @@ func (c *cacheValues) Get() configx.Value {
 
 // We store it in the cache and in the store
 func (c *cacheValues) Set(v interface{}) error {
+	// Secret references are resolved on read, make sure they are not replaced by their value
+	v = configx.KeepReferences(c.Values.Val(c.path...), v)
+
 	err := c.Values.Val(c.path...).Set(v)
 	if err != nil {
 		return err
@@ -0,0 +1,151 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/x/configx"
+)
+
+// MaintenanceMode defines how a datasource behaves during a maintenance window
+type MaintenanceMode string
+
+const (
+	// MaintenanceReadOnly rejects all write operations on the datasource
+	MaintenanceReadOnly MaintenanceMode = "read-only"
+	// MaintenanceOffline rejects all operations on the datasource
+	MaintenanceOffline MaintenanceMode = "offline"
+)
+
+// DataSourceMaintenance is a maintenance window scheduled on a datasource.
+// A zero Start means the window is opened immediately, a zero End means it lasts until it is removed.
+type DataSourceMaintenance struct {
+	Mode    MaintenanceMode `json:"Mode"`
+	Start   time.Time       `json:"Start,omitempty"`
+	End     time.Time       `json:"End,omitempty"`
+	Message string          `json:"Message,omitempty"`
+}
+
+// Validate checks the window mode and dates
+func (m *DataSourceMaintenance) Validate() error {
+	if m.Mode != MaintenanceReadOnly && m.Mode != MaintenanceOffline {
+		return fmt.Errorf("unknown maintenance mode %s, use %s or %s", m.Mode, MaintenanceReadOnly, MaintenanceOffline)
+	}
+	if !m.Start.IsZero() && !m.End.IsZero() && !m.End.After(m.Start) {
+		return fmt.Errorf("maintenance window must end after it starts")
+	}
+	return nil
+}
+
+// Active checks if the window is opened at time t
+func (m *DataSourceMaintenance) Active(t time.Time) bool {
+	if !m.Start.IsZero() && t.Before(m.Start) {
+		return false
+	}
+	if !m.End.IsZero() && !t.Before(m.End) {
+		return false
+	}
+	return true
+}
+
+// ReadOnly checks if write operations are rejected by this window
+func (m *DataSourceMaintenance) ReadOnly() bool {
+	return m.Mode == MaintenanceReadOnly || m.Mode == MaintenanceOffline
+}
+
+// Offline checks if all operations are rejected by this window
+func (m *DataSourceMaintenance) Offline() bool {
+	return m.Mode == MaintenanceOffline
+}
+
+// ActiveMaintenance finds the window opened at time t, offline windows taking precedence over read-only ones.
+func ActiveMaintenance(windows []*DataSourceMaintenance, t time.Time) *DataSourceMaintenance {
+	var active *DataSourceMaintenance
+	for _, w := range windows {
+		if !w.Active(t) {
+			continue
+		}
+		if active == nil || (w.Offline() && !active.Offline()) {
+			active = w
+		}
+	}
+	return active
+}
+
+// GetDataSourceMaintenance lists the maintenance windows scheduled on a datasource
+func GetDataSourceMaintenance(dsName string) []*DataSourceMaintenance {
+	var windows []*DataSourceMaintenance
+	if e := Get(configx.FormatPath("maintenance", "datasources", dsName)).Scan(&windows); e != nil {
+		return nil
+	}
+	return windows
+}
+
+// GetActiveDataSourceMaintenance returns the maintenance window currently opened on a datasource, if any
+func GetActiveDataSourceMaintenance(dsName string) *DataSourceMaintenance {
+	return ActiveMaintenance(GetDataSourceMaintenance(dsName), time.Now())
+}
+
+// ListDataSourcesMaintenance lists the maintenance windows scheduled on all datasources, indexed by datasource name
+func ListDataSourcesMaintenance() map[string][]*DataSourceMaintenance {
+	res := make(map[string][]*DataSourceMaintenance)
+	for name := range Get("maintenance", "datasources").Map() {
+		if windows := GetDataSourceMaintenance(name); len(windows) > 0 {
+			res[name] = windows
+		}
+	}
+	return res
+}
+
+// SetDataSourceMaintenance validates and saves the maintenance windows of a datasource. Passing no window
+// removes all maintenance windows from the datasource.
+func SetDataSourceMaintenance(dsName string, windows []*DataSourceMaintenance, ctxUser string) error {
+	for _, w := range windows {
+		if e := w.Validate(); e != nil {
+			return e
+		}
+	}
+	p := configx.FormatPath("maintenance", "datasources", dsName)
+	if len(windows) == 0 {
+		Del(p)
+	} else {
+		// Store plain values rather than structs
+		data, e := json.Marshal(windows)
+		if e != nil {
+			return e
+		}
+		var values []interface{}
+		if e := json.Unmarshal(data, &values); e != nil {
+			return e
+		}
+		if e := Set(values, p); e != nil {
+			return e
+		}
+	}
+	if ctxUser == "" {
+		ctxUser = common.PydioSystemUsername
+	}
+	return Save(ctxUser, "Update maintenance windows of datasource "+dsName)
+}
@@ -0,0 +1,37 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestDataSourceMaintenance(t *testing.T) {
+
+	now := time.Now()
+
+	Convey("Test maintenance window validation", t, func() {
+		So((&DataSourceMaintenance{Mode: MaintenanceReadOnly}).Validate(), ShouldBeNil)
+		So((&DataSourceMaintenance{Mode: "frozen"}).Validate(), ShouldNotBeNil)
+		So((&DataSourceMaintenance{Mode: MaintenanceOffline, Start: now, End: now.Add(-time.Hour)}).Validate(), ShouldNotBeNil)
+	})
+
+	Convey("Test active maintenance window", t, func() {
+		permanent := &DataSourceMaintenance{Mode: MaintenanceReadOnly}
+		past := &DataSourceMaintenance{Mode: MaintenanceOffline, Start: now.Add(-2 * time.Hour), End: now.Add(-time.Hour)}
+		future := &DataSourceMaintenance{Mode: MaintenanceOffline, Start: now.Add(time.Hour)}
+		current := &DataSourceMaintenance{Mode: MaintenanceOffline, Start: now.Add(-time.Hour), End: now.Add(time.Hour)}
+
+		So(permanent.Active(now), ShouldBeTrue)
+		So(past.Active(now), ShouldBeFalse)
+		So(future.Active(now), ShouldBeFalse)
+		So(current.Active(now), ShouldBeTrue)
+
+		So(ActiveMaintenance(nil, now), ShouldBeNil)
+		So(ActiveMaintenance([]*DataSourceMaintenance{past, future}, now), ShouldBeNil)
+		So(ActiveMaintenance([]*DataSourceMaintenance{permanent, past}, now), ShouldEqual, permanent)
+		So(ActiveMaintenance([]*DataSourceMaintenance{permanent, current}, now), ShouldEqual, current)
+	})
+
+}
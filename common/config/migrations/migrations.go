@@ -3,6 +3,7 @@ package migrations
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/hashicorp/go-version"
 
@@ -18,6 +19,14 @@ type migrationConfig struct {
 type migrationConfigFunc func(*configx.Values) func(context.Context) error
 type migrationFunc func(configx.Values) error
 
+const (
+	// ClusterLock is the name of the cluster lock held while applying migrations, so that the nodes
+	// sharing the configuration do not apply them concurrently
+	ClusterLock = "pydio-config-migrations"
+	// ClusterLockTimeout is the maximum time waited for the migrations lock
+	ClusterLockTimeout = time.Minute
+)
+
 var (
 	configMigrations []*migrationConfig
 
@@ -38,6 +47,12 @@ func getMigration(f migrationFunc) migrationConfigFunc {
 	}
 }
 
+// UpgradeRequired tells if the configuration version is older than the target version
+func UpgradeRequired(config configx.Values, targetVersion *version.Version) bool {
+	lastVersion, err := version.NewVersion(config.Val("version").Default("0.0.0").String())
+	return err == nil && lastVersion.LessThan(targetVersion)
+}
+
 // UpgradeConfigsIfRequired applies all registered configMigration functions
 // Returns true if there was a change and save is required, error if something nasty happened
 func UpgradeConfigsIfRequired(config configx.Values, targetVersion *version.Version) (bool, error) {
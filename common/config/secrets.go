@@ -1,6 +1,8 @@
 package config
 
 import (
+	"strings"
+
 	"github.com/pborman/uuid"
 	"github.com/pydio/cells/x/configx"
 )
@@ -41,3 +43,124 @@ func SetSecret(uuid string, val string) {
 func DelSecret(uuid string) {
 	stdvault.Val(uuid).Del()
 }
+
+// RedactedValue replaces secrets in the configurations exposed by admin APIs
+const RedactedValue = "********"
+
+var (
+	secretKeys = []string{"password", "secret", "dsn"}
+)
+
+// IsSecretKey checks if the values stored under this configuration key are secrets
+func IsSecretKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, s := range secretKeys {
+		if strings.Contains(key, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// Redact returns a copy of data, read at path, where the plain values of secret keys are masked.
+// Secret references are left as is as they do not disclose the secret itself.
+func Redact(data interface{}, path ...string) interface{} {
+	return redact(lastKey(path), data)
+}
+
+// Unredact puts back the original values of data that were previously masked by Redact
+func Unredact(data, original interface{}, path ...string) interface{} {
+	return unredact(lastKey(path), data, original)
+}
+
+func lastKey(path []string) string {
+	keys := configx.StringToKeys(path...)
+	if len(keys) == 0 {
+		return ""
+	}
+	return keys[len(keys)-1]
+}
+
+func redact(key string, data interface{}) interface{} {
+	switch d := data.(type) {
+	case string:
+		if d == "" || !IsSecretKey(key) || configx.IsReference(d) {
+			return d
+		}
+		if strings.Contains(strings.ToLower(key), "dsn") {
+			return redactDSN(d)
+		}
+		return RedactedValue
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(d))
+		for k, v := range d {
+			m[k] = redact(k, v)
+		}
+		return m
+	case map[string]string:
+		m := make(map[string]interface{}, len(d))
+		for k, v := range d {
+			m[k] = redact(k, v)
+		}
+		return m
+	case []interface{}:
+		s := make([]interface{}, len(d))
+		for i, v := range d {
+			s[i] = redact(key, v)
+		}
+		return s
+	}
+	return data
+}
+
+func unredact(key string, data, original interface{}) interface{} {
+	switch d := data.(type) {
+	case string:
+		if o, ok := original.(string); ok && d != o && d == redact(key, o) {
+			return o
+		}
+	case map[string]interface{}:
+		o, ok := original.(map[string]interface{})
+		if !ok {
+			return data
+		}
+		m := make(map[string]interface{}, len(d))
+		for k, v := range d {
+			m[k] = unredact(k, v, o[k])
+		}
+		return m
+	case []interface{}:
+		o, ok := original.([]interface{})
+		if !ok || len(o) != len(d) {
+			return data
+		}
+		s := make([]interface{}, len(d))
+		for i, v := range d {
+			s[i] = unredact(key, v, o[i])
+		}
+		return s
+	}
+	return data
+}
+
+// redactDSN only masks the password part of a DSN (user:password@host)
+func redactDSN(dsn string) string {
+	at := strings.LastIndex(dsn, "@")
+	if at < 0 {
+		return dsn
+	}
+	start := strings.Index(dsn, "://") + 1
+	if start > 0 {
+		start += 2
+	}
+	col := strings.Index(dsn[start:at], ":")
+	if col < 0 {
+		return dsn
+	}
+	return dsn[:start+col+1] + RedactedValue + dsn[at:]
+}
+
+// VaultKeys lists the configuration paths whose values are stored in the vault
+func VaultKeys() []string {
+	return append([]string{}, registeredVaultKeys...)
+}
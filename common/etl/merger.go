@@ -124,8 +124,11 @@ func (m *Merger) diffUsers(extUsers map[string]*idm.User, apiUsers map[string]*i
 					extUser.Attributes = make(map[string]string, 1)
 				}
 
-				if _, ok := extUser.Attributes[idm.UserAttrPassHashed]; !ok {
+				if val, ok := extUser.Attributes[idm.UserAttrPassHashed]; !ok {
 					extUser.Attributes[idm.UserAttrPassHashed] = "true"
+				} else if val != "true" {
+					// Clear text password from the source: let the user service hash it, do not store the flag
+					delete(extUser.Attributes, idm.UserAttrPassHashed)
 				}
 			}
 			userDiff.Create[extUserId] = extUser
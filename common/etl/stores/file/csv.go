@@ -0,0 +1,196 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package file
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/pydio/cells/common/proto/idm"
+)
+
+const (
+	csvType      = "type"
+	csvLogin     = "login"
+	csvUuid      = "uuid"
+	csvGroupPath = "groupPath"
+	csvLabel     = "label"
+	csvPassword  = "password"
+	csvRoles     = "roles"
+
+	csvTypeUser  = "user"
+	csvTypeGroup = "group"
+	csvTypeRole  = "role"
+
+	// csvRolesSeparator separates role UUIDs in the roles column
+	csvRolesSeparator = ";"
+)
+
+var (
+	csvColumns     = []string{csvType, csvLogin, csvUuid, csvGroupPath, csvLabel, csvPassword, csvRoles}
+	csvFirstAttrs  = []string{idm.UserAttrProfile, idm.UserAttrDisplayName, idm.UserAttrEmail}
+	csvKnownColumn = map[string]bool{}
+)
+
+func init() {
+	for _, c := range csvColumns {
+		csvKnownColumn[c] = true
+	}
+}
+
+// csvCodec reads and writes one object per line. The first line is a header, any column that
+// is not a known column is considered as a user or group attribute. The type column
+// is optional and defaults to user.
+type csvCodec struct{}
+
+func (c *csvCodec) Decode(r io.Reader) (*Content, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	header, e := reader.Read()
+	if e == io.EOF {
+		return &Content{}, nil
+	} else if e != nil {
+		return nil, e
+	}
+	for i, h := range header {
+		header[i] = strings.TrimSpace(h)
+	}
+	content := &Content{}
+	line := 1
+	for {
+		record, e := reader.Read()
+		if e == io.EOF {
+			break
+		} else if e != nil {
+			return nil, e
+		}
+		line++
+		values := make(map[string]string, len(header))
+		attributes := make(map[string]string)
+		for i, v := range record {
+			if i >= len(header) || header[i] == "" {
+				continue
+			}
+			if csvKnownColumn[header[i]] {
+				values[header[i]] = v
+			} else if v != "" {
+				attributes[header[i]] = v
+			}
+		}
+		switch values[csvType] {
+		case "", csvTypeUser:
+			if values[csvLogin] == "" {
+				return nil, fmt.Errorf("line %d: missing login", line)
+			}
+			u := &idm.User{
+				Uuid:       values[csvUuid],
+				Login:      values[csvLogin],
+				GroupPath:  values[csvGroupPath],
+				Password:   values[csvPassword],
+				Attributes: attributes,
+			}
+			for _, rId := range strings.Split(values[csvRoles], csvRolesSeparator) {
+				if rId = strings.TrimSpace(rId); rId != "" {
+					u.Roles = append(u.Roles, &idm.Role{Uuid: rId})
+				}
+			}
+			content.Users = append(content.Users, u)
+		case csvTypeGroup:
+			if values[csvGroupPath] == "" {
+				return nil, fmt.Errorf("line %d: missing group path", line)
+			}
+			content.Groups = append(content.Groups, &idm.User{
+				Uuid:       values[csvUuid],
+				IsGroup:    true,
+				GroupPath:  values[csvGroupPath],
+				GroupLabel: values[csvLabel],
+				Attributes: attributes,
+			})
+		case csvTypeRole:
+			if values[csvUuid] == "" {
+				return nil, fmt.Errorf("line %d: missing role uuid", line)
+			}
+			content.Roles = append(content.Roles, &idm.Role{Uuid: values[csvUuid], Label: values[csvLabel]})
+		default:
+			return nil, fmt.Errorf("line %d: unknown type %s", line, values[csvType])
+		}
+	}
+	return content, nil
+}
+
+func (c *csvCodec) Encode(w io.Writer, content *Content) error {
+	// Collect attributes names, most common ones first
+	attrs := append([]string{}, csvFirstAttrs...)
+	seen := make(map[string]bool)
+	for _, a := range attrs {
+		seen[a] = true
+	}
+	var others []string
+	for _, list := range [][]*idm.User{content.Groups, content.Users} {
+		for _, u := range list {
+			for k := range u.Attributes {
+				if !seen[k] && !csvKnownColumn[k] && !strings.HasPrefix(k, idm.UserAttrPrivatePrefix) {
+					seen[k] = true
+					others = append(others, k)
+				}
+			}
+		}
+	}
+	sort.Strings(others)
+	attrs = append(attrs, others...)
+
+	writer := csv.NewWriter(w)
+	if e := writer.Write(append(append([]string{}, csvColumns...), attrs...)); e != nil {
+		return e
+	}
+	record := func(typ, login, uuid, groupPath, label string, roles []*idm.Role, attributes map[string]string) []string {
+		var rIds []string
+		for _, r := range roles {
+			rIds = append(rIds, r.Uuid)
+		}
+		rec := []string{typ, login, uuid, groupPath, label, "", strings.Join(rIds, csvRolesSeparator)}
+		for _, a := range attrs {
+			rec = append(rec, attributes[a])
+		}
+		return rec
+	}
+	for _, r := range content.Roles {
+		if e := writer.Write(record(csvTypeRole, "", r.Uuid, "", r.Label, nil, nil)); e != nil {
+			return e
+		}
+	}
+	for _, g := range content.Groups {
+		if e := writer.Write(record(csvTypeGroup, "", g.Uuid, g.GroupPath, g.GroupLabel, nil, g.Attributes)); e != nil {
+			return e
+		}
+	}
+	for _, u := range content.Users {
+		if e := writer.Write(record(csvTypeUser, u.Login, u.Uuid, u.GroupPath, "", u.Roles, u.Attributes)); e != nil {
+			return e
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,410 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package file
+
+import (
+	"bufio"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/pydio/cells/common/proto/idm"
+)
+
+const (
+	defaultBaseDN = "dc=cells"
+
+	// ldifAttribute stores the user attributes that have no standard LDAP equivalent, as key=value
+	ldifAttribute = "cellsAttribute"
+	ldifLineWidth = 76
+)
+
+// ldifStandardAttrs maps user attributes to standard inetOrgPerson attributes
+var ldifStandardAttrs = map[string]string{
+	idm.UserAttrDisplayName: "cn",
+	idm.UserAttrEmail:       "mail",
+	idm.UserAttrProfile:     "employeeType",
+}
+
+// ldifEntry is a parsed LDIF record, attribute names are lower-cased.
+type ldifEntry struct {
+	dn    string
+	attrs map[string][]string
+}
+
+func (e *ldifEntry) first(name string) string {
+	if v := e.attrs[strings.ToLower(name)]; len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
+
+func (e *ldifEntry) hasClass(classes ...string) bool {
+	for _, c := range e.attrs["objectclass"] {
+		for _, cl := range classes {
+			if strings.EqualFold(c, cl) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// rdn is one component of a DN.
+type rdn struct {
+	attr  string
+	value string
+}
+
+// ldifCodec reads and writes LDAP Data Interchange Format files (RFC 2849). Groups are organizationalUnit
+// entries (ou=), users are inetOrgPerson entries (uid=) and roles are organizationalRole entries (cn=) directly
+// under the base DN. Users reference their roles with memberOf.
+type ldifCodec struct {
+	baseDN string
+}
+
+func (c *ldifCodec) Decode(r io.Reader) (*Content, error) {
+	entries, e := readLdifEntries(r)
+	if e != nil {
+		return nil, e
+	}
+	base, e := parseDN(c.baseDN)
+	if e != nil {
+		return nil, e
+	}
+	content := &Content{}
+	for _, entry := range entries {
+		rdns, e := parseDN(entry.dn)
+		if e != nil {
+			return nil, e
+		}
+		if len(rdns) == 0 {
+			continue
+		}
+		rdns = trimBaseDN(rdns, base)
+		switch {
+		case entry.hasClass("inetOrgPerson", "organizationalPerson", "person", "posixAccount") || strings.EqualFold(rdns[0].attr, "uid"):
+			u := &idm.User{
+				Uuid:       entry.first("entryUUID"),
+				Login:      entry.first("uid"),
+				GroupPath:  groupPathFromDN(rdns[1:]),
+				Password:   entry.first("userPassword"),
+				Attributes: make(map[string]string),
+			}
+			if u.Login == "" {
+				u.Login = rdns[0].value
+			}
+			for attr, ldapAttr := range ldifStandardAttrs {
+				// cn is mandatory and defaults to the login on export
+				if v := entry.first(ldapAttr); v != "" && !(ldapAttr == "cn" && v == u.Login) {
+					u.Attributes[attr] = v
+				}
+			}
+			for _, kv := range entry.attrs[strings.ToLower(ldifAttribute)] {
+				if parts := strings.SplitN(kv, "=", 2); len(parts) == 2 {
+					u.Attributes[parts[0]] = parts[1]
+				}
+			}
+			for _, m := range entry.attrs["memberof"] {
+				if roleDN, e := parseDN(m); e == nil && len(roleDN) > 0 {
+					u.Roles = append(u.Roles, &idm.Role{Uuid: roleDN[0].value})
+				}
+			}
+			content.Users = append(content.Users, u)
+		case entry.hasClass("organizationalUnit") || strings.EqualFold(rdns[0].attr, "ou"):
+			content.Groups = append(content.Groups, &idm.User{
+				Uuid:       entry.first("entryUUID"),
+				IsGroup:    true,
+				GroupPath:  groupPathFromDN(rdns),
+				GroupLabel: rdns[0].value,
+			})
+		case entry.hasClass("organizationalRole", "groupOfNames") || strings.EqualFold(rdns[0].attr, "cn"):
+			content.Roles = append(content.Roles, &idm.Role{
+				Uuid:  rdns[0].value,
+				Label: entry.first("description"),
+			})
+		}
+	}
+	return content, nil
+}
+
+func (c *ldifCodec) Encode(w io.Writer, content *Content) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "version: 1")
+	entry := func(dn string, classes []string, attrs [][2]string) {
+		fmt.Fprintln(bw)
+		writeLdifLine(bw, "dn", dn)
+		for _, cl := range classes {
+			writeLdifLine(bw, "objectClass", cl)
+		}
+		for _, a := range attrs {
+			if a[1] != "" {
+				writeLdifLine(bw, a[0], a[1])
+			}
+		}
+	}
+	roleDN := func(uuid string) string {
+		return "cn=" + escapeDNValue(uuid) + "," + c.baseDN
+	}
+	for _, r := range content.Roles {
+		entry(roleDN(r.Uuid), []string{"top", "organizationalRole"}, [][2]string{
+			{"cn", r.Uuid},
+			{"description", r.Label},
+		})
+	}
+	for _, g := range content.Groups {
+		entry(c.dnForPath(g.GroupPath), []string{"top", "organizationalUnit"}, [][2]string{
+			{"ou", g.GroupLabel},
+			{"entryUUID", g.Uuid},
+		})
+	}
+	for _, u := range content.Users {
+		attrs := [][2]string{{"uid", u.Login}, {"sn", u.Login}, {"entryUUID", u.Uuid}}
+		if u.Attributes[idm.UserAttrDisplayName] == "" {
+			attrs = append(attrs, [2]string{"cn", u.Login})
+		}
+		var keys []string
+		for k := range u.Attributes {
+			if !strings.HasPrefix(k, idm.UserAttrPrivatePrefix) {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if ldapAttr, ok := ldifStandardAttrs[k]; ok {
+				attrs = append(attrs, [2]string{ldapAttr, u.Attributes[k]})
+			} else {
+				attrs = append(attrs, [2]string{ldifAttribute, k + "=" + u.Attributes[k]})
+			}
+		}
+		for _, r := range u.Roles {
+			attrs = append(attrs, [2]string{"memberOf", roleDN(r.Uuid)})
+		}
+		dn := "uid=" + escapeDNValue(u.Login) + "," + c.dnForPath(u.GroupPath)
+		entry(dn, []string{"top", "person", "organizationalPerson", "inetOrgPerson"}, attrs)
+	}
+	return bw.Flush()
+}
+
+// dnForPath builds the DN of a group from its path.
+func (c *ldifCodec) dnForPath(groupPath string) string {
+	var parts []string
+	for _, p := range strings.Split(strings.Trim(groupPath, "/"), "/") {
+		if p != "" {
+			parts = append([]string{"ou=" + escapeDNValue(p)}, parts...)
+		}
+	}
+	return strings.Join(append(parts, c.baseDN), ",")
+}
+
+// readLdifEntries splits the input in records, unfolding lines and decoding base64 values.
+func readLdifEntries(r io.Reader) ([]*ldifEntry, error) {
+	var entries []*ldifEntry
+	var lines []string
+	flush := func() error {
+		if len(lines) == 0 {
+			return nil
+		}
+		entry := &ldifEntry{attrs: make(map[string][]string)}
+		for _, l := range lines {
+			idx := strings.Index(l, ":")
+			if idx <= 0 {
+				return fmt.Errorf("invalid line %q", l)
+			}
+			name, value := l[:idx], l[idx+1:]
+			if strings.HasPrefix(value, ":") {
+				decoded, e := base64.StdEncoding.DecodeString(strings.TrimSpace(value[1:]))
+				if e != nil {
+					return fmt.Errorf("invalid base64 value for %s: %v", name, e)
+				}
+				value = string(decoded)
+			} else if strings.HasPrefix(value, "<") {
+				return fmt.Errorf("URL values are not supported (%s)", name)
+			} else {
+				value = strings.TrimLeft(value, " ")
+			}
+			if strings.EqualFold(name, "dn") {
+				entry.dn = value
+			} else {
+				name = strings.ToLower(name)
+				entry.attrs[name] = append(entry.attrs[name], value)
+			}
+		}
+		lines = nil
+		if entry.dn == "" {
+			// Version line or non-entry record
+			return nil
+		}
+		entries = append(entries, entry)
+		return nil
+	}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		switch {
+		case line == "":
+			if e := flush(); e != nil {
+				return nil, e
+			}
+		case strings.HasPrefix(line, "#"):
+			continue
+		case strings.HasPrefix(line, " "):
+			if len(lines) == 0 {
+				return nil, fmt.Errorf("unexpected continuation line %q", line)
+			}
+			lines[len(lines)-1] += line[1:]
+		case strings.HasPrefix(strings.ToLower(line), "version:") && len(entries) == 0 && len(lines) == 0:
+			continue
+		default:
+			lines = append(lines, line)
+		}
+	}
+	if e := scanner.Err(); e != nil {
+		return nil, e
+	}
+	if e := flush(); e != nil {
+		return nil, e
+	}
+	return entries, nil
+}
+
+// writeLdifLine writes an attribute, base64-encoding unsafe values and folding long lines.
+func writeLdifLine(w io.Writer, name, value string) {
+	line := name + ": " + value
+	if !isSafeLdifValue(value) {
+		line = name + ":: " + base64.StdEncoding.EncodeToString([]byte(value))
+	}
+	for len(line) > ldifLineWidth {
+		fmt.Fprintln(w, line[:ldifLineWidth])
+		line = " " + line[ldifLineWidth:]
+	}
+	fmt.Fprintln(w, line)
+}
+
+func isSafeLdifValue(v string) bool {
+	if v == "" {
+		return true
+	}
+	if v[0] == ' ' || v[0] == ':' || v[0] == '<' || v[len(v)-1] == ' ' {
+		return false
+	}
+	for i := 0; i < len(v); i++ {
+		if v[i] == 0 || v[i] == '\n' || v[i] == '\r' || v[i] > 127 {
+			return false
+		}
+	}
+	return true
+}
+
+// parseDN splits a DN in its components, handling escaped characters.
+func parseDN(dn string) ([]rdn, error) {
+	var res []rdn
+	var buf strings.Builder
+	var attr string
+	inValue := false
+	push := func() error {
+		if !inValue {
+			if strings.TrimSpace(buf.String()) == "" && len(res) == 0 && attr == "" {
+				return nil
+			}
+			return fmt.Errorf("invalid DN %q", dn)
+		}
+		res = append(res, rdn{attr: strings.TrimSpace(attr), value: strings.TrimSpace(buf.String())})
+		buf.Reset()
+		attr = ""
+		inValue = false
+		return nil
+	}
+	for i := 0; i < len(dn); i++ {
+		ch := dn[i]
+		switch {
+		case ch == '\\' && i+1 < len(dn):
+			if i+2 < len(dn) && isHex(dn[i+1]) && isHex(dn[i+2]) {
+				var b byte
+				fmt.Sscanf(dn[i+1:i+3], "%02x", &b)
+				buf.WriteByte(b)
+				i += 2
+			} else {
+				buf.WriteByte(dn[i+1])
+				i++
+			}
+		case ch == '=' && !inValue:
+			attr = buf.String()
+			buf.Reset()
+			inValue = true
+		case ch == ',' || ch == ';':
+			if e := push(); e != nil {
+				return nil, e
+			}
+		default:
+			buf.WriteByte(ch)
+		}
+	}
+	if e := push(); e != nil {
+		return nil, e
+	}
+	return res, nil
+}
+
+func isHex(b byte) bool {
+	return (b >= '0' && b <= '9') || (b >= 'a' && b <= 'f') || (b >= 'A' && b <= 'F')
+}
+
+func escapeDNValue(v string) string {
+	var b strings.Builder
+	for i := 0; i < len(v); i++ {
+		ch := v[i]
+		if strings.IndexByte(",+\"\\<>;=", ch) >= 0 || (i == 0 && (ch == ' ' || ch == '#')) || (i == len(v)-1 && ch == ' ') {
+			b.WriteByte('\\')
+		}
+		b.WriteByte(ch)
+	}
+	return b.String()
+}
+
+// trimBaseDN removes the base DN components from the end of the DN, if present.
+func trimBaseDN(rdns, base []rdn) []rdn {
+	if len(base) == 0 || len(rdns) <= len(base) {
+		return rdns
+	}
+	offset := len(rdns) - len(base)
+	for i, b := range base {
+		r := rdns[offset+i]
+		if !strings.EqualFold(r.attr, b.attr) || !strings.EqualFold(r.value, b.value) {
+			return rdns
+		}
+	}
+	return rdns[:offset]
+}
+
+// groupPathFromDN builds a group path from the ou components of a DN, the top-most being the last one.
+func groupPathFromDN(rdns []rdn) string {
+	var parts []string
+	for _, r := range rdns {
+		if strings.EqualFold(r.attr, "ou") {
+			parts = append([]string{r.value}, parts...)
+		}
+	}
+	return "/" + strings.Join(parts, "/")
+}
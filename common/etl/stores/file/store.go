@@ -0,0 +1,379 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+// Package file provides ETL stores reading and writing users, groups and roles from flat files (CSV and LDIF).
+package file
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/pydio/cells/common/config/source"
+	"github.com/pydio/cells/common/etl/models"
+	"github.com/pydio/cells/common/etl/stores"
+	"github.com/pydio/cells/common/proto/idm"
+	"github.com/pydio/cells/common/proto/tree"
+)
+
+func init() {
+	stores.RegisterStore("csv", func(options *stores.Options) (interface{}, error) {
+		return loadStore(&csvCodec{}, options)
+	})
+	stores.RegisterStore("ldif", func(options *stores.Options) (interface{}, error) {
+		baseDN := defaultBaseDN
+		if b, ok := options.Params["baseDN"]; ok && b != "" {
+			baseDN = b
+		}
+		return loadStore(&ldifCodec{baseDN: baseDN}, options)
+	})
+}
+
+// codec reads and writes the content of a Store in a given file format.
+type codec interface {
+	Decode(r io.Reader) (*Content, error)
+	Encode(w io.Writer, c *Content) error
+}
+
+// Content is the list of objects found in a file. Users GroupPath is the path of their parent group,
+// whereas Groups GroupPath is their full path.
+type Content struct {
+	Users  []*idm.User
+	Groups []*idm.User
+	Roles  []*idm.Role
+}
+
+// Store keeps users, groups and roles in memory. It is loaded from a file and
+// written back to this file on Close if it was modified.
+type Store struct {
+	filePath       string
+	codec          codec
+	origin         string
+	passwordHashed bool
+
+	users  map[string]*idm.User
+	groups map[string]*idm.User
+	roles  map[string]*idm.Role
+	dirty  bool
+}
+
+// loadStore parses the store parameters, sets the merge options and reads the file if it exists.
+func loadStore(c codec, options *stores.Options) (*Store, error) {
+	filePath, ok := options.Params["path"]
+	if !ok || filePath == "" {
+		return nil, fmt.Errorf("missing path parameter")
+	}
+	s := NewStore(filePath, c)
+	if o, ok := options.Params["origin"]; ok && o != "" {
+		s.origin = o
+		options.MergeOptions.Origin = o
+		options.MergeOptions.AuthSource = o
+	}
+	s.passwordHashed = options.Params["passwordHashed"] == "true"
+	syncType, e := ParseSyncType(options.Params["syncType"])
+	if e != nil {
+		return nil, e
+	}
+	options.MergeOptions.SyncType = syncType
+
+	f, e := os.Open(filePath)
+	if os.IsNotExist(e) {
+		// File will be created on Close
+		return s, nil
+	} else if e != nil {
+		return nil, e
+	}
+	defer f.Close()
+	content, e := c.Decode(f)
+	if e != nil {
+		return nil, fmt.Errorf("cannot read %s: %v", filePath, e)
+	}
+	s.load(content)
+	return s, nil
+}
+
+// ParseSyncType reads a sync type from its string value. File stores never delete
+// users by default, as a file usually only contains a subset of the users.
+func ParseSyncType(s string) (models.MergeSyncType, error) {
+	switch s {
+	case "", "no-delete":
+		return models.NODELETESYNC, nil
+	case "create-only":
+		return models.CREATEONLYSYNC, nil
+	case "full":
+		return models.FULLSYNC, nil
+	}
+	return models.NODELETESYNC, fmt.Errorf("unknown sync type %s, use one of full, no-delete, create-only", s)
+}
+
+// NewStore creates an empty store bound to a file.
+func NewStore(filePath string, c codec) *Store {
+	return &Store{
+		filePath: filePath,
+		codec:    c,
+		users:    make(map[string]*idm.User),
+		groups:   make(map[string]*idm.User),
+		roles:    make(map[string]*idm.Role),
+	}
+}
+
+func (s *Store) load(c *Content) {
+	for _, r := range c.Roles {
+		if r.Label == "" {
+			r.Label = r.Uuid
+		}
+		s.roles[r.Uuid] = r
+	}
+	for _, g := range c.Groups {
+		g.IsGroup = true
+		g.GroupPath = cleanGroupPath(g.GroupPath)
+		if g.GroupLabel == "" {
+			g.GroupLabel = path.Base(g.GroupPath)
+		}
+		s.groups[g.GroupPath] = g
+	}
+	for _, u := range c.Users {
+		u.GroupPath = cleanGroupPath(u.GroupPath)
+		if u.Attributes == nil {
+			u.Attributes = make(map[string]string)
+		}
+		if s.origin != "" {
+			u.Attributes[idm.UserAttrOrigin] = s.origin
+			u.Attributes[idm.UserAttrAuthSource] = s.origin
+		}
+		if u.Password != "" {
+			u.Attributes[idm.UserAttrPassHashed] = fmt.Sprintf("%v", s.passwordHashed)
+		}
+		// Use role definitions found in the file, to keep their labels
+		for i, r := range u.Roles {
+			if def, ok := s.roles[r.Uuid]; ok {
+				u.Roles[i] = def
+			} else if r.Label == "" {
+				r.Label = r.Uuid
+			}
+		}
+		s.users[u.Login] = u
+		s.ensureGroups(u.GroupPath)
+	}
+}
+
+// Content returns the objects of the store, sorted for a stable output.
+func (s *Store) Content() *Content {
+	c := &Content{}
+	for _, u := range s.users {
+		c.Users = append(c.Users, u)
+	}
+	for _, g := range s.groups {
+		c.Groups = append(c.Groups, g)
+	}
+	for _, r := range s.roles {
+		c.Roles = append(c.Roles, r)
+	}
+	sort.Slice(c.Users, func(i, j int) bool {
+		return c.Users[i].GroupPath+"/"+c.Users[i].Login < c.Users[j].GroupPath+"/"+c.Users[j].Login
+	})
+	sort.Slice(c.Groups, func(i, j int) bool { return c.Groups[i].GroupPath < c.Groups[j].GroupPath })
+	sort.Slice(c.Roles, func(i, j int) bool { return c.Roles[i].Uuid < c.Roles[j].Uuid })
+	return c
+}
+
+// Close writes the file if the store was modified.
+func (s *Store) Close() error {
+	if !s.dirty {
+		return nil
+	}
+	f, e := os.OpenFile(s.filePath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if e != nil {
+		return e
+	}
+	if e := s.codec.Encode(f, s.Content()); e != nil {
+		f.Close()
+		return e
+	}
+	s.dirty = false
+	return f.Close()
+}
+
+func (s *Store) ListConfig(ctx context.Context, params map[string]interface{}) (*source.ChangeSet, error) {
+	return &source.ChangeSet{}, nil
+}
+
+func (s *Store) ListUsers(ctx context.Context, params map[string]interface{}, progress chan float32) (map[string]*idm.User, error) {
+	res := make(map[string]*idm.User, len(s.users))
+	var crt float32
+	for k, u := range s.users {
+		res[k] = u
+		crt++
+		if progress != nil {
+			progress <- crt / float32(len(s.users))
+		}
+	}
+	return res, nil
+}
+
+func (s *Store) ListGroups(ctx context.Context, params map[string]interface{}) ([]*idm.User, error) {
+	return s.Content().Groups, nil
+}
+
+func (s *Store) ListRoles(ctx context.Context, userStore models.ReadableStore, params map[string]interface{}) ([]*idm.Role, error) {
+	if v, o := params["teams"]; o && v.(bool) {
+		return []*idm.Role{}, nil
+	}
+	return s.Content().Roles, nil
+}
+
+func (s *Store) ListACLs(ctx context.Context, params map[string]interface{}) ([]*idm.ACL, error) {
+	return []*idm.ACL{}, nil
+}
+
+func (s *Store) ListShares(ctx context.Context, params map[string]interface{}) ([]*models.SyncShare, error) {
+	return []*models.SyncShare{}, nil
+}
+
+func (s *Store) CrossLoadShare(ctx context.Context, syncShare *models.SyncShare, target models.ReadableStore, params map[string]interface{}) error {
+	return fmt.Errorf("shares are not supported by file stores")
+}
+
+func (s *Store) GetUserInfo(ctx context.Context, userName string, params map[string]interface{}) (*idm.User, context.Context, error) {
+	if u, ok := s.users[userName]; ok {
+		return u, ctx, nil
+	}
+	return nil, ctx, fmt.Errorf("cannot find user %s", userName)
+}
+
+func (s *Store) GetGroupInfo(ctx context.Context, groupPath string, params map[string]interface{}) (*idm.User, error) {
+	if g, ok := s.groups[cleanGroupPath(groupPath)]; ok {
+		return g, nil
+	}
+	return nil, fmt.Errorf("cannot find group %s", groupPath)
+}
+
+func (s *Store) ReadNode(ctx context.Context, wsUuid string, wsPath string) (*tree.Node, error) {
+	return nil, fmt.Errorf("nodes are not supported by file stores")
+}
+
+func (s *Store) CreateUser(ctx context.Context, u *idm.User) (*idm.User, error) {
+	return s.putUser(u), nil
+}
+
+func (s *Store) UpdateUser(ctx context.Context, u *idm.User) (*idm.User, error) {
+	return s.putUser(u), nil
+}
+
+// putUser stores a copy of the user public data, with its parent groups and the roles that are not
+// automatically attached to it.
+func (s *Store) putUser(u *idm.User) *idm.User {
+	clone := &idm.User{
+		Uuid:       u.Uuid,
+		Login:      u.Login,
+		GroupPath:  cleanGroupPath(u.GroupPath),
+		Attributes: make(map[string]string),
+	}
+	for k, v := range u.Attributes {
+		if !strings.HasPrefix(k, idm.UserAttrPrivatePrefix) {
+			clone.Attributes[k] = v
+		}
+	}
+	for _, r := range u.Roles {
+		if r.GroupRole || r.UserRole || r.Uuid == clone.Uuid {
+			continue
+		}
+		clone.Roles = append(clone.Roles, r)
+	}
+	s.users[clone.Login] = clone
+	s.ensureGroups(clone.GroupPath)
+	s.dirty = true
+	return clone
+}
+
+func (s *Store) ensureGroups(groupPath string) {
+	for groupPath != "/" {
+		if _, ok := s.groups[groupPath]; !ok {
+			s.groups[groupPath] = &idm.User{IsGroup: true, GroupPath: groupPath, GroupLabel: path.Base(groupPath)}
+		}
+		groupPath = path.Dir(groupPath)
+	}
+}
+
+func (s *Store) DeleteUser(ctx context.Context, u *idm.User) error {
+	delete(s.users, u.Login)
+	s.dirty = true
+	return nil
+}
+
+// PutGroup stores a group. As for the cells-local store, GroupPath is the path of the parent group.
+func (s *Store) PutGroup(ctx context.Context, g *idm.User) error {
+	full := cleanGroupPath(path.Join(g.GroupPath, g.GroupLabel))
+	s.ensureGroups(path.Dir(full))
+	s.groups[full] = &idm.User{
+		Uuid:       g.Uuid,
+		IsGroup:    true,
+		GroupPath:  full,
+		GroupLabel: g.GroupLabel,
+		Attributes: g.Attributes,
+	}
+	s.dirty = true
+	return nil
+}
+
+func (s *Store) DeleteGroup(ctx context.Context, g *idm.User) error {
+	delete(s.groups, cleanGroupPath(g.GroupPath))
+	s.dirty = true
+	return nil
+}
+
+func (s *Store) PutRole(ctx context.Context, r *idm.Role) (*idm.Role, error) {
+	if r.UserRole || r.GroupRole {
+		return r, nil
+	}
+	s.roles[r.Uuid] = &idm.Role{Uuid: r.Uuid, Label: r.Label, IsTeam: r.IsTeam}
+	s.dirty = true
+	return r, nil
+}
+
+func (s *Store) DeleteRole(ctx context.Context, r *idm.Role) error {
+	delete(s.roles, r.Uuid)
+	s.dirty = true
+	return nil
+}
+
+func (s *Store) PutACL(ctx context.Context, acl *idm.ACL) error {
+	return fmt.Errorf("acls are not supported by file stores")
+}
+
+func (s *Store) DeleteACL(ctx context.Context, acl *idm.ACL) error {
+	return fmt.Errorf("acls are not supported by file stores")
+}
+
+func (s *Store) PutConfig(ctx context.Context, changeset *source.ChangeSet) error {
+	return fmt.Errorf("configs are not supported by file stores")
+}
+
+func (s *Store) PutShare(ctx context.Context, share *models.SyncShare) error {
+	return fmt.Errorf("shares are not supported by file stores")
+}
+
+func cleanGroupPath(p string) string {
+	return "/" + strings.Trim(path.Clean("/"+p), "/")
+}
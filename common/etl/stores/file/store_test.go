@@ -0,0 +1,208 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package file
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pydio/cells/common/etl/models"
+	"github.com/pydio/cells/common/etl/stores"
+	"github.com/pydio/cells/common/proto/idm"
+	"github.com/pydio/cells/common/proto/jobs"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+const testCsv = `type,login,uuid,groupPath,label,password,roles,profile,displayName,email,department
+role,,ADMINS,,Administrators,,,,,,
+group,,,/sales/emea,Europe,,,,,,
+user,john,,/sales/emea,,secret,ADMINS;EXTERNAL,standard,John Doe,john@example.com,Sales
+,jane,,/,,,,admin,,jane@example.com,
+`
+
+func TestCsvCodec(t *testing.T) {
+	Convey("Test CSV decoding", t, func() {
+		c, e := (&csvCodec{}).Decode(strings.NewReader(testCsv))
+		So(e, ShouldBeNil)
+		So(c.Roles, ShouldHaveLength, 1)
+		So(c.Roles[0].Label, ShouldEqual, "Administrators")
+		So(c.Groups, ShouldHaveLength, 1)
+		So(c.Groups[0].GroupLabel, ShouldEqual, "Europe")
+		So(c.Users, ShouldHaveLength, 2)
+		john := c.Users[0]
+		So(john.Login, ShouldEqual, "john")
+		So(john.Password, ShouldEqual, "secret")
+		So(john.Roles, ShouldHaveLength, 2)
+		So(john.Attributes[idm.UserAttrDisplayName], ShouldEqual, "John Doe")
+		So(john.Attributes["department"], ShouldEqual, "Sales")
+		So(c.Users[1].Attributes, ShouldNotContainKey, "department")
+
+		_, e = (&csvCodec{}).Decode(strings.NewReader("type,login\nuser,\n"))
+		So(e, ShouldNotBeNil)
+		_, e = (&csvCodec{}).Decode(strings.NewReader("type,login\nworkspace,ws\n"))
+		So(e, ShouldNotBeNil)
+	})
+
+	Convey("Test CSV round trip", t, func() {
+		c, _ := (&csvCodec{}).Decode(strings.NewReader(testCsv))
+		s := NewStore("", &csvCodec{})
+		s.load(c)
+		buf := &bytes.Buffer{}
+		So((&csvCodec{}).Encode(buf, s.Content()), ShouldBeNil)
+		So(buf.String(), ShouldStartWith, "type,login,uuid,groupPath,label,password,roles,profile,displayName,email,department\n")
+		// Passwords are never written
+		So(buf.String(), ShouldNotContainSubstring, "secret")
+
+		c2, e := (&csvCodec{}).Decode(buf)
+		So(e, ShouldBeNil)
+		So(c2.Users, ShouldHaveLength, 2)
+		// Parent groups are created from the users paths
+		So(c2.Groups, ShouldHaveLength, 2)
+		So(c2.Groups[0].GroupPath, ShouldEqual, "/sales")
+	})
+}
+
+func TestLdifCodec(t *testing.T) {
+	Convey("Test LDIF decoding", t, func() {
+		input := `version: 1
+# A comment
+dn: cn=ADMINS,dc=example,dc=com
+objectClass: organizationalRole
+cn: ADMINS
+description: Administrators
+
+dn: ou=emea,ou=sales,dc=example,dc=com
+objectClass: organizationalUnit
+ou: emea
+
+dn: uid=john,ou=emea,ou=sales,dc=example,dc=com
+objectClass: inetOrgPerson
+uid: john
+cn:: Sm9obiBEb8OpIA==
+mail: john@example.com
+employeeType: standard
+cellsAttribute: department=Sa
+ les
+memberOf: cn=ADMINS,dc=example,dc=com
+`
+		c, e := (&ldifCodec{baseDN: "dc=example,dc=com"}).Decode(strings.NewReader(input))
+		So(e, ShouldBeNil)
+		So(c.Roles, ShouldHaveLength, 1)
+		So(c.Roles[0].Uuid, ShouldEqual, "ADMINS")
+		So(c.Roles[0].Label, ShouldEqual, "Administrators")
+		So(c.Groups, ShouldHaveLength, 1)
+		So(c.Groups[0].GroupPath, ShouldEqual, "/sales/emea")
+		So(c.Users, ShouldHaveLength, 1)
+		u := c.Users[0]
+		So(u.Login, ShouldEqual, "john")
+		So(u.GroupPath, ShouldEqual, "/sales/emea")
+		So(u.Attributes[idm.UserAttrDisplayName], ShouldEqual, "John Doé ")
+		So(u.Attributes[idm.UserAttrEmail], ShouldEqual, "john@example.com")
+		So(u.Attributes["department"], ShouldEqual, "Sales")
+		So(u.Roles, ShouldHaveLength, 1)
+		So(u.Roles[0].Uuid, ShouldEqual, "ADMINS")
+	})
+
+	Convey("Test LDIF round trip", t, func() {
+		c, _ := (&csvCodec{}).Decode(strings.NewReader(testCsv))
+		s := NewStore("", &csvCodec{})
+		s.load(c)
+		codec := &ldifCodec{baseDN: defaultBaseDN}
+		buf := &bytes.Buffer{}
+		So(codec.Encode(buf, s.Content()), ShouldBeNil)
+		So(buf.String(), ShouldContainSubstring, "dn: uid=john,ou=emea,ou=sales,dc=cells\n")
+
+		c2, e := codec.Decode(buf)
+		So(e, ShouldBeNil)
+		So(c2.Roles, ShouldHaveLength, 1)
+		So(c2.Groups, ShouldHaveLength, 2)
+		So(c2.Users, ShouldHaveLength, 2)
+		So(c2.Users[0].Attributes, ShouldResemble, map[string]string{
+			idm.UserAttrProfile: "admin",
+			idm.UserAttrEmail:   "jane@example.com",
+		})
+		So(c2.Users[1].Attributes["department"], ShouldEqual, "Sales")
+	})
+
+	Convey("Test DN parsing", t, func() {
+		rdns, e := parseDN(`uid=doe\, john,ou=a\2Cb,dc=cells`)
+		So(e, ShouldBeNil)
+		So(rdns, ShouldHaveLength, 3)
+		So(rdns[0].value, ShouldEqual, "doe, john")
+		So(rdns[1].value, ShouldEqual, "a,b")
+		So(escapeDNValue("doe, john"), ShouldEqual, `doe\, john`)
+		_, e = parseDN("invalid,dc=cells")
+		So(e, ShouldNotBeNil)
+	})
+}
+
+func TestStore(t *testing.T) {
+	Convey("Test loading and writing a store", t, func() {
+		dir, _ := ioutil.TempDir("", "etl-file")
+		defer os.RemoveAll(dir)
+		p := filepath.Join(dir, "users.csv")
+		So(ioutil.WriteFile(p, []byte(testCsv), 0600), ShouldBeNil)
+
+		options := stores.CreateOptions(context.Background(), map[string]string{"path": p, "origin": "hr"}, jobs.ActionMessage{})
+		src, e := stores.LoadReadableStore("csv", options)
+		So(e, ShouldBeNil)
+		So(options.MergeOptions.Origin, ShouldEqual, "hr")
+		So(options.MergeOptions.SyncType, ShouldEqual, models.NODELETESYNC)
+		users, _ := src.ListUsers(context.Background(), nil, nil)
+		So(users, ShouldHaveLength, 2)
+		So(users["john"].Attributes[idm.UserAttrOrigin], ShouldEqual, "hr")
+		So(users["john"].Attributes[idm.UserAttrPassHashed], ShouldEqual, "false")
+		So(users["john"].Roles[0].Label, ShouldEqual, "Administrators")
+		So(users["john"].Roles[1].Label, ShouldEqual, "EXTERNAL")
+
+		_, e = stores.LoadReadableStore("csv", stores.CreateOptions(context.Background(), map[string]string{"path": p, "syncType": "wrong"}, jobs.ActionMessage{}))
+		So(e, ShouldNotBeNil)
+
+		out := filepath.Join(dir, "export.ldif")
+		target, e := stores.LoadWritableStore("ldif", stores.CreateOptions(context.Background(), map[string]string{"path": out}, jobs.ActionMessage{}))
+		So(e, ShouldBeNil)
+		_, e = target.CreateUser(context.Background(), &idm.User{
+			Login:      "bob",
+			Uuid:       "bob-uuid",
+			GroupPath:  "/it",
+			Attributes: map[string]string{idm.UserAttrOrigin: "hr", idm.UserAttrProfile: "standard"},
+			Roles: []*idm.Role{
+				{Uuid: "ROOT_GROUP", GroupRole: true},
+				{Uuid: "ADMINS", Label: "Administrators"},
+				{Uuid: "bob-uuid", UserRole: true},
+			},
+		})
+		So(e, ShouldBeNil)
+		So(target.(*Store).Close(), ShouldBeNil)
+
+		data, e := ioutil.ReadFile(out)
+		So(e, ShouldBeNil)
+		So(string(data), ShouldContainSubstring, "dn: ou=it,dc=cells\n")
+		So(string(data), ShouldContainSubstring, "memberOf: cn=ADMINS,dc=cells\n")
+		So(string(data), ShouldNotContainSubstring, "ROOT_GROUP")
+		So(string(data), ShouldNotContainSubstring, idm.UserAttrOrigin)
+	})
+}
@@ -1,6 +1,12 @@
 package broker
 
-import "github.com/micro/go-micro/broker"
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/micro/go-micro/broker"
+)
 
 type brokerwrap struct {
 	b    broker.Broker
@@ -57,3 +63,33 @@ func (b *brokerwrap) Subscribe(s string, h broker.Handler, opts ...broker.Subscr
 func (b *brokerwrap) String() string {
 	return b.b.String()
 }
+
+// CheckHealth delegates to the wrapped broker if it knows its connection state, otherwise it
+// verifies that a message published on a private topic is delivered back.
+func (b *brokerwrap) CheckHealth(ctx context.Context) error {
+	if c, ok := b.b.(interface{ CheckHealth(context.Context) error }); ok {
+		return c.CheckHealth(ctx)
+	}
+	received := make(chan struct{}, 1)
+	topic := "health.ping." + uuid.New().String()
+	sub, err := b.b.Subscribe(topic, func(broker.Publication) error {
+		select {
+		case received <- struct{}{}:
+		default:
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	defer sub.Unsubscribe()
+	if err := b.b.Publish(topic, &broker.Message{Header: map[string]string{}, Body: []byte("ping")}); err != nil {
+		return err
+	}
+	select {
+	case <-received:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("message was not delivered by broker %s at %s", b.b.String(), b.b.Address())
+	}
+}
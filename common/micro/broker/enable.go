@@ -19,6 +19,7 @@ import (
 	bs "github.com/pydio/cells/common/micro/selector/broker"
 	"github.com/pydio/cells/common/micro/transport/codec/proto"
 	"github.com/pydio/cells/common/registry"
+	"github.com/pydio/cells/common/service/health"
 )
 
 // EnableNATS enables the nats broker
@@ -51,6 +52,7 @@ func EnableNATS() {
 	})
 
 	broker.DefaultBroker = b
+	health.Register(health.ProcessScope, "broker", b.(health.HealthChecker))
 
 	// Establishing connectin
 	broker.Connect()
@@ -87,6 +89,7 @@ func EnableSTAN() {
 	})
 
 	broker.DefaultBroker = b
+	health.Register(health.ProcessScope, "broker", b.(health.HealthChecker))
 
 	// Establishing connectin
 	broker.Connect()
@@ -106,6 +106,19 @@ func (n *stanBroker) Address() string {
 	return ""
 }
 
+// CheckHealth reports an error if the underlying NATS connection is not established.
+func (n *stanBroker) CheckHealth(ctx context.Context) error {
+	n.RLock()
+	defer n.RUnlock()
+	if n.conn == nil {
+		return fmt.Errorf("not connected to %s", n.Address())
+	}
+	if nc := n.conn.NatsConn(); nc == nil || !nc.IsConnected() {
+		return fmt.Errorf("connection to %s is lost", n.Address())
+	}
+	return nil
+}
+
 func setAddrs(addrs []string) []string {
 	cAddrs := make([]string, 0, len(addrs))
 	for _, addr := range addrs {
@@ -34,6 +34,7 @@ import (
 	grpcserver "github.com/pydio/cells/common/micro/server/grpc"
 	httpserver "github.com/pydio/cells/common/micro/server/http"
 	servicecontext "github.com/pydio/cells/common/service/context"
+	"github.com/pydio/cells/common/service/tracing"
 )
 
 var (
@@ -67,6 +68,7 @@ func newClient(new ...client.Option) client.Client {
 	opts = append(opts, client.RequestTimeout(10*time.Minute))
 	opts = append(opts, client.Wrap(NetworkClientWrapper))
 	opts = append(opts, client.Wrap(servicecontext.SpanClientWrapper))
+	opts = append(opts, client.Wrap(tracing.ClientWrapper))
 
 	for _, o := range clientOpts {
 		opts = append(opts, o())
@@ -90,7 +92,10 @@ func NewClient(new ...client.Option) client.Client {
 
 // NewServer returns a server attached to the defaults
 func NewServer(new ...server.Option) server.Server {
-	opts := new
+	opts := append([]server.Option{
+		server.WrapHandler(tracing.HandlerWrapper),
+		server.WrapSubscriber(tracing.SubscriberWrapper),
+	}, new...)
 	for _, o := range serverOpts {
 		opts = append(opts, o())
 	}
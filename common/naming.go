@@ -67,6 +67,8 @@ const (
 	ServicePolicy    = "policy"
 	ServiceGraph     = "graph"
 	ServiceUserMeta  = "user-meta"
+	ServiceScim      = "scim"
+	ServiceReview    = "access-review"
 
 	ServiceUserKey   = "user-key"
 	ServiceTree      = "tree"
@@ -111,6 +113,7 @@ const (
 	ServiceGatewayGrpcClear = ServiceGatewayNamespace_ + "grpc.clear"
 	ServiceGatewayDav       = ServiceGatewayNamespace_ + "dav"
 	ServiceGatewayWopi      = ServiceGatewayNamespace_ + "wopi"
+	ServiceGatewayTus       = ServiceGatewayNamespace_ + "tus"
 	ServiceMicroApi         = ServiceGatewayNamespace_ + "rest"
 )
 
@@ -119,6 +122,7 @@ const (
 	TopicServiceRegistration = "topic.pydio.service.registration"
 	TopicProxyRestarted      = "topic.pydio.proxy.restarted"
 	TopicServiceStop         = "topic.pydio.service.stop" // @todo This is used in "stop" command but probably out-of-date
+	TopicClusterUpgrade      = "topic.pydio.cluster.upgrade"
 
 	EventTypeServiceRegistered        = "registered"
 	EventTypeServiceUnregistered      = "unregistered"
@@ -147,6 +151,10 @@ const (
 	MetaNamespaceDatasourceInternal  = "pydio:meta-data-source-internal"
 	MetaNamespaceNodeTestLocalFolder = "pydio:test:local-folder-storage"
 	MetaNamespaceRecycleRestore      = "pydio:recycle_restore"
+	MetaNamespaceOwner               = "pydio:meta-owner"
+	MetaNamespaceContentHash         = "pydio:meta-content-hash"
+	MetaNamespaceContentRefs         = "pydio:meta-content-refs"
+	MetaNamespaceAntivirus           = "pydio:meta-antivirus"
 	MetaNamespaceNodeName            = "name"
 	MetaNamespaceMime                = "mime"
 	RecycleBinName                   = "recycle_bin"
@@ -218,6 +226,9 @@ const (
 	DocStoreIdVersioningPolicies = "versioningPolicies"
 	DocStoreIdShares             = "share"
 	DocStoreIdResetPassKeys      = "resetPasswordKeys"
+	DocStoreIdAccessReviews      = "accessReviews"
+	DocStoreIdLoginProfiles      = "loginProfiles"
+	DocStoreIdLoginAttempts      = "loginAttempts"
 )
 
 // Define constants for Loggging configuration
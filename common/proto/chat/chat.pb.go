@@ -348,6 +348,8 @@ type ListMessagesRequest struct {
 	LastMessage string `protobuf:"bytes,2,opt,name=LastMessage" json:"LastMessage,omitempty"`
 	Offset      int64  `protobuf:"varint,3,opt,name=Offset" json:"Offset,omitempty"`
 	Limit       int64  `protobuf:"varint,4,opt,name=Limit" json:"Limit,omitempty"`
+	// Only return messages posted by this user. If RoomUuid is empty, all rooms are searched
+	ByAuthor string `protobuf:"bytes,5,opt,name=ByAuthor" json:"ByAuthor,omitempty"`
 }
 
 func (m *ListMessagesRequest) Reset()                    { *m = ListMessagesRequest{} }
@@ -383,6 +385,13 @@ func (m *ListMessagesRequest) GetLimit() int64 {
 	return 0
 }
 
+func (m *ListMessagesRequest) GetByAuthor() string {
+	if m != nil {
+		return m.ByAuthor
+	}
+	return ""
+}
+
 type ListMessagesResponse struct {
 	Message *ChatMessage `protobuf:"bytes,1,opt,name=Message" json:"Message,omitempty"`
 }
@@ -559,58 +568,59 @@ func init() {
 func init() { proto.RegisterFile("chat.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 847 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x56, 0xdd, 0x8e, 0xdb, 0x44,
-	0x14, 0xae, 0xe3, 0x24, 0x9b, 0x1c, 0xb3, 0xa9, 0x77, 0x76, 0xdb, 0xba, 0x06, 0xa1, 0xc8, 0x17,
-	0x55, 0xd4, 0x42, 0x02, 0xe1, 0xa7, 0xe2, 0x06, 0xc8, 0x26, 0xd6, 0xb6, 0xe0, 0xad, 0xa3, 0x71,
-	0xc2, 0x0a, 0x2e, 0xa8, 0x1c, 0x67, 0xda, 0x18, 0xd6, 0xeb, 0x90, 0x99, 0x44, 0xca, 0x25, 0x0f,
-	0xc0, 0x23, 0xf0, 0x24, 0xdc, 0xf0, 0x68, 0x68, 0x7e, 0xec, 0x38, 0x3f, 0xb0, 0x5d, 0x71, 0xe7,
-	0xf3, 0xf7, 0xcd, 0x77, 0xce, 0x7c, 0x73, 0x12, 0x80, 0x68, 0x16, 0xb2, 0xf6, 0x7c, 0x91, 0xb2,
-	0x14, 0x95, 0xf9, 0xb7, 0x3d, 0x78, 0x1b, 0xb3, 0xd9, 0x72, 0xd2, 0x8e, 0xd2, 0xa4, 0x33, 0x5f,
-	0x4f, 0xe3, 0xb4, 0x43, 0xc9, 0x62, 0x15, 0x47, 0x84, 0x76, 0xa2, 0x34, 0x49, 0xd2, 0x9b, 0x8e,
-	0xc8, 0xee, 0x84, 0x11, 0x8b, 0x57, 0x31, 0x5b, 0xe7, 0x1f, 0x94, 0x2d, 0x48, 0x98, 0x48, 0x2c,
-	0xe7, 0x6f, 0x0d, 0x6a, 0xfd, 0x59, 0xc8, 0x70, 0x9a, 0x26, 0x08, 0x41, 0x79, 0xbc, 0x8c, 0xa7,
-	0x96, 0xd6, 0xd4, 0x5a, 0x75, 0x2c, 0xbe, 0x91, 0x03, 0xe5, 0xd1, 0x7a, 0x4e, 0xac, 0x52, 0x53,
-	0x6b, 0x35, 0xba, 0x8d, 0xb6, 0xe0, 0xc1, 0xb3, 0xb9, 0x17, 0x8b, 0x18, 0x7a, 0x02, 0x8d, 0xcc,
-	0xe3, 0x4f, 0x7e, 0x21, 0x11, 0xb3, 0x74, 0x81, 0xb0, 0xe3, 0x45, 0x1f, 0x40, 0x9d, 0x7b, 0xbc,
-	0x70, 0x42, 0xae, 0xad, 0xb2, 0x48, 0xd9, 0x38, 0xd0, 0x19, 0x54, 0xc6, 0x94, 0x2c, 0xa8, 0x55,
-	0x69, 0xea, 0xad, 0x3a, 0x96, 0x06, 0x6a, 0x82, 0xe1, 0x85, 0x94, 0x8d, 0xe7, 0xd3, 0x90, 0x91,
-	0xa9, 0x55, 0x6d, 0x6a, 0xad, 0x0a, 0x2e, 0xba, 0x9c, 0xbf, 0x34, 0x30, 0x78, 0x0b, 0x97, 0x84,
-	0xd2, 0xf0, 0x2d, 0x39, 0xd8, 0x85, 0x0d, 0x35, 0x7e, 0x90, 0xf0, 0x97, 0x84, 0x3f, 0xb7, 0x91,
-	0x05, 0x47, 0xaa, 0x54, 0xd1, 0xce, 0x4c, 0xf4, 0x10, 0xaa, 0xbd, 0x25, 0x9b, 0xa5, 0x0b, 0x45,
-	0x56, 0x59, 0xbc, 0x8f, 0x51, 0x9c, 0x10, 0xca, 0xc2, 0x64, 0x6e, 0x55, 0x9a, 0x5a, 0x4b, 0xc7,
-	0x1b, 0x07, 0xfa, 0x08, 0x6a, 0x3d, 0x35, 0x6a, 0x41, 0xd7, 0xe8, 0x9a, 0xed, 0x6c, 0xf6, 0x6d,
-	0x39, 0x09, 0x9c, 0x67, 0x38, 0x9f, 0x43, 0x63, 0xb8, 0x14, 0xe3, 0xc7, 0xe4, 0xb7, 0x25, 0xa1,
-	0x8c, 0x4f, 0x9c, 0x9b, 0x82, 0xbf, 0x91, 0x4d, 0x3c, 0xbb, 0x23, 0x2c, 0x62, 0xce, 0x17, 0x70,
-	0x3f, 0xaf, 0xa2, 0xf3, 0xf4, 0x86, 0x92, 0x77, 0x2a, 0xeb, 0x03, 0x1a, 0xa6, 0x34, 0x9b, 0x54,
-	0x76, 0xe0, 0xc7, 0x50, 0x53, 0x1e, 0x6a, 0x69, 0x4d, 0xbd, 0x65, 0x74, 0x4f, 0x36, 0xd5, 0x59,
-	0x6e, 0x9e, 0xe2, 0xfc, 0x0c, 0xa7, 0x5b, 0x20, 0xea, 0x7c, 0x0b, 0x8e, 0x82, 0x65, 0x14, 0x11,
-	0x4a, 0x05, 0x85, 0x1a, 0xce, 0xcc, 0x2d, 0xfc, 0xd2, 0xed, 0xf8, 0x2e, 0x9c, 0x0d, 0xc8, 0x35,
-	0x61, 0xe4, 0xff, 0xd1, 0xfc, 0x14, 0x1e, 0xec, 0xc0, 0xdc, 0x46, 0xd4, 0xf9, 0x5d, 0x83, 0x53,
-	0x2f, 0xce, 0x5b, 0xa3, 0xd9, 0xc9, 0x45, 0xf5, 0x68, 0x3b, 0xea, 0x51, 0xfa, 0xcc, 0x14, 0x24,
-	0xc5, 0x55, 0x74, 0x71, 0x15, 0xf9, 0x6f, 0xde, 0x50, 0x22, 0x5f, 0x85, 0x8e, 0x95, 0xc5, 0xf5,
-	0xee, 0xc5, 0x49, 0xcc, 0x84, 0xb8, 0x74, 0x2c, 0x0d, 0xa7, 0x0f, 0x67, 0xdb, 0x14, 0x14, 0xeb,
-	0x67, 0x1b, 0x95, 0xca, 0x1b, 0x3e, 0xd0, 0x7c, 0x96, 0xe1, 0xfc, 0x04, 0x26, 0x07, 0xe1, 0x24,
-	0xf3, 0x26, 0x9e, 0x40, 0xf5, 0x7c, 0x2d, 0x9e, 0xb2, 0x76, 0xf0, 0x29, 0xab, 0x28, 0xfa, 0x10,
-	0xa0, 0xf0, 0x90, 0x65, 0x3f, 0x05, 0x8f, 0xf3, 0x1c, 0x4e, 0x0a, 0xd8, 0x77, 0x10, 0xdf, 0x73,
-	0x38, 0x91, 0x17, 0x72, 0x57, 0xb1, 0xb7, 0x01, 0x15, 0x0b, 0x6f, 0xbd, 0xc6, 0x15, 0xd4, 0x39,
-	0x82, 0xbb, 0x22, 0x37, 0xec, 0x4e, 0x73, 0xcb, 0xd9, 0x94, 0xfe, 0x9d, 0x0d, 0x3f, 0x77, 0x40,
-	0x58, 0x18, 0x5f, 0xd3, 0x6c, 0x5d, 0x28, 0xd3, 0xf9, 0x43, 0x03, 0xf3, 0x8a, 0x4c, 0x82, 0x34,
-	0xfa, 0x95, 0xe4, 0xb7, 0xdf, 0x52, 0xfb, 0x53, 0x0e, 0xfd, 0x54, 0x42, 0x5e, 0x51, 0x15, 0x16,
-	0x93, 0xaf, 0x7c, 0xcb, 0xf8, 0xe0, 0xdf, 0xe5, 0xf0, 0x67, 0xdb, 0xbb, 0xea, 0x3f, 0xbb, 0x79,
-	0xfa, 0x95, 0x94, 0xad, 0xb8, 0x55, 0x80, 0xea, 0x85, 0xe7, 0x9f, 0xf7, 0x3c, 0xf3, 0x1e, 0x3a,
-	0x86, 0xfa, 0x95, 0x8f, 0xbf, 0x0f, 0x86, 0xbd, 0xbe, 0x6b, 0x6a, 0xa8, 0x06, 0xe5, 0x71, 0xe0,
-	0x62, 0xb3, 0xc4, 0xbf, 0x5e, 0xf9, 0x03, 0xd7, 0xd4, 0x9f, 0x26, 0x70, 0xbc, 0xc5, 0x91, 0x87,
-	0xbe, 0xf3, 0x5f, 0xbe, 0x32, 0xef, 0xa1, 0x3a, 0x54, 0x3c, 0xb7, 0xf7, 0x83, 0xaa, 0x1c, 0xfa,
-	0xc1, 0xc8, 0x2c, 0xa1, 0xfb, 0x60, 0x60, 0xdf, 0xbf, 0x7c, 0x3d, 0x1e, 0x0e, 0x7a, 0x23, 0xd7,
-	0xd4, 0x91, 0x01, 0x47, 0x2f, 0x5e, 0x06, 0x23, 0x1f, 0xff, 0x68, 0x96, 0x51, 0x03, 0x60, 0xe0,
-	0x7a, 0xee, 0xc8, 0x7d, 0x7d, 0x19, 0x5c, 0x98, 0x15, 0x9e, 0xad, 0x6c, 0x5e, 0x64, 0x56, 0xbb,
-	0x7f, 0xea, 0x72, 0x85, 0x07, 0xf2, 0x17, 0x0c, 0x7d, 0x09, 0x47, 0x6a, 0xbd, 0xa1, 0x33, 0xd9,
-	0xe0, 0xf6, 0x8e, 0xb4, 0x1f, 0xec, 0x78, 0x95, 0x26, 0xbe, 0x01, 0xd8, 0x28, 0x05, 0x3d, 0x92,
-	0x49, 0x7b, 0xa2, 0xb3, 0xad, 0xfd, 0x80, 0x02, 0xf8, 0x1a, 0xea, 0xb9, 0xb8, 0xd1, 0x43, 0x99,
-	0xb6, 0xfb, 0x92, 0xec, 0x47, 0x7b, 0x7e, 0x59, 0xfd, 0x89, 0x86, 0x2e, 0xe0, 0xbd, 0xe2, 0xeb,
-	0x45, 0x8f, 0x37, 0xa9, 0x3b, 0x4b, 0xc5, 0xb6, 0x0f, 0x85, 0x72, 0xa0, 0x73, 0x30, 0x0a, 0x4b,
-	0x16, 0x29, 0xc6, 0xfb, 0xcb, 0xdb, 0x7e, 0x7c, 0x20, 0xa2, 0x9a, 0x79, 0x01, 0xc7, 0x5b, 0x1b,
-	0x10, 0xd9, 0xc5, 0xbe, 0x77, 0x70, 0xde, 0x3f, 0x18, 0x93, 0x48, 0x93, 0xaa, 0xf8, 0xb3, 0xf0,
-	0xd9, 0x3f, 0x01, 0x00, 0x00, 0xff, 0xff, 0x96, 0x6a, 0xe1, 0x85, 0x86, 0x08, 0x00, 0x00,
+	// 852 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x56, 0x5b, 0x73, 0xdb, 0x44,
+	0x14, 0xae, 0x2c, 0xcb, 0xb1, 0x8f, 0x88, 0xab, 0x6c, 0xd2, 0x56, 0x15, 0x0c, 0xe3, 0xd1, 0x43,
+	0xc7, 0xd3, 0x82, 0x0d, 0xe1, 0xd2, 0xe1, 0x05, 0x70, 0x62, 0x4d, 0x5a, 0x50, 0x2a, 0xcf, 0xca,
+	0x26, 0x03, 0x0f, 0x74, 0x64, 0x65, 0x5b, 0x0b, 0xac, 0xc8, 0x78, 0xd7, 0x9e, 0xf1, 0x8f, 0xe0,
+	0x27, 0xf4, 0x97, 0xf0, 0xc2, 0x4f, 0x63, 0xf6, 0x22, 0x59, 0xbe, 0x40, 0x9a, 0xe1, 0x4d, 0xe7,
+	0xf6, 0x9d, 0xcb, 0x7e, 0xe7, 0x8c, 0x00, 0xe2, 0x49, 0xc4, 0x3a, 0xb3, 0x79, 0xc6, 0x32, 0x54,
+	0xe5, 0xdf, 0x4e, 0xef, 0x6d, 0xc2, 0x26, 0x8b, 0x71, 0x27, 0xce, 0xd2, 0xee, 0x6c, 0x75, 0x9d,
+	0x64, 0xdd, 0x98, 0x4c, 0xa7, 0xb4, 0x1b, 0x67, 0x69, 0x9a, 0xdd, 0x74, 0x85, 0x6b, 0x37, 0x8a,
+	0x59, 0xb2, 0x4c, 0xd8, 0xaa, 0xf8, 0xa0, 0x6c, 0x4e, 0xa2, 0x54, 0x02, 0xb9, 0x7f, 0x6b, 0x50,
+	0x3f, 0x9f, 0x44, 0x0c, 0x67, 0x59, 0x8a, 0x10, 0x54, 0x47, 0x8b, 0xe4, 0xda, 0xd6, 0x5a, 0x5a,
+	0xbb, 0x81, 0xc5, 0x37, 0x72, 0xa1, 0x3a, 0x5c, 0xcd, 0x88, 0x5d, 0x69, 0x69, 0xed, 0xe6, 0x69,
+	0xb3, 0x23, 0x8a, 0xe0, 0xde, 0x5c, 0x8b, 0x85, 0x0d, 0x3d, 0x81, 0x66, 0xae, 0x09, 0xc6, 0xbf,
+	0x91, 0x98, 0xd9, 0xba, 0x40, 0xd8, 0xd2, 0xa2, 0x8f, 0xa0, 0xc1, 0x35, 0x7e, 0x34, 0x26, 0x53,
+	0xbb, 0x2a, 0x5c, 0xd6, 0x0a, 0x74, 0x02, 0xc6, 0x88, 0x92, 0x39, 0xb5, 0x8d, 0x96, 0xde, 0x6e,
+	0x60, 0x29, 0xa0, 0x16, 0x98, 0x7e, 0x44, 0xd9, 0x68, 0x76, 0x1d, 0x31, 0x72, 0x6d, 0xd7, 0x5a,
+	0x5a, 0xdb, 0xc0, 0x65, 0x95, 0xfb, 0x97, 0x06, 0x26, 0x6f, 0xe1, 0x92, 0x50, 0x1a, 0xbd, 0x25,
+	0x7b, 0xbb, 0x70, 0xa0, 0xce, 0x13, 0x09, 0x7d, 0x45, 0xe8, 0x0b, 0x19, 0xd9, 0x70, 0xa0, 0x42,
+	0x55, 0xd9, 0xb9, 0x88, 0x1e, 0x42, 0xad, 0xb7, 0x60, 0x93, 0x6c, 0xae, 0x8a, 0x55, 0x12, 0xef,
+	0x63, 0x98, 0xa4, 0x84, 0xb2, 0x28, 0x9d, 0xd9, 0x46, 0x4b, 0x6b, 0xeb, 0x78, 0xad, 0x40, 0x9f,
+	0x40, 0xbd, 0xa7, 0x46, 0x2d, 0xca, 0x35, 0x4f, 0xad, 0x4e, 0x3e, 0xfb, 0x8e, 0x9c, 0x04, 0x2e,
+	0x3c, 0xdc, 0x2f, 0xa1, 0x39, 0x58, 0x88, 0xf1, 0x63, 0xf2, 0xc7, 0x82, 0x50, 0xc6, 0x27, 0xce,
+	0x45, 0x51, 0xbf, 0x99, 0x4f, 0x3c, 0x7f, 0x23, 0x2c, 0x6c, 0xee, 0x57, 0x70, 0xbf, 0x88, 0xa2,
+	0xb3, 0xec, 0x86, 0x92, 0xf7, 0x0a, 0x3b, 0x07, 0x34, 0xc8, 0x68, 0x3e, 0xa9, 0x3c, 0xe1, 0xa7,
+	0x50, 0x57, 0x1a, 0x6a, 0x6b, 0x2d, 0xbd, 0x6d, 0x9e, 0x1e, 0xad, 0xa3, 0x73, 0xdf, 0xc2, 0xc5,
+	0xfd, 0x15, 0x8e, 0x37, 0x40, 0x54, 0x7e, 0x1b, 0x0e, 0xc2, 0x45, 0x1c, 0x13, 0x4a, 0x45, 0x09,
+	0x75, 0x9c, 0x8b, 0x1b, 0xf8, 0x95, 0xdb, 0xf1, 0x3d, 0x38, 0xe9, 0x93, 0x29, 0x61, 0xe4, 0xff,
+	0x95, 0xf9, 0x39, 0x3c, 0xd8, 0x82, 0xb9, 0xad, 0x50, 0xf7, 0x9d, 0x06, 0xc7, 0x7e, 0x52, 0xb4,
+	0x46, 0xf3, 0xcc, 0x65, 0xf6, 0x68, 0x5b, 0xec, 0x51, 0xfc, 0xcc, 0x19, 0x24, 0xc9, 0x55, 0x56,
+	0x71, 0x16, 0x05, 0x6f, 0xde, 0x50, 0x22, 0xb7, 0x42, 0xc7, 0x4a, 0xe2, 0x7c, 0xf7, 0x93, 0x34,
+	0x61, 0x82, 0x5c, 0x3a, 0x96, 0x02, 0xcf, 0x75, 0xb6, 0x52, 0xac, 0x33, 0x64, 0xae, 0x5c, 0x76,
+	0xcf, 0xe1, 0x64, 0xb3, 0x3c, 0xd5, 0xd1, 0xb3, 0x35, 0x83, 0xe5, 0xeb, 0xef, 0x19, 0x4c, 0xee,
+	0xe1, 0xfe, 0x02, 0x16, 0x07, 0xe1, 0x0d, 0x14, 0x0d, 0x3e, 0x81, 0xda, 0xd9, 0x4a, 0xac, 0xb9,
+	0xb6, 0x77, 0xcd, 0x95, 0x15, 0x7d, 0x0c, 0x50, 0x5a, 0x72, 0xd9, 0x6b, 0x49, 0xe3, 0x3e, 0x87,
+	0xa3, 0x12, 0xf6, 0x1d, 0x88, 0xf9, 0x1c, 0x8e, 0xe4, 0x63, 0xdd, 0x75, 0x11, 0x3a, 0x80, 0xca,
+	0x81, 0xb7, 0x3e, 0xf1, 0x12, 0x1a, 0x1c, 0xc1, 0x5b, 0x92, 0x1b, 0x76, 0xa7, 0xb9, 0x15, 0xd5,
+	0x54, 0xfe, 0xbd, 0x1a, 0x9e, 0xb7, 0x4f, 0x58, 0x94, 0x4c, 0x69, 0x7e, 0x4a, 0x94, 0xe8, 0xfe,
+	0xa9, 0x81, 0x75, 0x45, 0xc6, 0x61, 0x16, 0xff, 0x4e, 0x0a, 0x66, 0xb4, 0xd5, 0x6d, 0x95, 0x43,
+	0x3f, 0x96, 0x90, 0x57, 0x54, 0x99, 0xb9, 0x09, 0x1b, 0xdf, 0xb3, 0xd5, 0xec, 0xfd, 0x92, 0x3f,
+	0xdb, 0xbc, 0x63, 0xff, 0xd9, 0xcd, 0xd3, 0x6f, 0x24, 0xa5, 0xc5, 0xab, 0x02, 0xd4, 0x2e, 0xfc,
+	0xe0, 0xac, 0xe7, 0x5b, 0xf7, 0xd0, 0x21, 0x34, 0xae, 0x02, 0xfc, 0x63, 0x38, 0xe8, 0x9d, 0x7b,
+	0x96, 0x86, 0xea, 0x50, 0x1d, 0x85, 0x1e, 0xb6, 0x2a, 0xfc, 0xeb, 0x55, 0xd0, 0xf7, 0x2c, 0xfd,
+	0x69, 0x0a, 0x87, 0x1b, 0x35, 0x72, 0xd3, 0x0f, 0xc1, 0xcb, 0x57, 0xd6, 0x3d, 0xd4, 0x00, 0xc3,
+	0xf7, 0x7a, 0x3f, 0xa9, 0xc8, 0x41, 0x10, 0x0e, 0xad, 0x0a, 0xba, 0x0f, 0x26, 0x0e, 0x82, 0xcb,
+	0xd7, 0xa3, 0x41, 0xbf, 0x37, 0xf4, 0x2c, 0x1d, 0x99, 0x70, 0xf0, 0xe2, 0x65, 0x38, 0x0c, 0xf0,
+	0xcf, 0x56, 0x15, 0x35, 0x01, 0xfa, 0x9e, 0xef, 0x0d, 0xbd, 0xd7, 0x97, 0xe1, 0x85, 0x65, 0x70,
+	0x6f, 0x25, 0xf3, 0x20, 0xab, 0x76, 0xfa, 0x4e, 0x97, 0xe7, 0x3d, 0x24, 0xf3, 0x65, 0x12, 0x13,
+	0xf4, 0x35, 0x1c, 0xa8, 0xd3, 0x87, 0x4e, 0x64, 0x83, 0x9b, 0xf7, 0xd3, 0x79, 0xb0, 0xa5, 0x55,
+	0x9c, 0xf8, 0x0e, 0x60, 0xcd, 0x14, 0xf4, 0x48, 0x3a, 0xed, 0x90, 0xce, 0xb1, 0x77, 0x0d, 0x0a,
+	0xe0, 0x5b, 0x68, 0x14, 0xe4, 0x46, 0x0f, 0xa5, 0xdb, 0xf6, 0x26, 0x39, 0x8f, 0x76, 0xf4, 0x32,
+	0xfa, 0x33, 0x0d, 0x5d, 0xc0, 0x07, 0xe5, 0xed, 0x45, 0x8f, 0xd7, 0xae, 0x5b, 0x07, 0xc7, 0x71,
+	0xf6, 0x99, 0x0a, 0xa0, 0x33, 0x30, 0x4b, 0x07, 0x18, 0xa9, 0x8a, 0x77, 0x0f, 0xbb, 0xf3, 0x78,
+	0x8f, 0x45, 0x35, 0xf3, 0x02, 0x0e, 0x37, 0xae, 0x23, 0x72, 0xca, 0x7d, 0x6f, 0xe1, 0x7c, 0xb8,
+	0xd7, 0x26, 0x91, 0xc6, 0x35, 0xf1, 0x23, 0xf1, 0xc5, 0x3f, 0x03, 0x00, 0xff, 0x13, 0x34, 0xef,
+	0x9f, 0x08, 0x00, 0x00,
 }
@@ -0,0 +1,107 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package idm
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// RoleValidity restricts the link between a user and one of its roles to a time window.
+// Start and End are unix timestamps, a zero value meaning no bound.
+type RoleValidity struct {
+	Start int64 `json:"start,omitempty"`
+	End   int64 `json:"end,omitempty"`
+	// Notified is set once the user has been warned about the upcoming expiration
+	Notified bool `json:"notified,omitempty"`
+}
+
+// IsActive checks if t is inside the validity window.
+func (v *RoleValidity) IsActive(t time.Time) bool {
+	if v == nil {
+		return true
+	}
+	if v.Start > 0 && t.Unix() < v.Start {
+		return false
+	}
+	return !v.IsExpired(t)
+}
+
+// IsExpired checks if the validity window is over at time t.
+func (v *RoleValidity) IsExpired(t time.Time) bool {
+	return v != nil && v.End > 0 && t.Unix() >= v.End
+}
+
+// ParseRolesValidity decodes a JSON object mapping role UUIDs to their validity window.
+func ParseRolesValidity(s string) (map[string]*RoleValidity, error) {
+	m := make(map[string]*RoleValidity)
+	if s == "" {
+		return m, nil
+	}
+	if e := json.Unmarshal([]byte(s), &m); e != nil {
+		return nil, e
+	}
+	return m, nil
+}
+
+// RolesValidity reads the validity windows stored on the user, indexed by role UUID.
+func (u *User) RolesValidity() map[string]*RoleValidity {
+	if u == nil || u.Attributes == nil {
+		return map[string]*RoleValidity{}
+	}
+	m, e := ParseRolesValidity(u.Attributes[UserAttrRolesValidity])
+	if e != nil {
+		return map[string]*RoleValidity{}
+	}
+	return m
+}
+
+// SetRolesValidity stores the validity windows on the user, removing the attribute if there is none.
+func (u *User) SetRolesValidity(m map[string]*RoleValidity) {
+	if len(m) == 0 {
+		if u.Attributes != nil {
+			delete(u.Attributes, UserAttrRolesValidity)
+		}
+		return
+	}
+	if u.Attributes == nil {
+		u.Attributes = make(map[string]string)
+	}
+	data, _ := json.Marshal(m)
+	u.Attributes[UserAttrRolesValidity] = string(data)
+}
+
+// ActiveRoles returns the roles of the user whose link is valid at time t.
+// Group roles and the user personal role are always active.
+func (u *User) ActiveRoles(t time.Time) []*Role {
+	validity := u.RolesValidity()
+	if len(validity) == 0 {
+		return u.Roles
+	}
+	var roles []*Role
+	for _, r := range u.Roles {
+		if !r.GroupRole && !r.UserRole && !validity[r.Uuid].IsActive(t) {
+			continue
+		}
+		roles = append(roles, r)
+	}
+	return roles
+}
@@ -2,7 +2,9 @@ package idm
 
 import (
 	"context"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 )
@@ -12,6 +14,9 @@ const (
 	UserAttrPassHashed    = UserAttrPrivatePrefix + "password_hashed"
 	UserAttrLabelLike     = UserAttrPrivatePrefix + "labelLike"
 	UserAttrOrigin        = UserAttrPrivatePrefix + "origin"
+	UserAttrRolesValidity = UserAttrPrivatePrefix + "rolesValidity"
+	UserAttrDeletionToken = UserAttrPrivatePrefix + "deletionToken"
+	UserAttrDeletionDate  = UserAttrPrivatePrefix + "deletionDate"
 
 	UserAttrDisplayName = "displayName"
 	UserAttrProfile     = "profile"
@@ -20,6 +25,16 @@ const (
 	UserAttrHasEmail    = "hasEmail"
 	UserAttrAuthSource  = "AuthSource"
 	UserAttrHidden      = "hidden"
+
+	UserAttrQuota           = "quota"
+	UserAttrQuotaUsage      = "quota_usage"
+	UserAttrGroupQuota      = "group_quota"
+	UserAttrGroupQuotaUsage = "group_quota_usage"
+
+	// UserAttrRolesValidityPublic exposes the private UserAttrRolesValidity to users that can edit this user
+	UserAttrRolesValidityPublic = "roles_validity"
+	// UserAttrDeletionDatePublic exposes the private UserAttrDeletionDate to users that can edit this user
+	UserAttrDeletionDatePublic = "deletion_date"
 )
 
 func (u *User) WithPublicData(ctx context.Context, policiesContextEditable bool) *User {
@@ -30,6 +45,13 @@ func (u *User) WithPublicData(ctx context.Context, policiesContextEditable bool)
 	}
 	user.PoliciesContextEditable = policiesContextEditable
 
+	if v, ok := user.Attributes[UserAttrRolesValidity]; ok && policiesContextEditable {
+		user.Attributes[UserAttrRolesValidityPublic] = v
+	}
+	if v, ok := user.Attributes[UserAttrDeletionDate]; ok && policiesContextEditable {
+		user.Attributes[UserAttrDeletionDatePublic] = v
+	}
+
 	for k, _ := range user.Attributes {
 		if strings.HasPrefix(k, UserAttrPrivatePrefix) {
 			delete(user.Attributes, k)
@@ -79,3 +101,16 @@ func (u *User) IsHidden() bool {
 	}
 	return false
 }
+
+// ScheduledDeletion returns the date after which a user that requested the deletion of
+// its own account will be removed.
+func (u *User) ScheduledDeletion() (time.Time, bool) {
+	if u == nil || u.Attributes == nil {
+		return time.Time{}, false
+	}
+	ts, e := strconv.ParseInt(u.Attributes[UserAttrDeletionDate], 10, 64)
+	if e != nil || ts <= 0 {
+		return time.Time{}, false
+	}
+	return time.Unix(ts, 0), true
+}
@@ -47,6 +47,13 @@ const (
 	StorageKeyCellsInternal    = "cellsInternal"
 	StorageKeyInitFromBucket   = "initFromBucket"
 	StorageKeyInitFromSnapshot = "initFromSnapshot"
+
+	StorageKeyDedup = "dedup"
+
+	StorageKeyConflictStrategy = "conflictStrategy"
+
+	StorageKeySftpPeer          = "sftpPeer"
+	StorageKeySftpPeerDirection = "sftpPeerDirection"
 )
 
 // Builds the url used for clients
@@ -74,6 +81,15 @@ func (d *DataSource) IsInternal() bool {
 	return false
 }
 
+// IsDeduplicated checks if a flat datasource stores its objects by content hash. It is a short hand for
+// StorageConfiguration["dedup"] key, and is never enabled on encrypted datasources.
+func (d *DataSource) IsDeduplicated() bool {
+	if !d.FlatStorage || d.EncryptionMode != EncryptionMode_CLEAR || d.StorageConfiguration == nil {
+		return false
+	}
+	return d.StorageConfiguration[StorageKeyDedup] == "true"
+}
+
 /* LOGGING SUPPORT */
 // MarshalLogObject implements custom marshalling for datasource, to avoid logging ApiKey
 func (d *DataSource) MarshalLogObject(encoder zapcore.ObjectEncoder) error {
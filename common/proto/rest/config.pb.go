@@ -330,13 +330,168 @@ func (m *ListProcessesResponse) GetProcesses() []*Process {
 	return nil
 }
 
+// Maintenance window scheduled on a datasource
+type DataSourceMaintenance struct {
+	// read-only or offline
+	Mode string `protobuf:"bytes,1,opt,name=Mode" json:"Mode,omitempty"`
+	// Window start (RFC 3339), immediate if empty
+	Start string `protobuf:"bytes,2,opt,name=Start" json:"Start,omitempty"`
+	// Window end (RFC 3339), lasts until removed if empty
+	End string `protobuf:"bytes,3,opt,name=End" json:"End,omitempty"`
+	// Message displayed to users
+	Message string `protobuf:"bytes,4,opt,name=Message" json:"Message,omitempty"`
+}
+
+func (m *DataSourceMaintenance) Reset()                    { *m = DataSourceMaintenance{} }
+func (m *DataSourceMaintenance) String() string            { return proto.CompactTextString(m) }
+func (*DataSourceMaintenance) ProtoMessage()               {}
+func (*DataSourceMaintenance) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{13} }
+
+func (m *DataSourceMaintenance) GetMode() string {
+	if m != nil {
+		return m.Mode
+	}
+	return ""
+}
+
+func (m *DataSourceMaintenance) GetStart() string {
+	if m != nil {
+		return m.Start
+	}
+	return ""
+}
+
+func (m *DataSourceMaintenance) GetEnd() string {
+	if m != nil {
+		return m.End
+	}
+	return ""
+}
+
+func (m *DataSourceMaintenance) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+// Maintenance windows scheduled on a datasource
+type DataSourceMaintenanceCollection struct {
+	DataSource string                   `protobuf:"bytes,1,opt,name=DataSource" json:"DataSource,omitempty"`
+	Windows    []*DataSourceMaintenance `protobuf:"bytes,2,rep,name=Windows" json:"Windows,omitempty"`
+	// Window currently opened, if any
+	Active *DataSourceMaintenance `protobuf:"bytes,3,opt,name=Active" json:"Active,omitempty"`
+}
+
+func (m *DataSourceMaintenanceCollection) Reset()         { *m = DataSourceMaintenanceCollection{} }
+func (m *DataSourceMaintenanceCollection) String() string { return proto.CompactTextString(m) }
+func (*DataSourceMaintenanceCollection) ProtoMessage()    {}
+func (*DataSourceMaintenanceCollection) Descriptor() ([]byte, []int) {
+	return fileDescriptor2, []int{14}
+}
+
+func (m *DataSourceMaintenanceCollection) GetDataSource() string {
+	if m != nil {
+		return m.DataSource
+	}
+	return ""
+}
+
+func (m *DataSourceMaintenanceCollection) GetWindows() []*DataSourceMaintenance {
+	if m != nil {
+		return m.Windows
+	}
+	return nil
+}
+
+func (m *DataSourceMaintenanceCollection) GetActive() *DataSourceMaintenance {
+	if m != nil {
+		return m.Active
+	}
+	return nil
+}
+
+type ListDataSourcesMaintenanceRequest struct {
+}
+
+func (m *ListDataSourcesMaintenanceRequest) Reset()         { *m = ListDataSourcesMaintenanceRequest{} }
+func (m *ListDataSourcesMaintenanceRequest) String() string { return proto.CompactTextString(m) }
+func (*ListDataSourcesMaintenanceRequest) ProtoMessage()    {}
+func (*ListDataSourcesMaintenanceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor2, []int{15}
+}
+
+// Maintenance windows of all datasources having some
+type DataSourcesMaintenanceCollection struct {
+	DataSources []*DataSourceMaintenanceCollection `protobuf:"bytes,1,rep,name=DataSources" json:"DataSources,omitempty"`
+}
+
+func (m *DataSourcesMaintenanceCollection) Reset()         { *m = DataSourcesMaintenanceCollection{} }
+func (m *DataSourcesMaintenanceCollection) String() string { return proto.CompactTextString(m) }
+func (*DataSourcesMaintenanceCollection) ProtoMessage()    {}
+func (*DataSourcesMaintenanceCollection) Descriptor() ([]byte, []int) {
+	return fileDescriptor2, []int{16}
+}
+
+func (m *DataSourcesMaintenanceCollection) GetDataSources() []*DataSourceMaintenanceCollection {
+	if m != nil {
+		return m.DataSources
+	}
+	return nil
+}
+
+type DataSourceMaintenanceRequest struct {
+	// Datasource name
+	Name string `protobuf:"bytes,1,opt,name=Name" json:"Name,omitempty"`
+}
+
+func (m *DataSourceMaintenanceRequest) Reset()                    { *m = DataSourceMaintenanceRequest{} }
+func (m *DataSourceMaintenanceRequest) String() string            { return proto.CompactTextString(m) }
+func (*DataSourceMaintenanceRequest) ProtoMessage()               {}
+func (*DataSourceMaintenanceRequest) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{17} }
+
+func (m *DataSourceMaintenanceRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type PutDataSourceMaintenanceRequest struct {
+	// Datasource name
+	Name string `protobuf:"bytes,1,opt,name=Name" json:"Name,omitempty"`
+	// Windows replacing the current ones
+	Windows []*DataSourceMaintenance `protobuf:"bytes,2,rep,name=Windows" json:"Windows,omitempty"`
+}
+
+func (m *PutDataSourceMaintenanceRequest) Reset()         { *m = PutDataSourceMaintenanceRequest{} }
+func (m *PutDataSourceMaintenanceRequest) String() string { return proto.CompactTextString(m) }
+func (*PutDataSourceMaintenanceRequest) ProtoMessage()    {}
+func (*PutDataSourceMaintenanceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor2, []int{18}
+}
+
+func (m *PutDataSourceMaintenanceRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *PutDataSourceMaintenanceRequest) GetWindows() []*DataSourceMaintenance {
+	if m != nil {
+		return m.Windows
+	}
+	return nil
+}
+
 type ListVersioningPolicyRequest struct {
 }
 
 func (m *ListVersioningPolicyRequest) Reset()                    { *m = ListVersioningPolicyRequest{} }
 func (m *ListVersioningPolicyRequest) String() string            { return proto.CompactTextString(m) }
 func (*ListVersioningPolicyRequest) ProtoMessage()               {}
-func (*ListVersioningPolicyRequest) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{13} }
+func (*ListVersioningPolicyRequest) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{19} }
 
 type VersioningPolicyCollection struct {
 	Policies []*tree.VersioningPolicy `protobuf:"bytes,1,rep,name=Policies" json:"Policies,omitempty"`
@@ -345,7 +500,7 @@ type VersioningPolicyCollection struct {
 func (m *VersioningPolicyCollection) Reset()                    { *m = VersioningPolicyCollection{} }
 func (m *VersioningPolicyCollection) String() string            { return proto.CompactTextString(m) }
 func (*VersioningPolicyCollection) ProtoMessage()               {}
-func (*VersioningPolicyCollection) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{14} }
+func (*VersioningPolicyCollection) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{20} }
 
 func (m *VersioningPolicyCollection) GetPolicies() []*tree.VersioningPolicy {
 	if m != nil {
@@ -354,13 +509,284 @@ func (m *VersioningPolicyCollection) GetPolicies() []*tree.VersioningPolicy {
 	return nil
 }
 
+// Candidate policy to apply on the existing versions
+type VersioningSimulationRequest struct {
+	// Policy identifier
+	Uuid string `protobuf:"bytes,1,opt,name=Uuid" json:"Uuid,omitempty"`
+	// Candidate policy
+	Policy *tree.VersioningPolicy `protobuf:"bytes,2,opt,name=Policy" json:"Policy,omitempty"`
+	// Datasources to scan, defaults to the ones using the policy
+	DataSources []string `protobuf:"bytes,3,rep,name=DataSources" json:"DataSources,omitempty"`
+	// Maximum number of files to scan
+	MaxNodes int32 `protobuf:"varint,4,opt,name=MaxNodes" json:"MaxNodes,omitempty"`
+}
+
+func (m *VersioningSimulationRequest) Reset()                    { *m = VersioningSimulationRequest{} }
+func (m *VersioningSimulationRequest) String() string            { return proto.CompactTextString(m) }
+func (*VersioningSimulationRequest) ProtoMessage()               {}
+func (*VersioningSimulationRequest) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{21} }
+
+func (m *VersioningSimulationRequest) GetUuid() string {
+	if m != nil {
+		return m.Uuid
+	}
+	return ""
+}
+
+func (m *VersioningSimulationRequest) GetPolicy() *tree.VersioningPolicy {
+	if m != nil {
+		return m.Policy
+	}
+	return nil
+}
+
+func (m *VersioningSimulationRequest) GetDataSources() []string {
+	if m != nil {
+		return m.DataSources
+	}
+	return nil
+}
+
+func (m *VersioningSimulationRequest) GetMaxNodes() int32 {
+	if m != nil {
+		return m.MaxNodes
+	}
+	return 0
+}
+
+// Versions that a candidate policy would prune
+type VersioningSimulation struct {
+	ScannedNodes   int32 `protobuf:"varint,1,opt,name=ScannedNodes" json:"ScannedNodes,omitempty"`
+	VersionedNodes int32 `protobuf:"varint,2,opt,name=VersionedNodes" json:"VersionedNodes,omitempty"`
+	TotalVersions  int32 `protobuf:"varint,3,opt,name=TotalVersions" json:"TotalVersions,omitempty"`
+	PrunedVersions int32 `protobuf:"varint,4,opt,name=PrunedVersions" json:"PrunedVersions,omitempty"`
+	PrunedSize     int64 `protobuf:"varint,5,opt,name=PrunedSize" json:"PrunedSize,omitempty"`
+	// True if the scan stopped after MaxNodes files
+	Truncated bool             `protobuf:"varint,6,opt,name=Truncated" json:"Truncated,omitempty"`
+	Nodes     []*SimulatedNode `protobuf:"bytes,7,rep,name=Nodes" json:"Nodes,omitempty"`
+}
+
+func (m *VersioningSimulation) Reset()                    { *m = VersioningSimulation{} }
+func (m *VersioningSimulation) String() string            { return proto.CompactTextString(m) }
+func (*VersioningSimulation) ProtoMessage()               {}
+func (*VersioningSimulation) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{22} }
+
+func (m *VersioningSimulation) GetScannedNodes() int32 {
+	if m != nil {
+		return m.ScannedNodes
+	}
+	return 0
+}
+
+func (m *VersioningSimulation) GetVersionedNodes() int32 {
+	if m != nil {
+		return m.VersionedNodes
+	}
+	return 0
+}
+
+func (m *VersioningSimulation) GetTotalVersions() int32 {
+	if m != nil {
+		return m.TotalVersions
+	}
+	return 0
+}
+
+func (m *VersioningSimulation) GetPrunedVersions() int32 {
+	if m != nil {
+		return m.PrunedVersions
+	}
+	return 0
+}
+
+func (m *VersioningSimulation) GetPrunedSize() int64 {
+	if m != nil {
+		return m.PrunedSize
+	}
+	return 0
+}
+
+func (m *VersioningSimulation) GetTruncated() bool {
+	if m != nil {
+		return m.Truncated
+	}
+	return false
+}
+
+func (m *VersioningSimulation) GetNodes() []*SimulatedNode {
+	if m != nil {
+		return m.Nodes
+	}
+	return nil
+}
+
+// File that would lose some versions
+type SimulatedNode struct {
+	Uuid     string              `protobuf:"bytes,1,opt,name=Uuid" json:"Uuid,omitempty"`
+	Path     string              `protobuf:"bytes,2,opt,name=Path" json:"Path,omitempty"`
+	Versions int32               `protobuf:"varint,3,opt,name=Versions" json:"Versions,omitempty"`
+	Pruned   []*SimulatedVersion `protobuf:"bytes,4,rep,name=Pruned" json:"Pruned,omitempty"`
+}
+
+func (m *SimulatedNode) Reset()                    { *m = SimulatedNode{} }
+func (m *SimulatedNode) String() string            { return proto.CompactTextString(m) }
+func (*SimulatedNode) ProtoMessage()               {}
+func (*SimulatedNode) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{23} }
+
+func (m *SimulatedNode) GetUuid() string {
+	if m != nil {
+		return m.Uuid
+	}
+	return ""
+}
+
+func (m *SimulatedNode) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *SimulatedNode) GetVersions() int32 {
+	if m != nil {
+		return m.Versions
+	}
+	return 0
+}
+
+func (m *SimulatedNode) GetPruned() []*SimulatedVersion {
+	if m != nil {
+		return m.Pruned
+	}
+	return nil
+}
+
+// Version that would be pruned
+type SimulatedVersion struct {
+	VersionId string `protobuf:"bytes,1,opt,name=VersionId" json:"VersionId,omitempty"`
+	MTime     int64  `protobuf:"varint,2,opt,name=MTime" json:"MTime,omitempty"`
+	Size      int64  `protobuf:"varint,3,opt,name=Size" json:"Size,omitempty"`
+}
+
+func (m *SimulatedVersion) Reset()                    { *m = SimulatedVersion{} }
+func (m *SimulatedVersion) String() string            { return proto.CompactTextString(m) }
+func (*SimulatedVersion) ProtoMessage()               {}
+func (*SimulatedVersion) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{24} }
+
+func (m *SimulatedVersion) GetVersionId() string {
+	if m != nil {
+		return m.VersionId
+	}
+	return ""
+}
+
+func (m *SimulatedVersion) GetMTime() int64 {
+	if m != nil {
+		return m.MTime
+	}
+	return 0
+}
+
+func (m *SimulatedVersion) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
 type ListVirtualNodesRequest struct {
 }
 
 func (m *ListVirtualNodesRequest) Reset()                    { *m = ListVirtualNodesRequest{} }
 func (m *ListVirtualNodesRequest) String() string            { return proto.CompactTextString(m) }
 func (*ListVirtualNodesRequest) ProtoMessage()               {}
-func (*ListVirtualNodesRequest) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{15} }
+func (*ListVirtualNodesRequest) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{25} }
+
+type VirtualNodePreviewRequest struct {
+	// Template path identifier
+	Uuid string `protobuf:"bytes,1,opt,name=Uuid" json:"Uuid,omitempty"`
+	// Candidate definition, defaults to the stored template path
+	Node *tree.Node `protobuf:"bytes,2,opt,name=Node" json:"Node,omitempty"`
+	// Login of the user the path is resolved for
+	Login string `protobuf:"bytes,3,opt,name=Login" json:"Login,omitempty"`
+}
+
+func (m *VirtualNodePreviewRequest) Reset()                    { *m = VirtualNodePreviewRequest{} }
+func (m *VirtualNodePreviewRequest) String() string            { return proto.CompactTextString(m) }
+func (*VirtualNodePreviewRequest) ProtoMessage()               {}
+func (*VirtualNodePreviewRequest) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{26} }
+
+func (m *VirtualNodePreviewRequest) GetUuid() string {
+	if m != nil {
+		return m.Uuid
+	}
+	return ""
+}
+
+func (m *VirtualNodePreviewRequest) GetNode() *tree.Node {
+	if m != nil {
+		return m.Node
+	}
+	return nil
+}
+
+func (m *VirtualNodePreviewRequest) GetLogin() string {
+	if m != nil {
+		return m.Login
+	}
+	return ""
+}
+
+// Path a template path resolves to for a given user
+type VirtualNodePreview struct {
+	Login      string `protobuf:"bytes,1,opt,name=Login" json:"Login,omitempty"`
+	Path       string `protobuf:"bytes,2,opt,name=Path" json:"Path,omitempty"`
+	DataSource string `protobuf:"bytes,3,opt,name=DataSource" json:"DataSource,omitempty"`
+	// True if the resolved folder already exists, otherwise it is created on first access
+	NodeExists bool `protobuf:"varint,4,opt,name=NodeExists" json:"NodeExists,omitempty"`
+	// Reason why the resolution failed or would fail on access
+	Error string `protobuf:"bytes,5,opt,name=Error" json:"Error,omitempty"`
+}
+
+func (m *VirtualNodePreview) Reset()                    { *m = VirtualNodePreview{} }
+func (m *VirtualNodePreview) String() string            { return proto.CompactTextString(m) }
+func (*VirtualNodePreview) ProtoMessage()               {}
+func (*VirtualNodePreview) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{27} }
+
+func (m *VirtualNodePreview) GetLogin() string {
+	if m != nil {
+		return m.Login
+	}
+	return ""
+}
+
+func (m *VirtualNodePreview) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *VirtualNodePreview) GetDataSource() string {
+	if m != nil {
+		return m.DataSource
+	}
+	return ""
+}
+
+func (m *VirtualNodePreview) GetNodeExists() bool {
+	if m != nil {
+		return m.NodeExists
+	}
+	return false
+}
+
+func (m *VirtualNodePreview) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
 
 type ListServiceRequest struct {
 	// Filter services by a given status (ANY, STOPPED, STOPPING, RUNNING)
@@ -370,7 +796,7 @@ type ListServiceRequest struct {
 func (m *ListServiceRequest) Reset()                    { *m = ListServiceRequest{} }
 func (m *ListServiceRequest) String() string            { return proto.CompactTextString(m) }
 func (*ListServiceRequest) ProtoMessage()               {}
-func (*ListServiceRequest) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{16} }
+func (*ListServiceRequest) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{28} }
 
 func (m *ListServiceRequest) GetStatusFilter() ctl.ServiceStatus {
 	if m != nil {
@@ -387,7 +813,7 @@ type ServiceCollection struct {
 func (m *ServiceCollection) Reset()                    { *m = ServiceCollection{} }
 func (m *ServiceCollection) String() string            { return proto.CompactTextString(m) }
 func (*ServiceCollection) ProtoMessage()               {}
-func (*ServiceCollection) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{17} }
+func (*ServiceCollection) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{29} }
 
 func (m *ServiceCollection) GetServices() []*ctl.Service {
 	if m != nil {
@@ -415,7 +841,7 @@ type ControlServiceRequest struct {
 func (m *ControlServiceRequest) Reset()                    { *m = ControlServiceRequest{} }
 func (m *ControlServiceRequest) String() string            { return proto.CompactTextString(m) }
 func (*ControlServiceRequest) ProtoMessage()               {}
-func (*ControlServiceRequest) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{18} }
+func (*ControlServiceRequest) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{30} }
 
 func (m *ControlServiceRequest) GetServiceName() string {
 	if m != nil {
@@ -446,7 +872,7 @@ type DiscoveryRequest struct {
 func (m *DiscoveryRequest) Reset()                    { *m = DiscoveryRequest{} }
 func (m *DiscoveryRequest) String() string            { return proto.CompactTextString(m) }
 func (*DiscoveryRequest) ProtoMessage()               {}
-func (*DiscoveryRequest) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{19} }
+func (*DiscoveryRequest) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{31} }
 
 func (m *DiscoveryRequest) GetEndpointType() string {
 	if m != nil {
@@ -473,7 +899,7 @@ type DiscoveryResponse struct {
 func (m *DiscoveryResponse) Reset()                    { *m = DiscoveryResponse{} }
 func (m *DiscoveryResponse) String() string            { return proto.CompactTextString(m) }
 func (*DiscoveryResponse) ProtoMessage()               {}
-func (*DiscoveryResponse) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{20} }
+func (*DiscoveryResponse) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{32} }
 
 func (m *DiscoveryResponse) GetPackageType() string {
 	if m != nil {
@@ -525,7 +951,7 @@ type ConfigFormRequest struct {
 func (m *ConfigFormRequest) Reset()                    { *m = ConfigFormRequest{} }
 func (m *ConfigFormRequest) String() string            { return proto.CompactTextString(m) }
 func (*ConfigFormRequest) ProtoMessage()               {}
-func (*ConfigFormRequest) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{21} }
+func (*ConfigFormRequest) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{33} }
 
 func (m *ConfigFormRequest) GetServiceName() string {
 	if m != nil {
@@ -540,7 +966,7 @@ type OpenApiResponse struct {
 func (m *OpenApiResponse) Reset()                    { *m = OpenApiResponse{} }
 func (m *OpenApiResponse) String() string            { return proto.CompactTextString(m) }
 func (*OpenApiResponse) ProtoMessage()               {}
-func (*OpenApiResponse) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{22} }
+func (*OpenApiResponse) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{34} }
 
 type ActionDescription struct {
 	// Unique name of the action
@@ -574,7 +1000,7 @@ type ActionDescription struct {
 func (m *ActionDescription) Reset()                    { *m = ActionDescription{} }
 func (m *ActionDescription) String() string            { return proto.CompactTextString(m) }
 func (*ActionDescription) ProtoMessage()               {}
-func (*ActionDescription) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{23} }
+func (*ActionDescription) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{35} }
 
 func (m *ActionDescription) GetName() string {
 	if m != nil {
@@ -673,7 +1099,7 @@ type SchedulerActionsRequest struct {
 func (m *SchedulerActionsRequest) Reset()                    { *m = SchedulerActionsRequest{} }
 func (m *SchedulerActionsRequest) String() string            { return proto.CompactTextString(m) }
 func (*SchedulerActionsRequest) ProtoMessage()               {}
-func (*SchedulerActionsRequest) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{24} }
+func (*SchedulerActionsRequest) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{36} }
 
 type SchedulerActionsResponse struct {
 	// List of all registered actions
@@ -683,7 +1109,7 @@ type SchedulerActionsResponse struct {
 func (m *SchedulerActionsResponse) Reset()                    { *m = SchedulerActionsResponse{} }
 func (m *SchedulerActionsResponse) String() string            { return proto.CompactTextString(m) }
 func (*SchedulerActionsResponse) ProtoMessage()               {}
-func (*SchedulerActionsResponse) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{25} }
+func (*SchedulerActionsResponse) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{37} }
 
 func (m *SchedulerActionsResponse) GetActions() map[string]*ActionDescription {
 	if m != nil {
@@ -700,7 +1126,7 @@ type SchedulerActionFormRequest struct {
 func (m *SchedulerActionFormRequest) Reset()                    { *m = SchedulerActionFormRequest{} }
 func (m *SchedulerActionFormRequest) String() string            { return proto.CompactTextString(m) }
 func (*SchedulerActionFormRequest) ProtoMessage()               {}
-func (*SchedulerActionFormRequest) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{26} }
+func (*SchedulerActionFormRequest) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{38} }
 
 func (m *SchedulerActionFormRequest) GetActionName() string {
 	if m != nil {
@@ -715,7 +1141,7 @@ type SchedulerActionFormResponse struct {
 func (m *SchedulerActionFormResponse) Reset()                    { *m = SchedulerActionFormResponse{} }
 func (m *SchedulerActionFormResponse) String() string            { return proto.CompactTextString(m) }
 func (*SchedulerActionFormResponse) ProtoMessage()               {}
-func (*SchedulerActionFormResponse) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{27} }
+func (*SchedulerActionFormResponse) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{39} }
 
 // Request used for ListSites api
 type ListSitesRequest struct {
@@ -725,7 +1151,7 @@ type ListSitesRequest struct {
 func (m *ListSitesRequest) Reset()                    { *m = ListSitesRequest{} }
 func (m *ListSitesRequest) String() string            { return proto.CompactTextString(m) }
 func (*ListSitesRequest) ProtoMessage()               {}
-func (*ListSitesRequest) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{28} }
+func (*ListSitesRequest) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{40} }
 
 func (m *ListSitesRequest) GetFilter() string {
 	if m != nil {
@@ -742,7 +1168,7 @@ type ListSitesResponse struct {
 func (m *ListSitesResponse) Reset()                    { *m = ListSitesResponse{} }
 func (m *ListSitesResponse) String() string            { return proto.CompactTextString(m) }
 func (*ListSitesResponse) ProtoMessage()               {}
-func (*ListSitesResponse) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{29} }
+func (*ListSitesResponse) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{41} }
 
 func (m *ListSitesResponse) GetSites() []*install.ProxyConfig {
 	if m != nil {
@@ -765,9 +1191,21 @@ func init() {
 	proto.RegisterType((*Process)(nil), "rest.Process")
 	proto.RegisterType((*ListProcessesRequest)(nil), "rest.ListProcessesRequest")
 	proto.RegisterType((*ListProcessesResponse)(nil), "rest.ListProcessesResponse")
+	proto.RegisterType((*DataSourceMaintenance)(nil), "rest.DataSourceMaintenance")
+	proto.RegisterType((*DataSourceMaintenanceCollection)(nil), "rest.DataSourceMaintenanceCollection")
+	proto.RegisterType((*ListDataSourcesMaintenanceRequest)(nil), "rest.ListDataSourcesMaintenanceRequest")
+	proto.RegisterType((*DataSourcesMaintenanceCollection)(nil), "rest.DataSourcesMaintenanceCollection")
+	proto.RegisterType((*DataSourceMaintenanceRequest)(nil), "rest.DataSourceMaintenanceRequest")
+	proto.RegisterType((*PutDataSourceMaintenanceRequest)(nil), "rest.PutDataSourceMaintenanceRequest")
 	proto.RegisterType((*ListVersioningPolicyRequest)(nil), "rest.ListVersioningPolicyRequest")
 	proto.RegisterType((*VersioningPolicyCollection)(nil), "rest.VersioningPolicyCollection")
+	proto.RegisterType((*VersioningSimulationRequest)(nil), "rest.VersioningSimulationRequest")
+	proto.RegisterType((*VersioningSimulation)(nil), "rest.VersioningSimulation")
+	proto.RegisterType((*SimulatedNode)(nil), "rest.SimulatedNode")
+	proto.RegisterType((*SimulatedVersion)(nil), "rest.SimulatedVersion")
 	proto.RegisterType((*ListVirtualNodesRequest)(nil), "rest.ListVirtualNodesRequest")
+	proto.RegisterType((*VirtualNodePreviewRequest)(nil), "rest.VirtualNodePreviewRequest")
+	proto.RegisterType((*VirtualNodePreview)(nil), "rest.VirtualNodePreview")
 	proto.RegisterType((*ListServiceRequest)(nil), "rest.ListServiceRequest")
 	proto.RegisterType((*ServiceCollection)(nil), "rest.ServiceCollection")
 	proto.RegisterType((*ControlServiceRequest)(nil), "rest.ControlServiceRequest")
@@ -787,78 +1225,107 @@ func init() {
 func init() { proto.RegisterFile("config.proto", fileDescriptor2) }
 
 var fileDescriptor2 = []byte{
-	// 1165 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x56, 0x5b, 0x4f, 0x1b, 0x47,
-	0x14, 0x96, 0x01, 0x63, 0xfb, 0xd8, 0x10, 0xbc, 0x25, 0xb0, 0x71, 0x54, 0x84, 0x56, 0x55, 0x85,
-	0x48, 0x63, 0x54, 0x27, 0x4d, 0x2f, 0x8a, 0x14, 0x81, 0x0d, 0xaa, 0x25, 0x02, 0xd6, 0xda, 0xea,
-	0xfb, 0x78, 0x77, 0x6a, 0xa6, 0xcc, 0xee, 0x6c, 0x67, 0x66, 0x51, 0xfc, 0xde, 0x7f, 0xd3, 0xf7,
-	0xbe, 0xf5, 0x07, 0xf4, 0x5f, 0x55, 0x73, 0xd9, 0xf5, 0xd8, 0x26, 0x2a, 0x52, 0x1f, 0xc0, 0x73,
-	0xbe, 0x73, 0x99, 0x73, 0xf9, 0xe6, 0xd8, 0xd0, 0x8a, 0x58, 0xfa, 0x2b, 0x99, 0x75, 0x33, 0xce,
-	0x24, 0xf3, 0xb6, 0x38, 0x16, 0xb2, 0xf3, 0x66, 0x46, 0xe4, 0x5d, 0x3e, 0xed, 0x46, 0x2c, 0x39,
-	0xcb, 0xe6, 0x31, 0x61, 0x67, 0x11, 0xa6, 0x54, 0x9c, 0x45, 0x2c, 0x49, 0x58, 0x7a, 0xa6, 0x4d,
-	0xcf, 0x24, 0xc7, 0x58, 0xff, 0x33, 0xae, 0x9d, 0xef, 0x9f, 0xe2, 0xc4, 0xa6, 0xbf, 0xe1, 0x48,
-	0xda, 0x0f, 0xeb, 0xf8, 0xed, 0x53, 0x1c, 0x23, 0x49, 0xd5, 0x9f, 0x75, 0xf9, 0xf1, 0x29, 0x2e,
-	0x24, 0x15, 0x12, 0x51, 0x5a, 0x7c, 0x1a, 0xd7, 0xe0, 0x03, 0xec, 0xf4, 0x75, 0xc5, 0x39, 0x47,
-	0x92, 0xb0, 0xd4, 0xeb, 0x40, 0xfd, 0x2a, 0xa7, 0x74, 0x84, 0xe4, 0x9d, 0x5f, 0x39, 0xae, 0x9c,
-	0x34, 0xc2, 0x52, 0xf6, 0x3c, 0xd8, 0x1a, 0x20, 0x89, 0xfc, 0x0d, 0x8d, 0xeb, 0x73, 0x70, 0x08,
-	0xcf, 0xaf, 0x89, 0x90, 0xea, 0x3c, 0x66, 0x39, 0x8f, 0x70, 0x88, 0x7f, 0xcf, 0xb1, 0x90, 0xc1,
-	0x14, 0xf6, 0x17, 0x60, 0x9f, 0x51, 0x8a, 0x23, 0x7d, 0xc1, 0x5b, 0x68, 0x2e, 0x70, 0xe1, 0x57,
-	0x8e, 0x37, 0x4f, 0x9a, 0x3d, 0xaf, 0x6b, 0x7b, 0xe0, 0xc4, 0x71, 0xcd, 0xbc, 0x7d, 0xa8, 0x4e,
-	0x98, 0x44, 0x54, 0xdf, 0x5d, 0x0d, 0x8d, 0x10, 0xbc, 0x05, 0x7f, 0x80, 0x29, 0x96, 0xd8, 0xbd,
-	0x5e, 0x64, 0x2c, 0x15, 0xd8, 0xf3, 0xa1, 0x36, 0xce, 0xa3, 0x08, 0x0b, 0xa1, 0xeb, 0xa8, 0x87,
-	0x85, 0x18, 0xbc, 0x84, 0x17, 0x2a, 0xe5, 0x11, 0xc6, 0x5c, 0x9c, 0xc7, 0x31, 0xc7, 0x42, 0x60,
-	0x51, 0xa4, 0x7d, 0x01, 0x9d, 0xc7, 0x94, 0x36, 0xe8, 0x57, 0xb0, 0xa3, 0x34, 0xa5, 0x42, 0xa7,
-	0xdf, 0x08, 0x97, 0xc1, 0xe0, 0x06, 0x0e, 0x8a, 0x18, 0x57, 0x8c, 0xc6, 0x98, 0x17, 0xd1, 0xbd,
-	0x63, 0x68, 0x3a, 0xa6, 0xb6, 0xc1, 0x2e, 0xa4, 0x7a, 0xac, 0x7b, 0x6f, 0x7b, 0xac, 0xce, 0xc1,
-	0x2d, 0x1c, 0xf6, 0x39, 0x46, 0x12, 0x2f, 0x22, 0xfe, 0xbf, 0x80, 0x13, 0xf0, 0xd7, 0x03, 0xfe,
-	0x57, 0xdf, 0xbc, 0x23, 0xd8, 0xba, 0x61, 0x31, 0xd6, 0x91, 0x9a, 0x3d, 0xe8, 0x6a, 0xb6, 0x2b,
-	0x24, 0xd4, 0x78, 0x90, 0x9b, 0xbe, 0x8e, 0x25, 0xe3, 0x68, 0x86, 0x2f, 0xf2, 0xe8, 0x1e, 0xcb,
-	0xb2, 0xf2, 0x1e, 0xc0, 0x62, 0x48, 0x3a, 0xf2, 0xe3, 0x53, 0x77, 0xac, 0x54, 0xb7, 0xcb, 0x28,
-	0x33, 0xfc, 0x29, 0xb3, 0x35, 0x2c, 0x83, 0xc1, 0x3f, 0x15, 0xa8, 0x8d, 0x38, 0xd3, 0x29, 0xee,
-	0xc2, 0xc6, 0x70, 0x60, 0xbb, 0xb0, 0x31, 0x1c, 0x28, 0x36, 0x8f, 0x10, 0xc7, 0xa9, 0x1c, 0x0e,
-	0xac, 0x73, 0x29, 0xab, 0xd6, 0x7d, 0xc4, 0x92, 0x93, 0x48, 0x8c, 0x18, 0x97, 0xfe, 0xa6, 0x26,
-	0x96, 0x0b, 0x79, 0x07, 0xb0, 0xad, 0x1a, 0x34, 0x8c, 0xfd, 0x2d, 0xed, 0x6b, 0xa5, 0xd5, 0xa6,
-	0x57, 0xd7, 0x9b, 0xde, 0x81, 0xfa, 0x58, 0x22, 0x2e, 0x27, 0x68, 0xe6, 0x6f, 0x9b, 0x7b, 0x0b,
-	0x59, 0xeb, 0x30, 0x7f, 0x20, 0x8a, 0xfd, 0x35, 0x4d, 0x9f, 0x52, 0x0e, 0x46, 0xb0, 0xaf, 0x99,
-	0x63, 0xca, 0x29, 0x59, 0xe9, 0x64, 0x52, 0x59, 0xcd, 0xc4, 0xfa, 0xde, 0xa0, 0x04, 0xdb, 0x12,
-	0x5d, 0x28, 0x18, 0x98, 0xf7, 0xe9, 0x44, 0xb4, 0x73, 0x7e, 0x05, 0x8d, 0x12, 0xb4, 0xaf, 0x70,
-	0xa7, 0xab, 0xf6, 0x5d, 0xd7, 0xc2, 0xe1, 0x42, 0x1f, 0x7c, 0x09, 0x2f, 0x55, 0x94, 0x5f, 0x30,
-	0x17, 0x84, 0xa5, 0x24, 0x9d, 0x8d, 0x18, 0x25, 0xd1, 0xbc, 0x78, 0x34, 0x23, 0xe8, 0xac, 0xaa,
-	0x9c, 0x17, 0xdf, 0x83, 0xba, 0xc6, 0x48, 0x79, 0xd1, 0x81, 0xe1, 0xce, 0x5a, 0xb8, 0xd2, 0x2e,
-	0x78, 0x01, 0x87, 0xfa, 0x42, 0xc2, 0x65, 0x8e, 0xa8, 0xa2, 0x57, 0xf9, 0x42, 0xaf, 0xc1, 0xd3,
-	0x34, 0x33, 0x45, 0x16, 0x1d, 0x7a, 0x07, 0xad, 0xb1, 0x44, 0x32, 0x17, 0x57, 0x84, 0x4a, 0xcc,
-	0x75, 0x9f, 0x76, 0x7b, 0x5e, 0x57, 0x6d, 0x49, 0x6b, 0x6a, 0xf4, 0xe1, 0x92, 0x5d, 0x30, 0x86,
-	0xb6, 0x55, 0x3b, 0x19, 0x9f, 0x38, 0x23, 0x32, 0x19, 0xb7, 0xdc, 0x40, 0x8b, 0x81, 0x7d, 0x66,
-	0x2f, 0xfd, 0x51, 0x81, 0xe7, 0x7d, 0x96, 0x4a, 0xce, 0xe8, 0x4a, 0x9a, 0x2b, 0x03, 0xab, 0xac,
-	0x0d, 0x4c, 0xd1, 0x43, 0x95, 0xeb, 0xcc, 0xb3, 0x94, 0xbd, 0xd7, 0x50, 0xeb, 0xb3, 0x24, 0x41,
-	0x69, 0xac, 0xe9, 0xba, 0xdb, 0xfb, 0xc2, 0x4d, 0xcb, 0xaa, 0xc2, 0xc2, 0x26, 0x78, 0x07, 0x7b,
-	0x03, 0x22, 0x22, 0xf6, 0x80, 0x79, 0x31, 0x2a, 0x2f, 0x80, 0xd6, 0x65, 0x1a, 0x67, 0x8c, 0xa4,
-	0x72, 0x32, 0xcf, 0x8a, 0x0c, 0x96, 0xb0, 0xe0, 0xef, 0x0d, 0x68, 0x3b, 0x8e, 0x96, 0x30, 0x8a,
-	0xf5, 0x28, 0xba, 0x47, 0x33, 0xec, 0x38, 0xba, 0x90, 0x8a, 0x6d, 0xc5, 0x6b, 0x34, 0xc5, 0xd4,
-	0xa6, 0xbf, 0x84, 0xa9, 0xf5, 0x62, 0xc7, 0xae, 0x4b, 0x68, 0x84, 0x85, 0xe8, 0x1d, 0x01, 0x5c,
-	0xe4, 0x84, 0xc6, 0x63, 0x89, 0x92, 0x4c, 0xbf, 0xb8, 0x6a, 0xe8, 0x20, 0x66, 0x1b, 0x10, 0x1a,
-	0x87, 0xf8, 0x81, 0x68, 0xff, 0x6a, 0xb1, 0x0d, 0x1c, 0xd0, 0x1b, 0x40, 0xa3, 0xa8, 0x45, 0xf8,
-	0xdb, 0x7a, 0x76, 0x5f, 0x1b, 0x5a, 0xaf, 0x55, 0xd4, 0x2d, 0x0d, 0x2f, 0x53, 0xc9, 0xe7, 0xe1,
-	0xc2, 0xb1, 0xf3, 0x1e, 0x76, 0x97, 0x95, 0xde, 0x1e, 0x6c, 0xde, 0xe3, 0xb9, 0xad, 0x5a, 0x1d,
-	0xd5, 0xe8, 0x1f, 0x10, 0xcd, 0x8b, 0x29, 0x19, 0xe1, 0xa7, 0x8d, 0x1f, 0x2a, 0xc1, 0x77, 0xd0,
-	0x36, 0x5f, 0xaa, 0x57, 0x8c, 0x27, 0x4f, 0x9e, 0x7c, 0xd0, 0x86, 0x67, 0xb7, 0x19, 0x4e, 0xcf,
-	0x33, 0x52, 0x64, 0x18, 0xfc, 0xb9, 0x09, 0xed, 0x73, 0xcd, 0xc9, 0x01, 0x16, 0x11, 0x27, 0x99,
-	0xa6, 0xa7, 0x07, 0x5b, 0x4e, 0x0c, 0x7d, 0x56, 0xd8, 0x30, 0x62, 0x69, 0xb1, 0xe6, 0xd5, 0x59,
-	0x65, 0x68, 0x06, 0x61, 0x3a, 0x6d, 0x04, 0x95, 0x88, 0x13, 0xcc, 0xae, 0x36, 0x17, 0xf2, 0x4e,
-	0xe0, 0xd9, 0x38, 0x4f, 0x12, 0xc4, 0xe7, 0x13, 0x9c, 0x64, 0x14, 0x49, 0x6c, 0x7b, 0xbd, 0x0a,
-	0xab, 0x69, 0xfe, 0x8c, 0x84, 0x2a, 0x53, 0xaf, 0xb9, 0x7a, 0x58, 0x88, 0x6a, 0x9a, 0xea, 0xf3,
-	0x23, 0x8b, 0x73, 0x8a, 0xfd, 0xa6, 0x76, 0x77, 0x10, 0x75, 0xc7, 0x42, 0x1a, 0x71, 0x96, 0x09,
-	0xbf, 0x65, 0xee, 0x58, 0x81, 0xd5, 0x83, 0xe8, 0x23, 0x89, 0x67, 0x8c, 0xcf, 0xfd, 0x9a, 0x79,
-	0x10, 0x85, 0xac, 0xaa, 0x9e, 0x90, 0x54, 0xfa, 0x75, 0x53, 0xb5, 0x3a, 0x7b, 0xa7, 0xb0, 0x37,
-	0x4c, 0xb3, 0x5c, 0xba, 0x45, 0x36, 0xb4, 0x7e, 0x0d, 0xf7, 0xbe, 0x81, 0xf6, 0x6d, 0x2e, 0x57,
-	0x8c, 0x41, 0x1b, 0xaf, 0x2b, 0x54, 0x4d, 0x43, 0x31, 0x4c, 0x25, 0xe6, 0x29, 0xa2, 0xfe, 0x8e,
-	0x2e, 0xd8, 0x41, 0xd4, 0xd2, 0x1a, 0x47, 0x77, 0x58, 0xa5, 0xce, 0xcd, 0xd4, 0xca, 0xa5, 0xf5,
-	0x57, 0x05, 0xfc, 0x75, 0x9d, 0x7d, 0x59, 0x97, 0x50, 0xb3, 0x90, 0xdd, 0x36, 0xaf, 0x0c, 0x63,
-	0x3f, 0xe7, 0xd0, 0xb5, 0xb2, 0xa1, 0x6d, 0xe1, 0xdb, 0x19, 0x43, 0xcb, 0x55, 0x3c, 0x42, 0xd9,
-	0xd7, 0x2e, 0x65, 0x9b, 0xbd, 0x43, 0x73, 0xcd, 0x1a, 0xc1, 0x5c, 0x2e, 0xbf, 0x87, 0xce, 0x4a,
-	0x1a, 0x2e, 0xa9, 0x8f, 0x00, 0x0c, 0xe8, 0xf0, 0xd1, 0x41, 0xd4, 0xf7, 0xc6, 0xa3, 0xde, 0x96,
-	0xde, 0xa7, 0xb0, 0xa7, 0x57, 0x39, 0x91, 0x4b, 0x5f, 0x75, 0xce, 0x0a, 0x6f, 0x84, 0x56, 0x0a,
-	0x3e, 0x40, 0xdb, 0xb1, 0xb5, 0x9d, 0x3b, 0x85, 0xaa, 0x06, 0x6c, 0xdf, 0xf6, 0xbb, 0xc5, 0xaf,
-	0xdb, 0x11, 0x67, 0x9f, 0xe6, 0xe6, 0x11, 0x86, 0xc6, 0x64, 0xba, 0xad, 0x7f, 0xf1, 0xbe, 0xf9,
-	0x37, 0x00, 0x00, 0xff, 0xff, 0x86, 0xb5, 0x92, 0xb3, 0xe3, 0x0b, 0x00, 0x00,
+	// 1618 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x58, 0x4b, 0x73, 0x1b, 0x37,
+	0x12, 0x2e, 0x8a, 0x92, 0x28, 0xb6, 0x1e, 0x96, 0xc6, 0x92, 0x35, 0xa6, 0xbc, 0xb2, 0x16, 0xbb,
+	0xeb, 0xd2, 0xda, 0x6b, 0xaa, 0x96, 0x7e, 0xe4, 0x51, 0xae, 0x72, 0xc9, 0xa2, 0x94, 0xb0, 0x4a,
+	0xb2, 0x59, 0x43, 0x26, 0xa9, 0xca, 0x0d, 0x1a, 0x22, 0x34, 0xa2, 0xe1, 0x80, 0x01, 0x30, 0xb2,
+	0x98, 0x6b, 0xf2, 0x13, 0x72, 0xc9, 0x29, 0x3f, 0x20, 0x95, 0x6b, 0x6e, 0xf9, 0x01, 0xf9, 0x57,
+	0x29, 0x3c, 0x66, 0x06, 0x33, 0xa4, 0x6d, 0x25, 0x39, 0x48, 0x44, 0x7f, 0xe8, 0x6e, 0x74, 0x37,
+	0xfa, 0x01, 0x12, 0x56, 0x42, 0x16, 0x7f, 0x45, 0x87, 0xcd, 0x31, 0x67, 0x92, 0x79, 0xf3, 0x9c,
+	0x08, 0xd9, 0x78, 0x34, 0xa4, 0xf2, 0x75, 0x72, 0xde, 0x0c, 0xd9, 0xe8, 0x60, 0x3c, 0x19, 0x50,
+	0x76, 0x10, 0x92, 0x28, 0x12, 0x07, 0x21, 0x1b, 0x8d, 0x58, 0x7c, 0xa0, 0x59, 0x0f, 0x24, 0x27,
+	0x44, 0xff, 0x33, 0xa2, 0x8d, 0x0f, 0xae, 0x23, 0xc4, 0xce, 0xbf, 0x26, 0xa1, 0xb4, 0x1f, 0x56,
+	0xf0, 0xff, 0xd7, 0x11, 0x0c, 0x65, 0xa4, 0xfe, 0xac, 0xc8, 0x47, 0xd7, 0x11, 0xa1, 0xb1, 0x90,
+	0x38, 0x8a, 0xd2, 0x4f, 0x23, 0x8a, 0x9e, 0xc3, 0xea, 0x91, 0xf6, 0x38, 0xe1, 0x58, 0x52, 0x16,
+	0x7b, 0x0d, 0x58, 0x3a, 0x49, 0xa2, 0xa8, 0x8b, 0xe5, 0x6b, 0xbf, 0xb2, 0x57, 0xd9, 0xaf, 0x07,
+	0x19, 0xed, 0x79, 0x30, 0xdf, 0xc6, 0x12, 0xfb, 0x73, 0x1a, 0xd7, 0x6b, 0xb4, 0x0d, 0x5b, 0xa7,
+	0x54, 0x48, 0xb5, 0xee, 0xb1, 0x84, 0x87, 0x24, 0x20, 0xdf, 0x24, 0x44, 0x48, 0x74, 0x0e, 0x9b,
+	0x39, 0x78, 0xc4, 0xa2, 0x88, 0x84, 0xfa, 0x80, 0xc7, 0xb0, 0x9c, 0xe3, 0xc2, 0xaf, 0xec, 0x55,
+	0xf7, 0x97, 0x5b, 0x5e, 0xd3, 0xc6, 0xc0, 0xd1, 0xe3, 0xb2, 0x79, 0x9b, 0xb0, 0xd0, 0x67, 0x12,
+	0x47, 0xfa, 0xec, 0x85, 0xc0, 0x10, 0xe8, 0x31, 0xf8, 0x6d, 0x12, 0x11, 0x49, 0xdc, 0xe3, 0xc5,
+	0x98, 0xc5, 0x82, 0x78, 0x3e, 0xd4, 0x7a, 0x49, 0x18, 0x12, 0x21, 0xb4, 0x1f, 0x4b, 0x41, 0x4a,
+	0xa2, 0x1d, 0xb8, 0xad, 0x4c, 0xee, 0x12, 0xc2, 0xc5, 0xe1, 0x60, 0xc0, 0x89, 0x10, 0x44, 0xa4,
+	0x66, 0xbf, 0x80, 0xc6, 0xac, 0x4d, 0xab, 0xf4, 0xdf, 0xb0, 0xaa, 0x76, 0xb2, 0x0d, 0x6d, 0x7e,
+	0x3d, 0x28, 0x82, 0xe8, 0x25, 0xdc, 0x4a, 0x75, 0x9c, 0xb0, 0x68, 0x40, 0x78, 0xaa, 0xdd, 0xdb,
+	0x83, 0x65, 0x87, 0xd5, 0x06, 0xd8, 0x85, 0x54, 0x8c, 0x75, 0xec, 0x6d, 0x8c, 0xd5, 0x1a, 0xbd,
+	0x82, 0xed, 0x23, 0x4e, 0xb0, 0x24, 0xb9, 0xc6, 0xbf, 0xa7, 0xb0, 0x0f, 0xfe, 0xb4, 0xc2, 0xf7,
+	0xc5, 0xcd, 0xdb, 0x85, 0xf9, 0x97, 0x6c, 0x40, 0xb4, 0xa6, 0xe5, 0x16, 0x34, 0x75, 0xb6, 0x2b,
+	0x24, 0xd0, 0x38, 0x4a, 0x4c, 0x5c, 0x7b, 0x92, 0x71, 0x3c, 0x24, 0x2f, 0x92, 0xf0, 0x82, 0xc8,
+	0xcc, 0xf3, 0x16, 0x40, 0x7e, 0x49, 0x5a, 0xf3, 0xec, 0x5b, 0x77, 0xb8, 0x54, 0xb4, 0x33, 0x2d,
+	0x43, 0x72, 0x35, 0xb6, 0x3e, 0x14, 0x41, 0xf4, 0x7b, 0x05, 0x6a, 0x5d, 0xce, 0xb4, 0x89, 0x6b,
+	0x30, 0xd7, 0x69, 0xdb, 0x28, 0xcc, 0x75, 0xda, 0x2a, 0x9b, 0xbb, 0x98, 0x93, 0x58, 0x76, 0xda,
+	0x56, 0x38, 0xa3, 0x55, 0xe8, 0xce, 0x88, 0xe4, 0x34, 0x14, 0x5d, 0xc6, 0xa5, 0x5f, 0xd5, 0x89,
+	0xe5, 0x42, 0xde, 0x2d, 0x58, 0x54, 0x01, 0xea, 0x0c, 0xfc, 0x79, 0x2d, 0x6b, 0xa9, 0x72, 0xd0,
+	0x17, 0xa6, 0x83, 0xde, 0x80, 0xa5, 0x9e, 0xc4, 0x5c, 0xf6, 0xf1, 0xd0, 0x5f, 0x34, 0xe7, 0xa6,
+	0xb4, 0xde, 0x23, 0xfc, 0x92, 0xaa, 0xec, 0xaf, 0xe9, 0xf4, 0xc9, 0x68, 0xd4, 0x85, 0x4d, 0x9d,
+	0x39, 0xc6, 0x9d, 0x2c, 0x2b, 0x1d, 0x4b, 0x2a, 0x65, 0x4b, 0xac, 0xec, 0x4b, 0x3c, 0x22, 0xd6,
+	0x45, 0x17, 0x42, 0x6d, 0xd8, 0x2a, 0x69, 0xb4, 0xf7, 0xfc, 0x00, 0xea, 0x19, 0x68, 0xab, 0x70,
+	0xb5, 0xa9, 0xfa, 0x5d, 0xd3, 0xc2, 0x41, 0xbe, 0x8f, 0x46, 0xb0, 0x95, 0xdf, 0xcb, 0x19, 0xa6,
+	0xb1, 0x24, 0x31, 0x8e, 0x43, 0xa2, 0xb2, 0xeb, 0x4c, 0xe5, 0x84, 0x31, 0x4b, 0xaf, 0x55, 0xad,
+	0x6a, 0x67, 0xad, 0x39, 0x86, 0xf0, 0xd6, 0xa1, 0x7a, 0x1c, 0x0f, 0x74, 0x98, 0xeb, 0x81, 0x5a,
+	0xaa, 0x4c, 0x3b, 0x23, 0x42, 0xe0, 0x21, 0xb1, 0xf1, 0x4d, 0x49, 0xf4, 0x4b, 0x05, 0xee, 0xce,
+	0x3c, 0xcf, 0xe9, 0x23, 0xbb, 0x53, 0x09, 0x55, 0x2f, 0x24, 0xcf, 0x13, 0xa8, 0x7d, 0x41, 0xe3,
+	0x01, 0x7b, 0x23, 0xfc, 0x39, 0xed, 0xdd, 0x8e, 0xf1, 0x6e, 0xa6, 0xde, 0x20, 0xe5, 0xf5, 0x1e,
+	0xc1, 0xe2, 0x61, 0x28, 0xe9, 0x25, 0xd1, 0x96, 0xbe, 0x47, 0xca, 0xb2, 0xa2, 0x7f, 0xc1, 0x3f,
+	0x8b, 0x4d, 0x50, 0xb8, 0x5c, 0xb6, 0xb3, 0x5c, 0xc0, 0xde, 0x6c, 0x06, 0xc7, 0xa9, 0x4f, 0x66,
+	0x35, 0xc7, 0xff, 0xbc, 0xc3, 0x84, 0x5c, 0xb6, 0xd0, 0x2f, 0x51, 0x0b, 0xee, 0xcc, 0x36, 0xd9,
+	0x26, 0x94, 0x07, 0xf3, 0x3a, 0x63, 0xec, 0xbd, 0xe9, 0x54, 0x89, 0xe0, 0x6e, 0x37, 0x91, 0x7f,
+	0x56, 0xec, 0x2f, 0x06, 0x1a, 0xfd, 0x03, 0x76, 0x54, 0xcc, 0x3e, 0x27, 0x5c, 0x50, 0x16, 0xd3,
+	0x78, 0xd8, 0x65, 0x11, 0x0d, 0x27, 0x69, 0xb4, 0xba, 0xd0, 0x28, 0x6f, 0x39, 0x71, 0x6a, 0xc1,
+	0x92, 0xc6, 0x68, 0x16, 0xa4, 0x5b, 0xa6, 0x1d, 0x4d, 0xa9, 0xcb, 0xf8, 0xd0, 0x4f, 0x15, 0xd8,
+	0xc9, 0xb7, 0x7b, 0x74, 0x94, 0x44, 0x7a, 0xe4, 0x39, 0xbe, 0x7d, 0x96, 0xd0, 0xb4, 0xc2, 0xf4,
+	0xda, 0x6b, 0xc2, 0xa2, 0xd1, 0x63, 0x9b, 0xde, 0xdb, 0x4e, 0xb1, 0x5c, 0xaa, 0x1e, 0xdd, 0xfb,
+	0xab, 0xea, 0xf2, 0x76, 0x21, 0x55, 0xfd, 0x67, 0xf8, 0x4a, 0xf5, 0x4b, 0xa1, 0xb3, 0x7e, 0x21,
+	0xc8, 0x68, 0xf4, 0xe3, 0x1c, 0x6c, 0xce, 0xb2, 0xd0, 0x43, 0xb0, 0xd2, 0x0b, 0x71, 0x1c, 0x93,
+	0x81, 0x11, 0xac, 0x68, 0xc1, 0x02, 0xe6, 0xdd, 0x83, 0x35, 0x2b, 0x9b, 0x72, 0x99, 0x51, 0x59,
+	0x42, 0x55, 0x53, 0xd5, 0xc3, 0xd3, 0xc2, 0xc2, 0x36, 0xbe, 0x22, 0xa8, 0xb4, 0x75, 0x79, 0x12,
+	0x93, 0x41, 0xc6, 0x66, 0x8c, 0x2d, 0xa1, 0xaa, 0x0a, 0x0d, 0xd2, 0xa3, 0xdf, 0x12, 0xdd, 0x09,
+	0xab, 0x81, 0x83, 0x78, 0x77, 0xa0, 0xde, 0xe7, 0x49, 0x1c, 0x62, 0x49, 0x06, 0xba, 0x13, 0x2e,
+	0x05, 0x39, 0xe0, 0xfd, 0x17, 0x16, 0x8c, 0xa9, 0x35, 0x7d, 0x87, 0x37, 0x4d, 0xe2, 0x58, 0xc7,
+	0x8d, 0xc1, 0x81, 0xe1, 0x40, 0xdf, 0x55, 0x60, 0xb5, 0xb0, 0x31, 0xf3, 0xbe, 0x66, 0x0c, 0x3b,
+	0x15, 0xf1, 0x92, 0xaf, 0x19, 0xad, 0xef, 0x57, 0x1b, 0xeb, 0xcf, 0xdb, 0x2c, 0x2a, 0x5a, 0x60,
+	0x19, 0x03, 0xcb, 0x85, 0xbe, 0x84, 0xf5, 0xf2, 0x9e, 0x72, 0xd1, 0x2e, 0xb3, 0xf6, 0x9c, 0x03,
+	0xaa, 0x19, 0x9e, 0xf5, 0xa9, 0xed, 0xcd, 0xd5, 0xc0, 0x10, 0xca, 0x4e, 0x1d, 0xb0, 0xaa, 0x06,
+	0xf5, 0x1a, 0xdd, 0x86, 0x6d, 0x5d, 0x10, 0x94, 0xcb, 0x04, 0x47, 0xda, 0xeb, 0xb4, 0x18, 0x08,
+	0xdc, 0x76, 0xe0, 0x2e, 0x27, 0x97, 0x94, 0xbc, 0x79, 0x57, 0xde, 0xbe, 0x67, 0x54, 0x2b, 0xab,
+	0x4e, 0xd9, 0x90, 0xc6, 0xb6, 0x1d, 0x1b, 0x02, 0xfd, 0x50, 0x01, 0x6f, 0xfa, 0x9c, 0x9c, 0xb9,
+	0xe2, 0x30, 0xcf, 0x0c, 0x75, 0xb1, 0x27, 0x57, 0xa7, 0x7a, 0xf2, 0x2e, 0x80, 0x52, 0x7c, 0x7c,
+	0x45, 0x85, 0x34, 0x19, 0xb5, 0x14, 0x38, 0x88, 0x3a, 0xe9, 0x98, 0x73, 0xc6, 0xed, 0x48, 0x35,
+	0x04, 0x3a, 0x05, 0x4f, 0xbf, 0x2b, 0xcc, 0x54, 0x4b, 0xdd, 0x7e, 0x0a, 0x2b, 0x3d, 0x89, 0x65,
+	0x22, 0x4e, 0x68, 0x24, 0x09, 0xd7, 0xc6, 0xad, 0xb5, 0xbc, 0xa6, 0x7a, 0x16, 0x5b, 0x56, 0xb3,
+	0x1f, 0x14, 0xf8, 0x50, 0x0f, 0x36, 0xec, 0xb6, 0xd3, 0x4f, 0xf6, 0x9d, 0x99, 0x6c, 0xfa, 0xc9,
+	0x8a, 0xab, 0x28, 0x9f, 0xd0, 0x6f, 0x79, 0x88, 0x7e, 0x5f, 0x81, 0xad, 0x23, 0x16, 0x4b, 0xce,
+	0xa2, 0x92, 0x99, 0xa5, 0x09, 0x5d, 0x99, 0x9a, 0xd0, 0x2a, 0x3f, 0x55, 0x08, 0x9c, 0x01, 0x9e,
+	0xd1, 0xde, 0x43, 0xa8, 0x1d, 0xb1, 0xd1, 0x08, 0xdb, 0xc1, 0xb9, 0xd6, 0xba, 0xe9, 0x9a, 0x65,
+	0xb7, 0x82, 0x94, 0x07, 0x3d, 0x85, 0xf5, 0x36, 0x15, 0x21, 0xbb, 0x24, 0x3c, 0x6d, 0xa4, 0xaa,
+	0x77, 0x1c, 0xc7, 0x83, 0x31, 0xa3, 0xb1, 0xec, 0x4f, 0xc6, 0xa9, 0x05, 0x05, 0x0c, 0xfd, 0x36,
+	0x07, 0x1b, 0x8e, 0xa0, 0x7d, 0x21, 0xa8, 0x67, 0x0e, 0x0e, 0x2f, 0xf0, 0x90, 0x38, 0x82, 0x2e,
+	0xa4, 0x74, 0x5b, 0xf2, 0x14, 0x9f, 0x93, 0xc8, 0x9a, 0x5f, 0xc0, 0xd4, 0x94, 0xb7, 0xd5, 0x60,
+	0x13, 0x22, 0x25, 0x55, 0x36, 0xbc, 0x48, 0x68, 0x34, 0xe8, 0x49, 0x3c, 0x1a, 0xdb, 0xfe, 0xe2,
+	0x20, 0xe6, 0xf9, 0x47, 0xa3, 0x41, 0x40, 0x2e, 0xa9, 0x96, 0x5f, 0x48, 0x9f, 0x7f, 0x0e, 0xe8,
+	0xb5, 0xa1, 0x9e, 0xfa, 0x22, 0xfc, 0x45, 0x7d, 0x77, 0xf7, 0xec, 0x00, 0x2a, 0x7b, 0xd4, 0xcc,
+	0x18, 0x8f, 0x63, 0xc9, 0x27, 0x41, 0x2e, 0xd8, 0x78, 0x06, 0x6b, 0xc5, 0x4d, 0xf5, 0x5e, 0xb9,
+	0x20, 0x13, 0xeb, 0xb5, 0x5a, 0xaa, 0xab, 0xbf, 0xc4, 0x51, 0x92, 0xde, 0x92, 0x21, 0x3e, 0x9e,
+	0xfb, 0xb0, 0x82, 0x9e, 0xc0, 0x86, 0xf9, 0x16, 0x75, 0xc2, 0xf8, 0xe8, 0xda, 0x37, 0x8f, 0x36,
+	0xe0, 0xc6, 0xab, 0x31, 0x89, 0x0f, 0xc7, 0x34, 0xb5, 0x10, 0xfd, 0x5c, 0x85, 0x8d, 0x43, 0x9d,
+	0x93, 0x6d, 0x22, 0x42, 0x4e, 0xc7, 0x6a, 0x39, 0x73, 0xec, 0x7a, 0x30, 0xdf, 0x09, 0x59, 0x9c,
+	0xd6, 0x9f, 0x5a, 0xeb, 0x4a, 0xd5, 0x17, 0x91, 0x96, 0xb5, 0x22, 0xf4, 0x50, 0xca, 0x95, 0xd9,
+	0xb7, 0x96, 0x0b, 0x79, 0xfb, 0x70, 0xa3, 0x97, 0x8c, 0x46, 0x98, 0x4f, 0xfa, 0x64, 0x34, 0x56,
+	0xdd, 0xcd, 0xc6, 0xba, 0x0c, 0xab, 0xdb, 0xfc, 0x14, 0x0b, 0xe5, 0xa6, 0xed, 0xe6, 0x29, 0xa9,
+	0x6e, 0x53, 0x7d, 0x9e, 0xb1, 0x41, 0x12, 0x11, 0x7f, 0xd9, 0xd4, 0x7e, 0x8e, 0xa8, 0x33, 0x72,
+	0xaa, 0xcb, 0xd9, 0x58, 0xf8, 0x2b, 0xe6, 0x8c, 0x12, 0xac, 0x0a, 0xe2, 0x08, 0x4b, 0x32, 0x64,
+	0x7c, 0xe2, 0xd7, 0x4c, 0x41, 0xa4, 0xb4, 0xf2, 0xba, 0x4f, 0x63, 0xe9, 0x2f, 0x19, 0xaf, 0xd5,
+	0xda, 0xbb, 0x0f, 0xeb, 0x9d, 0x78, 0x9c, 0x48, 0xd7, 0xc9, 0xba, 0xde, 0x9f, 0xc2, 0xbd, 0xff,
+	0xc1, 0xc6, 0xab, 0x44, 0x96, 0x98, 0x41, 0x33, 0x4f, 0x6f, 0x28, 0x9f, 0x3a, 0xa2, 0x13, 0x4b,
+	0xc2, 0x63, 0x1c, 0xf9, 0xab, 0xa6, 0x5f, 0xe5, 0x88, 0x6a, 0xd9, 0xbd, 0xf0, 0x35, 0x51, 0xa6,
+	0x73, 0x73, 0x6b, 0x59, 0xcb, 0xfe, 0xb5, 0x02, 0xfe, 0xf4, 0x9e, 0xad, 0xac, 0x63, 0xa8, 0x59,
+	0xc8, 0x76, 0x9b, 0x07, 0x76, 0xee, 0xbc, 0x45, 0xa0, 0x69, 0x69, 0x93, 0xb6, 0xa9, 0x6c, 0xa3,
+	0x07, 0x2b, 0xee, 0xc6, 0x8c, 0x94, 0x7d, 0xe8, 0xa6, 0xec, 0x72, 0x6b, 0xdb, 0x1c, 0x33, 0x95,
+	0x60, 0x6e, 0x2e, 0x3f, 0x83, 0x46, 0xc9, 0x0c, 0x37, 0xa9, 0x77, 0x01, 0x0c, 0xe8, 0xe4, 0xa3,
+	0x83, 0xa8, 0x57, 0xdd, 0x4c, 0x69, 0x9b, 0xde, 0xf7, 0x61, 0x5d, 0xb7, 0x72, 0x2a, 0x0b, 0xdf,
+	0x6d, 0x9c, 0x16, 0x5e, 0x0f, 0x2c, 0x85, 0x9e, 0xc3, 0x86, 0xc3, 0x6b, 0x23, 0x77, 0x1f, 0x16,
+	0x34, 0x60, 0xe3, 0xb6, 0xd9, 0x4c, 0x7f, 0xce, 0xe8, 0x72, 0x76, 0x35, 0x31, 0x45, 0x18, 0x18,
+	0x96, 0xf3, 0x45, 0xfd, 0x13, 0xc7, 0xa3, 0x3f, 0x06, 0x00, 0xcb, 0x47, 0x02, 0x75, 0xd4, 0x11,
+	0x00, 0x00,
 }
@@ -80,6 +80,50 @@ func (this *ListProcessesResponse) Validate() error {
 	}
 	return nil
 }
+func (this *DataSourceMaintenance) Validate() error {
+	return nil
+}
+func (this *DataSourceMaintenanceCollection) Validate() error {
+	for _, item := range this.Windows {
+		if item != nil {
+			if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(item); err != nil {
+				return github_com_mwitkow_go_proto_validators.FieldError("Windows", err)
+			}
+		}
+	}
+	if this.Active != nil {
+		if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(this.Active); err != nil {
+			return github_com_mwitkow_go_proto_validators.FieldError("Active", err)
+		}
+	}
+	return nil
+}
+func (this *ListDataSourcesMaintenanceRequest) Validate() error {
+	return nil
+}
+func (this *DataSourcesMaintenanceCollection) Validate() error {
+	for _, item := range this.DataSources {
+		if item != nil {
+			if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(item); err != nil {
+				return github_com_mwitkow_go_proto_validators.FieldError("DataSources", err)
+			}
+		}
+	}
+	return nil
+}
+func (this *DataSourceMaintenanceRequest) Validate() error {
+	return nil
+}
+func (this *PutDataSourceMaintenanceRequest) Validate() error {
+	for _, item := range this.Windows {
+		if item != nil {
+			if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(item); err != nil {
+				return github_com_mwitkow_go_proto_validators.FieldError("Windows", err)
+			}
+		}
+	}
+	return nil
+}
 func (this *ListVersioningPolicyRequest) Validate() error {
 	return nil
 }
@@ -93,9 +137,51 @@ func (this *VersioningPolicyCollection) Validate() error {
 	}
 	return nil
 }
+func (this *VersioningSimulationRequest) Validate() error {
+	if this.Policy != nil {
+		if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(this.Policy); err != nil {
+			return github_com_mwitkow_go_proto_validators.FieldError("Policy", err)
+		}
+	}
+	return nil
+}
+func (this *VersioningSimulation) Validate() error {
+	for _, item := range this.Nodes {
+		if item != nil {
+			if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(item); err != nil {
+				return github_com_mwitkow_go_proto_validators.FieldError("Nodes", err)
+			}
+		}
+	}
+	return nil
+}
+func (this *SimulatedNode) Validate() error {
+	for _, item := range this.Pruned {
+		if item != nil {
+			if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(item); err != nil {
+				return github_com_mwitkow_go_proto_validators.FieldError("Pruned", err)
+			}
+		}
+	}
+	return nil
+}
+func (this *SimulatedVersion) Validate() error {
+	return nil
+}
 func (this *ListVirtualNodesRequest) Validate() error {
 	return nil
 }
+func (this *VirtualNodePreviewRequest) Validate() error {
+	if this.Node != nil {
+		if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(this.Node); err != nil {
+			return github_com_mwitkow_go_proto_validators.FieldError("Node", err)
+		}
+	}
+	return nil
+}
+func (this *VirtualNodePreview) Validate() error {
+	return nil
+}
 func (this *ListServiceRequest) Validate() error {
 	return nil
 }
@@ -595,6 +595,68 @@ func (m *DocstoreCollection) GetTotal() int64 {
 	return 0
 }
 
+type DiffVersionsRequest struct {
+	// Path to the node, as seen by the user
+	Path string `protobuf:"bytes,1,opt,name=Path" json:"Path,omitempty"`
+	// Original version, leave empty for current content
+	LeftVersionId string `protobuf:"bytes,2,opt,name=LeftVersionId" json:"LeftVersionId,omitempty"`
+	// Modified version, leave empty for current content
+	RightVersionId string `protobuf:"bytes,3,opt,name=RightVersionId" json:"RightVersionId,omitempty"`
+	// Either unified (default) or words
+	Format string `protobuf:"bytes,4,opt,name=Format" json:"Format,omitempty"`
+	// Number of context lines for unified format
+	Context int32 `protobuf:"varint,5,opt,name=Context" json:"Context,omitempty"`
+}
+
+func (m *DiffVersionsRequest) Reset()                    { *m = DiffVersionsRequest{} }
+func (m *DiffVersionsRequest) String() string            { return proto.CompactTextString(m) }
+func (*DiffVersionsRequest) ProtoMessage()               {}
+func (*DiffVersionsRequest) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{20} }
+
+func (m *DiffVersionsRequest) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *DiffVersionsRequest) GetLeftVersionId() string {
+	if m != nil {
+		return m.LeftVersionId
+	}
+	return ""
+}
+
+func (m *DiffVersionsRequest) GetRightVersionId() string {
+	if m != nil {
+		return m.RightVersionId
+	}
+	return ""
+}
+
+func (m *DiffVersionsRequest) GetFormat() string {
+	if m != nil {
+		return m.Format
+	}
+	return ""
+}
+
+func (m *DiffVersionsRequest) GetContext() int32 {
+	if m != nil {
+		return m.Context
+	}
+	return 0
+}
+
+// Not used, endpoint returns text/plain
+type DiffVersionsResponse struct {
+}
+
+func (m *DiffVersionsResponse) Reset()                    { *m = DiffVersionsResponse{} }
+func (m *DiffVersionsResponse) String() string            { return proto.CompactTextString(m) }
+func (*DiffVersionsResponse) ProtoMessage()               {}
+func (*DiffVersionsResponse) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{21} }
+
 func init() {
 	proto.RegisterType((*SearchResults)(nil), "rest.SearchResults")
 	proto.RegisterType((*Pagination)(nil), "rest.Pagination")
@@ -616,65 +678,71 @@ func init() {
 	proto.RegisterType((*RestoreNodesResponse)(nil), "rest.RestoreNodesResponse")
 	proto.RegisterType((*ListDocstoreRequest)(nil), "rest.ListDocstoreRequest")
 	proto.RegisterType((*DocstoreCollection)(nil), "rest.DocstoreCollection")
+	proto.RegisterType((*DiffVersionsRequest)(nil), "rest.DiffVersionsRequest")
+	proto.RegisterType((*DiffVersionsResponse)(nil), "rest.DiffVersionsResponse")
 }
 
 func init() { proto.RegisterFile("data.proto", fileDescriptor3) }
 
 var fileDescriptor3 = []byte{
-	// 868 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x56, 0x5b, 0x6f, 0x1b, 0x45,
-	0x14, 0x96, 0xe3, 0xd8, 0xb1, 0x8f, 0x69, 0x9b, 0x4e, 0xa2, 0x76, 0x89, 0xaa, 0x2a, 0x1a, 0x85,
-	0xaa, 0xa0, 0x62, 0xa3, 0xf4, 0x01, 0xa1, 0x3e, 0xb5, 0xb6, 0xb8, 0x44, 0x25, 0x5d, 0xc6, 0x0d,
-	0x0f, 0x20, 0x1e, 0xc6, 0xbb, 0x27, 0xf6, 0xaa, 0xbb, 0x3b, 0x66, 0x66, 0xd6, 0x4a, 0x24, 0x9e,
-	0xf8, 0x35, 0xfc, 0x2c, 0x7e, 0x0a, 0x9a, 0xcb, 0xde, 0x64, 0x03, 0x41, 0xe2, 0x25, 0x99, 0xf3,
-	0x9d, 0xef, 0xdc, 0xcf, 0xce, 0x18, 0x20, 0xe6, 0x9a, 0x8f, 0xd7, 0x52, 0x68, 0x41, 0xf6, 0x25,
-	0x2a, 0x7d, 0xf2, 0x72, 0x99, 0xe8, 0x55, 0xb1, 0x18, 0x47, 0x22, 0x9b, 0xac, 0x6f, 0xe3, 0x44,
-	0x4c, 0x22, 0x4c, 0x53, 0x35, 0x89, 0x44, 0x96, 0x89, 0x7c, 0x62, 0xa9, 0x13, 0x2d, 0x11, 0xed,
-	0x1f, 0x67, 0x7a, 0xf2, 0xea, 0x2e, 0x46, 0xb1, 0x88, 0x94, 0x16, 0x12, 0xab, 0x83, 0x33, 0xa6,
-	0x1b, 0xb8, 0x37, 0x47, 0x2e, 0xa3, 0x15, 0x43, 0x55, 0xa4, 0x5a, 0x91, 0x33, 0x38, 0xf0, 0xc7,
-	0xa0, 0x73, 0xda, 0x7d, 0x3e, 0x3a, 0x87, 0xb1, 0x8d, 0x75, 0x29, 0x62, 0x64, 0xa5, 0x8a, 0x7c,
-	0x0a, 0xfd, 0xaf, 0x79, 0x84, 0x5a, 0x05, 0x5d, 0x4b, 0x7a, 0xe8, 0x48, 0xce, 0x95, 0xd5, 0x30,
-	0x4f, 0x20, 0xc7, 0xd0, 0x7b, 0x2f, 0x34, 0x4f, 0x83, 0xbd, 0xd3, 0xce, 0xf3, 0x1e, 0x73, 0x02,
-	0xfd, 0xb3, 0x03, 0x10, 0xf2, 0x65, 0x92, 0x73, 0x9d, 0x88, 0xdc, 0x90, 0xde, 0x26, 0x59, 0xa2,
-	0x83, 0x8e, 0x23, 0x59, 0x81, 0x9c, 0xc1, 0xbd, 0x69, 0x21, 0x25, 0xe6, 0xfa, 0xdd, 0xf5, 0xb5,
-	0x42, 0xed, 0x5d, 0xb4, 0xc1, 0x3a, 0x40, 0xb7, 0x11, 0x80, 0x9c, 0xc2, 0xc8, 0xd3, 0x42, 0xbe,
-	0xc4, 0x60, 0xdf, 0xea, 0x9a, 0x10, 0x79, 0x0a, 0x60, 0xa9, 0x46, 0x50, 0x41, 0xcf, 0x12, 0x1a,
-	0x88, 0xd1, 0x5f, 0xe2, 0x4d, 0x19, 0xba, 0xef, 0xf4, 0x35, 0x62, 0xf4, 0xa1, 0xc4, 0x8d, 0xd7,
-	0x1f, 0x38, 0x7d, 0x8d, 0xd0, 0x19, 0x0c, 0xbe, 0x47, 0xcd, 0xcd, 0x90, 0xc9, 0x13, 0x18, 0x5e,
-	0xf2, 0x0c, 0xd5, 0x9a, 0x47, 0x68, 0x6b, 0x1c, 0xb2, 0x1a, 0x20, 0x27, 0x30, 0xb8, 0x50, 0x22,
-	0x37, 0x6c, 0x5b, 0xe2, 0x90, 0x55, 0x32, 0xfd, 0x09, 0xee, 0x9b, 0xff, 0x53, 0x91, 0xa6, 0x18,
-	0xd9, 0x5e, 0x9d, 0xc0, 0xc0, 0x0c, 0x23, 0xe4, 0x7a, 0xe5, 0x5d, 0x55, 0x32, 0x79, 0x01, 0xc3,
-	0x32, 0xa6, 0x0a, 0xf6, 0xec, 0x68, 0xee, 0x8f, 0xcd, 0x6a, 0x8d, 0x4b, 0x98, 0xd5, 0x04, 0x1a,
-	0xc2, 0xb1, 0x11, 0xaa, 0x44, 0x18, 0xfe, 0x5a, 0xa0, 0xd2, 0xff, 0x18, 0xa1, 0x55, 0x89, 0x89,
-	0xd0, 0xac, 0x84, 0xfe, 0xd1, 0x01, 0xf2, 0x0d, 0xea, 0x37, 0x45, 0xfa, 0xc1, 0x78, 0x2e, 0x1d,
-	0x1a, 0x23, 0xef, 0xc0, 0xad, 0x95, 0x31, 0x2a, 0x01, 0xf2, 0x19, 0x1c, 0xbe, 0x4e, 0x53, 0xc3,
-	0x0f, 0xa5, 0xd8, 0x24, 0x31, 0x4a, 0x65, 0x67, 0x39, 0x60, 0x5b, 0xb8, 0x49, 0xed, 0x47, 0x94,
-	0x2a, 0x11, 0xb9, 0xb2, 0x33, 0x1d, 0xb0, 0x4a, 0x26, 0x8f, 0xa0, 0xef, 0x87, 0xe1, 0x86, 0xd9,
-	0xaf, 0x17, 0xc4, 0x2d, 0x57, 0xbf, 0xb1, 0x5c, 0xf4, 0x1a, 0x0e, 0xeb, 0x34, 0xd5, 0x5a, 0xe4,
-	0x0a, 0xc9, 0x29, 0xf4, 0x4c, 0x5a, 0xbb, 0x56, 0xdf, 0x29, 0xc8, 0x17, 0xcd, 0xb5, 0xb5, 0x71,
-	0x46, 0xe7, 0x87, 0xae, 0xc3, 0x35, 0xce, 0x1a, 0x1c, 0xfa, 0x09, 0x3c, 0xf8, 0x16, 0x79, 0x6c,
-	0x9d, 0xf8, 0x76, 0x10, 0xd8, 0x37, 0xa2, 0xef, 0xad, 0x3d, 0xd3, 0x73, 0x38, 0xac, 0x69, 0x3e,
-	0x9d, 0xa7, 0x0d, 0x5e, 0x3b, 0x1b, 0x67, 0x73, 0x03, 0x64, 0x2a, 0x91, 0x6b, 0xb4, 0xb9, 0x95,
-	0xde, 0xff, 0xbd, 0x88, 0x27, 0x30, 0x64, 0x18, 0x15, 0x52, 0x25, 0x1b, 0xb4, 0x0b, 0x37, 0x60,
-	0x35, 0x40, 0x28, 0x7c, 0xf4, 0x1e, 0xb3, 0x75, 0xca, 0x35, 0x5e, 0x5d, 0x7d, 0x37, 0xb3, 0xa3,
-	0x18, 0xb2, 0x16, 0x46, 0x6f, 0xe0, 0x91, 0x8b, 0x3c, 0x47, 0xbf, 0x96, 0x77, 0x8f, 0x6e, 0xfc,
-	0x73, 0xb9, 0x44, 0xfd, 0xda, 0x1a, 0xfa, 0x8d, 0x6f, 0x61, 0x24, 0x80, 0x83, 0xd0, 0x8c, 0x55,
-	0x69, 0xbf, 0x09, 0xa5, 0x48, 0x39, 0x3c, 0xde, 0x8a, 0xec, 0xdb, 0x75, 0x66, 0xee, 0x32, 0x0f,
-	0xda, 0xcc, 0x5d, 0x7f, 0xdb, 0x60, 0x9d, 0xe0, 0xde, 0xdf, 0x24, 0x48, 0x7f, 0x81, 0x07, 0xf6,
-	0xd0, 0xf8, 0xe6, 0x28, 0xf4, 0x43, 0x6e, 0x6e, 0x8e, 0x1d, 0xb3, 0xf0, 0x1a, 0xf2, 0x0c, 0x06,
-	0xd3, 0x55, 0x92, 0xc6, 0x12, 0xf3, 0x1d, 0xbe, 0x2b, 0x1d, 0xfd, 0xbd, 0x03, 0x64, 0x86, 0x29,
-	0xfe, 0xcf, 0x63, 0x7b, 0x01, 0x0f, 0x19, 0x66, 0x62, 0x83, 0x21, 0xca, 0x8c, 0xe7, 0x98, 0xeb,
-	0xf4, 0xd6, 0x37, 0x6f, 0x5b, 0x41, 0x7f, 0x86, 0xa3, 0x37, 0x3c, 0xfa, 0xb0, 0x94, 0xa2, 0xc8,
-	0xe3, 0x0b, 0xb1, 0x70, 0x17, 0xbb, 0xd9, 0xcc, 0xab, 0x22, 0x89, 0xcb, 0xcd, 0x34, 0x67, 0xfb,
-	0xf9, 0xf0, 0x05, 0xa6, 0x7e, 0x50, 0x4e, 0x28, 0xef, 0x08, 0xcb, 0xee, 0xd6, 0x77, 0x84, 0x91,
-	0x69, 0x08, 0x47, 0xad, 0x02, 0xfd, 0x7c, 0xbe, 0x02, 0x70, 0xf0, 0x85, 0x58, 0x94, 0x65, 0x7e,
-	0xec, 0xbe, 0x9d, 0x1d, 0xb9, 0xb0, 0x06, 0x99, 0x7e, 0x09, 0x47, 0x0c, 0xed, 0xbb, 0xf5, 0xdf,
-	0x7a, 0x46, 0xe7, 0x70, 0xdc, 0x36, 0xf4, 0xb9, 0xbc, 0x82, 0x91, 0xc7, 0xef, 0x96, 0x4c, 0x93,
-	0x4d, 0x7f, 0x83, 0xa3, 0xb7, 0x89, 0xd2, 0x33, 0xff, 0x94, 0x96, 0xd9, 0x04, 0x70, 0x30, 0x37,
-	0x72, 0xb5, 0x79, 0xa5, 0x48, 0x3e, 0x87, 0xde, 0x0f, 0x05, 0xca, 0x5b, 0xdb, 0xc2, 0xd1, 0xf9,
-	0xe3, 0x71, 0xf5, 0x0a, 0xcf, 0x44, 0x54, 0x64, 0x98, 0x6b, 0xab, 0x66, 0x8e, 0x65, 0x06, 0x3d,
-	0x15, 0x45, 0xae, 0xdf, 0xe5, 0xd5, 0x08, 0x6b, 0x80, 0x32, 0x20, 0x65, 0xe4, 0xc6, 0x86, 0x3e,
-	0x83, 0x7d, 0x83, 0xfa, 0x4a, 0xc8, 0x76, 0x04, 0x66, 0xf5, 0xed, 0xe7, 0xb8, 0xeb, 0x5f, 0xcb,
-	0x45, 0xdf, 0xfe, 0x1a, 0x78, 0xf9, 0x57, 0x00, 0x00, 0x00, 0xff, 0xff, 0xf4, 0xaa, 0x0c, 0xab,
-	0x93, 0x08, 0x00, 0x00,
+	// 937 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x56, 0x5f, 0x6f, 0x1b, 0x45,
+	0x10, 0x97, 0xeb, 0xd8, 0xb1, 0xc7, 0x24, 0x4d, 0xd7, 0x51, 0x6a, 0xa2, 0xaa, 0x8a, 0x56, 0x21,
+	0x2a, 0xa8, 0xd8, 0x28, 0x7d, 0x40, 0xa8, 0x4f, 0xad, 0xad, 0x42, 0xa3, 0x90, 0x1e, 0x9b, 0x86,
+	0x07, 0x10, 0x0f, 0xeb, 0xbb, 0xb1, 0x7d, 0xea, 0xdd, 0xad, 0xd9, 0xdd, 0xb3, 0x12, 0x89, 0x27,
+	0x3e, 0x0d, 0x7c, 0x2b, 0x3e, 0x0a, 0xda, 0x3f, 0xf7, 0x8f, 0x04, 0x08, 0x12, 0x2f, 0xc9, 0xce,
+	0x6f, 0x7e, 0x3b, 0x33, 0x3b, 0xf3, 0xbb, 0x5d, 0x03, 0x44, 0x5c, 0xf3, 0xf1, 0x5a, 0x0a, 0x2d,
+	0xc8, 0x96, 0x44, 0xa5, 0x0f, 0x5f, 0x2c, 0x63, 0xbd, 0xca, 0xe7, 0xe3, 0x50, 0xa4, 0x93, 0xf5,
+	0x4d, 0x14, 0x8b, 0x49, 0x88, 0x49, 0xa2, 0x26, 0xa1, 0x48, 0x53, 0x91, 0x4d, 0x2c, 0x75, 0xa2,
+	0x25, 0xa2, 0xfd, 0xe3, 0xb6, 0x1e, 0xbe, 0xbc, 0xcf, 0xa6, 0x48, 0x84, 0x4a, 0x0b, 0x89, 0xe5,
+	0xc2, 0x6d, 0xa6, 0x1b, 0xd8, 0xb9, 0x44, 0x2e, 0xc3, 0x15, 0x43, 0x95, 0x27, 0x5a, 0x91, 0x63,
+	0xd8, 0xf6, 0xcb, 0x51, 0xeb, 0xa8, 0xfd, 0x6c, 0x70, 0x0a, 0x63, 0x9b, 0xeb, 0x42, 0x44, 0xc8,
+	0x0a, 0x17, 0xf9, 0x14, 0xba, 0x6f, 0x78, 0x88, 0x5a, 0x8d, 0xda, 0x96, 0xf4, 0xc8, 0x91, 0x5c,
+	0x28, 0xeb, 0x61, 0x9e, 0x40, 0xf6, 0xa1, 0xf3, 0x5e, 0x68, 0x9e, 0x8c, 0x1e, 0x1c, 0xb5, 0x9e,
+	0x75, 0x98, 0x33, 0xe8, 0x1f, 0x2d, 0x80, 0x80, 0x2f, 0xe3, 0x8c, 0xeb, 0x58, 0x64, 0x86, 0x74,
+	0x1e, 0xa7, 0xb1, 0x1e, 0xb5, 0x1c, 0xc9, 0x1a, 0xe4, 0x18, 0x76, 0xa6, 0xb9, 0x94, 0x98, 0xe9,
+	0x77, 0x8b, 0x85, 0x42, 0xed, 0x43, 0x34, 0xc1, 0x2a, 0x41, 0xbb, 0x96, 0x80, 0x1c, 0xc1, 0xc0,
+	0xd3, 0x02, 0xbe, 0xc4, 0xd1, 0x96, 0xf5, 0xd5, 0x21, 0xf2, 0x14, 0xc0, 0x52, 0x8d, 0xa1, 0x46,
+	0x1d, 0x4b, 0xa8, 0x21, 0xc6, 0x7f, 0x81, 0xd7, 0x45, 0xea, 0xae, 0xf3, 0x57, 0x88, 0xf1, 0x07,
+	0x12, 0x37, 0xde, 0xbf, 0xed, 0xfc, 0x15, 0x42, 0x67, 0xd0, 0xfb, 0x16, 0x35, 0x37, 0x43, 0x26,
+	0x4f, 0xa0, 0x7f, 0xc1, 0x53, 0x54, 0x6b, 0x1e, 0xa2, 0x3d, 0x63, 0x9f, 0x55, 0x00, 0x39, 0x84,
+	0xde, 0x99, 0x12, 0x99, 0x61, 0xdb, 0x23, 0xf6, 0x59, 0x69, 0xd3, 0x1f, 0x60, 0xd7, 0xfc, 0x9f,
+	0x8a, 0x24, 0xc1, 0xd0, 0xf6, 0xea, 0x10, 0x7a, 0x66, 0x18, 0x01, 0xd7, 0x2b, 0x1f, 0xaa, 0xb4,
+	0xc9, 0x73, 0xe8, 0x17, 0x39, 0xd5, 0xe8, 0x81, 0x1d, 0xcd, 0xee, 0xd8, 0x48, 0x6b, 0x5c, 0xc0,
+	0xac, 0x22, 0xd0, 0x00, 0xf6, 0x8d, 0x51, 0x16, 0xc2, 0xf0, 0xe7, 0x1c, 0x95, 0xfe, 0xc7, 0x0c,
+	0x8d, 0x93, 0x98, 0x0c, 0xf5, 0x93, 0xd0, 0xdf, 0x5a, 0x40, 0xbe, 0x46, 0xfd, 0x3a, 0x4f, 0x3e,
+	0x98, 0xc8, 0x45, 0x40, 0xb3, 0xc9, 0x07, 0x70, 0xb2, 0xea, 0xb3, 0x0a, 0x20, 0x9f, 0xc1, 0xde,
+	0xab, 0x24, 0x31, 0xfc, 0x40, 0x8a, 0x4d, 0x1c, 0xa1, 0x54, 0x76, 0x96, 0x3d, 0x76, 0x0b, 0x37,
+	0xa5, 0x7d, 0x8f, 0x52, 0xc5, 0x22, 0x53, 0x76, 0xa6, 0x3d, 0x56, 0xda, 0xe4, 0x00, 0xba, 0x7e,
+	0x18, 0x6e, 0x98, 0xdd, 0x4a, 0x20, 0x4e, 0x5c, 0xdd, 0x9a, 0xb8, 0xe8, 0x02, 0xf6, 0xaa, 0x32,
+	0xd5, 0x5a, 0x64, 0x0a, 0xc9, 0x11, 0x74, 0x4c, 0x59, 0x77, 0x49, 0xdf, 0x39, 0xc8, 0x17, 0x75,
+	0xd9, 0xda, 0x3c, 0x83, 0xd3, 0x3d, 0xd7, 0xe1, 0x0a, 0x67, 0x35, 0x0e, 0xfd, 0x04, 0x1e, 0x7e,
+	0x83, 0x3c, 0xb2, 0x41, 0x7c, 0x3b, 0x08, 0x6c, 0x19, 0xd3, 0xf7, 0xd6, 0xae, 0xe9, 0x29, 0xec,
+	0x55, 0x34, 0x5f, 0xce, 0xd3, 0x1a, 0xaf, 0x59, 0x8d, 0xdb, 0x73, 0x0d, 0x64, 0x2a, 0x91, 0x6b,
+	0x34, 0x96, 0x2a, 0xa2, 0xff, 0xfb, 0x21, 0x9e, 0x40, 0x9f, 0x61, 0x98, 0x4b, 0x15, 0x6f, 0xd0,
+	0x0a, 0xae, 0xc7, 0x2a, 0x80, 0x50, 0xf8, 0xe8, 0x3d, 0xa6, 0xeb, 0x84, 0x6b, 0xbc, 0xba, 0x7a,
+	0x3b, 0xb3, 0xa3, 0xe8, 0xb3, 0x06, 0x46, 0xaf, 0xe1, 0xc0, 0x65, 0xbe, 0x44, 0x2f, 0xcb, 0xfb,
+	0x67, 0x37, 0xf1, 0xb9, 0x5c, 0xa2, 0x7e, 0x65, 0x37, 0x7a, 0xc5, 0x37, 0x30, 0x32, 0x82, 0xed,
+	0xc0, 0x8c, 0x55, 0x69, 0xaf, 0x84, 0xc2, 0xa4, 0x1c, 0x1e, 0xdf, 0xca, 0xec, 0xdb, 0x75, 0x0c,
+	0x3b, 0x25, 0x68, 0x2b, 0x77, 0xfd, 0x6d, 0x82, 0x55, 0x81, 0x0f, 0xfe, 0xa6, 0x40, 0xfa, 0x13,
+	0x3c, 0xb4, 0x8b, 0xda, 0x37, 0x47, 0xa1, 0x1b, 0x70, 0x73, 0x73, 0xdc, 0x31, 0x0b, 0xef, 0x21,
+	0x27, 0xd0, 0x9b, 0xae, 0xe2, 0x24, 0x92, 0x98, 0xdd, 0x11, 0xbb, 0xf4, 0xd1, 0x5f, 0x5b, 0x40,
+	0x66, 0x98, 0xe0, 0xff, 0x3c, 0xb6, 0xe7, 0xf0, 0x88, 0x61, 0x2a, 0x36, 0x18, 0xa0, 0x4c, 0x79,
+	0x86, 0x99, 0x4e, 0x6e, 0x7c, 0xf3, 0x6e, 0x3b, 0xe8, 0x8f, 0x30, 0x7c, 0xcd, 0xc3, 0x0f, 0x4b,
+	0x29, 0xf2, 0x2c, 0x3a, 0x13, 0x73, 0x77, 0xb1, 0x1b, 0x65, 0x5e, 0xe5, 0x71, 0x54, 0x28, 0xd3,
+	0xac, 0xed, 0xe7, 0xc3, 0xe7, 0x98, 0xf8, 0x41, 0x39, 0xa3, 0xb8, 0x23, 0x2c, 0xbb, 0x5d, 0xdd,
+	0x11, 0xc6, 0xa6, 0x01, 0x0c, 0x1b, 0x07, 0xf4, 0xf3, 0xf9, 0x0a, 0xc0, 0xc1, 0x67, 0x62, 0x5e,
+	0x1c, 0xf3, 0x63, 0xf7, 0xed, 0xdc, 0x51, 0x0b, 0xab, 0x91, 0xe9, 0x97, 0x30, 0x64, 0x68, 0xdf,
+	0xad, 0xff, 0xd6, 0x33, 0x7a, 0x09, 0xfb, 0xcd, 0x8d, 0xbe, 0x96, 0x97, 0x30, 0xf0, 0xf8, 0xfd,
+	0x8a, 0xa9, 0xb3, 0xe9, 0x2f, 0x30, 0x3c, 0x8f, 0x95, 0x9e, 0xf9, 0xa7, 0xb4, 0xa8, 0x66, 0x04,
+	0xdb, 0x97, 0xc6, 0x2e, 0x95, 0x57, 0x98, 0xe4, 0x73, 0xe8, 0x7c, 0x97, 0xa3, 0xbc, 0xb1, 0x2d,
+	0x1c, 0x9c, 0x3e, 0x1e, 0x97, 0xaf, 0xf0, 0x4c, 0x84, 0x79, 0x8a, 0x99, 0xb6, 0x6e, 0xe6, 0x58,
+	0x66, 0xd0, 0x53, 0x91, 0x67, 0xfa, 0x5d, 0x56, 0x8e, 0xb0, 0x02, 0x28, 0x03, 0x52, 0x64, 0xae,
+	0x29, 0xf4, 0x04, 0xb6, 0x0c, 0xea, 0x4f, 0x42, 0x6e, 0x67, 0x60, 0xd6, 0xdf, 0x7c, 0x8e, 0xdb,
+	0xc5, 0x73, 0xfc, 0x7b, 0x0b, 0x86, 0xb3, 0x78, 0xb1, 0x28, 0xee, 0xd2, 0xda, 0x4d, 0x55, 0x7b,
+	0x05, 0xec, 0xda, 0x7c, 0x66, 0xe7, 0xb8, 0xd0, 0x9e, 0xfa, 0x36, 0xf2, 0xba, 0x68, 0x82, 0xe4,
+	0x04, 0x76, 0x59, 0xbc, 0x5c, 0xd5, 0x68, 0x4e, 0x25, 0x7f, 0x41, 0xcd, 0xa5, 0xfd, 0x46, 0xc8,
+	0x94, 0x6b, 0x7b, 0x9d, 0xf7, 0x99, 0xb7, 0x4c, 0x33, 0xa7, 0x22, 0xd3, 0x78, 0x5d, 0xdc, 0xe6,
+	0x85, 0x49, 0x0f, 0x60, 0xbf, 0x59, 0xaa, 0x1b, 0xe9, 0xbc, 0x6b, 0x7f, 0xd1, 0xbc, 0xf8, 0x73,
+	0x00, 0x12, 0x64, 0x1d, 0xf1, 0x57, 0x09, 0x00, 0x00,
 }
@@ -186,3 +186,9 @@ func (this *DocstoreCollection) Validate() error {
 	}
 	return nil
 }
+func (this *DiffVersionsRequest) Validate() error {
+	return nil
+}
+func (this *DiffVersionsResponse) Validate() error {
+	return nil
+}
@@ -829,6 +829,521 @@ func (m *DocumentAccessTokenResponse) GetAccessToken() string {
 	return ""
 }
 
+type UserSelfServiceRequest struct {
+}
+
+func (m *UserSelfServiceRequest) Reset()                    { *m = UserSelfServiceRequest{} }
+func (m *UserSelfServiceRequest) String() string            { return proto.CompactTextString(m) }
+func (*UserSelfServiceRequest) ProtoMessage()               {}
+func (*UserSelfServiceRequest) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{27} }
+
+type UserConfirmDeletionRequest struct {
+	// Code received by email
+	Token string `protobuf:"bytes,1,opt,name=Token" json:"Token,omitempty"`
+}
+
+func (m *UserConfirmDeletionRequest) Reset()                    { *m = UserConfirmDeletionRequest{} }
+func (m *UserConfirmDeletionRequest) String() string            { return proto.CompactTextString(m) }
+func (*UserConfirmDeletionRequest) ProtoMessage()               {}
+func (*UserConfirmDeletionRequest) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{28} }
+
+func (m *UserConfirmDeletionRequest) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+type UserSelfServiceResponse struct {
+	Success bool   `protobuf:"varint,1,opt,name=Success" json:"Success,omitempty"`
+	Message string `protobuf:"bytes,2,opt,name=Message" json:"Message,omitempty"`
+	// Job building the export archive
+	JobUuid string `protobuf:"bytes,3,opt,name=JobUuid" json:"JobUuid,omitempty"`
+	// Unix timestamp of the scheduled deletion
+	DeletionDate int64 `protobuf:"varint,4,opt,name=DeletionDate" json:"DeletionDate,omitempty"`
+}
+
+func (m *UserSelfServiceResponse) Reset()                    { *m = UserSelfServiceResponse{} }
+func (m *UserSelfServiceResponse) String() string            { return proto.CompactTextString(m) }
+func (*UserSelfServiceResponse) ProtoMessage()               {}
+func (*UserSelfServiceResponse) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{29} }
+
+func (m *UserSelfServiceResponse) GetSuccess() bool {
+	if m != nil {
+		return m.Success
+	}
+	return false
+}
+
+func (m *UserSelfServiceResponse) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *UserSelfServiceResponse) GetJobUuid() string {
+	if m != nil {
+		return m.JobUuid
+	}
+	return ""
+}
+
+func (m *UserSelfServiceResponse) GetDeletionDate() int64 {
+	if m != nil {
+		return m.DeletionDate
+	}
+	return 0
+}
+
+type Campaign struct {
+	Uuid        string `protobuf:"bytes,1,opt,name=Uuid" json:"Uuid,omitempty"`
+	Label       string `protobuf:"bytes,2,opt,name=Label" json:"Label,omitempty"`
+	Description string `protobuf:"bytes,3,opt,name=Description" json:"Description,omitempty"`
+	// One of workspaces, cells, links or group
+	Scope string `protobuf:"bytes,4,opt,name=Scope" json:"Scope,omitempty"`
+	// Restrict the review to these workspaces
+	WorkspaceUuids []string `protobuf:"bytes,5,rep,name=WorkspaceUuids" json:"WorkspaceUuids,omitempty"`
+	// Group reviewed by a group campaign
+	GroupPath string `protobuf:"bytes,6,opt,name=GroupPath" json:"GroupPath,omitempty"`
+	// Uuid of the user reviewing grants without owner, defaults to the creator
+	Reviewer string `protobuf:"bytes,7,opt,name=Reviewer" json:"Reviewer,omitempty"`
+	// Decision applied to pending tasks at the deadline, approve or revoke
+	DefaultDecision string            `protobuf:"bytes,8,opt,name=DefaultDecision" json:"DefaultDecision,omitempty"`
+	Deadline        int64             `protobuf:"varint,9,opt,name=Deadline" json:"Deadline,omitempty"`
+	Status          string            `protobuf:"bytes,10,opt,name=Status" json:"Status,omitempty"`
+	Creator         string            `protobuf:"bytes,11,opt,name=Creator" json:"Creator,omitempty"`
+	Created         int64             `protobuf:"varint,12,opt,name=Created" json:"Created,omitempty"`
+	Started         int64             `protobuf:"varint,13,opt,name=Started" json:"Started,omitempty"`
+	Closed          int64             `protobuf:"varint,14,opt,name=Closed" json:"Closed,omitempty"`
+	Tasks           []*ReviewTask     `protobuf:"bytes,15,rep,name=Tasks" json:"Tasks,omitempty"`
+	Progress        *CampaignProgress `protobuf:"bytes,16,opt,name=Progress" json:"Progress,omitempty"`
+}
+
+func (m *Campaign) Reset()                    { *m = Campaign{} }
+func (m *Campaign) String() string            { return proto.CompactTextString(m) }
+func (*Campaign) ProtoMessage()               {}
+func (*Campaign) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{30} }
+
+func (m *Campaign) GetUuid() string {
+	if m != nil {
+		return m.Uuid
+	}
+	return ""
+}
+
+func (m *Campaign) GetLabel() string {
+	if m != nil {
+		return m.Label
+	}
+	return ""
+}
+
+func (m *Campaign) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *Campaign) GetScope() string {
+	if m != nil {
+		return m.Scope
+	}
+	return ""
+}
+
+func (m *Campaign) GetWorkspaceUuids() []string {
+	if m != nil {
+		return m.WorkspaceUuids
+	}
+	return nil
+}
+
+func (m *Campaign) GetGroupPath() string {
+	if m != nil {
+		return m.GroupPath
+	}
+	return ""
+}
+
+func (m *Campaign) GetReviewer() string {
+	if m != nil {
+		return m.Reviewer
+	}
+	return ""
+}
+
+func (m *Campaign) GetDefaultDecision() string {
+	if m != nil {
+		return m.DefaultDecision
+	}
+	return ""
+}
+
+func (m *Campaign) GetDeadline() int64 {
+	if m != nil {
+		return m.Deadline
+	}
+	return 0
+}
+
+func (m *Campaign) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *Campaign) GetCreator() string {
+	if m != nil {
+		return m.Creator
+	}
+	return ""
+}
+
+func (m *Campaign) GetCreated() int64 {
+	if m != nil {
+		return m.Created
+	}
+	return 0
+}
+
+func (m *Campaign) GetStarted() int64 {
+	if m != nil {
+		return m.Started
+	}
+	return 0
+}
+
+func (m *Campaign) GetClosed() int64 {
+	if m != nil {
+		return m.Closed
+	}
+	return 0
+}
+
+func (m *Campaign) GetTasks() []*ReviewTask {
+	if m != nil {
+		return m.Tasks
+	}
+	return nil
+}
+
+func (m *Campaign) GetProgress() *CampaignProgress {
+	if m != nil {
+		return m.Progress
+	}
+	return nil
+}
+
+type CampaignProgress struct {
+	Total    int32 `protobuf:"varint,1,opt,name=Total" json:"Total,omitempty"`
+	Pending  int32 `protobuf:"varint,2,opt,name=Pending" json:"Pending,omitempty"`
+	Approved int32 `protobuf:"varint,3,opt,name=Approved" json:"Approved,omitempty"`
+	Revoked  int32 `protobuf:"varint,4,opt,name=Revoked" json:"Revoked,omitempty"`
+}
+
+func (m *CampaignProgress) Reset()                    { *m = CampaignProgress{} }
+func (m *CampaignProgress) String() string            { return proto.CompactTextString(m) }
+func (*CampaignProgress) ProtoMessage()               {}
+func (*CampaignProgress) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{31} }
+
+func (m *CampaignProgress) GetTotal() int32 {
+	if m != nil {
+		return m.Total
+	}
+	return 0
+}
+
+func (m *CampaignProgress) GetPending() int32 {
+	if m != nil {
+		return m.Pending
+	}
+	return 0
+}
+
+func (m *CampaignProgress) GetApproved() int32 {
+	if m != nil {
+		return m.Approved
+	}
+	return 0
+}
+
+func (m *CampaignProgress) GetRevoked() int32 {
+	if m != nil {
+		return m.Revoked
+	}
+	return 0
+}
+
+type CampaignRequest struct {
+	Uuid string `protobuf:"bytes,1,opt,name=Uuid" json:"Uuid,omitempty"`
+}
+
+func (m *CampaignRequest) Reset()                    { *m = CampaignRequest{} }
+func (m *CampaignRequest) String() string            { return proto.CompactTextString(m) }
+func (*CampaignRequest) ProtoMessage()               {}
+func (*CampaignRequest) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{32} }
+
+func (m *CampaignRequest) GetUuid() string {
+	if m != nil {
+		return m.Uuid
+	}
+	return ""
+}
+
+type ListCampaignsRequest struct {
+}
+
+func (m *ListCampaignsRequest) Reset()                    { *m = ListCampaignsRequest{} }
+func (m *ListCampaignsRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListCampaignsRequest) ProtoMessage()               {}
+func (*ListCampaignsRequest) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{33} }
+
+type CampaignCollection struct {
+	Campaigns []*Campaign `protobuf:"bytes,1,rep,name=Campaigns" json:"Campaigns,omitempty"`
+}
+
+func (m *CampaignCollection) Reset()                    { *m = CampaignCollection{} }
+func (m *CampaignCollection) String() string            { return proto.CompactTextString(m) }
+func (*CampaignCollection) ProtoMessage()               {}
+func (*CampaignCollection) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{34} }
+
+func (m *CampaignCollection) GetCampaigns() []*Campaign {
+	if m != nil {
+		return m.Campaigns
+	}
+	return nil
+}
+
+// Not used, endpoint returns text/csv
+type CampaignReportResponse struct {
+}
+
+func (m *CampaignReportResponse) Reset()                    { *m = CampaignReportResponse{} }
+func (m *CampaignReportResponse) String() string            { return proto.CompactTextString(m) }
+func (*CampaignReportResponse) ProtoMessage()               {}
+func (*CampaignReportResponse) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{35} }
+
+type ReviewTask struct {
+	Uuid string `protobuf:"bytes,1,opt,name=Uuid" json:"Uuid,omitempty"`
+	// Either acl or link
+	Kind           string `protobuf:"bytes,2,opt,name=Kind" json:"Kind,omitempty"`
+	WorkspaceUuid  string `protobuf:"bytes,3,opt,name=WorkspaceUuid" json:"WorkspaceUuid,omitempty"`
+	WorkspaceLabel string `protobuf:"bytes,4,opt,name=WorkspaceLabel" json:"WorkspaceLabel,omitempty"`
+	RoleId         string `protobuf:"bytes,5,opt,name=RoleId" json:"RoleId,omitempty"`
+	SubjectLabel   string `protobuf:"bytes,6,opt,name=SubjectLabel" json:"SubjectLabel,omitempty"`
+	Rights         string `protobuf:"bytes,7,opt,name=Rights" json:"Rights,omitempty"`
+	ReviewerUuid   string `protobuf:"bytes,8,opt,name=ReviewerUuid" json:"ReviewerUuid,omitempty"`
+	Reviewer       string `protobuf:"bytes,9,opt,name=Reviewer" json:"Reviewer,omitempty"`
+	Decision       string `protobuf:"bytes,10,opt,name=Decision" json:"Decision,omitempty"`
+	Comment        string `protobuf:"bytes,11,opt,name=Comment" json:"Comment,omitempty"`
+	DecidedBy      string `protobuf:"bytes,12,opt,name=DecidedBy" json:"DecidedBy,omitempty"`
+	DecidedAt      int64  `protobuf:"varint,13,opt,name=DecidedAt" json:"DecidedAt,omitempty"`
+	Applied        bool   `protobuf:"varint,14,opt,name=Applied" json:"Applied,omitempty"`
+	ApplyError     string `protobuf:"bytes,15,opt,name=ApplyError" json:"ApplyError,omitempty"`
+	CampaignUuid   string `protobuf:"bytes,16,opt,name=CampaignUuid" json:"CampaignUuid,omitempty"`
+	CampaignLabel  string `protobuf:"bytes,17,opt,name=CampaignLabel" json:"CampaignLabel,omitempty"`
+	Deadline       int64  `protobuf:"varint,18,opt,name=Deadline" json:"Deadline,omitempty"`
+}
+
+func (m *ReviewTask) Reset()                    { *m = ReviewTask{} }
+func (m *ReviewTask) String() string            { return proto.CompactTextString(m) }
+func (*ReviewTask) ProtoMessage()               {}
+func (*ReviewTask) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{36} }
+
+func (m *ReviewTask) GetUuid() string {
+	if m != nil {
+		return m.Uuid
+	}
+	return ""
+}
+
+func (m *ReviewTask) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
+}
+
+func (m *ReviewTask) GetWorkspaceUuid() string {
+	if m != nil {
+		return m.WorkspaceUuid
+	}
+	return ""
+}
+
+func (m *ReviewTask) GetWorkspaceLabel() string {
+	if m != nil {
+		return m.WorkspaceLabel
+	}
+	return ""
+}
+
+func (m *ReviewTask) GetRoleId() string {
+	if m != nil {
+		return m.RoleId
+	}
+	return ""
+}
+
+func (m *ReviewTask) GetSubjectLabel() string {
+	if m != nil {
+		return m.SubjectLabel
+	}
+	return ""
+}
+
+func (m *ReviewTask) GetRights() string {
+	if m != nil {
+		return m.Rights
+	}
+	return ""
+}
+
+func (m *ReviewTask) GetReviewerUuid() string {
+	if m != nil {
+		return m.ReviewerUuid
+	}
+	return ""
+}
+
+func (m *ReviewTask) GetReviewer() string {
+	if m != nil {
+		return m.Reviewer
+	}
+	return ""
+}
+
+func (m *ReviewTask) GetDecision() string {
+	if m != nil {
+		return m.Decision
+	}
+	return ""
+}
+
+func (m *ReviewTask) GetComment() string {
+	if m != nil {
+		return m.Comment
+	}
+	return ""
+}
+
+func (m *ReviewTask) GetDecidedBy() string {
+	if m != nil {
+		return m.DecidedBy
+	}
+	return ""
+}
+
+func (m *ReviewTask) GetDecidedAt() int64 {
+	if m != nil {
+		return m.DecidedAt
+	}
+	return 0
+}
+
+func (m *ReviewTask) GetApplied() bool {
+	if m != nil {
+		return m.Applied
+	}
+	return false
+}
+
+func (m *ReviewTask) GetApplyError() string {
+	if m != nil {
+		return m.ApplyError
+	}
+	return ""
+}
+
+func (m *ReviewTask) GetCampaignUuid() string {
+	if m != nil {
+		return m.CampaignUuid
+	}
+	return ""
+}
+
+func (m *ReviewTask) GetCampaignLabel() string {
+	if m != nil {
+		return m.CampaignLabel
+	}
+	return ""
+}
+
+func (m *ReviewTask) GetDeadline() int64 {
+	if m != nil {
+		return m.Deadline
+	}
+	return 0
+}
+
+type ListReviewTasksRequest struct {
+}
+
+func (m *ListReviewTasksRequest) Reset()                    { *m = ListReviewTasksRequest{} }
+func (m *ListReviewTasksRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListReviewTasksRequest) ProtoMessage()               {}
+func (*ListReviewTasksRequest) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{37} }
+
+type ReviewTaskCollection struct {
+	Tasks []*ReviewTask `protobuf:"bytes,1,rep,name=Tasks" json:"Tasks,omitempty"`
+}
+
+func (m *ReviewTaskCollection) Reset()                    { *m = ReviewTaskCollection{} }
+func (m *ReviewTaskCollection) String() string            { return proto.CompactTextString(m) }
+func (*ReviewTaskCollection) ProtoMessage()               {}
+func (*ReviewTaskCollection) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{38} }
+
+func (m *ReviewTaskCollection) GetTasks() []*ReviewTask {
+	if m != nil {
+		return m.Tasks
+	}
+	return nil
+}
+
+type DecideTaskRequest struct {
+	CampaignUuid string `protobuf:"bytes,1,opt,name=CampaignUuid" json:"CampaignUuid,omitempty"`
+	TaskUuid     string `protobuf:"bytes,2,opt,name=TaskUuid" json:"TaskUuid,omitempty"`
+	// Either approve or revoke
+	Decision string `protobuf:"bytes,3,opt,name=Decision" json:"Decision,omitempty"`
+	Comment  string `protobuf:"bytes,4,opt,name=Comment" json:"Comment,omitempty"`
+}
+
+func (m *DecideTaskRequest) Reset()                    { *m = DecideTaskRequest{} }
+func (m *DecideTaskRequest) String() string            { return proto.CompactTextString(m) }
+func (*DecideTaskRequest) ProtoMessage()               {}
+func (*DecideTaskRequest) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{39} }
+
+func (m *DecideTaskRequest) GetCampaignUuid() string {
+	if m != nil {
+		return m.CampaignUuid
+	}
+	return ""
+}
+
+func (m *DecideTaskRequest) GetTaskUuid() string {
+	if m != nil {
+		return m.TaskUuid
+	}
+	return ""
+}
+
+func (m *DecideTaskRequest) GetDecision() string {
+	if m != nil {
+		return m.Decision
+	}
+	return ""
+}
+
+func (m *DecideTaskRequest) GetComment() string {
+	if m != nil {
+		return m.Comment
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*ResourcePolicyQuery)(nil), "rest.ResourcePolicyQuery")
 	proto.RegisterType((*SearchRoleRequest)(nil), "rest.SearchRoleRequest")
@@ -857,68 +1372,119 @@ func init() {
 	proto.RegisterType((*ResetPasswordResponse)(nil), "rest.ResetPasswordResponse")
 	proto.RegisterType((*DocumentAccessTokenRequest)(nil), "rest.DocumentAccessTokenRequest")
 	proto.RegisterType((*DocumentAccessTokenResponse)(nil), "rest.DocumentAccessTokenResponse")
+	proto.RegisterType((*UserSelfServiceRequest)(nil), "rest.UserSelfServiceRequest")
+	proto.RegisterType((*UserConfirmDeletionRequest)(nil), "rest.UserConfirmDeletionRequest")
+	proto.RegisterType((*UserSelfServiceResponse)(nil), "rest.UserSelfServiceResponse")
+	proto.RegisterType((*Campaign)(nil), "rest.Campaign")
+	proto.RegisterType((*CampaignProgress)(nil), "rest.CampaignProgress")
+	proto.RegisterType((*CampaignRequest)(nil), "rest.CampaignRequest")
+	proto.RegisterType((*ListCampaignsRequest)(nil), "rest.ListCampaignsRequest")
+	proto.RegisterType((*CampaignCollection)(nil), "rest.CampaignCollection")
+	proto.RegisterType((*CampaignReportResponse)(nil), "rest.CampaignReportResponse")
+	proto.RegisterType((*ReviewTask)(nil), "rest.ReviewTask")
+	proto.RegisterType((*ListReviewTasksRequest)(nil), "rest.ListReviewTasksRequest")
+	proto.RegisterType((*ReviewTaskCollection)(nil), "rest.ReviewTaskCollection")
+	proto.RegisterType((*DecideTaskRequest)(nil), "rest.DecideTaskRequest")
 	proto.RegisterEnum("rest.ResourcePolicyQuery_QueryType", ResourcePolicyQuery_QueryType_name, ResourcePolicyQuery_QueryType_value)
 }
 
 func init() { proto.RegisterFile("idm.proto", fileDescriptor6) }
 
 var fileDescriptor6 = []byte{
-	// 907 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe4, 0x56, 0xdd, 0x8e, 0xdb, 0x44,
-	0x14, 0xc6, 0xf9, 0xf7, 0x09, 0xbb, 0x0d, 0xb3, 0xdb, 0xe0, 0x4d, 0x57, 0x22, 0x98, 0x9b, 0x20,
-	0x84, 0x23, 0xb6, 0xd0, 0xc2, 0x15, 0xca, 0x7a, 0x57, 0x65, 0xd5, 0x6c, 0x12, 0x66, 0xb3, 0x02,
-	0xc4, 0x95, 0xeb, 0x9c, 0x66, 0xad, 0xd8, 0x9e, 0xe0, 0xb1, 0x5b, 0xe5, 0x05, 0x78, 0x0b, 0xde,
-	0x80, 0x6b, 0xde, 0x86, 0x77, 0x41, 0x33, 0xf6, 0x38, 0x4e, 0x9a, 0x25, 0xa0, 0xde, 0x54, 0xea,
-	0x4d, 0x34, 0xe7, 0x9b, 0xef, 0x9c, 0xf9, 0xce, 0x77, 0x3c, 0x76, 0x40, 0xf7, 0x66, 0x81, 0xb5,
-	0x8c, 0x58, 0xcc, 0x48, 0x25, 0x42, 0x1e, 0x77, 0xbe, 0x9a, 0x7b, 0xf1, 0x5d, 0xf2, 0xc2, 0x72,
-	0x59, 0xd0, 0x5f, 0xae, 0x66, 0x1e, 0xeb, 0xbb, 0xe8, 0xfb, 0xbc, 0xef, 0xb2, 0x20, 0x60, 0x61,
-	0x5f, 0x52, 0xfb, 0xde, 0x2c, 0xe8, 0xe7, 0x89, 0x9d, 0x6f, 0xff, 0x3d, 0x85, 0x63, 0xf4, 0xca,
-	0x73, 0x31, 0x4b, 0x4d, 0xc1, 0x34, 0xd3, 0xfc, 0x43, 0x83, 0x23, 0x8a, 0x9c, 0x25, 0x91, 0x8b,
-	0x13, 0xe6, 0x7b, 0xee, 0xea, 0xc7, 0x04, 0xa3, 0x15, 0x79, 0x0a, 0x95, 0xe9, 0x6a, 0x89, 0x86,
-	0xd6, 0xd5, 0x7a, 0x87, 0x67, 0x9f, 0x59, 0x42, 0x99, 0xb5, 0x83, 0x68, 0xc9, 0x5f, 0x41, 0xa5,
-	0x32, 0x81, 0xb4, 0xa1, 0x76, 0xcb, 0x31, 0xba, 0x9a, 0x19, 0xa5, 0xae, 0xd6, 0xd3, 0x69, 0x16,
-	0x99, 0xdf, 0x80, 0x9e, 0x53, 0x49, 0x13, 0xea, 0xf6, 0x78, 0x34, 0xbd, 0xfc, 0x79, 0xda, 0xfa,
-	0x80, 0xd4, 0xa1, 0x3c, 0x18, 0xfd, 0xd2, 0xd2, 0x48, 0x03, 0x2a, 0xa3, 0xf1, 0xe8, 0xb2, 0x55,
-	0x12, 0xab, 0xdb, 0x9b, 0x4b, 0xda, 0x2a, 0x9b, 0x7f, 0x96, 0xe0, 0xa3, 0x1b, 0x74, 0x22, 0xf7,
-	0x8e, 0x32, 0x1f, 0x29, 0xfe, 0x96, 0x20, 0x8f, 0x89, 0x05, 0x75, 0x51, 0xcc, 0x43, 0x6e, 0x68,
-	0xdd, 0x72, 0xaf, 0x79, 0x76, 0x6c, 0x09, 0x33, 0x04, 0xe5, 0xc6, 0x0b, 0xe7, 0x3e, 0xca, 0xa3,
-	0xa8, 0x22, 0x91, 0xe7, 0x3b, 0x9b, 0x34, 0xea, 0x5d, 0xad, 0xd7, 0x3c, 0x3b, 0xb9, 0xb7, 0x39,
-	0xba, 0xd3, 0x9a, 0x36, 0xd4, 0xc6, 0x2f, 0x5f, 0x72, 0x8c, 0x65, 0x87, 0x65, 0x9a, 0x45, 0xe4,
-	0x18, 0xaa, 0x43, 0x2f, 0xf0, 0x62, 0xa3, 0x2c, 0xe1, 0x34, 0x20, 0x06, 0xd4, 0x9f, 0x45, 0x2c,
-	0x59, 0x9e, 0xaf, 0x8c, 0x4a, 0x57, 0xeb, 0x55, 0xa9, 0x0a, 0xc9, 0x29, 0xe8, 0x36, 0x4b, 0xc2,
-	0x78, 0x1c, 0xfa, 0x2b, 0xa3, 0xda, 0xd5, 0x7a, 0x0d, 0xba, 0x06, 0xc8, 0xd7, 0xa0, 0x8f, 0x97,
-	0x18, 0x39, 0xb1, 0xc7, 0x42, 0xa3, 0x26, 0xa7, 0xd0, 0xb6, 0xb2, 0x41, 0x5a, 0xf9, 0x8e, 0x34,
-	0x7e, 0x4d, 0x34, 0x7f, 0x80, 0x07, 0xc2, 0x04, 0x6e, 0x33, 0xdf, 0x47, 0x57, 0x40, 0xe4, 0x13,
-	0xa8, 0x4a, 0x28, 0x73, 0x4a, 0xcf, 0x9d, 0xa2, 0x29, 0x2e, 0x74, 0x4f, 0x59, 0xec, 0xf8, 0xb2,
-	0x9d, 0x2a, 0x4d, 0x83, 0x82, 0xf1, 0x62, 0x80, 0x7b, 0x8c, 0x17, 0x94, 0xf7, 0xdb, 0xf8, 0x05,
-	0x3c, 0x10, 0x26, 0x14, 0x8d, 0xff, 0x14, 0x6a, 0xf2, 0xc4, 0x4d, 0xe7, 0xa5, 0x9b, 0xd9, 0x86,
-	0x98, 0x8d, 0xcc, 0x32, 0x4a, 0xdb, 0x8c, 0x14, 0x5f, 0xcf, 0xa6, 0x5c, 0x9c, 0x4d, 0x0f, 0x3e,
-	0x3c, 0xf7, 0xc2, 0x19, 0x45, 0xbe, 0x64, 0x21, 0x47, 0xd1, 0xea, 0x4d, 0xe2, 0xba, 0xc8, 0xb9,
-	0xbc, 0xaf, 0x0d, 0xaa, 0x42, 0xf3, 0x6f, 0x0d, 0x5a, 0xe9, 0x14, 0x07, 0xf6, 0x50, 0x0d, 0xf1,
-	0xcb, 0xed, 0x21, 0x1e, 0xc9, 0x73, 0x07, 0xf6, 0x70, 0xe7, 0x0c, 0xdf, 0x65, 0xdb, 0x6d, 0x38,
-	0x18, 0xd8, 0xc3, 0x82, 0xe9, 0xa7, 0x50, 0x19, 0xd8, 0x43, 0xd5, 0x58, 0x43, 0x35, 0x46, 0x25,
-	0x7a, 0xcf, 0xa3, 0xfe, 0x57, 0x09, 0xda, 0xa9, 0x49, 0x3f, 0xb1, 0x68, 0xc1, 0x97, 0x8e, 0x9b,
-	0xbf, 0x68, 0x1e, 0x6f, 0x5b, 0x75, 0x22, 0x2b, 0xe6, 0xbc, 0xf7, 0xfb, 0xa1, 0xff, 0x15, 0x8e,
-	0x72, 0x27, 0x0a, 0x33, 0xb0, 0x00, 0x72, 0x58, 0xf9, 0x76, 0xb8, 0xe9, 0x1b, 0x2d, 0x30, 0xee,
-	0x99, 0xca, 0x00, 0x88, 0xb8, 0x03, 0xd7, 0x18, 0x3b, 0x85, 0xda, 0x5f, 0x80, 0x2e, 0x90, 0x99,
-	0x13, 0x3b, 0xaa, 0xf4, 0x41, 0x7e, 0x6b, 0xc4, 0x0e, 0x5d, 0xef, 0x9b, 0xb7, 0xf0, 0x48, 0xc1,
-	0x23, 0x27, 0xc0, 0x6d, 0x9d, 0x4f, 0x00, 0x72, 0x58, 0x15, 0x6b, 0x6f, 0x14, 0xcb, 0xb7, 0x69,
-	0x81, 0x69, 0x3e, 0x85, 0x8f, 0x87, 0x1e, 0x8f, 0x15, 0x69, 0xea, 0xcc, 0xb9, 0x7a, 0x5e, 0x4e,
-	0x41, 0xcf, 0x89, 0xf2, 0x2e, 0xea, 0x74, 0x0d, 0x98, 0x16, 0x18, 0x6f, 0x26, 0x66, 0x77, 0x98,
-	0x40, 0x45, 0xc4, 0x52, 0x86, 0x4e, 0xe5, 0xda, 0x7c, 0x06, 0x0f, 0x27, 0x49, 0x91, 0xfe, 0x9f,
-	0x8e, 0x21, 0x2d, 0x28, 0x4f, 0x9d, 0x79, 0xf6, 0xfd, 0x15, 0x4b, 0xf3, 0x0c, 0xda, 0xdb, 0x85,
-	0xf6, 0xbe, 0x3a, 0xae, 0xe1, 0xe4, 0x02, 0x7d, 0x8c, 0xf1, 0x7f, 0xf7, 0x99, 0xf7, 0x92, 0x2a,
-	0x48, 0x7b, 0x79, 0x02, 0x9d, 0x5d, 0xe5, 0xf6, 0xca, 0x68, 0xc3, 0xb1, 0xc8, 0x38, 0x67, 0x6c,
-	0x11, 0x38, 0xd1, 0x42, 0x29, 0x30, 0x3f, 0x87, 0x03, 0x8a, 0xaf, 0xd8, 0x22, 0xbf, 0xaa, 0x06,
-	0xd4, 0xa7, 0x6c, 0x81, 0xe1, 0xd5, 0x2c, 0x13, 0xa4, 0x42, 0xf3, 0x02, 0x0e, 0x15, 0x75, 0xdf,
-	0x71, 0x62, 0xe7, 0x1a, 0x39, 0x77, 0xe6, 0x98, 0xa9, 0x57, 0xa1, 0xf9, 0x1d, 0x9c, 0x50, 0xe4,
-	0x18, 0x4f, 0x1c, 0xce, 0x5f, 0xb3, 0x68, 0x26, 0xab, 0x17, 0xfc, 0x10, 0x2a, 0x87, 0x6c, 0xee,
-	0x85, 0xca, 0x8f, 0x1c, 0x30, 0x27, 0xd0, 0xd9, 0x95, 0xfa, 0x16, 0x62, 0x7e, 0xd7, 0xe0, 0x78,
-	0xa3, 0xe4, 0xfa, 0x03, 0x4d, 0xde, 0x3c, 0x2a, 0x53, 0xb4, 0x63, 0x67, 0x53, 0x78, 0x69, 0x4b,
-	0x38, 0xe9, 0x42, 0x73, 0x84, 0xaf, 0x55, 0x86, 0x7c, 0xd5, 0xe8, 0xb4, 0x08, 0x99, 0xcf, 0xe1,
-	0xe1, 0x96, 0x8e, 0xb7, 0xe8, 0x6a, 0x08, 0x9d, 0x0b, 0xe6, 0x26, 0x01, 0x86, 0xf1, 0x40, 0x72,
-	0x37, 0x3c, 0x26, 0x50, 0x99, 0x38, 0xf1, 0x5d, 0xd6, 0x8c, 0x5c, 0x93, 0x0e, 0x34, 0x6c, 0xdf,
-	0xc3, 0x30, 0xbe, 0xba, 0xc8, 0x8a, 0xe5, 0xb1, 0xf9, 0x3d, 0x3c, 0xda, 0x59, 0x2d, 0x13, 0xd8,
-	0x85, 0x66, 0x01, 0xce, 0xaa, 0x16, 0xa1, 0x17, 0x35, 0xf9, 0x17, 0xf9, 0xf1, 0x3f, 0x01, 0x00,
-	0x00, 0xff, 0xff, 0x3f, 0xf2, 0x61, 0x55, 0xa2, 0x0b, 0x00, 0x00,
+	// 1514 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe4, 0x58, 0x5f, 0x73, 0xdb, 0x44,
+	0x10, 0x47, 0xb1, 0x93, 0x58, 0x9b, 0x26, 0x71, 0xaf, 0xa9, 0xab, 0xb8, 0x1d, 0x30, 0x02, 0x3a,
+	0x66, 0x00, 0x67, 0x48, 0xa1, 0x85, 0x17, 0x18, 0xc7, 0xce, 0x94, 0x50, 0x37, 0x09, 0x67, 0x67,
+	0x80, 0xe1, 0x49, 0x91, 0x2e, 0x8e, 0x88, 0xac, 0x33, 0x92, 0x9c, 0xe2, 0x2f, 0xc0, 0x0c, 0xc3,
+	0x67, 0xe0, 0x9d, 0x07, 0x9e, 0xf9, 0x36, 0x7c, 0x05, 0x3e, 0x03, 0xb3, 0xf7, 0xcf, 0x92, 0xeb,
+	0x34, 0x40, 0x5f, 0x98, 0xe9, 0x4b, 0x46, 0xfb, 0xdb, 0xbd, 0xbd, 0xdd, 0xdf, 0xee, 0xde, 0x9d,
+	0x03, 0x76, 0x18, 0x8c, 0x5a, 0xe3, 0x84, 0x67, 0x9c, 0x94, 0x13, 0x96, 0x66, 0xf5, 0x0f, 0x87,
+	0x61, 0x76, 0x3e, 0x39, 0x6d, 0xf9, 0x7c, 0xb4, 0x33, 0x9e, 0x06, 0x21, 0xdf, 0xf1, 0x59, 0x14,
+	0xa5, 0x3b, 0x3e, 0x1f, 0x8d, 0x78, 0xbc, 0x23, 0x4c, 0x77, 0xc2, 0x60, 0xb4, 0x63, 0x16, 0xd6,
+	0x3f, 0x79, 0xf1, 0x92, 0x94, 0x25, 0x97, 0xa1, 0xcf, 0xd4, 0x52, 0x09, 0xca, 0x95, 0xee, 0xaf,
+	0x16, 0xdc, 0xa2, 0x2c, 0xe5, 0x93, 0xc4, 0x67, 0xc7, 0x3c, 0x0a, 0xfd, 0xe9, 0x57, 0x13, 0x96,
+	0x4c, 0xc9, 0x23, 0x28, 0x0f, 0xa6, 0x63, 0xe6, 0x58, 0x0d, 0xab, 0xb9, 0xb1, 0xfb, 0x56, 0x0b,
+	0x23, 0x6b, 0x2d, 0x30, 0x6c, 0x89, 0xbf, 0x68, 0x4a, 0xc5, 0x02, 0x52, 0x83, 0x95, 0x93, 0x94,
+	0x25, 0x07, 0x81, 0xb3, 0xd4, 0xb0, 0x9a, 0x36, 0x55, 0x92, 0xfb, 0x31, 0xd8, 0xc6, 0x94, 0xac,
+	0xc1, 0x6a, 0xe7, 0xe8, 0x70, 0xb0, 0xff, 0xcd, 0xa0, 0xfa, 0x1a, 0x59, 0x85, 0x52, 0xfb, 0xf0,
+	0xdb, 0xaa, 0x45, 0x2a, 0x50, 0x3e, 0x3c, 0x3a, 0xdc, 0xaf, 0x2e, 0xe1, 0xd7, 0x49, 0x7f, 0x9f,
+	0x56, 0x4b, 0xee, 0xef, 0x4b, 0x70, 0xb3, 0xcf, 0xbc, 0xc4, 0x3f, 0xa7, 0x3c, 0x62, 0x94, 0xfd,
+	0x30, 0x61, 0x69, 0x46, 0x5a, 0xb0, 0x8a, 0xce, 0x42, 0x96, 0x3a, 0x56, 0xa3, 0xd4, 0x5c, 0xdb,
+	0xdd, 0x6a, 0x21, 0x19, 0x68, 0xd2, 0x0f, 0xe3, 0x61, 0xc4, 0xc4, 0x56, 0x54, 0x1b, 0x91, 0x27,
+	0x0b, 0x93, 0x74, 0x56, 0x1b, 0x56, 0x73, 0x6d, 0x77, 0xfb, 0xca, 0xe4, 0xe8, 0x42, 0x6a, 0x6a,
+	0xb0, 0x72, 0x74, 0x76, 0x96, 0xb2, 0x4c, 0x64, 0x58, 0xa2, 0x4a, 0x22, 0x5b, 0xb0, 0xdc, 0x0b,
+	0x47, 0x61, 0xe6, 0x94, 0x04, 0x2c, 0x05, 0xe2, 0xc0, 0xea, 0xe3, 0x84, 0x4f, 0xc6, 0x7b, 0x53,
+	0xa7, 0xdc, 0xb0, 0x9a, 0xcb, 0x54, 0x8b, 0xe4, 0x1e, 0xd8, 0x1d, 0x3e, 0x89, 0xb3, 0xa3, 0x38,
+	0x9a, 0x3a, 0xcb, 0x0d, 0xab, 0x59, 0xa1, 0x33, 0x80, 0x7c, 0x04, 0xf6, 0xd1, 0x98, 0x25, 0x5e,
+	0x16, 0xf2, 0xd8, 0x59, 0x11, 0x55, 0xa8, 0xb5, 0x54, 0x21, 0x5b, 0x46, 0x23, 0x88, 0x9f, 0x19,
+	0xba, 0x5f, 0xc0, 0x26, 0x92, 0x90, 0x76, 0x78, 0x14, 0x31, 0x1f, 0x21, 0xf2, 0x06, 0x2c, 0x0b,
+	0x48, 0x31, 0x65, 0x1b, 0xa6, 0xa8, 0xc4, 0x31, 0xee, 0x01, 0xcf, 0xbc, 0x48, 0xa4, 0xb3, 0x4c,
+	0xa5, 0x90, 0x23, 0x1e, 0x0b, 0x78, 0x0d, 0xf1, 0x68, 0xf2, 0x6a, 0x13, 0x7f, 0x01, 0x9b, 0x48,
+	0x42, 0x9e, 0xf8, 0x37, 0x61, 0x45, 0xec, 0x58, 0x64, 0x5e, 0xb0, 0xa9, 0x14, 0x58, 0x1b, 0xb1,
+	0xca, 0x59, 0x9a, 0xb7, 0x90, 0xf8, 0xac, 0x36, 0xa5, 0x7c, 0x6d, 0x9a, 0x70, 0x63, 0x2f, 0x8c,
+	0x03, 0xca, 0xd2, 0x31, 0x8f, 0x53, 0x86, 0xa9, 0xf6, 0x27, 0xbe, 0xcf, 0xd2, 0x54, 0xcc, 0x6b,
+	0x85, 0x6a, 0xd1, 0xfd, 0xd3, 0x82, 0xaa, 0xac, 0x62, 0xbb, 0xd3, 0xd3, 0x45, 0xfc, 0x60, 0xbe,
+	0x88, 0xb7, 0xc4, 0xbe, 0xed, 0x4e, 0x6f, 0x61, 0x0d, 0xff, 0xcf, 0xb4, 0x77, 0x60, 0xbd, 0xdd,
+	0xe9, 0xe5, 0x48, 0xbf, 0x07, 0xe5, 0x76, 0xa7, 0xa7, 0x13, 0xab, 0xe8, 0xc4, 0xa8, 0x40, 0xaf,
+	0x68, 0xf5, 0x3f, 0x96, 0xa0, 0x26, 0x49, 0xfa, 0x9a, 0x27, 0x17, 0xe9, 0xd8, 0xf3, 0xcd, 0x41,
+	0xf3, 0x60, 0x9e, 0xaa, 0x6d, 0xe1, 0xd1, 0xd8, 0xbd, 0xda, 0x4d, 0xff, 0x1d, 0xdc, 0x32, 0x4c,
+	0xe4, 0x6a, 0xd0, 0x02, 0x30, 0xb0, 0xe6, 0x6d, 0xa3, 0xc8, 0x1b, 0xcd, 0x59, 0x5c, 0x51, 0x95,
+	0x36, 0x10, 0x9c, 0x81, 0xa7, 0x2c, 0xf3, 0x72, 0xbe, 0xdf, 0x03, 0x1b, 0x91, 0xc0, 0xcb, 0x3c,
+	0xed, 0x7a, 0xdd, 0x4c, 0x0d, 0x6a, 0xe8, 0x4c, 0xef, 0x9e, 0xc0, 0x5d, 0x0d, 0x1f, 0x7a, 0x23,
+	0x36, 0x1f, 0xe7, 0x43, 0x00, 0x03, 0x6b, 0x67, 0xb5, 0x82, 0x33, 0xa3, 0xa6, 0x39, 0x4b, 0xf7,
+	0x11, 0xdc, 0xe9, 0x85, 0x69, 0xa6, 0x8d, 0x06, 0xde, 0x30, 0xd5, 0xfd, 0x72, 0x0f, 0x6c, 0x63,
+	0x28, 0x66, 0xd1, 0xa6, 0x33, 0xc0, 0x6d, 0x81, 0xf3, 0xfc, 0x42, 0x35, 0xc3, 0x04, 0xca, 0x28,
+	0x8b, 0x30, 0x6c, 0x2a, 0xbe, 0xdd, 0xc7, 0x70, 0xfb, 0x78, 0x92, 0x37, 0xff, 0x47, 0xdb, 0x90,
+	0x2a, 0x94, 0x06, 0xde, 0x50, 0xdd, 0xbf, 0xf8, 0xe9, 0xee, 0x42, 0x6d, 0xde, 0xd1, 0xb5, 0x47,
+	0xc7, 0x53, 0xd8, 0xee, 0xb2, 0x88, 0x65, 0xec, 0x5f, 0xe7, 0x69, 0x72, 0x91, 0x11, 0xc8, 0x5c,
+	0x1e, 0x42, 0x7d, 0x91, 0xbb, 0x6b, 0xc3, 0xa8, 0xc1, 0x16, 0xae, 0xd8, 0xe3, 0xfc, 0x62, 0xe4,
+	0x25, 0x17, 0x3a, 0x02, 0xf7, 0x5d, 0x58, 0xa7, 0xec, 0x92, 0x5f, 0x98, 0x51, 0x75, 0x60, 0x75,
+	0xc0, 0x2f, 0x58, 0x7c, 0x10, 0xa8, 0x80, 0xb4, 0xe8, 0x76, 0x61, 0x43, 0x9b, 0x5e, 0xb7, 0x1d,
+	0x6a, 0x9e, 0xb2, 0x34, 0xf5, 0x86, 0x4c, 0x45, 0xaf, 0x45, 0xf7, 0x53, 0xd8, 0xa6, 0x2c, 0x65,
+	0xd9, 0xb1, 0x97, 0xa6, 0xcf, 0x78, 0x12, 0x08, 0xef, 0x39, 0x3e, 0x30, 0xca, 0x1e, 0x1f, 0x86,
+	0xb1, 0xe6, 0xc3, 0x00, 0xee, 0x31, 0xd4, 0x17, 0x2d, 0x7d, 0x89, 0x60, 0x7e, 0xb2, 0x60, 0xab,
+	0xe0, 0x72, 0x76, 0x41, 0x93, 0xe7, 0xb7, 0x52, 0x11, 0x2d, 0xd0, 0x14, 0x03, 0x5f, 0x9a, 0x0b,
+	0x9c, 0x34, 0x60, 0xed, 0x90, 0x3d, 0xd3, 0x2b, 0xc4, 0x51, 0x63, 0xd3, 0x3c, 0xe4, 0x3e, 0x81,
+	0xdb, 0x73, 0x71, 0xbc, 0x44, 0x56, 0x3d, 0xa8, 0x77, 0xb9, 0x3f, 0x19, 0xb1, 0x38, 0x6b, 0x0b,
+	0xdb, 0x02, 0xc7, 0x04, 0xca, 0xc7, 0x5e, 0x76, 0xae, 0x92, 0x11, 0xdf, 0xa4, 0x0e, 0x95, 0x4e,
+	0x14, 0xb2, 0x38, 0x3b, 0xe8, 0x2a, 0x67, 0x46, 0x76, 0x3f, 0x87, 0xbb, 0x0b, 0xbd, 0xa9, 0x00,
+	0x1b, 0xb0, 0x96, 0x83, 0x95, 0xd7, 0x3c, 0xe4, 0x3a, 0x50, 0x13, 0x0f, 0x1b, 0x16, 0x9d, 0xf5,
+	0xe5, 0x51, 0xa8, 0x9b, 0x6f, 0x17, 0xea, 0xa8, 0xe9, 0xf0, 0xf8, 0x2c, 0x4c, 0x46, 0xa2, 0xaf,
+	0x43, 0x6e, 0x02, 0x15, 0xe7, 0xd9, 0xcc, 0xa7, 0x14, 0xdc, 0x5f, 0x2c, 0xb8, 0xf3, 0x9c, 0xbb,
+	0xff, 0x4e, 0x16, 0x6a, 0xbe, 0xe4, 0xa7, 0x27, 0x93, 0x50, 0xd7, 0x45, 0x8b, 0xc4, 0x85, 0x1b,
+	0x3a, 0xa4, 0xae, 0x97, 0x31, 0x71, 0x13, 0x94, 0x68, 0x01, 0x73, 0xff, 0x2a, 0x41, 0xa5, 0xe3,
+	0x8d, 0xc6, 0x5e, 0x38, 0x8c, 0x91, 0x59, 0xe1, 0x47, 0x31, 0x2b, 0x9c, 0xe0, 0xfd, 0xe2, 0x9d,
+	0xb2, 0x48, 0x6d, 0x2b, 0x05, 0x24, 0xad, 0xcb, 0x52, 0x3f, 0x09, 0xc7, 0xe2, 0xa6, 0x50, 0x0d,
+	0x91, 0x83, 0x70, 0x5d, 0xdf, 0xe7, 0x63, 0xb9, 0xab, 0x4d, 0xa5, 0x40, 0xee, 0xc3, 0x86, 0x39,
+	0xf0, 0xd1, 0x7d, 0xea, 0x2c, 0x8b, 0x73, 0x6e, 0x0e, 0xc5, 0x76, 0x14, 0x17, 0x96, 0x28, 0xf4,
+	0x8a, 0x6c, 0x47, 0x03, 0x60, 0xb5, 0x29, 0xbb, 0x0c, 0xd9, 0x33, 0x96, 0x88, 0xdb, 0xd4, 0xa6,
+	0x46, 0x26, 0x4d, 0xd8, 0xec, 0xb2, 0x33, 0x6f, 0x12, 0x65, 0x5d, 0xe6, 0x87, 0x29, 0x46, 0x57,
+	0x11, 0x26, 0xf3, 0x30, 0x7a, 0xe9, 0x32, 0x2f, 0x88, 0xc2, 0x98, 0x39, 0xb6, 0xa0, 0xc6, 0xc8,
+	0x78, 0xdb, 0xf6, 0x33, 0x2f, 0x9b, 0xa4, 0x0e, 0xc8, 0x5f, 0x2f, 0x52, 0x42, 0xb2, 0x3b, 0x09,
+	0xf3, 0x32, 0x9e, 0x38, 0x6b, 0x92, 0x6c, 0x25, 0x1a, 0x0d, 0x0b, 0x9c, 0x1b, 0xc2, 0x99, 0x16,
+	0x45, 0x51, 0x33, 0x2f, 0x41, 0xcd, 0xba, 0xd4, 0x28, 0x11, 0x77, 0xe9, 0x44, 0x3c, 0x65, 0x81,
+	0xb3, 0x21, 0xef, 0x74, 0x29, 0x91, 0xfb, 0xb0, 0x3c, 0xf0, 0xd2, 0x8b, 0xd4, 0xd9, 0x14, 0x77,
+	0x51, 0x55, 0x3f, 0x15, 0x30, 0x45, 0x54, 0x50, 0xa9, 0x26, 0xbb, 0x50, 0x39, 0x4e, 0xf8, 0x30,
+	0xc1, 0x7e, 0xa9, 0x8a, 0x57, 0x45, 0x4d, 0x9a, 0xea, 0x8a, 0x6a, 0x2d, 0x35, 0x76, 0xee, 0x8f,
+	0x50, 0x9d, 0xd7, 0xce, 0x2e, 0x5e, 0x2b, 0x77, 0xf1, 0x62, 0xdc, 0xc7, 0x2c, 0x0e, 0xc2, 0x78,
+	0xa8, 0x2e, 0x64, 0x2d, 0x22, 0x73, 0xed, 0xf1, 0x38, 0xe1, 0x97, 0x2c, 0x50, 0x0f, 0x52, 0x23,
+	0xe3, 0x2a, 0x79, 0xc8, 0x06, 0xfa, 0xe5, 0xa1, 0x44, 0xf7, 0x1d, 0xd8, 0xd4, 0x3b, 0xe7, 0x46,
+	0x79, 0xbe, 0xe1, 0xf0, 0xa0, 0xc7, 0xcb, 0x51, 0x9b, 0x9a, 0x83, 0x7e, 0x0f, 0x88, 0xc6, 0x72,
+	0x77, 0xf7, 0xfb, 0x60, 0x1b, 0x4b, 0xf3, 0xc4, 0x28, 0x70, 0x40, 0x67, 0x06, 0x38, 0xc9, 0x06,
+	0x66, 0x63, 0x9e, 0x64, 0x7a, 0xf2, 0xdc, 0xdf, 0xca, 0x00, 0x33, 0x82, 0x17, 0x4e, 0x02, 0x81,
+	0xf2, 0x93, 0x30, 0xd6, 0xbf, 0x67, 0xc5, 0x37, 0x79, 0x1b, 0xd6, 0x0b, 0x9d, 0xab, 0x26, 0xa1,
+	0x08, 0x16, 0xba, 0x5e, 0x0e, 0x93, 0x1c, 0x8a, 0x39, 0x14, 0xfb, 0x01, 0x7f, 0x8a, 0x1d, 0x04,
+	0xe2, 0x61, 0x66, 0x53, 0x25, 0xe1, 0x20, 0xf7, 0x27, 0xa7, 0xdf, 0x33, 0x3f, 0x93, 0xab, 0xe5,
+	0x40, 0x14, 0x30, 0xb1, 0x36, 0x1c, 0x9e, 0x67, 0xa9, 0x9a, 0x08, 0x25, 0xe1, 0x5a, 0x3d, 0x1b,
+	0x22, 0x40, 0x39, 0x0c, 0x05, 0xac, 0x30, 0x4f, 0xf6, 0xdc, 0x3c, 0x89, 0x29, 0x51, 0x83, 0x24,
+	0x67, 0xc1, 0xc8, 0xa2, 0xe7, 0xf9, 0x08, 0x0f, 0x56, 0x33, 0x0d, 0x52, 0xc4, 0xf9, 0x45, 0xab,
+	0x80, 0x05, 0x7b, 0x53, 0x31, 0x0f, 0x36, 0x9d, 0x01, 0x39, 0x6d, 0x3b, 0x53, 0x33, 0x31, 0x03,
+	0xd0, 0x6b, 0x7b, 0x3c, 0x8e, 0x42, 0x35, 0x16, 0x15, 0xaa, 0x45, 0xf2, 0x3a, 0x00, 0x7e, 0x4e,
+	0xf7, 0x93, 0x84, 0x27, 0xce, 0xa6, 0x70, 0x9b, 0x43, 0x30, 0x57, 0x5d, 0x5e, 0x91, 0x6b, 0x55,
+	0xe6, 0x9a, 0xc7, 0xb0, 0x62, 0x5a, 0x96, 0x64, 0xde, 0x94, 0x15, 0x2b, 0x80, 0x85, 0xb3, 0x81,
+	0x14, 0xcf, 0x06, 0x6c, 0x22, 0x6c, 0xd0, 0x59, 0xb7, 0x98, 0x16, 0xfd, 0x0c, 0xb6, 0x66, 0x68,
+	0xae, 0x49, 0xcd, 0x3c, 0x5b, 0x2f, 0x9c, 0x67, 0xf7, 0x67, 0x0b, 0x6e, 0x4a, 0x1e, 0x04, 0xaa,
+	0x86, 0x64, 0x3e, 0x2b, 0x6b, 0x41, 0x56, 0x75, 0xa8, 0xe0, 0x12, 0xa1, 0x57, 0xf7, 0x9f, 0x96,
+	0x0b, 0x15, 0x2c, 0x5d, 0x5d, 0xc1, 0x72, 0xa1, 0x82, 0xa7, 0x2b, 0xe2, 0xff, 0x42, 0x0f, 0xfe,
+	0x1e, 0x00, 0x5d, 0x58, 0x25, 0x01, 0x97, 0x12, 0x00, 0x00,
 }
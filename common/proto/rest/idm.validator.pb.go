@@ -188,3 +188,68 @@ func (this *DocumentAccessTokenRequest) Validate() error {
 func (this *DocumentAccessTokenResponse) Validate() error {
 	return nil
 }
+func (this *UserSelfServiceRequest) Validate() error {
+	return nil
+}
+func (this *UserConfirmDeletionRequest) Validate() error {
+	return nil
+}
+func (this *UserSelfServiceResponse) Validate() error {
+	return nil
+}
+func (this *Campaign) Validate() error {
+	for _, item := range this.Tasks {
+		if item != nil {
+			if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(item); err != nil {
+				return github_com_mwitkow_go_proto_validators.FieldError("Tasks", err)
+			}
+		}
+	}
+	if this.Progress != nil {
+		if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(this.Progress); err != nil {
+			return github_com_mwitkow_go_proto_validators.FieldError("Progress", err)
+		}
+	}
+	return nil
+}
+func (this *CampaignProgress) Validate() error {
+	return nil
+}
+func (this *CampaignRequest) Validate() error {
+	return nil
+}
+func (this *ListCampaignsRequest) Validate() error {
+	return nil
+}
+func (this *CampaignCollection) Validate() error {
+	for _, item := range this.Campaigns {
+		if item != nil {
+			if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(item); err != nil {
+				return github_com_mwitkow_go_proto_validators.FieldError("Campaigns", err)
+			}
+		}
+	}
+	return nil
+}
+func (this *CampaignReportResponse) Validate() error {
+	return nil
+}
+func (this *ReviewTask) Validate() error {
+	return nil
+}
+func (this *ListReviewTasksRequest) Validate() error {
+	return nil
+}
+func (this *ReviewTaskCollection) Validate() error {
+	for _, item := range this.Tasks {
+		if item != nil {
+			if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(item); err != nil {
+				return github_com_mwitkow_go_proto_validators.FieldError("Tasks", err)
+			}
+		}
+	}
+	return nil
+}
+func (this *DecideTaskRequest) Validate() error {
+	return nil
+}
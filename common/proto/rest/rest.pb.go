@@ -28,224 +28,260 @@ var _ = math.Inf
 func init() { proto.RegisterFile("rest.proto", fileDescriptor7) }
 
 var fileDescriptor7 = []byte{
-	// 3504 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x5a, 0xcd, 0x73, 0xdc, 0xc6,
-	0xb1, 0x2f, 0x4a, 0xb2, 0x24, 0x0e, 0x77, 0x49, 0x6a, 0x48, 0x89, 0x12, 0x48, 0xc9, 0x14, 0xac,
-	0xe7, 0xf7, 0x8a, 0x2f, 0x5c, 0xd8, 0x74, 0x25, 0xb6, 0x75, 0x49, 0x56, 0x94, 0x44, 0x4b, 0xa6,
-	0xec, 0x0d, 0x97, 0xb2, 0x1d, 0xcb, 0x2e, 0x07, 0x8b, 0x1d, 0x81, 0x10, 0xb1, 0x18, 0x04, 0x33,
-	0xa0, 0xcc, 0xda, 0x62, 0x0e, 0x76, 0xa5, 0x52, 0xb9, 0xc6, 0x39, 0xf8, 0x0f, 0xc9, 0x39, 0x55,
-	0x39, 0x26, 0x95, 0x43, 0x52, 0xc9, 0x25, 0x87, 0x1c, 0x52, 0x95, 0xfc, 0x1f, 0xa9, 0xe9, 0xf9,
-	0xc0, 0xe0, 0x83, 0x4b, 0xd2, 0x39, 0x48, 0x5c, 0x74, 0xf7, 0xfc, 0x7e, 0x3d, 0x3d, 0x5f, 0x3d,
-	0x0d, 0x20, 0x94, 0x11, 0xc6, 0x3b, 0x69, 0x46, 0x39, 0xc5, 0x17, 0xc4, 0x6f, 0xa7, 0x15, 0xd0,
-	0xd1, 0x88, 0x26, 0x52, 0xe6, 0xa0, 0xa1, 0xcf, 0x7d, 0xf5, 0x7b, 0x3a, 0x1a, 0x8e, 0xd4, 0xcf,
-	0xd6, 0x20, 0xa3, 0xfb, 0x24, 0xd3, 0x4f, 0x01, 0x4d, 0x9e, 0x47, 0xa1, 0x7a, 0x9a, 0x63, 0xc1,
-	0x1e, 0x19, 0xe6, 0xb1, 0x51, 0xcf, 0x84, 0x99, 0x9f, 0xee, 0xe9, 0x07, 0xb6, 0xe7, 0x67, 0x44,
-	0x3d, 0xcc, 0x3e, 0xcf, 0x68, 0xc2, 0x49, 0x32, 0xd4, 0x4d, 0x39, 0x19, 0xa5, 0xb1, 0xcf, 0x09,
-	0x53, 0x82, 0xb7, 0xc2, 0x88, 0xef, 0xe5, 0x83, 0x4e, 0x40, 0x47, 0x5e, 0x7a, 0x38, 0x8c, 0xa8,
-	0x17, 0x90, 0x38, 0x66, 0x9e, 0xf4, 0xd1, 0x03, 0x23, 0x8f, 0x67, 0x84, 0xc0, 0x7f, 0xaa, 0xd1,
-	0x9b, 0xa7, 0x69, 0x14, 0x0d, 0x47, 0x5e, 0xd1, 0x9f, 0xb7, 0x4f, 0xd3, 0x64, 0xe4, 0x47, 0x31,
-	0xc9, 0xd4, 0x1f, 0xd5, 0xb0, 0x7b, 0x9a, 0x86, 0x7e, 0xc0, 0xa3, 0x83, 0x88, 0x1f, 0x9a, 0x1f,
-	0x8c, 0x67, 0xc4, 0x1f, 0x9d, 0xa5, 0x8f, 0x2f, 0xe8, 0x80, 0xc1, 0x7f, 0xaa, 0xd1, 0x0f, 0x4f,
-	0xd3, 0x88, 0x24, 0x41, 0x76, 0x98, 0xf2, 0x88, 0x26, 0xd6, 0xcf, 0xb3, 0x04, 0x29, 0xa6, 0xa1,
-	0xf8, 0x77, 0x96, 0x20, 0xd1, 0xc1, 0x0b, 0x12, 0x70, 0xf5, 0x47, 0x35, 0x7c, 0xf7, 0x54, 0x03,
-	0x92, 0x30, 0xee, 0xc7, 0xb1, 0xfe, 0x7b, 0x16, 0x37, 0x03, 0x1e, 0x8b, 0x7f, 0x67, 0x71, 0x33,
-	0x4f, 0x87, 0x3e, 0x27, 0xea, 0x8f, 0x6a, 0xb8, 0x12, 0x52, 0x1a, 0xc6, 0xc4, 0xf3, 0xd3, 0xc8,
-	0xf3, 0x93, 0x84, 0x72, 0x5f, 0xc4, 0x4b, 0x47, 0xfc, 0x7b, 0xf0, 0x27, 0x58, 0x0f, 0x49, 0xb2,
-	0xce, 0x5e, 0xfa, 0x61, 0x48, 0x32, 0x8f, 0x42, 0x44, 0x59, 0xdd, 0x7a, 0xe3, 0xb7, 0x4b, 0xa8,
-	0xbd, 0x09, 0xab, 0xa2, 0x4f, 0xb2, 0x83, 0x28, 0x20, 0x78, 0x17, 0x4d, 0xf7, 0x72, 0x2e, 0x65,
-	0x78, 0xa1, 0x03, 0xeb, 0x4e, 0x3e, 0xe5, 0x19, 0x34, 0x75, 0x9a, 0x84, 0xee, 0xcd, 0xaf, 0xfe,
-	0xfa, 0xaf, 0x6f, 0xce, 0x2d, 0x39, 0xd8, 0x93, 0x8b, 0xcc, 0x1b, 0x3f, 0xcc, 0xe3, 0xb8, 0xe7,
-	0xf3, 0xbd, 0xa3, 0xbb, 0x53, 0x6b, 0xf8, 0xc7, 0x68, 0x7a, 0x8b, 0x9c, 0x1d, 0xd5, 0x01, 0xd4,
-	0x45, 0xdc, 0x80, 0x8a, 0x3f, 0x47, 0xed, 0x5e, 0xce, 0xef, 0xfb, 0xdc, 0xef, 0xd3, 0x3c, 0x0b,
-	0x08, 0xc6, 0x1d, 0x35, 0x9a, 0x85, 0xcc, 0x69, 0x90, 0xb9, 0x77, 0x00, 0xf4, 0x96, 0x7b, 0x43,
-	0x83, 0x8a, 0xbd, 0x83, 0x81, 0xce, 0x1b, 0x7f, 0xe0, 0x8f, 0x08, 0x78, 0xfc, 0x29, 0x6a, 0x6f,
-	0x91, 0xef, 0x02, 0x7f, 0x1b, 0xe0, 0x97, 0xf1, 0xf1, 0xf0, 0x38, 0x42, 0xf3, 0xf7, 0x49, 0x4c,
-	0x38, 0x39, 0x01, 0xfe, 0x96, 0x8c, 0x49, 0xd5, 0x76, 0x87, 0xb0, 0x94, 0x26, 0xcc, 0x50, 0xad,
-	0x4d, 0xa0, 0x7a, 0x8e, 0xe6, 0xb6, 0x23, 0x66, 0xf5, 0x83, 0xe1, 0x65, 0x89, 0x5a, 0x16, 0xef,
-	0x90, 0x9f, 0xe5, 0x62, 0x5b, 0x75, 0x14, 0xa5, 0x51, 0x6c, 0xd2, 0x38, 0x26, 0x41, 0xf3, 0x68,
-	0x14, 0x74, 0xf8, 0x10, 0x5d, 0x13, 0x80, 0x1f, 0x91, 0x8c, 0x45, 0x34, 0x89, 0x92, 0xb0, 0x47,
-	0xe3, 0x28, 0x88, 0x08, 0xc3, 0xb7, 0x0b, 0xba, 0x8a, 0xf6, 0x50, 0x93, 0xae, 0x4a, 0x93, 0xaa,
-	0x7a, 0x12, 0xf5, 0x81, 0xb1, 0xc5, 0x7b, 0x68, 0x61, 0x8b, 0xd4, 0xb0, 0xf1, 0xb5, 0x0e, 0xec,
-	0xb5, 0x55, 0xb9, 0x73, 0x8c, 0xbc, 0x3e, 0x6e, 0x05, 0x85, 0x37, 0x7e, 0x9a, 0x47, 0x43, 0x11,
-	0xcc, 0x79, 0xe8, 0x46, 0x94, 0xf1, 0xdc, 0x8f, 0x3f, 0xa0, 0x43, 0xc2, 0xf0, 0x4d, 0xab, 0x7b,
-	0x96, 0x5c, 0x77, 0xed, 0xaa, 0x54, 0x83, 0xcc, 0xea, 0xcf, 0x0a, 0x90, 0x5d, 0xc3, 0x8b, 0x86,
-	0x4c, 0xb6, 0x4d, 0x00, 0xf3, 0x23, 0xd4, 0x12, 0x78, 0x6a, 0x49, 0x32, 0x7c, 0xbd, 0xe0, 0x50,
-	0x32, 0x0d, 0xbf, 0x24, 0x35, 0x4a, 0x6a, 0x11, 0x2c, 0x00, 0x41, 0x1b, 0xcf, 0x68, 0x82, 0x80,
-	0xc7, 0xb8, 0x8f, 0x66, 0x37, 0x69, 0xc2, 0x33, 0x1a, 0xeb, 0xd5, 0xbe, 0x6c, 0x56, 0x9d, 0x25,
-	0xd5, 0xe0, 0xad, 0x8e, 0xd8, 0xad, 0x94, 0xd0, 0xbd, 0x06, 0x88, 0xf3, 0xae, 0x8d, 0x28, 0x16,
-	0x4a, 0x82, 0xb0, 0x70, 0xac, 0x47, 0x48, 0xc6, 0xba, 0xc3, 0x61, 0x46, 0x18, 0x23, 0x0c, 0xbf,
-	0x5a, 0xb8, 0x5c, 0xd6, 0x54, 0xc6, 0xbc, 0xc9, 0x40, 0xcd, 0xee, 0xab, 0x40, 0x38, 0x87, 0xdb,
-	0x9a, 0x30, 0x15, 0x76, 0x38, 0x91, 0x33, 0x5a, 0x34, 0x7a, 0x48, 0xe3, 0xa1, 0x10, 0xad, 0x94,
-	0xb1, 0x94, 0xf8, 0x84, 0x21, 0x78, 0x1d, 0xe0, 0x57, 0xdd, 0xe5, 0x12, 0xbc, 0x37, 0x16, 0x08,
-	0xca, 0x19, 0xd8, 0x08, 0x0e, 0xd1, 0xfc, 0x66, 0x46, 0x7c, 0x4e, 0x0a, 0x68, 0x3d, 0xe8, 0x55,
-	0xb9, 0x66, 0xbc, 0x75, 0x9c, 0x5a, 0xf5, 0x4c, 0x51, 0x3b, 0x27, 0x51, 0xef, 0xc9, 0xd0, 0xf6,
-	0x39, 0xcd, 0xfc, 0x90, 0xdc, 0xcb, 0x83, 0x7d, 0xc2, 0x4b, 0xa1, 0x2d, 0x6b, 0x4e, 0xe8, 0xb0,
-	0x5a, 0x43, 0xee, 0x9c, 0x66, 0x1d, 0xc8, 0x66, 0x82, 0xe9, 0x39, 0x6a, 0x43, 0xf4, 0x32, 0x1a,
-	0xc8, 0xf1, 0x73, 0xac, 0x90, 0x6a, 0xa1, 0xc6, 0x5f, 0x6e, 0xd4, 0xa9, 0xbe, 0xa9, 0x99, 0xed,
-	0x5e, 0x31, 0x7d, 0xd3, 0x26, 0x82, 0xe7, 0x48, 0xf6, 0xe8, 0x81, 0x39, 0xe6, 0xdf, 0x27, 0x87,
-	0x0c, 0xaf, 0x76, 0xac, 0x73, 0xbf, 0x3b, 0x1c, 0x45, 0x89, 0x30, 0x12, 0x2a, 0x4d, 0x79, 0x7b,
-	0x82, 0x85, 0x22, 0x76, 0x81, 0x78, 0xc5, 0x5d, 0xd2, 0xc4, 0x56, 0x5a, 0x11, 0x47, 0x8c, 0x0b,
-	0xfa, 0xaf, 0xa6, 0xd0, 0x82, 0x1c, 0x95, 0x92, 0x07, 0xb8, 0x0e, 0x2f, 0xad, 0xde, 0x27, 0x66,
-	0x8f, 0x72, 0x27, 0x99, 0x28, 0x17, 0x6a, 0x27, 0x8b, 0xe5, 0x42, 0x00, 0xd6, 0xda, 0x09, 0xb9,
-	0xa5, 0x9f, 0xe4, 0x84, 0xb4, 0x9a, 0xe8, 0x84, 0x65, 0x72, 0x0a, 0x27, 0x86, 0x60, 0xad, 0x9d,
-	0x78, 0xf0, 0x65, 0x4a, 0x33, 0x7e, 0x92, 0x13, 0xd2, 0x6a, 0xa2, 0x13, 0x96, 0xc9, 0x29, 0x9c,
-	0x20, 0x60, 0xad, 0x9d, 0x78, 0x34, 0x3a, 0x8d, 0x13, 0xd2, 0x6a, 0xa2, 0x13, 0x96, 0x49, 0xd9,
-	0x09, 0xa7, 0xc9, 0x89, 0x68, 0xa4, 0x9d, 0xf8, 0x29, 0xc2, 0x0f, 0x92, 0x61, 0x4a, 0xa3, 0x84,
-	0xb3, 0xfb, 0x11, 0x0b, 0xe8, 0x01, 0xc9, 0xc4, 0xe9, 0x21, 0xcf, 0x41, 0x2d, 0xa8, 0x6c, 0xb8,
-	0x96, 0x5c, 0x91, 0xdd, 0x00, 0xb2, 0x05, 0x6c, 0xe6, 0xfd, 0xd0, 0x60, 0x0d, 0xd1, 0xfc, 0x87,
-	0x29, 0x49, 0xba, 0x69, 0x74, 0x32, 0xbe, 0x5a, 0xbb, 0xca, 0xbe, 0x7a, 0xd2, 0x5b, 0x49, 0x85,
-	0x6e, 0xe8, 0xd1, 0x94, 0x24, 0x7e, 0x1a, 0xe1, 0x97, 0x68, 0x51, 0x26, 0x4f, 0x0f, 0x69, 0x36,
-	0xb2, 0x7a, 0xb2, 0x64, 0x27, 0x56, 0x42, 0x77, 0x62, 0x57, 0xd6, 0x81, 0xec, 0x7f, 0xf1, 0xff,
-	0xd4, 0xc9, 0x9e, 0x0b, 0x6c, 0x6f, 0xac, 0xce, 0x04, 0x99, 0x62, 0x1c, 0xa1, 0x1b, 0x7d, 0x7d,
-	0x95, 0xea, 0xc2, 0x56, 0x63, 0xb1, 0xab, 0x9d, 0xb2, 0x6a, 0x50, 0xd9, 0x29, 0xeb, 0xea, 0xe3,
-	0xfa, 0x6d, 0x2e, 0x6d, 0x70, 0x49, 0xa1, 0x09, 0xc3, 0xdf, 0x4c, 0xa1, 0x95, 0x4a, 0x7b, 0xd1,
-	0xcb, 0xc2, 0x85, 0xd5, 0x46, 0x0e, 0x3b, 0x12, 0xb7, 0x27, 0x58, 0x28, 0x47, 0x3a, 0xe0, 0xc8,
-	0xff, 0xe1, 0xd7, 0x8f, 0x75, 0xc4, 0x1b, 0xcb, 0x66, 0x32, 0x28, 0x9f, 0xa1, 0x69, 0xd8, 0xa0,
-	0x23, 0x4e, 0x98, 0x1e, 0x6c, 0x23, 0xa8, 0x8c, 0x80, 0x25, 0x57, 0x6c, 0xb7, 0x80, 0xed, 0x3a,
-	0xbe, 0x66, 0xd8, 0x84, 0xda, 0x1b, 0x3f, 0x8c, 0x62, 0x4e, 0xb2, 0xa3, 0x8d, 0x5f, 0x9d, 0x43,
-	0x33, 0x3b, 0x34, 0x26, 0xfa, 0x18, 0x7f, 0x07, 0x5d, 0xea, 0x13, 0x2e, 0x24, 0x78, 0xba, 0x23,
-	0xae, 0x8b, 0xe2, 0xa7, 0x53, 0xfc, 0x74, 0x97, 0x00, 0xf0, 0x8a, 0xd3, 0xf2, 0x32, 0x1a, 0x13,
-	0x95, 0xcf, 0x88, 0xd9, 0xff, 0x0e, 0x42, 0x72, 0x0b, 0x99, 0xd0, 0x78, 0x11, 0x1a, 0xcf, 0xae,
-	0x95, 0x1a, 0xe3, 0xef, 0xa3, 0x4b, 0x5b, 0x13, 0x39, 0x55, 0x33, 0x5c, 0x6e, 0xf6, 0x21, 0x9a,
-	0xe9, 0x13, 0x3f, 0x0b, 0xf6, 0x84, 0x0d, 0xc3, 0x26, 0x81, 0xd1, 0xa2, 0xca, 0x42, 0x00, 0x2b,
-	0xeb, 0x10, 0x9b, 0x07, 0x50, 0xe4, 0xbe, 0x02, 0xa0, 0x77, 0xa7, 0xd6, 0x36, 0xfe, 0x7e, 0x0e,
-	0xcd, 0x3c, 0x65, 0x24, 0xd3, 0xb1, 0x78, 0x17, 0x5d, 0xea, 0xe5, 0x5c, 0x48, 0x94, 0x5f, 0xe2,
-	0xa7, 0x53, 0xfc, 0x74, 0xaf, 0x03, 0x04, 0x76, 0xda, 0x5e, 0xce, 0x48, 0xe6, 0x8d, 0xb7, 0x69,
-	0x18, 0x25, 0x10, 0x8c, 0xfb, 0x3a, 0x18, 0xd5, 0xd6, 0x8b, 0x76, 0x22, 0x5e, 0x4d, 0x50, 0xd6,
-	0xca, 0x40, 0xf8, 0x07, 0x10, 0x98, 0x09, 0x0e, 0x14, 0x89, 0x4d, 0xa9, 0x9d, 0x89, 0x8c, 0x30,
-	0xaa, 0x44, 0x46, 0x88, 0x2a, 0x91, 0x01, 0xab, 0xc6, 0xc8, 0x08, 0x54, 0xd1, 0x9d, 0x1f, 0xa1,
-	0xcb, 0xbd, 0x9c, 0xcb, 0x38, 0x37, 0x7b, 0xa2, 0xe6, 0x99, 0xb3, 0x20, 0x3d, 0x11, 0x21, 0x65,
-	0x56, 0x40, 0x36, 0xfe, 0x32, 0x85, 0x50, 0x77, 0x73, 0x5b, 0x87, 0x76, 0x1d, 0x5d, 0xec, 0xe5,
-	0xbc, 0x1b, 0xc4, 0xf8, 0x32, 0x60, 0x74, 0x37, 0xb7, 0x1d, 0xf3, 0xcb, 0x9d, 0x03, 0xb0, 0x69,
-	0xe7, 0x82, 0xe7, 0x07, 0x90, 0x19, 0xbe, 0x87, 0xa6, 0x65, 0xc4, 0xca, 0x2d, 0x9a, 0x83, 0xb9,
-	0x0c, 0xad, 0xaf, 0xba, 0xf3, 0xa2, 0xb5, 0x37, 0xc8, 0xe3, 0x7d, 0xeb, 0xb4, 0x7a, 0x8c, 0x90,
-	0x8c, 0x43, 0x37, 0x88, 0xcd, 0x72, 0x52, 0x92, 0xcd, 0x6d, 0x1d, 0x18, 0x75, 0x85, 0xec, 0x6e,
-	0x6e, 0x5b, 0x61, 0x51, 0x5e, 0xb9, 0xda, 0xab, 0x8d, 0x14, 0xb5, 0x65, 0xc6, 0xaf, 0x7b, 0xf5,
-	0x85, 0xcc, 0xb6, 0xcd, 0x85, 0x65, 0x05, 0x3c, 0x35, 0xa2, 0xc3, 0xad, 0x8c, 0xe6, 0xa9, 0x59,
-	0xb3, 0x37, 0x8f, 0xd1, 0xaa, 0x6e, 0x60, 0xa0, 0x6b, 0xb9, 0x97, 0xbc, 0x14, 0xd4, 0x82, 0xf1,
-	0xdb, 0x73, 0x68, 0xfe, 0x63, 0x9a, 0xed, 0xb3, 0xd4, 0x0f, 0xcc, 0x92, 0xdd, 0x46, 0xad, 0x5e,
-	0xce, 0x8d, 0x18, 0xcf, 0x02, 0xae, 0x79, 0x76, 0x2a, 0xcf, 0x3a, 0xaf, 0x72, 0xae, 0x78, 0x2f,
-	0xb5, 0xcc, 0x1b, 0xf7, 0xe3, 0x3c, 0x84, 0x99, 0xbb, 0x83, 0xe6, 0x64, 0x3c, 0x8f, 0x07, 0x6c,
-	0x0e, 0xbb, 0x3a, 0xb6, 0xd6, 0xea, 0xb0, 0x78, 0x80, 0xe6, 0x65, 0x88, 0x0d, 0x86, 0xc9, 0xb4,
-	0x2b, 0x72, 0x1d, 0x9b, 0x1b, 0x52, 0x6b, 0xe4, 0xd6, 0x30, 0xa8, 0x39, 0xef, 0xa2, 0x82, 0x47,
-	0x84, 0xe6, 0x8f, 0xe7, 0xd0, 0x5c, 0x57, 0x55, 0x9b, 0x74, 0x64, 0x3e, 0x45, 0x17, 0xfb, 0x50,
-	0x78, 0xc2, 0xb7, 0x3b, 0xba, 0x12, 0xd5, 0x91, 0x12, 0x65, 0x1a, 0x15, 0x5b, 0xe8, 0x7c, 0x61,
-	0xf2, 0x21, 0xdc, 0x9f, 0x4b, 0x13, 0x49, 0xd5, 0xb3, 0x64, 0x1d, 0x4b, 0xc4, 0xe9, 0x19, 0x9a,
-	0xee, 0xe7, 0x03, 0x16, 0x64, 0xd1, 0x80, 0xe0, 0x6b, 0x16, 0xbc, 0x14, 0x42, 0x6e, 0xe0, 0x1c,
-	0x23, 0xd7, 0xab, 0xc5, 0x5d, 0xb0, 0x90, 0x35, 0x98, 0x00, 0xff, 0x39, 0x5a, 0x90, 0x81, 0xb1,
-	0x5b, 0x31, 0x7c, 0xc7, 0x82, 0xab, 0xab, 0x8b, 0x79, 0x25, 0x23, 0x6b, 0xeb, 0xac, 0xf8, 0x15,
-	0xd9, 0x6d, 0x95, 0x5b, 0x9a, 0x8a, 0x60, 0x7e, 0x86, 0xd0, 0x36, 0x35, 0x85, 0x9c, 0x0f, 0xd0,
-	0xc5, 0xfe, 0x21, 0x8b, 0x69, 0x88, 0x17, 0x3a, 0x31, 0x0d, 0x61, 0xca, 0x6e, 0xd3, 0xb0, 0x72,
-	0xd1, 0xdf, 0xa6, 0xe1, 0x13, 0xc2, 0x98, 0x1f, 0x36, 0x5c, 0x1e, 0xdd, 0xcb, 0x50, 0x59, 0x63,
-	0x87, 0x80, 0xfe, 0x8f, 0xf3, 0xa8, 0xb5, 0x4b, 0xf7, 0x49, 0xa2, 0x09, 0x76, 0xd0, 0xc5, 0x1d,
-	0x72, 0x40, 0xf7, 0x89, 0x2e, 0xe8, 0xc8, 0x27, 0x4d, 0xb0, 0x58, 0x16, 0xaa, 0xf9, 0xa6, 0xea,
-	0x44, 0x2e, 0xf6, 0xfc, 0x9c, 0xef, 0x79, 0x5c, 0x00, 0x7a, 0x19, 0xd8, 0x88, 0x10, 0xfe, 0x72,
-	0x0a, 0xe1, 0x1d, 0xc2, 0x08, 0xef, 0xf9, 0x8c, 0xbd, 0xa4, 0xd9, 0x10, 0x18, 0xf5, 0x95, 0xa7,
-	0xae, 0xa9, 0xdc, 0x26, 0x9b, 0x0c, 0xca, 0x07, 0xb8, 0xf3, 0xba, 0x24, 0xce, 0x84, 0xe5, 0x7a,
-	0xaa, 0x4c, 0xd7, 0xa5, 0x1f, 0x63, 0xb1, 0x29, 0xaa, 0xdd, 0x38, 0x42, 0xed, 0x12, 0x9a, 0xbe,
-	0x11, 0x95, 0x84, 0x95, 0x1b, 0x51, 0x45, 0xa7, 0x98, 0x5f, 0x05, 0xe6, 0x1b, 0xee, 0x62, 0x13,
-	0xb3, 0xe8, 0xf4, 0xd7, 0x53, 0x68, 0x79, 0x8b, 0x24, 0x24, 0xf3, 0x39, 0xb9, 0x4f, 0x83, 0x7c,
-	0x44, 0x12, 0xde, 0x0d, 0xc4, 0xa5, 0x49, 0xf6, 0x5e, 0x75, 0xae, 0x41, 0x55, 0x49, 0x60, 0x1a,
-	0x2d, 0x9a, 0xbd, 0x90, 0x1d, 0x1e, 0xaa, 0x06, 0x62, 0x7c, 0x3f, 0x41, 0xed, 0x27, 0x50, 0x32,
-	0xd6, 0xe3, 0xbb, 0x85, 0x2e, 0xf4, 0x49, 0x32, 0xc4, 0xad, 0x8e, 0x2a, 0x25, 0x0b, 0xb5, 0x73,
-	0x5d, 0x3f, 0x09, 0x9d, 0x90, 0x18, 0x06, 0x95, 0x63, 0xb8, 0x2d, 0x5d, 0x81, 0x66, 0x24, 0x19,
-	0xca, 0x79, 0xd9, 0x56, 0x13, 0x5f, 0x21, 0xbf, 0x8f, 0x5e, 0x91, 0xc5, 0x93, 0x05, 0x59, 0x8b,
-	0x51, 0xa7, 0x7f, 0x79, 0x1b, 0xd7, 0x42, 0x96, 0xc7, 0x9c, 0xe9, 0x43, 0xdb, 0x6d, 0x7b, 0x0c,
-	0xe4, 0x1e, 0x54, 0x4a, 0x04, 0xfa, 0xef, 0x2f, 0xa0, 0x99, 0xdd, 0x8c, 0x98, 0x8d, 0xf5, 0x27,
-	0xa8, 0x7d, 0x2f, 0x8f, 0xf7, 0xfb, 0xdc, 0xe7, 0x92, 0x44, 0x55, 0x4f, 0xb6, 0x08, 0x17, 0xf2,
-	0x27, 0x84, 0xfb, 0x9a, 0x49, 0x1d, 0x24, 0x85, 0x58, 0xf5, 0xa4, 0x28, 0x75, 0x40, 0xcd, 0x9e,
-	0x71, 0x5f, 0xde, 0x92, 0x3f, 0x46, 0x33, 0xf2, 0xd2, 0x57, 0x02, 0xb6, 0x44, 0x27, 0xdc, 0xc0,
-	0x8b, 0x08, 0x01, 0x6e, 0x71, 0x25, 0xdc, 0x45, 0x97, 0xdf, 0x23, 0xfe, 0x50, 0xd8, 0x63, 0xd5,
-	0x56, 0x3f, 0x57, 0x7c, 0x2d, 0xc4, 0xb5, 0x7b, 0x87, 0xf1, 0xd5, 0x1b, 0x0b, 0x8b, 0x23, 0xfc,
-	0x0c, 0xcd, 0xc8, 0xdd, 0xbe, 0xe4, 0xae, 0x25, 0xaa, 0xec, 0xdb, 0x25, 0x4d, 0x6d, 0x50, 0x01,
-	0xbe, 0x38, 0x92, 0xbf, 0x40, 0xad, 0x1d, 0xc2, 0x38, 0xcd, 0x14, 0xfa, 0x0d, 0xb3, 0x04, 0x8c,
-	0xac, 0xb2, 0xd5, 0x94, 0x55, 0x0a, 0xbf, 0x18, 0x57, 0xc0, 0xcf, 0xa4, 0x8d, 0x20, 0x78, 0x81,
-	0xe6, 0x64, 0x64, 0xfb, 0x44, 0xc5, 0x4f, 0x9f, 0x3e, 0x15, 0x71, 0x65, 0x07, 0xad, 0x69, 0x15,
-	0x53, 0x51, 0xfe, 0x90, 0x81, 0xd2, 0x06, 0x32, 0x27, 0x98, 0xdf, 0xd5, 0xaf, 0x74, 0xf4, 0x3c,
-	0xfa, 0x4c, 0x96, 0x44, 0x8c, 0xdc, 0x2e, 0x89, 0x18, 0x61, 0x43, 0x49, 0xc4, 0xd2, 0x95, 0x73,
-	0x02, 0x8c, 0x3c, 0xf3, 0xde, 0x68, 0xe3, 0xdf, 0xe7, 0xd0, 0x8c, 0x98, 0x73, 0xc5, 0x66, 0x2a,
-	0x92, 0x46, 0x21, 0xd1, 0x3c, 0xe2, 0xb7, 0xb8, 0x4b, 0x94, 0x4e, 0x58, 0x24, 0x17, 0x8c, 0x88,
-	0xa1, 0xb5, 0xa2, 0x47, 0x84, 0xfb, 0x5e, 0x48, 0xd4, 0xc0, 0x9b, 0xa2, 0xfb, 0x36, 0xdc, 0x0a,
-	0x00, 0x73, 0xb1, 0xc0, 0x2c, 0xe6, 0xe3, 0x24, 0x34, 0x56, 0x43, 0xfb, 0x44, 0x27, 0xc7, 0x67,
-	0x72, 0xb2, 0x38, 0xb7, 0x00, 0x56, 0xce, 0x9f, 0x0a, 0xf2, 0xa7, 0x68, 0xc6, 0x5a, 0x9c, 0xdf,
-	0x61, 0xbd, 0xaa, 0x35, 0xe0, 0xce, 0x4a, 0x12, 0x48, 0x1e, 0x43, 0x02, 0xbb, 0xda, 0xef, 0x2e,
-	0xa1, 0x39, 0xb1, 0xab, 0xdb, 0xb1, 0x0e, 0xd1, 0xec, 0x53, 0x78, 0xa1, 0xa2, 0x15, 0xd8, 0x91,
-	0x29, 0x71, 0x49, 0x58, 0x0c, 0x6d, 0x93, 0xae, 0x5c, 0xed, 0x72, 0xae, 0x40, 0x02, 0xbd, 0x0e,
-	0xf4, 0xf2, 0x65, 0x8d, 0xe8, 0xd8, 0x10, 0xcd, 0x16, 0xe9, 0xbb, 0x45, 0x54, 0x16, 0x6a, 0xa2,
-	0xeb, 0x45, 0x5e, 0x5f, 0x1e, 0x27, 0xab, 0xa6, 0x56, 0xb0, 0xc8, 0x6d, 0x50, 0xb2, 0xb4, 0x45,
-	0x9b, 0x7b, 0x94, 0xee, 0x8f, 0xfc, 0x6c, 0xdf, 0x4c, 0xd4, 0x92, 0xf0, 0xa4, 0x10, 0x16, 0xc3,
-	0x5f, 0x50, 0x0c, 0x74, 0x63, 0xc1, 0xf2, 0x8b, 0x29, 0xb4, 0x54, 0x0e, 0x82, 0x19, 0x77, 0xfc,
-	0x5a, 0x43, 0x88, 0x6a, 0xb3, 0xe2, 0xce, 0x64, 0xa3, 0xb2, 0x1f, 0x8e, 0xed, 0x47, 0xa2, 0xad,
-	0x84, 0x1f, 0x63, 0x74, 0x55, 0xac, 0xb2, 0xba, 0x13, 0xb7, 0x4d, 0x62, 0x7e, 0xac, 0x0b, 0xb7,
-	0xcb, 0x11, 0x36, 0xfa, 0xc6, 0xc2, 0x7c, 0x03, 0x3f, 0x3e, 0x90, 0x2f, 0x00, 0x34, 0xc0, 0xae,
-	0x1f, 0x96, 0x5e, 0x00, 0xd8, 0xf2, 0x4a, 0x85, 0xa3, 0xae, 0x56, 0x1d, 0x7e, 0x0d, 0x08, 0x6f,
-	0xe2, 0x65, 0x8b, 0x90, 0xfb, 0x21, 0x93, 0x2f, 0x70, 0x80, 0xf6, 0x08, 0x33, 0x34, 0xab, 0xee,
-	0xb4, 0xaa, 0xbd, 0x2e, 0xdc, 0x97, 0xa5, 0x9a, 0x73, 0xa5, 0x59, 0x59, 0xae, 0x3e, 0xbb, 0x93,
-	0x18, 0x55, 0x5a, 0x82, 0x8b, 0xeb, 0xb0, 0xe9, 0xef, 0xab, 0xf6, 0x61, 0xd1, 0xd4, 0xe3, 0xd5,
-	0xe3, 0x0d, 0x94, 0x07, 0x6b, 0xe0, 0xc1, 0x9d, 0x35, 0x77, 0x82, 0x07, 0xde, 0x58, 0x34, 0x39,
-	0xda, 0xf8, 0xe7, 0x79, 0x34, 0xf3, 0x98, 0x0e, 0xcc, 0xb6, 0xfc, 0xb9, 0x9c, 0xed, 0x72, 0x97,
-	0x7f, 0x4c, 0x07, 0x7a, 0x6b, 0x13, 0xc2, 0xc7, 0x74, 0xd0, 0x70, 0x49, 0x06, 0x69, 0x6d, 0x7a,
-	0xc1, 0x9b, 0x6a, 0x79, 0xff, 0x7e, 0x4c, 0x07, 0xe6, 0xb5, 0xdf, 0x47, 0xa8, 0x05, 0x49, 0x60,
-	0xc4, 0xb8, 0x60, 0xc5, 0x57, 0x3b, 0xf0, 0x36, 0x5b, 0x3f, 0x37, 0xac, 0x55, 0x21, 0x6e, 0xbc,
-	0xe8, 0x18, 0x06, 0x81, 0xfb, 0x14, 0xcd, 0x82, 0xdb, 0xf2, 0x45, 0x8b, 0xf0, 0xfb, 0x8a, 0x44,
-	0xde, 0xe4, 0x59, 0xbc, 0x49, 0x47, 0x23, 0x3f, 0x19, 0x3a, 0x37, 0x6a, 0xa2, 0x6a, 0xad, 0xc1,
-	0xa9, 0xc0, 0x12, 0xb9, 0xbb, 0xc9, 0x58, 0xef, 0xfa, 0x6c, 0x5f, 0x1c, 0xf3, 0x00, 0x62, 0x89,
-	0x8a, 0x63, 0xbe, 0xae, 0xa9, 0xa5, 0xe5, 0x00, 0xcf, 0x85, 0xd2, 0x3a, 0xec, 0x3f, 0x57, 0x67,
-	0xa1, 0x10, 0x6f, 0xd3, 0x90, 0x9d, 0xfd, 0x4a, 0x51, 0xdc, 0xca, 0x2c, 0x82, 0x98, 0x86, 0x90,
-	0xc2, 0xfd, 0x69, 0x0a, 0xcd, 0x43, 0x0d, 0xd7, 0xce, 0xe3, 0x9e, 0x49, 0x4e, 0x23, 0xd7, 0x2f,
-	0xf4, 0x84, 0xf0, 0x34, 0xc9, 0x56, 0xc1, 0x08, 0xe7, 0xbd, 0x2f, 0x70, 0xcc, 0x8b, 0x80, 0x67,
-	0xa8, 0x2d, 0x12, 0xc4, 0x02, 0xfc, 0xaa, 0x04, 0xdf, 0xa9, 0x65, 0x5d, 0x15, 0x71, 0xad, 0x5a,
-	0x61, 0x81, 0x8b, 0xdc, 0x4b, 0x74, 0xe7, 0x0f, 0x53, 0xa8, 0xb5, 0x95, 0xf9, 0xe9, 0x5e, 0x91,
-	0x4a, 0x4c, 0x43, 0x85, 0x8a, 0xfb, 0x9c, 0xe8, 0xea, 0x85, 0x11, 0x54, 0x8a, 0x81, 0x96, 0xbc,
-	0x56, 0x0c, 0x84, 0x0f, 0x54, 0x80, 0x46, 0x5c, 0xd2, 0x49, 0x28, 0x52, 0x77, 0x91, 0xe6, 0x5d,
-	0xde, 0x21, 0x31, 0xbc, 0x30, 0xd7, 0xc9, 0xa3, 0x7e, 0xae, 0xec, 0xfa, 0x85, 0x58, 0x41, 0xaf,
-	0x02, 0xb4, 0x83, 0xaf, 0x2b, 0xe8, 0x4c, 0x19, 0xc8, 0x9b, 0xd0, 0xa3, 0xe1, 0xd1, 0xc6, 0x57,
-	0x17, 0x51, 0xab, 0xbf, 0xe7, 0x67, 0x66, 0x58, 0x36, 0xa1, 0xbc, 0xb6, 0x49, 0xe2, 0x58, 0xaf,
-	0x3c, 0xf5, 0x58, 0x9c, 0xfe, 0x32, 0x0d, 0x23, 0x71, 0xac, 0x13, 0x69, 0x67, 0xc6, 0x83, 0xcf,
-	0x69, 0xe0, 0x0b, 0x07, 0x11, 0xfe, 0x2d, 0xc8, 0x76, 0x6c, 0x10, 0xf5, 0xd8, 0x04, 0x52, 0xbc,
-	0xfb, 0x2d, 0x40, 0x74, 0x35, 0xf1, 0x99, 0x4e, 0x4a, 0x00, 0x6b, 0xc9, 0xde, 0x79, 0x6c, 0xb8,
-	0xeb, 0x75, 0x45, 0x39, 0x2b, 0x5c, 0x6b, 0x02, 0xdf, 0x81, 0x12, 0x0d, 0xf4, 0x7e, 0x3b, 0x4a,
-	0xf6, 0x75, 0x8a, 0x6b, 0xcb, 0x34, 0xc1, 0x9c, 0xba, 0xb3, 0x68, 0x79, 0xad, 0xe7, 0x71, 0x94,
-	0xec, 0xab, 0xfd, 0x65, 0x8b, 0xd4, 0x31, 0x6d, 0xd9, 0xb1, 0x98, 0xd5, 0x40, 0x08, 0x4c, 0xed,
-	0xeb, 0x0b, 0x5d, 0x00, 0x2a, 0xa0, 0x57, 0xec, 0x4e, 0xd7, 0xd0, 0x6f, 0x1e, 0xa3, 0x3d, 0x26,
-	0x2e, 0x36, 0xd7, 0x4b, 0xb4, 0x00, 0x25, 0x6b, 0xa1, 0x10, 0x3b, 0x94, 0xfa, 0xae, 0xc0, 0x7a,
-	0xa3, 0x5b, 0x51, 0x55, 0xce, 0xdf, 0x46, 0x8b, 0xda, 0xc2, 0x92, 0xbc, 0x99, 0xb6, 0x10, 0xc1,
-	0x3b, 0x40, 0x0b, 0x32, 0x7f, 0x80, 0xd6, 0xa6, 0x60, 0xa7, 0x88, 0x1b, 0x54, 0xd5, 0x83, 0xbf,
-	0xc9, 0xa2, 0xdc, 0x61, 0x67, 0x4e, 0x11, 0xa7, 0xca, 0x40, 0x2c, 0xe8, 0xaf, 0x2f, 0xa0, 0xd9,
-	0x47, 0xf2, 0x7b, 0x9f, 0xe2, 0x96, 0x89, 0xb6, 0x08, 0x57, 0x42, 0xbc, 0xdc, 0xd1, 0x9f, 0x03,
-	0x6d, 0x11, 0x7e, 0x9f, 0x3c, 0xf7, 0xc5, 0x9d, 0xb5, 0x38, 0x8d, 0x1b, 0x95, 0x8a, 0x57, 0x95,
-	0x6d, 0xf1, 0x65, 0xfd, 0x45, 0x11, 0x7e, 0x8a, 0x66, 0x7a, 0x94, 0x19, 0xec, 0x25, 0xd3, 0x5c,
-	0x49, 0x8a, 0x49, 0x5d, 0x53, 0x28, 0xcc, 0xa2, 0x7e, 0xa3, 0x2c, 0x44, 0xf0, 0x46, 0x68, 0xa1,
-	0x47, 0xb2, 0xe7, 0x34, 0x1b, 0x29, 0xf3, 0xcd, 0x3d, 0x12, 0x88, 0x59, 0xa2, 0x51, 0x94, 0x16,
-	0xc4, 0x56, 0xb5, 0xb3, 0x51, 0x5b, 0x4b, 0xbc, 0xf5, 0x67, 0x51, 0x81, 0xd0, 0x0b, 0xba, 0x10,
-	0x26, 0x7a, 0x37, 0xcc, 0x08, 0x11, 0xdb, 0x14, 0x2e, 0x45, 0xc1, 0x88, 0xeb, 0x3c, 0x65, 0x6d,
-	0x79, 0x70, 0x30, 0x36, 0x3c, 0xbe, 0x01, 0x0e, 0x51, 0x5b, 0x75, 0xe8, 0xc1, 0x01, 0x49, 0xb8,
-	0x48, 0xc8, 0x2a, 0x71, 0x91, 0xf2, 0x22, 0x21, 0x3b, 0x46, 0x5d, 0xbe, 0xf1, 0xe2, 0x39, 0xc3,
-	0x45, 0xc0, 0x60, 0xe3, 0xcf, 0x53, 0xa8, 0xad, 0x66, 0x90, 0x9a, 0x04, 0x7d, 0x7d, 0x91, 0x10,
-	0xd8, 0x51, 0x46, 0x86, 0xf8, 0x6a, 0x47, 0x7d, 0xaa, 0x55, 0xc8, 0xe5, 0xfe, 0x5b, 0x11, 0xd7,
-	0xaa, 0xc5, 0xc5, 0xa5, 0xe1, 0x05, 0x9a, 0xe9, 0xa6, 0x69, 0x7c, 0x28, 0x4d, 0xb1, 0xa3, 0x9b,
-	0x5a, 0xc2, 0xe2, 0x6a, 0xd2, 0xa4, 0x2b, 0xbf, 0x0f, 0xdf, 0x58, 0xd2, 0x1f, 0x91, 0x8d, 0x77,
-	0xfd, 0x2c, 0x34, 0x1f, 0xca, 0x40, 0x7d, 0xff, 0x6f, 0x97, 0xd0, 0xdc, 0x43, 0xf5, 0x6d, 0xa3,
-	0xee, 0xd4, 0x27, 0x08, 0x81, 0x48, 0x9e, 0x56, 0x6a, 0x4b, 0x2d, 0x24, 0x95, 0x2d, 0xd5, 0x56,
-	0xd4, 0x02, 0xa8, 0x3f, 0x9b, 0x94, 0x47, 0x96, 0xb8, 0xa8, 0x80, 0xf9, 0x3d, 0x4a, 0xe1, 0x53,
-	0x30, 0x7d, 0x51, 0x29, 0x09, 0x2b, 0x37, 0xea, 0x8a, 0xae, 0x36, 0x1f, 0x0c, 0xc5, 0x80, 0x52,
-	0x1e, 0x08, 0xd0, 0x7d, 0xc5, 0xa2, 0x72, 0x10, 0x56, 0x62, 0xd1, 0xc2, 0x26, 0x96, 0x42, 0x57,
-	0x7b, 0xf9, 0x68, 0x58, 0x46, 0xca, 0xc6, 0x1b, 0x6f, 0xfb, 0x49, 0x78, 0x24, 0x66, 0x39, 0xb4,
-	0xed, 0xc5, 0x79, 0x18, 0x25, 0xa6, 0x0a, 0x62, 0xcb, 0x2a, 0xd9, 0x51, 0x59, 0x55, 0x3b, 0x87,
-	0x0d, 0x53, 0x2a, 0x4d, 0x34, 0x51, 0xa0, 0x88, 0xfa, 0x84, 0x89, 0xd1, 0x2b, 0x11, 0x29, 0x59,
-	0x13, 0x91, 0x51, 0xd5, 0xbe, 0xce, 0x28, 0xc6, 0x46, 0x9a, 0x88, 0xa9, 0xb7, 0xaf, 0x66, 0xc3,
-	0x83, 0x24, 0xa3, 0x71, 0xdc, 0xcd, 0xf9, 0x9e, 0x3e, 0x44, 0x2a, 0xe2, 0xca, 0x21, 0x52, 0xd3,
-	0xd6, 0x36, 0x73, 0xc3, 0x46, 0xc0, 0x4a, 0x90, 0xbd, 0x44, 0xf3, 0xca, 0xc5, 0xec, 0x80, 0xdc,
-	0x8b, 0x12, 0x3f, 0x3b, 0xc4, 0xf6, 0xa4, 0x92, 0xa2, 0x4a, 0x89, 0xaa, 0xa4, 0xa9, 0xbd, 0x9a,
-	0x2d, 0x26, 0x83, 0xb0, 0x88, 0xc4, 0x30, 0x49, 0xdb, 0xdd, 0xc3, 0x54, 0xdc, 0x28, 0xe4, 0xf1,
-	0xf5, 0x25, 0x9a, 0x95, 0x83, 0x90, 0xf3, 0xff, 0x86, 0xf6, 0x4d, 0xa0, 0xfd, 0x7f, 0xf7, 0x94,
-	0xb4, 0xf2, 0x2b, 0x9b, 0x56, 0x9f, 0x70, 0x1e, 0x25, 0x21, 0x7b, 0x42, 0x92, 0x5c, 0x0f, 0xa2,
-	0x2d, 0xab, 0x0c, 0x62, 0x59, 0x55, 0xbe, 0xc4, 0xe0, 0x25, 0x7b, 0x10, 0xa5, 0xdd, 0xfa, 0x88,
-	0x24, 0xf9, 0xbd, 0x6f, 0xa7, 0x7e, 0xdd, 0xfd, 0xcd, 0x14, 0x7e, 0x1b, 0x2d, 0xf6, 0x0e, 0x87,
-	0x11, 0x5d, 0x15, 0x19, 0x0f, 0x5b, 0xdd, 0x21, 0x8c, 0xaf, 0x76, 0x7b, 0x8f, 0x5c, 0x07, 0xbd,
-	0x02, 0x72, 0x7c, 0x65, 0x8f, 0xf3, 0x94, 0xdd, 0xf5, 0xe4, 0xf7, 0xa7, 0x9d, 0x80, 0x8e, 0x36,
-	0xce, 0xbf, 0xd9, 0x79, 0x63, 0xed, 0xfc, 0xd4, 0xb9, 0x0b, 0x1b, 0xf3, 0x7e, 0x9a, 0xc6, 0x51,
-	0x20, 0xf3, 0xc1, 0x17, 0x8c, 0x26, 0x77, 0x6b, 0x92, 0xec, 0x0d, 0xb4, 0xfc, 0x84, 0x66, 0x64,
-	0xd5, 0x1f, 0xd0, 0x9c, 0xaf, 0xda, 0x64, 0xdd, 0x34, 0x62, 0x0d, 0xf8, 0x83, 0x8b, 0xf0, 0xdd,
-	0xe9, 0x5b, 0xff, 0x09, 0x00, 0x00, 0xff, 0xff, 0xfa, 0xe9, 0x9e, 0x38, 0xd1, 0x2d, 0x00, 0x00,
+	// 4067 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x5b, 0x5b, 0x6f, 0x1c, 0x47,
+	0x76, 0xc6, 0xc8, 0xb2, 0x2e, 0xc5, 0xab, 0x6a, 0x44, 0x91, 0x6a, 0x4a, 0x36, 0xd9, 0xbe, 0x05,
+	0x4c, 0x38, 0x6d, 0x73, 0x91, 0xec, 0xae, 0x5f, 0x92, 0x11, 0x25, 0x71, 0x25, 0x53, 0xf6, 0x84,
+	0x23, 0x79, 0x1d, 0xcb, 0xc6, 0x6e, 0x4f, 0x4f, 0x71, 0xd8, 0x62, 0x4f, 0xd7, 0xa4, 0xab, 0x9a,
+	0x5a, 0x82, 0xe0, 0x06, 0xf0, 0x22, 0xb7, 0xd7, 0xec, 0x22, 0x30, 0x82, 0xfc, 0x80, 0x20, 0x8f,
+	0xf9, 0x01, 0x79, 0x4f, 0x90, 0x87, 0x04, 0xc9, 0x6b, 0x1e, 0x02, 0x24, 0xff, 0x23, 0x38, 0x75,
+	0xef, 0xea, 0x1e, 0x5e, 0xec, 0xe4, 0x41, 0xe2, 0xcc, 0x39, 0xa7, 0xbe, 0xef, 0xd4, 0xa9, 0xea,
+	0xaa, 0x53, 0xa7, 0x7a, 0x10, 0x2a, 0x08, 0xe3, 0x9d, 0x49, 0x41, 0x39, 0xc5, 0x57, 0xe1, 0x73,
+	0x30, 0x9b, 0xd0, 0xf1, 0x98, 0xe6, 0x52, 0x16, 0xa0, 0x61, 0xcc, 0x63, 0xf5, 0xf9, 0x66, 0x3a,
+	0x1c, 0xab, 0x8f, 0xb3, 0x83, 0x82, 0x1e, 0x92, 0x42, 0x7f, 0x4b, 0x68, 0xbe, 0x9f, 0x8e, 0xd4,
+	0xb7, 0x05, 0x96, 0x1c, 0x90, 0x61, 0x99, 0x19, 0xf5, 0xcc, 0xa8, 0x88, 0x27, 0x07, 0xfa, 0x0b,
+	0x3b, 0x88, 0x0b, 0xa2, 0xbe, 0xcc, 0xef, 0x17, 0x34, 0xe7, 0x24, 0x1f, 0xea, 0xa6, 0x9c, 0x8c,
+	0x27, 0x59, 0xcc, 0x09, 0x53, 0x82, 0x1f, 0x8c, 0x52, 0x7e, 0x50, 0x0e, 0x3a, 0x09, 0x1d, 0x47,
+	0x93, 0xe3, 0x61, 0x4a, 0xa3, 0x84, 0x64, 0x19, 0x8b, 0xa4, 0x8f, 0x91, 0x30, 0x8a, 0x78, 0x41,
+	0x88, 0xf8, 0x4f, 0x35, 0xfa, 0xe8, 0x22, 0x8d, 0xd2, 0xe1, 0x38, 0xb2, 0xfd, 0xf9, 0xe1, 0x45,
+	0x9a, 0x8c, 0xe3, 0x34, 0x23, 0x85, 0xfa, 0xa3, 0x1a, 0x76, 0x2f, 0xd2, 0x30, 0x4e, 0x78, 0x7a,
+	0x94, 0xf2, 0x63, 0xf3, 0x81, 0xf1, 0x82, 0xc4, 0xe3, 0xcb, 0xf4, 0xf1, 0x15, 0x1d, 0x30, 0xf1,
+	0x9f, 0x6a, 0xf4, 0xfb, 0x17, 0x69, 0x44, 0xf2, 0xa4, 0x38, 0x9e, 0xf0, 0x94, 0xe6, 0xce, 0xc7,
+	0xcb, 0x04, 0x29, 0xa3, 0x23, 0xf8, 0x77, 0x99, 0x20, 0xd1, 0xc1, 0x2b, 0x92, 0x70, 0xf5, 0x47,
+	0x35, 0xfc, 0xf1, 0x85, 0x06, 0x24, 0x67, 0x3c, 0xce, 0x32, 0xfd, 0xf7, 0x32, 0x6e, 0x26, 0x3c,
+	0x83, 0x7f, 0x97, 0x71, 0xb3, 0x9c, 0x0c, 0x63, 0x4e, 0xd4, 0x1f, 0xd5, 0xf0, 0xde, 0x88, 0xd2,
+	0x51, 0x46, 0xa2, 0x78, 0x92, 0x46, 0x71, 0x9e, 0x53, 0x1e, 0x43, 0xbc, 0x74, 0xc4, 0x7f, 0x47,
+	0xfc, 0x49, 0x36, 0x47, 0x24, 0xdf, 0x64, 0xaf, 0xe3, 0xd1, 0x88, 0x14, 0x11, 0x15, 0x11, 0x65,
+	0x75, 0xeb, 0xad, 0xbf, 0x5d, 0x47, 0x73, 0xdb, 0xe2, 0xa9, 0xe8, 0x93, 0xe2, 0x28, 0x4d, 0x08,
+	0x7e, 0x8e, 0x6e, 0xf6, 0x4a, 0x2e, 0x65, 0xb8, 0xdd, 0x11, 0xcf, 0x9d, 0xfc, 0x56, 0x16, 0xa2,
+	0x69, 0xd0, 0x24, 0x0c, 0xef, 0x7f, 0xf3, 0xef, 0xff, 0xfd, 0xeb, 0x2b, 0xcb, 0x01, 0x8e, 0xe4,
+	0x43, 0x16, 0x9d, 0x3c, 0x2e, 0xb3, 0xac, 0x17, 0xf3, 0x83, 0xd3, 0x8f, 0x5b, 0x1b, 0xf8, 0x0f,
+	0xd1, 0xcd, 0x1d, 0x72, 0x79, 0xd4, 0x40, 0xa0, 0xde, 0xc6, 0x0d, 0xa8, 0xf8, 0x6b, 0x34, 0xd7,
+	0x2b, 0xf9, 0xc3, 0x98, 0xc7, 0x7d, 0x5a, 0x16, 0x09, 0xc1, 0xb8, 0xa3, 0x46, 0xd3, 0xca, 0x82,
+	0x06, 0x59, 0xf8, 0xae, 0x00, 0x7d, 0x2b, 0xbc, 0xab, 0x41, 0x61, 0xed, 0x60, 0x42, 0x17, 0x9d,
+	0x7c, 0x1a, 0x8f, 0x89, 0xf0, 0xf8, 0x4b, 0x34, 0xb7, 0x43, 0xbe, 0x0b, 0xfc, 0xba, 0x80, 0x5f,
+	0xc5, 0xd3, 0xe1, 0x71, 0x8a, 0x16, 0x1f, 0x92, 0x8c, 0x70, 0x72, 0x0e, 0xfc, 0x5b, 0x32, 0x26,
+	0xbe, 0xed, 0x1e, 0x61, 0x13, 0x9a, 0x33, 0x43, 0xb5, 0x71, 0x06, 0xd5, 0x3e, 0x5a, 0xd8, 0x4d,
+	0x99, 0xd3, 0x0f, 0x86, 0x57, 0x25, 0x6a, 0x55, 0xbc, 0x47, 0xfe, 0xb8, 0x84, 0x65, 0x35, 0x50,
+	0x94, 0x46, 0xb1, 0x4d, 0xb3, 0x8c, 0x24, 0xcd, 0xa3, 0x61, 0xe9, 0xf0, 0x31, 0xba, 0x03, 0x80,
+	0x9f, 0x93, 0x82, 0xa5, 0x34, 0x4f, 0xf3, 0x51, 0x8f, 0x66, 0x69, 0x92, 0x12, 0x86, 0xd7, 0x2d,
+	0x9d, 0xa7, 0x3d, 0xd6, 0xa4, 0x6b, 0xd2, 0xc4, 0x57, 0x9f, 0x45, 0x7d, 0x64, 0x6c, 0xf1, 0x01,
+	0x6a, 0xef, 0x90, 0x1a, 0x36, 0xbe, 0xd3, 0x11, 0x6b, 0xad, 0x2f, 0x0f, 0xa6, 0xc8, 0xeb, 0xe3,
+	0x66, 0x29, 0xa2, 0x93, 0x17, 0x65, 0x3a, 0x3c, 0xc5, 0x7f, 0xdd, 0x42, 0x81, 0x17, 0xcd, 0x67,
+	0x71, 0x0a, 0x3b, 0x43, 0x9c, 0x27, 0x04, 0x7f, 0xd0, 0x14, 0x58, 0xd7, 0x42, 0xf7, 0xf7, 0x7d,
+	0x3f, 0xc8, 0xae, 0x91, 0xd3, 0xeb, 0x0f, 0x84, 0x4b, 0xeb, 0xf8, 0x6d, 0xed, 0xd2, 0xd8, 0x9a,
+	0x39, 0xc1, 0x67, 0xf8, 0x37, 0x2d, 0xb4, 0x52, 0x99, 0xad, 0xae, 0x5b, 0xa1, 0xcf, 0xd6, 0xe0,
+	0xd1, 0x7b, 0x67, 0xd8, 0x38, 0x0e, 0x6d, 0x0a, 0x87, 0x3e, 0xc0, 0xef, 0x4d, 0x9d, 0x70, 0xae,
+	0x8b, 0xf8, 0x6f, 0x5a, 0x68, 0xa5, 0xf2, 0x8c, 0xba, 0x6e, 0x29, 0xca, 0x69, 0xfa, 0x4b, 0x7a,
+	0xf6, 0xa1, 0xf0, 0x6c, 0x23, 0xbc, 0x98, 0x67, 0xf0, 0x80, 0xff, 0x59, 0x0b, 0xad, 0xfa, 0x4f,
+	0xd6, 0x65, 0xc3, 0x76, 0xdb, 0x7d, 0x40, 0xcd, 0x63, 0xa9, 0xa2, 0xb4, 0x71, 0xc1, 0x28, 0x1d,
+	0xa2, 0x76, 0xaf, 0xfc, 0xfe, 0xf3, 0xb7, 0xb6, 0xac, 0xd5, 0xe6, 0x2f, 0xf4, 0x3a, 0x45, 0x77,
+	0xa4, 0xb7, 0x17, 0xe6, 0x6b, 0xee, 0x63, 0x6d, 0xe9, 0xa9, 0x3f, 0x2d, 0x7f, 0xd1, 0x42, 0x2b,
+	0xfd, 0x74, 0x5c, 0x66, 0x71, 0x03, 0xdb, 0xba, 0xff, 0xc8, 0x2b, 0xcb, 0x94, 0xe6, 0xde, 0x52,
+	0xd4, 0x64, 0xa2, 0x43, 0x1c, 0x86, 0x53, 0xe9, 0x23, 0xa6, 0xa8, 0xa1, 0xd7, 0xfb, 0x68, 0x51,
+	0xac, 0x3f, 0x69, 0xc1, 0xcb, 0x38, 0xfb, 0x94, 0x0e, 0x09, 0xc3, 0xf7, 0x9d, 0x75, 0xc9, 0x91,
+	0x6b, 0xf6, 0x25, 0xa9, 0x16, 0x32, 0x67, 0x9e, 0xdd, 0x13, 0xc4, 0x77, 0xf0, 0x6d, 0x43, 0x2c,
+	0xdb, 0xe6, 0x02, 0xb3, 0x87, 0xe6, 0x61, 0x28, 0x2d, 0x1c, 0x46, 0x32, 0xaa, 0xf0, 0x39, 0x70,
+	0x3e, 0x87, 0xef, 0x0b, 0x9c, 0xb5, 0x70, 0xb5, 0x09, 0xc7, 0x19, 0xaf, 0x2f, 0xd1, 0x2d, 0x35,
+	0x5e, 0x53, 0x40, 0x9b, 0x87, 0xe7, 0x1d, 0x01, 0x7f, 0x7f, 0xe3, 0x2c, 0x78, 0xfc, 0x27, 0x08,
+	0xf7, 0x0a, 0x72, 0x94, 0x92, 0xd7, 0x2e, 0xf8, 0xdb, 0x2a, 0xec, 0x56, 0xa4, 0x8c, 0x74, 0x64,
+	0x56, 0xa6, 0x19, 0x84, 0x1d, 0xc1, 0xfa, 0x5b, 0xe1, 0x3b, 0x67, 0xb0, 0x46, 0x13, 0x69, 0x0c,
+	0x9d, 0xfb, 0x1c, 0xcd, 0x42, 0xf8, 0x55, 0xea, 0xc1, 0xf0, 0x8a, 0x1d, 0x12, 0x25, 0xd3, 0x9c,
+	0xcb, 0x52, 0xa3, 0xa4, 0xce, 0x78, 0xb4, 0x05, 0xe5, 0x1c, 0x9e, 0xd1, 0x94, 0x09, 0xcf, 0x70,
+	0x1f, 0xcd, 0x6f, 0xd3, 0x9c, 0x17, 0x34, 0x53, 0x0d, 0xf4, 0x9e, 0x57, 0x95, 0x6a, 0xf0, 0xd9,
+	0x0e, 0x64, 0x65, 0x4a, 0x18, 0xde, 0x11, 0x88, 0x8b, 0xa1, 0x8b, 0x08, 0xce, 0xe6, 0x08, 0x83,
+	0x63, 0x3d, 0x42, 0x0a, 0xd6, 0x1d, 0x0e, 0x0b, 0xc2, 0x18, 0x61, 0x3a, 0x5a, 0x75, 0x8d, 0xb7,
+	0xb7, 0x35, 0x19, 0xa8, 0xb1, 0x5a, 0x12, 0x84, 0x0b, 0x78, 0x4e, 0x13, 0x4e, 0xc0, 0x0e, 0xe7,
+	0x68, 0x41, 0x37, 0x7a, 0x4c, 0xb3, 0x21, 0x88, 0xee, 0x55, 0xb1, 0x94, 0xf8, 0x9c, 0x19, 0x5b,
+	0x9b, 0x69, 0x02, 0x3e, 0x3a, 0x01, 0x04, 0xe5, 0x8c, 0x98, 0x69, 0xc7, 0x68, 0x71, 0xbb, 0x20,
+	0x31, 0x27, 0x16, 0x5a, 0x3f, 0x23, 0xbe, 0x5c, 0x33, 0xbe, 0x35, 0x4d, 0xad, 0x7a, 0xa6, 0xa8,
+	0x83, 0xf3, 0xa8, 0x0f, 0x64, 0x68, 0xfb, 0x9c, 0x16, 0xf1, 0x88, 0x3c, 0x28, 0x93, 0x43, 0xc2,
+	0x2b, 0xa1, 0xad, 0x6a, 0xce, 0xe9, 0xb0, 0xca, 0x15, 0xc2, 0x05, 0xcd, 0x3a, 0x90, 0xcd, 0xe4,
+	0x42, 0x30, 0x27, 0xa2, 0x57, 0xd0, 0x44, 0x8e, 0x5f, 0xe0, 0x84, 0x54, 0x0b, 0x35, 0xfe, 0x6a,
+	0xa3, 0x4e, 0xf5, 0x4d, 0x2d, 0x04, 0xe1, 0x2d, 0xd3, 0x37, 0x6d, 0x02, 0x3c, 0xa7, 0xb2, 0x47,
+	0x8f, 0xcc, 0x71, 0xe6, 0x13, 0x72, 0xcc, 0xf0, 0x5a, 0xc7, 0x39, 0xdf, 0x74, 0x87, 0xe3, 0x34,
+	0x07, 0x23, 0x50, 0x69, 0xca, 0xf5, 0x33, 0x2c, 0x14, 0x71, 0x28, 0x88, 0xef, 0x85, 0xcb, 0x9a,
+	0xd8, 0xb6, 0x88, 0xb2, 0x94, 0x71, 0xa0, 0xff, 0xa6, 0x85, 0xda, 0x72, 0x54, 0x2a, 0x1e, 0xe0,
+	0x3a, 0xbc, 0xb4, 0xfa, 0x84, 0x98, 0x5c, 0x2c, 0x3c, 0xcb, 0x44, 0xb9, 0x50, 0xdb, 0x6a, 0x1c,
+	0x17, 0x12, 0x61, 0xad, 0x9d, 0x90, 0xcb, 0xd2, 0x79, 0x4e, 0x48, 0xab, 0x33, 0x9d, 0x70, 0x4c,
+	0x2e, 0xe0, 0xc4, 0x50, 0x58, 0x6b, 0x27, 0x1e, 0xfd, 0x62, 0x42, 0x0b, 0x7e, 0x9e, 0x13, 0xd2,
+	0xea, 0x4c, 0x27, 0x1c, 0x93, 0x0b, 0x38, 0x41, 0x84, 0xb5, 0x76, 0xe2, 0xc9, 0xf8, 0x22, 0x4e,
+	0x3c, 0x19, 0x1b, 0x86, 0x69, 0x4e, 0x3c, 0x19, 0x4f, 0x71, 0x22, 0x68, 0x72, 0x22, 0x1d, 0x6b,
+	0x27, 0x7e, 0x8e, 0xf0, 0xa3, 0x7c, 0x38, 0xa1, 0x69, 0xce, 0xd9, 0xc3, 0x94, 0x25, 0xf4, 0x88,
+	0x14, 0xb0, 0xeb, 0xcb, 0xed, 0x43, 0x0b, 0xbc, 0x05, 0xd7, 0x91, 0x2b, 0xb2, 0xbb, 0x82, 0xac,
+	0x8d, 0xcd, 0xbc, 0x1f, 0x1a, 0xac, 0x21, 0x5a, 0xfc, 0x6c, 0x42, 0xf2, 0xee, 0x24, 0x3d, 0x1f,
+	0x5f, 0x3d, 0xbb, 0xca, 0xde, 0x4f, 0x2b, 0x9c, 0xc3, 0x93, 0x6e, 0x18, 0xd1, 0x09, 0xc9, 0xe3,
+	0x49, 0x8a, 0x5f, 0xa3, 0xdb, 0xf2, 0x90, 0xf8, 0x98, 0x16, 0x63, 0xa7, 0x27, 0xcb, 0xee, 0x01,
+	0x12, 0x74, 0xe7, 0x76, 0xa5, 0x9e, 0xcd, 0x1a, 0xb2, 0x7d, 0xc0, 0x8e, 0x4e, 0xd4, 0x9e, 0x20,
+	0x8f, 0x52, 0xa7, 0xe8, 0x6e, 0x5f, 0x97, 0x8c, 0xba, 0x62, 0xa9, 0x71, 0xd8, 0xd5, 0x4a, 0xe9,
+	0x1b, 0x78, 0x2b, 0x65, 0x5d, 0x3d, 0xad, 0xdf, 0xa6, 0x38, 0x25, 0x8a, 0x31, 0x34, 0x67, 0xf8,
+	0xd7, 0x2d, 0x74, 0xcf, 0x6b, 0x0f, 0xbd, 0xb4, 0x2e, 0xac, 0x35, 0x72, 0xb8, 0x91, 0x58, 0x3f,
+	0xc3, 0x42, 0x39, 0xa2, 0xb6, 0x70, 0xfc, 0xfe, 0x54, 0x47, 0xa2, 0x13, 0xd9, 0x4c, 0x06, 0xe5,
+	0x2b, 0x74, 0x53, 0x2c, 0xd0, 0x29, 0x27, 0x4c, 0x0f, 0xb6, 0x11, 0x78, 0x23, 0xe0, 0xc8, 0x15,
+	0xdb, 0x5b, 0x82, 0x6d, 0x05, 0xdf, 0x31, 0x6c, 0xa0, 0x8e, 0x4e, 0x1e, 0xa7, 0x19, 0x27, 0xc5,
+	0xe9, 0xd6, 0x5f, 0x5e, 0x41, 0x33, 0x7b, 0x34, 0x23, 0x7a, 0x1b, 0xff, 0x11, 0xba, 0xde, 0x27,
+	0x1c, 0x24, 0xf8, 0x66, 0x07, 0xca, 0x62, 0xf0, 0x31, 0xb0, 0x1f, 0xc3, 0x65, 0x01, 0x78, 0x2b,
+	0x98, 0x8d, 0x0a, 0x9a, 0x11, 0x27, 0x8f, 0xfa, 0x11, 0x42, 0x2a, 0x45, 0x9a, 0xde, 0xf8, 0xb6,
+	0x68, 0x3c, 0xbf, 0x51, 0x69, 0x8c, 0x7f, 0x17, 0x5d, 0xdf, 0x21, 0xfc, 0xfc, 0x66, 0xb8, 0xda,
+	0xec, 0x33, 0x34, 0xd3, 0x27, 0x71, 0x91, 0x1c, 0x80, 0x0d, 0xc3, 0x26, 0x81, 0xd1, 0x22, 0xef,
+	0x41, 0x10, 0x56, 0xce, 0x26, 0xb6, 0x28, 0x40, 0x51, 0xf8, 0xa6, 0x00, 0xfd, 0xb8, 0xb5, 0xb1,
+	0xf5, 0x0f, 0xd7, 0xd0, 0xcc, 0x0b, 0x46, 0x0a, 0x1d, 0x8b, 0x1f, 0xa3, 0xeb, 0xbd, 0x92, 0x83,
+	0x44, 0xf9, 0x05, 0x1f, 0x03, 0xfb, 0x31, 0x5c, 0x11, 0x10, 0x38, 0x98, 0x8b, 0x4a, 0x46, 0x8a,
+	0xe8, 0x64, 0x97, 0x8e, 0xd2, 0x5c, 0x04, 0xe3, 0xa1, 0x0e, 0x86, 0xdf, 0xba, 0x39, 0x99, 0x54,
+	0x09, 0xca, 0x46, 0x15, 0x08, 0xff, 0x9e, 0x08, 0xcc, 0x19, 0x0e, 0xd8, 0xc4, 0xa6, 0xd2, 0xce,
+	0x44, 0x06, 0x8c, 0xbc, 0xc8, 0x80, 0xc8, 0x8b, 0x8c, 0xb0, 0x6a, 0x8c, 0x0c, 0xa0, 0x42, 0x77,
+	0xfe, 0x00, 0xdd, 0xe8, 0x95, 0x5c, 0xc6, 0xb9, 0xd9, 0x13, 0x35, 0xcf, 0x82, 0xb6, 0xf4, 0x04,
+	0x42, 0xca, 0xdc, 0x80, 0x50, 0xb4, 0xe4, 0x30, 0xc3, 0x91, 0x4f, 0x2e, 0xf5, 0x3a, 0xe3, 0x92,
+	0x71, 0xcf, 0xf6, 0xbd, 0xc4, 0xf1, 0xfe, 0x14, 0x6d, 0x75, 0xa9, 0x0c, 0x6f, 0x49, 0x56, 0x46,
+	0xb2, 0x7d, 0xb5, 0x29, 0x60, 0x8a, 0xda, 0x2e, 0x21, 0xc4, 0x3b, 0xa5, 0xf9, 0xf7, 0xa3, 0x5b,
+	0x15, 0x74, 0x4b, 0x61, 0xdb, 0xa1, 0x1b, 0x6a, 0xe4, 0x5f, 0x4a, 0x42, 0xb1, 0x3a, 0x16, 0x63,
+	0x43, 0xb8, 0x66, 0x21, 0x3d, 0xd5, 0x05, 0x49, 0x6d, 0x76, 0x59, 0x27, 0x95, 0x0f, 0x75, 0x31,
+	0x86, 0x08, 0x97, 0x08, 0x0b, 0x12, 0x38, 0xf1, 0x66, 0xff, 0x37, 0xfd, 0xd5, 0x89, 0x50, 0xd0,
+	0x48, 0x2d, 0x88, 0xb6, 0xfe, 0xfe, 0x3a, 0x6a, 0x77, 0x93, 0x84, 0x30, 0xb6, 0x27, 0x4e, 0x1d,
+	0xfa, 0xe1, 0x19, 0xc9, 0x3c, 0x70, 0x3b, 0x1e, 0x4f, 0xe2, 0x74, 0x94, 0x57, 0xf2, 0x40, 0x23,
+	0xf4, 0x0e, 0x3c, 0x5a, 0xee, 0xcc, 0xc5, 0x35, 0xe1, 0x42, 0x80, 0x57, 0xa2, 0x58, 0x90, 0x6c,
+	0xca, 0xb3, 0x4d, 0x94, 0x18, 0xdc, 0x3d, 0x34, 0x03, 0xe5, 0x54, 0xf5, 0x1d, 0xcf, 0x57, 0xa1,
+	0x02, 0xef, 0xbb, 0x3e, 0xb7, 0x85, 0x53, 0x01, 0x21, 0x96, 0x5f, 0xa3, 0x19, 0x28, 0xa6, 0x6a,
+	0xcc, 0xa5, 0x2a, 0x86, 0xf6, 0xda, 0x87, 0xb6, 0xc5, 0xa4, 0x29, 0xd0, 0x7a, 0xe5, 0xfa, 0x39,
+	0x9a, 0x97, 0x0b, 0xc0, 0x77, 0x64, 0xd8, 0x38, 0x97, 0x81, 0xa0, 0xb9, 0x3e, 0x8f, 0x8b, 0x4b,
+	0x77, 0x41, 0x9f, 0xfa, 0xdf, 0x3b, 0x87, 0x20, 0x62, 0x80, 0x0e, 0x34, 0xdb, 0x19, 0x65, 0xe4,
+	0xff, 0x8d, 0x26, 0x01, 0x74, 0x5c, 0xa2, 0x79, 0x8b, 0x28, 0x9e, 0xee, 0x29, 0x3c, 0xf7, 0x7c,
+	0x31, 0x18, 0x37, 0xec, 0xbc, 0xe7, 0xb0, 0x16, 0x92, 0x24, 0x95, 0xe7, 0x43, 0x39, 0xaf, 0x9f,
+	0xc7, 0xec, 0xb0, 0x72, 0x3e, 0x74, 0xc4, 0x5e, 0x3d, 0xc5, 0x6a, 0x1a, 0xcb, 0x1a, 0x55, 0x72,
+	0x2e, 0x70, 0x09, 0xd4, 0xab, 0x93, 0x74, 0x48, 0x6c, 0x5b, 0xbd, 0x6c, 0x4b, 0x39, 0x48, 0x34,
+	0xcd, 0xa2, 0x4f, 0xe3, 0xac, 0x11, 0x0d, 0xe0, 0xd1, 0x50, 0x20, 0xc0, 0x0e, 0xf7, 0x6f, 0x2d,
+	0x84, 0xba, 0xdb, 0xbb, 0xfa, 0x19, 0xdd, 0x44, 0xd7, 0x7a, 0x25, 0xef, 0x26, 0x19, 0xbe, 0x21,
+	0x56, 0xf2, 0xee, 0xf6, 0x6e, 0x60, 0x3e, 0x85, 0x0b, 0x02, 0xf4, 0x66, 0x70, 0x35, 0x8a, 0x13,
+	0x71, 0x3e, 0xff, 0x09, 0xba, 0x29, 0xa7, 0x6d, 0xb5, 0x45, 0xf3, 0x96, 0xa6, 0xd7, 0xca, 0x45,
+	0x68, 0x1d, 0x0d, 0xca, 0xec, 0xd0, 0x39, 0x33, 0x3c, 0x45, 0x48, 0xee, 0x46, 0xdd, 0x24, 0x33,
+	0x49, 0x8d, 0x92, 0x6c, 0xef, 0xea, 0x7e, 0xaa, 0x0b, 0x8b, 0xee, 0xf6, 0xae, 0x13, 0x47, 0xe5,
+	0x55, 0xa8, 0xbd, 0xda, 0x9a, 0xa0, 0x39, 0x59, 0xf1, 0xd2, 0xbd, 0xfa, 0x99, 0xac, 0x79, 0x98,
+	0xf2, 0xf8, 0x3d, 0xe1, 0xa9, 0x11, 0x1d, 0xef, 0x14, 0xb4, 0x9c, 0x30, 0xbb, 0x04, 0x36, 0x6b,
+	0x55, 0x37, 0xb0, 0xa0, 0x9b, 0x0d, 0xaf, 0x47, 0x13, 0xa1, 0x06, 0xc6, 0x6f, 0xaf, 0xa0, 0xc5,
+	0x9f, 0xd2, 0xe2, 0x90, 0x4d, 0xe2, 0xc4, 0x24, 0x4e, 0xbb, 0x68, 0xb6, 0x57, 0x72, 0x23, 0xc6,
+	0xf3, 0x02, 0xd7, 0x7c, 0x0f, 0xbc, 0xef, 0x7a, 0x3e, 0x04, 0xb7, 0xa2, 0xd7, 0x5a, 0x16, 0x9d,
+	0xf4, 0xb3, 0x72, 0x24, 0xb6, 0xcb, 0x3d, 0xb4, 0x20, 0xe3, 0x39, 0x1d, 0xb0, 0x39, 0xec, 0x6a,
+	0x47, 0xdc, 0xa8, 0xc3, 0xe2, 0x01, 0x5a, 0x94, 0x21, 0x36, 0x18, 0x66, 0x3e, 0x7b, 0x72, 0x1d,
+	0x9b, 0xbb, 0x52, 0x6b, 0xe4, 0xce, 0x30, 0xa8, 0xcc, 0x23, 0x44, 0x96, 0x07, 0x42, 0xf3, 0xcf,
+	0x57, 0xd0, 0x42, 0x57, 0xdd, 0x6d, 0xea, 0xc8, 0x7c, 0x89, 0xae, 0xf5, 0xc5, 0x35, 0x27, 0x5e,
+	0xef, 0xe8, 0x7b, 0xcf, 0x8e, 0x94, 0x28, 0xd3, 0xd4, 0x26, 0xb2, 0x8b, 0xd6, 0xe4, 0x33, 0x71,
+	0x5b, 0x53, 0x99, 0x48, 0x52, 0x13, 0xc9, 0x5b, 0x53, 0x88, 0xd3, 0x4b, 0x74, 0xb3, 0x5f, 0x0e,
+	0x58, 0x52, 0xa4, 0x03, 0x82, 0xef, 0x38, 0xf0, 0x52, 0x28, 0x4e, 0x68, 0xc1, 0x14, 0xb9, 0xce,
+	0x59, 0xc2, 0xb6, 0x83, 0xac, 0xc1, 0x00, 0xfc, 0x97, 0xa8, 0x2d, 0x03, 0xe3, 0xb6, 0x62, 0xf8,
+	0x5d, 0x07, 0xae, 0xae, 0xf6, 0xb6, 0xd6, 0x8a, 0xce, 0x89, 0x9f, 0xad, 0x31, 0xf8, 0xdc, 0xd2,
+	0x14, 0x82, 0xf9, 0x15, 0x42, 0xbb, 0xd4, 0x5c, 0x1b, 0x7e, 0x8a, 0xae, 0xf5, 0x8f, 0x59, 0x46,
+	0xe1, 0x76, 0x0f, 0xae, 0x62, 0x61, 0xca, 0xee, 0xd2, 0x91, 0xb7, 0xf6, 0xec, 0xd2, 0xd1, 0x33,
+	0xc2, 0x58, 0x3c, 0x6a, 0x28, 0xe1, 0x85, 0x37, 0xc4, 0x3d, 0x2e, 0x3b, 0x16, 0xe8, 0xff, 0xf9,
+	0x06, 0x9a, 0x7d, 0x4e, 0x0f, 0x49, 0xae, 0x09, 0xf6, 0xd0, 0xb5, 0x3d, 0x72, 0x44, 0x0f, 0x89,
+	0xbe, 0x3e, 0x94, 0xdf, 0xbc, 0x4a, 0xbc, 0x16, 0xaa, 0xf9, 0xa6, 0x6e, 0x25, 0x43, 0x1c, 0xc5,
+	0x25, 0x3f, 0x88, 0x38, 0x00, 0x46, 0x85, 0xb0, 0x81, 0x10, 0xfe, 0x79, 0x0b, 0xe1, 0x3d, 0xc2,
+	0x08, 0xef, 0xc5, 0x8c, 0xbd, 0xa6, 0xc5, 0x50, 0x30, 0xea, 0xc2, 0x53, 0x5d, 0xe3, 0xd5, 0xf4,
+	0x9a, 0x0c, 0xaa, 0x8b, 0x79, 0xf0, 0xbe, 0x24, 0x2e, 0xc0, 0x72, 0x73, 0xa2, 0x4c, 0x37, 0xa5,
+	0x1f, 0x27, 0x90, 0xd8, 0xa8, 0x9c, 0x38, 0x45, 0x73, 0x15, 0x34, 0x1c, 0x34, 0x50, 0x78, 0x75,
+	0x29, 0x4f, 0xa7, 0x98, 0xdf, 0x16, 0xcc, 0x77, 0xc3, 0xdb, 0x4d, 0xcc, 0xd0, 0xe9, 0x5f, 0xb5,
+	0xd0, 0xea, 0x0e, 0xc9, 0x49, 0x11, 0x73, 0xf2, 0x90, 0x26, 0xe5, 0x98, 0xe4, 0x5c, 0xa6, 0x48,
+	0xb2, 0xf7, 0xaa, 0x73, 0x0d, 0x2a, 0xef, 0x18, 0xd9, 0x68, 0xd1, 0xec, 0x85, 0xec, 0xf0, 0x50,
+	0x35, 0x80, 0xf1, 0xfd, 0x02, 0xcd, 0x3d, 0x13, 0x2f, 0x28, 0xe8, 0xf1, 0xdd, 0x41, 0x57, 0xfb,
+	0x24, 0x1f, 0xe2, 0xd9, 0x8e, 0x7a, 0x71, 0x01, 0xd4, 0xc1, 0x8a, 0xfe, 0x06, 0x3a, 0x90, 0x18,
+	0x06, 0x75, 0xd2, 0x0b, 0x67, 0xf5, 0xfb, 0x0e, 0x8c, 0xe4, 0x43, 0x39, 0x2f, 0xe7, 0xd4, 0xc4,
+	0x57, 0xc8, 0x9f, 0xa0, 0x37, 0x65, 0xc5, 0xbf, 0x2d, 0xcb, 0xe6, 0x52, 0xeb, 0x2d, 0xe3, 0x5a,
+	0xc8, 0xca, 0x8c, 0x33, 0x7d, 0x74, 0x0a, 0xe7, 0x22, 0x26, 0xe4, 0x91, 0xa8, 0x60, 0x03, 0xfa,
+	0xdf, 0xbd, 0x89, 0x66, 0x9e, 0x17, 0xc4, 0x2c, 0xac, 0x7f, 0x84, 0xe6, 0x1e, 0x94, 0xd9, 0x61,
+	0x9f, 0xc7, 0x5c, 0x92, 0xa8, 0x64, 0x71, 0x87, 0x70, 0x90, 0x3f, 0x23, 0x3c, 0xd6, 0x4c, 0x6a,
+	0x23, 0xb1, 0x62, 0xd5, 0x13, 0x5b, 0x70, 0x06, 0xf7, 0x20, 0x77, 0x91, 0xb5, 0xca, 0x9f, 0xa2,
+	0x19, 0x59, 0x7a, 0xab, 0x00, 0x3b, 0xa2, 0x73, 0xea, 0xa0, 0x36, 0x42, 0x02, 0xd7, 0x16, 0xe6,
+	0x9e, 0xa3, 0x1b, 0x3f, 0x21, 0xf1, 0x10, 0xec, 0x75, 0xaa, 0xa2, 0xbf, 0x7b, 0xbe, 0x5a, 0x71,
+	0xad, 0xfa, 0x63, 0x7c, 0x8d, 0x4e, 0xc0, 0xe2, 0x14, 0xbf, 0x44, 0x33, 0x72, 0xb5, 0xaf, 0xb8,
+	0xeb, 0x88, 0xbc, 0x75, 0xbb, 0xa2, 0xa9, 0x0d, 0xaa, 0x80, 0xb7, 0x5b, 0xf2, 0xcf, 0xd0, 0xec,
+	0x1e, 0x61, 0x9c, 0x16, 0x0a, 0xfd, 0xae, 0x79, 0x04, 0x8c, 0xac, 0x96, 0xe6, 0xb8, 0x2a, 0x85,
+	0x6f, 0xc7, 0x55, 0xe0, 0x17, 0xd2, 0x06, 0x08, 0x5e, 0xa1, 0x05, 0x19, 0xd9, 0x3e, 0x51, 0xf1,
+	0xd3, 0xbb, 0x8f, 0x27, 0xf6, 0x56, 0xd0, 0x9a, 0x56, 0x31, 0xd9, 0x22, 0xb4, 0x0c, 0x94, 0x36,
+	0x00, 0x2e, 0x82, 0x66, 0x1f, 0xa6, 0xfb, 0xfb, 0xea, 0x62, 0xcb, 0x74, 0xc6, 0x95, 0xf9, 0xd7,
+	0xf1, 0x15, 0x55, 0xb5, 0x78, 0x12, 0xb6, 0x25, 0x85, 0xba, 0x01, 0x63, 0xd1, 0x30, 0xdd, 0xdf,
+	0x97, 0xa9, 0xc7, 0xe2, 0x73, 0xfd, 0x9e, 0x92, 0x9e, 0xae, 0x5f, 0xc9, 0x73, 0x8f, 0x91, 0xbb,
+	0xe7, 0x1e, 0x23, 0x6c, 0xa8, 0x7f, 0x3b, 0xba, 0x6a, 0xea, 0x81, 0x51, 0x64, 0x5e, 0x86, 0xda,
+	0xfa, 0x9f, 0x2b, 0x68, 0x06, 0xa6, 0xb6, 0x5d, 0xb3, 0xa1, 0x42, 0x00, 0x12, 0xcd, 0x03, 0x9f,
+	0xa1, 0x70, 0x54, 0xd9, 0xc8, 0xdd, 0x7b, 0x31, 0xbb, 0x70, 0x8c, 0x09, 0x8f, 0xa3, 0x11, 0x51,
+	0xf3, 0xcb, 0xbc, 0x49, 0xb2, 0x2b, 0x4a, 0x40, 0x02, 0xf3, 0xb6, 0xc5, 0xb4, 0xd3, 0xfe, 0x2c,
+	0x34, 0x56, 0x43, 0xfb, 0x42, 0x57, 0x42, 0x2e, 0xe5, 0xa4, 0xdd, 0x1e, 0x05, 0xac, 0x9c, 0xa6,
+	0x1e, 0xf2, 0x97, 0xe2, 0x90, 0xa6, 0x1f, 0xf6, 0xef, 0xb0, 0x2c, 0xe8, 0xea, 0xc1, 0xbc, 0x24,
+	0x11, 0x39, 0xea, 0x88, 0x88, 0xc5, 0xf3, 0x1f, 0xaf, 0xa3, 0x05, 0xd8, 0x3c, 0xdc, 0x58, 0x8f,
+	0xd0, 0xfc, 0x0b, 0xf1, 0x96, 0x90, 0x56, 0xe0, 0x40, 0xd6, 0x3f, 0x2a, 0x42, 0x3b, 0xb4, 0x4d,
+	0xba, 0xea, 0xd5, 0x46, 0x20, 0xeb, 0x16, 0x9b, 0x82, 0x5e, 0xbe, 0x81, 0x04, 0x1d, 0x1b, 0xa2,
+	0x79, 0x5b, 0xab, 0x71, 0x88, 0xaa, 0x42, 0xef, 0xec, 0xac, 0xc5, 0xf5, 0x23, 0x47, 0xe8, 0xb2,
+	0xc8, 0xd5, 0x56, 0xb2, 0xcc, 0x41, 0x9b, 0x07, 0x94, 0x1e, 0x8e, 0xe3, 0xe2, 0xd0, 0x4c, 0xd4,
+	0x8a, 0xf0, 0xbc, 0x10, 0xda, 0xe1, 0xb7, 0x14, 0x03, 0xdd, 0x18, 0x58, 0xfe, 0xb4, 0x85, 0x96,
+	0xab, 0x41, 0x30, 0xe3, 0x8e, 0xdf, 0x69, 0x08, 0x51, 0x6d, 0x56, 0xbc, 0x7b, 0xb6, 0x51, 0xd5,
+	0x8f, 0xc0, 0xf5, 0x23, 0xd7, 0x56, 0xe0, 0xc7, 0x09, 0x5a, 0x82, 0xa7, 0xac, 0xee, 0xc4, 0xba,
+	0xc9, 0xff, 0xa7, 0xba, 0xb0, 0x5e, 0x8d, 0xb0, 0xd1, 0x37, 0x9e, 0xee, 0x1a, 0xf8, 0xf1, 0x91,
+	0xbc, 0x1c, 0xd7, 0x00, 0xcf, 0xe3, 0x51, 0xe5, 0x72, 0xdc, 0x95, 0x7b, 0xe5, 0xec, 0xba, 0xba,
+	0x7a, 0xfd, 0x8c, 0x57, 0x1d, 0x42, 0x1e, 0x8f, 0x98, 0x7c, 0xfd, 0x41, 0xd0, 0x9e, 0x62, 0x26,
+	0x2e, 0xcb, 0x9d, 0xf6, 0xfa, 0x96, 0xb6, 0x2a, 0xf5, 0x4e, 0xcf, 0xbe, 0xb2, 0xb1, 0x0e, 0xd5,
+	0xcc, 0xa8, 0xb2, 0x1f, 0x6c, 0x6b, 0x9f, 0xa6, 0xbf, 0x6f, 0xbb, 0x7b, 0x52, 0x53, 0x8f, 0xd7,
+	0xa6, 0x1b, 0x28, 0x0f, 0x36, 0x84, 0x07, 0xef, 0x6e, 0x84, 0x67, 0x78, 0x10, 0x9d, 0x40, 0x93,
+	0xd3, 0xad, 0xff, 0x7a, 0x03, 0xcd, 0x3c, 0xa5, 0x03, 0xb3, 0x2c, 0x7f, 0x2d, 0x67, 0xbb, 0xdc,
+	0x4c, 0x9e, 0xd2, 0x81, 0x5e, 0xda, 0x40, 0xf8, 0x94, 0x0e, 0x1a, 0x2a, 0xa2, 0x42, 0x5a, 0x9b,
+	0x5e, 0xe2, 0xf5, 0x4b, 0x59, 0x6c, 0x7d, 0x4a, 0x07, 0xe6, 0x5d, 0xb6, 0xcf, 0xd1, 0xac, 0xc8,
+	0x35, 0x53, 0xc6, 0x81, 0x15, 0x2f, 0x75, 0xc0, 0xb0, 0xa3, 0xbf, 0x37, 0x3c, 0xab, 0x20, 0x6e,
+	0x3c, 0x4f, 0x19, 0x06, 0xc0, 0x7d, 0x81, 0xe6, 0x55, 0xe5, 0x90, 0x17, 0x34, 0x03, 0xbf, 0x6f,
+	0x49, 0xe4, 0x6d, 0x5e, 0x64, 0xdb, 0x74, 0x3c, 0x8e, 0xf3, 0x61, 0x70, 0xb7, 0x26, 0xf2, 0x0b,
+	0xcb, 0x81, 0x07, 0x4b, 0xe4, 0xea, 0x26, 0x63, 0x2d, 0x2b, 0x1b, 0x2b, 0x12, 0xc4, 0x11, 0xd9,
+	0x6c, 0xa2, 0xae, 0xa9, 0x65, 0xff, 0x02, 0x5e, 0x97, 0x1b, 0x74, 0x4e, 0xf1, 0xb5, 0xda, 0x0b,
+	0x41, 0xbc, 0x4b, 0x47, 0xec, 0xf2, 0x27, 0x17, 0x7b, 0xf8, 0x73, 0x08, 0x32, 0x3a, 0x12, 0x99,
+	0xe2, 0xbf, 0xb4, 0xd0, 0xa2, 0xb8, 0xb0, 0x73, 0xd3, 0xc5, 0x97, 0x92, 0xd3, 0xc8, 0xf5, 0x5b,
+	0x37, 0x20, 0xbc, 0x48, 0x4e, 0x67, 0x19, 0xa1, 0x59, 0x14, 0x03, 0x8e, 0xb9, 0xf5, 0x7d, 0x29,
+	0xca, 0x6a, 0x0e, 0xf8, 0x92, 0x04, 0xdf, 0xab, 0x25, 0x77, 0x9e, 0xb8, 0x56, 0x14, 0x71, 0xc0,
+	0x19, 0x8f, 0xc5, 0x9e, 0xf3, 0x4f, 0x2d, 0x34, 0xbb, 0x03, 0x2f, 0x48, 0xdb, 0x54, 0xe2, 0xa6,
+	0xa8, 0xcc, 0xf2, 0x98, 0x13, 0x5d, 0x24, 0x31, 0x02, 0xef, 0xe6, 0xc7, 0x91, 0xd7, 0x6e, 0x7e,
+	0xc4, 0x5b, 0xd7, 0x82, 0x06, 0x6a, 0x01, 0x64, 0x04, 0x27, 0x04, 0xc8, 0x26, 0x6f, 0xec, 0x11,
+	0xf9, 0xb2, 0x8f, 0xce, 0x51, 0xf5, 0x77, 0x6f, 0xd5, 0xb7, 0x62, 0x05, 0x6d, 0x8b, 0xb2, 0x12,
+	0xba, 0x50, 0x06, 0xf2, 0xc0, 0xf5, 0x64, 0x78, 0xba, 0xf5, 0xcd, 0x35, 0x34, 0xdb, 0x3f, 0x88,
+	0x0b, 0x33, 0x2c, 0xdb, 0xe2, 0x2e, 0x65, 0x9b, 0x64, 0x99, 0x7e, 0xf2, 0xd4, 0x57, 0xbb, 0xfb,
+	0x0b, 0x29, 0x88, 0x74, 0xbe, 0x1e, 0xcc, 0x44, 0xe2, 0x1d, 0x71, 0xf1, 0xda, 0x2e, 0x84, 0x7f,
+	0x47, 0x64, 0x3b, 0x2e, 0xc8, 0x0e, 0x99, 0x0a, 0x62, 0x5f, 0x68, 0xb4, 0x20, 0xba, 0x3c, 0xfa,
+	0x52, 0x27, 0x25, 0x02, 0x6b, 0xd9, 0x5d, 0x79, 0x5c, 0xb8, 0x95, 0xba, 0xa2, 0x9a, 0x7c, 0x6e,
+	0x34, 0x81, 0xef, 0x89, 0x4a, 0x90, 0xe8, 0xfd, 0x6e, 0x9a, 0x1f, 0xea, 0xe4, 0xd3, 0x95, 0x69,
+	0x82, 0x05, 0xa9, 0x32, 0xf2, 0x5a, 0xcf, 0xb3, 0x34, 0x3f, 0x54, 0xeb, 0xcb, 0x0e, 0xa9, 0x63,
+	0xee, 0x90, 0x0b, 0x60, 0xfa, 0x81, 0x00, 0x4c, 0xed, 0xeb, 0x2b, 0x5d, 0x67, 0xb2, 0xd0, 0xf7,
+	0xdc, 0x4e, 0xd7, 0xd0, 0xef, 0x4f, 0xd1, 0x4e, 0x89, 0x8b, 0xcb, 0xf5, 0x1a, 0xb5, 0xc5, 0xfd,
+	0x24, 0x28, 0x60, 0x85, 0x52, 0x6f, 0x56, 0x3a, 0xaf, 0xef, 0x78, 0x2a, 0x6f, 0xff, 0x6d, 0xb4,
+	0xa8, 0x3d, 0x58, 0x92, 0xb7, 0xd0, 0x16, 0x10, 0xbc, 0x23, 0xd4, 0x96, 0xf9, 0x83, 0x68, 0x6d,
+	0xea, 0x82, 0xfa, 0x66, 0xa6, 0xae, 0xf2, 0x37, 0xfe, 0x26, 0x8b, 0x6a, 0x87, 0x83, 0x05, 0x45,
+	0x3c, 0x51, 0x06, 0xf0, 0x40, 0xff, 0xea, 0x2a, 0x9a, 0x7f, 0x22, 0x5f, 0x62, 0xb7, 0x87, 0x59,
+	0xb4, 0x43, 0xb8, 0x12, 0xe2, 0xd5, 0x8e, 0x7e, 0xc7, 0x1d, 0x5e, 0x2d, 0x25, 0xfb, 0x31, 0x1c,
+	0x8d, 0xed, 0x6e, 0xdc, 0xa8, 0x54, 0xbc, 0xea, 0x8e, 0x0e, 0xdf, 0xd0, 0xaf, 0xc9, 0xe3, 0x17,
+	0x68, 0xa6, 0x47, 0x99, 0xc1, 0x5e, 0x36, 0xcd, 0x95, 0xc4, 0x4e, 0xea, 0x9a, 0x42, 0x61, 0xda,
+	0x32, 0x91, 0xb2, 0x80, 0xe0, 0x8d, 0x51, 0xbb, 0x47, 0x0a, 0xb8, 0xad, 0x57, 0xe6, 0xdb, 0x07,
+	0x24, 0x81, 0x59, 0xa2, 0x51, 0x94, 0x56, 0x88, 0x9d, 0xa2, 0x6a, 0xa3, 0xb6, 0x96, 0x78, 0x2b,
+	0xb3, 0x28, 0x01, 0x3d, 0xd0, 0x8d, 0xc4, 0x44, 0xef, 0x8e, 0x0a, 0x42, 0x60, 0x99, 0xc2, 0x95,
+	0x28, 0x18, 0x71, 0x9d, 0xa7, 0xaa, 0xad, 0x0e, 0x0e, 0xc6, 0x86, 0x27, 0x36, 0xc0, 0x23, 0x34,
+	0xa7, 0x3a, 0xf4, 0xe8, 0x88, 0xe4, 0x1c, 0x12, 0x32, 0x2f, 0x2e, 0x52, 0x6e, 0x13, 0xb2, 0x29,
+	0xea, 0xea, 0xc1, 0x1a, 0x2f, 0x18, 0x2e, 0x22, 0x0c, 0xb6, 0xfe, 0xb5, 0x85, 0xe6, 0xd4, 0x0c,
+	0x52, 0x93, 0xa0, 0xaf, 0x0f, 0x12, 0x80, 0x9d, 0x16, 0x64, 0x88, 0x97, 0x3a, 0xea, 0xf7, 0x07,
+	0x56, 0x2e, 0xd7, 0x5f, 0x4f, 0x5c, 0x2b, 0x4a, 0xdb, 0x43, 0xc3, 0x2b, 0x34, 0xd3, 0x9d, 0x4c,
+	0xb2, 0x63, 0x69, 0x8a, 0x03, 0xdd, 0xd4, 0x11, 0xda, 0xa3, 0x49, 0x93, 0xae, 0x7a, 0xe7, 0xb7,
+	0xb5, 0xac, 0xb0, 0x21, 0xa1, 0x2a, 0x46, 0xe6, 0xed, 0x6f, 0xc8, 0x76, 0xb6, 0xfe, 0xe3, 0x3a,
+	0x5a, 0x78, 0xac, 0x7e, 0xb0, 0xa3, 0x3b, 0xf5, 0x05, 0x42, 0x42, 0x24, 0x77, 0x2b, 0xb5, 0xa4,
+	0x5a, 0x89, 0xb7, 0xa4, 0xba, 0x8a, 0x5a, 0x00, 0xf5, 0x6f, 0x81, 0xe4, 0x96, 0x05, 0x07, 0x15,
+	0x61, 0xfe, 0x80, 0x52, 0xf1, 0xfb, 0x06, 0x7d, 0x50, 0xa9, 0x08, 0xbd, 0x13, 0xb5, 0xa7, 0xab,
+	0xcd, 0x07, 0x43, 0x31, 0xa0, 0x94, 0xc3, 0x25, 0x2a, 0x3e, 0x54, 0x2c, 0x2a, 0x07, 0x61, 0x15,
+	0x16, 0x2d, 0x6c, 0x62, 0xb1, 0xba, 0xda, 0x9b, 0x26, 0x86, 0x65, 0xac, 0x6c, 0xa2, 0x93, 0xdd,
+	0x38, 0x1f, 0x9d, 0xc2, 0x2c, 0x17, 0x6d, 0x7b, 0x59, 0x39, 0x4a, 0x6d, 0x7d, 0xc2, 0x95, 0x79,
+	0xd9, 0x51, 0x55, 0x55, 0xdb, 0x87, 0x0d, 0xd3, 0x44, 0x9a, 0x68, 0xa2, 0x44, 0x11, 0xf5, 0x09,
+	0x83, 0xd1, 0xab, 0x10, 0x29, 0x59, 0x13, 0x91, 0x51, 0xd5, 0x5e, 0xc5, 0xb3, 0x63, 0x23, 0x4d,
+	0x60, 0xea, 0x1d, 0xaa, 0xd9, 0xf0, 0x28, 0x2f, 0x68, 0x96, 0x75, 0x4b, 0x7e, 0xa0, 0x37, 0x11,
+	0x4f, 0xec, 0x6d, 0x22, 0x35, 0x6d, 0x6d, 0x31, 0x37, 0x6c, 0x44, 0x58, 0x01, 0xd9, 0x6b, 0xb4,
+	0xa8, 0x5c, 0x2c, 0x8e, 0xc8, 0x83, 0x34, 0x8f, 0x8b, 0x63, 0xec, 0x4e, 0x2a, 0x29, 0xf2, 0x2a,
+	0x61, 0x15, 0x4d, 0xed, 0x36, 0xd0, 0x4e, 0x06, 0xb0, 0x48, 0x61, 0x98, 0xa4, 0xed, 0xf3, 0xe3,
+	0x09, 0x39, 0xd5, 0xdb, 0xd7, 0x2f, 0xd0, 0xbc, 0x1c, 0x84, 0x92, 0x7f, 0x1f, 0xda, 0x8f, 0x04,
+	0xed, 0x6f, 0x87, 0x17, 0xa4, 0x95, 0xaf, 0x54, 0xce, 0xf6, 0x09, 0xe7, 0x69, 0x3e, 0x62, 0xcf,
+	0x48, 0x5e, 0xea, 0x41, 0x74, 0x65, 0xde, 0x20, 0x56, 0x55, 0xd5, 0x43, 0x0c, 0x5e, 0x76, 0x07,
+	0x51, 0xda, 0x6d, 0x8e, 0x49, 0x5e, 0x3e, 0xf8, 0xb6, 0xf5, 0x57, 0xdd, 0xdf, 0xb4, 0xf0, 0x0f,
+	0xd1, 0xed, 0x1e, 0xfc, 0x5a, 0x6a, 0x0d, 0x32, 0x1e, 0xb6, 0xb6, 0x47, 0x18, 0x5f, 0xeb, 0xf6,
+	0x9e, 0x84, 0x01, 0x7a, 0x53, 0xc8, 0xf1, 0xad, 0x03, 0xce, 0x27, 0xec, 0xe3, 0x48, 0xfe, 0xa8,
+	0x0a, 0x7e, 0x5e, 0xb5, 0xf5, 0xc6, 0x47, 0x9d, 0x0f, 0x37, 0xde, 0x68, 0x5d, 0xb9, 0xba, 0xb5,
+	0x18, 0x4f, 0x26, 0x59, 0x9a, 0xc8, 0x7c, 0xf0, 0x15, 0xa3, 0xf9, 0xc7, 0x35, 0x49, 0xf1, 0x21,
+	0x5a, 0x7d, 0x46, 0x0b, 0xb2, 0x16, 0x0f, 0x68, 0xc9, 0xd7, 0x5c, 0xb2, 0xee, 0x24, 0x65, 0x0d,
+	0xf8, 0x83, 0x6b, 0xe2, 0xc7, 0x54, 0x3f, 0xf8, 0xdf, 0x01, 0x00, 0x91, 0xd8, 0xb3, 0xd6, 0xa6,
+	0x38, 0x00, 0x00,
 }
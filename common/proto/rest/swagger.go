@@ -47,6 +47,215 @@ var SwaggerJson = `{
     }
   },
   "paths": {
+    "/access-review/campaigns": {
+      "get": {
+        "summary": "List access review campaigns",
+        "operationId": "ListCampaigns",
+        "responses": {
+          "200": {
+            "description": "",
+            "schema": {
+              "$ref": "#/definitions/restCampaignCollection"
+            }
+          }
+        },
+        "tags": [
+          "AccessReviewService"
+        ]
+      },
+      "post": {
+        "summary": "Create or update a draft campaign",
+        "operationId": "PutCampaign",
+        "responses": {
+          "200": {
+            "description": "",
+            "schema": {
+              "$ref": "#/definitions/restCampaign"
+            }
+          }
+        },
+        "parameters": [
+          {
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/restCampaign"
+            }
+          }
+        ],
+        "tags": [
+          "AccessReviewService"
+        ]
+      }
+    },
+    "/access-review/campaigns/{Uuid}": {
+      "get": {
+        "summary": "Load a campaign with all its tasks",
+        "operationId": "GetCampaign",
+        "responses": {
+          "200": {
+            "description": "",
+            "schema": {
+              "$ref": "#/definitions/restCampaign"
+            }
+          }
+        },
+        "parameters": [
+          {
+            "name": "Uuid",
+            "in": "path",
+            "required": true,
+            "type": "string"
+          }
+        ],
+        "tags": [
+          "AccessReviewService"
+        ]
+      },
+      "delete": {
+        "summary": "Delete a campaign, without applying its decisions",
+        "operationId": "DeleteCampaign",
+        "responses": {
+          "200": {
+            "description": "",
+            "schema": {
+              "$ref": "#/definitions/restCampaign"
+            }
+          }
+        },
+        "parameters": [
+          {
+            "name": "Uuid",
+            "in": "path",
+            "required": true,
+            "type": "string"
+          }
+        ],
+        "tags": [
+          "AccessReviewService"
+        ]
+      }
+    },
+    "/access-review/campaigns/{Uuid}/close": {
+      "post": {
+        "summary": "Close a campaign before its deadline and apply the revocations",
+        "operationId": "CloseCampaign",
+        "responses": {
+          "200": {
+            "description": "",
+            "schema": {
+              "$ref": "#/definitions/restCampaign"
+            }
+          }
+        },
+        "parameters": [
+          {
+            "name": "Uuid",
+            "in": "path",
+            "required": true,
+            "type": "string"
+          }
+        ],
+        "tags": [
+          "AccessReviewService"
+        ]
+      }
+    },
+    "/access-review/campaigns/{Uuid}/report": {
+      "get": {
+        "summary": "Export the decisions of a campaign as CSV",
+        "operationId": "CampaignReport",
+        "responses": {
+          "200": {
+            "description": "",
+            "schema": {
+              "$ref": "#/definitions/restCampaignReportResponse"
+            }
+          }
+        },
+        "parameters": [
+          {
+            "name": "Uuid",
+            "in": "path",
+            "required": true,
+            "type": "string"
+          }
+        ],
+        "tags": [
+          "AccessReviewService"
+        ]
+      }
+    },
+    "/access-review/campaigns/{Uuid}/start": {
+      "post": {
+        "summary": "Collect the grants matching the campaign scope and send the tasks to the reviewers",
+        "operationId": "StartCampaign",
+        "responses": {
+          "200": {
+            "description": "",
+            "schema": {
+              "$ref": "#/definitions/restCampaign"
+            }
+          }
+        },
+        "parameters": [
+          {
+            "name": "Uuid",
+            "in": "path",
+            "required": true,
+            "type": "string"
+          }
+        ],
+        "tags": [
+          "AccessReviewService"
+        ]
+      }
+    },
+    "/access-review/tasks": {
+      "get": {
+        "summary": "List the review tasks of the current user on active campaigns",
+        "operationId": "ListReviewTasks",
+        "responses": {
+          "200": {
+            "description": "",
+            "schema": {
+              "$ref": "#/definitions/restReviewTaskCollection"
+            }
+          }
+        },
+        "tags": [
+          "AccessReviewService"
+        ]
+      }
+    },
+    "/access-review/tasks/decide": {
+      "post": {
+        "summary": "Approve or revoke the grant of a review task",
+        "operationId": "DecideReviewTask",
+        "responses": {
+          "200": {
+            "description": "",
+            "schema": {
+              "$ref": "#/definitions/restReviewTask"
+            }
+          }
+        },
+        "parameters": [
+          {
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/restDecideTaskRequest"
+            }
+          }
+        ],
+        "tags": [
+          "AccessReviewService"
+        ]
+      }
+    },
     "/acl": {
       "post": {
         "summary": "Search Acls",
@@ -655,6 +864,85 @@ var SwaggerJson = `{
         ]
       }
     },
+    "/config/datasource/{Name}/maintenance": {
+      "get": {
+        "summary": "List maintenance windows scheduled on a datasource",
+        "operationId": "GetDataSourceMaintenance",
+        "responses": {
+          "200": {
+            "description": "",
+            "schema": {
+              "$ref": "#/definitions/restDataSourceMaintenanceCollection"
+            }
+          }
+        },
+        "parameters": [
+          {
+            "name": "Name",
+            "in": "path",
+            "required": true,
+            "type": "string"
+          }
+        ],
+        "tags": [
+          "ConfigService"
+        ]
+      },
+      "delete": {
+        "summary": "Remove all maintenance windows from a datasource",
+        "operationId": "DeleteDataSourceMaintenance",
+        "responses": {
+          "200": {
+            "description": "",
+            "schema": {
+              "$ref": "#/definitions/restDeleteResponse"
+            }
+          }
+        },
+        "parameters": [
+          {
+            "name": "Name",
+            "in": "path",
+            "required": true,
+            "type": "string"
+          }
+        ],
+        "tags": [
+          "ConfigService"
+        ]
+      },
+      "post": {
+        "summary": "Replace the maintenance windows scheduled on a datasource",
+        "operationId": "PutDataSourceMaintenance",
+        "responses": {
+          "200": {
+            "description": "",
+            "schema": {
+              "$ref": "#/definitions/restDataSourceMaintenanceCollection"
+            }
+          }
+        },
+        "parameters": [
+          {
+            "name": "Name",
+            "in": "path",
+            "required": true,
+            "type": "string"
+          },
+          {
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/restPutDataSourceMaintenanceRequest"
+            }
+          }
+        ],
+        "tags": [
+          "ConfigService"
+        ]
+      }
+    },
     "/config/discovery": {
       "get": {
         "summary": "Publish available endpoints",
@@ -867,6 +1155,23 @@ var SwaggerJson = `{
         ]
       }
     },
+    "/config/maintenance/datasources": {
+      "get": {
+        "summary": "List maintenance windows scheduled on all datasources",
+        "operationId": "ListDataSourcesMaintenance",
+        "responses": {
+          "200": {
+            "description": "",
+            "schema": {
+              "$ref": "#/definitions/restDataSourcesMaintenanceCollection"
+            }
+          }
+        },
+        "tags": [
+          "ConfigService"
+        ]
+      }
+    },
     "/config/peers": {
       "get": {
         "summary": "List all detected peers (servers on which the app is running)",
@@ -1117,23 +1422,183 @@ var SwaggerJson = `{
             "format": "int64"
           },
           {
-            "name": "IgnoreFilesGreaterThan",
-            "in": "query",
-            "required": false,
-            "type": "string",
-            "format": "int64"
+            "name": "IgnoreFilesGreaterThan",
+            "in": "query",
+            "required": false,
+            "type": "string",
+            "format": "int64"
+          },
+          {
+            "name": "NodeDeletedStrategy",
+            "in": "query",
+            "required": false,
+            "type": "string",
+            "enum": [
+              "KeepAll",
+              "KeepLast",
+              "KeepNone"
+            ],
+            "default": "KeepAll"
+          }
+        ],
+        "tags": [
+          "ConfigService"
+        ]
+      },
+      "delete": {
+        "summary": "Delete a versioning policy that is not used by any datasource",
+        "operationId": "DeleteVersioningPolicy",
+        "responses": {
+          "200": {
+            "description": "",
+            "schema": {
+              "$ref": "#/definitions/restDeleteResponse"
+            }
+          }
+        },
+        "parameters": [
+          {
+            "name": "Uuid",
+            "in": "path",
+            "required": true,
+            "type": "string"
+          }
+        ],
+        "tags": [
+          "ConfigService"
+        ]
+      },
+      "post": {
+        "summary": "Create or update a versioning policy",
+        "operationId": "PutVersioningPolicy",
+        "responses": {
+          "200": {
+            "description": "",
+            "schema": {
+              "$ref": "#/definitions/treeVersioningPolicy"
+            }
+          }
+        },
+        "parameters": [
+          {
+            "name": "Uuid",
+            "in": "path",
+            "required": true,
+            "type": "string"
+          },
+          {
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/treeVersioningPolicy"
+            }
+          }
+        ],
+        "tags": [
+          "ConfigService"
+        ]
+      }
+    },
+    "/config/versioning/{Uuid}/simulate": {
+      "post": {
+        "summary": "List the existing versions that a candidate policy would prune",
+        "operationId": "SimulateVersioningPolicy",
+        "responses": {
+          "200": {
+            "description": "",
+            "schema": {
+              "$ref": "#/definitions/restVersioningSimulation"
+            }
+          }
+        },
+        "parameters": [
+          {
+            "name": "Uuid",
+            "in": "path",
+            "required": true,
+            "type": "string"
+          },
+          {
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/restVersioningSimulationRequest"
+            }
+          }
+        ],
+        "tags": [
+          "ConfigService"
+        ]
+      }
+    },
+    "/config/virtualnodes": {
+      "get": {
+        "summary": "List all defined virtual nodes",
+        "operationId": "ListVirtualNodes",
+        "responses": {
+          "200": {
+            "description": "",
+            "schema": {
+              "$ref": "#/definitions/restNodesCollection"
+            }
+          }
+        },
+        "tags": [
+          "ConfigService"
+        ]
+      }
+    },
+    "/config/virtualnodes/{Uuid}": {
+      "delete": {
+        "summary": "Delete a template path that is not used as a workspace root",
+        "operationId": "DeleteVirtualNode",
+        "responses": {
+          "200": {
+            "description": "",
+            "schema": {
+              "$ref": "#/definitions/restDeleteResponse"
+            }
+          }
+        },
+        "parameters": [
+          {
+            "name": "Uuid",
+            "in": "path",
+            "required": true,
+            "type": "string"
+          }
+        ],
+        "tags": [
+          "ConfigService"
+        ]
+      },
+      "post": {
+        "summary": "Create or update a template path",
+        "operationId": "PutVirtualNode",
+        "responses": {
+          "200": {
+            "description": "",
+            "schema": {
+              "$ref": "#/definitions/treeNode"
+            }
+          }
+        },
+        "parameters": [
+          {
+            "name": "Uuid",
+            "in": "path",
+            "required": true,
+            "type": "string"
           },
           {
-            "name": "NodeDeletedStrategy",
-            "in": "query",
-            "required": false,
-            "type": "string",
-            "enum": [
-              "KeepAll",
-              "KeepLast",
-              "KeepNone"
-            ],
-            "default": "KeepAll"
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/treeNode"
+            }
           }
         ],
         "tags": [
@@ -1141,18 +1606,34 @@ var SwaggerJson = `{
         ]
       }
     },
-    "/config/virtualnodes": {
-      "get": {
-        "summary": "List all defined virtual nodes",
-        "operationId": "ListVirtualNodes",
+    "/config/virtualnodes/{Uuid}/preview": {
+      "post": {
+        "summary": "Resolve a template path for a given user, without creating the resolved folder",
+        "operationId": "PreviewVirtualNode",
         "responses": {
           "200": {
             "description": "",
             "schema": {
-              "$ref": "#/definitions/restNodesCollection"
+              "$ref": "#/definitions/restVirtualNodePreview"
             }
           }
         },
+        "parameters": [
+          {
+            "name": "Uuid",
+            "in": "path",
+            "required": true,
+            "type": "string"
+          },
+          {
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/restVirtualNodePreviewRequest"
+            }
+          }
+        ],
         "tags": [
           "ConfigService"
         ]
@@ -2575,6 +3056,33 @@ var SwaggerJson = `{
         ]
       }
     },
+    "/tree/versions/diff": {
+      "post": {
+        "summary": "Compute a unified or word-level diff between two versions of a text document",
+        "operationId": "DiffVersions",
+        "responses": {
+          "200": {
+            "description": "",
+            "schema": {
+              "$ref": "#/definitions/restDiffVersionsResponse"
+            }
+          }
+        },
+        "parameters": [
+          {
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/restDiffVersionsRequest"
+            }
+          }
+        ],
+        "tags": [
+          "TreeService"
+        ]
+      }
+    },
     "/update": {
       "post": {
         "summary": "Check the remote server to see if there are available binaries",
@@ -2905,6 +3413,84 @@ var SwaggerJson = `{
         ]
       }
     },
+    "/user/self/deletion": {
+      "post": {
+        "summary": "Request the deletion of the current user account, a confirmation code is sent by email",
+        "operationId": "UserRequestDeletion",
+        "responses": {
+          "200": {
+            "description": "",
+            "schema": {
+              "$ref": "#/definitions/restUserSelfServiceResponse"
+            }
+          }
+        },
+        "tags": [
+          "UserService"
+        ]
+      }
+    },
+    "/user/self/deletion/cancel": {
+      "post": {
+        "summary": "Cancel a pending deletion of the current user account",
+        "operationId": "UserCancelDeletion",
+        "responses": {
+          "200": {
+            "description": "",
+            "schema": {
+              "$ref": "#/definitions/restUserSelfServiceResponse"
+            }
+          }
+        },
+        "tags": [
+          "UserService"
+        ]
+      }
+    },
+    "/user/self/deletion/confirm": {
+      "post": {
+        "summary": "Confirm the deletion of the current user account, that will be deleted after a grace period",
+        "operationId": "UserConfirmDeletion",
+        "responses": {
+          "200": {
+            "description": "",
+            "schema": {
+              "$ref": "#/definitions/restUserSelfServiceResponse"
+            }
+          }
+        },
+        "parameters": [
+          {
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/restUserConfirmDeletionRequest"
+            }
+          }
+        ],
+        "tags": [
+          "UserService"
+        ]
+      }
+    },
+    "/user/self/export": {
+      "post": {
+        "summary": "Request an archive of all data attached to the current user, sent by email once ready",
+        "operationId": "UserRequestDataExport",
+        "responses": {
+          "200": {
+            "description": "",
+            "schema": {
+              "$ref": "#/definitions/restUserSelfServiceResponse"
+            }
+          }
+        },
+        "tags": [
+          "UserService"
+        ]
+      }
+    },
     "/user/{Login}": {
       "get": {
         "summary": "Get a user by login",
@@ -6121,6 +6707,110 @@ var SwaggerJson = `{
         }
       }
     },
+    "restCampaign": {
+      "type": "object",
+      "properties": {
+        "Uuid": {
+          "type": "string"
+        },
+        "Label": {
+          "type": "string"
+        },
+        "Description": {
+          "type": "string"
+        },
+        "Scope": {
+          "type": "string",
+          "title": "One of workspaces, cells, links or group"
+        },
+        "WorkspaceUuids": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          },
+          "title": "Restrict the review to these workspaces"
+        },
+        "GroupPath": {
+          "type": "string",
+          "title": "Group reviewed by a group campaign"
+        },
+        "Reviewer": {
+          "type": "string",
+          "title": "Uuid of the user reviewing grants without owner, defaults to the creator"
+        },
+        "DefaultDecision": {
+          "type": "string",
+          "title": "Decision applied to pending tasks at the deadline, approve or revoke"
+        },
+        "Deadline": {
+          "type": "string",
+          "format": "int64"
+        },
+        "Status": {
+          "type": "string"
+        },
+        "Creator": {
+          "type": "string"
+        },
+        "Created": {
+          "type": "string",
+          "format": "int64"
+        },
+        "Started": {
+          "type": "string",
+          "format": "int64"
+        },
+        "Closed": {
+          "type": "string",
+          "format": "int64"
+        },
+        "Tasks": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/restReviewTask"
+          }
+        },
+        "Progress": {
+          "$ref": "#/definitions/restCampaignProgress"
+        }
+      }
+    },
+    "restCampaignCollection": {
+      "type": "object",
+      "properties": {
+        "Campaigns": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/restCampaign"
+          }
+        }
+      }
+    },
+    "restCampaignProgress": {
+      "type": "object",
+      "properties": {
+        "Total": {
+          "type": "integer",
+          "format": "int32"
+        },
+        "Pending": {
+          "type": "integer",
+          "format": "int32"
+        },
+        "Approved": {
+          "type": "integer",
+          "format": "int32"
+        },
+        "Revoked": {
+          "type": "integer",
+          "format": "int32"
+        }
+      }
+    },
+    "restCampaignReportResponse": {
+      "type": "object",
+      "title": "Not used, endpoint returns text/csv"
+    },
     "restCell": {
       "type": "object",
       "properties": {
@@ -6290,42 +6980,113 @@ var SwaggerJson = `{
           "type": "string",
           "title": "Associated target action for this selection"
         },
-        "Persist": {
-          "type": "boolean",
-          "format": "boolean",
-          "title": "Whether to save the selection or just get a temporary Uuid in return"
+        "Persist": {
+          "type": "boolean",
+          "format": "boolean",
+          "title": "Whether to save the selection or just get a temporary Uuid in return"
+        }
+      }
+    },
+    "restCreateSelectionResponse": {
+      "type": "object",
+      "properties": {
+        "SelectionUUID": {
+          "type": "string"
+        },
+        "Nodes": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/treeNode"
+          }
+        }
+      }
+    },
+    "restDataSourceCollection": {
+      "type": "object",
+      "properties": {
+        "DataSources": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/objectDataSource"
+          }
+        },
+        "Total": {
+          "type": "integer",
+          "format": "int32"
+        }
+      },
+      "title": "Collection of datasources"
+    },
+    "restDataSourceMaintenance": {
+      "type": "object",
+      "properties": {
+        "Mode": {
+          "type": "string",
+          "title": "read-only or offline"
+        },
+        "Start": {
+          "type": "string",
+          "title": "Window start (RFC 3339), immediate if empty"
+        },
+        "End": {
+          "type": "string",
+          "title": "Window end (RFC 3339), lasts until removed if empty"
+        },
+        "Message": {
+          "type": "string",
+          "title": "Message displayed to users"
         }
-      }
+      },
+      "title": "Maintenance window scheduled on a datasource"
     },
-    "restCreateSelectionResponse": {
+    "restDataSourceMaintenanceCollection": {
       "type": "object",
       "properties": {
-        "SelectionUUID": {
+        "DataSource": {
           "type": "string"
         },
-        "Nodes": {
+        "Windows": {
           "type": "array",
           "items": {
-            "$ref": "#/definitions/treeNode"
+            "$ref": "#/definitions/restDataSourceMaintenance"
           }
+        },
+        "Active": {
+          "$ref": "#/definitions/restDataSourceMaintenance",
+          "title": "Window currently opened, if any"
         }
-      }
+      },
+      "title": "Maintenance windows scheduled on a datasource"
     },
-    "restDataSourceCollection": {
+    "restDataSourcesMaintenanceCollection": {
       "type": "object",
       "properties": {
         "DataSources": {
           "type": "array",
           "items": {
-            "$ref": "#/definitions/objectDataSource"
+            "$ref": "#/definitions/restDataSourceMaintenanceCollection"
           }
-        },
-        "Total": {
-          "type": "integer",
-          "format": "int32"
         }
       },
-      "title": "Collection of datasources"
+      "title": "Maintenance windows of all datasources having some"
+    },
+    "restDecideTaskRequest": {
+      "type": "object",
+      "properties": {
+        "CampaignUuid": {
+          "type": "string"
+        },
+        "TaskUuid": {
+          "type": "string"
+        },
+        "Decision": {
+          "type": "string",
+          "title": "Either approve or revoke"
+        },
+        "Comment": {
+          "type": "string"
+        }
+      }
     },
     "restDeleteCellResponse": {
       "type": "object",
@@ -6444,6 +7205,36 @@ var SwaggerJson = `{
         }
       }
     },
+    "restDiffVersionsRequest": {
+      "type": "object",
+      "properties": {
+        "Path": {
+          "type": "string",
+          "title": "Path to the node, as seen by the user"
+        },
+        "LeftVersionId": {
+          "type": "string",
+          "title": "Original version, leave empty for current content"
+        },
+        "RightVersionId": {
+          "type": "string",
+          "title": "Modified version, leave empty for current content"
+        },
+        "Format": {
+          "type": "string",
+          "title": "Either unified (default) or words"
+        },
+        "Context": {
+          "type": "integer",
+          "format": "int32",
+          "title": "Number of context lines for unified format"
+        }
+      }
+    },
+    "restDiffVersionsResponse": {
+      "type": "object",
+      "title": "Not used, endpoint returns text/plain"
+    },
     "restDiscoveryResponse": {
       "type": "object",
       "properties": {
@@ -6974,6 +7765,22 @@ var SwaggerJson = `{
       },
       "title": "Request for creating a Cell"
     },
+    "restPutDataSourceMaintenanceRequest": {
+      "type": "object",
+      "properties": {
+        "Name": {
+          "type": "string",
+          "title": "Datasource name"
+        },
+        "Windows": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/restDataSourceMaintenance"
+          },
+          "title": "Windows replacing the current ones"
+        }
+      }
+    },
     "restPutShareLinkRequest": {
       "type": "object",
       "properties": {
@@ -7119,6 +7926,80 @@ var SwaggerJson = `{
         }
       }
     },
+    "restReviewTask": {
+      "type": "object",
+      "properties": {
+        "Uuid": {
+          "type": "string"
+        },
+        "Kind": {
+          "type": "string",
+          "title": "Either acl or link"
+        },
+        "WorkspaceUuid": {
+          "type": "string"
+        },
+        "WorkspaceLabel": {
+          "type": "string"
+        },
+        "RoleId": {
+          "type": "string"
+        },
+        "SubjectLabel": {
+          "type": "string"
+        },
+        "Rights": {
+          "type": "string"
+        },
+        "ReviewerUuid": {
+          "type": "string"
+        },
+        "Reviewer": {
+          "type": "string"
+        },
+        "Decision": {
+          "type": "string"
+        },
+        "Comment": {
+          "type": "string"
+        },
+        "DecidedBy": {
+          "type": "string"
+        },
+        "DecidedAt": {
+          "type": "string",
+          "format": "int64"
+        },
+        "Applied": {
+          "type": "boolean",
+          "format": "boolean"
+        },
+        "ApplyError": {
+          "type": "string"
+        },
+        "CampaignUuid": {
+          "type": "string"
+        },
+        "CampaignLabel": {
+          "type": "string"
+        },
+        "Deadline": {
+          "type": "string",
+          "format": "int64"
+        }
+      }
+    },
+    "restReviewTaskCollection": {
+      "type": "object",
+      "properties": {
+        "Tasks": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/restReviewTask"
+          }
+        }
+      }
+    },
     "restRevokeRequest": {
       "type": "object",
       "properties": {
@@ -7613,6 +8494,45 @@ var SwaggerJson = `{
         }
       }
     },
+    "restSimulatedNode": {
+      "type": "object",
+      "properties": {
+        "Uuid": {
+          "type": "string"
+        },
+        "Path": {
+          "type": "string"
+        },
+        "Versions": {
+          "type": "integer",
+          "format": "int32"
+        },
+        "Pruned": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/restSimulatedVersion"
+          }
+        }
+      },
+      "title": "File that would lose some versions"
+    },
+    "restSimulatedVersion": {
+      "type": "object",
+      "properties": {
+        "VersionId": {
+          "type": "string"
+        },
+        "MTime": {
+          "type": "string",
+          "format": "int64"
+        },
+        "Size": {
+          "type": "string",
+          "format": "int64"
+        }
+      },
+      "title": "Version that would be pruned"
+    },
     "restSubscriptionsCollection": {
       "type": "object",
       "properties": {
@@ -7713,6 +8633,15 @@ var SwaggerJson = `{
     "restUserBookmarksRequest": {
       "type": "object"
     },
+    "restUserConfirmDeletionRequest": {
+      "type": "object",
+      "properties": {
+        "Token": {
+          "type": "string",
+          "title": "Code received by email"
+        }
+      }
+    },
     "restUserJobRequest": {
       "type": "object",
       "properties": {
@@ -7770,6 +8699,27 @@ var SwaggerJson = `{
       },
       "title": "Collection of Meta Namespaces"
     },
+    "restUserSelfServiceResponse": {
+      "type": "object",
+      "properties": {
+        "Success": {
+          "type": "boolean",
+          "format": "boolean"
+        },
+        "Message": {
+          "type": "string"
+        },
+        "JobUuid": {
+          "type": "string",
+          "title": "Job building the export archive"
+        },
+        "DeletionDate": {
+          "type": "string",
+          "format": "int64",
+          "title": "Unix timestamp of the scheduled deletion"
+        }
+      }
+    },
     "restUserStateResponse": {
       "type": "object",
       "properties": {
@@ -7823,6 +8773,110 @@ var SwaggerJson = `{
         }
       }
     },
+    "restVersioningSimulation": {
+      "type": "object",
+      "properties": {
+        "ScannedNodes": {
+          "type": "integer",
+          "format": "int32"
+        },
+        "VersionedNodes": {
+          "type": "integer",
+          "format": "int32"
+        },
+        "TotalVersions": {
+          "type": "integer",
+          "format": "int32"
+        },
+        "PrunedVersions": {
+          "type": "integer",
+          "format": "int32"
+        },
+        "PrunedSize": {
+          "type": "string",
+          "format": "int64"
+        },
+        "Truncated": {
+          "type": "boolean",
+          "format": "boolean",
+          "title": "True if the scan stopped after MaxNodes files"
+        },
+        "Nodes": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/restSimulatedNode"
+          }
+        }
+      },
+      "title": "Versions that a candidate policy would prune"
+    },
+    "restVersioningSimulationRequest": {
+      "type": "object",
+      "properties": {
+        "Uuid": {
+          "type": "string",
+          "title": "Policy identifier"
+        },
+        "Policy": {
+          "$ref": "#/definitions/treeVersioningPolicy",
+          "title": "Candidate policy"
+        },
+        "DataSources": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          },
+          "title": "Datasources to scan, defaults to the ones using the policy"
+        },
+        "MaxNodes": {
+          "type": "integer",
+          "format": "int32",
+          "title": "Maximum number of files to scan"
+        }
+      },
+      "title": "Candidate policy to apply on the existing versions"
+    },
+    "restVirtualNodePreview": {
+      "type": "object",
+      "properties": {
+        "Login": {
+          "type": "string"
+        },
+        "Path": {
+          "type": "string"
+        },
+        "DataSource": {
+          "type": "string"
+        },
+        "NodeExists": {
+          "type": "boolean",
+          "format": "boolean",
+          "title": "True if the resolved folder already exists, otherwise it is created on first access"
+        },
+        "Error": {
+          "type": "string",
+          "title": "Reason why the resolution failed or would fail on access"
+        }
+      },
+      "title": "Path a template path resolves to for a given user"
+    },
+    "restVirtualNodePreviewRequest": {
+      "type": "object",
+      "properties": {
+        "Uuid": {
+          "type": "string",
+          "title": "Template path identifier"
+        },
+        "Node": {
+          "$ref": "#/definitions/treeNode",
+          "title": "Candidate definition, defaults to the stored template path"
+        },
+        "Login": {
+          "type": "string",
+          "title": "Login of the user the path is resolved for"
+        }
+      }
+    },
     "restWorkspaceCollection": {
       "type": "object",
       "properties": {
@@ -8232,6 +9286,14 @@ var SwaggerJson = `{
           "type": "boolean",
           "format": "boolean",
           "title": "Negate this query"
+        },
+        "MetaNamespace": {
+          "type": "string",
+          "title": "Search values of a given metadata namespace (meta service only)"
+        },
+        "MetaValuePattern": {
+          "type": "string",
+          "title": "SQL LIKE pattern matched against the values of MetaNamespace"
         }
       },
       "title": "Search Queries"
@@ -534,6 +534,8 @@ func (m *CreateNodeRequest) GetSilent() bool {
 type CreateNodeResponse struct {
 	Success bool  `protobuf:"varint,1,opt,name=Success" json:"Success,omitempty"`
 	Node    *Node `protobuf:"bytes,2,opt,name=Node" json:"Node,omitempty"`
+	// Content hashes not referenced by any node anymore (deduplicated storage)
+	ReleasedContentHashes []string `protobuf:"bytes,3,rep,name=ReleasedContentHashes" json:"ReleasedContentHashes,omitempty"`
 }
 
 func (m *CreateNodeResponse) Reset()                    { *m = CreateNodeResponse{} }
@@ -555,6 +557,13 @@ func (m *CreateNodeResponse) GetNode() *Node {
 	return nil
 }
 
+func (m *CreateNodeResponse) GetReleasedContentHashes() []string {
+	if m != nil {
+		return m.ReleasedContentHashes
+	}
+	return nil
+}
+
 type UpdateNodeRequest struct {
 	From              *Node  `protobuf:"bytes,1,opt,name=From" json:"From,omitempty"`
 	To                *Node  `protobuf:"bytes,2,opt,name=To" json:"To,omitempty"`
@@ -653,6 +662,8 @@ func (m *DeleteNodeRequest) GetSilent() bool {
 
 type DeleteNodeResponse struct {
 	Success bool `protobuf:"varint,1,opt,name=Success" json:"Success,omitempty"`
+	// Content hashes not referenced by any node anymore (deduplicated storage)
+	ReleasedContentHashes []string `protobuf:"bytes,2,rep,name=ReleasedContentHashes" json:"ReleasedContentHashes,omitempty"`
 }
 
 func (m *DeleteNodeResponse) Reset()                    { *m = DeleteNodeResponse{} }
@@ -667,6 +678,13 @@ func (m *DeleteNodeResponse) GetSuccess() bool {
 	return false
 }
 
+func (m *DeleteNodeResponse) GetReleasedContentHashes() []string {
+	if m != nil {
+		return m.ReleasedContentHashes
+	}
+	return nil
+}
+
 type IndexationSession struct {
 	Uuid                    string `protobuf:"bytes,1,opt,name=Uuid" json:"Uuid,omitempty"`
 	Description             string `protobuf:"bytes,2,opt,name=Description" json:"Description,omitempty"`
@@ -1611,6 +1629,10 @@ type Query struct {
 	UUIDs []string `protobuf:"bytes,15,rep,name=UUIDs" json:"UUIDs,omitempty"`
 	// Negate this query
 	Not bool `protobuf:"varint,14,opt,name=Not" json:"Not,omitempty"`
+	// Search values of a given metadata namespace (meta service only)
+	MetaNamespace string `protobuf:"bytes,18,opt,name=MetaNamespace" json:"MetaNamespace,omitempty"`
+	// SQL LIKE pattern matched against the values of MetaNamespace
+	MetaValuePattern string `protobuf:"bytes,19,opt,name=MetaValuePattern" json:"MetaValuePattern,omitempty"`
 }
 
 func (m *Query) Reset()                    { *m = Query{} }
@@ -1737,6 +1759,20 @@ func (m *Query) GetNot() bool {
 	return false
 }
 
+func (m *Query) GetMetaNamespace() string {
+	if m != nil {
+		return m.MetaNamespace
+	}
+	return ""
+}
+
+func (m *Query) GetMetaValuePattern() string {
+	if m != nil {
+		return m.MetaValuePattern
+	}
+	return ""
+}
+
 type GeoQuery struct {
 	// Either use a center point and a distance
 	Center *GeoPoint `protobuf:"bytes,1,opt,name=Center" json:"Center,omitempty"`
@@ -2206,190 +2242,193 @@ func init() {
 func init() { proto.RegisterFile("tree.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 2955 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x3a, 0x5b, 0x6f, 0x23, 0x49,
-	0xd5, 0x69, 0xdb, 0x71, 0xec, 0x93, 0x5b, 0xa7, 0xe2, 0xcc, 0x78, 0xbd, 0x97, 0x6f, 0xbe, 0xde,
-	0xd5, 0x92, 0x1d, 0x56, 0xd1, 0x6e, 0x86, 0x65, 0xaf, 0x88, 0xf5, 0xd8, 0xce, 0x4c, 0x98, 0x5c,
-	0x4c, 0xdb, 0xd9, 0x08, 0x24, 0xb4, 0xf4, 0xb4, 0x2b, 0x4e, 0x33, 0x76, 0xb7, 0xa7, 0xba, 0x9c,
-	0x8d, 0x79, 0x81, 0x79, 0xe1, 0x0d, 0x21, 0x21, 0xf1, 0x03, 0x10, 0x12, 0x0f, 0xfc, 0x01, 0x1e,
-	0x79, 0xe1, 0x3f, 0xf0, 0x17, 0xe0, 0x95, 0x37, 0xc4, 0x0b, 0x3a, 0x75, 0xe9, 0x8b, 0xbb, 0x33,
-	0x93, 0xcc, 0xec, 0x8b, 0xd5, 0xe7, 0x52, 0xa7, 0xce, 0xa5, 0xce, 0xa9, 0x53, 0x55, 0x06, 0xe0,
-	0x8c, 0xd2, 0x9d, 0x09, 0x0b, 0x78, 0x40, 0x4a, 0xf8, 0x6d, 0xfd, 0xc9, 0x80, 0x75, 0x9b, 0x3a,
-	0x83, 0xa3, 0x60, 0x40, 0x6d, 0xfa, 0x74, 0x4a, 0x43, 0x4e, 0xde, 0x82, 0x12, 0x82, 0x75, 0xe3,
-	0x8e, 0xb1, 0xbd, 0xbc, 0x0b, 0x3b, 0x62, 0x90, 0x60, 0x10, 0x78, 0x72, 0x07, 0x96, 0x4f, 0x3d,
-	0x7e, 0xde, 0x0a, 0xc6, 0x63, 0x8f, 0x87, 0xf5, 0xc2, 0x1d, 0x63, 0xbb, 0x62, 0x27, 0x51, 0xe4,
-	0x7d, 0xd8, 0x40, 0xb0, 0x73, 0xc9, 0xa9, 0x3f, 0xa0, 0x83, 0x1e, 0x77, 0x78, 0x58, 0x2f, 0x0a,
-	0xbe, 0x2c, 0x01, 0xe5, 0x1d, 0x3f, 0xfe, 0x05, 0x75, 0xb9, 0xe4, 0x2b, 0x49, 0x79, 0x09, 0x94,
-	0x75, 0x00, 0x66, 0xac, 0x64, 0x38, 0x09, 0xfc, 0x90, 0x92, 0x3a, 0x2c, 0xf5, 0xa6, 0xae, 0x4b,
-	0xc3, 0x50, 0x28, 0x5a, 0xb1, 0x35, 0x18, 0xe9, 0x5f, 0xc8, 0xd7, 0xdf, 0xfa, 0x7d, 0x01, 0xcc,
-	0x03, 0x2f, 0xe4, 0x08, 0x84, 0xd7, 0x35, 0xfa, 0x0d, 0xa8, 0xda, 0xd4, 0x9d, 0xb2, 0xd0, 0xbb,
-	0xa0, 0xca, 0xe4, 0x18, 0x81, 0xd4, 0xa6, 0xef, 0xd2, 0x90, 0x07, 0x4c, 0x1b, 0x1a, 0x23, 0x88,
-	0x05, 0x2b, 0x68, 0xf5, 0x57, 0x94, 0x85, 0x5e, 0xe0, 0x87, 0xf5, 0x25, 0xc1, 0x90, 0xc2, 0xcd,
-	0x3b, 0xb5, 0x92, 0x75, 0x6a, 0x0d, 0x16, 0x0f, 0xbc, 0xb1, 0xc7, 0x85, 0x83, 0x8a, 0xb6, 0x04,
-	0xc8, 0x2d, 0x28, 0x1f, 0x9f, 0x9d, 0x85, 0x94, 0xd7, 0x17, 0x05, 0x5a, 0x41, 0x64, 0x07, 0x60,
-	0xcf, 0x1b, 0x71, 0xca, 0xfa, 0xb3, 0x09, 0xad, 0x97, 0xef, 0x18, 0xdb, 0x6b, 0xbb, 0x6b, 0xb1,
-	0x55, 0x88, 0xb5, 0x13, 0x1c, 0xd6, 0x3d, 0xd8, 0x48, 0xf8, 0x44, 0xf9, 0xf8, 0x05, 0x4e, 0xb1,
-	0xfe, 0x6e, 0x40, 0xfd, 0x94, 0x39, 0x93, 0x89, 0xe7, 0x0f, 0x7b, 0x9c, 0x51, 0x67, 0x4c, 0x59,
-	0x34, 0xf8, 0x41, 0x8e, 0x44, 0x25, 0xe9, 0xb6, 0x94, 0x94, 0x21, 0x3f, 0x5c, 0xb0, 0x73, 0xb4,
-	0x68, 0xc2, 0x3a, 0x22, 0x5a, 0xe7, 0x8e, 0x3f, 0xa4, 0x9d, 0x0b, 0xea, 0x73, 0x15, 0xda, 0xad,
-	0x58, 0xa1, 0x04, 0xf1, 0xe1, 0x82, 0x3d, 0xcf, 0x8f, 0xbe, 0xeb, 0x30, 0x16, 0x30, 0x11, 0x9b,
-	0xaa, 0x2d, 0x81, 0xfb, 0x65, 0x28, 0xb5, 0x1d, 0xee, 0x58, 0x7f, 0x34, 0x60, 0xa3, 0xc5, 0xa8,
-	0xc3, 0xe9, 0x4d, 0xd2, 0xe0, 0x5d, 0x58, 0x3b, 0x99, 0x0c, 0x1c, 0x4e, 0xf7, 0xcf, 0x3a, 0x97,
-	0x5e, 0x18, 0x65, 0xc2, 0x1c, 0x16, 0x93, 0x61, 0xdf, 0x1f, 0xd0, 0x4b, 0x87, 0x7b, 0x81, 0xdf,
-	0xa3, 0x21, 0xc6, 0x5b, 0xe9, 0x91, 0x25, 0x60, 0x3c, 0x7b, 0xde, 0x08, 0x6d, 0x94, 0x79, 0xa0,
-	0x20, 0xeb, 0x08, 0x48, 0x52, 0xc5, 0x57, 0x4e, 0x82, 0x3f, 0x18, 0xb0, 0x21, 0x15, 0x9d, 0xb3,
-	0x79, 0x8f, 0x05, 0xe3, 0x3c, 0x9b, 0x11, 0x4f, 0x1a, 0x50, 0xe8, 0x07, 0x39, 0x32, 0x0b, 0xfd,
-	0xe0, 0xdb, 0xb3, 0x33, 0xa9, 0xd6, 0x2b, 0xdb, 0x39, 0x83, 0x8d, 0x36, 0x1d, 0xd1, 0x9b, 0x85,
-	0x36, 0xd7, 0x94, 0xc2, 0x8b, 0x4d, 0x29, 0xa6, 0x4c, 0xd9, 0x01, 0x92, 0x9c, 0xfa, 0x45, 0xa6,
-	0x58, 0xff, 0x31, 0x72, 0xa6, 0x25, 0x04, 0x4a, 0x27, 0x53, 0x6f, 0x20, 0x98, 0xab, 0xb6, 0xf8,
-	0xc6, 0x62, 0xd1, 0xa6, 0xa1, 0xcb, 0xbc, 0x09, 0x8f, 0x35, 0x4b, 0xa2, 0xc8, 0xbb, 0x50, 0xb1,
-	0x83, 0x40, 0x24, 0x92, 0xd0, 0x2a, 0x6d, 0x65, 0x44, 0x23, 0x9f, 0xc0, 0xed, 0xce, 0xe5, 0x84,
-	0xba, 0x9c, 0x0e, 0x8e, 0x27, 0x94, 0x89, 0x99, 0xc3, 0x56, 0x30, 0xf5, 0x75, 0x99, 0xb9, 0x8a,
-	0x4c, 0xbe, 0x07, 0x5b, 0xad, 0x29, 0x63, 0xd4, 0xe7, 0x11, 0x45, 0x8e, 0x93, 0x75, 0x28, 0x9f,
-	0x98, 0xf0, 0x55, 0x39, 0xe5, 0xab, 0xa7, 0xb0, 0x19, 0x9b, 0x1e, 0x8d, 0x41, 0x43, 0x95, 0x1f,
-	0x12, 0x3e, 0x48, 0xa2, 0xae, 0xe1, 0x8a, 0x5b, 0x50, 0x6e, 0x4d, 0x59, 0xa8, 0x92, 0xbf, 0x68,
-	0x2b, 0xc8, 0x7a, 0x00, 0xe4, 0x78, 0x42, 0xb5, 0x9f, 0xf5, 0xd2, 0xf8, 0x10, 0x96, 0x74, 0xc0,
-	0x53, 0xb5, 0x2a, 0x13, 0x18, 0x5b, 0xf3, 0x59, 0x0f, 0x61, 0x33, 0x25, 0x48, 0x05, 0xfa, 0xe5,
-	0x24, 0xed, 0x8d, 0xa6, 0xe1, 0xf9, 0xab, 0xeb, 0xb4, 0x0f, 0xb5, 0xb4, 0xa4, 0x57, 0x52, 0xaa,
-	0x35, 0x0a, 0x42, 0xfa, 0xad, 0x28, 0x95, 0x96, 0xf4, 0xf2, 0x4a, 0xed, 0x82, 0x79, 0xea, 0x70,
-	0xf7, 0xfc, 0x06, 0x59, 0x8d, 0x5b, 0x5c, 0x62, 0xcc, 0x35, 0xb7, 0x38, 0x0e, 0xab, 0x3d, 0xea,
-	0x30, 0xf7, 0x5c, 0xcf, 0xf2, 0xff, 0xb0, 0xf8, 0xe3, 0x29, 0x65, 0x33, 0x35, 0x62, 0x59, 0x8e,
-	0x10, 0x28, 0x5b, 0x52, 0x30, 0x65, 0x7b, 0xde, 0x2f, 0x65, 0x4d, 0x5a, 0xb4, 0xc5, 0x37, 0xe2,
-	0x44, 0x65, 0x2d, 0x4a, 0x9c, 0xa8, 0xa6, 0x75, 0x58, 0x6a, 0x53, 0xee, 0x78, 0x23, 0xdd, 0xf4,
-	0x68, 0xd0, 0xfa, 0xab, 0x81, 0x0b, 0x1f, 0xa7, 0xdd, 0x73, 0x5c, 0xca, 0xb1, 0xbf, 0xd8, 0xf3,
-	0xe8, 0x68, 0x70, 0xe4, 0x8c, 0xa9, 0xca, 0x82, 0x18, 0x21, 0x3a, 0x03, 0xe7, 0x31, 0x1d, 0xa9,
-	0xd5, 0x2f, 0x01, 0xc4, 0xca, 0x84, 0x94, 0x53, 0x4a, 0x00, 0xf5, 0xe8, 0x53, 0x36, 0x16, 0x13,
-	0x56, 0x6d, 0xf1, 0x4d, 0x4c, 0x28, 0x1e, 0x7a, 0xbe, 0x4a, 0x5c, 0xfc, 0x14, 0x18, 0xe7, 0x52,
-	0xe4, 0x28, 0x62, 0x9c, 0x4b, 0x94, 0xd6, 0xe3, 0x0e, 0xe3, 0xa2, 0x79, 0x59, 0xb4, 0x25, 0x80,
-	0x7c, 0x1d, 0x7f, 0x20, 0xba, 0x95, 0x45, 0x1b, 0x3f, 0xad, 0x9f, 0xc0, 0x9a, 0xf6, 0xd7, 0xf5,
-	0x3c, 0x4c, 0xbe, 0x03, 0x8b, 0xc2, 0x48, 0x55, 0xc2, 0x37, 0x24, 0x43, 0xc2, 0x7a, 0x5b, 0xd2,
-	0xad, 0xa7, 0x50, 0x93, 0x5b, 0xa0, 0x6a, 0x9a, 0xae, 0x5b, 0xcd, 0x3f, 0x85, 0x95, 0x3e, 0xf3,
-	0x86, 0x43, 0xca, 0x5e, 0xdc, 0x3c, 0xd8, 0x29, 0x56, 0xeb, 0x3e, 0x6c, 0xcd, 0x4d, 0xa9, 0x8c,
-	0x7a, 0x0f, 0x96, 0x14, 0x4a, 0x4d, 0xbb, 0x2e, 0xc5, 0x49, 0x51, 0x07, 0xc1, 0xd0, 0xd6, 0x74,
-	0xeb, 0x23, 0xd8, 0xc4, 0x9e, 0x46, 0x77, 0x7a, 0xd7, 0x5d, 0xad, 0x4d, 0xa8, 0xa5, 0x87, 0xdd,
-	0x7c, 0x66, 0x1b, 0xc8, 0x43, 0xea, 0x0c, 0x6e, 0xe8, 0xae, 0x37, 0xa0, 0xaa, 0x46, 0xec, 0x0f,
-	0xd4, 0x8a, 0x8a, 0x11, 0xd6, 0x97, 0xb0, 0x99, 0x92, 0x79, 0x73, 0xad, 0x7e, 0x0e, 0x9b, 0x3d,
-	0x1e, 0xb0, 0x9b, 0x46, 0x31, 0x31, 0x43, 0xe1, 0x05, 0x33, 0x0c, 0xa1, 0x96, 0x9e, 0xe1, 0x85,
-	0x5d, 0xc4, 0x47, 0xb0, 0xda, 0x65, 0x53, 0x9f, 0x46, 0x2d, 0x7a, 0xe1, 0x4e, 0x31, 0x6f, 0x8a,
-	0x34, 0x97, 0x35, 0x82, 0x5a, 0x0a, 0xa1, 0x6d, 0xb9, 0x0b, 0x70, 0xe2, 0x7b, 0x4f, 0xa7, 0xf4,
-	0x0a, 0x8b, 0x12, 0x54, 0xb2, 0x0d, 0xeb, 0xcd, 0xd1, 0x48, 0x36, 0x0a, 0xe2, 0x84, 0xa3, 0xfb,
-	0xc8, 0x79, 0xb4, 0x65, 0xc3, 0xd6, 0xdc, 0x6c, 0xca, 0xae, 0x4f, 0x61, 0x5d, 0x31, 0x46, 0xfa,
-	0x1b, 0xf9, 0xfa, 0xcf, 0xf3, 0x59, 0xff, 0x2a, 0x82, 0xa9, 0x00, 0xcf, 0x1f, 0x76, 0x83, 0x91,
-	0xe7, 0xce, 0x72, 0x5b, 0x0e, 0x02, 0x25, 0x51, 0x7c, 0xe4, 0x82, 0x10, 0xdf, 0xf3, 0x7b, 0x6f,
-	0x31, 0xbb, 0xf7, 0x7e, 0x1f, 0x6e, 0xe9, 0xa9, 0xb0, 0xd3, 0xee, 0x05, 0x53, 0xe6, 0x52, 0x21,
-	0x47, 0xd6, 0x9f, 0x2b, 0xa8, 0xe4, 0x33, 0xa8, 0x67, 0x29, 0xf7, 0xa7, 0xee, 0x13, 0x75, 0xce,
-	0xa9, 0xda, 0x57, 0xd2, 0xf1, 0xb4, 0x75, 0xe8, 0x5c, 0xf6, 0x03, 0xee, 0x8c, 0x44, 0x15, 0x96,
-	0x45, 0x2c, 0x85, 0xc3, 0xde, 0xfd, 0xd0, 0xb9, 0xc4, 0xcf, 0x2e, 0x65, 0x7b, 0xde, 0x88, 0x8a,
-	0xb2, 0x56, 0xb4, 0xe7, 0xb0, 0xa8, 0xff, 0xfe, 0xd0, 0x0f, 0x18, 0x45, 0x28, 0x7c, 0x20, 0x4a,
-	0x01, 0xeb, 0x9f, 0x3b, 0xbe, 0x28, 0x79, 0x45, 0xfb, 0x0a, 0x2a, 0xf9, 0x02, 0x96, 0x1f, 0x51,
-	0x3a, 0xe9, 0x52, 0xe6, 0x05, 0x83, 0xb0, 0x5e, 0x15, 0xd1, 0x68, 0xc8, 0x68, 0xc4, 0xee, 0x8e,
-	0x59, 0xec, 0x24, 0x3b, 0x39, 0x81, 0x4d, 0x8c, 0xb8, 0x8a, 0x55, 0x8f, 0x33, 0x87, 0xd3, 0xe1,
-	0xac, 0x0e, 0xe2, 0x10, 0xf7, 0xf6, 0xbc, 0x94, 0x1c, 0x56, 0x3b, 0x6f, 0xbc, 0xf5, 0x53, 0xa8,
-	0xe5, 0xcd, 0x4d, 0xde, 0x81, 0xd5, 0x7d, 0x9f, 0x53, 0x76, 0xe1, 0x8c, 0x64, 0x89, 0x97, 0x71,
-	0x4f, 0x23, 0xb1, 0x2c, 0x1c, 0x3a, 0x97, 0x47, 0xd3, 0xf1, 0x63, 0xca, 0xd4, 0x96, 0x12, 0x23,
-	0xac, 0x67, 0x45, 0x99, 0xbe, 0x57, 0xad, 0x9d, 0xae, 0xc3, 0xcf, 0xf5, 0xda, 0xc1, 0x6f, 0x62,
-	0x41, 0x49, 0x9c, 0x4c, 0x8b, 0xb9, 0x27, 0x53, 0x41, 0x8b, 0xf6, 0x51, 0xd9, 0x89, 0xca, 0x7d,
-	0xb4, 0x06, 0x8b, 0x87, 0x7d, 0x6f, 0x4c, 0xd5, 0x6e, 0x25, 0x01, 0xe4, 0x3c, 0xc4, 0x54, 0x2b,
-	0xcb, 0xdd, 0xf5, 0x50, 0x69, 0xd2, 0xe1, 0xce, 0x50, 0x44, 0xb6, 0x6a, 0x8b, 0x6f, 0x2c, 0x22,
-	0xfa, 0x84, 0x5d, 0xcd, 0xcf, 0x10, 0x4d, 0x27, 0x1f, 0x43, 0xf5, 0x90, 0x72, 0x47, 0x14, 0x92,
-	0x7a, 0x45, 0x30, 0xbf, 0x16, 0x6b, 0xb9, 0x13, 0xd1, 0x3a, 0x3e, 0x67, 0x33, 0x3b, 0xe6, 0x25,
-	0x9f, 0x42, 0xb5, 0x39, 0x99, 0x50, 0x87, 0x85, 0xfb, 0x7e, 0x1d, 0xc4, 0xc0, 0xd7, 0xe5, 0xc0,
-	0xd3, 0x80, 0x3d, 0x09, 0x27, 0x8e, 0x4b, 0x6d, 0x3a, 0x72, 0xb8, 0x77, 0x41, 0xd1, 0x13, 0x76,
-	0xcc, 0xdd, 0xf8, 0x02, 0xd6, 0xd2, 0x72, 0x71, 0x83, 0x7d, 0x42, 0x67, 0xca, 0x9b, 0xf8, 0x89,
-	0x0e, 0xb8, 0x70, 0x46, 0x53, 0x9d, 0x89, 0x12, 0xf8, 0xac, 0xf0, 0x89, 0x61, 0xfd, 0xce, 0x80,
-	0xad, 0xdc, 0x29, 0xb0, 0x05, 0x3e, 0x0d, 0x13, 0x61, 0x51, 0x10, 0x16, 0xc4, 0xd3, 0x30, 0xd9,
-	0x3a, 0x68, 0x30, 0x0a, 0x59, 0x31, 0x11, 0x32, 0x21, 0xa5, 0x37, 0x9a, 0x0e, 0x55, 0xf2, 0x2a,
-	0x48, 0x4a, 0xe9, 0xb9, 0xc1, 0x84, 0xaa, 0xdc, 0xd4, 0xa0, 0xf5, 0x6f, 0x03, 0xaa, 0x91, 0x6b,
-	0x5f, 0xf2, 0x24, 0x13, 0x05, 0xbc, 0x38, 0x17, 0xf0, 0xcc, 0xd2, 0x20, 0xf2, 0x38, 0x2f, 0x94,
-	0x58, 0xb1, 0xc5, 0x37, 0xae, 0xda, 0xe3, 0x6f, 0x7c, 0xca, 0xc4, 0xc4, 0x65, 0xb9, 0x99, 0x45,
-	0x08, 0xf2, 0x5d, 0x58, 0x94, 0x2d, 0xc1, 0xd2, 0xf3, 0x5a, 0x02, 0xc9, 0x83, 0x47, 0xaa, 0x83,
-	0xc0, 0x15, 0xed, 0xa8, 0xc8, 0xfe, 0xb9, 0x23, 0x95, 0xa6, 0x59, 0xcf, 0x4a, 0xaa, 0x43, 0x44,
-	0xd5, 0xd1, 0x71, 0x61, 0x7d, 0xf5, 0x4e, 0x11, 0x43, 0x25, 0x00, 0xf2, 0x16, 0x00, 0x7e, 0x74,
-	0x19, 0x3d, 0xf3, 0x2e, 0x45, 0xa1, 0xae, 0xda, 0x09, 0x0c, 0xba, 0xf3, 0xd0, 0xf3, 0xa3, 0x06,
-	0xb2, 0x68, 0x6b, 0x50, 0x50, 0x64, 0x7d, 0x52, 0xce, 0xd0, 0xa0, 0x1a, 0xd3, 0x76, 0xb8, 0xf6,
-	0x88, 0x06, 0xd5, 0x18, 0x41, 0x59, 0x8c, 0xc6, 0x08, 0x8a, 0x05, 0x2b, 0xed, 0xa9, 0x3c, 0x67,
-	0x09, 0xb2, 0x29, 0xbc, 0x93, 0xc2, 0x45, 0x59, 0x5a, 0x7e, 0x4e, 0x96, 0x36, 0xa0, 0x82, 0xf5,
-	0x4f, 0x54, 0x75, 0x99, 0x6b, 0x11, 0x8c, 0xb3, 0xb7, 0x02, 0x9f, 0xa3, 0x8b, 0x2b, 0x72, 0x69,
-	0x28, 0x10, 0x8f, 0xd8, 0x9a, 0xeb, 0x98, 0x69, 0x9e, 0x0d, 0x79, 0xc4, 0xce, 0x10, 0xd0, 0x67,
-	0x7b, 0x8c, 0xd2, 0x1e, 0x67, 0x9e, 0x3f, 0xac, 0x57, 0x05, 0x5b, 0x02, 0x83, 0x61, 0x16, 0x77,
-	0x8a, 0xa2, 0x3d, 0x00, 0x19, 0xe6, 0x08, 0x41, 0xee, 0x42, 0xe5, 0x01, 0x0d, 0x64, 0xd7, 0xbe,
-	0x2c, 0x22, 0xa7, 0x2c, 0xd1, 0x58, 0x3b, 0xa2, 0xa3, 0x24, 0x8c, 0x45, 0x9b, 0x4e, 0xf8, 0x79,
-	0x7d, 0x45, 0x96, 0xb9, 0x08, 0x81, 0x11, 0x3d, 0x39, 0xd9, 0x6f, 0x87, 0xf5, 0x75, 0x19, 0x51,
-	0x01, 0x60, 0x92, 0x1e, 0x05, 0xbc, 0xbe, 0x26, 0xb6, 0x6d, 0xfc, 0xb4, 0xfe, 0x62, 0xc4, 0x53,
-	0x92, 0x77, 0xa1, 0xdc, 0xa2, 0x58, 0x4b, 0x55, 0x27, 0x10, 0x4f, 0xde, 0x0d, 0x3c, 0x9f, 0xdb,
-	0x8a, 0x8a, 0x8e, 0x6c, 0x7b, 0x21, 0x77, 0x7c, 0x57, 0x27, 0x77, 0x04, 0x93, 0x6d, 0x58, 0xea,
-	0x07, 0x93, 0x03, 0x7a, 0xc6, 0xd5, 0x71, 0x7e, 0x5e, 0x88, 0x26, 0x93, 0x0f, 0x60, 0xf9, 0x7e,
-	0xc0, 0x79, 0x30, 0xb6, 0xbd, 0xe1, 0xb9, 0x3c, 0xc5, 0x67, 0xb9, 0x93, 0x2c, 0xd6, 0x8e, 0xd0,
-	0x55, 0x10, 0xd0, 0x94, 0x03, 0x47, 0xee, 0x00, 0x86, 0x8d, 0x9f, 0x02, 0xa3, 0x32, 0x13, 0x31,
-	0xe2, 0xec, 0x55, 0x93, 0x97, 0x7d, 0x32, 0x49, 0xa2, 0xae, 0xa7, 0x21, 0xef, 0x1c, 0x44, 0xdd,
-	0x90, 0x39, 0x1e, 0xc1, 0xd6, 0xdf, 0x8a, 0x99, 0x4b, 0x3c, 0x72, 0x4f, 0x2d, 0x2e, 0x43, 0x2c,
-	0xae, 0xff, 0xcb, 0x4d, 0xbe, 0x1d, 0xf1, 0x9b, 0x58, 0x6d, 0x16, 0x94, 0xe5, 0x6e, 0x9f, 0x73,
-	0xe3, 0xa3, 0x28, 0xc8, 0xd3, 0x77, 0xd8, 0x90, 0xf2, 0x9c, 0xab, 0x0f, 0x45, 0x21, 0x3f, 0x84,
-	0x0a, 0x96, 0xda, 0x01, 0x16, 0x8c, 0xb2, 0x28, 0xd2, 0x6f, 0xe7, 0x2b, 0xa0, 0xb9, 0x64, 0x9d,
-	0x8f, 0x06, 0x5d, 0x75, 0x81, 0x85, 0x4b, 0xf5, 0x78, 0xc2, 0xbd, 0xb1, 0x17, 0x72, 0xcf, 0x15,
-	0x39, 0x57, 0xb1, 0x13, 0x98, 0xc6, 0xe7, 0xb0, 0x9a, 0x12, 0x79, 0xa3, 0x12, 0x3f, 0x83, 0x6a,
-	0xe4, 0x10, 0x02, 0x50, 0x6e, 0xd9, 0x9d, 0x66, 0xbf, 0x63, 0x2e, 0x90, 0x0a, 0x94, 0xec, 0x4e,
-	0xb3, 0x6d, 0x1a, 0x64, 0x1d, 0x96, 0x4f, 0xba, 0xed, 0x66, 0xbf, 0xf3, 0x75, 0xb7, 0xd9, 0x7f,
-	0x68, 0x16, 0x08, 0x81, 0x35, 0x85, 0x68, 0x1d, 0x1f, 0xf5, 0x3b, 0x47, 0x7d, 0xb3, 0x98, 0x60,
-	0x3a, 0xec, 0xf4, 0x9b, 0x66, 0x89, 0xd4, 0xc0, 0x54, 0x88, 0x93, 0x5e, 0xc7, 0x96, 0xd8, 0x32,
-	0xce, 0xd0, 0xee, 0x1c, 0x74, 0xfa, 0x1d, 0x73, 0xd1, 0xfa, 0xb3, 0x01, 0x20, 0x0e, 0xe4, 0x32,
-	0x78, 0xef, 0xc0, 0xaa, 0xb8, 0x44, 0x6d, 0x53, 0x2e, 0xae, 0x87, 0x54, 0x47, 0x9d, 0x46, 0x62,
-	0x9f, 0x35, 0xd7, 0xf7, 0x49, 0x93, 0xe6, 0xb0, 0x22, 0x7f, 0x71, 0x60, 0x62, 0xcf, 0x89, 0x11,
-	0x58, 0x2b, 0xd4, 0xb9, 0x7f, 0x2f, 0x60, 0x2e, 0x15, 0x77, 0x08, 0x6a, 0x0f, 0xca, 0x12, 0xac,
-	0x67, 0x06, 0xdc, 0x7e, 0x40, 0x79, 0xc7, 0x77, 0xd9, 0x4c, 0x6c, 0x21, 0x8f, 0xe8, 0x4c, 0x2f,
-	0x51, 0xdc, 0x82, 0x42, 0x95, 0x88, 0xb8, 0x05, 0x85, 0x32, 0xed, 0xba, 0x4e, 0x18, 0x7e, 0x13,
-	0x30, 0x7d, 0xdc, 0x89, 0xe0, 0xe8, 0x50, 0x52, 0xbc, 0xe2, 0x50, 0x72, 0x0b, 0xca, 0xf2, 0x7c,
-	0xa8, 0x02, 0xad, 0x20, 0xeb, 0x7d, 0xa8, 0x67, 0x55, 0x50, 0xdd, 0xba, 0x09, 0xc5, 0x47, 0x2a,
-	0xde, 0x2b, 0x36, 0x7e, 0x5a, 0xbf, 0x2e, 0x00, 0xf4, 0x66, 0xbe, 0x2b, 0x97, 0x1d, 0x32, 0x84,
-	0xf4, 0xa9, 0x60, 0x28, 0xd9, 0xf8, 0x49, 0x6e, 0x43, 0xd9, 0x0f, 0x06, 0x34, 0x3a, 0x8f, 0x2d,
-	0x21, 0xf4, 0xb5, 0x37, 0x20, 0xef, 0x41, 0x89, 0xc7, 0x5d, 0x94, 0xda, 0xbf, 0x62, 0x51, 0x3b,
-	0x32, 0x71, 0x90, 0x05, 0x55, 0x0d, 0x65, 0xe2, 0xa8, 0xdd, 0x5b, 0x42, 0x88, 0xe7, 0x32, 0x59,
-	0xe4, 0xe6, 0xad, 0x20, 0xb2, 0x0d, 0x25, 0x5f, 0xb7, 0x54, 0xcb, 0xbb, 0xb5, 0x79, 0xd1, 0xd2,
-	0x09, 0xc8, 0x61, 0xdd, 0x97, 0x79, 0x4c, 0x96, 0x61, 0x69, 0xea, 0x3f, 0xf1, 0x83, 0x6f, 0x7c,
-	0x73, 0x01, 0x97, 0x8e, 0x2b, 0x7c, 0x61, 0x1a, 0xf8, 0x3d, 0x10, 0xbd, 0xa8, 0x59, 0xc0, 0x85,
-	0x3a, 0x71, 0xf8, 0xb9, 0x59, 0x44, 0x76, 0x57, 0x96, 0x77, 0xb3, 0x84, 0xab, 0x6b, 0x2d, 0x2d,
-	0x1c, 0xe3, 0xf2, 0x78, 0xc6, 0x69, 0x88, 0xdb, 0x9d, 0x21, 0xb6, 0xae, 0x08, 0x46, 0x17, 0x8d,
-	0x07, 0x1f, 0x29, 0x6f, 0xe0, 0x27, 0xe6, 0xcc, 0x98, 0x27, 0xda, 0x04, 0x01, 0x90, 0xd7, 0xa1,
-	0x82, 0x2a, 0x8a, 0x65, 0x25, 0xcd, 0xae, 0x0a, 0xd7, 0xa1, 0x0a, 0xe4, 0x1e, 0xd4, 0x18, 0x9d,
-	0x04, 0xa1, 0xc7, 0x03, 0x36, 0xdb, 0x1f, 0x50, 0x9f, 0x7b, 0x67, 0x1e, 0x65, 0xca, 0x0f, 0x5b,
-	0x31, 0xed, 0x6b, 0x2f, 0x22, 0x5a, 0x2d, 0xd8, 0xea, 0x4e, 0x79, 0xac, 0x6a, 0xf2, 0x70, 0x19,
-	0xa6, 0x0f, 0x97, 0x0a, 0x14, 0xca, 0x86, 0xc3, 0x48, 0xd9, 0x70, 0x68, 0xfd, 0x0a, 0x6e, 0xcb,
-	0xfb, 0x8d, 0xa4, 0x1c, 0xb9, 0x42, 0xb3, 0xc1, 0xaf, 0xc3, 0xd2, 0xd9, 0xc8, 0xe1, 0x9c, 0xfa,
-	0xea, 0x60, 0xa8, 0x41, 0x0c, 0xdd, 0x44, 0x76, 0x11, 0x32, 0x65, 0x14, 0x84, 0x4d, 0xd5, 0xc8,
-	0x09, 0x79, 0x8f, 0x3e, 0x3d, 0xf6, 0x47, 0x33, 0xfd, 0xa0, 0x96, 0x40, 0xdd, 0x7d, 0x08, 0x6f,
-	0x3e, 0xf7, 0x00, 0x81, 0xc1, 0xc1, 0x13, 0x42, 0x73, 0x34, 0x32, 0x17, 0xc8, 0x0a, 0x54, 0x10,
-	0x38, 0x70, 0x42, 0x6e, 0x1a, 0x1a, 0x3a, 0x0a, 0x7c, 0x6a, 0x16, 0xee, 0x7e, 0x08, 0x15, 0xdd,
-	0x0f, 0xe0, 0xa0, 0x93, 0xa3, 0x47, 0x47, 0xc7, 0xa7, 0x47, 0xb2, 0x22, 0x1d, 0x74, 0x9a, 0x7b,
-	0xa6, 0x41, 0xd6, 0x00, 0x5a, 0xc7, 0x07, 0x07, 0x9d, 0x56, 0x7f, 0xff, 0xf8, 0xc8, 0x2c, 0xec,
-	0xfe, 0xd6, 0x80, 0x15, 0x1c, 0xd3, 0x65, 0xc1, 0x85, 0x37, 0xa0, 0x8c, 0x7c, 0x0e, 0x15, 0xfd,
-	0xbc, 0x47, 0xd4, 0x1a, 0x9e, 0x7b, 0x93, 0x6c, 0xdc, 0x9a, 0x47, 0x4b, 0xaf, 0x5b, 0x0b, 0xe4,
-	0x4b, 0xa8, 0x46, 0x4f, 0x46, 0xe4, 0x56, 0xe6, 0x61, 0x49, 0x0e, 0xbf, 0xea, 0xc1, 0xc9, 0x5a,
-	0xf8, 0xc0, 0xd8, 0xfd, 0x19, 0xd4, 0x92, 0xea, 0xe8, 0x87, 0x2c, 0xd2, 0x81, 0x35, 0x3d, 0x9f,
-	0xc4, 0xdd, 0x58, 0xb9, 0x6d, 0x43, 0x88, 0xdf, 0x8c, 0xf7, 0x94, 0x30, 0x92, 0xbe, 0x07, 0xab,
-	0xa9, 0x5d, 0x94, 0xa8, 0xe3, 0x61, 0xde, 0xd6, 0xda, 0xc8, 0xef, 0x4c, 0x85, 0xf6, 0xff, 0x50,
-	0xde, 0xb4, 0xa9, 0x4b, 0xbd, 0x0b, 0xca, 0x48, 0x13, 0x20, 0x7e, 0x29, 0x22, 0xca, 0xf2, 0xcc,
-	0xf3, 0x56, 0xa3, 0x9e, 0x25, 0x44, 0x3e, 0x6d, 0x02, 0xc4, 0x8f, 0x30, 0x5a, 0x44, 0xe6, 0xb5,
-	0x48, 0x8b, 0xc8, 0xbe, 0xd7, 0x48, 0x11, 0xf1, 0xe3, 0x87, 0x16, 0x91, 0x79, 0x89, 0xd1, 0x22,
-	0xb2, 0xef, 0x24, 0xd6, 0xc2, 0xee, 0x7f, 0x0d, 0x20, 0x49, 0xcb, 0x54, 0x10, 0x1e, 0x81, 0x19,
-	0x2b, 0xad, 0x70, 0x2f, 0x63, 0x25, 0x06, 0x07, 0x85, 0xc5, 0xea, 0xa7, 0x85, 0xdd, 0xc8, 0x5e,
-	0x2d, 0x2c, 0x36, 0x24, 0x2d, 0xec, 0x46, 0x96, 0x8b, 0x65, 0xf3, 0x4f, 0xac, 0x88, 0x72, 0x73,
-	0x13, 0xdb, 0x2e, 0x65, 0xa4, 0x0d, 0xcb, 0x89, 0x87, 0x06, 0xa2, 0x24, 0x64, 0x1f, 0x31, 0x1a,
-	0xaf, 0xe5, 0x50, 0xa2, 0xc8, 0x3c, 0x80, 0x95, 0xe4, 0xd3, 0x00, 0x51, 0xcc, 0x39, 0x0f, 0x0f,
-	0x8d, 0x46, 0x1e, 0x29, 0x29, 0x28, 0x79, 0x9d, 0xaf, 0x05, 0xe5, 0x3c, 0x16, 0x68, 0x41, 0x79,
-	0xb7, 0xff, 0xd6, 0xc2, 0xee, 0x57, 0x32, 0xce, 0x62, 0x4d, 0x87, 0x51, 0x55, 0xf8, 0x12, 0xaa,
-	0xd1, 0x75, 0xbd, 0x4e, 0xec, 0xf9, 0x3b, 0x7f, 0x9d, 0xd8, 0x99, 0x7b, 0x7d, 0x91, 0x1a, 0x2d,
-	0xa8, 0xc8, 0x32, 0x4b, 0x19, 0xf9, 0x18, 0xca, 0xf2, 0x9b, 0x6c, 0x26, 0x2f, 0x98, 0xb5, 0x9c,
-	0x5a, 0x1a, 0x99, 0x10, 0xb2, 0x09, 0x1b, 0x22, 0xed, 0xe4, 0x56, 0x85, 0x39, 0x4e, 0xd9, 0x1c,
-	0xf2, 0x94, 0x79, 0x9c, 0xb2, 0xdd, 0x67, 0x45, 0x58, 0x45, 0xac, 0xaa, 0xac, 0x94, 0x91, 0x1f,
-	0xc1, 0x6a, 0xea, 0xfa, 0x58, 0xe7, 0x78, 0xde, 0x35, 0x76, 0xe3, 0xf5, 0x5c, 0x5a, 0xd2, 0xdb,
-	0xc9, 0x4b, 0x4d, 0xed, 0xed, 0x9c, 0xab, 0xd4, 0x46, 0x23, 0x8f, 0x14, 0x09, 0xda, 0x87, 0x95,
-	0xe4, 0xc5, 0xb2, 0x16, 0x94, 0x73, 0x47, 0xad, 0x05, 0xe5, 0xdd, 0x43, 0xa3, 0x6f, 0x70, 0x41,
-	0x26, 0x2e, 0x83, 0xf5, 0x82, 0xcc, 0xde, 0x39, 0xeb, 0x05, 0x99, 0x73, 0x73, 0x6c, 0x2d, 0xa0,
-	0x97, 0x52, 0xf7, 0x9a, 0xda, 0x4b, 0x79, 0x57, 0xab, 0xda, 0x4b, 0xb9, 0x17, 0xa1, 0xd6, 0xc2,
-	0x2e, 0x85, 0x35, 0x3c, 0x3d, 0x3e, 0xa2, 0xb3, 0x43, 0xc7, 0x77, 0x86, 0x94, 0x91, 0x1e, 0x98,
-	0xf3, 0xad, 0x18, 0x79, 0x53, 0x1f, 0x87, 0x72, 0xbb, 0xc4, 0xc6, 0x5b, 0x57, 0x91, 0xa3, 0x69,
-	0x7e, 0x63, 0xc0, 0x72, 0xbc, 0x77, 0x87, 0xe4, 0x13, 0x28, 0x76, 0xa7, 0x9c, 0x98, 0xf3, 0x5d,
-	0x52, 0xa4, 0x6e, 0x5e, 0xcb, 0x80, 0x89, 0x4e, 0x7e, 0x10, 0xad, 0xcb, 0x37, 0x93, 0x4b, 0x30,
-	0xd3, 0x18, 0x34, 0x32, 0xb2, 0x31, 0x02, 0x8f, 0xcb, 0xe2, 0xcf, 0x3c, 0xf7, 0xfe, 0x17, 0x00,
-	0x00, 0xff, 0xff, 0xcd, 0xff, 0x06, 0x6d, 0xda, 0x23, 0x00, 0x00,
+	// 3003 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x1a, 0x4d, 0x6f, 0x23, 0x49,
+	0x35, 0x6d, 0x3b, 0x8e, 0xfd, 0xf2, 0xd5, 0xa9, 0x24, 0x33, 0xbd, 0x9e, 0xdd, 0x65, 0xe8, 0x5d,
+	0x2d, 0xd9, 0x61, 0x15, 0xed, 0x66, 0x58, 0xf6, 0x13, 0xb1, 0x1e, 0xdb, 0x99, 0xc9, 0x4e, 0x3e,
+	0x4c, 0xd9, 0xd9, 0x08, 0x24, 0xb4, 0xf4, 0xd8, 0x15, 0xa7, 0x19, 0xa7, 0xdb, 0x53, 0x5d, 0xce,
+	0xc6, 0x5c, 0x60, 0x25, 0xc4, 0x0d, 0x21, 0x21, 0xf1, 0x03, 0x10, 0x12, 0x07, 0xfe, 0x00, 0x47,
+	0x2e, 0xfc, 0x03, 0x0e, 0xfc, 0x05, 0xb8, 0x72, 0x43, 0x5c, 0xd0, 0xab, 0x8f, 0xee, 0xb6, 0xbb,
+	0x33, 0x33, 0x99, 0xd9, 0x8b, 0x55, 0xef, 0xa3, 0x5f, 0xbd, 0x7a, 0xaf, 0xde, 0x47, 0x55, 0x19,
+	0x40, 0x70, 0xc6, 0xb6, 0x47, 0x3c, 0x14, 0x21, 0x29, 0xe1, 0xd8, 0xfd, 0x93, 0x05, 0xab, 0x94,
+	0x79, 0xfd, 0xc3, 0xb0, 0xcf, 0x28, 0x7b, 0x32, 0x66, 0x91, 0x20, 0xaf, 0x43, 0x09, 0x41, 0xc7,
+	0xba, 0x6d, 0x6d, 0x2d, 0xee, 0xc0, 0xb6, 0xfc, 0x48, 0x32, 0x48, 0x3c, 0xb9, 0x0d, 0x8b, 0x27,
+	0xbe, 0x38, 0x6b, 0x84, 0xe7, 0xe7, 0xbe, 0x88, 0x9c, 0xc2, 0x6d, 0x6b, 0xab, 0x42, 0xd3, 0x28,
+	0xf2, 0x0e, 0xac, 0x21, 0xd8, 0xba, 0x14, 0x2c, 0xe8, 0xb3, 0x7e, 0x47, 0x78, 0x22, 0x72, 0x8a,
+	0x92, 0x2f, 0x4b, 0x40, 0x79, 0x47, 0x8f, 0x7e, 0xce, 0x7a, 0x42, 0xf1, 0x95, 0x94, 0xbc, 0x14,
+	0xca, 0xdd, 0x07, 0x3b, 0x51, 0x32, 0x1a, 0x85, 0x41, 0xc4, 0x88, 0x03, 0x0b, 0x9d, 0x71, 0xaf,
+	0xc7, 0xa2, 0x48, 0x2a, 0x5a, 0xa1, 0x06, 0x8c, 0xf5, 0x2f, 0xe4, 0xeb, 0xef, 0xfe, 0xbe, 0x00,
+	0xf6, 0xbe, 0x1f, 0x09, 0x04, 0xa2, 0xe7, 0x5d, 0xf4, 0xab, 0x50, 0xa5, 0xac, 0x37, 0xe6, 0x91,
+	0x7f, 0xc1, 0xf4, 0x92, 0x13, 0x04, 0x52, 0xeb, 0x41, 0x8f, 0x45, 0x22, 0xe4, 0x66, 0xa1, 0x09,
+	0x82, 0xb8, 0xb0, 0x84, 0xab, 0xfe, 0x82, 0xf1, 0xc8, 0x0f, 0x83, 0xc8, 0x59, 0x90, 0x0c, 0x53,
+	0xb8, 0x59, 0xa3, 0x56, 0xb2, 0x46, 0xdd, 0x80, 0xf9, 0x7d, 0xff, 0xdc, 0x17, 0xd2, 0x40, 0x45,
+	0xaa, 0x00, 0x72, 0x03, 0xca, 0x47, 0xa7, 0xa7, 0x11, 0x13, 0xce, 0xbc, 0x44, 0x6b, 0x88, 0x6c,
+	0x03, 0xec, 0xfa, 0x43, 0xc1, 0x78, 0x77, 0x32, 0x62, 0x4e, 0xf9, 0xb6, 0xb5, 0xb5, 0xb2, 0xb3,
+	0x92, 0xac, 0x0a, 0xb1, 0x34, 0xc5, 0xe1, 0xde, 0x85, 0xb5, 0x94, 0x4d, 0xb4, 0x8d, 0x9f, 0x61,
+	0x14, 0xf7, 0xef, 0x16, 0x38, 0x27, 0xdc, 0x1b, 0x8d, 0xfc, 0x60, 0xd0, 0x11, 0x9c, 0x79, 0xe7,
+	0x8c, 0xc7, 0x1f, 0xdf, 0xcf, 0x91, 0xa8, 0x25, 0xdd, 0x54, 0x92, 0x32, 0xe4, 0x07, 0x73, 0x34,
+	0x47, 0x8b, 0x3a, 0xac, 0x22, 0xa2, 0x71, 0xe6, 0x05, 0x03, 0xd6, 0xba, 0x60, 0x81, 0xd0, 0xae,
+	0xdd, 0x4c, 0x14, 0x4a, 0x11, 0x1f, 0xcc, 0xd1, 0x59, 0x7e, 0xb4, 0x5d, 0x8b, 0xf3, 0x90, 0x4b,
+	0xdf, 0x54, 0xa9, 0x02, 0xee, 0x95, 0xa1, 0xd4, 0xf4, 0x84, 0xe7, 0xfe, 0xd1, 0x82, 0xb5, 0x06,
+	0x67, 0x9e, 0x60, 0xd7, 0x09, 0x83, 0xb7, 0x60, 0xe5, 0x78, 0xd4, 0xf7, 0x04, 0xdb, 0x3b, 0x6d,
+	0x5d, 0xfa, 0x51, 0x1c, 0x09, 0x33, 0x58, 0x0c, 0x86, 0xbd, 0xa0, 0xcf, 0x2e, 0x3d, 0xe1, 0x87,
+	0x41, 0x87, 0x45, 0xe8, 0x6f, 0xad, 0x47, 0x96, 0x80, 0xfe, 0xec, 0xf8, 0x43, 0x16, 0x28, 0x37,
+	0x57, 0xa8, 0x86, 0xdc, 0x5f, 0x5b, 0x40, 0xd2, 0x3a, 0xbe, 0x6c, 0x14, 0x90, 0xef, 0xc1, 0x26,
+	0x65, 0x43, 0xe6, 0x45, 0xac, 0xdf, 0x08, 0x03, 0x81, 0x86, 0xf3, 0xa2, 0x33, 0x86, 0xdb, 0xb7,
+	0xb8, 0x55, 0xa5, 0xf9, 0x44, 0xf7, 0x0f, 0x16, 0xac, 0xa9, 0xf5, 0xcd, 0x98, 0x6a, 0x97, 0x87,
+	0xe7, 0x79, 0xa6, 0x42, 0x3c, 0xa9, 0x41, 0xa1, 0x1b, 0xe6, 0x68, 0x52, 0xe8, 0x86, 0xdf, 0x90,
+	0x79, 0x0e, 0x81, 0xa4, 0xd5, 0x7a, 0xe9, 0x1c, 0x31, 0x81, 0xb5, 0x26, 0x1b, 0xb2, 0xeb, 0xed,
+	0x88, 0xdc, 0xa5, 0x14, 0x9e, 0xbd, 0x94, 0xe2, 0xd4, 0x52, 0xfa, 0x40, 0xd2, 0x53, 0x3f, 0x73,
+	0x29, 0x57, 0x3a, 0xb2, 0xf0, 0x34, 0x47, 0xfe, 0xd7, 0xca, 0x51, 0x96, 0x10, 0x28, 0x1d, 0x8f,
+	0xfd, 0xbe, 0x9c, 0xa2, 0x4a, 0xe5, 0x18, 0x33, 0x53, 0x93, 0x45, 0x3d, 0xee, 0x8f, 0x44, 0xb2,
+	0x9e, 0x34, 0x8a, 0xbc, 0x05, 0x15, 0x1a, 0x86, 0x32, 0x6a, 0x9d, 0x62, 0xc6, 0x36, 0x31, 0x8d,
+	0x7c, 0x08, 0x37, 0x5b, 0x97, 0x23, 0xd6, 0x13, 0xac, 0x7f, 0x34, 0x62, 0x5c, 0xce, 0x1c, 0x35,
+	0xc2, 0x71, 0x60, 0x72, 0xda, 0x55, 0x64, 0x5c, 0x63, 0x63, 0xcc, 0x39, 0x0b, 0x44, 0x4c, 0x51,
+	0xdf, 0xa9, 0xa4, 0x97, 0x4f, 0x4c, 0x59, 0xb8, 0x3c, 0x65, 0xe1, 0x27, 0xb0, 0x9e, 0x2c, 0x3d,
+	0xfe, 0x06, 0x17, 0xaa, 0xed, 0x90, 0xb2, 0x41, 0x1a, 0xf5, 0x1c, 0xa6, 0xb8, 0x01, 0xe5, 0xc6,
+	0x98, 0x47, 0x3a, 0xd3, 0x14, 0xa9, 0x86, 0xdc, 0xfb, 0x40, 0x8e, 0x46, 0xcc, 0xd8, 0xd9, 0x6c,
+	0xa8, 0xf7, 0x60, 0xc1, 0x6c, 0x93, 0xa9, 0xc4, 0x98, 0x71, 0x0c, 0x35, 0x7c, 0xee, 0x03, 0x58,
+	0x9f, 0x12, 0xa4, 0xb7, 0xc7, 0x8b, 0x49, 0xda, 0x1d, 0x8e, 0xa3, 0xb3, 0x97, 0xd7, 0x69, 0x0f,
+	0x36, 0xa6, 0x25, 0xbd, 0x94, 0x52, 0x8d, 0x61, 0x18, 0xb1, 0x6f, 0x44, 0xa9, 0x69, 0x49, 0x2f,
+	0xae, 0xd4, 0x0e, 0xd8, 0x27, 0x9e, 0xe8, 0x9d, 0x5d, 0x23, 0x17, 0x60, 0x3d, 0x4d, 0x7d, 0xf3,
+	0x9c, 0xf5, 0x54, 0xc0, 0x72, 0x87, 0x79, 0xbc, 0x77, 0x66, 0x66, 0xf9, 0x36, 0xcc, 0xff, 0x68,
+	0xcc, 0xf8, 0x44, 0x7f, 0xb1, 0xa8, 0xbe, 0x90, 0x28, 0xaa, 0x28, 0x18, 0xb2, 0x1d, 0xff, 0x17,
+	0x2a, 0x93, 0xcd, 0x53, 0x39, 0x46, 0x9c, 0xcc, 0xc7, 0x45, 0x85, 0xc3, 0x31, 0x26, 0x90, 0x26,
+	0x13, 0x9e, 0x3f, 0x34, 0x1d, 0x96, 0x01, 0xdd, 0xbf, 0x5a, 0xb0, 0xa8, 0xa6, 0xdd, 0xf5, 0x7a,
+	0x4c, 0x60, 0x33, 0xb3, 0xeb, 0xb3, 0x61, 0xff, 0xd0, 0x3b, 0x67, 0x3a, 0x0a, 0x12, 0x84, 0x6c,
+	0x43, 0xbc, 0x47, 0x6c, 0xa8, 0x77, 0xbf, 0x02, 0x10, 0xab, 0x02, 0x52, 0x4d, 0xa9, 0x00, 0xd4,
+	0xa3, 0xcb, 0xf8, 0xb9, 0x9c, 0xb0, 0x4a, 0xe5, 0x98, 0xd8, 0x50, 0x3c, 0xf0, 0x03, 0x1d, 0xb8,
+	0x38, 0x94, 0x18, 0xef, 0xd2, 0x29, 0x6b, 0x8c, 0x77, 0x89, 0xd2, 0x3a, 0xc2, 0xe3, 0x42, 0x76,
+	0x4a, 0xf3, 0x54, 0x01, 0xc8, 0xd7, 0x0a, 0xfa, 0xb2, 0x35, 0x9a, 0xa7, 0x38, 0x74, 0x7f, 0x0c,
+	0x2b, 0xc6, 0x5e, 0xcf, 0x67, 0x61, 0xf2, 0x1d, 0x98, 0x97, 0x8b, 0xd4, 0x89, 0x7f, 0x4d, 0x31,
+	0xa4, 0x56, 0x4f, 0x15, 0xdd, 0x7d, 0x02, 0x1b, 0xaa, 0xdc, 0xea, 0x0e, 0xed, 0x79, 0x6b, 0xc0,
+	0x47, 0xb0, 0xd4, 0xe5, 0xfe, 0x60, 0xc0, 0xf8, 0xb3, 0x3b, 0x15, 0x3a, 0xc5, 0xea, 0xde, 0x83,
+	0xcd, 0x99, 0x29, 0xf5, 0xa2, 0xde, 0x86, 0x05, 0x8d, 0xd2, 0xd3, 0xae, 0x2a, 0x71, 0x4a, 0xd4,
+	0x7e, 0x38, 0xa0, 0x86, 0xee, 0xbe, 0x0f, 0xeb, 0xd8, 0x40, 0x69, 0xf0, 0x79, 0xbb, 0x5b, 0xb7,
+	0x0e, 0x1b, 0xd3, 0x9f, 0x5d, 0x7f, 0x66, 0x0a, 0xe4, 0x01, 0xf3, 0xfa, 0xd7, 0x34, 0xd7, 0xab,
+	0x50, 0xd5, 0x5f, 0xec, 0xf5, 0xf5, 0x8e, 0x4a, 0x10, 0xee, 0x67, 0xb0, 0x3e, 0x25, 0xf3, 0xfa,
+	0x5a, 0xfd, 0x0c, 0xd6, 0x3b, 0x22, 0xe4, 0xd7, 0xf5, 0x62, 0x6a, 0x86, 0xc2, 0x33, 0x66, 0x18,
+	0xc0, 0xc6, 0xf4, 0x0c, 0xcf, 0x2c, 0xd8, 0xef, 0xc3, 0x72, 0x9b, 0x8f, 0x03, 0x16, 0x9f, 0x07,
+	0xb0, 0x50, 0xe7, 0x4c, 0x31, 0xcd, 0xe5, 0x0e, 0x61, 0x63, 0x0a, 0x61, 0xd6, 0x72, 0x07, 0xe0,
+	0x38, 0xf0, 0x9f, 0x8c, 0xd9, 0x15, 0x2b, 0x4a, 0x51, 0xc9, 0x16, 0xac, 0xd6, 0x87, 0x43, 0xd5,
+	0x5e, 0xc8, 0xe3, 0x94, 0x69, 0x5a, 0x67, 0xd1, 0x2e, 0x85, 0xcd, 0x99, 0xd9, 0xf4, 0xba, 0x3e,
+	0x82, 0x55, 0xcd, 0x18, 0xeb, 0x6f, 0xe5, 0xeb, 0x3f, 0xcb, 0xe7, 0xfe, 0xbb, 0x08, 0xb6, 0x06,
+	0xfc, 0x60, 0xd0, 0x0e, 0x87, 0x7e, 0x6f, 0x92, 0xdb, 0x72, 0x10, 0x28, 0xc9, 0xe4, 0xa3, 0x36,
+	0x84, 0x1c, 0xcf, 0xd6, 0xde, 0x62, 0xb6, 0xf6, 0x7e, 0x1f, 0x6e, 0x98, 0xa9, 0xb0, 0xad, 0xef,
+	0x84, 0x63, 0xde, 0x63, 0x52, 0x8e, 0xca, 0x3f, 0x57, 0x50, 0xc9, 0xc7, 0xe0, 0x64, 0x29, 0xf7,
+	0xc6, 0xbd, 0xc7, 0xfa, 0x50, 0x55, 0xa5, 0x57, 0xd2, 0xf1, 0x68, 0x77, 0xe0, 0x5d, 0x76, 0x43,
+	0xe1, 0x0d, 0x65, 0x16, 0x56, 0x49, 0x6c, 0x0a, 0x87, 0x07, 0x85, 0x03, 0xef, 0x12, 0x87, 0x6d,
+	0xc6, 0x77, 0xfd, 0x21, 0x93, 0x69, 0xad, 0x48, 0x67, 0xb0, 0xa8, 0xff, 0xde, 0x20, 0x08, 0x39,
+	0x43, 0x28, 0xba, 0x2f, 0x53, 0x01, 0xef, 0x9e, 0x79, 0x81, 0x4c, 0x79, 0x45, 0x7a, 0x05, 0x95,
+	0x7c, 0x0a, 0x8b, 0x0f, 0x19, 0x1b, 0xb5, 0x19, 0xf7, 0xc3, 0x7e, 0xe4, 0x54, 0xa5, 0x37, 0x6a,
+	0xca, 0x1b, 0x89, 0xb9, 0x13, 0x16, 0x9a, 0x66, 0x27, 0xc7, 0xb0, 0x8e, 0x1e, 0xd7, 0xbe, 0xea,
+	0x08, 0xee, 0x09, 0x36, 0x98, 0x38, 0x20, 0x4f, 0x8c, 0x6f, 0xcc, 0x4a, 0xc9, 0x61, 0xa5, 0x79,
+	0xdf, 0xbb, 0x3f, 0x81, 0x8d, 0xbc, 0xb9, 0xc9, 0x9b, 0xb0, 0xbc, 0x17, 0x08, 0xc6, 0x2f, 0xbc,
+	0xa1, 0x4a, 0xf1, 0xca, 0xef, 0xd3, 0x48, 0x4c, 0x0b, 0x07, 0xde, 0xe5, 0xe1, 0xf8, 0xfc, 0x11,
+	0xe3, 0xba, 0xa4, 0x24, 0x08, 0xf7, 0xeb, 0xa2, 0x0a, 0xdf, 0xab, 0xf6, 0x4e, 0xdb, 0x13, 0x67,
+	0x66, 0xef, 0xe0, 0x98, 0xb8, 0x50, 0x92, 0xc7, 0xe0, 0x62, 0xee, 0x31, 0x58, 0xd2, 0xe2, 0x3a,
+	0xaa, 0x3a, 0x51, 0x39, 0xc6, 0x3a, 0x74, 0xd0, 0xf5, 0xcf, 0x99, 0xae, 0x56, 0x0a, 0x40, 0xce,
+	0x83, 0xb0, 0xaf, 0x7c, 0x3d, 0x4f, 0xe5, 0x18, 0x71, 0x2d, 0xe1, 0x0d, 0xa4, 0x67, 0xab, 0x54,
+	0x8e, 0x31, 0x89, 0x98, 0xe3, 0x7c, 0x35, 0x3f, 0x42, 0x0c, 0x9d, 0x7c, 0x00, 0xd5, 0x03, 0x26,
+	0x3c, 0x99, 0x48, 0x9c, 0x8a, 0x64, 0x7e, 0x25, 0xd1, 0x72, 0x3b, 0xa6, 0xb5, 0x02, 0xc1, 0x27,
+	0x34, 0xe1, 0x25, 0x1f, 0x41, 0xb5, 0x3e, 0x1a, 0x31, 0x8f, 0x47, 0x7b, 0x81, 0x03, 0xf2, 0xc3,
+	0x5b, 0xea, 0xc3, 0x93, 0x90, 0x3f, 0x8e, 0x46, 0x5e, 0x8f, 0x51, 0x36, 0xf4, 0x84, 0x7f, 0xc1,
+	0xd0, 0x12, 0x34, 0xe1, 0xae, 0x7d, 0x0a, 0x2b, 0xd3, 0x72, 0xb1, 0xc0, 0x3e, 0x66, 0x13, 0x6d,
+	0x4d, 0x1c, 0xa2, 0x01, 0x2e, 0xbc, 0xe1, 0xd8, 0x44, 0xa2, 0x02, 0x3e, 0x2e, 0x7c, 0x68, 0xb9,
+	0xbf, 0xb3, 0x60, 0x33, 0x77, 0x0a, 0x6c, 0x81, 0x4f, 0xa2, 0x94, 0x5b, 0x34, 0x84, 0x09, 0xf1,
+	0x24, 0x4a, 0xb7, 0x0e, 0x06, 0x8c, 0x5d, 0x56, 0x4c, 0xb9, 0x4c, 0x4a, 0xe9, 0x0c, 0xc7, 0x03,
+	0x1d, 0xbc, 0x1a, 0x52, 0x52, 0x3a, 0xbd, 0x70, 0xc4, 0x74, 0x6c, 0x1a, 0xd0, 0xfd, 0x8f, 0x05,
+	0xd5, 0xd8, 0xb4, 0x2f, 0x78, 0x92, 0x89, 0x1d, 0x5e, 0x9c, 0x71, 0x78, 0x66, 0x6b, 0x10, 0x75,
+	0x77, 0x20, 0x95, 0x58, 0xa2, 0x72, 0x8c, 0xbb, 0xf6, 0xe8, 0xab, 0x80, 0x71, 0x39, 0x71, 0x59,
+	0x15, 0xb3, 0x18, 0x41, 0xbe, 0x0b, 0xf3, 0xaa, 0x25, 0x58, 0x78, 0x5a, 0x4b, 0xa0, 0x78, 0xf0,
+	0x48, 0xb5, 0x1f, 0xf6, 0x64, 0x3b, 0xea, 0x54, 0x32, 0x29, 0x3d, 0xa6, 0xb9, 0xff, 0x28, 0xe9,
+	0x0e, 0x11, 0x55, 0x47, 0xc3, 0x45, 0xce, 0xb2, 0x3c, 0xf6, 0x29, 0x80, 0xbc, 0x0e, 0x80, 0x83,
+	0x36, 0x67, 0xa7, 0xfe, 0xa5, 0x4c, 0xd4, 0x55, 0x9a, 0xc2, 0xa0, 0x39, 0x0f, 0xfc, 0x20, 0x6e,
+	0x20, 0x8b, 0xd4, 0x80, 0x92, 0xa2, 0xf2, 0x93, 0x36, 0x86, 0x01, 0xf5, 0x37, 0x4d, 0x4f, 0x18,
+	0x8b, 0x18, 0x50, 0x7f, 0x23, 0x29, 0xf3, 0xf1, 0x37, 0x92, 0xe2, 0xc2, 0x52, 0x73, 0xac, 0xce,
+	0x59, 0x92, 0x6c, 0x4b, 0xeb, 0x4c, 0xe1, 0xe2, 0x28, 0x2d, 0x3f, 0x25, 0x4a, 0x6b, 0x50, 0xc1,
+	0xfc, 0x27, 0xb3, 0xba, 0x8a, 0xb5, 0x18, 0xc6, 0xd9, 0xf5, 0x19, 0x57, 0x9a, 0xac, 0x4a, 0x0d,
+	0x88, 0x07, 0x73, 0xc3, 0x75, 0xc4, 0x0d, 0xcf, 0x9a, 0x3a, 0x98, 0x67, 0x08, 0x68, 0xb3, 0x5d,
+	0xce, 0x58, 0x47, 0x70, 0x3f, 0x18, 0x38, 0x55, 0xc9, 0x96, 0xc2, 0xa0, 0x9b, 0xe5, 0x05, 0xa6,
+	0x6c, 0x0f, 0x40, 0xb9, 0x39, 0x46, 0x90, 0x3b, 0x50, 0xb9, 0xcf, 0x42, 0xd5, 0xb5, 0x2f, 0x4a,
+	0xcf, 0xe9, 0x95, 0x18, 0x2c, 0x8d, 0xe9, 0x28, 0x09, 0x7d, 0xd1, 0x64, 0x23, 0x71, 0xe6, 0x2c,
+	0xa9, 0x34, 0x17, 0x23, 0xd0, 0xa3, 0xc7, 0xc7, 0x7b, 0xcd, 0xc8, 0x59, 0x55, 0x1e, 0x95, 0x00,
+	0x06, 0xe9, 0x61, 0x28, 0x9c, 0x15, 0x59, 0xb6, 0x71, 0x88, 0x29, 0x15, 0x03, 0x19, 0x17, 0x21,
+	0xa3, 0xd1, 0x21, 0x2a, 0xa5, 0x4e, 0x21, 0xc9, 0x1d, 0xb0, 0x11, 0xf1, 0x05, 0x46, 0x70, 0xdb,
+	0x13, 0x82, 0xf1, 0xc0, 0x59, 0x97, 0x8c, 0x19, 0xbc, 0xfb, 0x17, 0x2b, 0x59, 0x04, 0x79, 0x0b,
+	0xca, 0x0d, 0x86, 0xd9, 0xd9, 0xb1, 0x66, 0x96, 0xd3, 0x0e, 0xfd, 0x40, 0x50, 0x4d, 0x45, 0xd7,
+	0x34, 0xfd, 0x48, 0x78, 0x41, 0xcf, 0xa4, 0x8b, 0x18, 0x26, 0x5b, 0xb0, 0xd0, 0x0d, 0x47, 0xfb,
+	0xec, 0x54, 0x38, 0xc5, 0x5c, 0x21, 0x86, 0x4c, 0xde, 0x85, 0xc5, 0x7b, 0xa1, 0x10, 0xe1, 0x39,
+	0xf5, 0x07, 0x67, 0xea, 0x5e, 0x20, 0xcb, 0x9d, 0x66, 0x71, 0xb7, 0xa1, 0x62, 0x08, 0x68, 0x9c,
+	0x7d, 0x4f, 0xd5, 0x14, 0x8b, 0xe2, 0x50, 0x62, 0x74, 0xac, 0x23, 0x46, 0x9e, 0xe6, 0x36, 0xd4,
+	0x5d, 0xa5, 0x0a, 0xbb, 0xb8, 0x8f, 0xaa, 0xa9, 0x5b, 0x0c, 0x99, 0x89, 0x54, 0xd6, 0x88, 0x61,
+	0xf7, 0x6f, 0xc5, 0xcc, 0x1d, 0x24, 0xb9, 0xab, 0xb7, 0xab, 0x25, 0xb7, 0xeb, 0xb7, 0x72, 0xc3,
+	0x79, 0x5b, 0xfe, 0xa6, 0xf6, 0xaf, 0x0b, 0x65, 0xd5, 0x3f, 0xe4, 0xdc, 0x3c, 0x69, 0x0a, 0xf2,
+	0x74, 0x3d, 0x3e, 0x60, 0x22, 0xe7, 0x32, 0x45, 0x53, 0xc8, 0x0f, 0xa1, 0x82, 0x5e, 0xeb, 0x63,
+	0x0a, 0x2a, 0xcb, 0xb4, 0xff, 0x46, 0xbe, 0x02, 0x86, 0x4b, 0x55, 0x8e, 0xf8, 0xa3, 0xab, 0x2e,
+	0xd2, 0x70, 0xf3, 0x1f, 0x8d, 0x84, 0x7f, 0xee, 0x47, 0xc2, 0xef, 0xc9, 0x28, 0xae, 0xd0, 0x14,
+	0xa6, 0xf6, 0x09, 0x2c, 0x1b, 0x19, 0xd7, 0x2f, 0x1a, 0x13, 0xa8, 0xc6, 0x06, 0x21, 0x00, 0xe5,
+	0x06, 0x6d, 0xd5, 0xbb, 0x2d, 0x7b, 0x8e, 0x54, 0xa0, 0x44, 0x5b, 0xf5, 0xa6, 0x6d, 0x91, 0x55,
+	0x58, 0x3c, 0x6e, 0x37, 0xeb, 0xdd, 0xd6, 0x97, 0xed, 0x7a, 0xf7, 0x81, 0x5d, 0x20, 0x04, 0x56,
+	0x34, 0xa2, 0x71, 0x74, 0xd8, 0x6d, 0x1d, 0x76, 0xed, 0x62, 0x8a, 0xe9, 0xa0, 0xd5, 0xad, 0xdb,
+	0x25, 0xb2, 0x01, 0xb6, 0x46, 0x1c, 0x77, 0x5a, 0x54, 0x61, 0xcb, 0x38, 0x43, 0xb3, 0xb5, 0xdf,
+	0xea, 0xb6, 0xec, 0x79, 0xf7, 0xcf, 0x16, 0x80, 0x3c, 0xe2, 0x2b, 0xe7, 0xbd, 0x09, 0xcb, 0xf2,
+	0x0e, 0xb8, 0xc9, 0x84, 0xbc, 0x70, 0xd2, 0x3d, 0xfa, 0x34, 0x12, 0x3b, 0xb7, 0x99, 0x4e, 0x52,
+	0x2d, 0x69, 0x06, 0x2b, 0x33, 0x02, 0x7e, 0x98, 0xaa, 0x62, 0x09, 0x02, 0xb3, 0x8f, 0xbe, 0x49,
+	0xd8, 0x0d, 0x79, 0x8f, 0xc9, 0x5b, 0x09, 0x5d, 0xd5, 0xb2, 0x04, 0xf7, 0x6b, 0x0b, 0x6e, 0xde,
+	0x67, 0xa2, 0x15, 0xf4, 0xf8, 0x44, 0x16, 0xa5, 0x87, 0x6c, 0x62, 0xb6, 0x28, 0x16, 0xb5, 0x88,
+	0xf1, 0xb8, 0xa8, 0x45, 0x2a, 0xec, 0xda, 0x5e, 0x14, 0x7d, 0x15, 0x72, 0x73, 0x80, 0x8a, 0xe1,
+	0xf8, 0x98, 0x53, 0xbc, 0xe2, 0x98, 0x83, 0xb7, 0x55, 0xb2, 0x91, 0xd4, 0x8e, 0xd6, 0x90, 0xfb,
+	0x0e, 0x38, 0x59, 0x15, 0x74, 0xff, 0x6f, 0x43, 0xf1, 0xa1, 0xf6, 0xf7, 0x12, 0xc5, 0xa1, 0xfb,
+	0xab, 0x02, 0x40, 0x67, 0x12, 0xf4, 0xd4, 0xb6, 0x43, 0x86, 0x88, 0x3d, 0x91, 0x0c, 0x25, 0x8a,
+	0x43, 0x72, 0x13, 0xca, 0x41, 0xd8, 0x67, 0xf1, 0x09, 0x6f, 0x01, 0xa1, 0x2f, 0xfd, 0x3e, 0x79,
+	0x1b, 0x4a, 0x22, 0xe9, 0xcb, 0x74, 0x45, 0x4c, 0x44, 0x6d, 0xab, 0xc0, 0x41, 0x16, 0x54, 0x35,
+	0x52, 0x81, 0xa3, 0xfb, 0x01, 0x05, 0x21, 0x5e, 0xa8, 0x60, 0x51, 0xed, 0x80, 0x86, 0xc8, 0x16,
+	0x94, 0x02, 0xd3, 0xa4, 0x2d, 0xee, 0x6c, 0xcc, 0x8a, 0x56, 0x46, 0x40, 0x0e, 0xf7, 0x9e, 0x8a,
+	0x63, 0xb2, 0x08, 0x0b, 0xe3, 0xe0, 0x71, 0x10, 0x7e, 0x15, 0xd8, 0x73, 0xb8, 0x75, 0x7a, 0xd2,
+	0x16, 0xb6, 0x85, 0xe3, 0xbe, 0xec, 0x6e, 0xed, 0x02, 0x6e, 0xd4, 0x91, 0x27, 0xce, 0xec, 0x22,
+	0xb2, 0xf7, 0x54, 0xc1, 0xb0, 0x4b, 0xb8, 0xbb, 0x56, 0xa6, 0x85, 0xa3, 0x5f, 0x1e, 0x4d, 0x04,
+	0x8b, 0xb0, 0x80, 0x5a, 0xb2, 0x18, 0xc6, 0x30, 0x9a, 0xe8, 0xbc, 0xff, 0xbe, 0xb6, 0x06, 0x0e,
+	0x31, 0x66, 0xce, 0x45, 0xaa, 0xf1, 0x90, 0x00, 0xb9, 0x05, 0x15, 0x54, 0x51, 0x6e, 0x2b, 0xb5,
+	0xec, 0xaa, 0x34, 0x1d, 0xaa, 0x40, 0xee, 0xc2, 0x06, 0x67, 0xa3, 0x30, 0xf2, 0x45, 0xc8, 0x27,
+	0x7b, 0x7d, 0x16, 0x08, 0xff, 0xd4, 0x67, 0x5c, 0xdb, 0x61, 0x33, 0xa1, 0x7d, 0xe9, 0xc7, 0x44,
+	0xb7, 0x01, 0x9b, 0xed, 0xb1, 0x48, 0x54, 0x4d, 0x1f, 0x57, 0xa3, 0xe9, 0xe3, 0xaa, 0x06, 0xa5,
+	0xb2, 0xd1, 0x20, 0x56, 0x36, 0x1a, 0xb8, 0xbf, 0x84, 0x9b, 0xea, 0xc6, 0x24, 0x2d, 0x47, 0xed,
+	0xd0, 0xac, 0xf3, 0x1d, 0x58, 0x38, 0x1d, 0x7a, 0x42, 0xb0, 0x40, 0x1f, 0x35, 0x0d, 0x88, 0xae,
+	0x1b, 0xa9, 0xbe, 0x44, 0x85, 0x8c, 0x86, 0xb0, 0x4d, 0x1b, 0x7a, 0x91, 0xe8, 0xb0, 0x27, 0x47,
+	0xc1, 0x70, 0x62, 0xde, 0x03, 0x53, 0xa8, 0x3b, 0x0f, 0xe0, 0xb5, 0xa7, 0x1e, 0x49, 0xd0, 0x39,
+	0x78, 0xe6, 0xa8, 0x0f, 0x87, 0xf6, 0x1c, 0x59, 0x82, 0x0a, 0x02, 0xfb, 0x5e, 0x24, 0x6c, 0xcb,
+	0x40, 0x87, 0x61, 0xc0, 0xec, 0xc2, 0x9d, 0xf7, 0xa0, 0x62, 0x3a, 0x0c, 0xfc, 0xe8, 0xf8, 0xf0,
+	0xe1, 0xe1, 0xd1, 0xc9, 0xa1, 0xca, 0x48, 0xfb, 0xad, 0xfa, 0xae, 0x6d, 0x91, 0x15, 0x80, 0xc6,
+	0xd1, 0xfe, 0x7e, 0xab, 0xd1, 0xdd, 0x3b, 0x3a, 0xb4, 0x0b, 0x3b, 0xbf, 0xb5, 0x60, 0x09, 0xbf,
+	0x69, 0xf3, 0xf0, 0xc2, 0xef, 0x33, 0x4e, 0x3e, 0x81, 0x8a, 0x79, 0x9d, 0x24, 0x7a, 0x0f, 0xcf,
+	0x3c, 0xa9, 0xd6, 0x6e, 0xcc, 0xa2, 0x95, 0xd5, 0xdd, 0x39, 0xf2, 0x19, 0x54, 0xe3, 0x17, 0x2f,
+	0x72, 0x23, 0xf3, 0x2e, 0xa6, 0x3e, 0xbf, 0xea, 0xbd, 0xcc, 0x9d, 0x7b, 0xd7, 0xda, 0xf9, 0x29,
+	0x6c, 0xa4, 0xd5, 0x31, 0xef, 0x70, 0xa4, 0x05, 0x2b, 0x66, 0x3e, 0x85, 0xbb, 0xb6, 0x72, 0x5b,
+	0x96, 0x14, 0xbf, 0x9e, 0xd4, 0x94, 0x28, 0x96, 0xbe, 0x0b, 0xcb, 0x53, 0x55, 0x94, 0xe8, 0x03,
+	0x67, 0x5e, 0x69, 0xad, 0xe5, 0xf7, 0xba, 0x52, 0xfb, 0x7f, 0x6a, 0x6b, 0x52, 0xd6, 0x63, 0xfe,
+	0x05, 0xe3, 0xa4, 0x0e, 0x90, 0xbc, 0x73, 0x11, 0xbd, 0xf2, 0xcc, 0xeb, 0x5c, 0xcd, 0xc9, 0x12,
+	0x62, 0x9b, 0xd6, 0x01, 0x92, 0xc7, 0x20, 0x23, 0x22, 0xf3, 0x6a, 0x55, 0x73, 0xb2, 0x84, 0xb4,
+	0x88, 0xe4, 0x11, 0xc6, 0x88, 0xc8, 0xbc, 0x08, 0xd5, 0x9c, 0x2c, 0xc1, 0x88, 0xd8, 0xf9, 0x9f,
+	0x05, 0x24, 0xbd, 0x32, 0xed, 0x84, 0x87, 0x60, 0x27, 0x4a, 0x6b, 0xdc, 0x8b, 0xac, 0x12, 0x9d,
+	0x83, 0xc2, 0x12, 0xf5, 0xa7, 0x85, 0x5d, 0x6b, 0xbd, 0x46, 0x58, 0xb2, 0x90, 0x69, 0x61, 0xd7,
+	0x5a, 0xb9, 0xdc, 0x36, 0xff, 0xc2, 0x8c, 0xa8, 0x8a, 0x9b, 0x2c, 0xbb, 0x8c, 0x93, 0x26, 0x2c,
+	0xa6, 0x9e, 0x2e, 0x88, 0x96, 0x90, 0x7d, 0x16, 0xa9, 0xbd, 0x92, 0x43, 0x89, 0x3d, 0x73, 0x1f,
+	0x96, 0xd2, 0x8f, 0x0d, 0x44, 0x33, 0xe7, 0x3c, 0x65, 0xd4, 0x6a, 0x79, 0xa4, 0xb4, 0xa0, 0xf4,
+	0x03, 0x81, 0x11, 0x94, 0xf3, 0xfc, 0x50, 0xab, 0xe5, 0x91, 0x62, 0x47, 0x7f, 0xa1, 0xfc, 0x2c,
+	0xf7, 0x74, 0x14, 0x67, 0x85, 0xcf, 0xa0, 0x1a, 0x3f, 0x00, 0x98, 0xc0, 0x9e, 0x7d, 0x45, 0xa8,
+	0xdd, 0xcc, 0xe0, 0x53, 0x81, 0xdd, 0x80, 0x8a, 0x4a, 0xb3, 0x8c, 0x93, 0x0f, 0xa0, 0xac, 0xc6,
+	0x64, 0x3d, 0x7d, 0x65, 0x6d, 0xe4, 0x6c, 0x4c, 0x23, 0x53, 0x42, 0xd6, 0x61, 0x4d, 0x86, 0x9d,
+	0x2a, 0x55, 0x18, 0xe3, 0x8c, 0xcf, 0x20, 0x4f, 0xb8, 0x2f, 0x18, 0xdf, 0xf9, 0xba, 0x08, 0xcb,
+	0x88, 0xd5, 0x99, 0x95, 0x71, 0xf2, 0x39, 0x2c, 0x4f, 0x5d, 0x48, 0x9b, 0x18, 0xcf, 0xbb, 0x18,
+	0xaf, 0xdd, 0xca, 0xa5, 0xa5, 0xad, 0x9d, 0xbe, 0x26, 0x35, 0xd6, 0xce, 0xb9, 0x9c, 0xad, 0xd5,
+	0xf2, 0x48, 0xb1, 0xa0, 0x3d, 0x58, 0x4a, 0x5f, 0x55, 0x1b, 0x41, 0x39, 0xb7, 0xde, 0xb5, 0x5a,
+	0x1e, 0x29, 0xb1, 0x0d, 0x6e, 0xc8, 0xd4, 0xf5, 0xb2, 0xd9, 0x90, 0xd9, 0x5b, 0xec, 0xda, 0x2b,
+	0x39, 0x94, 0x58, 0xa1, 0xcf, 0x67, 0xae, 0x73, 0x8d, 0x95, 0xf2, 0x2e, 0x6b, 0x6b, 0xb7, 0x72,
+	0x69, 0xf1, 0x56, 0x62, 0xb0, 0x82, 0xe7, 0xd1, 0x87, 0x6c, 0x72, 0xe0, 0x05, 0xde, 0x80, 0x71,
+	0xd2, 0x01, 0x7b, 0xb6, 0x15, 0x23, 0xaf, 0x99, 0xe3, 0x50, 0x6e, 0x97, 0x58, 0x7b, 0xfd, 0x2a,
+	0x72, 0x3c, 0xcd, 0x6f, 0xf0, 0xc5, 0x27, 0xae, 0xdd, 0x11, 0xf9, 0x10, 0x8a, 0xed, 0xb1, 0x20,
+	0xf6, 0x6c, 0x97, 0x14, 0xab, 0x9b, 0xd7, 0x32, 0x60, 0xa0, 0x93, 0x1f, 0xc4, 0xfb, 0xf2, 0xb5,
+	0xf4, 0x16, 0xcc, 0x34, 0x06, 0xb5, 0x8c, 0x6c, 0xf4, 0xc0, 0xa3, 0xb2, 0xfc, 0x2f, 0xd2, 0xdd,
+	0xff, 0x0f, 0x00, 0x6e, 0x03, 0xb8, 0x78, 0x99, 0x24, 0x00, 0x00,
 }
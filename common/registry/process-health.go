@@ -0,0 +1,38 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package registry
+
+import (
+	"net"
+	"strconv"
+)
+
+// HealthTargets lists the health endpoints of the given processes, indexed by process id.
+func HealthTargets(processes map[string]*Process) map[string]string {
+	targets := make(map[string]string, len(processes))
+	for id, p := range processes {
+		if p.HealthPort == 0 {
+			continue
+		}
+		targets[id] = net.JoinHostPort(p.host(), strconv.Itoa(p.HealthPort))
+	}
+	return targets
+}
@@ -0,0 +1,84 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package registry
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+)
+
+// MetricsTargetGroup is an entry of the Prometheus HTTP service discovery format.
+type MetricsTargetGroup struct {
+	Targets []string          `json:"targets"`
+	Labels  map[string]string `json:"labels"`
+}
+
+// MetricsTargets lists the metrics endpoints of all processes, labelled with their registry metadata.
+func MetricsTargets(processes map[string]*Process) []*MetricsTargetGroup {
+	groups := []*MetricsTargetGroup{}
+	for _, p := range processes {
+		if p.MetricsPort == 0 {
+			continue
+		}
+		labels := map[string]string{
+			"pid":       p.Id,
+			"peer":      p.PeerAddress,
+			"start_tag": p.StartTag,
+		}
+		if p.ParentId != "" {
+			labels["parent_pid"] = p.ParentId
+		}
+		if p.Hostname != "" {
+			labels["hostname"] = p.Hostname
+		}
+		groups = append(groups, &MetricsTargetGroup{
+			Targets: []string{net.JoinHostPort(p.host(), strconv.Itoa(p.MetricsPort))},
+			Labels:  labels,
+		})
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].Targets[0] < groups[j].Targets[0]
+	})
+	return groups
+}
+
+// host returns the address where the endpoints exposed by the process can be reached.
+func (p *Process) host() string {
+	host := p.PeerAddress
+	if h, _, e := net.SplitHostPort(host); e == nil {
+		host = h
+	}
+	if host == "" || host == "0.0.0.0" || host == "::" {
+		host = "localhost"
+	}
+	return host
+}
+
+// MetricsDiscoveryHandler serves the metrics targets of the cluster for a Prometheus http_sd_config.
+func MetricsDiscoveryHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(MetricsTargets(GetProcesses()))
+	})
+}
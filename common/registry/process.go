@@ -41,9 +41,12 @@ type Process struct {
 	Id          string
 	ParentId    string
 	MetricsPort int
+	HealthPort  int
 	PeerId      string
 	PeerAddress string
 	StartTag    string
+	Hostname    string
+	Version     string
 
 	sLock    *sync.RWMutex
 	Services map[string]string
@@ -70,9 +73,20 @@ func NewProcess(node *registry.Node) *Process {
 			process.MetricsPort = int(met)
 		}
 	}
+	if port, ok := node.Metadata[serviceMetaHealth]; ok {
+		if h, e := strconv.ParseInt(port, 10, 32); e == nil {
+			process.HealthPort = int(h)
+		}
+	}
 	if start, ok := node.Metadata[serviceMetaStartTag]; ok {
 		process.StartTag = start
 	}
+	if h, ok := node.Metadata[serviceMetaHostname]; ok {
+		process.Hostname = h
+	}
+	if v, ok := node.Metadata[serviceMetaVersion]; ok {
+		process.Version = v
+	}
 
 	return process
 }
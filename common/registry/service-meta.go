@@ -24,6 +24,8 @@ import (
 	"os"
 	"strings"
 
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/service/health"
 	"github.com/pydio/cells/common/service/metrics"
 )
 
@@ -33,6 +35,8 @@ const (
 	serviceMetaMetrics   = "metrics"
 	serviceMetaStartTag  = "start"
 	serviceMetaHostname  = "hostname"
+	serviceMetaHealth    = "health"
+	serviceMetaVersion   = "version"
 )
 
 func BuildServiceMeta() map[string]string {
@@ -41,6 +45,8 @@ func BuildServiceMeta() map[string]string {
 		serviceMetaParentPID: fmt.Sprintf("%d", os.Getppid()),
 		serviceMetaMetrics:   fmt.Sprintf("%d", metrics.GetExposedPort()),
 		serviceMetaStartTag:  strings.Join(ProcessStartTags, ","),
+		serviceMetaHealth:    fmt.Sprintf("%d", health.GetExposedPort()),
+		serviceMetaVersion:   common.Version().String(),
 	}
 	if h, e := os.Hostname(); e == nil {
 		meta[serviceMetaHostname] = h
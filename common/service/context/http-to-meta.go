@@ -22,9 +22,11 @@ package servicecontext
 
 import (
 	"context"
+	"fmt"
 	"net"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/micro/go-micro/metadata"
@@ -49,6 +51,84 @@ const (
 	CtxWorkspaceUuid      = "CtxWorkspaceUuid"
 )
 
+var (
+	trustedProxies     []*net.IPNet
+	trustedProxiesLock sync.RWMutex
+)
+
+// SetTrustedProxies registers the addresses (IPs or CIDR ranges) of the reverse proxies sitting in front
+// of Cells. Their X-Forwarded-For header is used to find the client address.
+func SetTrustedProxies(proxies []string) error {
+	var nets []*net.IPNet
+	for _, p := range proxies {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+		if !strings.Contains(p, "/") {
+			ip := net.ParseIP(p)
+			if ip == nil {
+				return fmt.Errorf("invalid trusted proxy address %s", p)
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+			nets = append(nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, n, e := net.ParseCIDR(p)
+		if e != nil {
+			return fmt.Errorf("invalid trusted proxy range %s: %v", p, e)
+		}
+		nets = append(nets, n)
+	}
+	trustedProxiesLock.Lock()
+	trustedProxies = nets
+	trustedProxiesLock.Unlock()
+	return nil
+}
+
+func isTrustedProxy(addr string) bool {
+	if h, _, e := net.SplitHostPort(addr); e == nil {
+		addr = h
+	}
+	ip := net.ParseIP(strings.TrimSpace(addr))
+	if ip == nil {
+		return false
+	}
+	trustedProxiesLock.RLock()
+	defer trustedProxiesLock.RUnlock()
+	for _, n := range trustedProxies {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// ClientAddress finds the address of the client that sent the request, see ResolveClientAddress.
+func ClientAddress(req *http.Request) string {
+	return ResolveClientAddress(req.RemoteAddr, req.Header.Get("X-Real-Ip"), strings.Join(req.Header["X-Forwarded-For"], ","))
+}
+
+// ResolveClientAddress finds the client address from the connection address and the X-Real-IP and X-Forwarded-For
+// headers. It is the X-Real-IP header set by the internal proxy, or the connection address. X-Forwarded-For is only read,
+// from the last hop, as long as the address found so far is a trusted proxy: its first entries are set by the client.
+func ResolveClientAddress(remoteAddr, realIP, forwardedFor string) string {
+	addr := remoteAddr
+	if real := strings.TrimSpace(realIP); real != "" {
+		addr = real
+	}
+	forwarded := strings.Split(forwardedFor, ",")
+	for i := len(forwarded) - 1; i >= 0 && isTrustedProxy(addr); i-- {
+		if f := strings.TrimSpace(forwarded[i]); f != "" {
+			addr = f
+		}
+	}
+	return addr
+}
+
 // HttpRequestInfoToMetadata extracts as much HTTP metadata as possible and stores it in the context as metadata.
 func HttpRequestInfoToMetadata(ctx context.Context, req *http.Request) context.Context {
 
@@ -75,11 +155,8 @@ func HttpRequestInfoToMetadata(ctx context.Context, req *http.Request) context.C
 		meta[HttpMetaPort] = p
 	}
 	// We might want to also support new standard "Forwarded" header.
-	if h, ok := req.Header["X-Forwarded-For"]; ok {
-		ips := strings.Split(strings.Join(h, ""), ",")
-		meta[HttpMetaRemoteAddress] = ips[0]
-	} else if req.RemoteAddr != "" {
-		meta[HttpMetaRemoteAddress] = req.RemoteAddr
+	if addr := ClientAddress(req); addr != "" {
+		meta[HttpMetaRemoteAddress] = addr
 	}
 
 	if h, ok := req.Header["User-Agent"]; ok {
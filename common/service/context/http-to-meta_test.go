@@ -0,0 +1,48 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package servicecontext
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestResolveClientAddress(t *testing.T) {
+
+	Convey("Test client address without trusted proxies", t, func() {
+		So(SetTrustedProxies(nil), ShouldBeNil)
+		So(ResolveClientAddress("10.0.0.1:4567", "", ""), ShouldEqual, "10.0.0.1:4567")
+		So(ResolveClientAddress("127.0.0.1:4567", "192.168.1.10", "1.2.3.4"), ShouldEqual, "192.168.1.10")
+		So(ResolveClientAddress("127.0.0.1:4567", "", "1.2.3.4"), ShouldEqual, "127.0.0.1:4567")
+	})
+
+	Convey("Test client address behind trusted proxies", t, func() {
+		So(SetTrustedProxies([]string{"192.168.1.10", "10.1.0.0/16"}), ShouldBeNil)
+		defer SetTrustedProxies(nil)
+		So(ResolveClientAddress("127.0.0.1:4567", "192.168.1.10", "5.6.7.8"), ShouldEqual, "5.6.7.8")
+		So(ResolveClientAddress("127.0.0.1:4567", "192.168.1.10", "1.2.3.4, 5.6.7.8, 10.1.2.3"), ShouldEqual, "5.6.7.8")
+		So(ResolveClientAddress("127.0.0.1:4567", "192.168.1.11", "5.6.7.8"), ShouldEqual, "192.168.1.11")
+		So(ResolveClientAddress("10.1.2.3:4567", "", "5.6.7.8"), ShouldEqual, "5.6.7.8")
+		So(SetTrustedProxies([]string{"not-an-ip"}), ShouldNotBeNil)
+	})
+
+}
@@ -3,6 +3,7 @@ package servicecontext
 import (
 	"context"
 	"net/http"
+	"time"
 
 	"github.com/micro/go-micro"
 	"github.com/micro/go-micro/server"
@@ -32,7 +33,17 @@ func wrapperByName(name string) server.HandlerWrapper {
 			scope.Counter("grpc_calls").Inc(1)
 			tsw := scope.Timer("grpc_time").Start()
 			defer tsw.Stop()
-			return fn(ctx, req, rsp)
+			start := time.Now()
+			err := fn(ctx, req, rsp)
+			status := "ok"
+			if err != nil {
+				status = "error"
+			}
+			scope.Tagged(map[string]string{
+				"method": req.Method(),
+				"status": status,
+			}).Timer("grpc_request_duration").Record(time.Since(start))
+			return err
 		}
 	}
 }
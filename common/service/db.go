@@ -31,6 +31,7 @@ import (
 	"github.com/pydio/cells/common/dao"
 	"github.com/pydio/cells/common/log"
 	servicecontext "github.com/pydio/cells/common/service/context"
+	"github.com/pydio/cells/common/service/health"
 )
 
 func newDBProvider(service micro.Service) error {
@@ -47,6 +48,8 @@ func newDBProvider(service micro.Service) error {
 			return nil
 		}
 
+		health.Register(servicecontext.GetServiceName(ctx), "dao", newDAOHealthChecker(d))
+
 		if err := d.Init(cfg); err != nil {
 			log.Logger(ctx).Error("Failed to init DB provider", zap.Error(err))
 			return err
@@ -0,0 +1,68 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package service
+
+import (
+	"context"
+	sqldb "database/sql"
+	"fmt"
+
+	bolt "github.com/etcd-io/bbolt"
+	"github.com/micro/go-micro"
+
+	"github.com/pydio/cells/common/boltdb"
+	"github.com/pydio/cells/common/dao"
+	servicecontext "github.com/pydio/cells/common/service/context"
+	"github.com/pydio/cells/common/service/health"
+	"github.com/pydio/cells/common/sql"
+)
+
+// newHealthProvider flags the service as ready in the health report once it is started.
+func newHealthProvider(service micro.Service) {
+	name := servicecontext.GetServiceName(service.Options().Context)
+	health.SetReady(name, false)
+	service.Init(
+		micro.AfterStart(func() error {
+			health.SetReady(name, true)
+			return nil
+		}),
+		micro.BeforeStop(func() error {
+			health.SetReady(name, false)
+			return nil
+		}),
+	)
+}
+
+// newDAOHealthChecker checks that the connection used by a DAO is actually usable: SQL databases
+// are pinged and their migrations verified, BoltDB files must be present and readable.
+func newDAOHealthChecker(d dao.DAO) health.HealthChecker {
+	return health.CheckerFunc(func(ctx context.Context) error {
+		switch conn := d.GetConn().(type) {
+		case *sqldb.DB:
+			return sql.CheckHealth(ctx, conn)
+		case *bolt.DB:
+			return boltdb.CheckHealth(conn)
+		case nil:
+			return fmt.Errorf("no connection to %s database", d.Driver())
+		}
+		return nil
+	})
+}
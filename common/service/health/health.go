@@ -0,0 +1,116 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+// Package health provides deep health and readiness checks for the services running in a process.
+//
+// Services (or the components they rely on) register HealthChecker implementations, which are
+// run on demand and aggregated into a report exposed on the /healthz and /readyz routes.
+package health
+
+import (
+	"context"
+	"sort"
+	"sync"
+)
+
+// ProcessScope is used to register checks that are not attached to a specific service
+// but to the process itself, like the broker connection. They apply to all services of the process.
+const ProcessScope = ""
+
+// HealthChecker is implemented by components that are able to verify that they are actually usable.
+type HealthChecker interface {
+	CheckHealth(ctx context.Context) error
+}
+
+// CheckerFunc is an adapter to use an ordinary function as a HealthChecker.
+type CheckerFunc func(ctx context.Context) error
+
+// CheckHealth implements HealthChecker.
+func (f CheckerFunc) CheckHealth(ctx context.Context) error {
+	return f(ctx)
+}
+
+var (
+	lock     sync.RWMutex
+	checkers = map[string]map[string]HealthChecker{}
+	ready    = map[string]bool{}
+	draining bool
+)
+
+// Register attaches a named checker to a service, replacing any checker registered with the same name.
+func Register(service, name string, checker HealthChecker) {
+	lock.Lock()
+	defer lock.Unlock()
+	if _, ok := checkers[service]; !ok {
+		checkers[service] = map[string]HealthChecker{}
+	}
+	checkers[service][name] = checker
+	if _, ok := ready[service]; !ok && service != ProcessScope {
+		ready[service] = false
+	}
+}
+
+// Unregister removes a service and all its checkers from the report.
+func Unregister(service string) {
+	lock.Lock()
+	defer lock.Unlock()
+	delete(checkers, service)
+	delete(ready, service)
+}
+
+// SetReady flags a service as started (or stopped) in the current process.
+func SetReady(service string, r bool) {
+	lock.Lock()
+	defer lock.Unlock()
+	ready[service] = r
+}
+
+// SetDraining flags the whole process as not ready, while it is still running: load balancers using
+// the readiness route stop sending it new requests before it is stopped.
+func SetDraining(d bool) {
+	lock.Lock()
+	defer lock.Unlock()
+	draining = d
+}
+
+type namedChecker struct {
+	name    string
+	checker HealthChecker
+}
+
+// snapshot returns a copy of the registered checkers and of the services readiness, and the draining flag.
+func snapshot() (map[string][]namedChecker, map[string]bool, bool) {
+	lock.RLock()
+	defer lock.RUnlock()
+	cc := make(map[string][]namedChecker, len(checkers))
+	for service, named := range checkers {
+		for name, c := range named {
+			cc[service] = append(cc[service], namedChecker{name: name, checker: c})
+		}
+		sort.Slice(cc[service], func(i, j int) bool {
+			return cc[service][i].name < cc[service][j].name
+		})
+	}
+	rr := make(map[string]bool, len(ready))
+	for k, v := range ready {
+		rr[k] = v
+	}
+	return cc, rr, draining
+}
@@ -0,0 +1,162 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package health
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func reset() {
+	lock.Lock()
+	defer lock.Unlock()
+	checkers = map[string]map[string]HealthChecker{}
+	ready = map[string]bool{}
+	draining = false
+}
+
+func okChecker(context.Context) error { return nil }
+
+func TestReport(t *testing.T) {
+
+	Convey("Test report aggregates services and process checks", t, func() {
+		reset()
+		Register("pydio.grpc.user", "dao", CheckerFunc(okChecker))
+		Register("pydio.grpc.data.sync.pydiods1", "objects", CheckerFunc(func(context.Context) error {
+			return fmt.Errorf("bucket not found")
+		}))
+		SetReady("pydio.grpc.user", true)
+		SetReady("pydio.grpc.data.sync.pydiods1", true)
+		SetReady("pydio.rest.user", false)
+
+		r := Report(context.Background(), time.Second)
+		So(r.Services, ShouldHaveLength, 3)
+		So(r.Healthy, ShouldBeFalse)
+		So(r.Ready, ShouldBeFalse)
+
+		sync := r.Services[0]
+		So(sync.Name, ShouldEqual, "pydio.grpc.data.sync.pydiods1")
+		So(sync.Healthy, ShouldBeFalse)
+		So(sync.Ready, ShouldBeFalse)
+		So(sync.Checks[0].Error, ShouldEqual, "bucket not found")
+
+		user := r.Services[1]
+		So(user.Healthy, ShouldBeTrue)
+		So(user.Ready, ShouldBeTrue)
+
+		rest := r.Services[2]
+		So(rest.Healthy, ShouldBeTrue)
+		So(rest.Ready, ShouldBeFalse)
+
+		Unregister("pydio.grpc.data.sync.pydiods1")
+		SetReady("pydio.rest.user", true)
+		r = Report(context.Background(), time.Second)
+		So(r.Healthy, ShouldBeTrue)
+		So(r.Ready, ShouldBeTrue)
+	})
+
+	Convey("Test process checks apply to all services", t, func() {
+		reset()
+		SetReady("pydio.grpc.user", true)
+		Register(ProcessScope, "broker", CheckerFunc(func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}))
+
+		r := Report(context.Background(), 50*time.Millisecond)
+		So(r.Checks, ShouldHaveLength, 1)
+		So(r.Checks[0].Healthy, ShouldBeFalse)
+		So(r.Services, ShouldHaveLength, 1)
+		So(r.Services[0].Healthy, ShouldBeFalse)
+		So(r.Healthy, ShouldBeFalse)
+	})
+
+	Convey("Test draining process is not ready", t, func() {
+		reset()
+		SetReady("pydio.grpc.user", true)
+		SetDraining(true)
+		r := Report(context.Background(), time.Second)
+		So(r.Healthy, ShouldBeTrue)
+		So(r.Draining, ShouldBeTrue)
+		So(r.Ready, ShouldBeFalse)
+		SetDraining(false)
+		So(Report(context.Background(), time.Second).Ready, ShouldBeTrue)
+	})
+
+	Convey("Test panicking checks are reported as failures", t, func() {
+		reset()
+		Register("pydio.grpc.user", "dao", CheckerFunc(func(context.Context) error {
+			panic("nil connection")
+		}))
+		r := Report(context.Background(), time.Second)
+		So(r.Services[0].Checks[0].Error, ShouldContainSubstring, "nil connection")
+	})
+
+}
+
+func TestHandlers(t *testing.T) {
+
+	Convey("Test routes status and cluster collection", t, func() {
+		reset()
+		Register("pydio.grpc.user", "dao", CheckerFunc(okChecker))
+
+		srv := httptest.NewServer(Handler())
+		defer srv.Close()
+
+		resp, e := http.Get(srv.URL + HealthzRoute)
+		So(e, ShouldBeNil)
+		So(resp.StatusCode, ShouldEqual, http.StatusOK)
+		resp, e = http.Get(srv.URL + ReadyzRoute)
+		So(e, ShouldBeNil)
+		So(resp.StatusCode, ShouldEqual, http.StatusServiceUnavailable)
+
+		SetReady("pydio.grpc.user", true)
+		resp, e = http.Get(srv.URL + ReadyzRoute)
+		So(e, ShouldBeNil)
+		So(resp.StatusCode, ShouldEqual, http.StatusOK)
+
+		cluster := Collect(context.Background(), map[string]string{
+			"100": strings.TrimPrefix(srv.URL, "http://"),
+			"200": "127.0.0.1:1",
+		}, time.Second)
+		So(cluster.Processes, ShouldHaveLength, 2)
+		byPid := map[string]*ProcessReport{}
+		for _, p := range cluster.Processes {
+			byPid[p.PID] = p
+		}
+		local := byPid[fmt.Sprintf("%d", os.Getpid())]
+		So(local, ShouldNotBeNil)
+		So(local.Healthy, ShouldBeTrue)
+		So(local.Services[0].Name, ShouldEqual, "pydio.grpc.user")
+		So(byPid["200"].Error, ShouldNotBeEmpty)
+		So(cluster.Healthy, ShouldBeFalse)
+		So(cluster.Ready, ShouldBeFalse)
+	})
+
+}
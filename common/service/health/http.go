@@ -0,0 +1,155 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// HealthzRoute reports whether all checks of the process pass.
+	HealthzRoute = "/healthz"
+	// ReadyzRoute reports whether all services of the process are started and healthy.
+	ReadyzRoute = "/readyz"
+)
+
+var port int
+
+// Serve exposes the health routes of the current process on the given host and port, or on a random free
+// port if port is 0. The actual port is published in the registry metadata of each service.
+func Serve(host string, p int) error {
+	l, e := net.Listen("tcp", net.JoinHostPort(host, fmt.Sprintf("%d", p)))
+	if e != nil {
+		return e
+	}
+	port = l.Addr().(*net.TCPAddr).Port
+	go http.Serve(l, Handler())
+	return nil
+}
+
+// GetExposedPort returns the port serving the health routes, or 0 if they are not exposed.
+func GetExposedPort() int {
+	return port
+}
+
+// Handler serves the report of the current process on the health routes. An optional
+// timeout query parameter (e.g. "2s") limits the duration of each check.
+func Handler() http.Handler {
+	mux := http.NewServeMux()
+	serve := func(readiness bool) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			timeout, _ := time.ParseDuration(r.URL.Query().Get("timeout"))
+			report := Report(r.Context(), timeout)
+			ok := report.Healthy
+			if readiness {
+				ok = report.Ready
+			}
+			WriteJSON(w, report, ok)
+		}
+	}
+	mux.Handle(HealthzRoute, serve(false))
+	mux.Handle(ReadyzRoute, serve(true))
+	return mux
+}
+
+// WriteJSON writes a report with status 200 if ok, or 503 otherwise.
+func WriteJSON(w http.ResponseWriter, report interface{}, ok bool) {
+	w.Header().Set("Content-Type", "application/json")
+	if ok {
+		w.WriteHeader(http.StatusOK)
+	} else {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	_ = json.NewEncoder(w).Encode(report)
+}
+
+// Fetch retrieves the report of a remote process from its health address (host:port).
+func Fetch(ctx context.Context, address string, timeout time.Duration) (*ProcessReport, error) {
+	u := "http://" + address + HealthzRoute
+	if timeout > 0 {
+		u += "?timeout=" + timeout.String()
+	}
+	req, e := http.NewRequest(http.MethodGet, u, nil)
+	if e != nil {
+		return nil, e
+	}
+	resp, e := http.DefaultClient.Do(req.WithContext(ctx))
+	if e != nil {
+		return nil, e
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusServiceUnavailable {
+		return nil, fmt.Errorf("unexpected status %s from %s", resp.Status, address)
+	}
+	report := &ProcessReport{}
+	if e := json.NewDecoder(resp.Body).Decode(report); e != nil {
+		return nil, e
+	}
+	return report, nil
+}
+
+// ClusterReport aggregates the reports of several processes.
+type ClusterReport struct {
+	Ready     bool             `json:"ready"`
+	Healthy   bool             `json:"healthy"`
+	Processes []*ProcessReport `json:"processes"`
+}
+
+// Collect fetches concurrently the reports of the processes listed in targets, a map of
+// process ids to health addresses. Unreachable processes are reported as unhealthy.
+func Collect(ctx context.Context, targets map[string]string, timeout time.Duration) *ClusterReport {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	report := &ClusterReport{Ready: true, Healthy: true, Processes: []*ProcessReport{}}
+	wg := &sync.WaitGroup{}
+	mu := &sync.Mutex{}
+	for pid, address := range targets {
+		wg.Add(1)
+		go func(pid, address string) {
+			defer wg.Done()
+			// Leave some room for the remote checks to time out by themselves
+			ct, cancel := context.WithTimeout(ctx, timeout+time.Second)
+			defer cancel()
+			r, e := Fetch(ct, address, timeout)
+			if e != nil {
+				r = &ProcessReport{PID: pid, Error: e.Error(), Services: []*ServiceReport{}}
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			report.Processes = append(report.Processes, r)
+			report.Healthy = report.Healthy && r.Healthy
+			report.Ready = report.Ready && r.Ready
+		}(pid, address)
+	}
+	wg.Wait()
+	sort.Slice(report.Processes, func(i, j int) bool {
+		return report.Processes[i].PID < report.Processes[j].PID
+	})
+	return report
+}
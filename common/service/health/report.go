@@ -0,0 +1,149 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package health
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultTimeout is the maximum duration given to each check when none is specified.
+const DefaultTimeout = 5 * time.Second
+
+// CheckResult is the outcome of a single checker.
+type CheckResult struct {
+	Name     string        `json:"name"`
+	Healthy  bool          `json:"healthy"`
+	Error    string        `json:"error,omitempty"`
+	Duration time.Duration `json:"duration"`
+}
+
+// ServiceReport aggregates the checks of a service. A service is healthy if its own checks and
+// the process checks pass, and ready if it is started and healthy.
+type ServiceReport struct {
+	Name    string         `json:"name"`
+	Ready   bool           `json:"ready"`
+	Healthy bool           `json:"healthy"`
+	Checks  []*CheckResult `json:"checks,omitempty"`
+}
+
+// ProcessReport aggregates the reports of all services running in a process.
+type ProcessReport struct {
+	PID      string           `json:"pid"`
+	Hostname string           `json:"hostname,omitempty"`
+	Ready    bool             `json:"ready"`
+	Healthy  bool             `json:"healthy"`
+	Draining bool             `json:"draining,omitempty"`
+	Checks   []*CheckResult   `json:"checks,omitempty"`
+	Services []*ServiceReport `json:"services"`
+	// Error is set when the report could not be retrieved from a remote process.
+	Error string `json:"error,omitempty"`
+}
+
+// Report runs all registered checks concurrently, each of them being given at most timeout to complete.
+func Report(ctx context.Context, timeout time.Duration) *ProcessReport {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	cc, rr, drain := snapshot()
+
+	results := make(map[string][]*CheckResult, len(cc))
+	wg := &sync.WaitGroup{}
+	for service, named := range cc {
+		results[service] = make([]*CheckResult, len(named))
+		for i, n := range named {
+			wg.Add(1)
+			go func(service string, i int, n namedChecker) {
+				defer wg.Done()
+				results[service][i] = runCheck(ctx, n, timeout)
+			}(service, i, n)
+		}
+	}
+	wg.Wait()
+
+	report := &ProcessReport{
+		PID:      fmt.Sprintf("%d", os.Getpid()),
+		Checks:   results[ProcessScope],
+		Services: []*ServiceReport{},
+	}
+	report.Hostname, _ = os.Hostname()
+	processHealthy := allHealthy(report.Checks)
+	report.Healthy = processHealthy
+	report.Ready = processHealthy && !drain
+	report.Draining = drain
+	for service, started := range rr {
+		sr := &ServiceReport{
+			Name:    service,
+			Checks:  results[service],
+			Healthy: processHealthy && allHealthy(results[service]),
+		}
+		sr.Ready = started && sr.Healthy
+		report.Healthy = report.Healthy && sr.Healthy
+		report.Ready = report.Ready && sr.Ready
+		report.Services = append(report.Services, sr)
+	}
+	sort.Slice(report.Services, func(i, j int) bool {
+		return report.Services[i].Name < report.Services[j].Name
+	})
+	return report
+}
+
+func runCheck(ctx context.Context, n namedChecker, timeout time.Duration) (r *CheckResult) {
+	ct, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	start := time.Now()
+	r = &CheckResult{Name: n.name}
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if p := recover(); p != nil {
+				done <- fmt.Errorf("check panicked: %v", p)
+			}
+		}()
+		done <- n.checker.CheckHealth(ct)
+	}()
+	var e error
+	select {
+	case e = <-done:
+	case <-ct.Done():
+		e = fmt.Errorf("check did not complete in %s", timeout)
+	}
+	r.Duration = time.Since(start)
+	if e != nil {
+		r.Error = e.Error()
+	} else {
+		r.Healthy = true
+	}
+	return
+}
+
+func allHealthy(results []*CheckResult) bool {
+	for _, r := range results {
+		if !r.Healthy {
+			return false
+		}
+	}
+	return true
+}
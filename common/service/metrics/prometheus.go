@@ -0,0 +1,280 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package metrics
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/uber-go/tally"
+)
+
+// PrometheusRoute is the path where metrics are exposed on the metrics port.
+const PrometheusRoute = "/metrics"
+
+var (
+	handlers = http.NewServeMux()
+
+	invalidChars = regexp.MustCompile("[^a-zA-Z0-9_:]")
+)
+
+// RegisterHandler serves an additional route on the metrics port, e.g. for service discovery.
+func RegisterHandler(pattern string, h http.Handler) {
+	handlers.Handle(pattern, h)
+}
+
+// RegisterPrometheus registers a root scope reporting to a Prometheus registry, and prepares the exposure
+// of the registry on the given host and port. If port is 0, a random free port is used: the actual port is published
+// in the registry metadata of each service so that processes can be discovered.
+func RegisterPrometheus(host string, port int) error {
+	l, e := net.Listen("tcp", net.JoinHostPort(host, fmt.Sprintf("%d", port)))
+	if e != nil {
+		return e
+	}
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(prometheus.NewGoCollector(), prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}))
+
+	RegisterRootScope(tally.ScopeOptions{
+		Prefix:         "cells",
+		Separator:      "_",
+		CachedReporter: NewPrometheusReporter(reg),
+	}, l.Addr().(*net.TCPAddr).Port)
+
+	handlers.Handle(PrometheusRoute, promhttp.HandlerFor(reg, promhttp.HandlerOpts{}))
+	RegisterOnStartExposure(func() {
+		go http.Serve(l, handlers)
+	})
+	return nil
+}
+
+// PrometheusReporter is a tally.CachedStatsReporter writing to a Prometheus registry.
+// Counters and gauges are mapped to their Prometheus equivalents, timers and histograms
+// to Prometheus histograms with durations in seconds.
+type PrometheusReporter struct {
+	reg        prometheus.Registerer
+	lock       sync.Mutex
+	collectors map[string]prometheus.Collector
+	labels     map[string]string
+}
+
+// NewPrometheusReporter creates a reporter registering its metrics on reg.
+func NewPrometheusReporter(reg prometheus.Registerer) *PrometheusReporter {
+	return &PrometheusReporter{
+		reg:        reg,
+		collectors: make(map[string]prometheus.Collector),
+		labels:     make(map[string]string),
+	}
+}
+
+type capabilities struct{}
+
+func (capabilities) Reporting() bool { return true }
+func (capabilities) Tagging() bool   { return true }
+
+// Capabilities implements tally.BaseStatsReporter.
+func (r *PrometheusReporter) Capabilities() tally.Capabilities {
+	return capabilities{}
+}
+
+// Flush implements tally.BaseStatsReporter, values are read by the Prometheus scraper.
+func (r *PrometheusReporter) Flush() {}
+
+// AllocateCounter implements tally.CachedStatsReporter.
+func (r *PrometheusReporter) AllocateCounter(name string, tags map[string]string) tally.CachedCount {
+	keys, values := sortedTags(tags)
+	c := r.collector(sanitize(name)+"_total", keys, func(n string) prometheus.Collector {
+		return prometheus.NewCounterVec(prometheus.CounterOpts{Name: n, Help: name + " counter"}, keys)
+	})
+	if cv, ok := c.(*prometheus.CounterVec); ok {
+		return &promCounter{c: cv.WithLabelValues(values...)}
+	}
+	return noop{}
+}
+
+// AllocateGauge implements tally.CachedStatsReporter.
+func (r *PrometheusReporter) AllocateGauge(name string, tags map[string]string) tally.CachedGauge {
+	keys, values := sortedTags(tags)
+	c := r.collector(sanitize(name), keys, func(n string) prometheus.Collector {
+		return prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: n, Help: name + " gauge"}, keys)
+	})
+	if gv, ok := c.(*prometheus.GaugeVec); ok {
+		return &promGauge{g: gv.WithLabelValues(values...)}
+	}
+	return noop{}
+}
+
+// AllocateTimer implements tally.CachedStatsReporter.
+func (r *PrometheusReporter) AllocateTimer(name string, tags map[string]string) tally.CachedTimer {
+	keys, values := sortedTags(tags)
+	c := r.collector(sanitize(name)+"_seconds", keys, func(n string) prometheus.Collector {
+		return prometheus.NewHistogramVec(prometheus.HistogramOpts{Name: n, Help: name + " duration", Buckets: prometheus.DefBuckets}, keys)
+	})
+	if hv, ok := c.(*prometheus.HistogramVec); ok {
+		return &promTimer{o: hv.WithLabelValues(values...)}
+	}
+	return noop{}
+}
+
+// AllocateHistogram implements tally.CachedStatsReporter.
+func (r *PrometheusReporter) AllocateHistogram(name string, tags map[string]string, buckets tally.Buckets) tally.CachedHistogram {
+	keys, values := sortedTags(tags)
+	metricName := sanitize(name)
+	var bounds []float64
+	if d, ok := buckets.(tally.DurationBuckets); ok {
+		metricName += "_seconds"
+		for _, b := range d {
+			bounds = append(bounds, b.Seconds())
+		}
+	} else if buckets != nil {
+		bounds = buckets.AsValues()
+	}
+	var finite []float64
+	for _, b := range bounds {
+		if !math.IsInf(b, 0) {
+			finite = append(finite, b)
+		}
+	}
+	c := r.collector(metricName, keys, func(n string) prometheus.Collector {
+		return prometheus.NewHistogramVec(prometheus.HistogramOpts{Name: n, Help: name + " histogram", Buckets: finite}, keys)
+	})
+	if hv, ok := c.(*prometheus.HistogramVec); ok {
+		return &promHistogram{o: hv.WithLabelValues(values...)}
+	}
+	return noop{}
+}
+
+// collector returns the collector registered under this name, creating it if required.
+// Prometheus requires all series of a metric to share the same labels: a metric reported
+// with another set of labels is ignored.
+func (r *PrometheusReporter) collector(name string, keys []string, create func(string) prometheus.Collector) prometheus.Collector {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	signature := strings.Join(keys, ",")
+	if c, ok := r.collectors[name]; ok {
+		if r.labels[name] != signature {
+			return nil
+		}
+		return c
+	}
+	c := create(name)
+	if e := r.reg.Register(c); e != nil {
+		if are, ok := e.(prometheus.AlreadyRegisteredError); ok {
+			c = are.ExistingCollector
+		} else {
+			return nil
+		}
+	}
+	r.collectors[name] = c
+	r.labels[name] = signature
+	return c
+}
+
+func sortedTags(tags map[string]string) (keys []string, values []string) {
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for i, k := range keys {
+		values = append(values, tags[k])
+		keys[i] = sanitize(k)
+	}
+	return
+}
+
+func sanitize(name string) string {
+	return invalidChars.ReplaceAllString(name, "_")
+}
+
+type noop struct{}
+
+func (noop) ReportCount(int64)                                    {}
+func (noop) ReportGauge(float64)                                  {}
+func (noop) ReportTimer(time.Duration)                            {}
+func (noop) ReportSamples(int64)                                  {}
+func (noop) ValueBucket(_, _ float64) tally.CachedHistogramBucket { return noop{} }
+func (noop) DurationBucket(_, _ time.Duration) tally.CachedHistogramBucket {
+	return noop{}
+}
+
+type promCounter struct {
+	c prometheus.Counter
+}
+
+func (p *promCounter) ReportCount(value int64) {
+	p.c.Add(float64(value))
+}
+
+type promGauge struct {
+	g prometheus.Gauge
+}
+
+func (p *promGauge) ReportGauge(value float64) {
+	p.g.Set(value)
+}
+
+type promTimer struct {
+	o prometheus.Observer
+}
+
+func (p *promTimer) ReportTimer(interval time.Duration) {
+	p.o.Observe(interval.Seconds())
+}
+
+type promHistogram struct {
+	o prometheus.Observer
+}
+
+// ValueBucket observes the upper bound of the bucket for each sample, or its lower bound for the last bucket.
+func (p *promHistogram) ValueBucket(lower, upper float64) tally.CachedHistogramBucket {
+	v := upper
+	if math.IsInf(v, 0) {
+		v = lower
+	}
+	return &promBucket{o: p.o, v: v}
+}
+
+func (p *promHistogram) DurationBucket(lower, upper time.Duration) tally.CachedHistogramBucket {
+	v := upper
+	if v == time.Duration(math.MaxInt64) {
+		v = lower
+	}
+	return &promBucket{o: p.o, v: v.Seconds()}
+}
+
+type promBucket struct {
+	o prometheus.Observer
+	v float64
+}
+
+func (p *promBucket) ReportSamples(value int64) {
+	for i := int64(0); i < value; i++ {
+		p.o.Observe(p.v)
+	}
+}
@@ -0,0 +1,62 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/uber-go/tally"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func gather(reg *prometheus.Registry) map[string]float64 {
+	out := map[string]float64{}
+	families, _ := reg.Gather()
+	for _, f := range families {
+		for _, m := range f.GetMetric() {
+			key := f.GetName()
+			for _, l := range m.GetLabel() {
+				key += "," + l.GetName() + "=" + l.GetValue()
+			}
+			switch {
+			case m.Counter != nil:
+				out[key] = m.Counter.GetValue()
+			case m.Gauge != nil:
+				out[key] = m.Gauge.GetValue()
+			case m.Histogram != nil:
+				out[key] = float64(m.Histogram.GetSampleCount())
+			}
+		}
+	}
+	return out
+}
+
+func TestPrometheusReporter(t *testing.T) {
+
+	Convey("Test tally metrics are exposed to prometheus", t, func() {
+		reg := prometheus.NewRegistry()
+		s, closer := tally.NewRootScope(tally.ScopeOptions{
+			Prefix:         "cells",
+			Separator:      "_",
+			CachedReporter: NewPrometheusReporter(reg),
+		}, 10*time.Millisecond)
+
+		svc := s.Tagged(map[string]string{"service": "pydio.grpc.tree"})
+		svc.Counter("grpc_calls").Inc(3)
+		svc.Gauge("mailer_queue_length").Update(12)
+		svc.Tagged(map[string]string{"method": "ReadNode"}).Timer("grpc_request_duration").Record(20 * time.Millisecond)
+		svc.Histogram("index_session_events", tally.ValueBuckets{10, 100}).RecordValue(50)
+		// Same metric with other labels is ignored
+		s.Counter("grpc_calls").Inc(1)
+		closer.Close()
+
+		values := gather(reg)
+		So(values["cells_grpc_calls_total,service=pydio.grpc.tree"], ShouldEqual, 3)
+		So(values["cells_mailer_queue_length,service=pydio.grpc.tree"], ShouldEqual, 12)
+		So(values["cells_grpc_request_duration_seconds,method=ReadNode,service=pydio.grpc.tree"], ShouldEqual, 1)
+		So(values["cells_index_session_events,service=pydio.grpc.tree"], ShouldEqual, 1)
+		So(values, ShouldHaveLength, 4)
+	})
+
+}
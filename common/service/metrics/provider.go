@@ -39,6 +39,11 @@ func Close() {
 	}
 }
 
+// Enabled tells whether a reporter is registered, i.e. if metrics are actually collected.
+func Enabled() bool {
+	return scope != tally.NoopScope
+}
+
 func GetExposedPort() int {
 	return port
 }
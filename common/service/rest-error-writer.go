@@ -79,6 +79,36 @@ func RestError404(req *restful.Request, resp *restful.Response, err error) {
 	resp.WriteHeaderAndEntity(404, e)
 }
 
+// RestError400 logs the error with context and writes an Error 400 on the response.
+func RestError400(req *restful.Request, resp *restful.Response, err error) {
+	log.Logger(req.Request.Context()).Warn("Rest Error 400", zap.Error(err))
+	resp.AddHeader("Content-Type", "application/json")
+	e := &rest.Error{
+		Title:  err.Error(),
+		Detail: err.Error(),
+	}
+	if parsed := errors.Parse(err.Error()); parsed.Status != "" && parsed.Detail != "" {
+		e.Title = parsed.Detail
+		e.Detail = parsed.Status + ": " + parsed.Detail
+	}
+	resp.WriteHeaderAndEntity(400, e)
+}
+
+// RestError409 logs the error with context and writes an Error 409 on the response.
+func RestError409(req *restful.Request, resp *restful.Response, err error) {
+	log.Logger(req.Request.Context()).Warn("Rest Error 409", zap.Error(err))
+	resp.AddHeader("Content-Type", "application/json")
+	e := &rest.Error{
+		Title:  err.Error(),
+		Detail: err.Error(),
+	}
+	if parsed := errors.Parse(err.Error()); parsed.Status != "" && parsed.Detail != "" {
+		e.Title = parsed.Detail
+		e.Detail = parsed.Status + ": " + parsed.Detail
+	}
+	resp.WriteHeaderAndEntity(409, e)
+}
+
 // RestError403 logs the error with context and write an Error 403 on the response.
 func RestError403(req *restful.Request, resp *restful.Response, err error) {
 	if isNetworkError(err) {
@@ -157,7 +187,9 @@ func RestErrorDetect(req *restful.Request, resp *restful.Response, err error, de
 	}
 	emitters := map[int32]restErrorEmitter{
 		500: RestError500,
+		400: RestError400,
 		404: RestError404,
+		409: RestError409,
 		403: RestError403,
 		401: RestError401,
 		423: RestError423,
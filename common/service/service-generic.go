@@ -190,6 +190,7 @@ func WithHTTP(handlerFunc func() http.Handler) ServiceOption {
 			// newTracer(name, &options)
 			newConfigProvider(svc)
 			newLogProvider(svc)
+			newHealthProvider(svc)
 
 			// We should actually offer that possibility
 			proto.RegisterServiceHandler(svc.Server(), &StatusHandler{s.Address()})
@@ -159,6 +159,7 @@ func WithMicro(f func(micro.Service) error) ServiceOption {
 			newConfigProvider(svc)
 			newDBProvider(svc)
 			newLogProvider(svc)
+			newHealthProvider(svc)
 			// newTraceProvider(s.Options().Micro) // DISABLED FOR NOW DUE TO CONFLICT WITH THE MICRO GO OS
 			newClaimsProvider(svc)
 
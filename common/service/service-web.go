@@ -28,6 +28,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	limiter "github.com/micro/go-plugins/wrapper/ratelimiter/uber"
 	// json "github.com/pydio/cells/x/jsonx"
@@ -47,6 +48,8 @@ import (
 	"github.com/pydio/cells/common/registry"
 	servicecontext "github.com/pydio/cells/common/service/context"
 	"github.com/pydio/cells/common/service/frontend"
+	"github.com/pydio/cells/common/service/metrics"
+	"github.com/pydio/cells/common/service/tracing"
 )
 
 var (
@@ -136,12 +139,15 @@ func WithWeb(handler func() WebHandler, opts ...micro.Option) ServiceOption {
 				micro.Metadata(registry.BuildServiceMeta()),
 			)
 
+			newHealthProvider(svc)
+
 			rootPath := "/" + strings.TrimPrefix(s.Options().Name, common.ServiceRestNamespace_)
 
 			ws := new(restful.WebService)
 			ws.Consumes(restful.MIME_JSON, "application/x-www-form-urlencoded", "multipart/form-data")
 			ws.Produces(restful.MIME_JSON, restful.MIME_OCTET, restful.MIME_XML)
 			ws.Path(rootPath)
+			ws.Filter(routeMetricsFilter(name))
 
 			h := handler()
 			swaggerTags := h.SwaggerTags()
@@ -204,6 +210,7 @@ func WithWeb(handler func() WebHandler, opts ...micro.Option) ServiceOption {
 				return e
 			}
 			wrapped = NewLogHTTPHandlerWrapper(wrapped, name)
+			wrapped = tracing.HttpHandlerWrapper(wrapped, name)
 
 			wrapped = cors.Default().Handler(wrapped)
 
@@ -347,3 +354,20 @@ func SwaggerSpec() *loads.Document {
 
 	return swaggerMergedDocument
 }
+
+// routeMetricsFilter records the latency of each REST call, labelled with the route pattern rather than the actual path.
+func routeMetricsFilter(name string) restful.FilterFunction {
+	return func(req *restful.Request, resp *restful.Response, chain *restful.FilterChain) {
+		if !metrics.Enabled() {
+			chain.ProcessFilter(req, resp)
+			return
+		}
+		start := time.Now()
+		chain.ProcessFilter(req, resp)
+		metrics.GetMetricsForService(name).Tagged(map[string]string{
+			"route":  req.SelectedRoutePath(),
+			"method": req.Request.Method,
+			"code":   strconv.Itoa(resp.StatusCode()),
+		}).Timer("rest_request_duration").Record(time.Since(start))
+	}
+}
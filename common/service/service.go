@@ -105,6 +105,9 @@ func buildForkStartParams(serviceName string) []string {
 	if viper.GetBool("enable_metrics") {
 		params = append(params, "--enable_metrics")
 	}
+	if viper.GetBool("enable_health") {
+		params = append(params, "--enable_health")
+	}
 	if viper.GetBool("enable_pprof") {
 		params = append(params, "--enable_pprof")
 	}
@@ -0,0 +1,272 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package tracing
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pydio/cells/common"
+)
+
+// Exporter sends batches of finished spans to an OTLP/HTTP collector using the JSON encoding.
+type Exporter struct {
+	opts   Options
+	client *http.Client
+
+	queue   chan *Span
+	flush   chan chan struct{}
+	done    chan struct{}
+	stopped sync.WaitGroup
+	once    sync.Once
+}
+
+// NewExporter starts the batching loop of a new exporter.
+func NewExporter(o Options) *Exporter {
+	e := &Exporter{
+		opts:   o,
+		client: &http.Client{Timeout: 10 * time.Second},
+		queue:  make(chan *Span, o.QueueSize),
+		flush:  make(chan chan struct{}),
+		done:   make(chan struct{}),
+	}
+	e.stopped.Add(1)
+	go e.run()
+	return e
+}
+
+// Export queues a span, dropping it if the queue is full rather than slowing down the caller.
+func (e *Exporter) Export(s *Span) {
+	select {
+	case e.queue <- s:
+	default:
+	}
+}
+
+// Flush sends all queued spans and waits for the request to complete.
+func (e *Exporter) Flush() {
+	ack := make(chan struct{})
+	select {
+	case e.flush <- ack:
+		<-ack
+	case <-e.done:
+	}
+}
+
+// Close sends the queued spans and stops the exporter.
+func (e *Exporter) Close() {
+	e.once.Do(func() {
+		close(e.done)
+	})
+	e.stopped.Wait()
+}
+
+func (e *Exporter) run() {
+	defer e.stopped.Done()
+	ticker := time.NewTicker(e.opts.FlushInterval)
+	defer ticker.Stop()
+
+	var batch []*Span
+	send := func() {
+		if len(batch) > 0 {
+			e.send(batch)
+			batch = nil
+		}
+	}
+	drain := func() {
+		for {
+			select {
+			case s := <-e.queue:
+				batch = append(batch, s)
+				if len(batch) >= e.opts.BatchSize {
+					send()
+				}
+			default:
+				return
+			}
+		}
+	}
+	for {
+		select {
+		case s := <-e.queue:
+			batch = append(batch, s)
+			if len(batch) >= e.opts.BatchSize {
+				send()
+			}
+		case <-ticker.C:
+			send()
+		case ack := <-e.flush:
+			drain()
+			send()
+			close(ack)
+		case <-e.done:
+			drain()
+			send()
+			return
+		}
+	}
+}
+
+func (e *Exporter) send(spans []*Span) {
+	body, er := json.Marshal(encodeSpans(spans))
+	if er != nil {
+		return
+	}
+	req, er := http.NewRequest(http.MethodPost, e.opts.Endpoint, bytes.NewReader(body))
+	if er != nil {
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range e.opts.Headers {
+		req.Header.Set(k, v)
+	}
+	resp, er := e.client.Do(req)
+	if er != nil {
+		// Logging here would create spans and loops: failed exports are silently dropped
+		return
+	}
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+	_ = resp.Body.Close()
+}
+
+// OTLP JSON payload, see opentelemetry-proto/opentelemetry/proto/collector/trace/v1
+type otlpRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+type otlpSpan struct {
+	TraceID           string         `json:"traceId"`
+	SpanID            string         `json:"spanId"`
+	ParentSpanID      string         `json:"parentSpanId,omitempty"`
+	TraceState        string         `json:"traceState,omitempty"`
+	Name              string         `json:"name"`
+	Kind              Kind           `json:"kind"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+	Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+	Status            otlpStatus     `json:"status"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+type otlpKeyValue struct {
+	Key   string        `json:"key"`
+	Value otlpAnyString `json:"value"`
+}
+
+type otlpAnyString struct {
+	StringValue string `json:"stringValue"`
+}
+
+const (
+	statusUnset = 0
+	statusError = 2
+)
+
+// encodeSpans groups spans by service, each service being an OTLP resource.
+func encodeSpans(spans []*Span) *otlpRequest {
+	byService := make(map[string][]otlpSpan)
+	var services []string
+	for _, s := range spans {
+		service := s.Service
+		if service == "" {
+			service = common.PackageType
+		}
+		if _, ok := byService[service]; !ok {
+			services = append(services, service)
+		}
+		byService[service] = append(byService[service], encodeSpan(s))
+	}
+	req := &otlpRequest{}
+	for _, service := range services {
+		req.ResourceSpans = append(req.ResourceSpans, otlpResourceSpans{
+			Resource: otlpResource{Attributes: []otlpKeyValue{
+				{Key: "service.name", Value: otlpAnyString{service}},
+				{Key: "service.namespace", Value: otlpAnyString{common.PackageType}},
+				{Key: "service.version", Value: otlpAnyString{common.Version().String()}},
+			}},
+			ScopeSpans: []otlpScopeSpans{{
+				Scope: otlpScope{Name: "github.com/pydio/cells/common/service/tracing"},
+				Spans: byService[service],
+			}},
+		})
+	}
+	return req
+}
+
+func encodeSpan(s *Span) otlpSpan {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	o := otlpSpan{
+		TraceID:           s.Context.TraceID.String(),
+		SpanID:            s.Context.SpanID.String(),
+		TraceState:        s.Context.State,
+		Name:              s.Name,
+		Kind:              s.Kind,
+		StartTimeUnixNano: strconv.FormatInt(s.Start.UnixNano(), 10),
+		EndTimeUnixNano:   strconv.FormatInt(s.End.UnixNano(), 10),
+		Status:            otlpStatus{Code: statusUnset},
+	}
+	if s.ParentID.IsValid() {
+		o.ParentSpanID = s.ParentID.String()
+	}
+	if s.Error != "" {
+		o.Status = otlpStatus{Code: statusError, Message: s.Error}
+	}
+	var keys []string
+	for k := range s.Attributes {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		o.Attributes = append(o.Attributes, otlpKeyValue{Key: k, Value: otlpAnyString{s.Attributes[k]}})
+	}
+	return o
+}
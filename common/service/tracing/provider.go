@@ -0,0 +1,168 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package tracing
+
+import (
+	"encoding/binary"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pydio/cells/common/config"
+)
+
+// DefaultEndpoint is the OTLP/HTTP port of a collector running locally.
+const DefaultEndpoint = "http://localhost:4318"
+
+// Options configure the export of spans.
+type Options struct {
+	// Endpoint is the base URL of the collector, "/v1/traces" is appended unless it is already present
+	Endpoint string
+	// Headers are added to each export request, e.g. for authentication
+	Headers map[string]string
+	// SampleRatio is the share of new traces that are recorded, between 0 and 1.
+	// Traces started by a remote caller follow the caller decision.
+	SampleRatio float64
+	// BatchSize is the maximum number of spans sent in one request
+	BatchSize int
+	// FlushInterval is the maximum delay before a span is sent
+	FlushInterval time.Duration
+	// QueueSize is the number of spans kept in memory, further spans are dropped
+	QueueSize int
+}
+
+type provider struct {
+	opts     Options
+	exporter *Exporter
+}
+
+var (
+	providerLock sync.RWMutex
+	active       *provider
+)
+
+func current() *provider {
+	providerLock.RLock()
+	defer providerLock.RUnlock()
+	return active
+}
+
+// Enabled tells whether spans are currently recorded.
+func Enabled() bool {
+	return current() != nil
+}
+
+// Init reads the "trace" section of the configuration and starts exporting spans if it is enabled:
+//
+//	"trace": {
+//	  "enabled": true,
+//	  "endpoint": "http://localhost:4318",
+//	  "headers": {"Authorization": "Bearer xxx"},
+//	  "sampleRatio": 0.1,
+//	  "batchSize": 512,
+//	  "flushInterval": "5s"
+//	}
+func Init() {
+	c := config.Get("trace")
+	if !c.Val("enabled").Default(false).Bool() {
+		Close()
+		return
+	}
+	ratio, e := strconv.ParseFloat(c.Val("sampleRatio").Default("1").String(), 64)
+	if e != nil {
+		ratio = 1
+	}
+	Configure(Options{
+		Endpoint:      c.Val("endpoint").Default(DefaultEndpoint).String(),
+		Headers:       c.Val("headers").StringMap(),
+		SampleRatio:   ratio,
+		BatchSize:     c.Val("batchSize").Default(512).Int(),
+		FlushInterval: c.Val("flushInterval").Default("5s").Duration(),
+		QueueSize:     c.Val("queueSize").Default(4096).Int(),
+	})
+}
+
+// Configure starts exporting spans with these options, replacing the previous exporter if any.
+func Configure(o Options) {
+	if o.Endpoint == "" {
+		o.Endpoint = DefaultEndpoint
+	}
+	if !strings.HasSuffix(o.Endpoint, "/v1/traces") {
+		o.Endpoint = strings.TrimRight(o.Endpoint, "/") + "/v1/traces"
+	}
+	if o.SampleRatio < 0 {
+		o.SampleRatio = 0
+	} else if o.SampleRatio > 1 {
+		o.SampleRatio = 1
+	}
+	if o.BatchSize <= 0 {
+		o.BatchSize = 512
+	}
+	if o.FlushInterval <= 0 {
+		o.FlushInterval = 5 * time.Second
+	}
+	if o.QueueSize < o.BatchSize {
+		o.QueueSize = o.BatchSize
+	}
+	p := &provider{opts: o, exporter: NewExporter(o)}
+
+	providerLock.Lock()
+	previous := active
+	active = p
+	providerLock.Unlock()
+
+	if previous != nil {
+		previous.exporter.Close()
+	}
+}
+
+// Close stops recording spans and sends the pending ones.
+func Close() {
+	providerLock.Lock()
+	previous := active
+	active = nil
+	providerLock.Unlock()
+
+	if previous != nil {
+		previous.exporter.Close()
+	}
+}
+
+// Flush sends the pending spans without waiting for the next interval.
+func Flush() {
+	if p := current(); p != nil {
+		p.exporter.Flush()
+	}
+}
+
+// sample keeps a trace if its identifier falls below the ratio, so that all services
+// take the same decision for a given trace.
+func (p *provider) sample(id TraceID) bool {
+	switch {
+	case p.opts.SampleRatio >= 1:
+		return true
+	case p.opts.SampleRatio <= 0:
+		return false
+	}
+	bound := uint64(p.opts.SampleRatio * (1 << 63))
+	return binary.BigEndian.Uint64(id[8:16])>>1 < bound
+}
@@ -0,0 +1,226 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+// Package tracing records distributed traces across gRPC, REST and views calls.
+//
+// Trace context is propagated with the W3C "traceparent" and "tracestate" headers, and spans are exported
+// to an OpenTelemetry collector with the OTLP/HTTP JSON protocol. Everything is a no-op until tracing
+// is enabled under the "trace" configuration key.
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// HeaderTraceParent is the W3C header carrying the trace and parent span identifiers.
+	HeaderTraceParent = "traceparent"
+	// HeaderTraceState is the W3C header carrying vendor-specific trace data.
+	HeaderTraceState = "tracestate"
+)
+
+// Kind is the role of a span in a remote call, with the OTLP values.
+type Kind int
+
+const (
+	KindInternal Kind = 1
+	KindServer   Kind = 2
+	KindClient   Kind = 3
+	KindProducer Kind = 4
+	KindConsumer Kind = 5
+)
+
+type TraceID [16]byte
+type SpanID [8]byte
+
+func (t TraceID) String() string { return hex.EncodeToString(t[:]) }
+func (s SpanID) String() string  { return hex.EncodeToString(s[:]) }
+
+// IsValid returns false for the all-zeros identifier.
+func (t TraceID) IsValid() bool { return t != TraceID{} }
+
+// IsValid returns false for the all-zeros identifier.
+func (s SpanID) IsValid() bool { return s != SpanID{} }
+
+// SpanContext identifies a span and is propagated between processes.
+type SpanContext struct {
+	TraceID TraceID
+	SpanID  SpanID
+	Sampled bool
+	State   string
+}
+
+// IsValid checks that both identifiers are set.
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID.IsValid() && sc.SpanID.IsValid()
+}
+
+// TraceParent formats the span context as a version 00 traceparent header.
+func (sc SpanContext) TraceParent() string {
+	flags := "00"
+	if sc.Sampled {
+		flags = "01"
+	}
+	return "00-" + sc.TraceID.String() + "-" + sc.SpanID.String() + "-" + flags
+}
+
+// ParseTraceParent reads a traceparent header. Unknown versions are accepted as long as
+// they start with the version 00 fields, as required by the specification.
+func ParseTraceParent(header string) (SpanContext, error) {
+	var sc SpanContext
+	parts := strings.Split(strings.TrimSpace(header), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" {
+		return sc, fmt.Errorf("invalid traceparent %s", header)
+	}
+	if parts[0] == "00" && len(parts) != 4 {
+		return sc, fmt.Errorf("invalid traceparent %s", header)
+	}
+	if e := decodeHex(parts[1], sc.TraceID[:]); e != nil {
+		return sc, fmt.Errorf("invalid trace id in traceparent %s", header)
+	}
+	if e := decodeHex(parts[2], sc.SpanID[:]); e != nil {
+		return sc, fmt.Errorf("invalid parent id in traceparent %s", header)
+	}
+	var flags [1]byte
+	if e := decodeHex(parts[3], flags[:]); e != nil {
+		return sc, fmt.Errorf("invalid flags in traceparent %s", header)
+	}
+	if !sc.IsValid() {
+		return sc, fmt.Errorf("invalid zero identifiers in traceparent %s", header)
+	}
+	sc.Sampled = flags[0]&1 == 1
+	return sc, nil
+}
+
+func decodeHex(s string, dst []byte) error {
+	if len(s) != 2*len(dst) || strings.ToLower(s) != s {
+		return fmt.Errorf("wrong length")
+	}
+	_, e := hex.Decode(dst, []byte(s))
+	return e
+}
+
+type spanContextKey struct{}
+
+// ContextWithSpanContext sets the span context that new spans will use as parent.
+func ContextWithSpanContext(ctx context.Context, sc SpanContext) context.Context {
+	return context.WithValue(ctx, spanContextKey{}, sc)
+}
+
+// SpanContextFromContext returns the current span context, either started locally or received from a remote caller.
+func SpanContextFromContext(ctx context.Context) (SpanContext, bool) {
+	if sc, ok := ctx.Value(spanContextKey{}).(SpanContext); ok && sc.IsValid() {
+		return sc, true
+	}
+	return SpanContext{}, false
+}
+
+// Span is an operation being timed. A nil Span is valid and does nothing, so that callers
+// do not have to check whether tracing is enabled.
+type Span struct {
+	Name       string
+	Kind       Kind
+	Service    string
+	Context    SpanContext
+	ParentID   SpanID
+	Start      time.Time
+	End        time.Time
+	Attributes map[string]string
+	Error      string
+
+	lock  sync.Mutex
+	ended bool
+	p     *provider
+}
+
+// StartSpan creates a child of the current span context, or a new trace if there is none.
+// It returns a context carrying the new span context, and a nil span if tracing is disabled.
+func StartSpan(ctx context.Context, service, name string, kind Kind) (context.Context, *Span) {
+	p := current()
+	if p == nil {
+		return ctx, nil
+	}
+	s := &Span{
+		Name:    name,
+		Kind:    kind,
+		Service: service,
+		Start:   time.Now(),
+		p:       p,
+	}
+	if parent, ok := SpanContextFromContext(ctx); ok {
+		s.Context.TraceID = parent.TraceID
+		s.Context.Sampled = parent.Sampled
+		s.Context.State = parent.State
+		s.ParentID = parent.SpanID
+	} else {
+		_, _ = rand.Read(s.Context.TraceID[:])
+		s.Context.Sampled = p.sample(s.Context.TraceID)
+	}
+	_, _ = rand.Read(s.Context.SpanID[:])
+	return ContextWithSpanContext(ctx, s.Context), s
+}
+
+// SetAttribute attaches a key/value to the span.
+func (s *Span) SetAttribute(key string, value interface{}) {
+	if s == nil {
+		return
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.Attributes == nil {
+		s.Attributes = make(map[string]string)
+	}
+	s.Attributes[key] = fmt.Sprintf("%v", value)
+}
+
+// SetError marks the span as failed if e is not nil.
+func (s *Span) SetError(e error) {
+	if s == nil || e == nil {
+		return
+	}
+	s.lock.Lock()
+	s.Error = e.Error()
+	s.lock.Unlock()
+}
+
+// Finish records the end time and sends the span to the exporter if it is sampled.
+// Calling Finish more than once has no effect.
+func (s *Span) Finish() {
+	if s == nil {
+		return
+	}
+	s.lock.Lock()
+	if s.ended {
+		s.lock.Unlock()
+		return
+	}
+	s.ended = true
+	s.End = time.Now()
+	s.lock.Unlock()
+	if s.Context.Sampled {
+		s.p.exporter.Export(s)
+	}
+}
@@ -0,0 +1,197 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/micro/go-micro/metadata"
+	"github.com/micro/go-micro/server"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// collector stands in for an OpenTelemetry collector receiving OTLP/HTTP JSON.
+type collector struct {
+	sync.Mutex
+	spans    map[string]otlpSpan
+	services map[string]string
+	headers  http.Header
+}
+
+func newCollector() (*collector, *httptest.Server) {
+	c := &collector{spans: map[string]otlpSpan{}, services: map[string]string{}}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/traces" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var req otlpRequest
+		if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		c.Lock()
+		defer c.Unlock()
+		c.headers = r.Header
+		for _, rs := range req.ResourceSpans {
+			service := rs.Resource.Attributes[0].Value.StringValue
+			for _, ss := range rs.ScopeSpans {
+				for _, s := range ss.Spans {
+					c.spans[s.Name] = s
+					c.services[s.Name] = service
+				}
+			}
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	return c, srv
+}
+
+type testRequest struct{}
+
+func (r *testRequest) Service() string      { return "pydio.grpc.tree" }
+func (r *testRequest) Method() string       { return "NodeProvider.ReadNode" }
+func (r *testRequest) ContentType() string  { return "application/grpc" }
+func (r *testRequest) Request() interface{} { return nil }
+func (r *testRequest) Stream() bool         { return false }
+
+func TestTraceParent(t *testing.T) {
+
+	Convey("Test traceparent parsing", t, func() {
+		sc, e := ParseTraceParent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+		So(e, ShouldBeNil)
+		So(sc.TraceID.String(), ShouldEqual, "4bf92f3577b34da6a3ce929d0e0e4736")
+		So(sc.SpanID.String(), ShouldEqual, "00f067aa0ba902b7")
+		So(sc.Sampled, ShouldBeTrue)
+		So(sc.TraceParent(), ShouldEqual, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+		sc, e = ParseTraceParent("cc-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00-future")
+		So(e, ShouldBeNil)
+		So(sc.Sampled, ShouldBeFalse)
+
+		for _, invalid := range []string{
+			"",
+			"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7",
+			"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra",
+			"00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+			"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01",
+			"00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01",
+			"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			"00-4bf92f3577b34da6a3ce929d0e0e47-00f067aa0ba902b7-01",
+		} {
+			_, e := ParseTraceParent(invalid)
+			So(e, ShouldNotBeNil)
+		}
+	})
+
+}
+
+func TestExport(t *testing.T) {
+
+	Convey("Test spans are propagated and exported to the collector", t, func() {
+		c, srv := newCollector()
+		defer srv.Close()
+		Configure(Options{Endpoint: srv.URL, Headers: map[string]string{"X-Token": "secret"}, FlushInterval: time.Minute})
+		defer Close()
+
+		grpcHandler := HandlerWrapper(func(ctx context.Context, req server.Request, rsp interface{}) error {
+			_, span := StartSpan(ctx, "pydio.grpc.tree", "internal", KindInternal)
+			span.SetAttribute("key", 12)
+			span.Finish()
+			return nil
+		})
+		h := HttpHandlerWrapper(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// Simulate a client call: the metadata sent by the client is received by the server
+			ctx := Inject(r.Context())
+			md, _ := metadata.FromContext(ctx)
+			_ = grpcHandler(metadata.NewContext(context.Background(), md), &testRequest{}, nil)
+			w.WriteHeader(http.StatusInternalServerError)
+		}), "pydio.rest.tree")
+
+		req := httptest.NewRequest(http.MethodGet, "/a/tree/stats", nil)
+		req.Header.Set(HeaderTraceParent, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+		req.Header.Set(HeaderTraceState, "vendor=value")
+		h.ServeHTTP(httptest.NewRecorder(), req)
+		Flush()
+
+		c.Lock()
+		defer c.Unlock()
+		So(c.spans, ShouldHaveLength, 3)
+		So(c.headers.Get("X-Token"), ShouldEqual, "secret")
+
+		rest := c.spans["GET /a/tree/stats"]
+		grpc := c.spans["pydio.grpc.tree/NodeProvider.ReadNode"]
+		internal := c.spans["internal"]
+		So(rest.TraceID, ShouldEqual, "4bf92f3577b34da6a3ce929d0e0e4736")
+		So(rest.ParentSpanID, ShouldEqual, "00f067aa0ba902b7")
+		So(rest.Kind, ShouldEqual, KindServer)
+		So(rest.TraceState, ShouldEqual, "vendor=value")
+		So(rest.Status.Code, ShouldEqual, statusError)
+		So(c.services["GET /a/tree/stats"], ShouldEqual, "pydio.rest.tree")
+
+		So(grpc.TraceID, ShouldEqual, rest.TraceID)
+		So(grpc.ParentSpanID, ShouldEqual, rest.SpanID)
+		So(grpc.Status.Code, ShouldEqual, statusUnset)
+		So(c.services["pydio.grpc.tree/NodeProvider.ReadNode"], ShouldEqual, "pydio.grpc.tree")
+
+		So(internal.TraceID, ShouldEqual, rest.TraceID)
+		So(internal.ParentSpanID, ShouldEqual, grpc.SpanID)
+		So(internal.Attributes, ShouldResemble, []otlpKeyValue{{Key: "key", Value: otlpAnyString{"12"}}})
+	})
+
+	Convey("Test unsampled traces are propagated but not exported", t, func() {
+		c, srv := newCollector()
+		defer srv.Close()
+		Configure(Options{Endpoint: srv.URL + "/v1/traces", SampleRatio: 0, FlushInterval: time.Minute})
+		defer Close()
+
+		ctx, span := StartSpan(context.Background(), "test", "root", KindInternal)
+		So(span, ShouldNotBeNil)
+		So(span.Context.Sampled, ShouldBeFalse)
+		_, child := StartSpan(ctx, "test", "child", KindInternal)
+		So(child.Context.TraceID, ShouldEqual, span.Context.TraceID)
+		child.Finish()
+		span.Finish()
+		Flush()
+
+		c.Lock()
+		defer c.Unlock()
+		So(c.spans, ShouldBeEmpty)
+	})
+
+	Convey("Test nothing is recorded when disabled", t, func() {
+		Close()
+		So(Enabled(), ShouldBeFalse)
+		ctx, span := StartSpan(context.Background(), "test", "root", KindInternal)
+		So(span, ShouldBeNil)
+		span.SetAttribute("key", "value")
+		span.Finish()
+		_, ok := SpanContextFromContext(ctx)
+		So(ok, ShouldBeFalse)
+	})
+
+}
@@ -0,0 +1,224 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package tracing
+
+import (
+	"context"
+	"io"
+	"net/http"
+
+	"github.com/micro/go-micro/client"
+	"github.com/micro/go-micro/metadata"
+	"github.com/micro/go-micro/server"
+
+	servicecontext "github.com/pydio/cells/common/service/context"
+)
+
+// Inject writes the current span context in the outgoing micro metadata.
+func Inject(ctx context.Context) context.Context {
+	sc, ok := SpanContextFromContext(ctx)
+	if !ok {
+		return ctx
+	}
+	md := metadata.Metadata{}
+	if meta, ok := metadata.FromContext(ctx); ok {
+		for k, v := range meta {
+			md[k] = v
+		}
+	}
+	md[HeaderTraceParent] = sc.TraceParent()
+	if sc.State != "" {
+		md[HeaderTraceState] = sc.State
+	} else {
+		delete(md, HeaderTraceState)
+	}
+	return metadata.NewContext(ctx, md)
+}
+
+// Extract reads the span context sent by a remote caller in the incoming micro metadata.
+func Extract(ctx context.Context) context.Context {
+	md, ok := metadata.FromContext(ctx)
+	if !ok {
+		return ctx
+	}
+	return extract(ctx, md[HeaderTraceParent], md[HeaderTraceState])
+}
+
+func extract(ctx context.Context, parent, state string) context.Context {
+	if parent == "" {
+		return ctx
+	}
+	sc, e := ParseTraceParent(parent)
+	if e != nil {
+		return ctx
+	}
+	sc.State = state
+	return ContextWithSpanContext(ctx, sc)
+}
+
+type clientWrapper struct {
+	client.Client
+}
+
+// ClientWrapper records a client span for each call and propagates it to the called service.
+func ClientWrapper(c client.Client) client.Client {
+	return &clientWrapper{Client: c}
+}
+
+func (c *clientWrapper) Call(ctx context.Context, req client.Request, rsp interface{}, opts ...client.CallOption) error {
+	if !Enabled() {
+		return c.Client.Call(ctx, req, rsp, opts...)
+	}
+	ctx, span := StartSpan(ctx, servicecontext.GetServiceName(ctx), req.Service()+"/"+req.Method(), KindClient)
+	span.SetAttribute("rpc.service", req.Service())
+	span.SetAttribute("rpc.method", req.Method())
+	err := c.Client.Call(Inject(ctx), req, rsp, opts...)
+	span.SetError(err)
+	span.Finish()
+	return err
+}
+
+func (c *clientWrapper) Stream(ctx context.Context, req client.Request, opts ...client.CallOption) (client.Streamer, error) {
+	if !Enabled() {
+		return c.Client.Stream(ctx, req, opts...)
+	}
+	ctx, span := StartSpan(ctx, servicecontext.GetServiceName(ctx), req.Service()+"/"+req.Method(), KindClient)
+	span.SetAttribute("rpc.service", req.Service())
+	span.SetAttribute("rpc.method", req.Method())
+	st, err := c.Client.Stream(Inject(ctx), req, opts...)
+	if err != nil {
+		span.SetError(err)
+		span.Finish()
+		return nil, err
+	}
+	return &tracedStreamer{Streamer: st, span: span}, nil
+}
+
+func (c *clientWrapper) Publish(ctx context.Context, msg client.Publication, opts ...client.PublishOption) error {
+	if !Enabled() {
+		return c.Client.Publish(ctx, msg, opts...)
+	}
+	ctx, span := StartSpan(ctx, servicecontext.GetServiceName(ctx), msg.Topic()+" send", KindProducer)
+	span.SetAttribute("messaging.destination", msg.Topic())
+	err := c.Client.Publish(Inject(ctx), msg, opts...)
+	span.SetError(err)
+	span.Finish()
+	return err
+}
+
+// tracedStreamer ends the client span when the stream is closed or fails.
+type tracedStreamer struct {
+	client.Streamer
+	span *Span
+}
+
+func (s *tracedStreamer) Recv(msg interface{}) error {
+	err := s.Streamer.Recv(msg)
+	if err != nil {
+		if err != io.EOF {
+			s.span.SetError(err)
+		}
+		s.span.Finish()
+	}
+	return err
+}
+
+func (s *tracedStreamer) Close() error {
+	err := s.Streamer.Close()
+	s.span.Finish()
+	return err
+}
+
+// HandlerWrapper records a server span for each call, as a child of the caller span.
+func HandlerWrapper(fn server.HandlerFunc) server.HandlerFunc {
+	return func(ctx context.Context, req server.Request, rsp interface{}) error {
+		if !Enabled() {
+			return fn(ctx, req, rsp)
+		}
+		ctx, span := StartSpan(Extract(ctx), req.Service(), req.Service()+"/"+req.Method(), KindServer)
+		span.SetAttribute("rpc.service", req.Service())
+		span.SetAttribute("rpc.method", req.Method())
+		err := fn(ctx, req, rsp)
+		span.SetError(err)
+		span.Finish()
+		return err
+	}
+}
+
+// SubscriberWrapper records a consumer span for each received message.
+func SubscriberWrapper(fn server.SubscriberFunc) server.SubscriberFunc {
+	return func(ctx context.Context, msg server.Publication) error {
+		if !Enabled() {
+			return fn(ctx, msg)
+		}
+		ctx, span := StartSpan(Extract(ctx), servicecontext.GetServiceName(ctx), msg.Topic()+" process", KindConsumer)
+		span.SetAttribute("messaging.destination", msg.Topic())
+		err := fn(ctx, msg)
+		span.SetError(err)
+		span.Finish()
+		return err
+	}
+}
+
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *statusWriter) WriteHeader(code int) {
+	w.status = code
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *statusWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// HttpHandlerWrapper records a server span for each HTTP request, reading the W3C headers sent by the client.
+func HttpHandlerWrapper(h http.Handler, serviceName string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !Enabled() {
+			h.ServeHTTP(w, r)
+			return
+		}
+		ctx := extract(r.Context(), r.Header.Get(HeaderTraceParent), r.Header.Get(HeaderTraceState))
+		ctx, span := StartSpan(ctx, serviceName, r.Method+" "+r.URL.Path, KindServer)
+		span.SetAttribute("http.method", r.Method)
+		span.SetAttribute("http.target", r.URL.Path)
+		sw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+		h.ServeHTTP(sw, r.WithContext(ctx))
+		span.SetAttribute("http.status_code", sw.status)
+		if sw.status >= http.StatusInternalServerError {
+			span.SetError(&httpError{status: sw.status})
+		}
+		span.Finish()
+	})
+}
+
+type httpError struct {
+	status int
+}
+
+func (e *httpError) Error() string {
+	return http.StatusText(e.status)
+}
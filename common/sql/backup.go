@@ -0,0 +1,294 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package sql
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// backupRecord is a line of a database dump: either the schema of a table or one of its rows.
+type backupRecord struct {
+	Table   string            `json:"table"`
+	Schema  []string          `json:"schema,omitempty"`
+	Columns []string          `json:"columns,omitempty"`
+	Row     []json.RawMessage `json:"row,omitempty"`
+}
+
+// Backup dumps the schema and the rows of all tables of the database as JSON lines. Rows are read
+// inside a single read-only transaction to get a consistent snapshot.
+func (h *Handler) Backup(ctx context.Context, w io.Writer) error {
+	var opts *sql.TxOptions
+	if h.Driver() == "mysql" {
+		opts = &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true}
+	}
+	tx, err := h.DB().BeginTx(ctx, opts)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	tables, err := h.listTables(ctx, tx)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(w)
+	for _, table := range tables {
+		schema, err := h.tableSchema(ctx, tx, table)
+		if err != nil {
+			return err
+		}
+		if err := enc.Encode(&backupRecord{Table: table, Schema: schema}); err != nil {
+			return err
+		}
+		if err := dumpRows(ctx, tx, table, enc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Restore recreates the tables found in a dump produced by Backup and inserts their rows.
+// Existing tables with the same names are dropped.
+func (h *Handler) Restore(ctx context.Context, r io.Reader) error {
+	conn, err := h.DB().Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	fkOff, fkOn := "SET FOREIGN_KEY_CHECKS=0", "SET FOREIGN_KEY_CHECKS=1"
+	if h.Driver() == "sqlite3" {
+		fkOff, fkOn = "PRAGMA foreign_keys=OFF", "PRAGMA foreign_keys=ON"
+	}
+	if _, err := conn.ExecContext(ctx, fkOff); err != nil {
+		return err
+	}
+	defer conn.ExecContext(context.Background(), fkOn)
+
+	var tx *sql.Tx
+	var stmt *sql.Stmt
+	var stmtTable string
+	commit := func() error {
+		if tx == nil {
+			return nil
+		}
+		stmt.Close()
+		e := tx.Commit()
+		tx, stmt, stmtTable = nil, nil, ""
+		return e
+	}
+	defer func() {
+		if tx != nil {
+			tx.Rollback()
+		}
+	}()
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 256*1024*1024)
+	for scanner.Scan() {
+		var rec backupRecord
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			return err
+		}
+		if len(rec.Schema) > 0 {
+			if err := commit(); err != nil {
+				return err
+			}
+			if _, err := conn.ExecContext(ctx, "DROP TABLE IF EXISTS "+quoteIdentifier(rec.Table)); err != nil {
+				return err
+			}
+			for _, s := range rec.Schema {
+				if _, err := conn.ExecContext(ctx, s); err != nil {
+					return fmt.Errorf("cannot create table %s: %v", rec.Table, err)
+				}
+			}
+			continue
+		}
+		if stmtTable != rec.Table {
+			if err := commit(); err != nil {
+				return err
+			}
+			if tx, err = conn.BeginTx(ctx, nil); err != nil {
+				return err
+			}
+			cols := make([]string, len(rec.Columns))
+			for i, c := range rec.Columns {
+				cols[i] = quoteIdentifier(c)
+			}
+			query := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", quoteIdentifier(rec.Table), strings.Join(cols, ","), strings.TrimSuffix(strings.Repeat("?,", len(cols)), ","))
+			if stmt, err = tx.PrepareContext(ctx, query); err != nil {
+				return err
+			}
+			stmtTable = rec.Table
+		}
+		args := make([]interface{}, len(rec.Row))
+		for i, raw := range rec.Row {
+			if args[i], err = decodeValue(raw); err != nil {
+				return err
+			}
+		}
+		if _, err := stmt.ExecContext(ctx, args...); err != nil {
+			return fmt.Errorf("cannot insert row in %s: %v", rec.Table, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	return commit()
+}
+
+func (h *Handler) listTables(ctx context.Context, tx *sql.Tx) ([]string, error) {
+	query := "SHOW FULL TABLES WHERE Table_type = 'BASE TABLE'"
+	if h.Driver() == "sqlite3" {
+		query = "SELECT name, type FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%' ORDER BY name"
+	}
+	rows, err := tx.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var tables []string
+	for rows.Next() {
+		var name, kind string
+		if err := rows.Scan(&name, &kind); err != nil {
+			return nil, err
+		}
+		tables = append(tables, name)
+	}
+	return tables, rows.Err()
+}
+
+func (h *Handler) tableSchema(ctx context.Context, tx *sql.Tx, table string) ([]string, error) {
+	if h.Driver() != "sqlite3" {
+		var name, create string
+		if err := tx.QueryRowContext(ctx, "SHOW CREATE TABLE "+quoteIdentifier(table)).Scan(&name, &create); err != nil {
+			return nil, err
+		}
+		return []string{create}, nil
+	}
+	// Table first, then its indexes
+	rows, err := tx.QueryContext(ctx, "SELECT sql FROM sqlite_master WHERE tbl_name = ? AND sql IS NOT NULL ORDER BY CASE type WHEN 'table' THEN 0 ELSE 1 END, name", table)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var schema []string
+	for rows.Next() {
+		var s string
+		if err := rows.Scan(&s); err != nil {
+			return nil, err
+		}
+		schema = append(schema, s)
+	}
+	return schema, rows.Err()
+}
+
+func dumpRows(ctx context.Context, tx *sql.Tx, table string, enc *json.Encoder) error {
+	rows, err := tx.QueryContext(ctx, "SELECT * FROM "+quoteIdentifier(table))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+	values := make([]interface{}, len(columns))
+	pointers := make([]interface{}, len(columns))
+	for i := range values {
+		pointers[i] = &values[i]
+	}
+	for rows.Next() {
+		if err := rows.Scan(pointers...); err != nil {
+			return err
+		}
+		rec := &backupRecord{Table: table, Columns: columns, Row: make([]json.RawMessage, len(values))}
+		for i, v := range values {
+			if rec.Row[i], err = encodeValue(v); err != nil {
+				return err
+			}
+		}
+		if err := enc.Encode(rec); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// encodeValue keeps the type of a value read from the database: numbers and booleans are written as is,
+// strings, binary data and dates are wrapped in an object so that they are inserted back with the same type.
+func encodeValue(v interface{}) (json.RawMessage, error) {
+	switch t := v.(type) {
+	case nil:
+		return json.RawMessage("null"), nil
+	case int64, float64, bool:
+		return json.Marshal(t)
+	case time.Time:
+		return json.Marshal(map[string]string{"time": t.Format(time.RFC3339Nano)})
+	case string:
+		if utf8.ValidString(t) {
+			return json.Marshal(map[string]string{"s": t})
+		}
+		return json.Marshal(map[string]string{"b": base64.StdEncoding.EncodeToString([]byte(t))})
+	case []byte:
+		return json.Marshal(map[string]string{"b": base64.StdEncoding.EncodeToString(t)})
+	}
+	return nil, fmt.Errorf("unsupported value type %T", v)
+}
+
+func decodeValue(raw json.RawMessage) (interface{}, error) {
+	s := strings.TrimSpace(string(raw))
+	switch {
+	case s == "null":
+		return nil, nil
+	case s == "true" || s == "false":
+		return s == "true", nil
+	case strings.HasPrefix(s, "{"):
+		var m map[string]string
+		if err := json.Unmarshal(raw, &m); err != nil {
+			return nil, err
+		}
+		if b, ok := m["b"]; ok {
+			return base64.StdEncoding.DecodeString(b)
+		} else if t, ok := m["time"]; ok {
+			return time.Parse(time.RFC3339Nano, t)
+		}
+		return m["s"], nil
+	}
+	if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return i, nil
+	}
+	return strconv.ParseFloat(s, 64)
+}
+
+func quoteIdentifier(name string) string {
+	return "`" + strings.Replace(name, "`", "``", -1) + "`"
+}
@@ -0,0 +1,73 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package sql
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestBackup(t *testing.T) {
+
+	Convey("Test database dump and restore", t, func() {
+		ctx := context.Background()
+		source := NewDAO("sqlite3", "file:backup-source?mode=memory&cache=shared", "").(*Handler)
+		target := NewDAO("sqlite3", "file:backup-target?mode=memory&cache=shared", "").(*Handler)
+		So(source, ShouldNotBeNil)
+		So(target, ShouldNotBeNil)
+
+		db := source.DB()
+		_, e := db.Exec("CREATE TABLE `test_nodes` (`id` INTEGER PRIMARY KEY, `name` VARCHAR(255), `size` REAL, `data` BLOB)")
+		So(e, ShouldBeNil)
+		_, e = db.Exec("CREATE INDEX `test_nodes_name` ON `test_nodes` (`name`)")
+		So(e, ShouldBeNil)
+		_, e = db.Exec("INSERT INTO `test_nodes` VALUES (1, 'first', 1.5, ?), (2, NULL, 2, NULL)", []byte{0, 255, 12})
+		So(e, ShouldBeNil)
+
+		// Existing table is replaced
+		_, e = target.DB().Exec("CREATE TABLE `test_nodes` (`other` TEXT)")
+		So(e, ShouldBeNil)
+
+		buf := &bytes.Buffer{}
+		So(source.Backup(ctx, buf), ShouldBeNil)
+		So(target.Restore(ctx, buf), ShouldBeNil)
+
+		var name *string
+		var size float64
+		var data []byte
+		So(target.DB().QueryRow("SELECT `name`, `size`, `data` FROM `test_nodes` WHERE `id` = 1").Scan(&name, &size, &data), ShouldBeNil)
+		So(*name, ShouldEqual, "first")
+		So(size, ShouldEqual, 1.5)
+		So(data, ShouldResemble, []byte{0, 255, 12})
+		So(target.DB().QueryRow("SELECT `name`, `data` FROM `test_nodes` WHERE `id` = 2").Scan(&name, &data), ShouldBeNil)
+		So(name, ShouldBeNil)
+		So(data, ShouldBeNil)
+
+		var indexes int
+		So(target.DB().QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type = 'index' AND name = 'test_nodes_name'").Scan(&indexes), ShouldBeNil)
+		So(indexes, ShouldEqual, 1)
+	})
+
+}
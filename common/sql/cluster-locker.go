@@ -0,0 +1,89 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+)
+
+var (
+	clusterLocks     = map[string]*sync.Mutex{}
+	clusterLocksLock sync.Mutex
+)
+
+// LockCluster acquires a named lock shared by all the nodes using the same database, waiting at most timeout.
+// It returns the function releasing the lock.
+// Sqlite is only used by single-node installs: the lock is then maintained for the current process only.
+func LockCluster(ctx context.Context, db *sql.DB, driver string, name string, timeout time.Duration) (func(), error) {
+	if driver != "mysql" {
+		clusterLocksLock.Lock()
+		mu, ok := clusterLocks[name]
+		if !ok {
+			mu = &sync.Mutex{}
+			clusterLocks[name] = mu
+		}
+		clusterLocksLock.Unlock()
+		mu.Lock()
+		return mu.Unlock, nil
+	}
+
+	// MySQL named locks belong to the connection that acquired them
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var res sql.NullInt64
+	if err := conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, ?)", name, int(timeout.Seconds())).Scan(&res); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if !res.Valid || res.Int64 != 1 {
+		conn.Close()
+		return nil, fmt.Errorf("could not acquire lock %s after %s", name, timeout)
+	}
+
+	return func() {
+		conn.ExecContext(context.Background(), "SELECT RELEASE_LOCK(?)", name)
+		conn.Close()
+	}, nil
+}
+
+// LockClusterDatabase opens the database and acquires the named lock with LockCluster. The returned function
+// releases the lock and closes the database.
+func LockClusterDatabase(ctx context.Context, driver, dsn, name string, timeout time.Duration) (func(), error) {
+	dao := NewDAO(driver, dsn, "")
+	if dao == nil {
+		return nil, fmt.Errorf("cannot connect to the database")
+	}
+	unlock, err := LockCluster(ctx, dao.DB(), driver, name, timeout)
+	if err != nil {
+		dao.CloseConn()
+		return nil, err
+	}
+	return func() {
+		unlock()
+		dao.CloseConn()
+	}, nil
+}
@@ -0,0 +1,66 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+var (
+	migrationsLock   sync.RWMutex
+	migrationsFailed = map[*sql.DB]map[string]error{}
+)
+
+// setMigrationState records the outcome of the last migration run for this prefix on this connection.
+func setMigrationState(db *sql.DB, prefix string, err error) {
+	migrationsLock.Lock()
+	defer migrationsLock.Unlock()
+	if err == nil {
+		delete(migrationsFailed[db], prefix)
+		return
+	}
+	if _, ok := migrationsFailed[db]; !ok {
+		migrationsFailed[db] = map[string]error{}
+	}
+	migrationsFailed[db][prefix] = err
+}
+
+// CheckHealth pings the database and verifies that no migration failed on this connection.
+func CheckHealth(ctx context.Context, db *sql.DB) error {
+	if err := db.PingContext(ctx); err != nil {
+		return err
+	}
+	migrationsLock.RLock()
+	defer migrationsLock.RUnlock()
+	var prefixes []string
+	for prefix := range migrationsFailed[db] {
+		prefixes = append(prefixes, prefix)
+	}
+	if len(prefixes) == 0 {
+		return nil
+	}
+	sort.Strings(prefixes)
+	return fmt.Errorf("migration failed for %s: %v", prefixes[0], migrationsFailed[db][prefixes[0]])
+}
@@ -53,7 +53,9 @@ func (b migrationById) Less(i, j int) bool { return b[i].Less(b[j]) }
 //
 // Returns the number of applied migrations.
 func ExecMigration(db *sql.DB, dialect string, m migrate.MigrationSource, dir migrate.MigrationDirection, prefix string) (int, error) {
-	return ExecMax(db, dialect, m, dir, 0, prefix)
+	n, err := ExecMax(db, dialect, m, dir, 0, prefix)
+	setMigrationState(db, prefix, err)
+	return n, err
 }
 
 // Execute a set of migrations
@@ -0,0 +1,195 @@
+/*
+ * Copyright (c) 2019. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package sftp
+
+import (
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pydio/cells/common/sync/model"
+)
+
+// pollEntry is the minimal state kept for each remote path between two polls
+type pollEntry struct {
+	folder bool
+	size   int64
+	mtime  int64
+}
+
+// Watch polls the remote folder at the configured interval and emits events for every
+// created, modified or removed path. SFTP has no notification mechanism, so moves are
+// seen as a removal followed by a creation.
+func (c *Client) Watch(recursivePath string) (*model.WatchObject, error) {
+
+	eventChan := make(chan model.EventInfo)
+	errorChan := make(chan error)
+	doneChan := make(chan bool)
+	connInfo := make(chan model.WatchConnectionInfo)
+
+	interval := c.config.PollInterval
+	if interval == 0 {
+		interval = DefaultPollInterval
+	}
+	root := strings.Trim(recursivePath, "/")
+	previous, e := c.pollListing(root)
+	if e != nil {
+		return nil, e
+	}
+
+	go func() {
+		defer func() {
+			close(eventChan)
+			close(errorChan)
+			close(connInfo)
+		}()
+		connected := true
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-doneChan:
+				return
+			case <-ticker.C:
+				current, er := c.pollListing(root)
+				if er != nil {
+					if connected {
+						connected = false
+						c.sendConnInfo(connInfo, doneChan, model.WatchDisconnected)
+					}
+					select {
+					case errorChan <- er:
+					case <-doneChan:
+						return
+					}
+					continue
+				}
+				if !connected {
+					connected = true
+					c.sendConnInfo(connInfo, doneChan, model.WatchConnected)
+				}
+				for _, ev := range c.diffListings(previous, current) {
+					select {
+					case eventChan <- ev:
+					case <-doneChan:
+						return
+					}
+				}
+				previous = current
+			}
+		}
+	}()
+
+	return &model.WatchObject{
+		EventInfoChan:  eventChan,
+		ErrorChan:      errorChan,
+		DoneChan:       doneChan,
+		ConnectionInfo: connInfo,
+	}, nil
+}
+
+func (c *Client) sendConnInfo(connInfo chan model.WatchConnectionInfo, done chan bool, info model.WatchConnectionInfo) {
+	select {
+	case connInfo <- info:
+	case <-done:
+	case <-time.After(5 * time.Second):
+	}
+}
+
+// pollListing lists the remote tree without loading folders uuids or files hashes
+func (c *Client) pollListing(root string) (map[string]pollEntry, error) {
+	cl, e := c.client()
+	if e != nil {
+		return nil, e
+	}
+	rootPath := c.fullPath(root)
+	listing := make(map[string]pollEntry)
+	walker := cl.Walk(rootPath)
+	for walker.Step() {
+		if er := walker.Err(); er != nil {
+			c.reset(er)
+			return nil, er
+		}
+		if walker.Path() == rootPath {
+			continue
+		}
+		stat := walker.Stat()
+		if strings.HasPrefix(stat.Name(), SyncTmpPrefix) {
+			continue
+		}
+		listing[c.relPath(walker.Path())] = pollEntry{
+			folder: stat.IsDir(),
+			size:   stat.Size(),
+			mtime:  stat.ModTime().Unix(),
+		}
+	}
+	return listing, nil
+}
+
+// diffListings computes events between two listings, parents first. Removals of children of a removed folder are not sent.
+func (c *Client) diffListings(previous, current map[string]pollEntry) (events []model.EventInfo) {
+	now := time.Now().Format(time.RFC3339)
+	var paths []string
+	for p := range current {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	for _, p := range paths {
+		entry := current[p]
+		old, ok := previous[p]
+		if ok && old.folder == entry.folder && (entry.folder || old.size == entry.size && old.mtime == entry.mtime) {
+			continue
+		}
+		events = append(events, model.EventInfo{
+			Time:   now,
+			Size:   entry.size,
+			Folder: entry.folder,
+			Path:   p,
+			Type:   model.EventCreate,
+			Source: c,
+		})
+	}
+	paths = paths[:0]
+	for p := range previous {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	for _, p := range paths {
+		if _, ok := current[p]; ok {
+			continue
+		}
+		if parent := path.Dir(p); parent != "." {
+			if _, parentGone := previous[parent]; parentGone {
+				if _, stillThere := current[parent]; !stillThere {
+					continue
+				}
+			}
+		}
+		events = append(events, model.EventInfo{
+			Time:   now,
+			Path:   p,
+			Type:   model.EventRemove,
+			Source: c,
+		})
+	}
+	return
+}
@@ -52,6 +52,7 @@ type BidirectionalPatch struct {
 	unexpected          []error
 	ctx                 context.Context
 	ignoreUUIDConflicts bool
+	conflictStrategy    ConflictStrategy
 }
 
 func NewBidirectionalPatch(ctx context.Context, source, target model.Endpoint) *BidirectionalPatch {
@@ -72,14 +73,18 @@ func NewBidirectionalPatch(ctx context.Context, source, target model.Endpoint) *
 	return b
 }
 
-// ComputeBidirectionalPatch merges two unidirectional Patch into one BidirectionalPatch
-func ComputeBidirectionalPatch(ctx context.Context, left, right Patch) (*BidirectionalPatch, error) {
+// ComputeBidirectionalPatch merges two unidirectional Patch into one BidirectionalPatch. An optional ConflictStrategy
+// can be passed to automatically solve conflicts, otherwise they are returned as errors.
+func ComputeBidirectionalPatch(ctx context.Context, left, right Patch, strategy ...ConflictStrategy) (*BidirectionalPatch, error) {
 	source := left.Source()
 	target, _ := model.AsPathSyncTarget(right.Source())
 	b := &BidirectionalPatch{
 		TreePatch: *newTreePatch(source, target, PatchOptions{MoveDetection: false}),
 		ctx:       ctx,
 	}
+	if len(strategy) > 0 {
+		b.conflictStrategy = strategy[0]
+	}
 
 	// If syncing on same server, do not trigger conflicts on .pydio
 	u1, _ := url.Parse(source.GetEndpointInfo().URI)
@@ -105,6 +110,7 @@ func ComputeBidirectionalPatch(ctx context.Context, left, right Patch) (*Bidirec
 			b.TreePatch = *newTreePatch(source, target, PatchOptions{MoveDetection: false})
 			b.mergeTrees(&l.TreeNode, &r.TreeNode)
 		}
+		b.pruneResolvedBranches()
 		log.Logger(ctx).Info("Merged Patch", zap.Any("stats", b.Stats()))
 	}
 	if len(b.unexpected) > 0 {
@@ -333,22 +339,17 @@ func (p *BidirectionalPatch) enqueueRight(left, right *TreeNode) {
 	p.enqueueOperations(right, OperationDirLeft)
 }
 
-// enqueueConflict sets a Conflict flag on the the given path in side the patch. The Conflict has references to left and right operations
+// enqueueConflict sets a Conflict flag on the the given path in side the patch. The Conflict has references to left and right operations.
+// If the patch has a ConflictStrategy, the conflict is resolved and will be applied by the processor.
 func (p *BidirectionalPatch) enqueueConflict(left, right *TreeNode, t ConflictType) {
-	log.Logger(p.ctx).Error("-- Unsolvable conflict!", zap.Any("left", left.PathOperation), zap.Any("right", right.PathOperation))
-	p.unexpected = append(p.unexpected, fmt.Errorf("registered conflict at path %s", left.Path))
-	var leftOp, rightOp Operation
-	if left.PathOperation != nil {
-		leftOp = left.PathOperation
-	} else if left.DataOperation != nil {
-		leftOp = left.DataOperation
-	}
-	if right.PathOperation != nil {
-		rightOp = right.PathOperation
-	} else if right.DataOperation != nil {
-		rightOp = right.DataOperation
+	op := p.newConflictOperation(&left.Node, t, conflictingOperation(left), conflictingOperation(right))
+	if r := op.(ConflictOperation).GetResolution(); r != nil {
+		log.Logger(p.ctx).Info("-- Conflict solved with strategy "+string(r.Strategy), zap.String("path", left.Path), zap.String("winner", r.Winner))
+	} else {
+		log.Logger(p.ctx).Error("-- Unsolvable conflict!", zap.Any("left", left.PathOperation), zap.Any("right", right.PathOperation))
+		p.unexpected = append(p.unexpected, fmt.Errorf("registered conflict at path %s", left.Path))
 	}
-	op := NewConflictOperation(&left.Node, t, leftOp, rightOp)
+	op.AttachToPatch(p)
 	p.QueueOperation(op)
 }
 
@@ -454,8 +455,9 @@ func (p *BidirectionalPatch) compareMoveTargets(left, right *TreeNode) {
 	}
 }
 
-// mergeDataOperations handles DataOperations (CreateFile, UpdateFile) by checking the nodes ETag. If they differ, auto-solving
-// is done by creating a -left and -right version on both side, so that users can fix them manually afterward.
+// mergeDataOperations handles DataOperations (CreateFile, UpdateFile) by checking the nodes ETag. If they differ, the conflict
+// is solved by the patch ConflictStrategy if any. Otherwise auto-solving is done by creating a -left and -right version on
+// both side, so that users can fix them manually afterward.
 func (p *BidirectionalPatch) mergeDataOperations(left, right *TreeNode) {
 	if left.DataOperation != nil && right.DataOperation != nil {
 		lOp := left.DataOperation
@@ -479,6 +481,15 @@ func (p *BidirectionalPatch) mergeDataOperations(left, right *TreeNode) {
 			}
 		}
 
+		if op := p.newConflictOperation(&left.Node, ConflictFileContent, lOp, rOp); op.(ConflictOperation).GetResolution() != nil {
+			log.Logger(p.ctx).Info("-- Conflict solved with strategy "+string(p.conflictStrategy), zap.String("path", initialPath))
+			op.AttachToPatch(p)
+			p.QueueOperation(op)
+			left.DataOperation = nil
+			right.DataOperation = nil
+			return
+		}
+
 		// TODO - FIND A CLEANER WAY ?
 		leftSuffix, rightSuffix := p.computeAutoFixSuffixes(left.DataOperation.Source().GetEndpointInfo(), right.DataOperation.Source().GetEndpointInfo())
 		leftSource, _ := model.AsPathSyncTarget(left.DataOperation.Source())
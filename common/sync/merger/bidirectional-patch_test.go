@@ -84,3 +84,183 @@ func TestMergeWithConflicts(t *testing.T) {
 	})
 
 }
+
+func TestConflictStrategies(t *testing.T) {
+	ctx := context.Background()
+	old := time.Now().Add(-time.Minute).Unix()
+	recent := time.Now().Unix()
+
+	prepare := func() (*memory.MemDB, *memory.MemDB, Patch, Patch) {
+		source, target := memory.NewMemDB(), memory.NewMemDB()
+		left := newTreePatch(source, target, PatchOptions{MoveDetection: false})
+		right := newTreePatch(target, source, PatchOptions{MoveDetection: false})
+		left.Enqueue(&patchOperation{OpType: OpCreateFolder, Node: &tree.Node{Path: "conflict", Type: tree.NodeType_COLLECTION, MTime: old}, EventInfo: model.EventInfo{Path: "conflict"}})
+		left.Enqueue(&patchOperation{OpType: OpCreateFile, Node: &tree.Node{Path: "conflict/child", Type: tree.NodeType_LEAF, Etag: "child"}, EventInfo: model.EventInfo{Path: "conflict/child"}})
+		right.Enqueue(&patchOperation{OpType: OpCreateFile, Node: &tree.Node{Path: "conflict", Type: tree.NodeType_LEAF, Etag: "etag", MTime: recent}, EventInfo: model.EventInfo{Path: "conflict"}})
+		return source, target, left, right
+	}
+
+	conflictOf := func(p Patch) ConflictOperation {
+		ops := p.OperationsByType([]OperationType{OpConflict})
+		So(ops, ShouldHaveLength, 1)
+		c, ok := ops[0].(ConflictOperation)
+		So(ok, ShouldBeTrue)
+		return c
+	}
+
+	Convey("Test manual strategy leaves conflicts unresolved", t, func() {
+		_, _, left, right := prepare()
+		bi, e := ComputeBidirectionalPatch(ctx, left, right)
+		So(e, ShouldNotBeNil)
+		So(conflictOf(bi).GetResolution(), ShouldBeNil)
+		_, has := bi.HasErrors()
+		So(has, ShouldBeTrue)
+		stats := bi.Stats()
+		So(stats["Conflicts"], ShouldHaveLength, 1)
+		So(stats["Resolutions"], ShouldBeNil)
+	})
+
+	Convey("Test left-wins strategy", t, func() {
+		source, target, left, right := prepare()
+		bi, e := ComputeBidirectionalPatch(ctx, left, right, ConflictStrategyLeftWins)
+		So(e, ShouldBeNil)
+		_, has := bi.HasErrors()
+		So(has, ShouldBeFalse)
+
+		c := conflictOf(bi)
+		r := c.GetResolution()
+		So(r, ShouldNotBeNil)
+		So(r.Winner, ShouldEqual, ConflictWinnerLeft)
+		So(r.Type, ShouldEqual, "PathOperation")
+		So(r.CopyPath, ShouldBeEmpty)
+
+		ops := c.ResolutionOperations()
+		So(ops, ShouldHaveLength, 2)
+		So(ops[0].Type(), ShouldEqual, OpDelete)
+		So(ops[0].GetRefPath(), ShouldEqual, "conflict")
+		So(ops[0].Target(), ShouldEqual, target)
+		So(ops[1].Type(), ShouldEqual, OpCreateFolder)
+		So(ops[1].GetRefPath(), ShouldEqual, "conflict")
+		So(ops[1].Source(), ShouldEqual, source)
+		So(ops[1].Target(), ShouldEqual, target)
+
+		// Winner children are kept
+		So(bi.OperationsByType([]OperationType{OpCreateFile}), ShouldHaveLength, 1)
+		So(bi.Stats()["Resolutions"], ShouldHaveLength, 1)
+	})
+
+	Convey("Test right-wins strategy prunes left changes inside the conflicting node", t, func() {
+		source, _, left, right := prepare()
+		bi, e := ComputeBidirectionalPatch(ctx, left, right, ConflictStrategyRightWins)
+		So(e, ShouldBeNil)
+		r := conflictOf(bi).GetResolution()
+		So(r.Winner, ShouldEqual, ConflictWinnerRight)
+		ops := conflictOf(bi).ResolutionOperations()
+		So(ops, ShouldHaveLength, 2)
+		So(ops[0].Type(), ShouldEqual, OpDelete)
+		So(ops[0].Target(), ShouldEqual, source)
+		So(ops[1].Type(), ShouldEqual, OpCreateFile)
+		So(ops[1].Target(), ShouldEqual, source)
+		So(bi.OperationsByType([]OperationType{OpCreateFile}), ShouldBeEmpty)
+	})
+
+	Convey("Test last-writer-wins strategy", t, func() {
+		_, _, left, right := prepare()
+		bi, e := ComputeBidirectionalPatch(ctx, left, right, ConflictStrategyLastWriterWins)
+		So(e, ShouldBeNil)
+		So(conflictOf(bi).GetResolution().Winner, ShouldEqual, ConflictWinnerRight)
+	})
+
+	Convey("Test keep-both strategy", t, func() {
+		source, target, left, right := prepare()
+		bi, e := ComputeBidirectionalPatch(ctx, left, right, ConflictStrategyKeepBoth)
+		So(e, ShouldBeNil)
+		r := conflictOf(bi).GetResolution()
+		So(r.Winner, ShouldEqual, ConflictWinnerRight)
+		So(r.LoserPath, ShouldEqual, "conflict")
+		So(r.CopyPath, ShouldStartWith, "conflict-conflict-left-")
+
+		ops := conflictOf(bi).ResolutionOperations()
+		So(ops, ShouldHaveLength, 3)
+		// Rename folder on the left
+		So(ops[0].Type(), ShouldEqual, OpMoveFolder)
+		So(ops[0].GetMoveOriginPath(), ShouldEqual, "conflict")
+		So(ops[0].GetRefPath(), ShouldEqual, r.CopyPath)
+		So(ops[0].Target(), ShouldEqual, source)
+		// Replicate renamed folder on the right
+		So(ops[1].Type(), ShouldEqual, OpCreateFolder)
+		So(ops[1].GetRefPath(), ShouldEqual, r.CopyPath)
+		So(ops[1].Target(), ShouldEqual, target)
+		// Replicate right file on the left
+		So(ops[2].Type(), ShouldEqual, OpCreateFile)
+		So(ops[2].GetRefPath(), ShouldEqual, "conflict")
+		So(ops[2].Target(), ShouldEqual, source)
+	})
+
+	Convey("Test conflicts are resolved when merging into an existing patch", t, func() {
+		_, _, left, right := prepare()
+		bi, e := ComputeBidirectionalPatch(ctx, left, right, ConflictStrategyLeftWins)
+		So(e, ShouldBeNil)
+		source, target := memory.NewMemDB(), memory.NewMemDB()
+		global := NewBidirectionalPatch(ctx, source, target)
+		global.AppendBranch(ctx, bi)
+		c := conflictOf(global)
+		So(c.GetResolution(), ShouldNotBeNil)
+		So(c.ResolutionOperations()[0].Target(), ShouldEqual, target)
+		_, has := global.HasErrors()
+		So(has, ShouldBeFalse)
+	})
+
+	Convey("Test file content conflicts", t, func() {
+		prepareContent := func() (*memory.MemDB, *memory.MemDB, Patch, Patch) {
+			source, target := memory.NewMemDB(), memory.NewMemDB()
+			left := newTreePatch(source, target, PatchOptions{MoveDetection: false})
+			right := newTreePatch(target, source, PatchOptions{MoveDetection: false})
+			left.Enqueue(&patchOperation{OpType: OpUpdateFile, Node: &tree.Node{Path: "file.txt", Type: tree.NodeType_LEAF, Etag: "left", MTime: recent}, EventInfo: model.EventInfo{Path: "file.txt"}})
+			right.Enqueue(&patchOperation{OpType: OpUpdateFile, Node: &tree.Node{Path: "file.txt", Type: tree.NodeType_LEAF, Etag: "right", MTime: old}, EventInfo: model.EventInfo{Path: "file.txt"}})
+			return source, target, left, right
+		}
+
+		_, target, left, right := prepareContent()
+		bi, e := ComputeBidirectionalPatch(ctx, left, right, ConflictStrategyLastWriterWins)
+		So(e, ShouldBeNil)
+		r := conflictOf(bi).GetResolution()
+		So(r, ShouldNotBeNil)
+		So(r.Type, ShouldEqual, "FileContent")
+		So(r.Winner, ShouldEqual, ConflictWinnerLeft)
+		ops := conflictOf(bi).ResolutionOperations()
+		So(ops, ShouldHaveLength, 1)
+		So(ops[0].Type(), ShouldEqual, OpCreateFile)
+		So(ops[0].GetNode().GetEtag(), ShouldEqual, "left")
+		So(ops[0].Target(), ShouldEqual, target)
+		So(bi.OperationsByType([]OperationType{OpUpdateFile, OpCreateFile}), ShouldBeEmpty)
+
+		source, target, left, right := prepareContent()
+		bi, e = ComputeBidirectionalPatch(ctx, left, right, ConflictStrategyKeepBoth)
+		So(e, ShouldBeNil)
+		r = conflictOf(bi).GetResolution()
+		So(r.CopyPath, ShouldStartWith, "file-conflict-right-")
+		So(r.CopyPath, ShouldEndWith, ".txt")
+		ops = conflictOf(bi).ResolutionOperations()
+		So(ops, ShouldHaveLength, 3)
+		So(ops[0].Type(), ShouldEqual, OpMoveFile)
+		So(ops[0].Target(), ShouldEqual, target)
+		So(ops[1].Type(), ShouldEqual, OpCreateFile)
+		So(ops[1].GetRefPath(), ShouldEqual, r.CopyPath)
+		So(ops[1].Target(), ShouldEqual, source)
+		So(ops[2].GetRefPath(), ShouldEqual, "file.txt")
+		So(ops[2].Target(), ShouldEqual, target)
+	})
+
+	Convey("Test strategies parsing", t, func() {
+		s, e := ParseConflictStrategy("")
+		So(e, ShouldBeNil)
+		So(s, ShouldEqual, ConflictStrategyManual)
+		s, e = ParseConflictStrategy(" Keep-Both ")
+		So(e, ShouldBeNil)
+		So(s, ShouldEqual, ConflictStrategyKeepBoth)
+		_, e = ParseConflictStrategy("random")
+		So(e, ShouldNotBeNil)
+	})
+
+}
@@ -0,0 +1,293 @@
+/*
+ * Copyright (c) 2019. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package merger
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/pydio/cells/common/proto/tree"
+	"github.com/pydio/cells/common/sync/model"
+)
+
+// ConflictStrategy defines how a BidirectionalPatch solves conflicts that cannot be merged automatically
+type ConflictStrategy string
+
+const (
+	// ConflictStrategyManual leaves conflicts unresolved: they are reported as errors and the patch is not processed
+	ConflictStrategyManual ConflictStrategy = "manual"
+	// ConflictStrategyLastWriterWins applies the most recent change on both sides
+	ConflictStrategyLastWriterWins ConflictStrategy = "last-writer-wins"
+	// ConflictStrategyLeftWins always applies the left change on both sides
+	ConflictStrategyLeftWins ConflictStrategy = "left-wins"
+	// ConflictStrategyRightWins always applies the right change on both sides
+	ConflictStrategyRightWins ConflictStrategy = "right-wins"
+	// ConflictStrategyKeepBoth applies the most recent change, and renames the other one to a conflict copy
+	// that is replicated on both sides
+	ConflictStrategyKeepBoth ConflictStrategy = "keep-both"
+
+	// ConflictWinnerLeft is set in ConflictResolution.Winner when left change is applied
+	ConflictWinnerLeft = "left"
+	// ConflictWinnerRight is set in ConflictResolution.Winner when right change is applied
+	ConflictWinnerRight = "right"
+)
+
+// ParseConflictStrategy checks a strategy name. An empty string is parsed as ConflictStrategyManual.
+func ParseConflictStrategy(s string) (ConflictStrategy, error) {
+	switch strategy := ConflictStrategy(strings.ToLower(strings.TrimSpace(s))); strategy {
+	case "":
+		return ConflictStrategyManual, nil
+	case ConflictStrategyManual, ConflictStrategyLastWriterWins, ConflictStrategyLeftWins, ConflictStrategyRightWins, ConflictStrategyKeepBoth:
+		return strategy, nil
+	}
+	return ConflictStrategyManual, fmt.Errorf("unsupported conflict strategy %s", s)
+}
+
+// ConflictResolution records the decision taken for a given conflict. It is serialized along with the
+// conflict operation for audit purposes.
+type ConflictResolution struct {
+	Path      string
+	Type      string
+	Strategy  ConflictStrategy
+	Winner    string
+	LoserPath string
+	CopyPath  string `json:",omitempty"`
+	Left      string
+	Right     string
+	Date      time.Time
+	Applied   bool
+	Error     string `json:",omitempty"`
+}
+
+// SetConflictStrategy changes the way conflicts are handled by this patch. It must be called before the
+// left and right changes are merged.
+func (p *BidirectionalPatch) SetConflictStrategy(strategy ConflictStrategy) {
+	p.conflictStrategy = strategy
+}
+
+// newConflictOperation creates a conflict operation and tries to resolve it with the current strategy
+func (p *BidirectionalPatch) newConflictOperation(node *tree.Node, t ConflictType, left, right Operation) Operation {
+	c := NewConflictOperation(node, t, left, right).(*conflictOperation)
+	c.Resolution = p.resolveConflict(c)
+	return c
+}
+
+// resolveConflict finds which side wins for a given conflict. It returns nil if the strategy is manual, or if the
+// conflict type cannot be solved automatically.
+func (p *BidirectionalPatch) resolveConflict(c *conflictOperation) *ConflictResolution {
+	switch p.conflictStrategy {
+	case ConflictStrategyLastWriterWins, ConflictStrategyLeftWins, ConflictStrategyRightWins, ConflictStrategyKeepBoth:
+	default:
+		return nil
+	}
+	switch c.ConflictType {
+	case ConflictNodeType, ConflictPathOperation, ConflictMoveSameSource, ConflictFileContent:
+	default:
+		return nil
+	}
+	if c.LeftOp == nil || c.RightOp == nil {
+		return nil
+	}
+	if c.ConflictType == ConflictMoveSameSource && (!c.LeftOp.IsTypeMove() || !c.RightOp.IsTypeMove()) {
+		return nil
+	}
+	r := &ConflictResolution{
+		Path:     c.GetRefPath(),
+		Type:     c.ConflictType.String(),
+		Strategy: p.conflictStrategy,
+		Left:     c.LeftOp.String(),
+		Right:    c.RightOp.String(),
+		Date:     time.Now(),
+	}
+	leftWins := true
+	switch p.conflictStrategy {
+	case ConflictStrategyRightWins:
+		leftWins = false
+	case ConflictStrategyLastWriterWins, ConflictStrategyKeepBoth:
+		l, rr := c.LeftOp.GetNode(), c.RightOp.GetNode()
+		leftWins = l != nil && (rr == nil || MostRecentNode(l, rr) == l)
+	}
+	loser := c.RightOp
+	r.Winner = ConflictWinnerLeft
+	if !leftWins {
+		loser = c.LeftOp
+		r.Winner = ConflictWinnerRight
+	}
+	if c.ConflictType == ConflictMoveSameSource {
+		r.LoserPath = r.Path
+	} else {
+		r.LoserPath = loser.GetRefPath()
+	}
+	if p.conflictStrategy == ConflictStrategyKeepBoth {
+		leftSuffix, rightSuffix := p.computeAutoFixSuffixes(p.Source().GetEndpointInfo(), p.Target().GetEndpointInfo())
+		suffix := rightSuffix
+		if !leftWins {
+			suffix = leftSuffix
+		}
+		ext := path.Ext(r.LoserPath)
+		r.CopyPath = fmt.Sprintf("%s-conflict-%s-%s%s", strings.TrimSuffix(r.LoserPath, ext), suffix, r.Date.Format("20060102150405"), ext)
+	}
+	return r
+}
+
+// pruneResolvedBranches removes operations made obsolete by conflicts resolutions: the changes performed
+// on the losing side below a conflicting node, or the losing move for ConflictMoveSameSource.
+func (p *BidirectionalPatch) pruneResolvedBranches() {
+	var resolved []*conflictOperation
+	p.WalkOperations([]OperationType{OpConflict}, func(o Operation) {
+		if c, ok := o.(*conflictOperation); ok && c.Resolution != nil {
+			resolved = append(resolved, c)
+		}
+	})
+	for _, c := range resolved {
+		toWinner, loser := OperationDirLeft, c.RightOp
+		if c.Resolution.Winner == ConflictWinnerRight {
+			toWinner, loser = OperationDirRight, c.LeftOp
+		}
+		fromLoser := func(o Operation) bool {
+			po, ok := o.(*patchOperation)
+			return ok && po.Dir == toWinner
+		}
+		if c.ConflictType == ConflictMoveSameSource {
+			if n := p.ChildByPath(loser.GetMoveOriginPath()); n != nil && n.PathOperation != nil && fromLoser(n.PathOperation) {
+				n.PathOperation = nil
+				n.OpMoveTarget = nil
+			}
+			continue
+		}
+		n := p.ChildByPath(c.GetRefPath())
+		if n == nil {
+			continue
+		}
+		for _, child := range n.SortedChildren() {
+			child.Walk(func(t *TreeNode) bool {
+				if t.PathOperation != nil && fromLoser(t.PathOperation) {
+					t.PathOperation = nil
+					t.OpMoveTarget = nil
+				}
+				if t.DataOperation != nil && fromLoser(t.DataOperation) {
+					t.DataOperation = nil
+				}
+				return false
+			})
+		}
+	}
+}
+
+// ResolutionOperations builds the operations enforcing the resolution. The conflicting path is first
+// freed on the losing side, by deleting the node or by renaming it to the conflict copy. The copy is then
+// replicated on the winning side, and the winning change is replayed on the losing side.
+// For ConflictMoveSameSource, the winning move is already part of the patch and is not replayed. For ConflictFileContent,
+// the winning content simply overwrites the losing one when no copy is kept.
+// Changes performed inside a losing folder are not replicated, they are picked by the next sync.
+func (c *conflictOperation) ResolutionOperations() (ops []Operation) {
+	r := c.Resolution
+	if r == nil || c.LeftOp == nil || c.RightOp == nil {
+		return
+	}
+	winner, loser := c.LeftOp, c.RightOp
+	toLoser, toWinner := OperationDirRight, OperationDirLeft
+	if r.Winner == ConflictWinnerRight {
+		winner, loser = c.RightOp, c.LeftOp
+		toLoser, toWinner = OperationDirLeft, OperationDirRight
+	}
+	loserLeaf := isLeafOperation(loser)
+	if r.CopyPath != "" {
+		ops = append(ops, c.resolutionOperation(moveOperationType(loserLeaf), r.CopyPath, nodeFromOperation(nil, r.LoserPath, loserLeaf), toLoser))
+		if c.ConflictType == ConflictMoveSameSource {
+			ops = append(ops, c.resolutionOperation(moveOperationType(loserLeaf), r.CopyPath, nodeFromOperation(nil, loser.GetMoveOriginPath(), loserLeaf), toWinner))
+		} else {
+			ops = append(ops, c.resolutionOperation(createOperationType(loserLeaf), r.CopyPath, nodeFromOperation(loser, r.CopyPath, loserLeaf), toWinner))
+		}
+	} else if c.ConflictType != ConflictFileContent {
+		ops = append(ops, c.resolutionOperation(OpDelete, r.LoserPath, nodeFromOperation(nil, r.LoserPath, loserLeaf), toLoser))
+	}
+	if c.ConflictType != ConflictMoveSameSource {
+		winnerLeaf := isLeafOperation(winner)
+		ops = append(ops, c.resolutionOperation(createOperationType(winnerLeaf), winner.GetRefPath(), nodeFromOperation(winner, winner.GetRefPath(), winnerLeaf), toLoser))
+	}
+	return
+}
+
+func (c *conflictOperation) resolutionOperation(t OperationType, refPath string, node *tree.Node, dir OperationDirection) Operation {
+	op := NewOperation(t, model.EventInfo{Path: refPath}, node)
+	op.SetDirection(dir)
+	op.AttachToPatch(c.patch)
+	return op
+}
+
+// conflictingOperation finds the operation registered on a conflicting node. For a move target, it is
+// registered on the move source.
+func conflictingOperation(n *TreeNode) Operation {
+	if n.PathOperation != nil {
+		return n.PathOperation
+	} else if n.DataOperation != nil {
+		return n.DataOperation
+	} else if n.MoveSourcePath != "" {
+		if src := n.getRoot().ChildByPath(n.MoveSourcePath); src != nil {
+			return src.PathOperation
+		}
+	}
+	return nil
+}
+
+func isLeafOperation(o Operation) bool {
+	switch o.Type() {
+	case OpCreateFile, OpUpdateFile, OpMoveFile:
+		return true
+	case OpCreateFolder, OpMoveFolder:
+		return false
+	}
+	return o.GetNode() != nil && o.GetNode().IsLeaf()
+}
+
+func createOperationType(leaf bool) OperationType {
+	if leaf {
+		return OpCreateFile
+	}
+	return OpCreateFolder
+}
+
+func moveOperationType(leaf bool) OperationType {
+	if leaf {
+		return OpMoveFile
+	}
+	return OpMoveFolder
+}
+
+// nodeFromOperation clones the operation node, if any, with a new path
+func nodeFromOperation(o Operation, p string, leaf bool) *tree.Node {
+	var n *tree.Node
+	if o != nil && o.GetNode() != nil {
+		n = o.GetNode().Clone()
+	} else {
+		n = &tree.Node{}
+		if leaf {
+			n.Type = tree.NodeType_LEAF
+		} else {
+			n.Type = tree.NodeType_COLLECTION
+		}
+	}
+	n.Path = p
+	return n
+}
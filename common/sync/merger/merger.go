@@ -45,6 +45,27 @@ const (
 	ConflictMetaChanged
 )
 
+// String gives a string representation of this integer type
+func (t ConflictType) String() string {
+	switch t {
+	case ConflictFolderUUID:
+		return "FolderUUID"
+	case ConflictFileContent:
+		return "FileContent"
+	case ConflictNodeType:
+		return "NodeType"
+	case ConflictPathOperation:
+		return "PathOperation"
+	case ConflictMoveSameSource:
+		return "MoveSameSource"
+	case ConflictMoveSameTarget:
+		return "MoveSameTarget"
+	case ConflictMetaChanged:
+		return "MetaChanged"
+	}
+	return ""
+}
+
 type OperationDirection int
 
 const (
@@ -88,6 +109,8 @@ func (t OperationType) String() string {
 		return "Delete"
 	case OpRefreshUuid:
 		return "RefreshUuid"
+	case OpConflict:
+		return "Conflict"
 	case OpUpdateMeta:
 		return "UpdateMetadata"
 	case OpCreateMeta:
@@ -175,8 +198,14 @@ type Operation interface {
 	CreateContext(ctx context.Context) context.Context
 }
 
+// ConflictOperation is an Operation registering two conflicting changes on the same node
 type ConflictOperation interface {
 	ConflictInfo() (t ConflictType, left Operation, right Operation)
+	// GetResolution returns the decision taken by the ConflictStrategy of the patch, or nil if the conflict
+	// must be solved manually.
+	GetResolution() *ConflictResolution
+	// ResolutionOperations builds the operations to be applied by a processor to enforce the resolution, in order.
+	ResolutionOperations() []Operation
 }
 
 // OpWalker is a callback passed to the Walk functions of a patch
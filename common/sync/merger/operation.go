@@ -48,6 +48,7 @@ type conflictOperation struct {
 	ConflictType ConflictType
 	LeftOp       Operation
 	RightOp      Operation
+	Resolution   *ConflictResolution
 }
 
 func NewOperation(t OperationType, e model.EventInfo, loadedNode ...*tree.Node) Operation {
@@ -65,9 +66,10 @@ func NewOperation(t OperationType, e model.EventInfo, loadedNode ...*tree.Node)
 func NewConflictOperation(node *tree.Node, t ConflictType, left, right Operation) Operation {
 	return &conflictOperation{
 		patchOperation: patchOperation{
-			OpType: OpConflict,
-			Node:   node,
-			Dir:    OperationDirDefault,
+			OpType:    OpConflict,
+			Node:      node,
+			EventInfo: model.EventInfo{Path: node.GetPath()},
+			Dir:       OperationDirDefault,
 		},
 		ConflictType: t,
 		LeftOp:       left,
@@ -79,8 +81,14 @@ func (c *conflictOperation) ConflictInfo() (t ConflictType, left Operation, righ
 	return c.ConflictType, c.LeftOp, c.RightOp
 }
 
+func (c *conflictOperation) GetResolution() *ConflictResolution {
+	return c.Resolution
+}
+
 func (c *conflictOperation) Clone(replaceType ...OperationType) Operation {
-	return NewConflictOperation(c.Node, c.ConflictType, c.LeftOp, c.RightOp)
+	clone := NewConflictOperation(c.Node, c.ConflictType, c.LeftOp, c.RightOp).(*conflictOperation)
+	clone.Resolution = c.Resolution
+	return clone
 }
 
 func (o *patchOperation) Clone(replaceType ...OperationType) Operation {
@@ -280,6 +288,8 @@ func (o *patchOperation) String() string {
 		return "Delete" + dir
 	case OpRefreshUuid:
 		return "RefreshUuid" + dir
+	case OpConflict:
+		return "Conflict" + dir
 	case OpCreateMeta, OpUpdateMeta, OpDeleteMeta:
 		return o.OpType.String() + dir
 	default:
@@ -232,7 +232,7 @@ func (diff *TreeDiff) ToBidirectionalPatch(leftTarget model.PathSyncTarget, righ
 	diff.solveConflicts(diff.ctx)
 
 	leftPatch, rightPatch := diff.leftAndRightPatches(leftTarget, rightTarget)
-	b, err = ComputeBidirectionalPatch(diff.ctx, leftPatch, rightPatch)
+	b, err = ComputeBidirectionalPatch(diff.ctx, leftPatch, rightPatch, patch.conflictStrategy)
 	if err != nil {
 		return
 	}
@@ -250,8 +250,9 @@ func (diff *TreeDiff) ToBidirectionalPatch(leftTarget model.PathSyncTarget, righ
 		} else {
 			rightOp = NewOperation(OpCreateFolder, model.EventInfo{Path: c.NodeRight.Path}, c.NodeRight)
 		}
-		b.Enqueue(NewConflictOperation(c.NodeLeft, c.Type, leftOp, rightOp))
+		b.Enqueue(b.newConflictOperation(c.NodeLeft, c.Type, leftOp, rightOp))
 	}
+	b.pruneResolvedBranches()
 	if errs, ok := b.HasErrors(); ok {
 		err = fmt.Errorf("diff has conflicts %v", errs)
 	}
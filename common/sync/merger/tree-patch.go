@@ -212,7 +212,7 @@ func (t *TreePatch) HasErrors() (errs []error, has bool) {
 	t.WalkOperations([]OperationType{}, func(operation Operation) {
 		if e := operation.Error(); e != nil {
 			errs = append(errs, e)
-		} else if operation.Type() == OpConflict {
+		} else if c, ok := operation.(ConflictOperation); ok && c.GetResolution() == nil {
 			errs = append(errs, fmt.Errorf("conflict on path %s", operation.GetRefPath()))
 		}
 	})
@@ -237,7 +237,7 @@ func (t *TreePatch) ProgressTotal() int64 {
 		var total int64
 		t.WalkOperations([]OperationType{}, func(operation Operation) {
 			switch operation.Type() {
-			case OpCreateFolder, OpMoveFolder, OpMoveFile, OpDelete:
+			case OpCreateFolder, OpMoveFolder, OpMoveFile, OpDelete, OpConflict:
 				total++
 			case OpCreateFile, OpUpdateFile:
 				total += operation.GetNode().Size
@@ -260,11 +260,23 @@ func (t *TreePatch) Stats() map[string]interface{} {
 		"Source": t.Source().GetEndpointInfo().URI,
 		"Target": t.Target().GetEndpointInfo().URI,
 	}
+	var conflicts []map[string]string
+	var resolutions []*ConflictResolution
 	t.WalkOperations([]OperationType{}, func(operation Operation) {
+		unresolved := false
+		if c, ok := operation.(ConflictOperation); ok {
+			if r := c.GetResolution(); r != nil {
+				resolutions = append(resolutions, r)
+			} else {
+				cType, _, _ := c.ConflictInfo()
+				conflicts = append(conflicts, map[string]string{"Path": operation.GetRefPath(), "Type": cType.String()})
+				unresolved = true
+			}
+		}
 		var target map[string]int
 		if operation.IsProcessed() {
 			target = processed
-		} else if (operation.GetStatus() != nil && operation.GetStatus().IsError()) || operation.Type() == OpConflict {
+		} else if (operation.GetStatus() != nil && operation.GetStatus().IsError()) || unresolved {
 			target = errors
 		} else {
 			target = pending
@@ -290,6 +302,12 @@ func (t *TreePatch) Stats() map[string]interface{} {
 	if len(pending) > 0 {
 		s["Pending"] = pending
 	}
+	if len(conflicts) > 0 {
+		s["Conflicts"] = conflicts
+	}
+	if len(resolutions) > 0 {
+		s["Resolutions"] = resolutions
+	}
 	//t.PrintTree()
 	return s
 }
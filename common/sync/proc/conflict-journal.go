@@ -0,0 +1,103 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package proc
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"path/filepath"
+	"time"
+
+	"github.com/etcd-io/bbolt"
+
+	"github.com/pydio/cells/common/sync/merger"
+)
+
+var conflictsBucket = []byte("conflicts")
+
+// ConflictJournal persists the conflicts resolutions applied by the processor, for audit purposes
+type ConflictJournal interface {
+	// Record appends a resolution to the journal
+	Record(resolution *merger.ConflictResolution) error
+	// List returns all recorded resolutions, oldest first
+	List() ([]*merger.ConflictResolution, error)
+}
+
+// BoltConflictJournal is a ConflictJournal stored in a bolt file
+type BoltConflictJournal struct {
+	db *bbolt.DB
+}
+
+// NewBoltConflictJournal opens or creates a "conflicts" bolt file inside the given folder
+func NewBoltConflictJournal(folderPath string) (*BoltConflictJournal, error) {
+	options := bbolt.DefaultOptions
+	options.Timeout = 5 * time.Second
+	db, err := bbolt.Open(filepath.Join(folderPath, "conflicts"), 0644, options)
+	if err != nil {
+		return nil, err
+	}
+	if err := db.Update(func(tx *bbolt.Tx) error {
+		_, e := tx.CreateBucketIfNotExists(conflictsBucket)
+		return e
+	}); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &BoltConflictJournal{db: db}, nil
+}
+
+// Record implements ConflictJournal interface
+func (j *BoltConflictJournal) Record(resolution *merger.ConflictResolution) error {
+	data, e := json.Marshal(resolution)
+	if e != nil {
+		return e
+	}
+	return j.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(conflictsBucket)
+		seq, e := b.NextSequence()
+		if e != nil {
+			return e
+		}
+		key := make([]byte, 8)
+		binary.BigEndian.PutUint64(key, seq)
+		return b.Put(key, data)
+	})
+}
+
+// List implements ConflictJournal interface
+func (j *BoltConflictJournal) List() (resolutions []*merger.ConflictResolution, err error) {
+	err = j.db.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket(conflictsBucket).ForEach(func(k, v []byte) error {
+			var r merger.ConflictResolution
+			if e := json.Unmarshal(v, &r); e != nil {
+				return e
+			}
+			resolutions = append(resolutions, &r)
+			return nil
+		})
+	})
+	return
+}
+
+// Close closes the underlying bolt file
+func (j *BoltConflictJournal) Close() error {
+	return j.db.Close()
+}
@@ -0,0 +1,73 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package proc
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/pydio/cells/common/sync/merger"
+)
+
+// processConflict applies the operations enforcing a conflict resolution, and records the result
+// in the ConflictJournal if one is set.
+func (pr *Processor) processConflict(ctx context.Context, operation merger.Operation, operationId string, pg chan int64) (err error) {
+
+	c, ok := operation.(merger.ConflictOperation)
+	if !ok || c.GetResolution() == nil {
+		return fmt.Errorf("unresolved conflict on path %s", operation.GetRefPath())
+	}
+	resolution := c.GetResolution()
+	defer func() {
+		resolution.Applied = err == nil
+		if err != nil {
+			resolution.Error = err.Error()
+		}
+		if pr.ConflictJournal != nil {
+			if e := pr.ConflictJournal.Record(resolution); e != nil {
+				pr.Logger().Error("Cannot record conflict resolution", zap.String("path", resolution.Path), zap.Error(e))
+			}
+		}
+	}()
+
+	for _, op := range c.ResolutionOperations() {
+		var cb ProcessFunc
+		switch op.Type() {
+		case merger.OpDelete:
+			cb = pr.processDelete
+		case merger.OpMoveFile, merger.OpMoveFolder:
+			cb = pr.processMove
+		case merger.OpCreateFolder:
+			cb = pr.processCreateFolder
+		case merger.OpCreateFile:
+			cb = pr.processCreateFile
+		default:
+			continue
+		}
+		if err = cb(ctx, op, operationId, pg); err != nil {
+			return
+		}
+	}
+	return
+
+}
@@ -60,6 +60,7 @@ type Processor struct {
 	SkipTargetChecks bool
 	Ignores          []glob.Glob
 	PatchListener    merger.PatchListener
+	ConflictJournal  ConflictJournal
 }
 
 // NewProcessor creates a new processor
@@ -234,7 +235,8 @@ func (pr *Processor) Process(patch merger.Patch, cmd *model.Command) {
 		pr.applyProcessFunc(ctx, patch, op, processUUID, &cursor, total, false)
 	}
 
-	patch.WalkOperations([]merger.OperationType{merger.OpCreateFolder}, serialWalker)
+	// Resolved conflicts are walked along with folders, so that a conflicting path is freed before its children are created
+	patch.WalkOperations([]merger.OperationType{merger.OpCreateFolder, merger.OpConflict}, serialWalker)
 	patch.WalkOperations([]merger.OperationType{merger.OpMoveFolder}, serialWalker)
 	patch.WalkOperations([]merger.OperationType{merger.OpMoveFile}, serialWalker)
 	if patch.HasTransfers() {
@@ -386,6 +388,12 @@ func (pr *Processor) dataForOperation(p merger.Patch, op merger.Operation) (cb P
 		complete = "Deleted " + nS
 		error = "Error while deleting " + nS
 		fields = append(fields, zap.String(common.KeyNodePath, op.GetRefPath()))
+	case merger.OpConflict:
+		cb = pr.processConflict
+		progress = "Resolving conflict"
+		complete = "Resolved conflict"
+		error = "Error while resolving conflict"
+		fields = append(fields, zap.String(common.KeyNodePath, op.GetRefPath()))
 	case merger.OpCreateMeta:
 		cb = pr.processMetadata
 		progress = "Creating metadata"
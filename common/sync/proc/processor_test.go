@@ -22,6 +22,8 @@ package proc
 
 import (
 	"context"
+	"io/ioutil"
+	"os"
 	"testing"
 	"time"
 
@@ -124,3 +126,57 @@ func TestProcess(t *testing.T) {
 	})
 
 }
+
+func TestProcessConflict(t *testing.T) {
+
+	Convey("Test applying conflict resolutions", t, func() {
+
+		left := memory.NewMemDB()
+		right := memory.NewMemDB()
+		left.CreateNode(testCtx, &tree.Node{Path: "conflict", Type: tree.NodeType_COLLECTION, Uuid: "left-uuid", MTime: 1}, true)
+		right.CreateNode(testCtx, &tree.Node{Path: "conflict", Type: tree.NodeType_LEAF, Etag: "right-etag", MTime: 2}, true)
+
+		leftPatch := merger.NewPatch(left, right, merger.PatchOptions{})
+		leftPatch.Enqueue(merger.NewOperation(merger.OpCreateFolder, model.EventInfo{Path: "conflict"}, &tree.Node{Path: "conflict", Type: tree.NodeType_COLLECTION, Uuid: "left-uuid", MTime: 1}))
+		rightPatch := merger.NewPatch(right, left, merger.PatchOptions{})
+		rightPatch.Enqueue(merger.NewOperation(merger.OpCreateFile, model.EventInfo{Path: "conflict"}, &tree.Node{Path: "conflict", Type: tree.NodeType_LEAF, Etag: "right-etag", MTime: 2}))
+
+		bi, e := merger.ComputeBidirectionalPatch(testCtx, leftPatch, rightPatch, merger.ConflictStrategyKeepBoth)
+		So(e, ShouldBeNil)
+
+		dir, _ := ioutil.TempDir("", "conflicts")
+		defer os.RemoveAll(dir)
+		journal, e := NewBoltConflictJournal(dir)
+		So(e, ShouldBeNil)
+		defer journal.Close()
+
+		m := NewProcessor(testCtx)
+		m.SkipTargetChecks = true
+		m.ConflictJournal = journal
+		m.Process(bi, nil)
+
+		// Most recent file is kept on both sides
+		n, e := left.LoadNode(testCtx, "conflict")
+		So(e, ShouldBeNil)
+		So(n.IsLeaf(), ShouldBeTrue)
+		So(n.Etag, ShouldEqual, "right-etag")
+		n, e = right.LoadNode(testCtx, "conflict")
+		So(e, ShouldBeNil)
+		So(n.IsLeaf(), ShouldBeTrue)
+
+		// Folder is renamed on both sides
+		resolutions, e := journal.List()
+		So(e, ShouldBeNil)
+		So(resolutions, ShouldHaveLength, 1)
+		So(resolutions[0].Applied, ShouldBeTrue)
+		So(resolutions[0].Winner, ShouldEqual, merger.ConflictWinnerRight)
+		n, e = left.LoadNode(testCtx, resolutions[0].CopyPath)
+		So(e, ShouldBeNil)
+		So(n.IsLeaf(), ShouldBeFalse)
+		n, e = right.LoadNode(testCtx, resolutions[0].CopyPath)
+		So(e, ShouldBeNil)
+		So(n.IsLeaf(), ShouldBeFalse)
+
+	})
+
+}
@@ -46,6 +46,7 @@ func (s *Sync) run(ctx context.Context, dryRun bool, force bool) (model.Stater,
 
 		// INIT BI PATCH
 		bb := merger.NewBidirectionalPatch(ctx, s.Source, s.Target)
+		bb.SetConflictStrategy(s.ConflictStrategy)
 		bb.SetSessionData(ctx, false)
 		bb.SetupChannels(s.statuses, s.runDone, s.cmd)
 
@@ -185,7 +186,7 @@ func (s *Sync) runBi(ctx context.Context, bb *merger.BidirectionalPatch, dryRun
 
 		log.Logger(ctx).Info("Computing patches from Snapshots")
 		for _, r := range roots {
-			b, e := merger.ComputeBidirectionalPatch(ctx, leftPatches[r], rightPatches[r])
+			b, e := merger.ComputeBidirectionalPatch(ctx, leftPatches[r], rightPatches[r], s.ConflictStrategy)
 			if b != nil {
 				bb.AppendBranch(ctx, b)
 			}
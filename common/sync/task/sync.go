@@ -51,12 +51,14 @@ type Sync struct {
 	Ignores          []glob.Glob
 	SkipTargetChecks bool
 	FailsafeDeletes  bool
+	ConflictStrategy merger.ConflictStrategy
 
 	snapshotFactory model.SnapshotFactory
 	echoFilter      *filters.EchoFilter
 	eventsBatchers  []*filters.EventsBatcher
 	processor       *proc.ConnectedProcessor
 	patchListener   merger.PatchListener
+	conflictJournal proc.ConflictJournal
 
 	watch        bool
 	watchersChan []chan bool
@@ -97,6 +99,11 @@ func (s *Sync) SetPatchListener(listener merger.PatchListener) {
 	s.patchListener = listener
 }
 
+// SetConflictJournal sets a journal where conflicts resolutions are recorded once they are applied
+func (s *Sync) SetConflictJournal(journal proc.ConflictJournal) {
+	s.conflictJournal = journal
+}
+
 // Start makes a first sync and setup watchers
 func (s *Sync) Start(ctx context.Context, withWatches bool) {
 
@@ -110,6 +117,7 @@ func (s *Sync) Start(ctx context.Context, withWatches bool) {
 		s.processor.PatchListener = s.patchListener
 	}
 	s.processor.Ignores = s.Ignores
+	s.processor.ConflictJournal = s.conflictJournal
 	s.processor.Start()
 
 	// Init EchoFilter
@@ -0,0 +1,194 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+// Package clamd provides a minimal client for the ClamAV daemon, supporting the PING and INSTREAM commands.
+package clamd
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultChunkSize is the size of the chunks sent to clamd. It must stay below the StreamMaxLength clamd setting.
+	DefaultChunkSize = 64 * 1024
+	// DefaultTimeout is applied to each network operation
+	DefaultTimeout = 60 * time.Second
+
+	// StatusClean is returned when no virus was found
+	StatusClean = "OK"
+	// StatusInfected is returned when a signature matched
+	StatusInfected = "FOUND"
+	// StatusError is returned when clamd could not scan the stream
+	StatusError = "ERROR"
+)
+
+// Result of a clamd scan
+type Result struct {
+	Status    string
+	Signature string
+	Raw       string
+}
+
+// Infected checks if a signature was found
+func (r *Result) Infected() bool {
+	return r.Status == StatusInfected
+}
+
+// Client connects to a clamd daemon
+type Client struct {
+	Network   string
+	Address   string
+	Timeout   time.Duration
+	ChunkSize int
+}
+
+// NewClient creates a client from an address like tcp://host:3310, unix:///var/run/clamd.ctl or host:3310
+func NewClient(address string) *Client {
+	c := &Client{
+		Network:   "tcp",
+		Address:   address,
+		Timeout:   DefaultTimeout,
+		ChunkSize: DefaultChunkSize,
+	}
+	if strings.HasPrefix(address, "unix://") {
+		c.Network = "unix"
+		c.Address = strings.TrimPrefix(address, "unix://")
+	} else if strings.HasPrefix(address, "tcp://") {
+		c.Address = strings.TrimPrefix(address, "tcp://")
+	} else if strings.HasPrefix(address, "/") {
+		c.Network = "unix"
+	}
+	return c
+}
+
+// Ping checks that clamd is alive
+func (c *Client) Ping(ctx context.Context) error {
+	conn, e := c.dial(ctx)
+	if e != nil {
+		return e
+	}
+	defer conn.Close()
+	if _, e := conn.Write([]byte("zPING\x00")); e != nil {
+		return e
+	}
+	resp, e := readResponse(conn)
+	if e != nil {
+		return e
+	}
+	if resp != "PONG" {
+		return fmt.Errorf("unexpected clamd response to PING: %s", resp)
+	}
+	return nil
+}
+
+// ScanStream sends the reader content to clamd using the INSTREAM command and parses its verdict.
+// The reader is always consumed until EOF, even if clamd stops reading before.
+func (c *Client) ScanStream(ctx context.Context, reader io.Reader) (*Result, error) {
+	conn, e := c.dial(ctx)
+	if e != nil {
+		return nil, e
+	}
+	defer conn.Close()
+	if _, e := conn.Write([]byte("zINSTREAM\x00")); e != nil {
+		return nil, e
+	}
+	chunkSize := c.ChunkSize
+	if chunkSize <= 0 {
+		chunkSize = DefaultChunkSize
+	}
+	buf := make([]byte, chunkSize)
+	header := make([]byte, 4)
+	var writeErr error
+	for {
+		n, er := io.ReadFull(reader, buf)
+		if n > 0 && writeErr == nil {
+			binary.BigEndian.PutUint32(header, uint32(n))
+			if _, writeErr = conn.Write(header); writeErr == nil {
+				_, writeErr = conn.Write(buf[:n])
+			}
+		}
+		if er == io.EOF || er == io.ErrUnexpectedEOF {
+			break
+		} else if er != nil {
+			return nil, er
+		}
+	}
+	if writeErr == nil {
+		binary.BigEndian.PutUint32(header, 0)
+		writeErr = func() error { _, e := conn.Write(header); return e }()
+	}
+	// Clamd may close the stream before the end (size limit exceeded), try to read its response anyway
+	resp, e := readResponse(conn)
+	if e != nil {
+		if writeErr != nil {
+			return nil, writeErr
+		}
+		return nil, e
+	}
+	return ParseResponse(resp), nil
+}
+
+func (c *Client) dial(ctx context.Context) (net.Conn, error) {
+	d := &net.Dialer{Timeout: c.Timeout}
+	conn, e := d.DialContext(ctx, c.Network, c.Address)
+	if e != nil {
+		return nil, e
+	}
+	if c.Timeout > 0 {
+		conn.SetDeadline(time.Now().Add(c.Timeout))
+	}
+	return conn, nil
+}
+
+// readResponse reads a NULL-terminated response
+func readResponse(conn net.Conn) (string, error) {
+	resp, e := bufio.NewReader(conn).ReadString('\x00')
+	if e != nil && (e != io.EOF || resp == "") {
+		return "", e
+	}
+	return strings.TrimSpace(strings.TrimRight(resp, "\x00")), nil
+}
+
+// ParseResponse parses a clamd scan response line, like "stream: Eicar-Signature FOUND"
+func ParseResponse(line string) *Result {
+	r := &Result{Raw: line}
+	msg := line
+	if i := strings.Index(line, ": "); i > -1 {
+		msg = line[i+2:]
+	}
+	switch {
+	case strings.HasSuffix(msg, " "+StatusInfected):
+		r.Status = StatusInfected
+		r.Signature = strings.TrimSuffix(msg, " "+StatusInfected)
+	case msg == StatusClean:
+		r.Status = StatusClean
+	default:
+		r.Status = StatusError
+		r.Signature = strings.TrimSpace(strings.TrimSuffix(msg, StatusError))
+	}
+	return r
+}
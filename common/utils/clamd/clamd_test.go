@@ -0,0 +1,81 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package clamd
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestClamd(t *testing.T) {
+
+	Convey("Test response parsing", t, func() {
+		r := ParseResponse("stream: OK")
+		So(r.Status, ShouldEqual, StatusClean)
+		So(r.Infected(), ShouldBeFalse)
+
+		r = ParseResponse("stream: Win.Test.EICAR_HDB-1 FOUND")
+		So(r.Infected(), ShouldBeTrue)
+		So(r.Signature, ShouldEqual, "Win.Test.EICAR_HDB-1")
+
+		r = ParseResponse("INSTREAM size limit exceeded. ERROR")
+		So(r.Status, ShouldEqual, StatusError)
+		So(r.Signature, ShouldEqual, "INSTREAM size limit exceeded.")
+	})
+
+	Convey("Test against fake clamd server", t, func() {
+		srv, e := NewFakeServer("EICAR", 0)
+		So(e, ShouldBeNil)
+		defer srv.Close()
+
+		c := NewClient("tcp://" + srv.Addr())
+		c.ChunkSize = 16
+		So(c.Ping(context.Background()), ShouldBeNil)
+
+		r, e := c.ScanStream(context.Background(), strings.NewReader(strings.Repeat("clean content ", 20)))
+		So(e, ShouldBeNil)
+		So(r.Infected(), ShouldBeFalse)
+
+		r, e = c.ScanStream(context.Background(), strings.NewReader("some content with EICAR signature"))
+		So(e, ShouldBeNil)
+		So(r.Infected(), ShouldBeTrue)
+		So(r.Signature, ShouldEqual, FakeSignature)
+	})
+
+	Convey("Test size limit", t, func() {
+		srv, e := NewFakeServer("EICAR", 32)
+		So(e, ShouldBeNil)
+		defer srv.Close()
+
+		c := NewClient(srv.Addr())
+		c.ChunkSize = 8
+		reader := bytes.NewReader(make([]byte, 1024))
+		r, e := c.ScanStream(context.Background(), reader)
+		So(e, ShouldBeNil)
+		So(r.Status, ShouldEqual, StatusError)
+		So(reader.Len(), ShouldEqual, 0)
+	})
+
+}
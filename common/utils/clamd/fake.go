@@ -0,0 +1,114 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package clamd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+	"net"
+)
+
+// FakeSignature is the signature name returned by the FakeServer
+const FakeSignature = "Cells.Test.Fake"
+
+// FakeServer is a local clamd server for testing purpose. It answers PING commands, and flags
+// INSTREAM contents that contain a given marker.
+type FakeServer struct {
+	listener net.Listener
+	marker   []byte
+	maxSize  int
+}
+
+// NewFakeServer starts a fake clamd on a random local port. If maxSize is > 0, streams bigger
+// than maxSize are rejected like clamd does with its StreamMaxLength setting.
+func NewFakeServer(marker string, maxSize int) (*FakeServer, error) {
+	l, e := net.Listen("tcp", "127.0.0.1:0")
+	if e != nil {
+		return nil, e
+	}
+	s := &FakeServer{listener: l, marker: []byte(marker), maxSize: maxSize}
+	go s.serve()
+	return s, nil
+}
+
+// Addr returns the host:port of the server
+func (s *FakeServer) Addr() string {
+	return s.listener.Addr().String()
+}
+
+// Close stops the server
+func (s *FakeServer) Close() error {
+	return s.listener.Close()
+}
+
+func (s *FakeServer) serve() {
+	for {
+		conn, e := s.listener.Accept()
+		if e != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *FakeServer) handle(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	cmd, e := r.ReadString('\x00')
+	if e != nil {
+		return
+	}
+	switch cmd {
+	case "zPING\x00":
+		conn.Write([]byte("PONG\x00"))
+	case "zINSTREAM\x00":
+		content := &bytes.Buffer{}
+		header := make([]byte, 4)
+		for {
+			if _, e := io.ReadFull(r, header); e != nil {
+				return
+			}
+			size := binary.BigEndian.Uint32(header)
+			if size == 0 {
+				break
+			}
+			if _, e := io.CopyN(content, r, int64(size)); e != nil {
+				return
+			}
+			if s.maxSize > 0 && content.Len() > s.maxSize {
+				conn.Write([]byte("INSTREAM size limit exceeded. ERROR\x00"))
+				// Drain remaining data instead of resetting the connection
+				io.Copy(ioutil.Discard, r)
+				return
+			}
+		}
+		if len(s.marker) > 0 && bytes.Contains(content.Bytes(), s.marker) {
+			conn.Write([]byte("stream: " + FakeSignature + " FOUND\x00"))
+		} else {
+			conn.Write([]byte("stream: OK\x00"))
+		}
+	default:
+		conn.Write([]byte("UNKNOWN COMMAND\x00"))
+	}
+}
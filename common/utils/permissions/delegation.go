@@ -0,0 +1,106 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package permissions
+
+import (
+	"context"
+	"path"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/auth/claim"
+	defaults "github.com/pydio/cells/common/micro"
+	"github.com/pydio/cells/common/proto/idm"
+	"github.com/pydio/cells/idm/policy/conditions"
+)
+
+const (
+	// DelegationResourceUser is the policy resource protecting the administration of users and groups by non-admin users
+	DelegationResourceUser = "delegation:user"
+	// DelegationResourceRole is the policy resource protecting the assignment of roles by non-admin users
+	DelegationResourceRole = "delegation:role"
+	// DelegationActionWrite allows creating, editing and deleting users and groups, their password and personal roles
+	DelegationActionWrite = "write"
+	// DelegationActionAssign allows adding or removing a role on a user
+	DelegationActionAssign = "assign"
+
+	// PolicyTargetGroupPath is the policy context key holding the parent group of the user or group being administrated
+	PolicyTargetGroupPath = "TargetGroupPath"
+	// PolicyRoleId is the policy context key holding the UUID of the role being assigned
+	PolicyRoleId = "RoleId"
+)
+
+// DelegatedAdminAllowed checks whether the context user was granted the administration
+// of the users and groups located under the passed parent group path.
+func DelegatedAdminAllowed(ctx context.Context, parentGroupPath string) bool {
+	return delegationAllowed(ctx, DelegationResourceUser, DelegationActionWrite, map[string]string{
+		PolicyTargetGroupPath: parentGroupPath,
+	})
+}
+
+// DelegatedRoleAllowed checks whether the context user can assign the passed role to the users it administrates.
+func DelegatedRoleAllowed(ctx context.Context, roleId string) bool {
+	return delegationAllowed(ctx, DelegationResourceRole, DelegationActionAssign, map[string]string{
+		PolicyRoleId: roleId,
+	})
+}
+
+// DelegatedAdminAllowedOnRole checks whether the passed role is the personal role of a user
+// or the role of a group that the context user administrates.
+func DelegatedAdminAllowedOnRole(ctx context.Context, role *idm.Role) bool {
+	if role == nil || (!role.UserRole && !role.GroupRole) {
+		return false
+	}
+	q := &idm.UserSingleQuery{Uuid: role.Uuid, NodeType: idm.NodeType_USER}
+	if role.GroupRole {
+		q.NodeType = idm.NodeType_GROUP
+	}
+	owner, e := SearchUniqueUser(ctx, "", "", q)
+	if e != nil || owner == nil {
+		return false
+	}
+	return DelegatedAdminAllowed(ctx, DelegationParentPath(owner))
+}
+
+// DelegationParentPath returns the group that must be administrated to manage a user or a group,
+// that is its parent group. Groups loaded from the user service carry their parent in GroupPath and
+// their name in GroupLabel, whereas a group addressed by its full path has an empty GroupLabel.
+func DelegationParentPath(u *idm.User) string {
+	if u.IsGroup {
+		return path.Dir(path.Join("/", u.GroupPath, u.GroupLabel))
+	}
+	return path.Clean("/" + u.GroupPath)
+}
+
+func delegationAllowed(ctx context.Context, resource, action string, policyContext map[string]string) bool {
+	claims, ok := ctx.Value(claim.ContextKey).(claim.Claims)
+	if !ok || claims.Profile == common.PydioProfileShared || claims.Profile == common.PydioProfileAnon {
+		return false
+	}
+	policyContext[conditions.SubjectGroupPathKey] = claims.GroupPath
+	cli := idm.NewPolicyEngineServiceClient(common.ServiceGrpcNamespace_+common.ServicePolicy, defaults.NewClient())
+	resp, e := cli.IsAllowed(ctx, &idm.PolicyEngineRequest{
+		Subjects: PolicyRequestSubjectsFromClaims(claims),
+		Resource: resource,
+		Action:   action,
+		Context:  policyContext,
+	})
+	return e == nil && resp.Allowed
+}
@@ -0,0 +1,52 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package permissions
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/auth/claim"
+	"github.com/pydio/cells/common/proto/idm"
+)
+
+func TestDelegationParentPath(t *testing.T) {
+	Convey("Test parent path of users and groups", t, func() {
+		So(DelegationParentPath(&idm.User{Login: "user", GroupPath: "/sales/emea"}), ShouldEqual, "/sales/emea")
+		So(DelegationParentPath(&idm.User{Login: "user", GroupPath: ""}), ShouldEqual, "/")
+		So(DelegationParentPath(&idm.User{IsGroup: true, GroupPath: "/sales/emea/"}), ShouldEqual, "/sales")
+		So(DelegationParentPath(&idm.User{IsGroup: true, GroupPath: "sales"}), ShouldEqual, "/")
+		So(DelegationParentPath(&idm.User{IsGroup: true, GroupPath: "/sales/", GroupLabel: "emea"}), ShouldEqual, "/sales")
+		So(DelegationParentPath(&idm.User{IsGroup: true, GroupPath: "/", GroupLabel: "sales"}), ShouldEqual, "/")
+	})
+}
+
+func TestDelegationAllowed(t *testing.T) {
+	Convey("Test delegation is never granted without claims or to shared users", t, func() {
+		So(DelegatedAdminAllowed(context.Background(), "/sales"), ShouldBeFalse)
+		ctx := context.WithValue(context.Background(), claim.ContextKey, claim.Claims{Profile: common.PydioProfileShared})
+		So(DelegatedRoleAllowed(ctx, "role"), ShouldBeFalse)
+		So(DelegatedAdminAllowedOnRole(ctx, &idm.Role{Uuid: "role"}), ShouldBeFalse)
+	})
+}
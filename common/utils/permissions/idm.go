@@ -434,7 +434,8 @@ func AccessListFromUser(ctx context.Context, userNameOrUuid string, isUuid bool)
 		return
 	}
 
-	accessList, err = AccessListFromRoles(ctx, user.Roles, true, true)
+	// Roles whose membership is not started yet or already expired are ignored
+	accessList, err = AccessListFromRoles(ctx, user.ActiveRoles(time.Now()), true, true)
 
 	return
 }
@@ -32,6 +32,11 @@ import (
 	"github.com/pydio/cells/common/log"
 )
 
+// JavaScriptTimeout is the maximum execution time of a script run by RunJavaScript.
+var JavaScriptTimeout = 2 * time.Second
+
+var errJavaScriptHalted = errors.New("javascript runner: execution interrupted after timeout")
+
 type JsUser struct {
 	Uuid        string
 	Name        string
@@ -49,10 +54,32 @@ type JsRequest struct {
 	UserIP    string
 }
 
-func RunJavaScript(ctx context.Context, script string, inputs map[string]interface{}, outputs map[string]interface{}) error {
+// RunJavaScript runs a script with the given inputs and reads back the outputs.
+// The script is interrupted after JavaScriptTimeout or when ctx is done.
+func RunJavaScript(ctx context.Context, script string, inputs map[string]interface{}, outputs map[string]interface{}) (err error) {
 
 	t := time.Now()
 	vm := otto.New()
+	vm.Interrupt = make(chan func(), 1)
+	runCtx, cancel := context.WithTimeout(ctx, JavaScriptTimeout)
+	defer cancel()
+	go func() {
+		<-runCtx.Done()
+		if runCtx.Err() == context.DeadlineExceeded || ctx.Err() != nil {
+			vm.Interrupt <- func() {
+				panic(errJavaScriptHalted)
+			}
+		}
+	}()
+	defer func() {
+		if r := recover(); r != nil {
+			if r == errJavaScriptHalted {
+				err = errJavaScriptHalted
+				return
+			}
+			panic(r)
+		}
+	}()
 
 	for inputVar, inputData := range inputs {
 		vm.Set(inputVar, inputData)
@@ -91,3 +118,11 @@ func RunJavaScript(ctx context.Context, script string, inputs map[string]interfa
 	return nil
 
 }
+
+// CompileJavaScript checks the syntax of a script without running it.
+func CompileJavaScript(script string) error {
+	if _, e := otto.New().Compile("", script); e != nil {
+		return errors.Wrap(e, "javascript compiler")
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package permissions
 import (
 	"context"
 	"testing"
+	"time"
 
 	. "github.com/smartystreets/goconvey/convey"
 )
@@ -62,4 +63,21 @@ func TestRunJavaScript(t *testing.T) {
 		e := RunJavaScript(context.Background(), script, in, out)
 		So(e, ShouldNotBeNil)
 	})
+	Convey("Test RunJavascript timeout", t, func() {
+		timeout := JavaScriptTimeout
+		JavaScriptTimeout = 100 * time.Millisecond
+		defer func() { JavaScriptTimeout = timeout }()
+		out := map[string]interface{}{
+			"Path": "",
+		}
+		e := RunJavaScript(context.Background(), `while (true) {}`, map[string]interface{}{}, out)
+		So(e, ShouldNotBeNil)
+		So(out["Path"], ShouldEqual, "")
+	})
+	Convey("Test CompileJavascript", t, func() {
+		So(CompileJavaScript(`Path = DataSources.personal + "/" + User.Name;`), ShouldBeNil)
+		// Unknown variables are only detected at runtime
+		So(CompileJavaScript(`Value = User/Name === john;`), ShouldBeNil)
+		So(CompileJavaScript(`Path = DataSources.personal + "/" + ;`), ShouldNotBeNil)
+	})
 }
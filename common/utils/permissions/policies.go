@@ -63,7 +63,7 @@ func PolicyRequestSubjectsFromUser(user *idm.User) []string {
 	} else {
 		subjects = append(subjects, "profile:standard")
 	}
-	for _, r := range user.Roles {
+	for _, r := range user.ActiveRoles(time.Now()) {
 		subjects = append(subjects, fmt.Sprintf("role:%s", r.Uuid))
 	}
 	return subjects
@@ -0,0 +1,42 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package permissions
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/pydio/cells/common/proto/idm"
+)
+
+func TestPolicyRequestSubjectsFromUser(t *testing.T) {
+	Convey("Test expired roles are not used as policy subjects", t, func() {
+		u := &idm.User{
+			Login:      "user",
+			Attributes: map[string]string{"profile": "standard"},
+			Roles:      []*idm.Role{{Uuid: "active"}, {Uuid: "expired"}},
+		}
+		u.SetRolesValidity(map[string]*idm.RoleValidity{"expired": {End: time.Now().Add(-time.Hour).Unix()}})
+		So(PolicyRequestSubjectsFromUser(u), ShouldResemble, []string{"user:user", "profile:standard", "role:active"})
+	})
+}
@@ -0,0 +1,288 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package views
+
+import (
+	"context"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"go.uber.org/zap"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/config"
+	"github.com/pydio/cells/common/log"
+	defaults "github.com/pydio/cells/common/micro"
+	"github.com/pydio/cells/common/proto/activity"
+	"github.com/pydio/cells/common/proto/idm"
+	"github.com/pydio/cells/common/proto/mailer"
+	"github.com/pydio/cells/common/proto/tree"
+	"github.com/pydio/cells/common/registry"
+	service "github.com/pydio/cells/common/service/proto"
+	"github.com/pydio/cells/common/utils/clamd"
+	"github.com/pydio/cells/common/utils/permissions"
+)
+
+const (
+	antivirusPlugin = "action.antivirus"
+
+	defaultQuarantineFolder = "quarantine"
+)
+
+// AntivirusConfig is loaded from the global parameters of the action.antivirus plugin
+type AntivirusConfig struct {
+	Enabled          bool
+	Address          string
+	Timeout          time.Duration
+	SyncScan         bool
+	MaxSize          int64
+	QuarantineFolder string
+	NotifyEmails     []string
+}
+
+// AntivirusReport is stored in the MetaNamespaceAntivirus metadata of each scanned file
+type AntivirusReport struct {
+	Status       string
+	Signature    string `json:",omitempty"`
+	ScanDate     int64
+	OriginalPath string `json:",omitempty"`
+	Uploader     string `json:",omitempty"`
+}
+
+// LoadAntivirusConfig reads the antivirus configuration. It returns nil if the plugin is disabled.
+func LoadAntivirusConfig() *AntivirusConfig {
+	c := config.Get("frontend", "plugin", antivirusPlugin)
+	if !c.Val(config.KeyFrontPluginEnabled).Default(false).Bool() {
+		return nil
+	}
+	conf := &AntivirusConfig{
+		Enabled:          true,
+		Address:          c.Val("CLAMD_ADDRESS").Default("tcp://localhost:3310").String(),
+		Timeout:          time.Duration(c.Val("CLAMD_TIMEOUT").Default(60).Int()) * time.Second,
+		SyncScan:         c.Val("SCAN_SYNC").Default(false).Bool(),
+		MaxSize:          c.Val("SCAN_MAX_SIZE").Default(int64(0)).Int64(),
+		QuarantineFolder: strings.Trim(c.Val("QUARANTINE_FOLDER").Default(defaultQuarantineFolder).String(), "/"),
+	}
+	if conf.QuarantineFolder == "" {
+		conf.QuarantineFolder = defaultQuarantineFolder
+	}
+	for _, m := range strings.Split(c.Val("NOTIFY_EMAILS").String(), ",") {
+		if m = strings.TrimSpace(m); m != "" {
+			conf.NotifyEmails = append(conf.NotifyEmails, m)
+		}
+	}
+	return conf
+}
+
+// Scan streams a content to clamd.
+func (a *AntivirusConfig) Scan(ctx context.Context, reader io.Reader) (*clamd.Result, error) {
+	c := clamd.NewClient(a.Address)
+	if a.Timeout > 0 {
+		c.Timeout = a.Timeout
+	}
+	return c.ScanStream(ctx, reader)
+}
+
+// Skip checks if a file is too big to be scanned
+func (a *AntivirusConfig) Skip(size int64) bool {
+	return a.MaxSize > 0 && size > a.MaxSize
+}
+
+// IsQuarantined checks if a node path, expressed as datasource/path, is inside the quarantine folder
+func (a *AntivirusConfig) IsQuarantined(nodePath string) bool {
+	parts := strings.SplitN(strings.Trim(nodePath, "/"), "/", 3)
+	return len(parts) > 2 && parts[1] == a.QuarantineFolder
+}
+
+// QuarantinePath computes the location of an infected node, at the root of its datasource
+func (a *AntivirusConfig) QuarantinePath(node *tree.Node) string {
+	dsName := strings.SplitN(strings.Trim(node.GetPath(), "/"), "/", 2)[0]
+	name := path.Base(node.GetPath())
+	if node.GetUuid() != "" {
+		name = node.GetUuid() + "-" + name
+	}
+	return path.Join(dsName, a.QuarantineFolder, name)
+}
+
+// TagScanResult stores the scan report as node metadata
+func TagScanResult(ctx context.Context, nodeUuid string, report *AntivirusReport) error {
+	node := &tree.Node{Uuid: nodeUuid}
+	node.SetMeta(common.MetaNamespaceAntivirus, report)
+	receiver := tree.NewNodeReceiverClient(registry.GetClient(common.ServiceMeta))
+	_, e := receiver.CreateNode(ctx, &tree.CreateNodeRequest{Node: node, UpdateIfExists: true})
+	return e
+}
+
+// QuarantineNode moves an infected node to the quarantine folder of its datasource, tags it with the scan
+// result, and notifies the uploader and the administrators. Router must be an admin router, and node path
+// must be expressed as datasource/path.
+func QuarantineNode(ctx context.Context, router Handler, node *tree.Node, result *clamd.Result, uploader string, conf *AntivirusConfig) (*tree.Node, error) {
+
+	target := &tree.Node{Path: conf.QuarantinePath(node), Type: tree.NodeType_LEAF}
+	if e := ensureQuarantineFolder(ctx, router, path.Dir(target.Path)); e != nil {
+		return nil, e
+	}
+
+	status := make(chan string)
+	progress := make(chan float32)
+	done := make(chan bool)
+	go func() {
+		for {
+			select {
+			case s := <-status:
+				log.Logger(ctx).Debug(s)
+			case <-progress:
+			case <-done:
+				return
+			}
+		}
+	}()
+	e := CopyMoveNodes(ctx, router, node, target, true, false, status, progress)
+	close(done)
+	if e != nil {
+		return nil, e
+	}
+
+	quarantined := node.Clone()
+	quarantined.Path = target.Path
+	if r, er := router.ReadNode(ctx, &tree.ReadNodeRequest{Node: &tree.Node{Path: target.Path}}); er == nil {
+		quarantined = r.GetNode()
+	}
+	report := &AntivirusReport{
+		Status:       result.Status,
+		Signature:    result.Signature,
+		ScanDate:     time.Now().Unix(),
+		OriginalPath: node.GetPath(),
+		Uploader:     uploader,
+	}
+	if er := TagScanResult(ctx, quarantined.GetUuid(), report); er != nil {
+		log.Logger(ctx).Error("Cannot tag quarantined node", quarantined.ZapPath(), zap.Error(er))
+	}
+	log.Logger(ctx).Warn("Infected file moved to quarantine", zap.String("signature", result.Signature), zap.String("from", node.GetPath()), zap.String("to", target.Path))
+
+	notifyInfection(ctx, uploader, node, report, conf)
+
+	return quarantined, nil
+}
+
+// ensureQuarantineFolder creates the quarantine folder if necessary, and makes sure it is denied to all users
+func ensureQuarantineFolder(ctx context.Context, router Handler, folderPath string) error {
+	var folder *tree.Node
+	if r, e := router.ReadNode(ctx, &tree.ReadNodeRequest{Node: &tree.Node{Path: folderPath}}); e == nil {
+		folder = r.GetNode()
+	} else if c, e := router.CreateNode(ctx, &tree.CreateNodeRequest{Node: &tree.Node{Path: folderPath, Type: tree.NodeType_COLLECTION}}); e == nil {
+		folder = c.GetNode()
+	} else {
+		return e
+	}
+	if folder.GetUuid() == "" {
+		return nil
+	}
+	aclClient := idm.NewACLServiceClient(common.ServiceGrpcNamespace_+common.ServiceAcl, defaults.NewClient())
+	q, _ := ptypes.MarshalAny(&idm.ACLSingleQuery{
+		NodeIDs: []string{folder.GetUuid()},
+		RoleIDs: []string{"ROOT_GROUP"},
+		Actions: []*idm.ACLAction{permissions.AclDeny},
+	})
+	st, e := aclClient.SearchACL(ctx, &idm.SearchACLRequest{Query: &service.Query{SubQueries: []*any.Any{q}}})
+	if e != nil {
+		return e
+	}
+	defer st.Close()
+	if r, er := st.Recv(); er == nil && r.GetACL() != nil {
+		return nil
+	}
+	_, e = aclClient.CreateACL(ctx, &idm.CreateACLRequest{ACL: &idm.ACL{
+		NodeID: folder.GetUuid(),
+		RoleID: "ROOT_GROUP",
+		Action: permissions.AclDeny,
+	}})
+	return e
+}
+
+// notifyInfection posts an activity in the uploader inbox and sends an email to the uploader and the administrators
+func notifyInfection(ctx context.Context, uploader string, node *tree.Node, report *AntivirusReport, conf *AntivirusConfig) {
+
+	fileName := path.Base(node.GetPath())
+	var to []*mailer.User
+	if uploader != "" {
+		ac := &activity.Object{
+			JsonLdContext: "https://www.w3.org/ns/activitystreams",
+			Type:          activity.ObjectType_Flag,
+			Name:          report.Signature,
+			Object: &activity.Object{
+				Type: activity.ObjectType_Document,
+				Name: fileName,
+			},
+			Actor: &activity.Object{
+				Type: activity.ObjectType_Service,
+				Name: "antivirus",
+				Id:   "antivirus",
+			},
+			Updated: &timestamp.Timestamp{Seconds: report.ScanDate},
+		}
+		acClient := activity.NewActivityServiceClient(registry.GetClient(common.ServiceActivity))
+		if st, e := acClient.PostActivity(ctx); e == nil {
+			if er := st.Send(&activity.PostActivityRequest{OwnerType: activity.OwnerType_USER, OwnerId: uploader, BoxName: "inbox", Activity: ac}); er != nil {
+				log.Logger(ctx).Error("Cannot post antivirus activity", zap.Error(er))
+			}
+			st.Close()
+		} else {
+			log.Logger(ctx).Error("Cannot post antivirus activity", zap.Error(e))
+		}
+		if u, e := permissions.SearchUniqueUser(ctx, uploader, ""); e == nil && u != nil {
+			if email, ok := u.Attributes[idm.UserAttrEmail]; ok && email != "" {
+				name := u.Attributes[idm.UserAttrDisplayName]
+				if name == "" {
+					name = u.Login
+				}
+				to = append(to, &mailer.User{Uuid: u.Uuid, Name: name, Address: email})
+			}
+		}
+	}
+	for _, m := range conf.NotifyEmails {
+		to = append(to, &mailer.User{Address: m})
+	}
+	if len(to) == 0 {
+		return
+	}
+	mailCli := mailer.NewMailerServiceClient(registry.GetClient(common.ServiceMailer))
+	if _, e := mailCli.SendMail(ctx, &mailer.SendMailRequest{
+		InQueue: false,
+		Mail: &mailer.Mail{
+			To:         to,
+			TemplateId: "AntivirusQuarantine",
+			TemplateData: map[string]string{
+				"File":      fileName,
+				"Path":      node.GetPath(),
+				"Signature": report.Signature,
+				"Uploader":  uploader,
+			},
+		},
+	}); e != nil {
+		log.Logger(ctx).Error("Cannot send antivirus notification email", zap.Error(e))
+	}
+}
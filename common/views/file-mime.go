@@ -53,8 +53,7 @@ func NewTeeMimeReader(reader io.Reader, callbackRoutine func(result *MimeResult)
 	go func() {
 		<-mr.loaded
 		//fmt.Printf("Stored %d bytes in buffer - Error: %v\n", len(mr.data), mr.loadError)
-		kind, _ := filetype.Match(mr.data)
-		mime := kind.MIME.Value
+		mime := sniffMime(mr.data)
 		if callbackRoutine != nil {
 			callbackRoutine(&MimeResult{mime: mime, err: mr.loadError})
 		}
@@ -66,6 +65,12 @@ func NewTeeMimeReader(reader io.Reader, callbackRoutine func(result *MimeResult)
 	return mr
 }
 
+// sniffMime detects the mime type from the first bytes of a content, or returns an empty string.
+func sniffMime(head []byte) string {
+	kind, _ := filetype.Match(head)
+	return kind.MIME.Value
+}
+
 func (m *TeeMimeReader) SetLimit(size int) {
 	m.limit = size
 }
@@ -0,0 +1,219 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+package views
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// ErrTextTooLarge is returned by ReadText when the content exceeds the given limit
+var ErrTextTooLarge = fmt.Errorf("content is too large to be read as text")
+
+var (
+	textMimePrefixes = []string{"text/"}
+	textMimes        = map[string]struct{}{
+		"application/json":       {},
+		"application/xml":        {},
+		"application/javascript": {},
+		"application/x-sh":       {},
+		"application/x-yaml":     {},
+		"application/sql":        {},
+	}
+	textExtensions = map[string]struct{}{
+		"txt": {}, "md": {}, "csv": {}, "tsv": {}, "json": {}, "xml": {}, "html": {}, "htm": {}, "css": {},
+		"js": {}, "ts": {}, "go": {}, "py": {}, "php": {}, "java": {}, "c": {}, "h": {}, "cpp": {}, "rb": {},
+		"sh": {}, "yml": {}, "yaml": {}, "ini": {}, "conf": {}, "log": {}, "sql": {}, "svg": {}, "tex": {},
+	}
+	// officeTextParts lists the archive members holding the text for each office mime type
+	officeTextParts = map[string]func(name string) bool{
+		"application/vnd.openxmlformats-officedocument.wordprocessingml.document": func(n string) bool {
+			return n == "word/document.xml"
+		},
+		"application/vnd.openxmlformats-officedocument.presentationml.presentation": func(n string) bool {
+			return strings.HasPrefix(n, "ppt/slides/slide") && path.Ext(n) == ".xml"
+		},
+		"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet": func(n string) bool {
+			return n == "xl/sharedStrings.xml"
+		},
+		"application/vnd.oasis.opendocument.text": func(n string) bool {
+			return n == "content.xml"
+		},
+		"application/vnd.oasis.opendocument.spreadsheet": func(n string) bool {
+			return n == "content.xml"
+		},
+		"application/vnd.oasis.opendocument.presentation": func(n string) bool {
+			return n == "content.xml"
+		},
+	}
+	// officeParagraphs lists the XML elements that end a line of text
+	officeParagraphs = map[string]struct{}{"p": {}, "h": {}, "si": {}, "br": {}}
+)
+
+// DetectMime sniffs the first bytes of a content to find its mime type, relying on the same
+// detection as the TeeMimeReader, and falling back to text/plain for valid UTF-8 contents.
+func DetectMime(head []byte) string {
+	if mime := sniffMime(head); mime != "" {
+		return mime
+	}
+	if looksLikeText(head) {
+		return "text/plain"
+	}
+	return ""
+}
+
+// IsTextMime checks if a mime type (or a file name extension) denotes a text-like content.
+func IsTextMime(mime, name string) bool {
+	mime = strings.Split(mime, ";")[0]
+	for _, p := range textMimePrefixes {
+		if strings.HasPrefix(mime, p) {
+			return true
+		}
+	}
+	if _, ok := textMimes[mime]; ok {
+		return true
+	}
+	if strings.HasSuffix(mime, "+xml") || strings.HasSuffix(mime, "+json") {
+		return true
+	}
+	_, ok := textExtensions[strings.ToLower(strings.TrimPrefix(path.Ext(name), "."))]
+	return ok && (mime == "" || mime == "application/octet-stream")
+}
+
+// IsOfficeMime checks if a text can be extracted from this office document mime type.
+func IsOfficeMime(mime string) bool {
+	_, ok := officeTextParts[mime]
+	return ok
+}
+
+// ReadText reads the whole content of reader as text, limited to maxSize bytes. Mime is detected
+// from the content if not passed. Office documents (OOXML and OpenDocument) are unzipped and their
+// text is extracted. It returns the detected mime type along with the text.
+func ReadText(reader io.Reader, name, mime string, maxSize int64) (string, string, error) {
+
+	data, e := ioutil.ReadAll(io.LimitReader(reader, maxSize+1))
+	if e != nil {
+		return "", mime, e
+	}
+	if int64(len(data)) > maxSize {
+		return "", mime, ErrTextTooLarge
+	}
+	if mime == "" || mime == "application/octet-stream" {
+		head := data
+		if len(head) > mimeReadLimit {
+			head = head[:mimeReadLimit]
+		}
+		if detected := DetectMime(head); detected != "" {
+			mime = detected
+		}
+	}
+	if IsOfficeMime(mime) {
+		text, er := extractOfficeText(data, mime, maxSize)
+		return text, mime, er
+	}
+	if !IsTextMime(mime, name) || !looksLikeText(data) {
+		return "", mime, fmt.Errorf("cannot read %s as text (mime type %s)", name, mime)
+	}
+	return string(data), mime, nil
+
+}
+
+// extractOfficeText opens the office zip container and concatenates the text content
+// of its main parts, adding a line break at each paragraph.
+func extractOfficeText(data []byte, mime string, maxSize int64) (string, error) {
+
+	zr, e := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if e != nil {
+		return "", e
+	}
+	accept := officeTextParts[mime]
+	var files []*zip.File
+	for _, f := range zr.File {
+		if accept(f.Name) {
+			files = append(files, f)
+		}
+	}
+	// Keep slides in their natural order
+	sort.Slice(files, func(i, j int) bool {
+		if len(files[i].Name) != len(files[j].Name) {
+			return len(files[i].Name) < len(files[j].Name)
+		}
+		return files[i].Name < files[j].Name
+	})
+	out := &bytes.Buffer{}
+	for _, f := range files {
+		r, er := f.Open()
+		if er != nil {
+			return "", er
+		}
+		er = xmlToText(io.LimitReader(r, maxSize), out)
+		r.Close()
+		if er != nil {
+			return "", er
+		}
+		if int64(out.Len()) > maxSize {
+			return "", ErrTextTooLarge
+		}
+	}
+	return out.String(), nil
+
+}
+
+func xmlToText(r io.Reader, out *bytes.Buffer) error {
+	dec := xml.NewDecoder(r)
+	for {
+		t, e := dec.Token()
+		if e == io.EOF {
+			return nil
+		} else if e != nil {
+			return e
+		}
+		switch el := t.(type) {
+		case xml.CharData:
+			out.Write(el)
+		case xml.EndElement:
+			if _, ok := officeParagraphs[el.Name.Local]; ok {
+				out.WriteString("\n")
+			}
+		}
+	}
+}
+
+// looksLikeText checks that data is valid UTF-8 and does not contain NUL bytes.
+// Last bytes may be a truncated rune and are ignored.
+func looksLikeText(data []byte) bool {
+	if bytes.IndexByte(data, 0) > -1 {
+		return false
+	}
+	if len(data) > utf8.UTFMax {
+		for i := 0; i < utf8.UTFMax && !utf8.Valid(data); i++ {
+			data = data[:len(data)-1]
+		}
+	}
+	return utf8.Valid(data)
+}
@@ -0,0 +1,50 @@
+package views
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestReadText(t *testing.T) {
+
+	Convey("Read plain text", t, func() {
+		text, mime, e := ReadText(strings.NewReader("hello world\n"), "file.txt", "", 1024)
+		So(e, ShouldBeNil)
+		So(mime, ShouldEqual, "text/plain")
+		So(text, ShouldEqual, "hello world\n")
+
+		_, _, e = ReadText(strings.NewReader("hello world\n"), "file.txt", "", 5)
+		So(e, ShouldEqual, ErrTextTooLarge)
+	})
+
+	Convey("Refuse binary content", t, func() {
+		f, e := os.Open("./testdata/mimes/pdf-sample.pdf")
+		So(e, ShouldBeNil)
+		defer f.Close()
+		_, mime, e := ReadText(f, "pdf-sample.pdf", "", 1024*1024)
+		So(e, ShouldNotBeNil)
+		So(mime, ShouldEqual, "application/pdf")
+	})
+
+	Convey("Extract text from docx", t, func() {
+		f, e := os.Open("./testdata/mimes/docx-sample_1MB.docx")
+		So(e, ShouldBeNil)
+		defer f.Close()
+		text, mime, e := ReadText(f, "docx-sample_1MB.docx", "", 5*1024*1024)
+		So(e, ShouldBeNil)
+		So(mime, ShouldEqual, "application/vnd.openxmlformats-officedocument.wordprocessingml.document")
+		So(len(text), ShouldBeGreaterThan, 0)
+		So(strings.Count(text, "\n"), ShouldBeGreaterThan, 1)
+	})
+
+	Convey("Text mimes", t, func() {
+		So(IsTextMime("text/markdown", "README.md"), ShouldBeTrue)
+		So(IsTextMime("application/json; charset=utf-8", "a.json"), ShouldBeTrue)
+		So(IsTextMime("", "main.go"), ShouldBeTrue)
+		So(IsTextMime("image/png", "main.go"), ShouldBeFalse)
+	})
+
+}
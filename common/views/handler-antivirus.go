@@ -0,0 +1,153 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package views
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+
+	"github.com/micro/go-micro/errors"
+	"github.com/pydio/minio-go"
+	"go.uber.org/zap"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/auth/claim"
+	"github.com/pydio/cells/common/log"
+	"github.com/pydio/cells/common/proto/tree"
+	"github.com/pydio/cells/common/utils/clamd"
+	context2 "github.com/pydio/cells/common/utils/context"
+	"github.com/pydio/cells/common/views/models"
+)
+
+var (
+	antivirusRouter *Router
+)
+
+// AntivirusHandler synchronously scans uploaded contents when the action.antivirus plugin is enabled
+// with the SCAN_SYNC option. Infected files are moved to quarantine and the upload returns an error.
+type AntivirusHandler struct {
+	AbstractHandler
+	config *AntivirusConfig
+}
+
+// PutObject streams the content to clamd while it is uploaded.
+func (a *AntivirusHandler) PutObject(ctx context.Context, node *tree.Node, reader io.Reader, requestData *models.PutRequestData) (int64, error) {
+
+	conf := a.loadConfig()
+	if conf == nil || !conf.SyncScan || conf.Skip(requestData.Size) || conf.IsQuarantined(node.GetPath()) {
+		return a.next.PutObject(ctx, node, reader, requestData)
+	}
+
+	pr, pw := io.Pipe()
+	results := make(chan *clamd.Result, 1)
+	go func() {
+		defer close(results)
+		res, e := conf.Scan(ctx, pr)
+		// Drain in case clamd stopped reading, a scan failure must not block the upload
+		io.Copy(ioutil.Discard, pr)
+		if e != nil {
+			log.Logger(ctx).Error("Antivirus scan failed", node.ZapPath(), zap.Error(e))
+			return
+		}
+		results <- res
+	}()
+
+	written, err := a.next.PutObject(ctx, node, io.TeeReader(reader, pw), requestData)
+	pw.CloseWithError(err)
+	result := <-results
+	if err != nil || result == nil {
+		return written, err
+	}
+	if !result.Infected() {
+		return written, nil
+	}
+	return written, a.quarantine(ctx, node, result, conf)
+
+}
+
+// MultipartComplete reads the whole object once it is assembled and scans it.
+func (a *AntivirusHandler) MultipartComplete(ctx context.Context, target *tree.Node, uploadID string, uploadedParts []minio.CompletePart) (minio.ObjectInfo, error) {
+
+	info, err := a.next.MultipartComplete(ctx, target, uploadID, uploadedParts)
+	if err != nil {
+		return info, err
+	}
+	conf := a.loadConfig()
+	if conf == nil || !conf.SyncScan || conf.Skip(info.Size) || conf.IsQuarantined(target.GetPath()) {
+		return info, nil
+	}
+	reader, e := a.next.GetObject(ctx, target.Clone(), &models.GetRequestData{Length: -1})
+	if e != nil {
+		log.Logger(ctx).Error("Cannot read object for antivirus scan", target.ZapPath(), zap.Error(e))
+		return info, nil
+	}
+	defer reader.Close()
+	result, e := conf.Scan(ctx, reader)
+	if e != nil {
+		log.Logger(ctx).Error("Antivirus scan failed", target.ZapPath(), zap.Error(e))
+		return info, nil
+	}
+	if result.Infected() {
+		return info, a.quarantine(ctx, target, result, conf)
+	}
+	return info, nil
+
+}
+
+func (a *AntivirusHandler) loadConfig() *AntivirusConfig {
+	if a.config != nil {
+		return a.config
+	}
+	return LoadAntivirusConfig()
+}
+
+// quarantine moves the node in background context using an admin router. If it cannot be moved,
+// the node is deleted to make sure infected content is not left available.
+func (a *AntivirusHandler) quarantine(ctx context.Context, node *tree.Node, result *clamd.Result, conf *AntivirusConfig) error {
+
+	var uploader string
+	if claims, ok := ctx.Value(claim.ContextKey).(claim.Claims); ok {
+		uploader = claims.Name
+	}
+	bgCtx := context2.WithUserNameMetadata(context2.NewBackgroundWithMetaCopy(ctx), common.PydioSystemUsername)
+	src := node.Clone()
+	if src.GetUuid() == "" {
+		if r, e := a.next.ReadNode(ctx, &tree.ReadNodeRequest{Node: node.Clone()}); e == nil {
+			src.Uuid = r.GetNode().GetUuid()
+		}
+	}
+	if _, e := QuarantineNode(bgCtx, getAntivirusRouter(), src, result, uploader, conf); e != nil {
+		log.Logger(ctx).Error("Cannot move infected file to quarantine, deleting it", src.ZapPath(), zap.Error(e))
+		if _, de := getAntivirusRouter().DeleteNode(bgCtx, &tree.DeleteNodeRequest{Node: src}); de != nil {
+			log.Logger(ctx).Error("Cannot delete infected file", src.ZapPath(), zap.Error(de))
+		}
+	}
+	return errors.New("virus.detected", "File was rejected by antivirus: "+result.Signature, 422)
+
+}
+
+func getAntivirusRouter() *Router {
+	if antivirusRouter == nil {
+		antivirusRouter = NewStandardRouter(RouterOptions{AdminView: true, WatchRegistry: true})
+	}
+	return antivirusRouter
+}
@@ -0,0 +1,67 @@
+package views
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/pydio/cells/common/proto/tree"
+	"github.com/pydio/cells/common/utils/clamd"
+	"github.com/pydio/cells/common/views/models"
+)
+
+func TestAntivirusHandler(t *testing.T) {
+
+	Convey("Test antivirus configuration helpers", t, func() {
+		conf := &AntivirusConfig{QuarantineFolder: "quarantine", MaxSize: 100}
+		So(conf.IsQuarantined("ds/quarantine/file.txt"), ShouldBeTrue)
+		So(conf.IsQuarantined("/ds/quarantine/sub/file.txt"), ShouldBeTrue)
+		So(conf.IsQuarantined("ds/quarantine"), ShouldBeFalse)
+		So(conf.IsQuarantined("ds/folder/quarantine/file.txt"), ShouldBeFalse)
+		So(conf.QuarantinePath(&tree.Node{Path: "ds/folder/file.txt", Uuid: "uuid"}), ShouldEqual, "ds/quarantine/uuid-file.txt")
+		So(conf.QuarantinePath(&tree.Node{Path: "ds/file.txt"}), ShouldEqual, "ds/quarantine/file.txt")
+		So(conf.Skip(101), ShouldBeTrue)
+		So(conf.Skip(100), ShouldBeFalse)
+		So((&AntivirusConfig{}).Skip(1000), ShouldBeFalse)
+	})
+
+	Convey("Test synchronous scan of clean uploads", t, func() {
+		srv, e := clamd.NewFakeServer("EICAR", 0)
+		So(e, ShouldBeNil)
+		defer srv.Close()
+
+		tmpDir, e := ioutil.TempDir("", "antivirus")
+		So(e, ShouldBeNil)
+		defer os.RemoveAll(tmpDir)
+
+		mock := NewHandlerMock()
+		h := &AntivirusHandler{config: &AntivirusConfig{Enabled: true, SyncScan: true, Address: srv.Addr(), QuarantineFolder: "quarantine"}}
+		h.SetNextHandler(mock)
+		ctx := context.Background()
+
+		// Next handler does not consume the reader
+		content := strings.Repeat("clean content ", 1000)
+		_, e = h.PutObject(ctx, &tree.Node{Path: "ds/file.txt"}, strings.NewReader(content), &models.PutRequestData{Size: int64(len(content))})
+		So(e, ShouldBeNil)
+
+		// Next handler stores the content
+		mock.RootDir = tmpDir
+		written, e := h.PutObject(ctx, &tree.Node{Path: "file.txt"}, strings.NewReader(content), &models.PutRequestData{Size: int64(len(content))})
+		So(e, ShouldBeNil)
+		So(written, ShouldEqual, len(content))
+		data, e := ioutil.ReadFile(filepath.Join(tmpDir, "file.txt"))
+		So(e, ShouldBeNil)
+		So(string(data), ShouldEqual, content)
+
+		// Clamd is not reachable, upload must not fail
+		h.config.Address = "127.0.0.1:1"
+		_, e = h.PutObject(ctx, &tree.Node{Path: "other.txt"}, strings.NewReader(content), &models.PutRequestData{Size: int64(len(content))})
+		So(e, ShouldBeNil)
+	})
+
+}
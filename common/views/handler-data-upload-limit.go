@@ -22,7 +22,6 @@ package views
 
 import (
 	"context"
-	"fmt"
 	"io"
 	"path"
 	"strconv"
@@ -31,6 +30,7 @@ import (
 	"github.com/micro/go-micro/errors"
 	"github.com/pydio/minio-go"
 
+	"github.com/pydio/cells/common"
 	"github.com/pydio/cells/common/config"
 	"github.com/pydio/cells/common/log"
 	"github.com/pydio/cells/common/proto/idm"
@@ -47,56 +47,62 @@ type UploadLimitFilter struct {
 // Check Upload Limits (size, extension) defined in the frontend on PutObject operation
 func (a *UploadLimitFilter) PutObject(ctx context.Context, node *tree.Node, reader io.Reader, requestData *models.PutRequestData) (int64, error) {
 
-	size, exts, err := a.getUploadLimits(ctx)
-	if err != nil {
+	if err := a.checkLimits(ctx, node, requestData.Size); err != nil {
 		return 0, err
 	}
-	if size > 0 && requestData.Size > size {
-		return 0, errors.Forbidden("max.upload.limit", fmt.Sprintf("Upload limit is %d", size))
+
+	return a.next.PutObject(ctx, node, reader, requestData)
+}
+
+// Check Upload Limits (size, extension) defined in the frontend on MultipartCreate. Size is only checked if the
+// client declared the total size of the upload in the metadata.
+func (a *UploadLimitFilter) MultipartCreate(ctx context.Context, target *tree.Node, requestData *models.MultipartRequestData) (string, error) {
+
+	var size int64
+	if requestData.Metadata != nil {
+		size, _ = strconv.ParseInt(requestData.Metadata[common.XAmzMetaClearSize], 10, 64)
 	}
-	if len(exts) > 0 {
-		// Beware, Ext function includes the leading dot
-		nodeExt := path.Ext(node.GetPath())
-		allowed := false
-		for _, e := range exts {
-			if "."+strings.ToLower(e) == strings.ToLower(nodeExt) {
-				allowed = true
-				break
-			}
-		}
-		if !allowed {
-			return 0, errors.Forbidden("forbidden.upload.extensions", fmt.Sprintf("Extension %s is not allowed!", nodeExt))
-		}
+	if err := a.checkLimits(ctx, target, size); err != nil {
+		return "", err
 	}
 
-	return a.next.PutObject(ctx, node, reader, requestData)
+	return a.next.MultipartCreate(ctx, target, requestData)
 }
 
 // Check Upload Limits (size, extension) defined in the frontend on MultipartPutObjectPart
 func (a *UploadLimitFilter) MultipartPutObjectPart(ctx context.Context, target *tree.Node, uploadID string, partNumberMarker int, reader io.Reader, requestData *models.PutRequestData) (minio.ObjectPart, error) {
 
+	if err := a.checkLimits(ctx, target, requestData.Size); err != nil {
+		return minio.ObjectPart{}, err
+	}
+
+	return a.next.MultipartPutObjectPart(ctx, target, uploadID, partNumberMarker, reader, requestData)
+}
+
+func (a *UploadLimitFilter) checkLimits(ctx context.Context, node *tree.Node, requestSize int64) error {
+
 	size, exts, err := a.getUploadLimits(ctx)
 	if err != nil {
-		return minio.ObjectPart{}, err
+		return err
 	}
-	if size > 0 && requestData.Size > size {
-		return minio.ObjectPart{}, errors.Forbidden("max.upload.limit", fmt.Sprintf("Upload limit is %d", size))
+	if size > 0 && requestSize > size {
+		return errors.Forbidden("max.upload.limit", "Upload limit is %d", size)
 	}
 	if len(exts) > 0 {
-		nodeExt := path.Ext(target.GetPath())
+		// Beware, Ext function includes the leading dot
+		nodeExt := path.Ext(node.GetPath())
 		allowed := false
 		for _, e := range exts {
-			if strings.ToLower(e) == strings.ToLower(nodeExt) {
+			if "."+strings.ToLower(e) == strings.ToLower(nodeExt) {
 				allowed = true
 				break
 			}
 		}
 		if !allowed {
-			return minio.ObjectPart{}, errors.Forbidden("forbidden.upload.extensions", fmt.Sprintf("Extension %s is not allowed!", nodeExt))
+			return errors.Forbidden("forbidden.upload.extensions", "Extension %s is not allowed!", nodeExt)
 		}
 	}
-
-	return a.next.MultipartPutObjectPart(ctx, target, uploadID, partNumberMarker, reader, requestData)
+	return nil
 }
 
 // Parse Upload Limits from config
@@ -0,0 +1,68 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+package views
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/micro/go-micro/errors"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/pydio/cells/common/config"
+	"github.com/pydio/cells/common/proto/tree"
+	"github.com/pydio/cells/common/views/models"
+)
+
+func TestUploadLimitFilter(t *testing.T) {
+
+	config.Set(map[string]interface{}{
+		"UPLOAD_MAX_SIZE":    "100",
+		"ALLOWED_EXTENSIONS": "txt,PDF",
+	}, "frontend", "plugin", "core.uploader")
+	defer config.Del("frontend", "plugin", "core.uploader")
+
+	filter := &UploadLimitFilter{}
+	filter.SetNextHandler(NewHandlerMock())
+	ctx := context.Background()
+
+	Convey("Test multipart part with allowed extension", t, func() {
+		_, e := filter.MultipartPutObjectPart(ctx, &tree.Node{Path: "folder/file.txt"}, "upload", 1, strings.NewReader(""), &models.PutRequestData{Size: 50})
+		So(e, ShouldBeNil)
+		_, e = filter.MultipartPutObjectPart(ctx, &tree.Node{Path: "folder/file.pdf"}, "upload", 1, strings.NewReader(""), &models.PutRequestData{Size: 50})
+		So(e, ShouldBeNil)
+	})
+
+	Convey("Test multipart part with forbidden extension or size", t, func() {
+		_, e := filter.MultipartPutObjectPart(ctx, &tree.Node{Path: "folder/file.exe"}, "upload", 1, strings.NewReader(""), &models.PutRequestData{Size: 50})
+		So(e, ShouldNotBeNil)
+		So(errors.Parse(e.Error()).Id, ShouldEqual, "forbidden.upload.extensions")
+		_, e = filter.MultipartPutObjectPart(ctx, &tree.Node{Path: "folder/file.txt"}, "upload", 1, strings.NewReader(""), &models.PutRequestData{Size: 500})
+		So(e, ShouldNotBeNil)
+		So(errors.Parse(e.Error()).Id, ShouldEqual, "max.upload.limit")
+	})
+
+	Convey("Test put with allowed extension", t, func() {
+		_, e := filter.PutObject(ctx, &tree.Node{Path: "folder/file.txt"}, strings.NewReader(""), &models.PutRequestData{Size: 50})
+		So(e, ShouldBeNil)
+	})
+
+}
@@ -0,0 +1,157 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package views
+
+import (
+	"context"
+	"io"
+	"net/http"
+
+	"github.com/micro/go-micro/client"
+	"github.com/micro/go-micro/errors"
+
+	"github.com/pydio/cells/common/config"
+	"github.com/pydio/cells/common/proto/tree"
+	"github.com/pydio/cells/common/views/models"
+)
+
+// DataSourceMaintenanceFilter rejects operations on datasources that are currently in a maintenance window.
+// Read-only windows reject all writes, offline windows reject reads as well. Parts of multipart uploads that
+// were started before the window opened are still accepted, so that in-flight uploads can finish.
+type DataSourceMaintenanceFilter struct {
+	AbstractHandler
+	// maintenance finds the window opened on a datasource, defaults to config.GetActiveDataSourceMaintenance
+	maintenance func(dsName string) *config.DataSourceMaintenance
+}
+
+// NewDataSourceMaintenanceFilter creates a new DataSourceMaintenanceFilter
+func NewDataSourceMaintenanceFilter() *DataSourceMaintenanceFilter {
+	return &DataSourceMaintenanceFilter{
+		maintenance: config.GetActiveDataSourceMaintenance,
+	}
+}
+
+// ReadNode is rejected on offline datasources
+func (a *DataSourceMaintenanceFilter) ReadNode(ctx context.Context, in *tree.ReadNodeRequest, opts ...client.CallOption) (*tree.ReadNodeResponse, error) {
+	if e := a.checkMaintenance(ctx, false, "in"); e != nil {
+		return nil, e
+	}
+	return a.next.ReadNode(ctx, in, opts...)
+}
+
+// ListNodes is rejected on offline datasources
+func (a *DataSourceMaintenanceFilter) ListNodes(ctx context.Context, in *tree.ListNodesRequest, opts ...client.CallOption) (tree.NodeProvider_ListNodesClient, error) {
+	if e := a.checkMaintenance(ctx, false, "in"); e != nil {
+		return nil, e
+	}
+	return a.next.ListNodes(ctx, in, opts...)
+}
+
+// GetObject is rejected on offline datasources
+func (a *DataSourceMaintenanceFilter) GetObject(ctx context.Context, node *tree.Node, requestData *models.GetRequestData) (io.ReadCloser, error) {
+	if e := a.checkMaintenance(ctx, false, "in"); e != nil {
+		return nil, e
+	}
+	return a.next.GetObject(ctx, node, requestData)
+}
+
+// CreateNode is rejected on datasources in maintenance
+func (a *DataSourceMaintenanceFilter) CreateNode(ctx context.Context, in *tree.CreateNodeRequest, opts ...client.CallOption) (*tree.CreateNodeResponse, error) {
+	if e := a.checkMaintenance(ctx, true, "in"); e != nil {
+		return nil, e
+	}
+	return a.next.CreateNode(ctx, in, opts...)
+}
+
+// UpdateNode is rejected if either the source or the target datasource is in maintenance
+func (a *DataSourceMaintenanceFilter) UpdateNode(ctx context.Context, in *tree.UpdateNodeRequest, opts ...client.CallOption) (*tree.UpdateNodeResponse, error) {
+	if e := a.checkMaintenance(ctx, true, "from", "to"); e != nil {
+		return nil, e
+	}
+	return a.next.UpdateNode(ctx, in, opts...)
+}
+
+// DeleteNode is rejected on datasources in maintenance
+func (a *DataSourceMaintenanceFilter) DeleteNode(ctx context.Context, in *tree.DeleteNodeRequest, opts ...client.CallOption) (*tree.DeleteNodeResponse, error) {
+	if e := a.checkMaintenance(ctx, true, "in"); e != nil {
+		return nil, e
+	}
+	return a.next.DeleteNode(ctx, in, opts...)
+}
+
+// PutObject is rejected on datasources in maintenance
+func (a *DataSourceMaintenanceFilter) PutObject(ctx context.Context, node *tree.Node, reader io.Reader, requestData *models.PutRequestData) (int64, error) {
+	if e := a.checkMaintenance(ctx, true, "in"); e != nil {
+		return 0, e
+	}
+	return a.next.PutObject(ctx, node, reader, requestData)
+}
+
+// CopyObject is rejected if either the source or the target datasource is in maintenance
+func (a *DataSourceMaintenanceFilter) CopyObject(ctx context.Context, from *tree.Node, to *tree.Node, requestData *models.CopyRequestData) (int64, error) {
+	if e := a.checkMaintenance(ctx, true, "from", "to"); e != nil {
+		return 0, e
+	}
+	return a.next.CopyObject(ctx, from, to, requestData)
+}
+
+// MultipartCreate is rejected on datasources in maintenance. Parts, completion and abortion of uploads that are
+// already started are passed through.
+func (a *DataSourceMaintenanceFilter) MultipartCreate(ctx context.Context, target *tree.Node, requestData *models.MultipartRequestData) (string, error) {
+	if e := a.checkMaintenance(ctx, true, "in"); e != nil {
+		return "", e
+	}
+	return a.next.MultipartCreate(ctx, target, requestData)
+}
+
+// checkMaintenance looks up the datasources resolved for the given branches. Reads are only rejected on
+// offline datasources.
+func (a *DataSourceMaintenanceFilter) checkMaintenance(ctx context.Context, write bool, identifiers ...string) error {
+	for _, identifier := range identifiers {
+		branchInfo, ok := GetBranchInfo(ctx, identifier)
+		if !ok || branchInfo.LoadedSource.Name == "" {
+			continue
+		}
+		dsName := branchInfo.LoadedSource.Name
+		m := a.maintenance(dsName)
+		if m == nil {
+			continue
+		}
+		if m.Offline() {
+			return errors.New("datasource.maintenance", maintenanceMessage(dsName, m, "offline"), http.StatusServiceUnavailable)
+		}
+		if write && m.ReadOnly() {
+			return errors.Forbidden("datasource.maintenance", "%s", maintenanceMessage(dsName, m, "read-only"))
+		}
+	}
+	return nil
+}
+
+func maintenanceMessage(dsName string, m *config.DataSourceMaintenance, state string) string {
+	msg := "Datasource " + dsName + " is " + state + " for maintenance"
+	if !m.End.IsZero() {
+		msg += " until " + m.End.Format("2006-01-02 15:04 MST")
+	}
+	if m.Message != "" {
+		msg += ": " + m.Message
+	}
+	return msg
+}
@@ -0,0 +1,73 @@
+package views
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/micro/go-micro/errors"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/pydio/cells/common/config"
+	"github.com/pydio/cells/common/proto/object"
+	"github.com/pydio/cells/common/proto/tree"
+	"github.com/pydio/cells/common/views/models"
+)
+
+func TestDataSourceMaintenanceFilter(t *testing.T) {
+
+	windows := map[string]*config.DataSourceMaintenance{
+		"readonly": {Mode: config.MaintenanceReadOnly, Message: "Migrating storage"},
+		"offline":  {Mode: config.MaintenanceOffline},
+	}
+	filter := &DataSourceMaintenanceFilter{
+		maintenance: func(dsName string) *config.DataSourceMaintenance {
+			return windows[dsName]
+		},
+	}
+	mock := NewHandlerMock()
+	filter.SetNextHandler(mock)
+
+	dsContext := func(identifier, dsName string) context.Context {
+		return WithBranchInfo(context.Background(), identifier, BranchInfo{LoadedSource: LoadedSource{DataSource: object.DataSource{Name: dsName}}})
+	}
+	node := &tree.Node{Path: "file.txt"}
+	mock.Nodes[node.Path] = node
+
+	Convey("Test datasource without maintenance", t, func() {
+		ctx := dsContext("in", "pydiods1")
+		_, e := filter.PutObject(ctx, node, strings.NewReader(""), &models.PutRequestData{})
+		So(e, ShouldBeNil)
+		_, e = filter.ReadNode(ctx, &tree.ReadNodeRequest{Node: node})
+		So(e, ShouldBeNil)
+	})
+
+	Convey("Test read-only datasource", t, func() {
+		ctx := dsContext("in", "readonly")
+		_, e := filter.ReadNode(ctx, &tree.ReadNodeRequest{Node: node})
+		So(e, ShouldBeNil)
+		_, e = filter.PutObject(ctx, node, strings.NewReader(""), &models.PutRequestData{})
+		So(e, ShouldNotBeNil)
+		So(errors.Parse(e.Error()).Code, ShouldEqual, http.StatusForbidden)
+		So(errors.Parse(e.Error()).Detail, ShouldContainSubstring, "Migrating storage")
+		_, e = filter.MultipartCreate(ctx, node, &models.MultipartRequestData{})
+		So(e, ShouldNotBeNil)
+		_, e = filter.MultipartPutObjectPart(ctx, node, "upload", 1, strings.NewReader(""), &models.PutRequestData{})
+		So(e, ShouldBeNil)
+	})
+
+	Convey("Test offline datasource", t, func() {
+		ctx := dsContext("in", "offline")
+		_, e := filter.ReadNode(ctx, &tree.ReadNodeRequest{Node: node})
+		So(e, ShouldNotBeNil)
+		So(errors.Parse(e.Error()).Code, ShouldEqual, http.StatusServiceUnavailable)
+	})
+
+	Convey("Test move towards a read-only datasource", t, func() {
+		ctx := WithBranchInfo(dsContext("from", "pydiods1"), "to", BranchInfo{LoadedSource: LoadedSource{DataSource: object.DataSource{Name: "readonly"}}})
+		_, e := filter.UpdateNode(ctx, &tree.UpdateNodeRequest{From: node, To: node})
+		So(e, ShouldNotBeNil)
+	})
+
+}
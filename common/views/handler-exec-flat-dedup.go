@@ -0,0 +1,370 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package views
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"fmt"
+	"hash"
+	"io"
+	"io/ioutil"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/pborman/uuid"
+	"github.com/pydio/minio-go"
+	"go.uber.org/zap"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/log"
+	"github.com/pydio/cells/common/proto/object"
+	"github.com/pydio/cells/common/proto/tree"
+	"github.com/pydio/cells/common/registry"
+	"github.com/pydio/cells/common/sync/endpoints/s3"
+	context2 "github.com/pydio/cells/common/utils/context"
+	"github.com/pydio/cells/common/views/models"
+)
+
+const (
+	// dedupBlobsFolder is the folder where content-addressed objects are stored in a deduplicated datasource
+	dedupBlobsFolder = "blobs"
+)
+
+// blobs serializes the storage and the removal of a given blob inside this process
+var blobs = newBlobGuard()
+
+// blobGuard tracks, for each blob hash, the references that are stored but not yet registered in the index.
+// A blob is only removed when no such reference is pending, under the same lock as its storage.
+type blobGuard struct {
+	mu    sync.Mutex
+	locks map[string]*blobLock
+}
+
+type blobLock struct {
+	sync.Mutex
+	// users is the number of goroutines holding or waiting for the lock
+	users int
+	// pending is the number of references stored but not yet registered in the index
+	pending int
+}
+
+func newBlobGuard() *blobGuard {
+	return &blobGuard{locks: make(map[string]*blobLock)}
+}
+
+func (g *blobGuard) lock(hash string) *blobLock {
+	g.mu.Lock()
+	l, ok := g.locks[hash]
+	if !ok {
+		l = &blobLock{}
+		g.locks[hash] = l
+	}
+	l.users++
+	g.mu.Unlock()
+	l.Lock()
+	return l
+}
+
+func (g *blobGuard) unlock(hash string, l *blobLock, pending int) {
+	g.mu.Lock()
+	l.pending += pending
+	l.users--
+	if l.users == 0 && l.pending == 0 {
+		delete(g.locks, hash)
+	}
+	g.mu.Unlock()
+	l.Unlock()
+}
+
+// reserve runs store under the blob lock and marks a reference as pending. The returned function
+// must be called once the reference is registered in the index (or failed to be).
+func (g *blobGuard) reserve(hash string, store func() error) (func(), error) {
+	l := g.lock(hash)
+	if e := store(); e != nil {
+		g.unlock(hash, l, 0)
+		return nil, e
+	}
+	g.unlock(hash, l, 1)
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			g.mu.Lock()
+			l.pending--
+			if l.users == 0 && l.pending == 0 {
+				delete(g.locks, hash)
+			}
+			g.mu.Unlock()
+		})
+	}, nil
+}
+
+// release runs remove under the blob lock if no reference is pending and the index reports no reference anymore.
+func (g *blobGuard) release(hash string, refs func() (int, error), remove func() error) (bool, error) {
+	l := g.lock(hash)
+	defer g.unlock(hash, l, 0)
+	g.mu.Lock()
+	pending := l.pending
+	g.mu.Unlock()
+	if pending > 0 {
+		return false, nil
+	}
+	if n, e := refs(); e != nil || n > 0 {
+		return false, e
+	}
+	return true, remove()
+}
+
+// blobKey builds the object key for a given content hash, relative to the datasource objects base folder
+func blobKey(hash string) string {
+	return path.Join(dedupBlobsFolder, hash)
+}
+
+// isDedup checks if the branch points to a flat datasource storing objects by content hash
+func isDedup(ctx context.Context, identifier string) bool {
+	if info, ok := GetBranchInfo(ctx, identifier); ok && info.FlatStorage && !info.Binary && info.IsDeduplicated() {
+		return true
+	}
+	return false
+}
+
+// withoutBlob returns a node addressed by its uuid in the storage, cloning it if necessary
+func withoutBlob(node *tree.Node) *tree.Node {
+	if node == nil || !node.HasMetaKey(common.MetaNamespaceContentHash) {
+		return node
+	}
+	c := node.Clone()
+	delete(c.MetaStore, common.MetaNamespaceContentHash)
+	delete(c.MetaStore, common.MetaNamespaceContentRefs)
+	return c
+}
+
+// blobHasher computes both the content hash used as a blob key and the MD5 used as an ETag
+type blobHasher struct {
+	sha hash.Hash
+	md5 hash.Hash
+}
+
+func newBlobHasher() *blobHasher {
+	return &blobHasher{sha: sha256.New(), md5: md5.New()}
+}
+
+// Reader wraps a reader to feed the hashes while it is consumed
+func (b *blobHasher) Reader(r io.Reader) io.Reader {
+	return io.TeeReader(r, io.MultiWriter(b.sha, b.md5))
+}
+
+// Hash returns the hex-encoded SHA-256 of the content
+func (b *blobHasher) Hash() string {
+	return fmt.Sprintf("%x", b.sha.Sum(nil))
+}
+
+// MD5 returns the hex-encoded MD5 of the content
+func (b *blobHasher) MD5() string {
+	return fmt.Sprintf("%x", b.md5.Sum(nil))
+}
+
+// withBlob returns a copy of the node pointing to its blob, if it has one
+func (f *FlatStorageHandler) withBlob(ctx context.Context, node *tree.Node) *tree.Node {
+	if node.GetStringMeta(common.MetaNamespaceContentHash) != "" {
+		return node
+	}
+	resolved := node.Clone()
+	if r, e := f.next.ReadNode(ctx, &tree.ReadNodeRequest{Node: withoutBlob(node)}); e == nil && r.GetNode() != nil {
+		if h := r.GetNode().GetStringMeta(common.MetaNamespaceContentHash); h != "" {
+			resolved.SetMeta(common.MetaNamespaceContentHash, h)
+		}
+		if resolved.Uuid == "" {
+			resolved.Uuid = r.GetNode().GetUuid()
+		}
+	}
+	return resolved
+}
+
+// putBlob uploads content to a temporary object keyed by the node uuid, while computing its hash.
+// Object is then moved to its blob location (or simply dropped if the blob already exists) and
+// the index is updated with the content hash.
+func (f *FlatStorageHandler) putBlob(ctx context.Context, node *tree.Node, reader io.Reader, requestData *models.PutRequestData) (int64, error) {
+	target := withoutBlob(node)
+	hasher := newBlobHasher()
+	i, e := f.next.PutObject(ctx, target, hasher.Reader(reader), requestData)
+	if e != nil {
+		return i, e
+	}
+	if target != node {
+		node.Uuid = target.Uuid
+	}
+	meta := map[string]string{}
+	for k, v := range requestData.Metadata {
+		meta[k] = v
+	}
+	registered, e := f.storeBlob(ctx, "in", node, hasher.Hash(), hasher.MD5(), i, meta)
+	if e != nil {
+		return i, e
+	}
+	defer registered()
+	if er := f.postCreate(ctx, "in", node, meta, requestData.MetaContentType()); er != nil {
+		return i, er
+	}
+	return i, nil
+}
+
+// blobFromObject reads an object stored under the node uuid to compute its hash, then moves it to its blob location.
+// The returned function must be called once the node is updated in the index.
+func (f *FlatStorageHandler) blobFromObject(ctx context.Context, identifier string, node *tree.Node, meta map[string]string) (func(), error) {
+	info, _ := GetBranchInfo(ctx, identifier)
+	getOpts := minio.GetObjectOptions{}
+	if m, ok := context2.MinioMetaFromContext(ctx); ok {
+		for k, v := range m {
+			getOpts.Set(k, v)
+		}
+	}
+	reader, _, e := info.Client.GetObject(info.ObjectsBucket, f.objectKey(info, node.GetUuid()), getOpts)
+	if e != nil {
+		return nil, e
+	}
+	defer reader.Close()
+	hasher := newBlobHasher()
+	size, e := io.Copy(ioutil.Discard, hasher.Reader(reader))
+	if e != nil {
+		return nil, e
+	}
+	return f.storeBlob(ctx, identifier, node, hasher.Hash(), hasher.MD5(), size, meta)
+}
+
+// storeBlob moves the object stored under the node uuid to the blob location for this hash. If the
+// blob already exists, the uploaded object is simply removed. Hash is then attached to the node.
+// The blob cannot be released until the returned function is called, once the node is registered in the index.
+func (f *FlatStorageHandler) storeBlob(ctx context.Context, identifier string, node *tree.Node, hash, md5 string, size int64, meta map[string]string) (func(), error) {
+	info, _ := GetBranchInfo(ctx, identifier)
+	srcKey := f.objectKey(info, node.GetUuid())
+	dstKey := f.objectKey(info, blobKey(hash))
+	statOpts := minio.StatObjectOptions{}
+	copyMeta := map[string]string{
+		common.XAmzMetaDirective:  "REPLACE",
+		common.XAmzMetaContentMd5: md5,
+	}
+	if m, ok := context2.MinioMetaFromContext(ctx); ok {
+		for k, v := range m {
+			statOpts.Set(k, v)
+		}
+	}
+	registered, er := blobs.reserve(hash, func() error {
+		if _, e := info.Client.StatObject(info.ObjectsBucket, dstKey, statOpts); e != nil {
+			if e.Error() != noSuchKeyString {
+				return e
+			}
+			src, e := info.Client.StatObject(info.ObjectsBucket, srcKey, statOpts)
+			if e != nil {
+				return e
+			}
+			if src.Size > s3.MaxCopyObjectSize && info.StorageType != object.StorageType_LOCAL {
+				e = s3.CopyObjectMultipart(ctx, info.Client, src, info.ObjectsBucket, srcKey, info.ObjectsBucket, dstKey, copyMeta, nil)
+			} else {
+				_, e = info.Client.CopyObject(info.ObjectsBucket, srcKey, info.ObjectsBucket, dstKey, copyMeta)
+			}
+			if e != nil {
+				return e
+			}
+			log.Logger(ctx).Debug("Stored new blob", zap.String("hash", hash), zap.Int64("size", size))
+		} else {
+			log.Logger(ctx).Debug("Blob already exists, dropping uploaded object", zap.String("hash", hash), node.ZapPath())
+		}
+		return nil
+	})
+	if er != nil {
+		return nil, er
+	}
+	if e := info.Client.RemoveObjectWithContext(ctx, info.ObjectsBucket, srcKey); e != nil {
+		log.Logger(ctx).Warn("Cannot remove temporary object after storing blob", zap.String("key", srcKey), zap.Error(e))
+	}
+	node.SetMeta(common.MetaNamespaceContentHash, hash)
+	meta[common.XAmzMetaContentMd5] = md5
+	meta[common.XAmzMetaClearSize] = fmt.Sprintf("%d", size)
+	return registered, nil
+}
+
+// releaseBlobs removes blobs that the index reported as not referenced anymore, unless a new reference
+// was stored in the meantime.
+func (f *FlatStorageHandler) releaseBlobs(ctx context.Context, identifier string, hashes []string) {
+	if len(hashes) == 0 {
+		return
+	}
+	info, _ := GetBranchInfo(ctx, identifier)
+	indexClient := tree.NewNodeProviderClient(registry.GetClient(common.ServiceDataIndex_ + info.Name))
+	for _, hash := range hashes {
+		refs := func() (int, error) {
+			blob := &tree.Node{}
+			blob.SetMeta(common.MetaNamespaceContentHash, hash)
+			r, e := indexClient.ReadNode(ctx, &tree.ReadNodeRequest{Node: blob})
+			if e != nil {
+				return 0, e
+			}
+			var n int
+			e = r.GetNode().GetMeta(common.MetaNamespaceContentRefs, &n)
+			return n, e
+		}
+		remove := func() error {
+			return info.Client.RemoveObjectWithContext(ctx, info.ObjectsBucket, f.objectKey(info, blobKey(hash)))
+		}
+		if removed, e := blobs.release(hash, refs, remove); e != nil {
+			log.Logger(ctx).Error("Cannot remove unreferenced blob "+hash, zap.Error(e))
+		} else if !removed {
+			log.Logger(ctx).Debug("Blob is referenced again, keeping it", zap.String("hash", hash))
+		}
+	}
+}
+
+// copyBlobRef copies a node inside a deduplicated datasource by creating a new reference to the same blob,
+// without touching the storage.
+func (f *FlatStorageHandler) copyBlobRef(ctx context.Context, from *tree.Node, to *tree.Node, hash string, requestData *models.CopyRequestData) (int64, error) {
+	target := withoutBlob(to).Clone()
+	if dir, o := requestData.Metadata[common.XAmzMetaDirective]; o && dir == "COPY" {
+		target.Uuid = from.Uuid
+	} else if target.Uuid == "" || target.Uuid == from.Uuid {
+		target.Uuid = uuid.New()
+	}
+	target.Type = tree.NodeType_LEAF
+	target.Size = from.Size
+	target.Etag = from.Etag
+	target.MTime = time.Now().Unix()
+	if ctype := from.GetStringMeta(common.MetaNamespaceMime); ctype != "" {
+		target.SetMeta(common.MetaNamespaceMime, ctype)
+	}
+	target.SetMeta(common.MetaNamespaceContentHash, hash)
+	resp, e := f.clientsPool.GetTreeClientWrite().CreateNode(ctx, &tree.CreateNodeRequest{Node: target, UpdateIfExists: true})
+	if e != nil {
+		return 0, e
+	}
+	to.Uuid = resp.GetNode().GetUuid()
+	f.releaseBlobs(ctx, "to", resp.GetReleasedContentHashes())
+	return from.Size, nil
+}
+
+// objectKey prepends the datasource objects base folder to a key
+func (f *FlatStorageHandler) objectKey(info BranchInfo, key string) string {
+	if info.ObjectsBaseFolder != "" {
+		return path.Join(info.ObjectsBaseFolder, key)
+	}
+	return key
+}
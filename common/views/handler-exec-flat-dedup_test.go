@@ -0,0 +1,119 @@
+package views
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/proto/object"
+	"github.com/pydio/cells/common/proto/tree"
+)
+
+func TestFlatDedup(t *testing.T) {
+
+	dedupSource := LoadedSource{DataSource: object.DataSource{
+		Name:                 "flat",
+		FlatStorage:          true,
+		ObjectsBaseFolder:    "base",
+		StorageConfiguration: map[string]string{object.StorageKeyDedup: "true"},
+	}}
+
+	Convey("Test dedup detection", t, func() {
+		ctx := WithBranchInfo(context.Background(), "in", BranchInfo{LoadedSource: dedupSource})
+		So(isDedup(ctx, "in"), ShouldBeTrue)
+		So(isDedup(ctx, "to"), ShouldBeFalse)
+
+		encrypted := dedupSource
+		encrypted.EncryptionMode = object.EncryptionMode_MASTER
+		ctx = WithBranchInfo(context.Background(), "in", BranchInfo{LoadedSource: encrypted})
+		So(isDedup(ctx, "in"), ShouldBeFalse)
+	})
+
+	Convey("Test blob storage keys", t, func() {
+		e := &Executor{}
+		n := &tree.Node{Uuid: "node-uuid", Type: tree.NodeType_LEAF}
+		So(e.buildS3Path(BranchInfo{LoadedSource: dedupSource}, n), ShouldEqual, "base/node-uuid")
+
+		n.SetMeta(common.MetaNamespaceContentHash, "abcdef")
+		So(e.buildS3Path(BranchInfo{LoadedSource: dedupSource}, n), ShouldEqual, "base/blobs/abcdef")
+
+		plain := dedupSource
+		plain.StorageConfiguration = nil
+		So(e.buildS3Path(BranchInfo{LoadedSource: plain}, n), ShouldEqual, "base/node-uuid")
+
+		clean := withoutBlob(n)
+		So(clean, ShouldNotEqual, n)
+		So(clean.HasMetaKey(common.MetaNamespaceContentHash), ShouldBeFalse)
+		So(n.GetStringMeta(common.MetaNamespaceContentHash), ShouldEqual, "abcdef")
+		So(withoutBlob(clean), ShouldEqual, clean)
+	})
+
+	Convey("Test blob hasher", t, func() {
+		h := newBlobHasher()
+		r := h.Reader(strings.NewReader("hello world"))
+		buf := make([]byte, 64)
+		for {
+			if _, e := r.Read(buf); e != nil {
+				break
+			}
+		}
+		So(h.Hash(), ShouldEqual, "b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9")
+		So(h.MD5(), ShouldEqual, "5eb63bbbe01eeed093cb22bb8f5acdc3")
+	})
+
+	Convey("Test concurrent blob store and release", t, func() {
+		g := newBlobGuard()
+		for i := 0; i < 200; i++ {
+			// The index just dropped the last reference, while a new upload reuses the same content
+			var mu sync.Mutex
+			stored, refs := true, 0
+			count := func() (int, error) {
+				mu.Lock()
+				defer mu.Unlock()
+				return refs, nil
+			}
+			remove := func() error {
+				mu.Lock()
+				defer mu.Unlock()
+				stored = false
+				return nil
+			}
+			var wg sync.WaitGroup
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				g.release("hash", count, remove)
+			}()
+			go func() {
+				defer wg.Done()
+				registered, e := g.reserve("hash", func() error {
+					mu.Lock()
+					defer mu.Unlock()
+					stored = true
+					return nil
+				})
+				if e != nil {
+					return
+				}
+				mu.Lock()
+				refs++
+				mu.Unlock()
+				registered()
+			}()
+			wg.Wait()
+			So(refs, ShouldEqual, 1)
+			So(stored, ShouldBeTrue)
+		}
+		So(g.locks, ShouldBeEmpty)
+
+		removed, e := g.release("hash", func() (int, error) { return 0, nil }, func() error { return nil })
+		So(e, ShouldBeNil)
+		So(removed, ShouldBeTrue)
+		So(g.locks, ShouldBeEmpty)
+	})
+
+}
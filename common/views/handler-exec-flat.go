@@ -75,7 +75,20 @@ func (f *FlatStorageHandler) CreateNode(ctx context.Context, in *tree.CreateNode
 func (f *FlatStorageHandler) DeleteNode(ctx context.Context, in *tree.DeleteNodeRequest, opts ...client.CallOption) (*tree.DeleteNodeResponse, error) {
 	isFlat := isFlatStorage(ctx, "in")
 	if isFlat && !in.GetNode().IsLeaf() {
-		return f.clientsPool.GetTreeClientWrite().DeleteNode(ctx, in)
+		resp, e := f.clientsPool.GetTreeClientWrite().DeleteNode(ctx, in)
+		if e == nil && isDedup(ctx, "in") {
+			f.releaseBlobs(ctx, "in", resp.GetReleasedContentHashes())
+		}
+		return resp, e
+	}
+	if isDedup(ctx, "in") && f.withBlob(ctx, in.GetNode()).GetStringMeta(common.MetaNamespaceContentHash) != "" {
+		// Remove reference from index first, index reports blobs that are not referenced anymore
+		resp, e := f.clientsPool.GetTreeClientWrite().DeleteNode(ctx, in)
+		if e != nil {
+			return resp, e
+		}
+		f.releaseBlobs(ctx, "in", resp.GetReleasedContentHashes())
+		return resp, nil
 	}
 	resp, e := f.next.DeleteNode(ctx, in, opts...)
 	if isFlat && e == nil && resp.Success {
@@ -90,12 +103,27 @@ func (f *FlatStorageHandler) GetObject(ctx context.Context, node *tree.Node, req
 		if e := f.resolveUUID(ctx, node); e != nil {
 			return nil, e
 		}
+		if isDedup(ctx, "in") && requestData.VersionId == "" {
+			node = f.withBlob(ctx, node)
+		}
 	}
 	return f.next.GetObject(ctx, node, requestData)
 }
 
 func (f *FlatStorageHandler) CopyObject(ctx context.Context, from *tree.Node, to *tree.Node, requestData *models.CopyRequestData) (int64, error) {
 
+	if isDedup(ctx, "from") && len(requestData.SrcVersionId) == 0 {
+		from = f.withBlob(ctx, from)
+		srcInfo, _ := GetBranchInfo(ctx, "from")
+		destInfo, _ := GetBranchInfo(ctx, "to")
+		if hash := from.GetStringMeta(common.MetaNamespaceContentHash); hash != "" && isDedup(ctx, "to") && srcInfo.Name == destInfo.Name {
+			return f.copyBlobRef(ctx, from, to, hash, requestData)
+		}
+	}
+	if isDedup(ctx, "to") {
+		to = withoutBlob(to)
+	}
+
 	var revertNode *tree.Node
 	if isFlatStorage(ctx, "to") {
 		if len(requestData.SrcVersionId) > 0 {
@@ -134,8 +162,20 @@ func (f *FlatStorageHandler) CopyObject(ctx context.Context, from *tree.Node, to
 				tgtCtx = context2.WithAdditionalMetadata(tgtCtx, requestData.Metadata)
 			}
 		}
+		postMeta := requestData.Metadata
+		if isDedup(ctx, "to") && len(requestData.SrcVersionId) == 0 {
+			postMeta = map[string]string{}
+			for k, v := range requestData.Metadata {
+				postMeta[k] = v
+			}
+			registered, er := f.blobFromObject(tgtCtx, "to", to, postMeta)
+			if er != nil {
+				return i, er
+			}
+			defer registered()
+		}
 		// Now store in index
-		if er := f.postCreate(tgtCtx, "to", to, requestData.Metadata, from.GetStringMeta(common.MetaNamespaceMime)); er != nil {
+		if er := f.postCreate(tgtCtx, "to", to, postMeta, from.GetStringMeta(common.MetaNamespaceMime)); er != nil {
 			return i, er
 		}
 	} else if e != nil && revertNode != nil {
@@ -149,6 +189,9 @@ func (f *FlatStorageHandler) CopyObject(ctx context.Context, from *tree.Node, to
 }
 
 func (f *FlatStorageHandler) PutObject(ctx context.Context, node *tree.Node, reader io.Reader, requestData *models.PutRequestData) (int64, error) {
+	if isDedup(ctx, "in") {
+		return f.putBlob(ctx, node, reader, requestData)
+	}
 	i, e := f.next.PutObject(ctx, node, reader, requestData)
 	if e == nil && isFlatStorage(ctx, "in") {
 		if er := f.postCreate(ctx, "in", node, requestData.Metadata, requestData.MetaContentType()); er != nil {
@@ -158,7 +201,17 @@ func (f *FlatStorageHandler) PutObject(ctx context.Context, node *tree.Node, rea
 	return i, e
 }
 
+func (f *FlatStorageHandler) MultipartCreate(ctx context.Context, target *tree.Node, requestData *models.MultipartRequestData) (string, error) {
+	if isDedup(ctx, "in") {
+		target = withoutBlob(target)
+	}
+	return f.next.MultipartCreate(ctx, target, requestData)
+}
+
 func (f *FlatStorageHandler) MultipartPutObjectPart(ctx context.Context, target *tree.Node, uploadID string, partNumberMarker int, reader io.Reader, requestData *models.PutRequestData) (minio.ObjectPart, error) {
+	if isDedup(ctx, "in") {
+		target = withoutBlob(target)
+	}
 	if isFlatStorage(ctx, "in") {
 		if e := f.resolveUUID(ctx, target); e != nil {
 			return minio.ObjectPart{}, e
@@ -168,6 +221,9 @@ func (f *FlatStorageHandler) MultipartPutObjectPart(ctx context.Context, target
 }
 
 func (f *FlatStorageHandler) MultipartComplete(ctx context.Context, target *tree.Node, uploadID string, uploadedParts []minio.CompletePart) (minio.ObjectInfo, error) {
+	if isDedup(ctx, "in") {
+		target = withoutBlob(target)
+	}
 	if isFlatStorage(ctx, "in") {
 		if e := f.resolveUUID(ctx, target); e != nil {
 			return minio.ObjectInfo{}, e
@@ -189,6 +245,13 @@ func (f *FlatStorageHandler) MultipartComplete(ctx context.Context, target *tree
 				meta[common.XAmzMetaClearSize] = fmt.Sprintf("%d", info.Size)
 			}
 		}
+		if isDedup(ctx, "in") {
+			registered, er := f.blobFromObject(ctx, "in", target, meta)
+			if er != nil {
+				return info, er
+			}
+			defer registered()
+		}
 		if er := f.postCreate(ctx, "in", target, meta, ""); er != nil {
 			return info, er
 		}
@@ -197,6 +260,9 @@ func (f *FlatStorageHandler) MultipartComplete(ctx context.Context, target *tree
 }
 
 func (f *FlatStorageHandler) MultipartListObjectParts(ctx context.Context, target *tree.Node, uploadID string, partNumberMarker int, maxParts int) (lpi minio.ListObjectPartsResult, err error) {
+	if isDedup(ctx, "in") {
+		target = withoutBlob(target)
+	}
 	if isFlatStorage(ctx, "in") {
 		if e := f.resolveUUID(ctx, target); e != nil {
 			return minio.ListObjectPartsResult{}, e
@@ -206,6 +272,9 @@ func (f *FlatStorageHandler) MultipartListObjectParts(ctx context.Context, targe
 }
 
 func (f *FlatStorageHandler) MultipartAbort(ctx context.Context, target *tree.Node, uploadID string, requestData *models.MultipartRequestData) error {
+	if isDedup(ctx, "in") {
+		target = withoutBlob(target)
+	}
 	if isFlatStorage(ctx, "in") {
 		if e := f.resolveUUID(ctx, target); e != nil {
 			return e
@@ -247,6 +316,9 @@ func (f *FlatStorageHandler) postCreate(ctx context.Context, identifier string,
 	if err != nil {
 		return err
 	}
+	if hash := node.GetStringMeta(common.MetaNamespaceContentHash); hash != "" {
+		updateNode.SetMeta(common.MetaNamespaceContentHash, hash)
+	}
 	updateNode.MTime = stats.GetNode().GetMTime()
 	updateNode.Size = stats.GetNode().GetSize()
 	if requestMeta != nil {
@@ -257,7 +329,9 @@ func (f *FlatStorageHandler) postCreate(ctx context.Context, identifier string,
 		}
 	}
 	updateNode.Etag = stats.GetNode().GetEtag()
-	if updateNode.Etag == "" || strings.Contains(updateNode.Etag, "-") {
+	if checksum := requestMeta[common.XAmzMetaContentMd5]; checksum != "" && (updateNode.Etag == "" || strings.Contains(updateNode.Etag, "-")) {
+		updateNode.Etag = checksum
+	} else if updateNode.Etag == "" || strings.Contains(updateNode.Etag, "-") {
 		newETag, e := f.recomputeETag(ctx, identifier, updateNode)
 		if e == nil {
 			log.Logger(ctx).Info("Recomputed ETag :" + updateNode.Etag + " => " + newETag)
@@ -269,10 +343,11 @@ func (f *FlatStorageHandler) postCreate(ctx context.Context, identifier string,
 	if cType != "" {
 		updateNode.SetMeta(common.MetaNamespaceMime, cType)
 	}
-	_, er := f.clientsPool.GetTreeClientWrite().CreateNode(ctx, &tree.CreateNodeRequest{Node: updateNode, UpdateIfExists: true})
+	resp, er := f.clientsPool.GetTreeClientWrite().CreateNode(ctx, &tree.CreateNodeRequest{Node: updateNode, UpdateIfExists: true})
 	if er != nil {
 		return er
 	}
+	f.releaseBlobs(ctx, identifier, resp.GetReleasedContentHashes())
 	return nil
 }
 
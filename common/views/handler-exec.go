@@ -528,6 +528,9 @@ func (e *Executor) buildS3Path(branchInfo BranchInfo, node *tree.Node) string {
 
 	if branchInfo.FlatStorage && !branchInfo.Binary {
 		nodeId := node.GetUuid()
+		if hash := node.GetStringMeta(common.MetaNamespaceContentHash); hash != "" && branchInfo.IsDeduplicated() {
+			nodeId = blobKey(hash)
+		}
 		if branchInfo.ObjectsBaseFolder != "" {
 			return path.Join(branchInfo.ObjectsBaseFolder, nodeId)
 		} else {
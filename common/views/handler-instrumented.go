@@ -0,0 +1,188 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package views
+
+import (
+	"context"
+	"io"
+	"reflect"
+	"time"
+
+	"github.com/micro/go-micro/client"
+	"github.com/pydio/minio-go"
+
+	"github.com/pydio/cells/common/proto/tree"
+	servicecontext "github.com/pydio/cells/common/service/context"
+	"github.com/pydio/cells/common/service/metrics"
+	"github.com/pydio/cells/common/service/tracing"
+	"github.com/pydio/cells/common/views/models"
+)
+
+// InstrumentedHandler decorates a Handler to record a span and a duration for each call going through it.
+// They cover the call itself: for streams and readers, they end before the data is consumed.
+type InstrumentedHandler struct {
+	Handler
+	name string
+}
+
+// NewInstrumentedHandler wraps a Handler, naming its spans and metrics after the handler type.
+func NewInstrumentedHandler(h Handler) *InstrumentedHandler {
+	t := reflect.TypeOf(h)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return &InstrumentedHandler{Handler: h, name: t.Name()}
+}
+
+// callSpan is a running call to a handler method.
+type callSpan struct {
+	*tracing.Span
+	start  time.Time
+	name   string
+	method string
+}
+
+func (t *InstrumentedHandler) start(ctx context.Context, method string, node *tree.Node) (context.Context, *callSpan) {
+	ctx, span := tracing.StartSpan(ctx, servicecontext.GetServiceName(ctx), "views."+t.name+"/"+method, tracing.KindInternal)
+	if node != nil {
+		span.SetAttribute("node.path", node.GetPath())
+	}
+	return ctx, &callSpan{Span: span, start: time.Now(), name: t.name, method: method}
+}
+
+func finishSpan(span *callSpan, e error) {
+	span.SetError(e)
+	span.Finish()
+	metrics.GetMetrics().Tagged(map[string]string{
+		"handler": span.name,
+		"method":  span.method,
+	}).Timer("views_handler_duration").Record(time.Since(span.start))
+}
+
+func (t *InstrumentedHandler) ReadNode(ctx context.Context, in *tree.ReadNodeRequest, opts ...client.CallOption) (*tree.ReadNodeResponse, error) {
+	ctx, span := t.start(ctx, "ReadNode", in.GetNode())
+	resp, e := t.Handler.ReadNode(ctx, in, opts...)
+	finishSpan(span, e)
+	return resp, e
+}
+
+func (t *InstrumentedHandler) ListNodes(ctx context.Context, in *tree.ListNodesRequest, opts ...client.CallOption) (tree.NodeProvider_ListNodesClient, error) {
+	ctx, span := t.start(ctx, "ListNodes", in.GetNode())
+	resp, e := t.Handler.ListNodes(ctx, in, opts...)
+	finishSpan(span, e)
+	return resp, e
+}
+
+func (t *InstrumentedHandler) CreateNode(ctx context.Context, in *tree.CreateNodeRequest, opts ...client.CallOption) (*tree.CreateNodeResponse, error) {
+	ctx, span := t.start(ctx, "CreateNode", in.GetNode())
+	resp, e := t.Handler.CreateNode(ctx, in, opts...)
+	finishSpan(span, e)
+	return resp, e
+}
+
+func (t *InstrumentedHandler) UpdateNode(ctx context.Context, in *tree.UpdateNodeRequest, opts ...client.CallOption) (*tree.UpdateNodeResponse, error) {
+	ctx, span := t.start(ctx, "UpdateNode", in.GetFrom())
+	resp, e := t.Handler.UpdateNode(ctx, in, opts...)
+	finishSpan(span, e)
+	return resp, e
+}
+
+func (t *InstrumentedHandler) DeleteNode(ctx context.Context, in *tree.DeleteNodeRequest, opts ...client.CallOption) (*tree.DeleteNodeResponse, error) {
+	ctx, span := t.start(ctx, "DeleteNode", in.GetNode())
+	resp, e := t.Handler.DeleteNode(ctx, in, opts...)
+	finishSpan(span, e)
+	return resp, e
+}
+
+func (t *InstrumentedHandler) StreamChanges(ctx context.Context, in *tree.StreamChangesRequest, opts ...client.CallOption) (tree.NodeChangesStreamer_StreamChangesClient, error) {
+	ctx, span := t.start(ctx, "StreamChanges", nil)
+	resp, e := t.Handler.StreamChanges(ctx, in, opts...)
+	finishSpan(span, e)
+	return resp, e
+}
+
+func (t *InstrumentedHandler) GetObject(ctx context.Context, node *tree.Node, requestData *models.GetRequestData) (io.ReadCloser, error) {
+	ctx, span := t.start(ctx, "GetObject", node)
+	resp, e := t.Handler.GetObject(ctx, node, requestData)
+	finishSpan(span, e)
+	return resp, e
+}
+
+func (t *InstrumentedHandler) PutObject(ctx context.Context, node *tree.Node, reader io.Reader, requestData *models.PutRequestData) (int64, error) {
+	ctx, span := t.start(ctx, "PutObject", node)
+	if requestData != nil {
+		span.SetAttribute("object.size", requestData.Size)
+	}
+	resp, e := t.Handler.PutObject(ctx, node, reader, requestData)
+	finishSpan(span, e)
+	return resp, e
+}
+
+func (t *InstrumentedHandler) CopyObject(ctx context.Context, from *tree.Node, to *tree.Node, requestData *models.CopyRequestData) (int64, error) {
+	ctx, span := t.start(ctx, "CopyObject", from)
+	span.SetAttribute("node.target", to.GetPath())
+	resp, e := t.Handler.CopyObject(ctx, from, to, requestData)
+	finishSpan(span, e)
+	return resp, e
+}
+
+func (t *InstrumentedHandler) MultipartCreate(ctx context.Context, target *tree.Node, requestData *models.MultipartRequestData) (string, error) {
+	ctx, span := t.start(ctx, "MultipartCreate", target)
+	resp, e := t.Handler.MultipartCreate(ctx, target, requestData)
+	finishSpan(span, e)
+	return resp, e
+}
+
+func (t *InstrumentedHandler) MultipartPutObjectPart(ctx context.Context, target *tree.Node, uploadID string, partNumberMarker int, reader io.Reader, requestData *models.PutRequestData) (minio.ObjectPart, error) {
+	ctx, span := t.start(ctx, "MultipartPutObjectPart", target)
+	span.SetAttribute("multipart.part", partNumberMarker)
+	resp, e := t.Handler.MultipartPutObjectPart(ctx, target, uploadID, partNumberMarker, reader, requestData)
+	finishSpan(span, e)
+	return resp, e
+}
+
+func (t *InstrumentedHandler) MultipartList(ctx context.Context, prefix string, requestData *models.MultipartRequestData) (minio.ListMultipartUploadsResult, error) {
+	ctx, span := t.start(ctx, "MultipartList", nil)
+	resp, e := t.Handler.MultipartList(ctx, prefix, requestData)
+	finishSpan(span, e)
+	return resp, e
+}
+
+func (t *InstrumentedHandler) MultipartAbort(ctx context.Context, target *tree.Node, uploadID string, requestData *models.MultipartRequestData) error {
+	ctx, span := t.start(ctx, "MultipartAbort", target)
+	e := t.Handler.MultipartAbort(ctx, target, uploadID, requestData)
+	finishSpan(span, e)
+	return e
+}
+
+func (t *InstrumentedHandler) MultipartComplete(ctx context.Context, target *tree.Node, uploadID string, uploadedParts []minio.CompletePart) (minio.ObjectInfo, error) {
+	ctx, span := t.start(ctx, "MultipartComplete", target)
+	resp, e := t.Handler.MultipartComplete(ctx, target, uploadID, uploadedParts)
+	finishSpan(span, e)
+	return resp, e
+}
+
+func (t *InstrumentedHandler) MultipartListObjectParts(ctx context.Context, target *tree.Node, uploadID string, partNumberMarker int, maxParts int) (minio.ListObjectPartsResult, error) {
+	ctx, span := t.start(ctx, "MultipartListObjectParts", target)
+	resp, e := t.Handler.MultipartListObjectParts(ctx, target, uploadID, partNumberMarker, maxParts)
+	finishSpan(span, e)
+	return resp, e
+}
@@ -0,0 +1,491 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package views
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/micro/go-micro/errors"
+	"github.com/patrickmn/go-cache"
+	"github.com/pydio/minio-go"
+	"go.uber.org/zap"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/auth/claim"
+	"github.com/pydio/cells/common/config"
+	"github.com/pydio/cells/common/log"
+	"github.com/pydio/cells/common/proto/idm"
+	"github.com/pydio/cells/common/proto/mailer"
+	"github.com/pydio/cells/common/proto/tree"
+	"github.com/pydio/cells/common/registry"
+	context2 "github.com/pydio/cells/common/utils/context"
+	"github.com/pydio/cells/common/utils/permissions"
+	"github.com/pydio/cells/common/views/models"
+	json "github.com/pydio/cells/x/jsonx"
+)
+
+const (
+	userQuotaPlugin     = "core.uploader"
+	userQuotaParam      = "USER_QUOTA"
+	groupQuotaParam     = "GROUP_QUOTA"
+	quotaThresholdParam = "QUOTA_WARNING_THRESHOLDS"
+
+	defaultQuotaThresholds = "80,95"
+)
+
+var (
+	userQuotaCache    = cache.New(1*time.Minute, 5*time.Minute)
+	userQuotaWarnings = cache.New(24*time.Hour, 1*time.Hour)
+)
+
+// NodeOwner is stored as JSON in the MetaNamespaceOwner namespace of each file uploaded through the views.
+// Fields order matters as it is used to build search patterns.
+type NodeOwner struct {
+	Login     string
+	GroupPath string
+	Size      int64
+}
+
+// GroupQuota is a storage quota shared by all the users of a group and its sub-groups.
+type GroupQuota struct {
+	Path  string
+	Quota int64
+	Usage int64
+}
+
+// UserQuota gathers the storage quotas applying to a user across all workspaces, along with their current usage.
+type UserQuota struct {
+	Quota      int64
+	Usage      int64
+	Groups     []*GroupQuota
+	Thresholds []int
+}
+
+// Exceeded checks if adding size bytes would go over one of the quotas.
+func (u *UserQuota) Exceeded(size int64) error {
+	if u.Quota > 0 && u.Usage+size > u.Quota {
+		return errors.New("quota.exceeded", fmt.Sprintf("Your allowed quota of %d is reached", u.Quota), 422)
+	}
+	for _, g := range u.Groups {
+		if g.Usage+size > g.Quota {
+			return errors.New("quota.exceeded", fmt.Sprintf("The quota of %d allowed to your group is reached", g.Quota), 422)
+		}
+	}
+	return nil
+}
+
+// UserQuotaFilter applies storage quota limitation on a per-user and per-group basis, counting all files owned by
+// the users whatever the workspace they are stored in.
+type UserQuotaFilter struct {
+	AbstractHandler
+}
+
+// PutObject checks quota and records node owner on PutObject operation.
+func (a *UserQuotaFilter) PutObject(ctx context.Context, node *tree.Node, reader io.Reader, requestData *models.PutRequestData) (int64, error) {
+
+	claims, ok := a.quotaClaims(ctx, "in")
+	if !ok {
+		return a.next.PutObject(ctx, node, reader, requestData)
+	}
+	if q, e := a.cachedQuota(ctx, claims); e != nil {
+		return 0, e
+	} else if e := q.Exceeded(requestData.Size); e != nil {
+		return 0, e
+	}
+	written, err := a.next.PutObject(ctx, node, reader, requestData)
+	if err == nil {
+		size := written
+		if size <= 0 {
+			size = requestData.Size
+		}
+		a.recordOwner(ctx, claims, node.GetUuid(), size)
+	}
+	return written, err
+}
+
+// MultipartCreate refuses to start a multipart upload if quota is already reached, or would be
+// exceeded by the total size declared in the request metadata.
+func (a *UserQuotaFilter) MultipartCreate(ctx context.Context, target *tree.Node, requestData *models.MultipartRequestData) (string, error) {
+
+	if claims, ok := a.quotaClaims(ctx, "in"); ok {
+		size := int64(1)
+		if requestData.Metadata != nil {
+			if s, e := strconv.ParseInt(requestData.Metadata[common.XAmzMetaClearSize], 10, 64); e == nil && s > 0 {
+				size = s
+			}
+		}
+		if q, e := a.cachedQuota(ctx, claims); e != nil {
+			return "", e
+		} else if e := q.Exceeded(size); e != nil {
+			return "", e
+		}
+	}
+	return a.next.MultipartCreate(ctx, target, requestData)
+}
+
+// MultipartPutObjectPart checks quota on MultipartPutObjectPart.
+func (a *UserQuotaFilter) MultipartPutObjectPart(ctx context.Context, target *tree.Node, uploadID string, partNumberMarker int, reader io.Reader, requestData *models.PutRequestData) (minio.ObjectPart, error) {
+
+	if claims, ok := a.quotaClaims(ctx, "in"); ok {
+		if q, e := a.cachedQuota(ctx, claims); e != nil {
+			return minio.ObjectPart{}, e
+		} else if e := q.Exceeded(requestData.Size); e != nil {
+			return minio.ObjectPart{}, e
+		}
+	}
+	return a.next.MultipartPutObjectPart(ctx, target, uploadID, partNumberMarker, reader, requestData)
+}
+
+// MultipartComplete records node owner once the upload is finished.
+func (a *UserQuotaFilter) MultipartComplete(ctx context.Context, target *tree.Node, uploadID string, uploadedParts []minio.CompletePart) (minio.ObjectInfo, error) {
+
+	info, err := a.next.MultipartComplete(ctx, target, uploadID, uploadedParts)
+	if err != nil {
+		return info, err
+	}
+	if claims, ok := a.quotaClaims(ctx, "in"); ok {
+		a.recordOwner(ctx, claims, a.resolveUuid(ctx, target), info.Size)
+	}
+	return info, err
+}
+
+// CopyObject checks quota and records node owner on CopyObject operation.
+func (a *UserQuotaFilter) CopyObject(ctx context.Context, from *tree.Node, to *tree.Node, requestData *models.CopyRequestData) (int64, error) {
+
+	claims, ok := a.quotaClaims(ctx, "to")
+	if !ok {
+		return a.next.CopyObject(ctx, from, to, requestData)
+	}
+	if q, e := a.cachedQuota(ctx, claims); e != nil {
+		return 0, e
+	} else if e := q.Exceeded(from.Size); e != nil {
+		return 0, e
+	}
+	written, err := a.next.CopyObject(ctx, from, to, requestData)
+	if err == nil {
+		size := written
+		if size <= 0 {
+			size = from.Size
+		}
+		a.recordOwner(ctx, claims, a.resolveUuid(ctx, to), size)
+	}
+	return written, err
+}
+
+// quotaClaims finds current user claims if the branch is subject to user quotas.
+func (a *UserQuotaFilter) quotaClaims(ctx context.Context, identifier string) (claim.Claims, bool) {
+	branchInfo, ok := GetBranchInfo(ctx, identifier)
+	if !ok || branchInfo.IsInternal() || branchInfo.Binary {
+		return claim.Claims{}, false
+	}
+	claims, ok := ctx.Value(claim.ContextKey).(claim.Claims)
+	if !ok || claims.Name == "" || claims.Name == common.PydioS3AnonUsername {
+		return claim.Claims{}, false
+	}
+	return claims, true
+}
+
+// cachedQuota loads quotas for the current user, from cache if possible.
+func (a *UserQuotaFilter) cachedQuota(ctx context.Context, claims claim.Claims) (*UserQuota, error) {
+	if q, ok := userQuotaCache.Get(claims.Name); ok {
+		return q.(*UserQuota), nil
+	}
+	q, e := UserQuotaFor(ctx, claims.Name, claims.GroupPath, a.claimsRoles(ctx, claims))
+	if e != nil {
+		return nil, e
+	}
+	userQuotaCache.Set(claims.Name, q, cache.DefaultExpiration)
+	return q, nil
+}
+
+// claimsRoles rebuilds the ordered list of roles of the current user from the claims.
+func (a *UserQuotaFilter) claimsRoles(ctx context.Context, claims claim.Claims) []*idm.Role {
+	if acl, e := permissions.AccessListFromContextClaims(ctx); e == nil {
+		return acl.OrderedRoles
+	}
+	var roles []*idm.Role
+	for _, r := range strings.Split(claims.Roles, ",") {
+		roles = append(roles, &idm.Role{Uuid: r})
+	}
+	return roles
+}
+
+// resolveUuid loads the node uuid if it is not set yet.
+func (a *UserQuotaFilter) resolveUuid(ctx context.Context, node *tree.Node) string {
+	if node.GetUuid() != "" {
+		return node.GetUuid()
+	}
+	if resp, e := a.next.ReadNode(ctx, &tree.ReadNodeRequest{Node: node.Clone()}); e == nil {
+		return resp.GetNode().GetUuid()
+	}
+	return ""
+}
+
+// recordOwner stores owner information in the metadata service, then checks the warning thresholds. Both operations
+// are performed in background. A node that already has an owner keeps it, only the size is updated.
+func (a *UserQuotaFilter) recordOwner(ctx context.Context, claims claim.Claims, nodeUuid string, size int64) {
+	if nodeUuid == "" {
+		return
+	}
+	bgCtx := context2.NewBackgroundWithMetaCopy(ctx)
+	roles := a.claimsRoles(ctx, claims)
+	go func() {
+		owner := &NodeOwner{Login: claims.Name, GroupPath: ownerGroupPath(claims.GroupPath), Size: size}
+		metaClient := tree.NewNodeProviderClient(registry.GetClient(common.ServiceMeta))
+		if resp, e := metaClient.ReadNode(bgCtx, &tree.ReadNodeRequest{Node: &tree.Node{Uuid: nodeUuid}}); e == nil {
+			var existing NodeOwner
+			if resp.GetNode().GetMeta(common.MetaNamespaceOwner, &existing) == nil && existing.Login != "" {
+				owner.Login = existing.Login
+				owner.GroupPath = existing.GroupPath
+			}
+		}
+		node := &tree.Node{Uuid: nodeUuid}
+		node.SetMeta(common.MetaNamespaceOwner, owner)
+		receiver := tree.NewNodeReceiverClient(registry.GetClient(common.ServiceMeta))
+		if _, e := receiver.CreateNode(bgCtx, &tree.CreateNodeRequest{Node: node, UpdateIfExists: true}); e != nil {
+			log.Logger(ctx).Error("Could not store owner for node", zap.String("uuid", nodeUuid), zap.Error(e))
+			return
+		}
+		userQuotaCache.Delete(claims.Name)
+		if owner.Login != claims.Name {
+			return
+		}
+		q, e := UserQuotaFor(bgCtx, claims.Name, claims.GroupPath, roles)
+		if e != nil {
+			log.Logger(ctx).Error("Could not compute user quota", zap.Error(e))
+			return
+		}
+		userQuotaCache.Set(claims.Name, q, cache.DefaultExpiration)
+		sendQuotaWarnings(bgCtx, claims, q)
+	}()
+}
+
+// UserQuotaFor finds the user and group quotas defined by the core.uploader parameters, in the
+// scope of all workspaces, and computes the current usage for each of them.
+func UserQuotaFor(ctx context.Context, login, groupPath string, orderedRoles []*idm.Role) (*UserQuota, error) {
+
+	q := &UserQuota{}
+	thresholds := defaultQuotaThresholds
+	if v := config.Get("frontend", "plugin", userQuotaPlugin).StringMap(); v != nil {
+		if u, ok := v[userQuotaParam]; ok {
+			q.Quota, _ = strconv.ParseInt(u, 10, 64)
+		}
+		if t, ok := v[quotaThresholdParam]; ok {
+			thresholds = t
+		}
+	}
+
+	groupPaths := groupRolesPaths(orderedRoles, groupPath)
+	acls := permissions.GetACLsForRoles(ctx, orderedRoles, permissions.AclFrontParam_)
+	for _, role := range orderedRoles {
+		for _, acl := range acls {
+			if acl.RoleID != role.Uuid || acl.WorkspaceID != permissions.FrontWsScopeAll {
+				continue
+			}
+			value := strings.Trim(acl.Action.Value, `"`)
+			switch acl.Action.Name {
+			case "parameter:" + userQuotaPlugin + ":" + userQuotaParam:
+				if i, e := strconv.ParseInt(value, 10, 64); e == nil {
+					q.Quota = i
+				}
+			case "parameter:" + userQuotaPlugin + ":" + quotaThresholdParam:
+				thresholds = value
+			case "parameter:" + userQuotaPlugin + ":" + groupQuotaParam:
+				gPath, isGroup := groupPaths[role.Uuid]
+				if i, e := strconv.ParseInt(value, 10, 64); e == nil && i > 0 && isGroup {
+					q.Groups = append(q.Groups, &GroupQuota{Path: gPath, Quota: i})
+				}
+			}
+		}
+	}
+	q.Thresholds = parseQuotaThresholds(thresholds)
+
+	if q.Quota > 0 {
+		u, e := ownerUsage(ctx, ownerLoginPattern(login))
+		if e != nil {
+			return nil, e
+		}
+		q.Usage = u
+	}
+	for _, g := range q.Groups {
+		u, e := ownerUsage(ctx, ownerGroupPattern(g.Path))
+		if e != nil {
+			return nil, e
+		}
+		g.Usage = u
+	}
+
+	return q, nil
+}
+
+// ownerUsage sums the size of all nodes whose owner metadata matches the given pattern.
+func ownerUsage(ctx context.Context, pattern string) (int64, error) {
+	searcher := tree.NewSearcherClient(registry.GetClient(common.ServiceMeta))
+	stream, e := searcher.Search(ctx, &tree.SearchRequest{Query: &tree.Query{MetaNamespace: common.MetaNamespaceOwner, MetaValuePattern: pattern}})
+	if e != nil {
+		return 0, e
+	}
+	defer stream.Close()
+	var usage int64
+	for {
+		resp, er := stream.Recv()
+		if er != nil {
+			if er != io.EOF {
+				return 0, er
+			}
+			break
+		}
+		var owner NodeOwner
+		if resp.GetNode().GetMeta(common.MetaNamespaceOwner, &owner) == nil {
+			usage += owner.Size
+		}
+	}
+	return usage, nil
+}
+
+// sendQuotaWarnings sends an email to the user when the highest threshold crossed by one of its quotas was not
+// notified yet.
+func sendQuotaWarnings(ctx context.Context, claims claim.Claims, q *UserQuota) {
+	if claims.Email == "" || len(q.Thresholds) == 0 {
+		return
+	}
+	check := func(templateId, scope string, usage, quota int64) {
+		threshold := crossedThreshold(q.Thresholds, usage, quota)
+		if threshold == 0 {
+			return
+		}
+		key := fmt.Sprintf("%s-%s-%d", claims.Name, scope, threshold)
+		if _, sent := userQuotaWarnings.Get(key); sent {
+			return
+		}
+		userQuotaWarnings.Set(key, true, cache.DefaultExpiration)
+		name := claims.DisplayName
+		if name == "" {
+			name = claims.Name
+		}
+		mailCli := mailer.NewMailerServiceClient(registry.GetClient(common.ServiceMailer))
+		if _, e := mailCli.SendMail(ctx, &mailer.SendMailRequest{
+			InQueue: false,
+			Mail: &mailer.Mail{
+				To:         []*mailer.User{{Name: name, Address: claims.Email}},
+				TemplateId: templateId,
+				TemplateData: map[string]string{
+					"Group":     scope,
+					"Threshold": strconv.Itoa(threshold),
+					"Usage":     humanize.Bytes(uint64(usage)),
+					"Quota":     humanize.Bytes(uint64(quota)),
+				},
+			},
+		}); e != nil {
+			log.Logger(ctx).Error("Could not send quota warning email", zap.String("user", claims.Name), zap.Error(e))
+		}
+	}
+	if q.Quota > 0 {
+		check("QuotaWarning", "", q.Usage, q.Quota)
+	}
+	for _, g := range q.Groups {
+		check("GroupQuotaWarning", g.Path, g.Usage, g.Quota)
+	}
+}
+
+// crossedThreshold returns the highest percentage of quota reached by usage, or 0.
+func crossedThreshold(thresholds []int, usage, quota int64) int {
+	if quota <= 0 {
+		return 0
+	}
+	percent := usage * 100 / quota
+	crossed := 0
+	for _, t := range thresholds {
+		if percent >= int64(t) && t > crossed {
+			crossed = t
+		}
+	}
+	return crossed
+}
+
+// parseQuotaThresholds reads a comma-separated list of percentages.
+func parseQuotaThresholds(s string) (thresholds []int) {
+	for _, p := range strings.Split(s, ",") {
+		if i, e := strconv.Atoi(strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(p), "%"))); e == nil && i > 0 && i <= 100 {
+			thresholds = append(thresholds, i)
+		}
+	}
+	sort.Ints(thresholds)
+	return
+}
+
+// groupRolesPaths maps the groups roles of a user to the corresponding group paths. Group roles are
+// ordered from the root group down to the user direct parent group.
+func groupRolesPaths(orderedRoles []*idm.Role, groupPath string) map[string]string {
+	paths := make(map[string]string)
+	var segments []string
+	if trimmed := strings.Trim(groupPath, "/"); trimmed != "" {
+		segments = strings.Split(trimmed, "/")
+	}
+	level := 0
+	for _, r := range orderedRoles {
+		if !r.GroupRole {
+			continue
+		}
+		if r.Uuid == "ROOT_GROUP" {
+			paths[r.Uuid] = "/"
+			continue
+		}
+		level++
+		if level > len(segments) {
+			break
+		}
+		paths[r.Uuid] = ownerGroupPath(strings.Join(segments[:level], "/"))
+	}
+	return paths
+}
+
+// ownerGroupPath normalizes a group path to always start and end with a slash.
+func ownerGroupPath(groupPath string) string {
+	trimmed := strings.Trim(groupPath, "/")
+	if trimmed == "" {
+		return "/"
+	}
+	return "/" + trimmed + "/"
+}
+
+// ownerLoginPattern builds a LIKE pattern matching all nodes owned by a given user.
+func ownerLoginPattern(login string) string {
+	l, _ := json.Marshal(login)
+	return escapeLikePattern(`{"Login":`+string(l)+`,`) + "%"
+}
+
+// ownerGroupPattern builds a LIKE pattern matching all nodes owned by users of a group or its sub-groups.
+func ownerGroupPattern(groupPath string) string {
+	p, _ := json.Marshal(ownerGroupPath(groupPath))
+	return "%" + escapeLikePattern(`,"GroupPath":`+strings.TrimSuffix(string(p), `"`)) + "%"
+}
+
+func escapeLikePattern(s string) string {
+	return strings.NewReplacer("!", "!!", "%", "!%", "_", "!_").Replace(s)
+}
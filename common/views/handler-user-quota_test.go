@@ -0,0 +1,56 @@
+package views
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/pydio/cells/common/proto/idm"
+	"github.com/pydio/cells/common/proto/tree"
+)
+
+func TestUserQuota(t *testing.T) {
+
+	Convey("Test thresholds parsing", t, func() {
+		So(parseQuotaThresholds("95, 80%,,abc,120"), ShouldResemble, []int{80, 95})
+		So(parseQuotaThresholds(""), ShouldBeEmpty)
+		So(crossedThreshold([]int{80, 95}, 50, 100), ShouldEqual, 0)
+		So(crossedThreshold([]int{80, 95}, 85, 100), ShouldEqual, 80)
+		So(crossedThreshold([]int{80, 95}, 120, 100), ShouldEqual, 95)
+		So(crossedThreshold([]int{80, 95}, 120, 0), ShouldEqual, 0)
+	})
+
+	Convey("Test group roles mapping", t, func() {
+		roles := []*idm.Role{
+			{Uuid: "ROOT_GROUP", GroupRole: true},
+			{Uuid: "sales-uuid", GroupRole: true},
+			{Uuid: "emea-uuid", GroupRole: true},
+			{Uuid: "user-uuid", UserRole: true},
+		}
+		So(groupRolesPaths(roles, "/sales/emea"), ShouldResemble, map[string]string{
+			"ROOT_GROUP": "/",
+			"sales-uuid": "/sales/",
+			"emea-uuid":  "/sales/emea/",
+		})
+		So(groupRolesPaths(roles[:1], "/"), ShouldResemble, map[string]string{"ROOT_GROUP": "/"})
+	})
+
+	Convey("Test owner patterns match stored values", t, func() {
+		n := &tree.Node{}
+		n.SetMeta("owner", &NodeOwner{Login: "john_doe", GroupPath: ownerGroupPath("/sales/emea"), Size: 10})
+		So(n.MetaStore["owner"], ShouldStartWith, `{"Login":"john_doe","GroupPath":"/sales/emea/"`)
+		So(ownerLoginPattern("john_doe"), ShouldEqual, `{"Login":"john!_doe",%`)
+		So(ownerGroupPattern("/sales"), ShouldEqual, `%,"GroupPath":"/sales/%`)
+		So(ownerGroupPattern(""), ShouldEqual, `%,"GroupPath":"/%`)
+	})
+
+	Convey("Test quota exceeded", t, func() {
+		q := &UserQuota{Quota: 100, Usage: 60}
+		So(q.Exceeded(40), ShouldBeNil)
+		So(q.Exceeded(41), ShouldNotBeNil)
+		q.Groups = append(q.Groups, &GroupQuota{Path: "/sales/", Quota: 1000, Usage: 990})
+		So(q.Exceeded(20), ShouldNotBeNil)
+		So((&UserQuota{}).Exceeded(1000), ShouldBeNil)
+	})
+
+}
@@ -30,6 +30,8 @@ import (
 
 	"github.com/pydio/cells/common"
 	"github.com/pydio/cells/common/proto/tree"
+	"github.com/pydio/cells/common/service/metrics"
+	"github.com/pydio/cells/common/service/tracing"
 	"github.com/pydio/cells/common/views/models"
 )
 
@@ -71,6 +73,7 @@ func NewStandardRouter(options RouterOptions) *Router {
 	}
 	handlers = append(handlers, NewWorkspaceRootResolver())
 	handlers = append(handlers, NewPathDataSourceHandler())
+	handlers = append(handlers, NewDataSourceMaintenanceFilter())
 
 	if options.SynchronousCache {
 		handlers = append(handlers, NewSynchronousCacheHandler())
@@ -91,6 +94,8 @@ func NewStandardRouter(options RouterOptions) *Router {
 		handlers = append(handlers, &UploadLimitFilter{})
 		handlers = append(handlers, &AclContentLockFilter{})
 		handlers = append(handlers, &AclQuotaFilter{})
+		handlers = append(handlers, &UserQuotaFilter{})
+		handlers = append(handlers, &AntivirusHandler{})
 	}
 
 	if options.SynchronousTasks {
@@ -113,6 +118,7 @@ func NewUuidRouter(options RouterOptions) *Router {
 		NewAccessListHandler(options.AdminView),
 		NewUuidNodeHandler(),
 		NewUuidDataSourceHandler(),
+		NewDataSourceMaintenanceFilter(),
 	}
 
 	if options.AuditEvent {
@@ -128,6 +134,8 @@ func NewUuidRouter(options RouterOptions) *Router {
 		handlers = append(handlers, &AclLockFilter{})
 		handlers = append(handlers, &AclContentLockFilter{})
 		handlers = append(handlers, &AclQuotaFilter{})
+		handlers = append(handlers, &UserQuotaFilter{})
+		handlers = append(handlers, &AntivirusHandler{})
 	}
 	handlers = append(handlers, &VersionHandler{})
 	handlers = append(handlers, &EncryptionHandler{}) // retrieves encryption materials from encryption service
@@ -154,6 +162,13 @@ type Router struct {
 }
 
 func (v *Router) initHandlers() {
+	if tracing.Enabled() || metrics.Enabled() {
+		for i, h := range v.handlers {
+			if _, ok := h.(*InstrumentedHandler); !ok {
+				v.handlers[i] = NewInstrumentedHandler(h)
+			}
+		}
+	}
 	for i, h := range v.handlers {
 		if i < len(v.handlers)-1 {
 			next := v.handlers[i+1]
@@ -252,6 +252,37 @@ func (m *VirtualNodesManager) toJsUser(c claim.Claims) *permissions.JsUser {
 	}
 }
 
+// ResolvePathForClaims resolves the path of a virtual node for the user described by the claims, without
+// reading or creating the resolved node.
+func (m *VirtualNodesManager) ResolvePathForClaims(ctx context.Context, vNode *tree.Node, c claim.Claims, clientsPool SourcesPool) (*tree.Node, error) {
+	return m.resolvePathWithClaims(ctx, vNode, c, clientsPool)
+}
+
+// ValidateVirtualNode checks a virtual node definition before it is stored. Javascript resolutions are
+// compiled to detect syntax errors.
+func ValidateVirtualNode(vNode *tree.Node) error {
+	if vNode.Uuid == "" || vNode.Path == "" {
+		return errors.BadRequest("virtualnode.invalid", "Virtual node must have a Uuid and a Path")
+	}
+	if strings.Contains(strings.Trim(vNode.Path, "/"), "/") {
+		return errors.BadRequest("virtualnode.invalid", "Virtual node path %s must not contain slashes", vNode.Path)
+	}
+	resolution := vNode.MetaStore["resolution"]
+	if strings.TrimSpace(resolution) == "" {
+		return errors.BadRequest("virtualnode.invalid", "Virtual node %s has no resolution", vNode.Uuid)
+	}
+	switch vNode.MetaStore["contentType"] {
+	case "text/javascript":
+		if e := permissions.CompileJavaScript(resolution); e != nil {
+			return errors.BadRequest("virtualnode.invalid", "Invalid resolution script for %s: %s", vNode.Uuid, e.Error())
+		}
+	case "":
+	default:
+		return errors.BadRequest("virtualnode.invalid", "Unsupported content type %s", vNode.MetaStore["contentType"])
+	}
+	return nil
+}
+
 // resolvePathWithClaims performs the actual Path resolution and returns a node. There is no guarantee that the node exists.
 func (m *VirtualNodesManager) resolvePathWithClaims(ctx context.Context, vNode *tree.Node, c claim.Claims, clientsPool SourcesPool) (*tree.Node, error) {
 
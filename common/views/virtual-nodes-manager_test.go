@@ -0,0 +1,44 @@
+package views
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/pydio/cells/common/proto/tree"
+)
+
+func TestValidateVirtualNode(t *testing.T) {
+
+	Convey("Test virtual node validation", t, func() {
+		vNode := &tree.Node{
+			Uuid: "my-files",
+			Path: "my-files",
+			Type: tree.NodeType_COLLECTION,
+			MetaStore: map[string]string{
+				"name":        "my-files",
+				"resolution":  `Path = DataSources.personal + "/" + User.Name;`,
+				"contentType": "text/javascript",
+			},
+		}
+		So(ValidateVirtualNode(vNode), ShouldBeNil)
+
+		vNode.MetaStore["resolution"] = `Path = DataSources.personal + "/" + ;`
+		So(ValidateVirtualNode(vNode), ShouldNotBeNil)
+
+		vNode.MetaStore["contentType"] = ""
+		vNode.MetaStore["resolution"] = "personal/{USERNAME}"
+		So(ValidateVirtualNode(vNode), ShouldBeNil)
+
+		vNode.MetaStore["resolution"] = " "
+		So(ValidateVirtualNode(vNode), ShouldNotBeNil)
+
+		vNode.MetaStore["resolution"] = "personal/{USERNAME}"
+		vNode.Path = "my/files"
+		So(ValidateVirtualNode(vNode), ShouldNotBeNil)
+
+		vNode.Path = ""
+		So(ValidateVirtualNode(vNode), ShouldNotBeNil)
+	})
+
+}
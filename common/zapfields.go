@@ -38,6 +38,8 @@ const (
 	AuditLoginPolicyDenial = "3"
 	AuditInvalidJwt        = "4"
 	AuditLockUser          = "5"
+	AuditLoginAnomaly      = "6"
+	AuditLoginThrottled    = "7"
 
 	// Tree events
 	AuditNodeCreate     = "11"
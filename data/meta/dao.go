@@ -32,6 +32,7 @@ type DAO interface {
 	SetMetadata(nodeId string, author string, metadata map[string]string) (err error)
 	GetMetadata(nodeId string) (metadata map[string]string, err error)
 	ListMetadata(query string) (metadataByUuid map[string]map[string]string, err error)
+	SearchMetadata(namespace string, dataPattern string) (metadataByUuid map[string]map[string]string, err error)
 }
 
 func NewDAO(o dao.DAO) dao.DAO {
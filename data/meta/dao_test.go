@@ -25,6 +25,8 @@ import (
 	"testing"
 
 	_ "github.com/mattn/go-sqlite3"
+	. "github.com/smartystreets/goconvey/convey"
+
 	"github.com/pydio/cells/common/sql"
 	"github.com/pydio/cells/x/configx"
 )
@@ -50,3 +52,28 @@ func TestMain(m *testing.M) {
 
 	m.Run()
 }
+
+func TestSearchMetadata(t *testing.T) {
+
+	Convey("Search values in a given namespace", t, func() {
+
+		So(mockDAO.SetMetadata("node1", "admin", map[string]string{"owner": `{"Login":"user_1","Size":10}`, "other": `"user_1"`}), ShouldBeNil)
+		So(mockDAO.SetMetadata("node2", "admin", map[string]string{"owner": `{"Login":"user_2","Size":20}`}), ShouldBeNil)
+		So(mockDAO.SetMetadata("node3", "admin", map[string]string{"owner": `{"Login":"userx1","Size":30}`}), ShouldBeNil)
+
+		all, e := mockDAO.SearchMetadata("owner", "")
+		So(e, ShouldBeNil)
+		So(all, ShouldHaveLength, 3)
+
+		res, e := mockDAO.SearchMetadata("owner", `%"Login":"user!_1"%`)
+		So(e, ShouldBeNil)
+		So(res, ShouldHaveLength, 1)
+		So(res["node1"], ShouldResemble, map[string]string{"owner": `{"Login":"user_1","Size":10}`})
+
+		none, e := mockDAO.SearchMetadata("other", `%user_2%`)
+		So(e, ShouldBeNil)
+		So(none, ShouldBeEmpty)
+
+	})
+
+}
@@ -348,7 +348,13 @@ func (s *MetaServer) Search(ctx context.Context, request *tree.SearchRequest, re
 
 	dao := servicecontext.GetDAO(ctx).(meta.DAO)
 
-	metaByUUID, err := dao.ListMetadata(request.Query.FileName)
+	var metaByUUID map[string]map[string]string
+	var err error
+	if ns := request.GetQuery().GetMetaNamespace(); ns != "" {
+		metaByUUID, err = dao.SearchMetadata(ns, request.GetQuery().GetMetaValuePattern())
+	} else {
+		metaByUUID, err = dao.ListMetadata(request.Query.FileName)
+	}
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,5 @@
+-- +migrate Up
+CREATE INDEX data_meta_namespace_idx ON data_meta(namespace);
+
+-- +migrate Down
+DROP INDEX data_meta_namespace_idx;
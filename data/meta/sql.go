@@ -21,6 +21,7 @@
 package meta
 
 import (
+	sql2 "database/sql"
 	"time"
 
 	"github.com/micro/go-micro/errors"
@@ -33,11 +34,13 @@ import (
 
 var (
 	queries = map[string]string{
-		"upsert":     `INSERT INTO data_meta (node_id,namespace,data,author,timestamp,format) VALUES (?,?,?,?,?,?) ON DUPLICATE KEY UPDATE data=?,author=?,timestamp=?,format=?`,
-		"deleteNS":   `DELETE FROM data_meta WHERE namespace=?`,
-		"deleteUuid": `DELETE FROM data_meta WHERE node_id=?`,
-		"select":     `SELECT * FROM data_meta WHERE node_id=?`,
-		"selectAll":  `SELECT * FROM data_meta LIMIT 0, 500`,
+		"upsert":        `INSERT INTO data_meta (node_id,namespace,data,author,timestamp,format) VALUES (?,?,?,?,?,?) ON DUPLICATE KEY UPDATE data=?,author=?,timestamp=?,format=?`,
+		"upsert-sqlite": `INSERT INTO data_meta (node_id,namespace,data,author,timestamp,format) VALUES (?,?,?,?,?,?) ON CONFLICT(node_id,namespace) DO UPDATE SET data=?,author=?,timestamp=?,format=?`,
+		"deleteNS":      `DELETE FROM data_meta WHERE namespace=?`,
+		"deleteUuid":    `DELETE FROM data_meta WHERE node_id=?`,
+		"select":        `SELECT * FROM data_meta WHERE node_id=?`,
+		"selectAll":     `SELECT * FROM data_meta LIMIT 0, 500`,
+		"selectNS":      `SELECT * FROM data_meta WHERE namespace=? AND data LIKE ? ESCAPE '!'`,
 	}
 )
 
@@ -109,7 +112,11 @@ func (h *sqlImpl) SetMetadata(nodeId string, author string, metadata map[string]
 				// Insert or update namespace
 				tStamp := time.Now().Unix()
 
-				stmt, er := h.GetStmt("upsert")
+				upsertKey := "upsert"
+				if h.Driver() == "sqlite3" {
+					upsertKey = "upsert-sqlite"
+				}
+				stmt, er := h.GetStmt(upsertKey)
 				if er != nil {
 					return er
 				}
@@ -189,6 +196,31 @@ func (h *sqlImpl) ListMetadata(query string) (metaByUuid map[string]map[string]s
 	if err != nil {
 		return nil, err
 	}
+	return h.scanByUuid(r)
+
+}
+
+// SearchMetadata lists all values of a given namespace whose data matches the pattern.
+// Pattern uses the SQL LIKE syntax, with '!' as escape character.
+func (h *sqlImpl) SearchMetadata(namespace string, dataPattern string) (metaByUuid map[string]map[string]string, err error) {
+
+	stmt, er := h.GetStmt("selectNS")
+	if er != nil {
+		return nil, er
+	}
+
+	if dataPattern == "" {
+		dataPattern = "%"
+	}
+	r, err := stmt.Query(namespace, dataPattern)
+	if err != nil {
+		return nil, err
+	}
+	return h.scanByUuid(r)
+
+}
+
+func (h *sqlImpl) scanByUuid(r *sql2.Rows) (metaByUuid map[string]map[string]string, err error) {
 	metaByUuid = make(map[string]map[string]string)
 
 	defer r.Close()
@@ -215,7 +247,6 @@ func (h *sqlImpl) ListMetadata(query string) (metaByUuid map[string]map[string]s
 			metaByUuid[row.id] = metadata
 		}
 		metadata[row.namespace] = row.data
-		metadata[row.namespace] = row.data
 	}
 	if r.Err() != nil {
 		return nil, r.Err()
@@ -32,6 +32,39 @@ type DAO interface {
 	index.DAO
 }
 
+// BlobsDAO keeps track of the content-addressed objects referenced by the nodes of a deduplicated datasource.
+// It is implemented by the SQL DAO only, session caches do not expose it.
+type BlobsDAO interface {
+	// SetNodeBlob registers or replaces the blob referenced by a node, and returns the previous blob
+	// hash if it is not referenced anymore
+	SetNodeBlob(nodeUuid string, hash string, size int64) (released string, err error)
+	// GetNodeBlob finds the blob referenced by a node, and the total number of references to this blob
+	GetNodeBlob(nodeUuid string) (hash string, refs int, err error)
+	// GetBlobRefs counts the nodes currently referencing a blob
+	GetBlobRefs(hash string) (refs int, err error)
+	// DelNodeBlob removes a node reference, and tells whether the blob is not referenced anymore
+	DelNodeBlob(nodeUuid string) (hash string, released bool, err error)
+	// BlobsStats computes space usage for the deduplicated storage
+	BlobsStats() (*BlobsStats, error)
+}
+
+// BlobsStats gives an overview of space saved by deduplication
+type BlobsStats struct {
+	// References is the number of nodes pointing to a blob
+	References int64
+	// Blobs is the number of distinct objects stored
+	Blobs int64
+	// LogicalSize is the sum of all nodes sizes
+	LogicalSize int64
+	// StoredSize is the sum of all blobs sizes
+	StoredSize int64
+}
+
+// Saved returns the number of bytes spared by deduplication
+func (b *BlobsStats) Saved() int64 {
+	return b.LogicalSize - b.StoredSize
+}
+
 func NewDAO(o dao.DAO) dao.DAO {
 	switch v := o.(type) {
 	case sql.DAO:
@@ -105,6 +105,7 @@ func TestMain(m *testing.M) {
 	}
 
 	d := NewDAO(dao)
+	options.Val("dedup").Set(true)
 	if err := d.Init(options); err != nil {
 		fmt.Print("Could not start test ", err)
 		return
@@ -500,3 +501,72 @@ func TestMysql(t *testing.T) {
 	})
 
 }
+
+func TestBlobs(t *testing.T) {
+
+	Convey("Test blobs references", t, func() {
+		b := getDAO(ctx).(BlobsDAO)
+
+		released, err := b.SetNodeBlob("node1", "hash1", 10)
+		So(err, ShouldBeNil)
+		So(released, ShouldBeEmpty)
+		_, err = b.SetNodeBlob("node2", "hash1", 10)
+		So(err, ShouldBeNil)
+		_, err = b.SetNodeBlob("node3", "hash2", 25)
+		So(err, ShouldBeNil)
+
+		hash, refs, err := b.GetNodeBlob("node1")
+		So(err, ShouldBeNil)
+		So(hash, ShouldEqual, "hash1")
+		So(refs, ShouldEqual, 2)
+
+		stats, err := b.BlobsStats()
+		So(err, ShouldBeNil)
+		So(stats.References, ShouldEqual, 3)
+		So(stats.Blobs, ShouldEqual, 2)
+		So(stats.LogicalSize, ShouldEqual, 45)
+		So(stats.StoredSize, ShouldEqual, 35)
+		So(stats.Saved(), ShouldEqual, 10)
+
+		// Same content again does not add a reference
+		_, err = b.SetNodeBlob("node1", "hash1", 10)
+		So(err, ShouldBeNil)
+		_, refs, _ = b.GetNodeBlob("node1")
+		So(refs, ShouldEqual, 2)
+
+		// Replacing the content of the last reference releases the blob
+		released, err = b.SetNodeBlob("node3", "hash1", 10)
+		So(err, ShouldBeNil)
+		So(released, ShouldEqual, "hash2")
+		_, refs, _ = b.GetNodeBlob("node3")
+		So(refs, ShouldEqual, 3)
+
+		hash, ok, err := b.DelNodeBlob("node2")
+		So(err, ShouldBeNil)
+		So(hash, ShouldEqual, "hash1")
+		So(ok, ShouldBeFalse)
+
+		hash, _, err = b.GetNodeBlob("unknown")
+		So(err, ShouldBeNil)
+		So(hash, ShouldBeEmpty)
+
+		_, ok, _ = b.DelNodeBlob("node1")
+		So(ok, ShouldBeFalse)
+		refs, err = b.GetBlobRefs("hash1")
+		So(err, ShouldBeNil)
+		So(refs, ShouldEqual, 1)
+		hash, ok, err = b.DelNodeBlob("node3")
+		So(err, ShouldBeNil)
+		So(hash, ShouldEqual, "hash1")
+		So(ok, ShouldBeTrue)
+		refs, err = b.GetBlobRefs("hash1")
+		So(err, ShouldBeNil)
+		So(refs, ShouldEqual, 0)
+
+		stats, err = b.BlobsStats()
+		So(err, ShouldBeNil)
+		So(stats.References, ShouldEqual, 0)
+		So(stats.StoredSize, ShouldEqual, 0)
+	})
+
+}
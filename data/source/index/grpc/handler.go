@@ -50,6 +50,7 @@ import (
 type TreeServer struct {
 	DataSourceName     string
 	DataSourceInternal bool
+	Dedup              bool
 	client             client.Client
 	sessionStore       sessions.DAO
 }
@@ -81,6 +82,7 @@ func NewTreeServer(ds *object.DataSource) *TreeServer {
 	return &TreeServer{
 		DataSourceName:     ds.Name,
 		DataSourceInternal: ds.IsInternal(),
+		Dedup:              ds.IsDeduplicated(),
 		client:             client.NewClient(),
 		sessionStore:       sessions.NewSessionMemoryStore(),
 	}
@@ -94,6 +96,51 @@ func (s *TreeServer) setDataSourceMeta(node *mtree.TreeNode) {
 	}
 }
 
+// blobsDAO returns the blobs references DAO if the datasource is deduplicated
+func (s *TreeServer) blobsDAO(ctx context.Context) (index.BlobsDAO, bool) {
+	if !s.Dedup {
+		return nil, false
+	}
+	b, ok := servicecontext.GetDAO(ctx).(index.BlobsDAO)
+	return b, ok
+}
+
+// setBlobMeta attaches the content hash and its number of references to a leaf node
+func (s *TreeServer) setBlobMeta(ctx context.Context, node *mtree.TreeNode) {
+	b, ok := s.blobsDAO(ctx)
+	if !ok || !node.IsLeaf() {
+		return
+	}
+	if hash, refs, e := b.GetNodeBlob(node.Uuid); e == nil && hash != "" {
+		node.SetMeta(common.MetaNamespaceContentHash, hash)
+		node.SetMeta(common.MetaNamespaceContentRefs, refs)
+	} else if e != nil {
+		log.Logger(ctx).Error("Cannot read blob reference for node", node.Zap(), zap.Error(e))
+	}
+}
+
+// registerBlob updates the content reference of a leaf node for deduplicated storage, and
+// reports the blob that is not referenced anymore, if any.
+func (s *TreeServer) registerBlob(ctx context.Context, node *mtree.TreeNode, reqNode *tree.Node, resp *tree.CreateNodeResponse) error {
+	hash := reqNode.GetStringMeta(common.MetaNamespaceContentHash)
+	if hash == "" || !node.IsLeaf() {
+		return nil
+	}
+	b, ok := s.blobsDAO(ctx)
+	if !ok {
+		return nil
+	}
+	released, er := b.SetNodeBlob(node.Uuid, hash, node.Size)
+	if er != nil {
+		return er
+	}
+	if released != "" {
+		resp.ReleasedContentHashes = append(resp.ReleasedContentHashes, released)
+	}
+	node.SetMeta(common.MetaNamespaceContentHash, hash)
+	return nil
+}
+
 // updateMeta simplifies the dao.SetNodeMeta call
 func (s *TreeServer) updateMeta(dao index.DAO, node *mtree.TreeNode, reqNode *tree.Node) (previousEtag string, contentChange bool, err error) {
 	if node.IsLeaf() {
@@ -150,6 +197,9 @@ func (s *TreeServer) CreateNode(ctx context.Context, req *tree.CreateNodeRequest
 				}
 				node.Path = req.GetNode().GetPath()
 				s.setDataSourceMeta(node)
+				if er := s.registerBlob(ctx, node, req.GetNode(), resp); er != nil {
+					return errors.InternalServerError(name, "Error while registering content hash: %s", er.Error())
+				}
 				if err := s.UpdateParentsAndNotify(ctx, dao, req.GetNode().GetSize(), eventType, nil, node, req.IndexationSession); err != nil {
 					return errors.InternalServerError(common.ServiceDataIndex_, "Error while updating parents: %s", err.Error())
 				}
@@ -232,6 +282,11 @@ func (s *TreeServer) CreateNode(ctx context.Context, req *tree.CreateNodeRequest
 		node.SetMeta(common.MetaNamespaceMime, req.GetNode().GetStringMeta(common.MetaNamespaceMime))
 	}
 
+	// Register content reference for deduplicated storage
+	if er := s.registerBlob(ctx, node, req.GetNode(), resp); er != nil {
+		return errors.InternalServerError(name, "Error while registering content hash: %s", er.Error())
+	}
+
 	if err := s.UpdateParentsAndNotify(ctx, dao, req.GetNode().GetSize(), eventType, nil, node, req.IndexationSession); err != nil {
 		return errors.InternalServerError(common.ServiceDataIndex_, "Error while updating parents: %s", err.Error())
 	}
@@ -262,6 +317,24 @@ func (s *TreeServer) ReadNode(ctx context.Context, req *tree.ReadNodeRequest, re
 	var node *mtree.TreeNode
 	var err error
 
+	if hash := req.GetNode().GetStringMeta(common.MetaNamespaceContentHash); hash != "" && req.GetNode().GetPath() == "" && req.GetNode().GetUuid() == "" {
+		// Blob lookup: report the number of nodes referencing this content
+		b, ok := s.blobsDAO(ctx)
+		if !ok {
+			return errors.BadRequest(name, "datasource is not deduplicated")
+		}
+		refs, er := b.GetBlobRefs(hash)
+		if er != nil {
+			return errors.InternalServerError(name, "Cannot count references for blob %s, cause: %s", hash, er.Error())
+		}
+		blob := &tree.Node{}
+		blob.SetMeta(common.MetaNamespaceContentHash, hash)
+		blob.SetMeta(common.MetaNamespaceContentRefs, refs)
+		resp.Success = true
+		resp.Node = blob
+		return nil
+	}
+
 	if req.GetNode().GetPath() == "" && req.GetNode().GetUuid() != "" {
 
 		node, err = dao.GetNodeByUUID(req.GetNode().GetUuid())
@@ -317,7 +390,15 @@ func (s *TreeServer) ReadNode(ctx context.Context, req *tree.ReadNodeRequest, re
 		node.SetMeta("ChildrenCount", folderCount+fileCount)
 		node.SetMeta("ChildrenFolders", folderCount)
 		node.SetMeta("ChildrenFiles", fileCount)
+		if b, ok := s.blobsDAO(ctx); ok && len(node.MPath) == 1 {
+			if stats, e := b.BlobsStats(); e == nil {
+				node.SetMeta("DedupStats", stats)
+			} else {
+				log.Logger(ctx).Error("Cannot compute deduplication stats", zap.Error(e))
+			}
+		}
 	}
+	s.setBlobMeta(ctx, node)
 
 	resp.Node = node.Node
 
@@ -575,6 +656,10 @@ func (s *TreeServer) DeleteNode(ctx context.Context, req *tree.DeleteNodeRequest
 	node.Path = reqPath
 	s.setDataSourceMeta(node)
 	var childrenEvents []*tree.NodeChangeEvent
+	var blobRefs []string
+	if node.IsLeaf() {
+		blobRefs = append(blobRefs, node.Uuid)
+	}
 	if node.Type == tree.NodeType_COLLECTION {
 		c := dao.GetNodeTree(path)
 		names := strings.Split(reqPath, "/")
@@ -597,6 +682,9 @@ func (s *TreeServer) DeleteNode(ctx context.Context, req *tree.DeleteNodeRequest
 			names[child.Level-1] = child.Name()
 			child.Path = safePath(strings.Join(names, "/"))
 			s.setDataSourceMeta(child)
+			if child.IsLeaf() {
+				blobRefs = append(blobRefs, child.Uuid)
+			}
 			childrenEvents = append(childrenEvents, &tree.NodeChangeEvent{
 				Type:   tree.NodeChangeEvent_DELETE,
 				Source: child.Node,
@@ -612,6 +700,16 @@ func (s *TreeServer) DeleteNode(ctx context.Context, req *tree.DeleteNodeRequest
 		return errors.InternalServerError(name, "Could not delete node at %s, cause: %s", reqPath, err.Error())
 	}
 
+	if b, ok := s.blobsDAO(ctx); ok {
+		for _, ref := range blobRefs {
+			if hash, released, er := b.DelNodeBlob(ref); er != nil {
+				log.Logger(ctx).Error("Cannot remove blob reference for node "+ref, zap.Error(er))
+			} else if released {
+				resp.ReleasedContentHashes = append(resp.ReleasedContentHashes, hash)
+			}
+		}
+	}
+
 	if err := s.UpdateParentsAndNotify(ctx, dao, node.Size, tree.NodeChangeEvent_DELETE, node, nil, req.IndexationSession); err != nil {
 		return errors.InternalServerError(common.ServiceDataIndex_, "Error while updating parents: %s", err.Error())
 	}
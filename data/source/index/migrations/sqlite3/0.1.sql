@@ -0,0 +1,22 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS %%PREFIX%%_blobs (
+    uuid  VARCHAR(128)  NOT NULL,
+    hash  VARCHAR(128)  NOT NULL,
+    size  BIGINT        NOT NULL,
+
+    CONSTRAINT blobs_pk PRIMARY KEY (uuid)
+);
+
+CREATE INDEX %%PREFIX%%_blobs_hash_idx ON %%PREFIX%%_blobs(hash);
+
+CREATE TABLE IF NOT EXISTS %%PREFIX%%_blob_refs (
+    hash  VARCHAR(128)  NOT NULL,
+    size  BIGINT        NOT NULL,
+    ref   INT           NOT NULL DEFAULT 0,
+
+    CONSTRAINT blob_refs_pk PRIMARY KEY (hash)
+);
+
+-- +migrate Down
+DROP TABLE %%PREFIX%%_blob_refs;
+DROP TABLE %%PREFIX%%_blobs;
@@ -26,13 +26,21 @@ import (
 	"sync"
 	"time"
 
+	"github.com/uber-go/tally"
+
 	"github.com/pydio/cells/common/log"
 	"github.com/pydio/cells/common/micro"
 	"github.com/pydio/cells/common/proto/tree"
+	servicecontext "github.com/pydio/cells/common/service/context"
+	"github.com/pydio/cells/common/service/metrics"
 	"github.com/pydio/cells/data/source/index"
 )
 
-var benchmarks bool
+var (
+	benchmarks bool
+
+	sessionSizeBuckets = tally.MustMakeExponentialValueBuckets(10, 4, 8)
+)
 
 func init() {
 	benchmarks = false
@@ -131,5 +139,6 @@ func (b *MemoryBatcher) Flush(ctx context.Context, dao index.DAO) {
 		}
 	}
 	log.Logger(ctx).Info(fmt.Sprintf("Sent %d events event on topic", count))
+	metrics.GetMetricsForService(servicecontext.GetServiceName(ctx)).Histogram("index_session_events", sessionSizeBuckets).RecordValue(float64(count))
 
 }
@@ -21,8 +21,12 @@ package index
 
 import (
 	"context"
+	sql2 "database/sql"
 	"time"
 
+	"github.com/pydio/packr"
+	migrate "github.com/rubenv/sql-migrate"
+
 	"github.com/pydio/cells/common/log"
 	"github.com/pydio/cells/common/proto/tree"
 	"github.com/pydio/cells/common/sql"
@@ -32,13 +36,26 @@ import (
 )
 
 var (
-	queries = map[string]interface{}{}
+	queries = map[string]interface{}{
+		"blobSet":           `replace into %%PREFIX%%_idx_blobs (uuid, hash, size) values (?, ?, ?)`,
+		"blobGet":           `select hash from %%PREFIX%%_idx_blobs where uuid = ?`,
+		"blobDel":           `delete from %%PREFIX%%_idx_blobs where uuid = ?`,
+		"blobRefInc":        `insert into %%PREFIX%%_idx_blob_refs (hash, size, ref) values (?, ?, 1) on duplicate key update ref = ref + 1`,
+		"blobRefInc-sqlite": `insert into %%PREFIX%%_idx_blob_refs (hash, size, ref) values (?, ?, 1) on conflict(hash) do update set ref = ref + 1`,
+		"blobRefDec":        `update %%PREFIX%%_idx_blob_refs set ref = ref - 1 where hash = ?`,
+		"blobRefRelease":    `delete from %%PREFIX%%_idx_blob_refs where hash = ? and ref <= 0`,
+		"blobRefs":          `select ref from %%PREFIX%%_idx_blob_refs where hash = ?`,
+		"blobStats":         `select count(*), count(distinct hash), coalesce(sum(size), 0) from %%PREFIX%%_idx_blobs`,
+		"blobStored":        `select coalesce(sum(size), 0) from %%PREFIX%%_idx_blob_refs`,
+	}
 )
 
 type sqlimpl struct {
 	*sql.Handler
 
 	*index.IndexSQL
+
+	dedup bool
 }
 
 // Init handler for the SQL DAO
@@ -55,6 +72,14 @@ func (s *sqlimpl) Init(options configx.Values) error {
 
 	log.Logger(context.Background()).Debug("Finished IndexSQL Init")
 
+	// Blobs tables are only required by deduplicated datasources
+	s.dedup = options.Val("dedup").Bool()
+	if s.dedup {
+		if _, err := sql.ExecMigration(s.DB(), s.Driver(), s.blobsMigrations(), migrate.Up, s.Prefix()+"_idx_blobs_"); err != nil {
+			return err
+		}
+	}
+
 	// Preparing the db statements
 	if options.Val("prepare").Default(true).Bool() {
 		for key, query := range queries {
@@ -79,3 +104,172 @@ func (s *sqlimpl) Init(options configx.Values) error {
 
 	return nil
 }
+
+// CleanResourcesOnDeletion removes the blobs tables along with the index tables
+func (s *sqlimpl) CleanResourcesOnDeletion() (error, string) {
+
+	if s.dedup {
+		if _, err := sql.ExecMigration(s.DB(), s.Driver(), s.blobsMigrations(), migrate.Down, s.Prefix()+"_idx_blobs_"); err != nil {
+			return err, ""
+		}
+	}
+
+	return s.IndexSQL.CleanResourcesOnDeletion()
+}
+
+func (s *sqlimpl) blobsMigrations() *sql.PackrMigrationSource {
+	return &sql.PackrMigrationSource{
+		Box:         packr.NewBox("../../source/index/migrations"),
+		Dir:         "./" + s.Driver(),
+		TablePrefix: s.Prefix() + "_idx",
+	}
+}
+
+// SetNodeBlob registers or replaces the blob referenced by a node. References counts are updated in a single
+// transaction, and the previous blob is returned if it is not referenced anymore.
+func (s *sqlimpl) SetNodeBlob(nodeUuid string, hash string, size int64) (released string, err error) {
+	err = s.blobsTx(func(tx *sql2.Tx) error {
+		previous, er := s.txBlobHash(tx, nodeUuid)
+		if er != nil {
+			return er
+		}
+		if _, er := s.txExec(tx, "blobSet", nodeUuid, hash, size); er != nil {
+			return er
+		}
+		if previous == hash {
+			return nil
+		}
+		incKey := "blobRefInc"
+		if s.Driver() == "sqlite3" {
+			incKey = "blobRefInc-sqlite"
+		}
+		if _, er := s.txExec(tx, incKey, hash, size); er != nil {
+			return er
+		}
+		if previous != "" {
+			if ok, er := s.txRelease(tx, previous); er != nil {
+				return er
+			} else if ok {
+				released = previous
+			}
+		}
+		return nil
+	})
+	return
+}
+
+// GetNodeBlob finds the blob referenced by a node and its number of references.
+// It returns an empty hash if the node is not registered.
+func (s *sqlimpl) GetNodeBlob(nodeUuid string) (string, int, error) {
+	stmt, er := s.GetStmt("blobGet")
+	if er != nil {
+		return "", 0, er
+	}
+	var hash string
+	if er := stmt.QueryRow(nodeUuid).Scan(&hash); er == sql2.ErrNoRows {
+		return "", 0, nil
+	} else if er != nil {
+		return "", 0, er
+	}
+	count, er := s.GetBlobRefs(hash)
+	if er != nil {
+		return "", 0, er
+	}
+	return hash, count, nil
+}
+
+// GetBlobRefs counts the references to a blob, zero if it was released.
+func (s *sqlimpl) GetBlobRefs(hash string) (int, error) {
+	refs, er := s.GetStmt("blobRefs")
+	if er != nil {
+		return 0, er
+	}
+	var count int
+	if er := refs.QueryRow(hash).Scan(&count); er != nil && er != sql2.ErrNoRows {
+		return 0, er
+	}
+	return count, nil
+}
+
+// DelNodeBlob removes a node reference. The blob is released if it is not referenced anymore.
+func (s *sqlimpl) DelNodeBlob(nodeUuid string) (hash string, released bool, err error) {
+	err = s.blobsTx(func(tx *sql2.Tx) error {
+		var er error
+		if hash, er = s.txBlobHash(tx, nodeUuid); er != nil || hash == "" {
+			return er
+		}
+		if _, er := s.txExec(tx, "blobDel", nodeUuid); er != nil {
+			return er
+		}
+		released, er = s.txRelease(tx, hash)
+		return er
+	})
+	return
+}
+
+// BlobsStats computes space usage for the deduplicated storage
+func (s *sqlimpl) BlobsStats() (*BlobsStats, error) {
+	stats := &BlobsStats{}
+	stmt, er := s.GetStmt("blobStats")
+	if er != nil {
+		return nil, er
+	}
+	if er := stmt.QueryRow().Scan(&stats.References, &stats.Blobs, &stats.LogicalSize); er != nil {
+		return nil, er
+	}
+	stored, er := s.GetStmt("blobStored")
+	if er != nil {
+		return nil, er
+	}
+	if er := stored.QueryRow().Scan(&stats.StoredSize); er != nil {
+		return nil, er
+	}
+	return stats, nil
+}
+
+// blobsTx runs f inside a transaction, committing only if it returns no error
+func (s *sqlimpl) blobsTx(f func(tx *sql2.Tx) error) error {
+	tx, er := s.DB().Begin()
+	if er != nil {
+		return er
+	}
+	if er := f(tx); er != nil {
+		tx.Rollback()
+		return er
+	}
+	return tx.Commit()
+}
+
+func (s *sqlimpl) txExec(tx *sql2.Tx, key string, args ...interface{}) (sql2.Result, error) {
+	stmt, er := s.GetStmt(key)
+	if er != nil {
+		return nil, er
+	}
+	return tx.Stmt(stmt.GetSQLStmt()).Exec(args...)
+}
+
+func (s *sqlimpl) txBlobHash(tx *sql2.Tx, nodeUuid string) (string, error) {
+	stmt, er := s.GetStmt("blobGet")
+	if er != nil {
+		return "", er
+	}
+	var hash string
+	if er := tx.Stmt(stmt.GetSQLStmt()).QueryRow(nodeUuid).Scan(&hash); er != nil && er != sql2.ErrNoRows {
+		return "", er
+	}
+	return hash, nil
+}
+
+// txRelease decrements the references count of a blob, and removes it when it drops to zero.
+// The removal is conditioned in the same statement, so that only one caller can release a given blob.
+func (s *sqlimpl) txRelease(tx *sql2.Tx, hash string) (bool, error) {
+	if _, er := s.txExec(tx, "blobRefDec", hash); er != nil {
+		return false, er
+	}
+	res, er := s.txExec(tx, "blobRefRelease", hash)
+	if er != nil {
+		return false, er
+	}
+	n, er := res.RowsAffected()
+	return n > 0, er
+}
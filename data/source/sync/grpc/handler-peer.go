@@ -0,0 +1,135 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package grpc
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/pydio/cells/common/log"
+	"github.com/pydio/cells/common/proto/object"
+	"github.com/pydio/cells/common/sync/endpoints/sftp"
+	"github.com/pydio/cells/common/sync/merger"
+	"github.com/pydio/cells/common/sync/model"
+	"github.com/pydio/cells/common/sync/task"
+)
+
+const (
+	peerDirectionPull = "pull"
+	peerDirectionPush = "push"
+	peerDirectionBoth = "both"
+)
+
+// initPeer creates an additional sync task between the datasource storage and an SFTP server,
+// if the sftpPeer storage key is set. The sftpPeerDirection key can be "pull" (default),
+// "push" or "both".
+func (s *Handler) initPeer(syncConfig *object.DataSource) error {
+	s.stopPeer()
+	peerUrl, ok := syncConfig.StorageConfiguration[object.StorageKeySftpPeer]
+	if !ok || peerUrl == "" {
+		return nil
+	}
+	if syncConfig.FlatStorage {
+		return fmt.Errorf("sftp peer is not supported on flat datasources")
+	}
+	conf, e := sftp.ParseURL(peerUrl)
+	if e != nil {
+		return e
+	}
+	storage, ok := s.s3client.(model.PathSyncTarget)
+	if !ok {
+		return fmt.Errorf("datasource storage cannot be used as sync peer")
+	}
+	client, e := sftp.NewClient(conf, model.EndpointOptions{})
+	if e != nil {
+		return e
+	}
+	var peerTask *task.Sync
+	switch syncConfig.StorageConfiguration[object.StorageKeySftpPeerDirection] {
+	case "", peerDirectionPull:
+		peerTask = task.NewSync(client, storage, model.DirectionRight)
+	case peerDirectionPush:
+		peerTask = task.NewSync(storage, client, model.DirectionRight)
+	case peerDirectionBoth:
+		peerTask = task.NewSync(storage, client, model.DirectionBi)
+	default:
+		client.Close()
+		return fmt.Errorf("unsupported sftp peer direction %s", syncConfig.StorageConfiguration[object.StorageKeySftpPeerDirection])
+	}
+	s.peerClient = client
+	s.peerTask = peerTask
+	return nil
+}
+
+// startPeer starts the peer task with watches. Each time a batch modifies the datasource storage,
+// the main task is run again to update the index.
+func (s *Handler) startPeer() {
+	if s.peerTask == nil {
+		return
+	}
+	peerTask := s.peerTask
+	ctx, cancel := context.WithCancel(s.globalCtx)
+	s.peerCancel = cancel
+	peerDone := make(chan interface{})
+	peerTask.SetupEventsChan(nil, peerDone, nil)
+	peerTask.Start(s.globalCtx, true)
+	go func() {
+		if _, e := peerTask.Run(s.globalCtx, false, false); e != nil {
+			log.Logger(s.globalCtx).Error("Initial sync with SFTP peer failed", zap.Error(e))
+		}
+	}()
+	pushOnly := s.SyncConfig.StorageConfiguration[object.StorageKeySftpPeerDirection] == peerDirectionPush
+	go func() {
+		for {
+			select {
+			case d := <-peerDone:
+				if p, ok := d.(merger.Patch); pushOnly || ok && p.Size() == 0 {
+					continue
+				}
+				log.Logger(ctx).Info("SFTP peer batch processed, resyncing datasource index")
+				if _, e := s.syncTask.Run(ctx, false, false); e != nil {
+					log.Logger(ctx).Error("Cannot resync datasource after SFTP peer batch", zap.Error(e))
+				}
+			case <-ctx.Done():
+				return
+			case <-s.stop:
+				return
+			}
+		}
+	}()
+}
+
+func (s *Handler) stopPeer() {
+	if s.peerCancel != nil {
+		s.peerCancel()
+		s.peerCancel = nil
+	}
+	if s.peerTask != nil {
+		s.peerTask.Shutdown()
+		s.peerTask = nil
+	}
+	if s.peerClient != nil {
+		s.peerClient.Close()
+		s.peerClient = nil
+	}
+}
@@ -42,6 +42,7 @@ import (
 	"github.com/micro/go-micro/client"
 	"github.com/micro/go-micro/metadata"
 	"github.com/pydio/minio-go"
+	"github.com/uber-go/tally"
 	"go.uber.org/zap"
 
 	"github.com/pydio/cells/common"
@@ -56,10 +57,13 @@ import (
 	"github.com/pydio/cells/common/registry"
 	"github.com/pydio/cells/common/service"
 	servicecontext "github.com/pydio/cells/common/service/context"
+	"github.com/pydio/cells/common/service/metrics"
 	protoservice "github.com/pydio/cells/common/service/proto"
 	"github.com/pydio/cells/common/sync/endpoints/index"
 	"github.com/pydio/cells/common/sync/endpoints/s3"
+	"github.com/pydio/cells/common/sync/endpoints/sftp"
 	"github.com/pydio/cells/common/sync/model"
+	"github.com/pydio/cells/common/sync/proc"
 	"github.com/pydio/cells/common/sync/task"
 	context2 "github.com/pydio/cells/common/utils/context"
 	"github.com/pydio/cells/scheduler/tasks"
@@ -77,13 +81,21 @@ type Handler struct {
 	indexClientSession tree.SessionIndexerClient
 	s3client           model.Endpoint
 
-	syncTask     *task.Sync
-	SyncConfig   *object.DataSource
-	ObjectConfig *object.MinioConfig
+	syncTask        *task.Sync
+	conflictJournal *proc.BoltConflictJournal
+	peerTask        *task.Sync
+	peerClient      *sftp.Client
+	peerCancel      context.CancelFunc
+	SyncConfig      *object.DataSource
+	ObjectConfig    *object.MinioConfig
 
 	watcher    configx.Receiver
 	reloadChan chan bool
 	stop       chan bool
+	stopOnce   sync2.Once
+
+	maintenance     *config.DataSourceMaintenance
+	maintenanceLock sync2.Mutex
 }
 
 func NewHandler(ctx context.Context, datasource string) (*Handler, error) {
@@ -107,17 +119,25 @@ func NewHandler(ctx context.Context, datasource string) (*Handler, error) {
 
 func (s *Handler) Start() {
 	s.syncTask.Start(s.globalCtx, true)
+	s.startPeer()
 	go s.watchConfigs()
 	go s.watchErrors()
 	go s.watchDisconnection()
+	go s.watchMaintenance()
 }
 
 func (s *Handler) Stop() {
-	s.stop <- true
-	s.syncTask.Shutdown()
-	if s.watcher != nil {
-		s.watcher.Stop()
-	}
+	s.stopOnce.Do(func() {
+		close(s.stop)
+		s.syncTask.Shutdown()
+		s.stopPeer()
+		if s.conflictJournal != nil {
+			s.conflictJournal.Close()
+		}
+		if s.watcher != nil {
+			s.watcher.Stop()
+		}
+	})
 }
 
 func (s *Handler) StartConfigsOnly() {
@@ -359,8 +379,72 @@ func (s *Handler) initSync(syncConfig *object.DataSource) error {
 	s.syncTask.SkipTargetChecks = true
 	s.syncTask.FailsafeDeletes = true
 
+	if e := s.initPeer(syncConfig); e != nil {
+		return e
+	}
+	return s.initConflicts(syncConfig)
+
+}
+
+// CheckHealth verifies that the objects service is reachable and that the datasource bucket can be listed.
+func (s *Handler) CheckHealth(ctx context.Context) error {
+	mc := s.ObjectConfig
+	if mc == nil || s.SyncConfig == nil {
+		return fmt.Errorf("datasource %s is not initialized", s.dsName)
+	}
+	core, e := minio.NewCore(mc.BuildUrl(), mc.ApiKey, mc.ApiSecret, mc.RunningSecure)
+	if e != nil {
+		return e
+	}
+	ctx = metadata.NewContext(ctx, map[string]string{common.PydioContextUserKey: common.PydioSystemUsername})
+	if bucket := s.SyncConfig.ObjectsBucket; bucket != "" {
+		if _, e := core.ListObjectsWithContext(ctx, bucket, "", "/", "/", 1); e != nil {
+			return fmt.Errorf("bucket %s is not reachable: %v", bucket, e)
+		}
+		return nil
+	}
+	if _, e := core.ListBucketsWithContext(ctx); e != nil {
+		return fmt.Errorf("buckets cannot be listed: %v", e)
+	}
 	return nil
+}
 
+// initConflicts applies the conflict strategy read from the datasource configuration and attaches
+// a persistent journal to the peer task. Conflicts only happen when merging changes from both sides, so
+// the strategy is refused unless the datasource has a bidirectional peer, the index sync being unidirectional.
+// The journal is opened once and kept across sync restarts.
+func (s *Handler) initConflicts(syncConfig *object.DataSource) error {
+	ctx := s.globalCtx
+	value := syncConfig.StorageConfiguration[object.StorageKeyConflictStrategy]
+	strategy, e := merger.ParseConflictStrategy(value)
+	if e != nil {
+		return e
+	}
+	if s.peerTask == nil || s.peerTask.Direction != model.DirectionBi {
+		if value != "" {
+			return fmt.Errorf("conflict strategy %s requires a bidirectional sftp peer", value)
+		}
+		return nil
+	}
+	if value == "" {
+		strategy = merger.ConflictStrategyLastWriterWins
+	}
+	s.peerTask.ConflictStrategy = strategy
+	if s.conflictJournal == nil {
+		dir, er := config.ServiceDataDir(common.ServiceGrpcNamespace_ + common.ServiceDataSync_ + s.dsName)
+		if er != nil {
+			log.Logger(ctx).Error("Cannot find data directory for conflicts journal", zap.Error(er))
+			return nil
+		}
+		journal, er := proc.NewBoltConflictJournal(dir)
+		if er != nil {
+			log.Logger(ctx).Error("Cannot open conflicts journal", zap.Error(er))
+			return nil
+		}
+		s.conflictJournal = journal
+	}
+	s.peerTask.SetConflictJournal(s.conflictJournal)
+	return nil
 }
 
 func (s *Handler) watchDisconnection() {
@@ -384,6 +468,10 @@ func (s *Handler) watchDisconnection() {
 				log.Logger(s.globalCtx).Error("Error while restarting sync")
 			}
 			s.syncTask.Start(s.globalCtx, true)
+			if s.inMaintenance() != nil {
+				s.syncTask.Pause(s.globalCtx)
+			}
+			s.startPeer()
 			return
 		}
 	}
@@ -427,6 +515,42 @@ func (s *Handler) watchErrors() {
 	}
 }
 
+// watchMaintenance pauses the sync while a maintenance window is opened on the datasource and resumes it
+// once the window is closed.
+func (s *Handler) watchMaintenance() {
+	check := func() {
+		m := config.GetActiveDataSourceMaintenance(s.dsName)
+		s.maintenanceLock.Lock()
+		defer s.maintenanceLock.Unlock()
+		if m != nil && s.maintenance == nil {
+			log.Logger(s.globalCtx).Info("Datasource " + s.dsName + " entered " + string(m.Mode) + " maintenance, pausing sync")
+			s.syncTask.Pause(s.globalCtx)
+		} else if m == nil && s.maintenance != nil {
+			log.Logger(s.globalCtx).Info("Datasource " + s.dsName + " maintenance is over, resuming sync")
+			s.syncTask.Resume(s.globalCtx)
+		}
+		s.maintenance = m
+	}
+	check()
+	ticker := time.NewTicker(30 * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			check()
+		case <-s.stop:
+			return
+		}
+	}
+}
+
+// inMaintenance returns the maintenance window the sync is currently paused for, if any
+func (s *Handler) inMaintenance() *config.DataSourceMaintenance {
+	s.maintenanceLock.Lock()
+	defer s.maintenanceLock.Unlock()
+	return s.maintenance
+}
+
 func (s *Handler) watchConfigs() {
 	serviceName := common.ServiceGrpcNamespace_ + common.ServiceDataSync_ + s.dsName
 
@@ -472,6 +596,10 @@ func (s *Handler) watchConfigs() {
 // TriggerResync sets 2 servers in sync
 func (s *Handler) TriggerResync(c context.Context, req *protosync.ResyncRequest, resp *protosync.ResyncResponse) error {
 
+	if m := s.inMaintenance(); m != nil {
+		return fmt.Errorf("datasource %s is in %s maintenance, resync is paused until the maintenance window is closed", s.dsName, m.Mode)
+	}
+
 	var statusChan chan model.Status
 	var doneChan chan interface{}
 	fullLog := &jobs.ActionLog{
@@ -600,7 +728,12 @@ func (s *Handler) TriggerResync(c context.Context, req *protosync.ResyncRequest,
 		}
 		return e
 	} else {
-		data, _ := json.Marshal(result.Stats())
+		stats := result.Stats()
+		processed, failed := statsTotals(stats)
+		scope := metrics.GetMetricsForService(servicecontext.GetServiceName(c))
+		scope.Histogram("sync_session_operations", syncSizeBuckets).RecordValue(float64(processed))
+		scope.Counter("sync_session_errors").Inc(int64(failed))
+		data, _ := json.Marshal(stats)
 		resp.JsonDiff = string(data)
 		resp.Success = true
 		return nil
@@ -662,3 +795,24 @@ func (s *Handler) CleanResourcesBeforeDelete(ctx context.Context, request *objec
 
 	return nil
 }
+
+var syncSizeBuckets = tally.MustMakeExponentialValueBuckets(10, 4, 8)
+
+// statsTotals sums the processed and failed operations of a sync result, walking nested stats.
+func statsTotals(stats map[string]interface{}) (processed int, failed int) {
+	for k, v := range stats {
+		switch val := v.(type) {
+		case map[string]int:
+			if k == "Processed" {
+				processed += val["Total"]
+			} else if k == "Errors" {
+				failed += val["Total"]
+			}
+		case map[string]interface{}:
+			p, e := statsTotals(val)
+			processed += p
+			failed += e
+		}
+	}
+	return
+}
@@ -48,6 +48,7 @@ import (
 	"github.com/pydio/cells/common/proto/tree"
 	"github.com/pydio/cells/common/registry"
 	"github.com/pydio/cells/common/service"
+	"github.com/pydio/cells/common/service/health"
 	"github.com/pydio/cells/data/source/sync"
 )
 
@@ -100,6 +101,7 @@ func init() {
 					if e != nil {
 						return e
 					}
+					health.Register(servicecontext.GetServiceName(ctx), "objects", syncHandler)
 
 					tree.RegisterNodeProviderHandler(m.Server(), syncHandler)
 					tree.RegisterNodeReceiverHandler(m.Server(), syncHandler)
@@ -0,0 +1,142 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package rest
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/emicklei/go-restful"
+	"github.com/micro/go-micro/errors"
+	"go.uber.org/zap"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/config"
+	"github.com/pydio/cells/common/log"
+	"github.com/pydio/cells/common/proto/rest"
+	"github.com/pydio/cells/common/proto/tree"
+	"github.com/pydio/cells/common/service"
+	"github.com/pydio/cells/common/views"
+	"github.com/pydio/cells/common/views/models"
+	"github.com/pydio/cells/data/versions"
+)
+
+const (
+	// defaultDiffMaxSize is the maximum size of each side of a diff, in bytes
+	defaultDiffMaxSize = 5 * 1024 * 1024
+)
+
+// DiffVersions loads two revisions of a text document (plain text or office document) and
+// streams a textual diff between them.
+func (h *Handler) DiffVersions(req *restful.Request, resp *restful.Response) {
+
+	var input rest.DiffVersionsRequest
+	if e := req.ReadEntity(&input); e != nil {
+		service.RestError400(req, resp, e)
+		return
+	}
+	if input.Path == "" {
+		service.RestError400(req, resp, errors.BadRequest(common.ServiceTree, "please provide a node path"))
+		return
+	}
+	if input.LeftVersionId == input.RightVersionId {
+		service.RestError400(req, resp, errors.BadRequest(common.ServiceTree, "please provide two different versions"))
+		return
+	}
+	ctx := req.Request.Context()
+	maxSize := config.Get("services", common.ServiceRestNamespace_+common.ServiceTree, "diffMaxSize").Default(defaultDiffMaxSize).Int64()
+	maxLines := config.Get("services", common.ServiceRestNamespace_+common.ServiceTree, "diffMaxLines").Default(versions.DefaultDiffMaxLines).Int()
+	opts := versions.DiffOptions{
+		Format:   input.Format,
+		Context:  int(input.Context),
+		MaxLines: maxLines,
+	}
+	if e := versions.CheckDiff(versions.DiffSide{}, versions.DiffSide{}, opts); e != nil {
+		service.RestError400(req, resp, e)
+		return
+	}
+
+	left, e := h.loadDiffSide(ctx, input.Path, input.LeftVersionId, maxSize)
+	if e != nil {
+		service.RestErrorDetect(req, resp, e)
+		return
+	}
+	right, e := h.loadDiffSide(ctx, input.Path, input.RightVersionId, maxSize)
+	if e != nil {
+		service.RestErrorDetect(req, resp, e)
+		return
+	}
+	if e := versions.CheckDiff(left, right, opts); e != nil {
+		service.RestError400(req, resp, e)
+		return
+	}
+
+	resp.AddHeader("Content-Type", "text/plain; charset=utf-8")
+	resp.WriteHeader(200)
+	if e := versions.WriteDiff(resp, left, right, opts); e != nil {
+		// Headers are already sent, just log the error
+		log.Logger(ctx).Error("Could not write diff between versions", zap.String("path", input.Path), zap.Error(e))
+	}
+
+}
+
+// loadDiffSide reads a node (at a given version if versionId is not empty) and extracts its text
+func (h *Handler) loadDiffSide(ctx context.Context, nodePath string, versionId string, maxSize int64) (versions.DiffSide, error) {
+
+	side := versions.DiffSide{}
+	node := &tree.Node{Path: nodePath}
+	label := "current"
+	if versionId != "" {
+		node.SetMeta("versionId", versionId)
+		label = versionId
+	}
+	rr, e := h.GetRouter().ReadNode(ctx, &tree.ReadNodeRequest{Node: node})
+	if e != nil {
+		return side, e
+	}
+	n := rr.GetNode()
+	if !n.IsLeaf() {
+		return side, errors.BadRequest(common.ServiceTree, "cannot compute a diff on a folder")
+	}
+	if n.Size > maxSize {
+		return side, errors.New(common.ServiceTree, fmt.Sprintf("file is too large to be compared (%d bytes, limit is %d)", n.Size, maxSize), 413)
+	}
+	reader, e := h.GetRouter().GetObject(ctx, &tree.Node{Path: nodePath}, &models.GetRequestData{StartOffset: 0, Length: -1, VersionId: versionId})
+	if e != nil {
+		return side, e
+	}
+	defer reader.Close()
+	text, mime, e := views.ReadText(reader, n.GetStringMeta("name"), n.GetStringMeta(common.MetaNamespaceMime), maxSize)
+	if e == views.ErrTextTooLarge {
+		return side, errors.New(common.ServiceTree, e.Error(), 413)
+	} else if e != nil {
+		return side, errors.BadRequest(common.ServiceTree, "%s", e.Error())
+	}
+	log.Logger(ctx).Debug("Loaded text for diff", zap.String("path", nodePath), zap.String("version", label), zap.String("mime", mime))
+	side.Label = nodePath + " (" + label + ")"
+	side.Text = text
+	if n.MTime > 0 {
+		side.Date = time.Unix(n.MTime, 0).Format(time.RFC3339)
+	}
+	return side, nil
+
+}
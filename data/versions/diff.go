@@ -0,0 +1,194 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package versions
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+const (
+	// DiffFormatUnified produces a standard unified diff, line by line
+	DiffFormatUnified = "unified"
+	// DiffFormatWords produces a word-level diff using the "[-removed-]{+added+}" notation
+	DiffFormatWords = "words"
+
+	// DefaultDiffContext is the number of context lines used around unified hunks
+	DefaultDiffContext = 3
+	// DefaultDiffMaxLines is the maximum number of lines accepted on each side of a diff
+	DefaultDiffMaxLines = 20000
+)
+
+// DiffSide describes one of the two texts to compare
+type DiffSide struct {
+	Label string
+	Date  string
+	Text  string
+}
+
+// DiffOptions configures a text diff
+type DiffOptions struct {
+	Format   string
+	Context  int
+	MaxLines int
+}
+
+// ErrDiffTooLarge is returned when one of the texts exceeds the maximum number of lines
+type ErrDiffTooLarge struct {
+	Label string
+	Lines int
+	Max   int
+}
+
+func (e *ErrDiffTooLarge) Error() string {
+	return fmt.Sprintf("%s has %d lines, diff is limited to %d lines", e.Label, e.Lines, e.Max)
+}
+
+// CheckDiff verifies that the format is supported and that both texts fit within the
+// maximum number of lines, so that errors can be reported before streaming anything.
+func CheckDiff(left, right DiffSide, opts DiffOptions) error {
+
+	switch opts.Format {
+	case DiffFormatWords, DiffFormatUnified, "":
+	default:
+		return fmt.Errorf("unsupported diff format %s", opts.Format)
+	}
+	if opts.MaxLines <= 0 {
+		opts.MaxLines = DefaultDiffMaxLines
+	}
+	for _, side := range []DiffSide{left, right} {
+		if l := len(difflib.SplitLines(side.Text)); l > opts.MaxLines {
+			return &ErrDiffTooLarge{Label: side.Label, Lines: l, Max: opts.MaxLines}
+		}
+	}
+	return nil
+
+}
+
+// WriteDiff computes the difference between left and right and streams the result to the writer,
+// using the format defined in the options.
+func WriteDiff(w io.Writer, left, right DiffSide, opts DiffOptions) error {
+
+	if e := CheckDiff(left, right, opts); e != nil {
+		return e
+	}
+	if opts.Context <= 0 {
+		opts.Context = DefaultDiffContext
+	}
+	leftLines := difflib.SplitLines(left.Text)
+	rightLines := difflib.SplitLines(right.Text)
+
+	switch opts.Format {
+	case DiffFormatWords:
+		return writeWordDiff(w, left.Text, right.Text)
+	case DiffFormatUnified, "":
+		bw := bufio.NewWriter(w)
+		if e := difflib.WriteUnifiedDiff(bw, difflib.UnifiedDiff{
+			A:        leftLines,
+			B:        rightLines,
+			FromFile: left.Label,
+			FromDate: left.Date,
+			ToFile:   right.Label,
+			ToDate:   right.Date,
+			Context:  opts.Context,
+		}); e != nil {
+			return e
+		}
+		return bw.Flush()
+	default:
+		return fmt.Errorf("unsupported diff format %s", opts.Format)
+	}
+
+}
+
+// writeWordDiff tokenizes both texts into words and separators, maps each distinct token
+// to a rune so that the diff algorithm works on tokens, and writes the resulting chunks.
+func writeWordDiff(w io.Writer, left, right string) error {
+
+	tokens := map[string]rune{}
+	var tokensArray []string
+	encode := func(text string) []rune {
+		var out []rune
+		for _, t := range splitWords(text) {
+			r, ok := tokens[t]
+			if !ok {
+				r = rune(len(tokensArray))
+				tokens[t] = r
+				tokensArray = append(tokensArray, t)
+			}
+			out = append(out, r)
+		}
+		return out
+	}
+	leftRunes := encode(left)
+	rightRunes := encode(right)
+
+	dmp := diffmatchpatch.New()
+	diffs := dmp.DiffMainRunes(leftRunes, rightRunes, false)
+
+	bw := bufio.NewWriter(w)
+	for _, d := range diffs {
+		var text strings.Builder
+		for _, r := range d.Text {
+			text.WriteString(tokensArray[r])
+		}
+		var e error
+		switch d.Type {
+		case diffmatchpatch.DiffEqual:
+			_, e = bw.WriteString(text.String())
+		case diffmatchpatch.DiffDelete:
+			_, e = bw.WriteString("[-" + text.String() + "-]")
+		case diffmatchpatch.DiffInsert:
+			_, e = bw.WriteString("{+" + text.String() + "+}")
+		}
+		if e != nil {
+			return e
+		}
+	}
+	return bw.Flush()
+
+}
+
+// splitWords splits a text in a list of tokens that are either words or whitespaces/punctuation.
+// Concatenating all tokens gives the original text back.
+func splitWords(text string) (tokens []string) {
+	var current []rune
+	var currentIsWord bool
+	for _, r := range text {
+		isWord := unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+		if len(current) > 0 && (isWord != currentIsWord || !isWord) {
+			tokens = append(tokens, string(current))
+			current = current[:0]
+		}
+		current = append(current, r)
+		currentIsWord = isWord
+	}
+	if len(current) > 0 {
+		tokens = append(tokens, string(current))
+	}
+	return
+}
@@ -0,0 +1,78 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package versions
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestWriteDiff(t *testing.T) {
+
+	left := DiffSide{Label: "a.txt (v1)", Text: "line one\nline two\nline three\n"}
+	right := DiffSide{Label: "a.txt (v2)", Text: "line one\nline 2\nline three\nline four\n"}
+
+	Convey("Unified diff", t, func() {
+		buf := &bytes.Buffer{}
+		e := WriteDiff(buf, left, right, DiffOptions{})
+		So(e, ShouldBeNil)
+		out := buf.String()
+		So(out, ShouldStartWith, "--- a.txt (v1)\n+++ a.txt (v2)\n")
+		So(out, ShouldContainSubstring, "-line two\n")
+		So(out, ShouldContainSubstring, "+line 2\n")
+		So(out, ShouldContainSubstring, "+line four\n")
+		So(out, ShouldContainSubstring, " line three\n")
+	})
+
+	Convey("Word diff", t, func() {
+		buf := &bytes.Buffer{}
+		e := WriteDiff(buf, left, right, DiffOptions{Format: DiffFormatWords})
+		So(e, ShouldBeNil)
+		So(buf.String(), ShouldEqual, "line one\nline [-two-]{+2+}\nline three\n{+line four\n+}")
+	})
+
+	Convey("Identical texts", t, func() {
+		buf := &bytes.Buffer{}
+		e := WriteDiff(buf, left, left, DiffOptions{})
+		So(e, ShouldBeNil)
+		So(buf.Len(), ShouldEqual, 0)
+	})
+
+	Convey("Limits and formats", t, func() {
+		big := DiffSide{Label: "big", Text: strings.Repeat("line\n", 20)}
+		e := WriteDiff(&bytes.Buffer{}, left, big, DiffOptions{MaxLines: 10})
+		So(e, ShouldHaveSameTypeAs, &ErrDiffTooLarge{})
+		e = WriteDiff(&bytes.Buffer{}, left, right, DiffOptions{Format: "html"})
+		So(e, ShouldNotBeNil)
+		So(CheckDiff(left, big, DiffOptions{MaxLines: 10}), ShouldHaveSameTypeAs, &ErrDiffTooLarge{})
+		So(CheckDiff(left, right, DiffOptions{Format: "html"}), ShouldNotBeNil)
+		So(CheckDiff(left, right, DiffOptions{Format: DiffFormatWords}), ShouldBeNil)
+	})
+
+	Convey("Split words", t, func() {
+		So(splitWords("hello, big_world  2021"), ShouldResemble, []string{"hello", ",", " ", "big_world", " ", " ", "2021"})
+		So(strings.Join(splitWords("été à l'eau"), ""), ShouldEqual, "été à l'eau")
+	})
+
+}
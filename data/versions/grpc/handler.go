@@ -139,21 +139,20 @@ func (h *Handler) StoreVersion(ctx context.Context, request *tree.StoreVersionRe
 		resp.Success = true
 	}
 
-	pruningPeriods, err := versions.PreparePeriods(time.Now(), p.KeepPeriods)
-	if err != nil {
-		log.Logger(ctx).Error("cannot prepare periods for versions policy", p.Zap(), zap.Error(err))
-	}
+	var changes []*tree.ChangeLog
 	logs, done := h.db.GetVersions(request.Node.Uuid)
-	pruningPeriods, err = versions.DispatchChangeLogsByPeriod(pruningPeriods, logs, done)
-	log.Logger(ctx).Debug("[VERSION] Pruning Periods", log.DangerouslyZapSmallSlice("p", pruningPeriods))
-	var toRemove []*tree.ChangeLog
-	for _, period := range pruningPeriods {
-		out := period.Prune()
-		toRemove = append(toRemove, out...)
+loop:
+	for {
+		select {
+		case l := <-logs:
+			changes = append(changes, l)
+		case <-done:
+			break loop
+		}
 	}
-	if p.MaxSizePerFile > 0 {
-		out, _ := versions.PruneAllWithMaxSize(pruningPeriods, p.MaxSizePerFile)
-		toRemove = append(toRemove, out...)
+	toRemove, er := versions.PruneByPolicy(p, time.Now(), changes)
+	if er != nil {
+		log.Logger(ctx).Error("cannot prepare periods for versions policy", p.Zap(), zap.Error(er))
 	}
 	if len(toRemove) > 0 {
 		log.Logger(ctx).Debug("[VERSION] Pruning should remove", zap.Int("number", len(toRemove)))
@@ -0,0 +1,141 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package versions
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/micro/go-micro/errors"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/config"
+	"github.com/pydio/cells/common/proto/tree"
+)
+
+var (
+	policyUuidRegexp = regexp.MustCompile("^[0-9a-zA-Z_-]+$")
+
+	// dataSourceExists tells if a datasource is declared in the configuration
+	dataSourceExists = func(name string) bool {
+		for _, n := range config.SourceNamesForDataServices(common.ServiceDataSync) {
+			if n == name {
+				return true
+			}
+		}
+		return false
+	}
+)
+
+// ValidatePolicy checks that a VersioningPolicy can be stored: it must have an identifier, a name and an existing
+// datasource for the versions, and its periods must start with the current time and be sorted by increasing durations.
+// It returns a BadRequest error otherwise.
+func ValidatePolicy(p *tree.VersioningPolicy) error {
+	if e := checkPolicy(p); e != nil {
+		return errors.BadRequest(common.ServiceGrpcNamespace_+common.ServiceVersions, "%s", e.Error())
+	}
+	return nil
+}
+
+func checkPolicy(p *tree.VersioningPolicy) error {
+	if !policyUuidRegexp.MatchString(p.Uuid) {
+		return fmt.Errorf("policy identifier must only contain letters, digits, dashes and underscores")
+	}
+	if p.Name == "" {
+		return fmt.Errorf("policy name cannot be empty")
+	}
+	if p.VersionsDataSourceName == "" {
+		return fmt.Errorf("please provide a datasource for storing versions")
+	}
+	// "default" is kept for backward compatibility, see DataSourceForPolicy
+	if p.VersionsDataSourceName != "default" && !dataSourceExists(p.VersionsDataSourceName) {
+		return fmt.Errorf("unknown datasource %s for storing versions", p.VersionsDataSourceName)
+	}
+	if _, ok := tree.VersioningNodeDeletedStrategy_name[int32(p.NodeDeletedStrategy)]; !ok {
+		return fmt.Errorf("unknown strategy %d for deleted nodes", p.NodeDeletedStrategy)
+	}
+	for name, size := range map[string]int64{
+		"MaxTotalSize":           p.MaxTotalSize,
+		"MaxSizePerFile":         p.MaxSizePerFile,
+		"IgnoreFilesGreaterThan": p.IgnoreFilesGreaterThan,
+	} {
+		if size < -1 {
+			return fmt.Errorf("%s must be positive, or -1 for no limit", name)
+		}
+	}
+	if len(p.KeepPeriods) == 0 {
+		return fmt.Errorf("policy must define at least one period")
+	}
+	var last time.Duration
+	for i, period := range p.KeepPeriods {
+		if period.MaxNumber < -1 {
+			return fmt.Errorf("period %d: maximum number of versions must be positive, or -1 for no limit", i+1)
+		}
+		if period.IntervalStart == "" || period.IntervalStart == "0" {
+			if i > 0 {
+				return fmt.Errorf("period %d: only the first period can start now", i+1)
+			}
+			continue
+		}
+		d, e := ParseDuration(period.IntervalStart)
+		if e != nil {
+			return fmt.Errorf("period %d: invalid start %s (use a duration like 12h or 30d)", i+1, period.IntervalStart)
+		}
+		if i == 0 {
+			return fmt.Errorf("period 1 must start now (0)")
+		}
+		if d <= last {
+			return fmt.Errorf("period %d: periods must be sorted by increasing start", i+1)
+		}
+		last = d
+	}
+	_, e := PreparePeriods(time.Now(), p.KeepPeriods)
+	return e
+}
+
+// PruneByPolicy computes which versions of a node would be removed by the periods and the size limit of a policy.
+func PruneByPolicy(p *tree.VersioningPolicy, now time.Time, changes []*tree.ChangeLog) ([]*tree.ChangeLog, error) {
+
+	periods, e := PreparePeriods(now, p.KeepPeriods)
+	if e != nil {
+		return nil, e
+	}
+	c := make(chan *tree.ChangeLog)
+	done := make(chan bool, 1)
+	go func() {
+		for _, change := range changes {
+			c <- change
+		}
+		done <- true
+	}()
+	periods, _ = DispatchChangeLogsByPeriod(periods, c, done)
+
+	var toRemove []*tree.ChangeLog
+	for _, period := range periods {
+		toRemove = append(toRemove, period.Prune()...)
+	}
+	if p.MaxSizePerFile > 0 {
+		out, _ := PruneAllWithMaxSize(periods, p.MaxSizePerFile)
+		toRemove = append(toRemove, out...)
+	}
+	return toRemove, nil
+}
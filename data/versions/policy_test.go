@@ -0,0 +1,132 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package versions
+
+import (
+	"testing"
+	"time"
+
+	"github.com/micro/go-micro/errors"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/pydio/cells/common/proto/tree"
+)
+
+func testPolicy() *tree.VersioningPolicy {
+	return &tree.VersioningPolicy{
+		Uuid:                   "my-policy",
+		Name:                   "My policy",
+		VersionsDataSourceName: "versions",
+		MaxSizePerFile:         -1,
+		KeepPeriods: []*tree.VersioningKeepPeriod{
+			{IntervalStart: "0", MaxNumber: -1},
+			{IntervalStart: "3h", MaxNumber: 2},
+			{IntervalStart: "7d", MaxNumber: 0},
+		},
+		NodeDeletedStrategy: tree.VersioningNodeDeletedStrategy_KeepLast,
+	}
+}
+
+func TestValidatePolicy(t *testing.T) {
+
+	dataSourceExists = func(name string) bool {
+		return name == "versions"
+	}
+
+	Convey("Test valid policy", t, func() {
+		So(ValidatePolicy(testPolicy()), ShouldBeNil)
+	})
+
+	Convey("Test invalid identifiers and sizes", t, func() {
+		p := testPolicy()
+		p.Uuid = "my policy"
+		So(ValidatePolicy(p), ShouldNotBeNil)
+		p = testPolicy()
+		p.Name = ""
+		So(ValidatePolicy(p), ShouldNotBeNil)
+		p = testPolicy()
+		p.IgnoreFilesGreaterThan = -2
+		So(ValidatePolicy(p), ShouldNotBeNil)
+		p = testPolicy()
+		p.NodeDeletedStrategy = 12
+		So(ValidatePolicy(p), ShouldNotBeNil)
+	})
+
+	Convey("Test versions datasource", t, func() {
+		p := testPolicy()
+		p.VersionsDataSourceName = "unknown"
+		e := ValidatePolicy(p)
+		So(e, ShouldNotBeNil)
+		So(errors.Parse(e.Error()).Code, ShouldEqual, 400)
+		p.VersionsDataSourceName = "default"
+		So(ValidatePolicy(p), ShouldBeNil)
+	})
+
+	Convey("Test invalid periods", t, func() {
+		p := testPolicy()
+		p.KeepPeriods = nil
+		So(ValidatePolicy(p), ShouldNotBeNil)
+		p = testPolicy()
+		p.KeepPeriods[1].IntervalStart = "3 hours"
+		So(ValidatePolicy(p), ShouldNotBeNil)
+		p = testPolicy()
+		p.KeepPeriods[2].IntervalStart = "2h"
+		So(ValidatePolicy(p), ShouldNotBeNil)
+		p = testPolicy()
+		p.KeepPeriods[0].IntervalStart = "1d"
+		So(ValidatePolicy(p), ShouldNotBeNil)
+		p = testPolicy()
+		p.KeepPeriods[2].IntervalStart = "0"
+		So(ValidatePolicy(p), ShouldNotBeNil)
+		p = testPolicy()
+		p.KeepPeriods[1].MaxNumber = -3
+		So(ValidatePolicy(p), ShouldNotBeNil)
+	})
+
+}
+
+func TestPruneByPolicy(t *testing.T) {
+
+	Convey("Test versions pruned by a policy", t, func() {
+		changes := generateChanges("1m", "10m", "4h", "5h", "6h", "8d", "9d")
+		pruned, e := PruneByPolicy(testPolicy(), time.Now(), changes)
+		So(e, ShouldBeNil)
+		var ids []string
+		for _, p := range pruned {
+			ids = append(ids, p.Uuid)
+		}
+		So(ids, ShouldHaveLength, 3)
+		So(ids, ShouldContain, "id-6")
+		So(ids, ShouldContain, "id-7")
+		So(ids, ShouldNotContain, "id-1")
+		So(ids, ShouldNotContain, "id-2")
+	})
+
+	Convey("Test max size per file", t, func() {
+		p := testPolicy()
+		p.MaxSizePerFile = 40
+		pruned, e := PruneByPolicy(p, time.Now(), generateChanges("1m", "2m", "3m"))
+		So(e, ShouldBeNil)
+		So(pruned, ShouldHaveLength, 1)
+		So(pruned[0].Uuid, ShouldEqual, "id-3")
+	})
+
+}
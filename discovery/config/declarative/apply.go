@@ -0,0 +1,324 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package declarative
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/pborman/uuid"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/config"
+	"github.com/pydio/cells/common/proto/idm"
+	"github.com/pydio/cells/common/proto/jobs"
+	"github.com/pydio/cells/common/proto/object"
+	"github.com/pydio/cells/common/proto/tree"
+	"github.com/pydio/cells/common/registry"
+	service "github.com/pydio/cells/common/service/proto"
+	"github.com/pydio/cells/common/utils/permissions"
+	"github.com/pydio/cells/common/views"
+	configrest "github.com/pydio/cells/discovery/config/rest"
+	workspacerest "github.com/pydio/cells/idm/workspace/rest"
+)
+
+// Apply performs the changes through the services owning each resource, which emit the corresponding
+// events. Changes are applied in order and Apply stops at the first failure.
+func Apply(ctx context.Context, changes []*Change) error {
+	for _, c := range changes {
+		var e error
+		if c.Op == OpDelete {
+			e = remove(ctx, c)
+		} else {
+			e = store(ctx, c)
+		}
+		if e != nil {
+			return fmt.Errorf("cannot %s %s %s: %v", c.Op, c.Kind, c.Key, e)
+		}
+	}
+	return nil
+}
+
+func store(ctx context.Context, c *Change) error {
+	switch r := c.Desired.(type) {
+	case *tree.VersioningPolicy:
+		return (&configrest.Handler{}).StoreVersioningPolicy(ctx, proto.Clone(r).(*tree.VersioningPolicy))
+	case *object.DataSource:
+		ds := proto.Clone(r).(*object.DataSource)
+		if current, ok := c.Current.(*object.DataSource); ok && ds.ApiSecret == "" {
+			// Keep the secret stored in the vault
+			ds.ApiSecret = current.ApiSecret
+		}
+		return (&configrest.Handler{}).StoreDataSource(ctx, ds)
+	case *idm.PolicyGroup:
+		_, e := idm.NewPolicyEngineServiceClient(registry.GetClient(common.ServicePolicy)).StorePolicyGroup(ctx, &idm.StorePolicyGroupRequest{PolicyGroup: r})
+		return e
+	case *idm.Role:
+		_, e := idm.NewRoleServiceClient(registry.GetClient(common.ServiceRole)).CreateRole(ctx, &idm.CreateRoleRequest{Role: r})
+		return e
+	case *idm.Workspace:
+		return storeWorkspace(ctx, r, c.Current)
+	case *RoleACLs:
+		current, _ := c.Current.(*RoleACLs)
+		return storeRoleACLs(ctx, r, current)
+	case *jobs.Job:
+		_, e := jobs.NewJobServiceClient(registry.GetClient(common.ServiceJobs)).PutJob(ctx, &jobs.PutJobRequest{Job: r})
+		return e
+	case Sites:
+		return config.SaveSites(r, userName(ctx), "Apply sites from configuration file")
+	}
+	return fmt.Errorf("unsupported resource type %T", c.Desired)
+}
+
+// storeWorkspace keeps the UUID of an existing workspace, and finds the root nodes by their path.
+func storeWorkspace(ctx context.Context, desired *idm.Workspace, current interface{}) error {
+	ws := proto.Clone(desired).(*idm.Workspace)
+	update := false
+	if cur, ok := current.(*idm.Workspace); ok {
+		ws.UUID = cur.UUID
+		update = true
+	} else if ws.UUID == "" {
+		ws.UUID = uuid.New()
+	}
+	for _, p := range ws.Policies {
+		p.Resource = ws.UUID
+	}
+	roots := make(map[string]*tree.Node, len(ws.RootNodes))
+	for _, n := range ws.RootNodes {
+		r, e := nodeByPath(ctx, n.GetPath())
+		if e != nil {
+			return e
+		}
+		roots[r.Uuid] = r
+	}
+	ws.RootNodes = roots
+	_, e := workspacerest.NewWorkspaceHandler().StoreWorkspace(ctx, ws, update)
+	return e
+}
+
+// nodeByPath finds a virtual node or a node of the tree by its path.
+func nodeByPath(ctx context.Context, p string) (*tree.Node, error) {
+	if vNode, ok := views.GetVirtualNodesManager().ByPath(p); ok {
+		return vNode, nil
+	}
+	r, e := tree.NewNodeProviderClient(registry.GetClient(common.ServiceTree)).ReadNode(ctx, &tree.ReadNodeRequest{Node: &tree.Node{Path: p}})
+	if e != nil {
+		return nil, fmt.Errorf("cannot find node %s: %v", p, e)
+	}
+	return r.Node.WithoutReservedMetas(), nil
+}
+
+// storeRoleACLs creates the desired ACLs of a role that do not exist yet, and deletes its exported ACLs that are
+// not desired anymore. Other ACLs of the role (on cells, or that could not be exported) are left untouched.
+func storeRoleACLs(ctx context.Context, desired, current *RoleACLs) error {
+	existing := map[string]bool{}
+	wanted := map[string]bool{}
+	var toDelete []*idm.ACL
+	for _, a := range desired.ACLs {
+		wanted[a.key()] = true
+	}
+	if current != nil {
+		for _, a := range current.ACLs {
+			existing[a.key()] = true
+			if !wanted[a.key()] && a.acl != nil {
+				toDelete = append(toDelete, a.acl)
+			}
+		}
+	}
+	var toCreate []*idm.ACL
+	for _, a := range desired.ACLs {
+		if existing[a.key()] {
+			continue
+		}
+		acl, e := resolveRoleACL(ctx, desired.Role, a)
+		if e != nil {
+			return e
+		}
+		toCreate = append(toCreate, acl)
+	}
+	if len(toDelete) > 0 {
+		// Queries may match other ACLs of the role sharing the same action: they are recreated afterwards
+		recreate, e := deleteACLs(ctx, desired.Role, toDelete)
+		if e != nil {
+			return e
+		}
+		toCreate = append(toCreate, recreate...)
+	}
+	cl := idm.NewACLServiceClient(registry.GetClient(common.ServiceAcl))
+	for _, acl := range toCreate {
+		if _, e := cl.CreateACL(ctx, &idm.CreateACLRequest{ACL: acl}); e != nil {
+			return e
+		}
+	}
+	return nil
+}
+
+// resolveRoleACL finds the UUIDs of the workspace and of the node of an ACL.
+func resolveRoleACL(ctx context.Context, roleId string, a *RoleACL) (*idm.ACL, error) {
+	acl := &idm.ACL{RoleID: roleId, Action: &idm.ACLAction{Name: a.Action, Value: a.Value}, WorkspaceID: a.Workspace, NodeID: a.Node}
+	if a.Workspace != "" {
+		q, _ := ptypes.MarshalAny(&idm.WorkspaceSingleQuery{Slug: a.Workspace})
+		st, e := idm.NewWorkspaceServiceClient(registry.GetClient(common.ServiceWorkspace)).SearchWorkspace(ctx, &idm.SearchWorkspaceRequest{Query: &service.Query{SubQueries: []*any.Any{q}}})
+		if e != nil {
+			return nil, e
+		}
+		defer st.Close()
+		for {
+			r, er := st.Recv()
+			if er == io.EOF {
+				break
+			} else if er != nil {
+				return nil, er
+			}
+			if ws := r.GetWorkspace(); ws.GetSlug() == a.Workspace && ws.GetScope() == idm.WorkspaceScope_ADMIN {
+				acl.WorkspaceID = ws.UUID
+			}
+		}
+	}
+	if a.Path != "" {
+		n, e := nodeByPath(ctx, a.Path)
+		if e != nil {
+			return nil, e
+		}
+		acl.NodeID = n.Uuid
+	}
+	return acl, nil
+}
+
+// deleteACLs deletes ACLs of a role. ACLs have no identifier in queries, so that the ACLs of the role matching the
+// same queries but that must be kept are returned, to be created again.
+func deleteACLs(ctx context.Context, roleId string, acls []*idm.ACL) (recreate []*idm.ACL, e error) {
+	all, e := searchRoleACLs(ctx, roleId)
+	if e != nil {
+		return nil, e
+	}
+	deleted := map[string]bool{}
+	for _, acl := range acls {
+		deleted[acl.ID] = true
+	}
+	var queries []*any.Any
+	for _, acl := range acls {
+		sq := &idm.ACLSingleQuery{RoleIDs: []string{roleId}, Actions: []*idm.ACLAction{acl.Action}}
+		if acl.WorkspaceID != "" {
+			sq.WorkspaceIDs = []string{acl.WorkspaceID}
+		}
+		if acl.NodeID != "" {
+			sq.NodeIDs = []string{acl.NodeID}
+		}
+		q, _ := ptypes.MarshalAny(sq)
+		queries = append(queries, q)
+		for _, other := range all {
+			if deleted[other.ID] || other.Action.GetName() != acl.Action.GetName() {
+				continue
+			}
+			if acl.Action.GetValue() != "" && other.Action.GetValue() != acl.Action.GetValue() {
+				continue
+			}
+			if (acl.WorkspaceID != "" && other.WorkspaceID != acl.WorkspaceID) || (acl.NodeID != "" && other.NodeID != acl.NodeID) {
+				continue
+			}
+			deleted[other.ID] = true
+			recreate = append(recreate, &idm.ACL{RoleID: roleId, Action: other.Action, WorkspaceID: other.WorkspaceID, NodeID: other.NodeID})
+		}
+	}
+	_, e = idm.NewACLServiceClient(registry.GetClient(common.ServiceAcl)).DeleteACL(ctx, &idm.DeleteACLRequest{Query: &service.Query{SubQueries: queries, Operation: service.OperationType_OR}})
+	return
+}
+
+func searchRoleACLs(ctx context.Context, roleId string) (acls []*idm.ACL, e error) {
+	q, _ := ptypes.MarshalAny(&idm.ACLSingleQuery{RoleIDs: []string{roleId}})
+	st, e := idm.NewACLServiceClient(registry.GetClient(common.ServiceAcl)).SearchACL(ctx, &idm.SearchACLRequest{Query: &service.Query{SubQueries: []*any.Any{q}}})
+	if e != nil {
+		return nil, e
+	}
+	defer st.Close()
+	for {
+		r, er := st.Recv()
+		if er == io.EOF {
+			break
+		} else if er != nil {
+			return nil, er
+		}
+		acls = append(acls, r.GetACL())
+	}
+	return
+}
+
+func remove(ctx context.Context, c *Change) error {
+	switch r := c.Current.(type) {
+	case *tree.VersioningPolicy:
+		return (&configrest.Handler{}).RemoveVersioningPolicy(ctx, r.Uuid)
+	case *object.DataSource:
+		return (&configrest.Handler{}).RemoveDataSource(ctx, r.Name)
+	case *idm.PolicyGroup:
+		_, e := idm.NewPolicyEngineServiceClient(registry.GetClient(common.ServicePolicy)).DeletePolicyGroup(ctx, &idm.DeletePolicyGroupRequest{PolicyGroup: r})
+		return e
+	case *idm.Role:
+		q, _ := ptypes.MarshalAny(&idm.RoleSingleQuery{Uuid: []string{r.Uuid}})
+		if _, e := idm.NewRoleServiceClient(registry.GetClient(common.ServiceRole)).DeleteRole(ctx, &idm.DeleteRoleRequest{Query: &service.Query{SubQueries: []*any.Any{q}}}); e != nil {
+			return e
+		}
+		// All ACLs of the role are deleted with it, including the ones that are not exported
+		aq, _ := ptypes.MarshalAny(&idm.ACLSingleQuery{RoleIDs: []string{r.Uuid}})
+		_, e := idm.NewACLServiceClient(registry.GetClient(common.ServiceAcl)).DeleteACL(ctx, &idm.DeleteACLRequest{Query: &service.Query{SubQueries: []*any.Any{aq}}})
+		return e
+	case *RoleACLs:
+		var acls []*idm.ACL
+		for _, a := range r.ACLs {
+			if a.acl != nil {
+				acls = append(acls, a.acl)
+			}
+		}
+		if len(acls) == 0 {
+			return nil
+		}
+		recreate, e := deleteACLs(ctx, r.Role, acls)
+		if e != nil {
+			return e
+		}
+		cl := idm.NewACLServiceClient(registry.GetClient(common.ServiceAcl))
+		for _, acl := range recreate {
+			if _, e := cl.CreateACL(ctx, &idm.CreateACLRequest{ACL: acl}); e != nil {
+				return e
+			}
+		}
+		return nil
+	case *idm.Workspace:
+		q, _ := ptypes.MarshalAny(&idm.WorkspaceSingleQuery{Uuid: r.UUID})
+		_, e := idm.NewWorkspaceServiceClient(registry.GetClient(common.ServiceWorkspace)).DeleteWorkspace(ctx, &idm.DeleteWorkspaceRequest{Query: &service.Query{SubQueries: []*any.Any{q}}})
+		return e
+	case *jobs.Job:
+		_, e := jobs.NewJobServiceClient(registry.GetClient(common.ServiceJobs)).DeleteJob(ctx, &jobs.DeleteJobRequest{JobID: r.ID})
+		return e
+	}
+	return fmt.Errorf("unsupported resource type %T", c.Current)
+}
+
+func userName(ctx context.Context) string {
+	if u, _ := permissions.FindUserNameInContext(ctx); u != "" {
+		return u
+	}
+	return common.PydioSystemUsername
+}
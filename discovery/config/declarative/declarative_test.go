@@ -0,0 +1,202 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package declarative
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/pydio/cells/common/proto/idm"
+	"github.com/pydio/cells/common/proto/install"
+	"github.com/pydio/cells/common/proto/jobs"
+	"github.com/pydio/cells/common/proto/object"
+	"github.com/pydio/cells/common/proto/tree"
+	service "github.com/pydio/cells/common/service/proto"
+)
+
+func testState() *State {
+	return &State{
+		VersioningPolicies: []*tree.VersioningPolicy{{Uuid: "default-policy", Name: "Default", MaxSizePerFile: 1024}},
+		DataSources: []*object.DataSource{{
+			Name:                 "pydiods1",
+			StorageType:          object.StorageType_LOCAL,
+			StorageConfiguration: map[string]string{"folder": "/data/pydiods1", "normalize": "false"},
+			VersioningPolicyName: "default-policy",
+		}},
+		Roles: []*idm.Role{{Uuid: "ADMINS", Label: "Admins", LastUpdated: 12, Policies: []*service.ResourcePolicy{{Id: 3, Subject: "profile:admin"}}}},
+		Workspaces: []*idm.Workspace{{
+			UUID:      "ws-uuid",
+			Slug:      "common-files",
+			Label:     "Common Files",
+			Scope:     idm.WorkspaceScope_ADMIN,
+			RootNodes: map[string]*tree.Node{"node-uuid": {Uuid: "node-uuid", Path: "pydiods1"}},
+		}},
+		RoleACLs: []*RoleACLs{{Role: "ADMINS", ACLs: []*RoleACL{
+			{Action: "read", Workspace: "common-files", Path: "pydiods1"},
+			{Action: "parameter:core.conf:lang", Value: `"fr"`, Workspace: "PYDIO_REPO_SCOPE_ALL"},
+			{Action: "read", Workspace: "settings", Node: "settings-ROOT"},
+		}}},
+		Jobs:  []*jobs.Job{{ID: "clean-job", Label: "Clean", Schedule: &jobs.Schedule{Iso8601Schedule: "R/2012-06-04T19:25:16.828696-07:00/PT10M"}}},
+		Sites: []*install.ProxyConfig{{Binds: []string{"0.0.0.0:8080"}}},
+	}
+}
+
+func TestYAML(t *testing.T) {
+
+	Convey("Test state YAML round trip", t, func() {
+		data, e := testState().MarshalYAML()
+		So(e, ShouldBeNil)
+		So(string(data), ShouldContainSubstring, "version: 1")
+		So(string(data), ShouldContainSubstring, "Slug: common-files")
+
+		s, e := UnmarshalYAML(data)
+		So(e, ShouldBeNil)
+		So(s.DataSources, ShouldHaveLength, 1)
+		So(s.DataSources[0].StorageConfiguration["folder"], ShouldEqual, "/data/pydiods1")
+		So(s.Workspaces[0].RootNodes["node-uuid"].Path, ShouldEqual, "pydiods1")
+		So(s.Jobs[0].Schedule.Iso8601Schedule, ShouldStartWith, "R/")
+		So(s.Sites[0].Binds, ShouldResemble, []string{"0.0.0.0:8080"})
+		So(s.RoleACLs, ShouldHaveLength, 1)
+		So(s.RoleACLs[0].ACLs, ShouldHaveLength, 3)
+		So(s.RoleACLs[0].ACLs[0].Path, ShouldEqual, "pydiods1")
+
+		changes, e := Diff(testState(), s, true)
+		So(e, ShouldBeNil)
+		So(changes, ShouldBeEmpty)
+	})
+
+	Convey("Test invalid documents", t, func() {
+		_, e := UnmarshalYAML([]byte("version: 2\n"))
+		So(e, ShouldNotBeNil)
+		_, e = UnmarshalYAML([]byte("version: 1\nroles:\n- Label: No uuid\n"))
+		So(e, ShouldNotBeNil)
+		_, e = UnmarshalYAML([]byte("version: 1\njobs:\n- ID: a\n- ID: a\n"))
+		So(e, ShouldNotBeNil)
+		_, e = UnmarshalYAML([]byte("version: 1\njobs:\n- ID: a\n  Unknown: true\n"))
+		So(e, ShouldNotBeNil)
+		_, e = UnmarshalYAML([]byte("version: 1\nroleAcls:\n- role: a\n  unknown: true\n"))
+		So(e, ShouldNotBeNil)
+	})
+
+}
+
+func TestDiff(t *testing.T) {
+
+	Convey("Test installation specific fields are ignored", t, func() {
+		current := testState()
+		desired := testState()
+		current.Roles[0].LastUpdated = 0
+		current.Roles[0].Policies[0].Id = 12
+		desired.Workspaces[0].UUID = "other-uuid"
+		desired.Workspaces[0].RootNodes = map[string]*tree.Node{"other-node": {Uuid: "other-node", Path: "pydiods1"}}
+		current.DataSources[0].ApiSecret = "vault-uuid"
+		changes, e := Diff(current, desired, false)
+		So(e, ShouldBeNil)
+		So(changes, ShouldBeEmpty)
+	})
+
+	Convey("Test changes are ordered", t, func() {
+		current := testState()
+		desired := testState()
+		desired.Workspaces[0].Label = "Shared Files"
+		desired.DataSources = append(desired.DataSources, &object.DataSource{Name: "pydiods2"})
+		desired.Jobs = nil
+		desired.VersioningPolicies = nil
+		desired.Sites = append(desired.Sites, &install.ProxyConfig{Binds: []string{"0.0.0.0:8443"}})
+
+		changes, e := Diff(current, desired, false)
+		So(e, ShouldBeNil)
+		So(changes, ShouldHaveLength, 3)
+
+		changes, e = Diff(current, desired, true)
+		So(e, ShouldBeNil)
+		So(changes, ShouldHaveLength, 5)
+		var ops []string
+		for _, c := range changes {
+			ops = append(ops, string(c.Op)+" "+string(c.Kind)+" "+c.Key)
+		}
+		So(ops, ShouldResemble, []string{
+			"create datasource pydiods2",
+			"update workspace common-files",
+			"update sites sites",
+			"delete job clean-job",
+			"delete versioningPolicy default-policy",
+		})
+
+		details, e := changes[1].Details()
+		So(e, ShouldBeNil)
+		So(details, ShouldContainSubstring, "Shared Files")
+		So(strings.Contains(details, "Common Files"), ShouldBeTrue)
+	})
+
+	Convey("Test role ACLs", t, func() {
+		current := testState()
+		desired := testState()
+		// ACLs order does not matter
+		acls := desired.RoleACLs[0].ACLs
+		acls[0], acls[2] = acls[2], acls[0]
+		changes, e := Diff(current, desired, true)
+		So(e, ShouldBeNil)
+		So(changes, ShouldBeEmpty)
+
+		desired.RoleACLs[0].ACLs = append(desired.RoleACLs[0].ACLs, &RoleACL{Action: "write", Workspace: "common-files", Path: "pydiods1"})
+		changes, e = Diff(current, desired, true)
+		So(e, ShouldBeNil)
+		So(changes, ShouldHaveLength, 1)
+		So(changes[0].Kind, ShouldEqual, KindRoleACLs)
+		So(changes[0].Op, ShouldEqual, OpUpdate)
+
+		// Pruning a role also prunes its ACLs, before deleting the workspaces they use and the role
+		desired = testState()
+		desired.Roles = nil
+		desired.RoleACLs = nil
+		desired.Workspaces = nil
+		changes, e = Diff(current, desired, true)
+		So(e, ShouldBeNil)
+		var ops []string
+		for _, c := range changes {
+			ops = append(ops, string(c.Op)+" "+string(c.Kind)+" "+c.Key)
+		}
+		So(ops, ShouldResemble, []string{
+			"delete roleAcls ADMINS",
+			"delete workspace common-files",
+			"delete role ADMINS",
+		})
+	})
+
+	Convey("Test desired secrets are compared to the vault", t, func() {
+		resolveSecret = func(uuid string) string {
+			return map[string]string{"vault-uuid": "secret"}[uuid]
+		}
+		current := testState()
+		current.DataSources[0].ApiSecret = "vault-uuid"
+		desired := testState()
+		desired.DataSources[0].ApiSecret = "secret"
+		changes, _ := Diff(current, desired, false)
+		So(changes, ShouldBeEmpty)
+		desired.DataSources[0].ApiSecret = "changed"
+		changes, _ = Diff(current, desired, false)
+		So(changes, ShouldHaveLength, 1)
+	})
+
+}
@@ -0,0 +1,199 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package declarative
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/yudai/gojsondiff"
+	"github.com/yudai/gojsondiff/formatter"
+
+	"github.com/pydio/cells/common/config"
+	"github.com/pydio/cells/common/proto/idm"
+	"github.com/pydio/cells/common/proto/object"
+	"github.com/pydio/cells/common/proto/tree"
+)
+
+// Operation is the type of a change.
+type Operation string
+
+const (
+	OpCreate Operation = "create"
+	OpUpdate Operation = "update"
+	OpDelete Operation = "delete"
+)
+
+// Change is a difference between the actual and the desired state for one resource.
+type Change struct {
+	Kind    Kind
+	Key     string
+	Op      Operation
+	Current interface{}
+	Desired interface{}
+}
+
+// Diff computes the changes required to go from the current state to the desired state. Resources that
+// are not declared in the desired state are only deleted if prune is set. Changes are sorted in the
+// order they must be applied: creations and updates by kind, then deletions in the reverse order.
+func Diff(current, desired *State, prune bool) ([]*Change, error) {
+	var changes, deletes []*Change
+	for _, k := range Kinds {
+		actual := map[string]interface{}{}
+		for _, r := range current.Resources(k) {
+			actual[KeyOf(r)] = r
+		}
+		declared := map[string]bool{}
+		for _, r := range desired.Resources(k) {
+			key := KeyOf(r)
+			declared[key] = true
+			cur, ok := actual[key]
+			if !ok {
+				changes = append(changes, &Change{Kind: k, Key: key, Op: OpCreate, Desired: r})
+				continue
+			}
+			equal, e := equivalent(cur, r)
+			if e != nil {
+				return nil, e
+			}
+			if !equal {
+				changes = append(changes, &Change{Kind: k, Key: key, Op: OpUpdate, Current: cur, Desired: r})
+			}
+		}
+		if !prune || k == KindSites {
+			continue
+		}
+		for _, r := range current.Resources(k) {
+			if key := KeyOf(r); !declared[key] {
+				deletes = append([]*Change{{Kind: k, Key: key, Op: OpDelete, Current: r}}, deletes...)
+			}
+		}
+	}
+	return append(changes, deletes...), nil
+}
+
+// Details describes the modified fields of an update.
+func (c *Change) Details() (string, error) {
+	if c.Op != OpUpdate {
+		return "", nil
+	}
+	left, e := comparable(c.Current)
+	if e != nil {
+		return "", e
+	}
+	right, e := comparable(c.Desired)
+	if e != nil {
+		return "", e
+	}
+	d, e := gojsondiff.New().Compare(left, right)
+	if e != nil {
+		return "", e
+	}
+	var leftObject map[string]interface{}
+	if e := json.Unmarshal(left, &leftObject); e != nil {
+		return "", e
+	}
+	return formatter.NewAsciiFormatter(leftObject, formatter.AsciiFormatterConfig{ShowArrayIndex: true}).Format(d)
+}
+
+// resolveSecret reads a secret from the vault.
+var resolveSecret = func(uuid string) string {
+	return config.GetSecret(uuid).String()
+}
+
+func equivalent(current, desired interface{}) (bool, error) {
+	// Secrets are stored in the vault: the desired secret, if any, is compared to the resolved current one
+	if d, ok := desired.(*object.DataSource); ok && d.ApiSecret != "" {
+		if c := current.(*object.DataSource); c.ApiSecret != d.ApiSecret && resolveSecret(c.ApiSecret) != d.ApiSecret {
+			return false, nil
+		}
+	}
+	left, e := comparable(current)
+	if e != nil {
+		return false, e
+	}
+	right, e := comparable(desired)
+	if e != nil {
+		return false, e
+	}
+	return bytes.Equal(left, right), nil
+}
+
+// comparable encodes a resource without the fields that are specific to an installation or updated
+// by the services. Maps are encoded with sorted keys, so that equal resources have equal encodings.
+func comparable(r interface{}) ([]byte, error) {
+	switch v := r.(type) {
+	case *object.DataSource:
+		c := proto.Clone(v).(*object.DataSource)
+		c.ApiSecret = ""
+		r = c
+	case *idm.PolicyGroup:
+		c := proto.Clone(v).(*idm.PolicyGroup)
+		c.LastUpdated = 0
+		r = c
+	case *idm.Role:
+		c := proto.Clone(v).(*idm.Role)
+		c.LastUpdated = 0
+		c.PoliciesContextEditable = false
+		for _, p := range c.Policies {
+			p.Id = 0
+		}
+		r = c
+	case *idm.Workspace:
+		r = comparableWorkspace(v)
+	case *RoleACLs:
+		c := &RoleACLs{Role: v.Role, ACLs: append([]*RoleACL{}, v.ACLs...)}
+		c.sort()
+		r = c
+	}
+	raw, e := encode(r)
+	if e != nil {
+		return nil, e
+	}
+	// Re-encode through a generic value to sort map keys
+	var generic interface{}
+	if e := json.Unmarshal(raw, &generic); e != nil {
+		return nil, e
+	}
+	return json.Marshal(generic)
+}
+
+// comparableWorkspace identifies root nodes by their path, as nodes UUIDs differ between installations.
+func comparableWorkspace(ws *idm.Workspace) *idm.Workspace {
+	c := proto.Clone(ws).(*idm.Workspace)
+	c.UUID = ""
+	c.LastUpdated = 0
+	c.RootUUIDs = nil
+	c.PoliciesContextEditable = false
+	if len(c.RootNodes) > 0 {
+		roots := make(map[string]*tree.Node, len(c.RootNodes))
+		for _, n := range c.RootNodes {
+			roots[n.GetPath()] = &tree.Node{Path: n.GetPath()}
+		}
+		c.RootNodes = roots
+	}
+	for _, p := range c.Policies {
+		p.Id = 0
+		p.Resource = ""
+	}
+	return c
+}
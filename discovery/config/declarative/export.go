@@ -0,0 +1,292 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package declarative
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/micro/go-micro/errors"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/config"
+	"github.com/pydio/cells/common/proto/docstore"
+	"github.com/pydio/cells/common/proto/idm"
+	"github.com/pydio/cells/common/proto/jobs"
+	"github.com/pydio/cells/common/proto/tree"
+	"github.com/pydio/cells/common/registry"
+	service "github.com/pydio/cells/common/service/proto"
+	"github.com/pydio/cells/common/views"
+	configrest "github.com/pydio/cells/discovery/config/rest"
+	workspacerest "github.com/pydio/cells/idm/workspace/rest"
+)
+
+// Export loads the current state from the services. Users roles, groups roles and teams, as well as
+// cells and jobs cleaned after their execution are not part of the configuration. Datasources secrets
+// are not exported, neither are the ACLs of the roles on cells and on deleted nodes.
+func Export(ctx context.Context) (*State, error) {
+	s := &State{}
+	var e error
+	if s.VersioningPolicies, e = loadVersioningPolicies(ctx); e != nil {
+		return nil, e
+	}
+	if s.DataSources, e = (&configrest.Handler{}).GetDataSources(ctx); e != nil {
+		return nil, e
+	}
+	for _, ds := range s.DataSources {
+		ds.ApiSecret = ""
+	}
+	policies, e := idm.NewPolicyEngineServiceClient(registry.GetClient(common.ServicePolicy)).ListPolicyGroups(ctx, &idm.ListPolicyGroupsRequest{})
+	if e != nil {
+		return nil, e
+	}
+	for _, p := range policies.PolicyGroups {
+		p.LastUpdated = 0
+		s.Policies = append(s.Policies, p)
+	}
+	if s.Roles, e = loadRoles(ctx); e != nil {
+		return nil, e
+	}
+	if s.Workspaces, e = loadWorkspaces(ctx); e != nil {
+		return nil, e
+	}
+	if s.RoleACLs, e = loadRoleACLs(ctx, s.Roles, s.Workspaces); e != nil {
+		return nil, e
+	}
+	if s.Jobs, e = loadJobs(ctx); e != nil {
+		return nil, e
+	}
+	if s.Sites, e = config.LoadSites(true); e != nil {
+		return nil, e
+	}
+	s.Sort()
+	return s, nil
+}
+
+func loadVersioningPolicies(ctx context.Context) (policies []*tree.VersioningPolicy, e error) {
+	dc := docstore.NewDocStoreClient(registry.GetClient(common.ServiceDocStore))
+	docs, e := dc.ListDocuments(ctx, &docstore.ListDocumentsRequest{StoreID: common.DocStoreIdVersioningPolicies})
+	if e != nil {
+		return nil, e
+	}
+	defer docs.Close()
+	for {
+		r, er := docs.Recv()
+		if er == io.EOF {
+			break
+		} else if er != nil {
+			return nil, er
+		}
+		var policy *tree.VersioningPolicy
+		if er := json.Unmarshal([]byte(r.Document.Data), &policy); er == nil {
+			policies = append(policies, policy)
+		}
+	}
+	return
+}
+
+func loadRoles(ctx context.Context) (roles []*idm.Role, e error) {
+	q, _ := ptypes.MarshalAny(&idm.RoleSingleQuery{IsUserRole: true, IsGroupRole: true, IsTeam: true, Not: true})
+	cl := idm.NewRoleServiceClient(registry.GetClient(common.ServiceRole))
+	st, e := cl.SearchRole(ctx, &idm.SearchRoleRequest{Query: &service.Query{SubQueries: []*any.Any{q}}})
+	if e != nil {
+		return nil, e
+	}
+	defer st.Close()
+	for {
+		r, er := st.Recv()
+		if er == io.EOF {
+			break
+		} else if er != nil {
+			return nil, er
+		}
+		role := r.GetRole()
+		role.LastUpdated = 0
+		for _, p := range role.Policies {
+			p.Id = 0
+		}
+		roles = append(roles, role)
+	}
+	return
+}
+
+func loadWorkspaces(ctx context.Context) (workspaces []*idm.Workspace, e error) {
+	q, _ := ptypes.MarshalAny(&idm.WorkspaceSingleQuery{Scope: idm.WorkspaceScope_ADMIN})
+	cl := idm.NewWorkspaceServiceClient(registry.GetClient(common.ServiceWorkspace))
+	st, e := cl.SearchWorkspace(ctx, &idm.SearchWorkspaceRequest{Query: &service.Query{SubQueries: []*any.Any{q}}})
+	if e != nil {
+		return nil, e
+	}
+	defer st.Close()
+	wss := map[string]*idm.Workspace{}
+	for {
+		r, er := st.Recv()
+		if er == io.EOF {
+			break
+		} else if er != nil {
+			return nil, er
+		}
+		ws := r.GetWorkspace()
+		wss[ws.UUID] = ws
+		workspaces = append(workspaces, ws)
+	}
+	if e := workspacerest.NewWorkspaceHandler().LoadWorkspacesDetails(ctx, wss); e != nil {
+		return nil, e
+	}
+	for _, ws := range workspaces {
+		ws.LastUpdated = 0
+		ws.RootUUIDs = nil
+		for _, p := range ws.Policies {
+			p.Id = 0
+		}
+		// Only keep what identifies root nodes
+		for id, n := range ws.RootNodes {
+			ws.RootNodes[id] = &tree.Node{Uuid: n.Uuid, Path: n.Path}
+		}
+	}
+	return
+}
+
+// loadRoleACLs loads the ACLs of the exported roles. Workspaces are identified by their slug and nodes by their path.
+// Other workspace identifiers (scopes and frontend workspaces like "settings") are kept as is, as well as the nodes
+// that are not in the tree. ACLs on cells and links, and ACLs left on deleted nodes are ignored.
+func loadRoleACLs(ctx context.Context, roles []*idm.Role, workspaces []*idm.Workspace) (rr []*RoleACLs, e error) {
+	if len(roles) == 0 {
+		return nil, nil
+	}
+	slugs := map[string]string{}
+	paths := map[string]string{}
+	for _, ws := range workspaces {
+		slugs[ws.UUID] = ws.Slug
+		for id, n := range ws.RootNodes {
+			paths[id] = n.Path
+		}
+	}
+	others, e := loadSharedWorkspaces(ctx)
+	if e != nil {
+		return nil, e
+	}
+
+	byRole := map[string]*RoleACLs{}
+	var roleIds []string
+	for _, r := range roles {
+		roleIds = append(roleIds, r.Uuid)
+	}
+	q, _ := ptypes.MarshalAny(&idm.ACLSingleQuery{RoleIDs: roleIds})
+	st, e := idm.NewACLServiceClient(registry.GetClient(common.ServiceAcl)).SearchACL(ctx, &idm.SearchACLRequest{Query: &service.Query{SubQueries: []*any.Any{q}}})
+	if e != nil {
+		return nil, e
+	}
+	defer st.Close()
+	treeClient := tree.NewNodeProviderClient(registry.GetClient(common.ServiceTree))
+	for {
+		r, er := st.Recv()
+		if er == io.EOF {
+			break
+		} else if er != nil {
+			return nil, er
+		}
+		acl := r.GetACL()
+		if acl.GetAction() == nil || others[acl.WorkspaceID] {
+			continue
+		}
+		ra := &RoleACL{Action: acl.Action.Name, Value: acl.Action.Value, Workspace: acl.WorkspaceID, acl: acl}
+		slug, isWorkspace := slugs[acl.WorkspaceID]
+		if isWorkspace {
+			ra.Workspace = slug
+		}
+		if acl.NodeID != "" {
+			if p, ok := paths[acl.NodeID]; ok {
+				ra.Path = p
+			} else if vNode, ok := views.GetVirtualNodesManager().ByUuid(acl.NodeID); ok {
+				ra.Path = vNode.Path
+			} else if resp, er := treeClient.ReadNode(ctx, &tree.ReadNodeRequest{Node: &tree.Node{Uuid: acl.NodeID}}); er == nil {
+				ra.Path = resp.GetNode().GetPath()
+			} else if errors.Parse(er.Error()).Code != 404 {
+				return nil, er
+			} else if isWorkspace || acl.WorkspaceID == "" {
+				// ACL left on a deleted node
+				continue
+			} else {
+				ra.Node = acl.NodeID
+			}
+		}
+		ras, ok := byRole[acl.RoleID]
+		if !ok {
+			ras = &RoleACLs{Role: acl.RoleID}
+			byRole[acl.RoleID] = ras
+			rr = append(rr, ras)
+		}
+		ras.ACLs = append(ras.ACLs, ra)
+	}
+	return
+}
+
+// loadSharedWorkspaces lists the UUIDs of the cells and links.
+func loadSharedWorkspaces(ctx context.Context) (map[string]bool, error) {
+	var queries []*any.Any
+	for _, scope := range []idm.WorkspaceScope{idm.WorkspaceScope_ROOM, idm.WorkspaceScope_LINK} {
+		q, _ := ptypes.MarshalAny(&idm.WorkspaceSingleQuery{Scope: scope})
+		queries = append(queries, q)
+	}
+	cl := idm.NewWorkspaceServiceClient(registry.GetClient(common.ServiceWorkspace))
+	st, e := cl.SearchWorkspace(ctx, &idm.SearchWorkspaceRequest{Query: &service.Query{SubQueries: queries, Operation: service.OperationType_OR}})
+	if e != nil {
+		return nil, e
+	}
+	defer st.Close()
+	uuids := map[string]bool{}
+	for {
+		r, er := st.Recv()
+		if er == io.EOF {
+			break
+		} else if er != nil {
+			return nil, er
+		}
+		uuids[r.GetWorkspace().GetUUID()] = true
+	}
+	return uuids, nil
+}
+
+func loadJobs(ctx context.Context) (jj []*jobs.Job, e error) {
+	cl := jobs.NewJobServiceClient(registry.GetClient(common.ServiceJobs))
+	st, e := cl.ListJobs(ctx, &jobs.ListJobsRequest{})
+	if e != nil {
+		return nil, e
+	}
+	defer st.Close()
+	for {
+		r, er := st.Recv()
+		if er == io.EOF {
+			break
+		} else if er != nil {
+			return nil, er
+		}
+		if j := r.GetJob(); !j.AutoClean {
+			j.Tasks = nil
+			jj = append(jj, j)
+		}
+	}
+	return
+}
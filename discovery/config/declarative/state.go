@@ -0,0 +1,369 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+// Package declarative exports the configuration of an installation to a YAML document, computes the
+// differences between such a document and a running installation, and applies them through the services.
+package declarative
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/ghodss/yaml"
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+
+	"github.com/pydio/cells/common/proto/idm"
+	"github.com/pydio/cells/common/proto/install"
+	"github.com/pydio/cells/common/proto/jobs"
+	"github.com/pydio/cells/common/proto/object"
+	"github.com/pydio/cells/common/proto/tree"
+)
+
+// FormatVersion is the version of the document layout.
+const FormatVersion = 1
+
+// Kind identifies a type of resource managed as code.
+type Kind string
+
+const (
+	KindVersioningPolicy Kind = "versioningPolicy"
+	KindDataSource       Kind = "datasource"
+	KindPolicy           Kind = "policy"
+	KindRole             Kind = "role"
+	KindWorkspace        Kind = "workspace"
+	KindRoleACLs         Kind = "roleAcls"
+	KindJob              Kind = "job"
+	KindSites            Kind = "sites"
+)
+
+// Kinds lists all kinds in the order they are created: a resource may only depend on resources of previous kinds.
+var Kinds = []Kind{KindVersioningPolicy, KindDataSource, KindPolicy, KindRole, KindWorkspace, KindRoleACLs, KindJob, KindSites}
+
+// SitesKey is the key of the single resource holding the list of sites.
+const SitesKey = "sites"
+
+// State is the desired or actual configuration of an installation.
+type State struct {
+	VersioningPolicies []*tree.VersioningPolicy
+	DataSources        []*object.DataSource
+	Policies           []*idm.PolicyGroup
+	Roles              []*idm.Role
+	Workspaces         []*idm.Workspace
+	RoleACLs           []*RoleACLs
+	Jobs               []*jobs.Job
+	Sites              []*install.ProxyConfig
+}
+
+// RoleACL is an ACL of a role. Its workspace is identified by its slug (or by a scope like PYDIO_REPO_SCOPE_ALL)
+// and its node by its path, as UUIDs differ between installations.
+type RoleACL struct {
+	Action    string `json:"action"`
+	Value     string `json:"value,omitempty"`
+	Workspace string `json:"workspace,omitempty"`
+	Path      string `json:"path,omitempty"`
+	// Node identifies nodes that are not in the tree, like the root of the "settings" frontend workspace
+	Node string `json:"node,omitempty"`
+
+	// acl is the ACL loaded from the services, it is only set on exported states
+	acl *idm.ACL
+}
+
+// RoleACLs holds the ACLs of a role, managed as a single resource keyed by the role UUID.
+type RoleACLs struct {
+	Role string     `json:"role"`
+	ACLs []*RoleACL `json:"acls,omitempty"`
+}
+
+// key identifies an ACL within its role.
+func (a *RoleACL) key() string {
+	return a.Workspace + "|" + a.Path + "|" + a.Node + "|" + a.Action + "|" + a.Value
+}
+
+// sort orders ACLs by workspace, path and action.
+func (r *RoleACLs) sort() {
+	sort.Slice(r.ACLs, func(i, j int) bool { return r.ACLs[i].key() < r.ACLs[j].key() })
+}
+
+// document is the serialized form of a State, each resource being encoded with jsonpb.
+type document struct {
+	Version            int               `json:"version"`
+	VersioningPolicies []json.RawMessage `json:"versioningPolicies,omitempty"`
+	DataSources        []json.RawMessage `json:"datasources,omitempty"`
+	Policies           []json.RawMessage `json:"policies,omitempty"`
+	Roles              []json.RawMessage `json:"roles,omitempty"`
+	Workspaces         []json.RawMessage `json:"workspaces,omitempty"`
+	RoleACLs           []json.RawMessage `json:"roleAcls,omitempty"`
+	Jobs               []json.RawMessage `json:"jobs,omitempty"`
+	Sites              []json.RawMessage `json:"sites,omitempty"`
+}
+
+// MarshalYAML encodes the state as a YAML document.
+func (s *State) MarshalYAML() ([]byte, error) {
+	doc := &document{Version: FormatVersion}
+	for _, k := range Kinds {
+		var raws []json.RawMessage
+		for _, r := range s.Resources(k) {
+			if k == KindSites {
+				for _, site := range r.(Sites) {
+					raw, er := encode(site)
+					if er != nil {
+						return nil, er
+					}
+					raws = append(raws, raw)
+				}
+				continue
+			}
+			raw, er := encode(r)
+			if er != nil {
+				return nil, er
+			}
+			raws = append(raws, raw)
+		}
+		switch k {
+		case KindVersioningPolicy:
+			doc.VersioningPolicies = raws
+		case KindDataSource:
+			doc.DataSources = raws
+		case KindPolicy:
+			doc.Policies = raws
+		case KindRole:
+			doc.Roles = raws
+		case KindWorkspace:
+			doc.Workspaces = raws
+		case KindRoleACLs:
+			doc.RoleACLs = raws
+		case KindJob:
+			doc.Jobs = raws
+		case KindSites:
+			doc.Sites = raws
+		}
+	}
+	data, e := json.Marshal(doc)
+	if e != nil {
+		return nil, e
+	}
+	return yaml.JSONToYAML(data)
+}
+
+// UnmarshalYAML decodes a YAML document produced by MarshalYAML.
+func UnmarshalYAML(data []byte) (*State, error) {
+	js, e := yaml.YAMLToJSON(data)
+	if e != nil {
+		return nil, e
+	}
+	doc := &document{}
+	if e := json.Unmarshal(js, doc); e != nil {
+		return nil, e
+	}
+	if doc.Version > FormatVersion {
+		return nil, fmt.Errorf("document version %d is not supported by this version", doc.Version)
+	}
+	s := &State{}
+	for _, raw := range doc.VersioningPolicies {
+		p := &tree.VersioningPolicy{}
+		s.VersioningPolicies = append(s.VersioningPolicies, p)
+		if e := decode(raw, p); e != nil {
+			return nil, e
+		}
+	}
+	for _, raw := range doc.DataSources {
+		d := &object.DataSource{}
+		s.DataSources = append(s.DataSources, d)
+		if e := decode(raw, d); e != nil {
+			return nil, e
+		}
+	}
+	for _, raw := range doc.Policies {
+		p := &idm.PolicyGroup{}
+		s.Policies = append(s.Policies, p)
+		if e := decode(raw, p); e != nil {
+			return nil, e
+		}
+	}
+	for _, raw := range doc.Roles {
+		r := &idm.Role{}
+		s.Roles = append(s.Roles, r)
+		if e := decode(raw, r); e != nil {
+			return nil, e
+		}
+	}
+	for _, raw := range doc.Workspaces {
+		w := &idm.Workspace{}
+		s.Workspaces = append(s.Workspaces, w)
+		if e := decode(raw, w); e != nil {
+			return nil, e
+		}
+	}
+	for _, raw := range doc.RoleACLs {
+		r := &RoleACLs{}
+		s.RoleACLs = append(s.RoleACLs, r)
+		dec := json.NewDecoder(bytes.NewReader(raw))
+		dec.DisallowUnknownFields()
+		if e := dec.Decode(r); e != nil {
+			return nil, e
+		}
+	}
+	for _, raw := range doc.Jobs {
+		j := &jobs.Job{}
+		s.Jobs = append(s.Jobs, j)
+		if e := decode(raw, j); e != nil {
+			return nil, e
+		}
+	}
+	for _, raw := range doc.Sites {
+		p := &install.ProxyConfig{}
+		s.Sites = append(s.Sites, p)
+		if e := decode(raw, p); e != nil {
+			return nil, e
+		}
+	}
+	return s, s.validate()
+}
+
+// validate checks that all resources have a key and that keys are unique per kind.
+func (s *State) validate() error {
+	for _, k := range Kinds {
+		seen := map[string]bool{}
+		for _, r := range s.Resources(k) {
+			key := KeyOf(r)
+			if key == "" {
+				return fmt.Errorf("a %s has no identifier", k)
+			}
+			if seen[key] {
+				return fmt.Errorf("%s %s is declared twice", k, key)
+			}
+			seen[key] = true
+		}
+	}
+	return nil
+}
+
+// Sites is the list of sites, managed as a single resource.
+type Sites []*install.ProxyConfig
+
+// Resources lists the resources of a kind.
+func (s *State) Resources(k Kind) (rr []interface{}) {
+	switch k {
+	case KindVersioningPolicy:
+		for _, r := range s.VersioningPolicies {
+			rr = append(rr, r)
+		}
+	case KindDataSource:
+		for _, r := range s.DataSources {
+			rr = append(rr, r)
+		}
+	case KindPolicy:
+		for _, r := range s.Policies {
+			rr = append(rr, r)
+		}
+	case KindRole:
+		for _, r := range s.Roles {
+			rr = append(rr, r)
+		}
+	case KindWorkspace:
+		for _, r := range s.Workspaces {
+			rr = append(rr, r)
+		}
+	case KindRoleACLs:
+		for _, r := range s.RoleACLs {
+			rr = append(rr, r)
+		}
+	case KindJob:
+		for _, r := range s.Jobs {
+			rr = append(rr, r)
+		}
+	case KindSites:
+		if len(s.Sites) > 0 {
+			rr = append(rr, Sites(s.Sites))
+		}
+	}
+	return
+}
+
+// KeyOf returns the identifier of a resource, that is stable across installations.
+func KeyOf(r interface{}) string {
+	switch v := r.(type) {
+	case *tree.VersioningPolicy:
+		return v.Uuid
+	case *object.DataSource:
+		return v.Name
+	case *idm.PolicyGroup:
+		return v.Uuid
+	case *idm.Role:
+		return v.Uuid
+	case *idm.Workspace:
+		return v.Slug
+	case *RoleACLs:
+		return v.Role
+	case *jobs.Job:
+		return v.ID
+	case Sites:
+		return SitesKey
+	}
+	return ""
+}
+
+// Sort orders resources by key, for stable exports.
+func (s *State) Sort() {
+	sort.Slice(s.VersioningPolicies, func(i, j int) bool { return s.VersioningPolicies[i].Uuid < s.VersioningPolicies[j].Uuid })
+	sort.Slice(s.DataSources, func(i, j int) bool { return s.DataSources[i].Name < s.DataSources[j].Name })
+	sort.Slice(s.Policies, func(i, j int) bool { return s.Policies[i].Uuid < s.Policies[j].Uuid })
+	sort.Slice(s.Roles, func(i, j int) bool { return s.Roles[i].Uuid < s.Roles[j].Uuid })
+	sort.Slice(s.Workspaces, func(i, j int) bool { return s.Workspaces[i].Slug < s.Workspaces[j].Slug })
+	sort.Slice(s.RoleACLs, func(i, j int) bool { return s.RoleACLs[i].Role < s.RoleACLs[j].Role })
+	for _, r := range s.RoleACLs {
+		r.sort()
+	}
+	sort.Slice(s.Jobs, func(i, j int) bool { return s.Jobs[i].ID < s.Jobs[j].ID })
+}
+
+// encode serializes a resource to JSON, using jsonpb for protobuf messages.
+func encode(r interface{}) (json.RawMessage, error) {
+	if acls, ok := r.(*RoleACLs); ok {
+		return json.Marshal(acls)
+	}
+	if sites, ok := r.(Sites); ok {
+		var raws []json.RawMessage
+		for _, site := range sites {
+			raw, e := encode(site)
+			if e != nil {
+				return nil, e
+			}
+			raws = append(raws, raw)
+		}
+		return json.Marshal(raws)
+	}
+	m, ok := r.(proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("unsupported resource type %T", r)
+	}
+	buf := &bytes.Buffer{}
+	if e := (&jsonpb.Marshaler{}).Marshal(buf, m); e != nil {
+		return nil, e
+	}
+	return json.RawMessage(buf.Bytes()), nil
+}
+
+func decode(raw json.RawMessage, m proto.Message) error {
+	return jsonpb.Unmarshal(bytes.NewReader(raw), m)
+}
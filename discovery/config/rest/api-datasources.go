@@ -50,6 +50,7 @@ import (
 	"github.com/pydio/cells/common/proto/tree"
 	"github.com/pydio/cells/common/service"
 	service2 "github.com/pydio/cells/common/service/proto"
+	"github.com/pydio/cells/common/sync/merger"
 	"github.com/pydio/cells/common/utils/filesystem"
 	"github.com/pydio/cells/common/utils/permissions"
 	"github.com/pydio/minio-go/pkg/credentials"
@@ -87,6 +88,20 @@ func (s *Handler) PutDataSource(req *restful.Request, resp *restful.Response) {
 		service.RestError500(req, resp, err)
 		return
 	}
+	ctx := req.Request.Context()
+	if err := s.StoreDataSource(ctx, &ds); err != nil {
+		service.RestError500(req, resp, err)
+		return
+	}
+	if err := resp.WriteEntity(&ds); err != nil {
+		log.Logger(ctx).Warn("could not write response", zap.Error(err))
+	}
+
+}
+
+// StoreDataSource validates a datasource, creates or updates its services configurations and notifies
+// the services of the change.
+func (s *Handler) StoreDataSource(ctx context.Context, ds *object.DataSource) error {
 
 	// Replace uuid secret if it exists
 	var secretUuid string
@@ -96,17 +111,23 @@ func (s *Handler) PutDataSource(req *restful.Request, resp *restful.Response) {
 	}
 
 	if reg, _ := regexp.MatchString("^[0-9a-z]*$", ds.Name); !reg {
-		service.RestError500(req, resp, fmt.Errorf("datasource name contains an invalid character, please use alphanumeric characters"))
-		return
+		return fmt.Errorf("datasource name contains an invalid character, please use alphanumeric characters")
 	}
 
-	ctx := req.Request.Context()
+	// Conflicts are only merged by the bidirectional sync with a sftp peer
+	if strategy := ds.StorageConfiguration[object.StorageKeyConflictStrategy]; strategy != "" {
+		if _, e := merger.ParseConflictStrategy(strategy); e != nil {
+			return e
+		}
+		if ds.StorageConfiguration[object.StorageKeySftpPeer] == "" || ds.StorageConfiguration[object.StorageKeySftpPeerDirection] != "both" {
+			return fmt.Errorf("conflict strategy can only be set on datasources with a bidirectional sftp peer")
+		}
+	}
 
 	// Handle / and \ for OS
 	if ds.StorageType == object.StorageType_LOCAL {
-		if err := s.ValidateLocalDSFolderOnPeer(ctx, &ds); err != nil {
-			service.RestError500(req, resp, err)
-			return
+		if err := s.ValidateLocalDSFolderOnPeer(ctx, ds); err != nil {
+			return err
 		}
 		osFolder := filesystem.ToFilePath(ds.StorageConfiguration[object.StorageKeyFolder])
 		rootPrefix := config.Get("services", common.ServiceGrpcNamespace_+common.ServiceDataObjects, "allowedLocalDsFolder").String()
@@ -124,12 +145,11 @@ func (s *Handler) PutDataSource(req *restful.Request, resp *restful.Response) {
 		initialVersioningEmpty = initialDs.VersioningPolicyName == ""
 	}
 
-	minioConfig, e := config.FactorizeMinioServers(currentMinios, &ds, update)
+	minioConfig, e := config.FactorizeMinioServers(currentMinios, ds, update)
 	if e != nil {
-		service.RestError500(req, resp, e)
-		return
+		return e
 	}
-	currentSources[ds.Name] = &ds
+	currentSources[ds.Name] = ds
 	currentMinios[minioConfig.Name] = minioConfig
 	if ds.ApiSecret != "" {
 		if secretUuid == "" {
@@ -154,6 +174,12 @@ func (s *Handler) PutDataSource(req *restful.Request, resp *restful.Response) {
 		config.Del("services", "pydio.grpc.data.index."+dsName, "PeerAddress")
 	}
 	config.Get().Map()
+	if ds.IsDeduplicated() {
+		config.Set(true, "services", "pydio.grpc.data.index."+dsName, "dedup")
+	} else {
+		config.Del("services", "pydio.grpc.data.index."+dsName, "dedup")
+	}
+	config.Get().Map()
 	config.Set("default", "services", "pydio.grpc.data.index."+dsName, "dsn")
 	config.Set(config.IndexServiceTableNames(dsName), "services", "pydio.grpc.data.index."+dsName, "tables")
 	// UPDATE SYNC
@@ -161,7 +187,7 @@ func (s *Handler) PutDataSource(req *restful.Request, resp *restful.Response) {
 	// UPDATE OBJECTS
 	config.Set(minioConfig, "services", "pydio.grpc.data.objects."+minioConfig.Name)
 
-	log.Logger(ctx).Debug("Now Store Sources", zap.Any("sources", currentSources), zap.Any("ds", &ds))
+	log.Logger(ctx).Debug("Now Store Sources", zap.Any("sources", currentSources), zap.Any("ds", ds))
 	config.SourceNamesToConfig(currentSources)
 	config.MinioConfigNamesToConfig(currentMinios)
 
@@ -170,65 +196,66 @@ func (s *Handler) PutDataSource(req *restful.Request, resp *restful.Response) {
 		u = "rest"
 	}
 
-	if err := config.Save(u, "Create DataSource"); err == nil {
-		eventType := object.DataSourceEvent_CREATE
-		if update {
-			eventType = object.DataSourceEvent_UPDATE
-			if initialVersioningEmpty && ds.VersioningPolicyName != "" {
-				if e := createFullVersioningJob(ctx, dsName); e != nil {
-					log.Logger(ctx).Warn("Could not insert full versioning job for datasource " + dsName)
-				}
-			} else if ds.VersioningPolicyName == "" && !initialVersioningEmpty {
-				if e := removeFullVersioningJob(ctx, dsName); e != nil {
-					log.Logger(ctx).Warn("Could not insert full versioning job for datasource " + dsName)
-				}
+	if err := config.Save(u, "Create DataSource"); err != nil {
+		return err
+	}
+	eventType := object.DataSourceEvent_CREATE
+	if update {
+		eventType = object.DataSourceEvent_UPDATE
+		if initialVersioningEmpty && ds.VersioningPolicyName != "" {
+			if e := createFullVersioningJob(ctx, dsName); e != nil {
+				log.Logger(ctx).Warn("Could not insert full versioning job for datasource " + dsName)
+			}
+		} else if ds.VersioningPolicyName == "" && !initialVersioningEmpty {
+			if e := removeFullVersioningJob(ctx, dsName); e != nil {
+				log.Logger(ctx).Warn("Could not insert full versioning job for datasource " + dsName)
 			}
 		}
+	}
 
-		if err = client.Publish(ctx, client.NewPublication(common.TopicDatasourceEvent, &object.DataSourceEvent{
-			Name:   dsName,
-			Type:   eventType,
-			Config: &ds,
-		})); err != nil {
-			log.Logger(ctx).Warn("could not notify the new data source creation", zap.Error(err))
-		}
-
-		err = resp.WriteEntity(&ds)
-		if err != nil {
-			log.Logger(ctx).Warn("could not write response", zap.Error(err))
-		}
-
-	} else {
-		service.RestError500(req, resp, err)
+	if err := client.Publish(ctx, client.NewPublication(common.TopicDatasourceEvent, &object.DataSourceEvent{
+		Name:   dsName,
+		Type:   eventType,
+		Config: ds,
+	})); err != nil {
+		log.Logger(ctx).Warn("could not notify the new data source creation", zap.Error(err))
 	}
+	return nil
 
 }
 
 func (s *Handler) DeleteDataSource(req *restful.Request, resp *restful.Response) {
 
-	ctx := req.Request.Context()
 	dsName := req.PathParameter("Name")
-	if dsName == "" {
-		service.RestError500(req, resp, fmt.Errorf("Please provide a data source name"))
+	if e := s.RemoveDataSource(req.Request.Context(), dsName); e != nil {
+		service.RestError500(req, resp, e)
 		return
 	}
+	resp.WriteEntity(&rest.DeleteDataSourceResponse{
+		Success: true,
+	})
+}
+
+// RemoveDataSource deletes the services configurations of a datasource that is not used by any workspace,
+// and notifies the services of the change.
+func (s *Handler) RemoveDataSource(ctx context.Context, dsName string) error {
+
+	if dsName == "" {
+		return fmt.Errorf("Please provide a data source name")
+	}
 	if dsName == config.Get("defaults", "datasource").String() {
-		service.RestError500(req, resp, fmt.Errorf("This is the default datasource! Please replace it in your config file before trying to delete."))
-		return
+		return fmt.Errorf("This is the default datasource! Please replace it in your config file before trying to delete.")
 	}
-	hasWorkspace, err := s.findWorkspacesForDatasource(req.Request.Context(), dsName)
+	hasWorkspace, err := s.findWorkspacesForDatasource(ctx, dsName)
 	if err != nil {
-		service.RestError500(req, resp, fmt.Errorf("Error while trying to find workspaces for datasource:"+err.Error()))
-		return
+		return fmt.Errorf("Error while trying to find workspaces for datasource: %s", err.Error())
 	} else if hasWorkspace {
-		service.RestError500(req, resp, fmt.Errorf("There are workspaces defined on this datasource, please delete them before removing datasource"))
-		return
+		return fmt.Errorf("There are workspaces defined on this datasource, please delete them before removing datasource")
 	}
 	currentSources := config.ListSourcesFromConfig()
 
 	if existingDS, ok := currentSources[dsName]; !ok {
-		service.RestError500(req, resp, fmt.Errorf("Cannot find datasource!"))
-		return
+		return fmt.Errorf("Cannot find datasource!")
 	} else if existingDS.VersioningPolicyName != "" {
 		if e := removeFullVersioningJob(ctx, dsName); e != nil {
 			log.Logger(ctx).Warn("Error while removing full versioning job on ds deletion", zap.Error(e))
@@ -248,27 +275,24 @@ func (s *Handler) DeleteDataSource(req *restful.Request, resp *restful.Response)
 		config.MinioConfigNamesToConfig(currentMinios)
 	}
 
-	u, _ := permissions.FindUserNameInContext(req.Request.Context())
+	u, _ := permissions.FindUserNameInContext(ctx)
 	if u == "" {
 		u = "rest"
 	}
 	if e := config.Save(u, "Delete DataSource"); e != nil {
-		service.RestError500(req, resp, e)
-		return
+		return e
 	}
 	cl := defaults.NewClient()
-	cl.Publish(req.Request.Context(), cl.NewPublication(common.TopicDatasourceEvent, &object.DataSourceEvent{
+	cl.Publish(ctx, cl.NewPublication(common.TopicDatasourceEvent, &object.DataSourceEvent{
 		Name: dsName,
 		Type: object.DataSourceEvent_DELETE,
 	}))
-	resp.WriteEntity(&rest.DeleteDataSourceResponse{
-		Success: true,
-	})
+	return nil
 }
 
 func (s *Handler) ListDataSources(req *restful.Request, resp *restful.Response) {
 
-	if sources, err := s.getDataSources(req.Request.Context()); err != nil {
+	if sources, err := s.GetDataSources(req.Request.Context()); err != nil {
 		service.RestError500(req, resp, err)
 
 	} else {
@@ -339,7 +363,8 @@ func (s *Handler) ListStorageBuckets(req *restful.Request, resp *restful.Respons
 
 }
 
-func (s *Handler) getDataSources(ctx context.Context) ([]*object.DataSource, error) {
+// GetDataSources lists all datasources as exposed by the API.
+func (s *Handler) GetDataSources(ctx context.Context) ([]*object.DataSource, error) {
 
 	sources := config.SourceNamesForDataServices(common.ServiceDataIndex)
 	var dataSources []*object.DataSource
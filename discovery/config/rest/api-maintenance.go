@@ -0,0 +1,150 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package rest
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/emicklei/go-restful"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/config"
+	"github.com/pydio/cells/common/proto/rest"
+	"github.com/pydio/cells/common/service"
+	"github.com/pydio/cells/common/utils/permissions"
+)
+
+/*********************************
+DATASOURCES MAINTENANCE WINDOWS
+*********************************/
+
+// ListDataSourcesMaintenance lists maintenance windows of all datasources.
+func (s *Handler) ListDataSourcesMaintenance(req *restful.Request, resp *restful.Response) {
+	response := &rest.DataSourcesMaintenanceCollection{}
+	for _, name := range config.SourceNamesForDataServices(common.ServiceDataSync) {
+		if windows := config.GetDataSourceMaintenance(name); len(windows) > 0 {
+			response.DataSources = append(response.DataSources, newMaintenanceCollection(name, windows))
+		}
+	}
+	resp.WriteEntity(response)
+}
+
+// GetDataSourceMaintenance lists maintenance windows of one datasource.
+func (s *Handler) GetDataSourceMaintenance(req *restful.Request, resp *restful.Response) {
+	dsName := req.PathParameter("Name")
+	if ds, e := s.loadDataSource(req.Request.Context(), dsName); e != nil || ds == nil {
+		service.RestError404(req, resp, fmt.Errorf("unknown datasource [%s]", dsName))
+		return
+	}
+	resp.WriteEntity(newMaintenanceCollection(dsName, config.GetDataSourceMaintenance(dsName)))
+}
+
+// PutDataSourceMaintenance replaces all the maintenance windows of a datasource.
+func (s *Handler) PutDataSourceMaintenance(req *restful.Request, resp *restful.Response) {
+	ctx := req.Request.Context()
+	dsName := req.PathParameter("Name")
+	var input rest.PutDataSourceMaintenanceRequest
+	if e := req.ReadEntity(&input); e != nil {
+		service.RestError500(req, resp, e)
+		return
+	}
+	if ds, e := s.loadDataSource(ctx, dsName); e != nil || ds == nil {
+		service.RestError404(req, resp, fmt.Errorf("unknown datasource [%s]", dsName))
+		return
+	}
+	var windows []*config.DataSourceMaintenance
+	for _, w := range input.Windows {
+		window, e := maintenanceFromRest(w)
+		if e == nil {
+			e = window.Validate()
+		}
+		if e != nil {
+			service.RestError400(req, resp, e)
+			return
+		}
+		windows = append(windows, window)
+	}
+	u, _ := permissions.FindUserNameInContext(ctx)
+	if e := config.SetDataSourceMaintenance(dsName, windows, u); e != nil {
+		service.RestError500(req, resp, e)
+		return
+	}
+	resp.WriteEntity(newMaintenanceCollection(dsName, windows))
+}
+
+// DeleteDataSourceMaintenance removes all the maintenance windows of a datasource.
+func (s *Handler) DeleteDataSourceMaintenance(req *restful.Request, resp *restful.Response) {
+	ctx := req.Request.Context()
+	dsName := req.PathParameter("Name")
+	windows := config.GetDataSourceMaintenance(dsName)
+	if len(windows) == 0 {
+		service.RestError404(req, resp, fmt.Errorf("no maintenance scheduled on datasource [%s]", dsName))
+		return
+	}
+	u, _ := permissions.FindUserNameInContext(ctx)
+	if e := config.SetDataSourceMaintenance(dsName, nil, u); e != nil {
+		service.RestError500(req, resp, e)
+		return
+	}
+	resp.WriteEntity(&rest.DeleteResponse{Success: true, NumRows: int64(len(windows))})
+}
+
+func newMaintenanceCollection(dsName string, windows []*config.DataSourceMaintenance) *rest.DataSourceMaintenanceCollection {
+	c := &rest.DataSourceMaintenanceCollection{
+		DataSource: dsName,
+		Windows:    []*rest.DataSourceMaintenance{},
+	}
+	for _, w := range windows {
+		c.Windows = append(c.Windows, maintenanceToRest(w))
+	}
+	if active := config.ActiveMaintenance(windows, time.Now()); active != nil {
+		c.Active = maintenanceToRest(active)
+	}
+	return c
+}
+
+func maintenanceToRest(w *config.DataSourceMaintenance) *rest.DataSourceMaintenance {
+	r := &rest.DataSourceMaintenance{Mode: string(w.Mode), Message: w.Message}
+	if !w.Start.IsZero() {
+		r.Start = w.Start.Format(time.RFC3339)
+	}
+	if !w.End.IsZero() {
+		r.End = w.End.Format(time.RFC3339)
+	}
+	return r
+}
+
+func maintenanceFromRest(r *rest.DataSourceMaintenance) (*config.DataSourceMaintenance, error) {
+	w := &config.DataSourceMaintenance{Mode: config.MaintenanceMode(r.Mode), Message: r.Message}
+	var e error
+	if r.Start != "" {
+		if w.Start, e = time.Parse(time.RFC3339, r.Start); e != nil {
+			return nil, fmt.Errorf("invalid window start: %v", e)
+		}
+	}
+	if r.End != "" {
+		if w.End, e = time.Parse(time.RFC3339, r.End); e != nil {
+			return nil, fmt.Errorf("invalid window end: %v", e)
+		}
+	}
+	return w, nil
+}
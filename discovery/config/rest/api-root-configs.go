@@ -69,7 +69,8 @@ func (s *Handler) PutConfig(req *restful.Request, resp *restful.Response) {
 			original = o
 			config.Del(path...)
 		}
-		config.Set(parsed, path...)
+		// Values masked by GetConfig are sent back as is, restore them
+		config.Set(config.Unredact(parsed, original, path...), path...)
 		if err := config.Save(u, "Setting config via API"); err != nil {
 			log.Logger(ctx).Error("Put", zap.Error(err))
 			service.RestError500(req, resp, err)
@@ -83,7 +84,7 @@ func (s *Handler) PutConfig(req *restful.Request, resp *restful.Response) {
 		// Reload new data
 		resp.WriteEntity(&rest.Configuration{
 			FullPath: configuration.FullPath,
-			Data:     config.Get(path...).String(),
+			Data:     redactedConfig(path),
 		})
 	} else {
 		service.RestError500(req, resp, e)
@@ -104,7 +105,7 @@ func (s *Handler) GetConfig(req *restful.Request, resp *restful.Response) {
 		return
 	}
 
-	data := config.Get(path...).String()
+	data := redactedConfig(path)
 
 	output := &rest.Configuration{
 		FullPath: fullPath,
@@ -132,3 +133,23 @@ func (s *Handler) logPluginEnabled(ctx context.Context, cPath string, conf map[s
 		log.Auditer(ctx).Info("Disabling plugin " + cPath)
 	}
 }
+
+// redactedConfig returns the configuration stored at path with its secrets masked. Secret references
+// are not resolved.
+func redactedConfig(path []string) string {
+	var raw interface{}
+	if len(path) == 0 {
+		raw = config.Get().Map()
+	} else {
+		raw = config.Get(path[:len(path)-1]...).Map()[path[len(path)-1]]
+	}
+	switch v := config.Redact(raw, path...).(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	default:
+		data, _ := json.Marshal(v)
+		return string(data)
+	}
+}
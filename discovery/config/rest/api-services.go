@@ -68,7 +68,7 @@ func (h *Handler) ListServices(req *restful.Request, resp *restful.Response) {
 	}
 
 	disabledDss := map[string]struct{}{}
-	if dss, e := h.getDataSources(req.Request.Context()); e == nil {
+	if dss, e := h.GetDataSources(req.Request.Context()); e == nil {
 		for _, ds := range dss {
 			if ds.Disabled {
 				disabledDss[common.ServiceGrpcNamespace_+common.ServiceDataIndex_+ds.Name] = struct{}{}
@@ -21,21 +21,38 @@
 package rest
 
 import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
 	json "github.com/pydio/cells/x/jsonx"
 
 	"github.com/emicklei/go-restful"
+	"github.com/micro/go-micro/errors"
+	"go.uber.org/zap"
 
 	"github.com/pydio/cells/common"
 	"github.com/pydio/cells/common/config"
+	"github.com/pydio/cells/common/log"
 	defaults "github.com/pydio/cells/common/micro"
 	"github.com/pydio/cells/common/proto/docstore"
 	"github.com/pydio/cells/common/proto/rest"
 	"github.com/pydio/cells/common/proto/tree"
 	"github.com/pydio/cells/common/service"
 	"github.com/pydio/cells/common/utils/i18n"
+	"github.com/pydio/cells/common/utils/permissions"
+	"github.com/pydio/cells/data/versions"
 	"github.com/pydio/cells/discovery/config/lang"
 )
 
+const (
+	// defaultSimulationMaxNodes is the number of files scanned by a simulation when the request does not set it
+	defaultSimulationMaxNodes = 1000
+)
+
 /****************************
 VERSIONING POLICIES MANAGEMENT
 *****************************/
@@ -89,3 +106,224 @@ func (s *Handler) GetVersioningPolicy(req *restful.Request, resp *restful.Respon
 		}
 	}
 }
+
+// PutVersioningPolicy validates and stores a policy. Versioning actions reload policies from their cache,
+// so changes may take up to one hour to apply to running datasources.
+func (s *Handler) PutVersioningPolicy(req *restful.Request, resp *restful.Response) {
+	var policy tree.VersioningPolicy
+	if e := req.ReadEntity(&policy); e != nil {
+		service.RestError400(req, resp, e)
+		return
+	}
+	policyId := req.PathParameter("Uuid")
+	if policy.Uuid == "" {
+		policy.Uuid = policyId
+	} else if policy.Uuid != policyId {
+		service.RestError400(req, resp, fmt.Errorf("policy identifier does not match the request path"))
+		return
+	}
+	if e := s.StoreVersioningPolicy(req.Request.Context(), &policy); e != nil {
+		service.RestErrorDetect(req, resp, e)
+		return
+	}
+	resp.WriteEntity(&policy)
+}
+
+// StoreVersioningPolicy validates and stores a policy in the docstore.
+func (s *Handler) StoreVersioningPolicy(ctx context.Context, policy *tree.VersioningPolicy) error {
+	if e := versions.ValidatePolicy(policy); e != nil {
+		return e
+	}
+	data, e := json.Marshal(policy)
+	if e != nil {
+		return e
+	}
+	u, _ := permissions.FindUserNameInContext(ctx)
+	dc := docstore.NewDocStoreClient(common.ServiceGrpcNamespace_+common.ServiceDocStore, defaults.NewClient())
+	if _, e := dc.PutDocument(ctx, &docstore.PutDocumentRequest{
+		StoreID:    common.DocStoreIdVersioningPolicies,
+		DocumentID: policy.Uuid,
+		Document: &docstore.Document{
+			ID:    policy.Uuid,
+			Owner: u,
+			Type:  docstore.DocumentType_JSON,
+			Data:  string(data),
+		},
+	}); e != nil {
+		return e
+	}
+	log.Logger(ctx).Info("Stored versioning policy "+policy.Uuid, policy.Zap())
+	return nil
+}
+
+// DeleteVersioningPolicy removes a policy, unless a datasource still uses it.
+func (s *Handler) DeleteVersioningPolicy(req *restful.Request, resp *restful.Response) {
+	ctx := req.Request.Context()
+	policyId := req.PathParameter("Uuid")
+	dc := docstore.NewDocStoreClient(common.ServiceGrpcNamespace_+common.ServiceDocStore, defaults.NewClient())
+	if _, e := dc.GetDocument(ctx, &docstore.GetDocumentRequest{
+		StoreID:    common.DocStoreIdVersioningPolicies,
+		DocumentID: policyId,
+	}); e != nil {
+		service.RestError404(req, resp, e)
+		return
+	}
+	if e := s.RemoveVersioningPolicy(ctx, policyId); e != nil {
+		service.RestErrorDetect(req, resp, e)
+		return
+	}
+	resp.WriteEntity(&rest.DeleteResponse{Success: true, NumRows: 1})
+}
+
+// RemoveVersioningPolicy deletes a policy from the docstore, unless a datasource still uses it.
+func (s *Handler) RemoveVersioningPolicy(ctx context.Context, policyId string) error {
+	if used := datasourcesForPolicy(policyId); len(used) > 0 {
+		return errors.Conflict(common.ServiceRestNamespace_+common.ServiceConfig, "This policy is used by the following datasources: %s. Please change their versioning policy before deleting it.", strings.Join(used, ", "))
+	}
+	dc := docstore.NewDocStoreClient(common.ServiceGrpcNamespace_+common.ServiceDocStore, defaults.NewClient())
+	if _, e := dc.DeleteDocuments(ctx, &docstore.DeleteDocumentsRequest{
+		StoreID:    common.DocStoreIdVersioningPolicies,
+		DocumentID: policyId,
+	}); e != nil {
+		return e
+	}
+	log.Logger(ctx).Info("Deleted versioning policy " + policyId)
+	return nil
+}
+
+// SimulateVersioningPolicy applies a candidate policy to the versions of the files of some datasources,
+// without removing anything, and lists the versions that would be pruned.
+func (s *Handler) SimulateVersioningPolicy(req *restful.Request, resp *restful.Response) {
+	var input rest.VersioningSimulationRequest
+	if e := req.ReadEntity(&input); e != nil {
+		service.RestError400(req, resp, e)
+		return
+	}
+	policy := input.Policy
+	if policy == nil {
+		service.RestError400(req, resp, fmt.Errorf("please provide a candidate policy"))
+		return
+	}
+	policyId := req.PathParameter("Uuid")
+	if policy.Uuid == "" {
+		policy.Uuid = policyId
+	}
+	if e := versions.ValidatePolicy(policy); e != nil {
+		service.RestErrorDetect(req, resp, e)
+		return
+	}
+	sources := input.DataSources
+	if len(sources) == 0 {
+		sources = datasourcesForPolicy(policyId)
+	}
+	if len(sources) == 0 {
+		service.RestError400(req, resp, fmt.Errorf("no datasource uses this policy, please provide the datasources to scan"))
+		return
+	}
+	known := config.ListSourcesFromConfig()
+	for _, dsName := range sources {
+		if _, ok := known[dsName]; !ok {
+			service.RestError404(req, resp, fmt.Errorf("cannot find datasource %s", dsName))
+			return
+		}
+	}
+	maxNodes := input.MaxNodes
+	if maxNodes <= 0 {
+		maxNodes = defaultSimulationMaxNodes
+	}
+
+	ctx := req.Request.Context()
+	cl := defaults.NewClient()
+	treeClient := tree.NewNodeProviderClient(common.ServiceGrpcNamespace_+common.ServiceTree, cl)
+	versionClient := tree.NewNodeVersionerClient(common.ServiceGrpcNamespace_+common.ServiceVersions, cl)
+	now := time.Now()
+	result := &rest.VersioningSimulation{}
+
+scan:
+	for _, dsName := range sources {
+		streamer, e := treeClient.ListNodes(ctx, &tree.ListNodesRequest{
+			Node:       &tree.Node{Path: dsName},
+			Recursive:  true,
+			FilterType: tree.NodeType_LEAF,
+		})
+		if e != nil {
+			service.RestErrorDetect(req, resp, e)
+			return
+		}
+		for {
+			r, er := streamer.Recv()
+			if er != nil {
+				break
+			}
+			node := r.GetNode()
+			if path.Base(node.GetPath()) == common.PydioSyncHiddenFile {
+				continue
+			}
+			if result.ScannedNodes >= maxNodes {
+				result.Truncated = true
+				streamer.Close()
+				break scan
+			}
+			result.ScannedNodes++
+			logs, er := listNodeVersions(ctx, versionClient, node)
+			if er != nil {
+				log.Logger(ctx).Warn("Cannot list versions for node", node.Zap(), zap.Error(er))
+				continue
+			}
+			if len(logs) == 0 {
+				continue
+			}
+			result.VersionedNodes++
+			result.TotalVersions += int32(len(logs))
+			pruned, er := versions.PruneByPolicy(policy, now, logs)
+			if er != nil {
+				service.RestError500(req, resp, er)
+				streamer.Close()
+				return
+			}
+			if len(pruned) == 0 {
+				continue
+			}
+			sn := &rest.SimulatedNode{Uuid: node.Uuid, Path: node.Path, Versions: int32(len(logs))}
+			for _, v := range pruned {
+				sn.Pruned = append(sn.Pruned, &rest.SimulatedVersion{VersionId: v.Uuid, MTime: v.MTime, Size: v.Size})
+				result.PrunedSize += v.Size
+			}
+			sort.Slice(sn.Pruned, func(i, j int) bool {
+				return sn.Pruned[i].MTime > sn.Pruned[j].MTime
+			})
+			result.PrunedVersions += int32(len(pruned))
+			result.Nodes = append(result.Nodes, sn)
+		}
+		streamer.Close()
+	}
+
+	resp.WriteEntity(result)
+}
+
+// datasourcesForPolicy lists the names of the datasources using a versioning policy.
+func datasourcesForPolicy(policyId string) (names []string) {
+	for name, ds := range config.ListSourcesFromConfig() {
+		if ds.VersioningPolicyName == policyId {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return
+}
+
+func listNodeVersions(ctx context.Context, cl tree.NodeVersionerClient, node *tree.Node) (logs []*tree.ChangeLog, e error) {
+	st, e := cl.ListVersions(ctx, &tree.ListVersionsRequest{Node: node})
+	if e != nil {
+		return nil, e
+	}
+	defer st.Close()
+	for {
+		r, er := st.Recv()
+		if er != nil {
+			break
+		}
+		logs = append(logs, r.GetVersion())
+	}
+	return
+}
@@ -21,22 +21,38 @@
 package rest
 
 import (
-	"github.com/emicklei/go-restful"
+	"context"
+	"fmt"
+	"io"
+	"strings"
 
+	"github.com/emicklei/go-restful"
 	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/micro/go-micro/errors"
+
 	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/auth"
+	"github.com/pydio/cells/common/auth/claim"
+	"github.com/pydio/cells/common/config"
+	"github.com/pydio/cells/common/log"
 	"github.com/pydio/cells/common/micro"
 	"github.com/pydio/cells/common/proto/docstore"
+	"github.com/pydio/cells/common/proto/idm"
 	"github.com/pydio/cells/common/proto/rest"
 	"github.com/pydio/cells/common/proto/tree"
 	"github.com/pydio/cells/common/service"
+	serviceproto "github.com/pydio/cells/common/service/proto"
+	"github.com/pydio/cells/common/utils/permissions"
+	"github.com/pydio/cells/common/views"
 )
 
 /****************************
-VERSIONING POLICIES MANAGEMENT
+TEMPLATE PATHS MANAGEMENT
 *****************************/
 
-// ListVersioningPolicies list all defined policies.
+// ListVirtualNodes list all defined template paths.
 func (s *Handler) ListVirtualNodes(req *restful.Request, resp *restful.Response) {
 	//T := lang.Bundle().GetTranslationFunc(utils.UserLanguagesFromRestRequest(req)...)
 	dc := docstore.NewDocStoreClient(common.ServiceGrpcNamespace_+common.ServiceDocStore, defaults.NewClient())
@@ -61,3 +77,204 @@ func (s *Handler) ListVirtualNodes(req *restful.Request, resp *restful.Response)
 	}
 	resp.WriteEntity(response)
 }
+
+// PutVirtualNode validates and stores a template path.
+func (s *Handler) PutVirtualNode(req *restful.Request, resp *restful.Response) {
+	var vNode tree.Node
+	if e := req.ReadEntity(&vNode); e != nil {
+		service.RestError400(req, resp, e)
+		return
+	}
+	nodeId := req.PathParameter("Uuid")
+	if vNode.Uuid == "" {
+		vNode.Uuid = nodeId
+	} else if vNode.Uuid != nodeId {
+		service.RestError400(req, resp, fmt.Errorf("template path identifier does not match the request path"))
+		return
+	}
+	if e := s.StoreVirtualNode(req.Request.Context(), &vNode); e != nil {
+		service.RestErrorDetect(req, resp, e)
+		return
+	}
+	resp.WriteEntity(&vNode)
+}
+
+// StoreVirtualNode validates a template path and stores it in the docstore.
+func (s *Handler) StoreVirtualNode(ctx context.Context, vNode *tree.Node) error {
+	if vNode.Type == tree.NodeType_UNKNOWN {
+		vNode.Type = tree.NodeType_COLLECTION
+	}
+	if e := views.ValidateVirtualNode(vNode); e != nil {
+		return e
+	}
+	manager := views.GetVirtualNodesManager()
+	manager.Load(true)
+	if other, ok := manager.ByPath(vNode.Path); ok && other.Uuid != vNode.Uuid {
+		return errors.Conflict(common.ServiceRestNamespace_+common.ServiceConfig, "path %s is already used by template path %s", vNode.Path, other.Uuid)
+	}
+	for _, ds := range config.SourceNamesForDataServices(common.ServiceDataSync) {
+		if ds == strings.Trim(vNode.Path, "/") {
+			return errors.Conflict(common.ServiceRestNamespace_+common.ServiceConfig, "path %s is already used by a datasource", vNode.Path)
+		}
+	}
+	m := &jsonpb.Marshaler{}
+	data, e := m.MarshalToString(vNode)
+	if e != nil {
+		return e
+	}
+	u, _ := permissions.FindUserNameInContext(ctx)
+	dc := docstore.NewDocStoreClient(common.ServiceGrpcNamespace_+common.ServiceDocStore, defaults.NewClient())
+	if _, e := dc.PutDocument(ctx, &docstore.PutDocumentRequest{
+		StoreID:    common.DocStoreIdVirtualNodes,
+		DocumentID: vNode.Uuid,
+		Document: &docstore.Document{
+			ID:    vNode.Uuid,
+			Owner: u,
+			Data:  data,
+		},
+	}); e != nil {
+		return e
+	}
+	manager.Load(true)
+	log.Logger(ctx).Info("Stored template path " + vNode.Uuid)
+	return nil
+}
+
+// DeleteVirtualNode removes a template path.
+func (s *Handler) DeleteVirtualNode(req *restful.Request, resp *restful.Response) {
+	ctx := req.Request.Context()
+	nodeId := req.PathParameter("Uuid")
+	dc := docstore.NewDocStoreClient(common.ServiceGrpcNamespace_+common.ServiceDocStore, defaults.NewClient())
+	if _, e := dc.GetDocument(ctx, &docstore.GetDocumentRequest{
+		StoreID:    common.DocStoreIdVirtualNodes,
+		DocumentID: nodeId,
+	}); e != nil {
+		service.RestError404(req, resp, e)
+		return
+	}
+	if e := s.RemoveVirtualNode(ctx, nodeId); e != nil {
+		service.RestErrorDetect(req, resp, e)
+		return
+	}
+	resp.WriteEntity(&rest.DeleteResponse{Success: true, NumRows: 1})
+}
+
+// RemoveVirtualNode deletes a template path from the docstore, unless it is used as a workspace root.
+func (s *Handler) RemoveVirtualNode(ctx context.Context, nodeId string) error {
+	used, e := workspacesForVirtualNode(ctx, nodeId)
+	if e != nil {
+		return e
+	}
+	if len(used) > 0 {
+		return errors.Conflict(common.ServiceRestNamespace_+common.ServiceConfig, "This template path is used as root of the following workspaces: %s. Please change their roots before deleting it.", strings.Join(used, ", "))
+	}
+	dc := docstore.NewDocStoreClient(common.ServiceGrpcNamespace_+common.ServiceDocStore, defaults.NewClient())
+	if _, e := dc.DeleteDocuments(ctx, &docstore.DeleteDocumentsRequest{
+		StoreID:    common.DocStoreIdVirtualNodes,
+		DocumentID: nodeId,
+	}); e != nil {
+		return e
+	}
+	views.GetVirtualNodesManager().Load(true)
+	log.Logger(ctx).Info("Deleted template path " + nodeId)
+	return nil
+}
+
+// PreviewVirtualNode resolves a stored or candidate template path for a user, and reports the resolution errors.
+func (s *Handler) PreviewVirtualNode(req *restful.Request, resp *restful.Response) {
+	ctx := req.Request.Context()
+	var input rest.VirtualNodePreviewRequest
+	if e := req.ReadEntity(&input); e != nil {
+		service.RestError400(req, resp, e)
+		return
+	}
+	if input.Login == "" {
+		service.RestError400(req, resp, fmt.Errorf("please provide the login of a user"))
+		return
+	}
+	nodeId := req.PathParameter("Uuid")
+	vNode := input.Node
+	if vNode != nil {
+		if vNode.Uuid == "" {
+			vNode.Uuid = nodeId
+		}
+	} else {
+		manager := views.GetVirtualNodesManager()
+		manager.Load(true)
+		stored, ok := manager.ByUuid(nodeId)
+		if !ok {
+			service.RestError404(req, resp, fmt.Errorf("cannot find template path %s", nodeId))
+			return
+		}
+		vNode = stored
+	}
+	user, e := permissions.SearchUniqueUser(ctx, input.Login, "")
+	if e != nil || user == nil {
+		service.RestError404(req, resp, fmt.Errorf("cannot find user %s", input.Login))
+		return
+	}
+	resp.WriteEntity(previewVirtualNode(ctx, vNode, user))
+}
+
+// previewVirtualNode resolves the template path in the context of the user, without creating the resolved node.
+func previewVirtualNode(ctx context.Context, vNode *tree.Node, user *idm.User) *rest.VirtualNodePreview {
+	preview := &rest.VirtualNodePreview{Login: user.Login}
+	if e := views.ValidateVirtualNode(vNode); e != nil {
+		preview.Error = e.Error()
+		return preview
+	}
+	userCtx := auth.WithImpersonate(ctx, user)
+	claims, _ := userCtx.Value(claim.ContextKey).(claim.Claims)
+	pool := views.NewClientsPool(false)
+	resolved, e := views.GetVirtualNodesManager().ResolvePathForClaims(userCtx, vNode, claims, pool)
+	if e != nil {
+		preview.Error = e.Error()
+		return preview
+	}
+	preview.Path = resolved.Path
+	preview.DataSource = resolved.GetStringMeta(common.MetaNamespaceDatasourceName)
+	if strings.Trim(resolved.Path, "/") == "" {
+		preview.Error = "template path resolves to an empty path"
+		return preview
+	}
+	if _, ok := pool.GetDataSources()[preview.DataSource]; !ok {
+		preview.Error = fmt.Sprintf("datasource %s does not exist", preview.DataSource)
+		return preview
+	}
+	if r, e := pool.GetTreeClient().ReadNode(ctx, &tree.ReadNodeRequest{Node: &tree.Node{Path: resolved.Path}}); e == nil && r.GetNode() != nil {
+		preview.NodeExists = true
+	}
+	return preview
+}
+
+// workspacesForVirtualNode lists the workspaces that use a template path as root.
+func workspacesForVirtualNode(ctx context.Context, nodeId string) ([]string, error) {
+	q, e := ptypes.MarshalAny(&idm.ACLSingleQuery{
+		NodeIDs: []string{nodeId},
+		Actions: []*idm.ACLAction{{Name: permissions.AclWsrootActionName}},
+	})
+	if e != nil {
+		return nil, e
+	}
+	cl := idm.NewACLServiceClient(common.ServiceGrpcNamespace_+common.ServiceAcl, defaults.NewClient())
+	st, e := cl.SearchACL(ctx, &idm.SearchACLRequest{Query: &serviceproto.Query{SubQueries: []*any.Any{q}}})
+	if e != nil {
+		return nil, e
+	}
+	defer st.Close()
+	var used []string
+	seen := make(map[string]bool)
+	for {
+		r, e := st.Recv()
+		if e == io.EOF {
+			break
+		} else if e != nil {
+			return nil, e
+		}
+		if ws := r.GetACL().GetWorkspaceID(); ws != "" && !seen[ws] {
+			seen[ws] = true
+			used = append(used, ws)
+		}
+	}
+	return used, nil
+}
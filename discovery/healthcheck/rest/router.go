@@ -27,6 +27,7 @@ import (
 	"os"
 	"sort"
 	"strings"
+	"time"
 
 	json "github.com/pydio/cells/x/jsonx"
 
@@ -34,6 +35,7 @@ import (
 	"github.com/pydio/cells/common/proto/ctl"
 	"github.com/pydio/cells/common/proto/rest"
 	"github.com/pydio/cells/common/registry"
+	"github.com/pydio/cells/common/service/health"
 	cnet "github.com/pydio/cells/common/utils/net"
 )
 
@@ -253,6 +255,51 @@ func serviceToRest(srv registry.Service, running bool) *ctl.Service {
 	return protoSrv
 }
 
+// clusterHealth aggregates the health reports of the processes of this host, or of all processes
+// of the cluster if the global parameter is set. Processes can be filtered by hostname and pid.
+func clusterHealth(readiness bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		params := r.URL.Query()
+
+		hostname, _ := os.Hostname()
+		if params.Get("global") != "" {
+			hostname = params.Get("hostname")
+		}
+		var pids []string
+		if p := params.Get("pid"); p != "" {
+			pids = strings.Split(p, ",")
+		}
+
+		processes := registry.GetProcesses()
+		for id, p := range processes {
+			if hostname != "" && p.Hostname != hostname {
+				delete(processes, id)
+				continue
+			}
+			if len(pids) > 0 {
+				found := false
+				for _, pid := range pids {
+					if p.Id == pid || p.ParentId == pid {
+						found = true
+						break
+					}
+				}
+				if !found {
+					delete(processes, id)
+				}
+			}
+		}
+
+		timeout, _ := time.ParseDuration(params.Get("timeout"))
+		report := health.Collect(r.Context(), registry.HealthTargets(processes), timeout)
+		ok := report.Healthy
+		if readiness {
+			ok = report.Ready
+		}
+		health.WriteJSON(w, report, ok)
+	}
+}
+
 var myRoutes = routes{
 	route{
 		"Index",
@@ -260,4 +307,16 @@ var myRoutes = routes{
 		"/healthcheck",
 		index,
 	},
+	route{
+		"Healthz",
+		"GET",
+		health.HealthzRoute,
+		clusterHealth(false),
+	},
+	route{
+		"Readyz",
+		"GET",
+		health.ReadyzRoute,
+		clusterHealth(true),
+	},
 }
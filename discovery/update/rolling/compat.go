@@ -0,0 +1,62 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package rolling
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+)
+
+// CheckCompatibility verifies that the nodes, given as a map of hostnames to their running version, can be
+// upgraded one by one to target. While rolling, nodes running the current and the target versions serve
+// requests side by side: this is only supported inside the same major version, one minor version at a time.
+func CheckCompatibility(target *version.Version, nodes map[string]string) error {
+	var errs []string
+	for host, v := range nodes {
+		if v == "" {
+			errs = append(errs, fmt.Sprintf("%s does not publish its version and must be upgraded manually", host))
+			continue
+		}
+		current, e := version.NewVersion(v)
+		if e != nil {
+			errs = append(errs, fmt.Sprintf("%s runs an invalid version %s", host, v))
+			continue
+		}
+		if current.GreaterThan(target) {
+			errs = append(errs, fmt.Sprintf("%s runs %s, downgrading to %s is not supported", host, current, target))
+			continue
+		}
+		cs, ts := current.Segments(), target.Segments()
+		if cs[0] != ts[0] {
+			errs = append(errs, fmt.Sprintf("%s runs %s, upgrading to another major version requires stopping the cluster", host, current))
+		} else if ts[1]-cs[1] > 1 {
+			errs = append(errs, fmt.Sprintf("%s runs %s, please upgrade to %d.%d first", host, current, ts[0], cs[1]+1))
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	sort.Strings(errs)
+	return fmt.Errorf("cluster cannot be upgraded to %s: %s", target, strings.Join(errs, ", "))
+}
@@ -0,0 +1,46 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package rolling
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-version"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCheckCompatibility(t *testing.T) {
+	Convey("Test versions compatibility", t, func() {
+		target, _ := version.NewVersion("2.3.0")
+
+		So(CheckCompatibility(target, map[string]string{"node1": "2.2.7", "node2": "2.3.0"}), ShouldBeNil)
+		So(CheckCompatibility(target, map[string]string{"node1": "2.3.0-rc1"}), ShouldBeNil)
+
+		e := CheckCompatibility(target, map[string]string{"node1": "2.1.2", "node2": "2.2.7"})
+		So(e, ShouldNotBeNil)
+		So(e.Error(), ShouldContainSubstring, "node1 runs 2.1.2, please upgrade to 2.2 first")
+		So(e.Error(), ShouldNotContainSubstring, "node2")
+
+		So(CheckCompatibility(target, map[string]string{"node1": "1.6.0"}), ShouldNotBeNil)
+		So(CheckCompatibility(target, map[string]string{"node1": "2.4.0"}), ShouldNotBeNil)
+		So(CheckCompatibility(target, map[string]string{"node1": ""}), ShouldNotBeNil)
+	})
+}
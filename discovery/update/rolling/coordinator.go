@@ -0,0 +1,282 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package rolling
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/go-version"
+	"github.com/pborman/uuid"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/config"
+	"github.com/pydio/cells/common/config/migrations"
+	defaults "github.com/pydio/cells/common/micro"
+	"github.com/pydio/cells/common/registry"
+	"github.com/pydio/cells/common/service/health"
+	"github.com/pydio/cells/common/sql"
+)
+
+const lockName = "pydio-cluster-upgrade"
+
+var (
+	// PollInterval is the delay between two checks of the registry while waiting for a node
+	PollInterval = 5 * time.Second
+	// LockTimeout is the maximum time waited for the cluster lock
+	LockTimeout = 10 * time.Second
+)
+
+// Coordinator upgrades all the nodes of the cluster to the version of the current binary: it checks that
+// the versions of all nodes are compatible, runs the configuration migrations once, then restarts the
+// nodes one by one, waiting for each of them to come back healthy before moving to the next one.
+type Coordinator struct {
+	Version *version.Version
+	// Download asks the nodes to fetch the binary from the update server
+	Download bool
+	// Drain is the time left to each node to complete running requests before it stops
+	Drain time.Duration
+	// NodeTimeout is the maximum time given to a node to restart, after its drain period
+	NodeTimeout time.Duration
+	// Progress is notified each time the status changes
+	Progress func(*Status)
+
+	status *Status
+}
+
+// Run performs the upgrade. It holds a cluster lock so that only one upgrade runs at a time.
+func (c *Coordinator) Run(ctx context.Context) error {
+	driver, dsn := config.GetDatabase("default")
+	unlock, e := sql.LockClusterDatabase(ctx, driver, dsn, lockName, LockTimeout)
+	if e != nil {
+		return fmt.Errorf("another upgrade seems to be running: %v", e)
+	}
+	defer unlock()
+
+	c.status = &Status{
+		Id:        uuid.New(),
+		Version:   c.Version.String(),
+		Phase:     PhaseChecking,
+		StartedAt: time.Now(),
+	}
+	if e := c.run(ctx); e != nil {
+		c.status.Phase = PhaseFailed
+		c.status.Error = e.Error()
+		c.update()
+		return e
+	}
+	c.status.Phase = PhaseDone
+	return c.update()
+}
+
+func (c *Coordinator) run(ctx context.Context) error {
+	nodes := listNodes()
+	versions := make(map[string]string, len(nodes))
+	for host, processes := range nodes {
+		versions[host] = lowestVersion(processes)
+		c.status.Nodes = append(c.status.Nodes, &Node{
+			Hostname:  host,
+			From:      versions[host],
+			Processes: len(processes),
+			State:     NodePending,
+		})
+	}
+	sort.Slice(c.status.Nodes, func(i, j int) bool {
+		return c.status.Nodes[i].Hostname < c.status.Nodes[j].Hostname
+	})
+	if e := c.update(); e != nil {
+		return e
+	}
+	if len(nodes) == 0 {
+		return fmt.Errorf("no running nodes found in the registry")
+	}
+	if e := CheckCompatibility(c.Version, versions); e != nil {
+		return e
+	}
+
+	c.status.Phase = PhaseMigrating
+	if e := c.update(); e != nil {
+		return e
+	}
+	if migrated, e := c.migrate(ctx); e != nil {
+		return e
+	} else {
+		c.status.Migrated = migrated
+	}
+
+	c.status.Phase = PhaseRolling
+	if e := c.update(); e != nil {
+		return e
+	}
+	for _, n := range c.status.Nodes {
+		if n.From == c.Version.String() {
+			c.setNode(n, NodeUpgraded, "")
+			continue
+		}
+		if e := c.upgradeNode(ctx, n); e != nil {
+			c.setNode(n, NodeFailed, e.Error())
+			return fmt.Errorf("upgrade of %s failed, other nodes are left untouched: %v", n.Hostname, e)
+		}
+		c.setNode(n, NodeUpgraded, "")
+	}
+	return nil
+}
+
+// migrate applies the configuration migrations under the same lock as the nodes starting with a new version
+func (c *Coordinator) migrate(ctx context.Context) (bool, error) {
+	driver, dsn := config.GetDatabase("default")
+	unlock, e := sql.LockClusterDatabase(ctx, driver, dsn, migrations.ClusterLock, migrations.ClusterLockTimeout)
+	if e != nil {
+		return false, fmt.Errorf("cannot lock configuration migrations: %v", e)
+	}
+	defer unlock()
+	save, e := migrations.UpgradeConfigsIfRequired(config.Get(), c.Version)
+	if e != nil {
+		return false, fmt.Errorf("configuration migrations failed: %v", e)
+	} else if !save {
+		return false, nil
+	}
+	if e := config.Save(common.PydioSystemUsername, "Configs upgrades applied"); e != nil {
+		return false, e
+	}
+	return true, nil
+}
+
+// upgradeNode asks a node to restart and waits until all its processes run the target version and are healthy
+func (c *Coordinator) upgradeNode(ctx context.Context, n *Node) error {
+	c.setNode(n, NodeDraining, "")
+	if e := Publish(&Command{
+		Id:       c.status.Id,
+		Hostname: n.Hostname,
+		Version:  c.Version.String(),
+		Download: c.Download,
+		Drain:    c.Drain,
+	}); e != nil {
+		return e
+	}
+
+	timeout := time.After(c.Drain + c.NodeTimeout)
+	var lastErr error
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timeout:
+			if lastErr == nil {
+				lastErr = fmt.Errorf("node did not restart")
+			}
+			return fmt.Errorf("timeout after %s: %v", c.NodeTimeout, lastErr)
+		case <-time.After(PollInterval):
+		}
+
+		processes := listNodes()[n.Hostname]
+		if len(processes) == 0 {
+			if n.State == NodeDraining {
+				c.setNode(n, NodeRestarting, "")
+			}
+			lastErr = fmt.Errorf("node is not registered")
+			continue
+		}
+		if v := lowestVersion(processes); v != c.Version.String() {
+			lastErr = fmt.Errorf("node still runs version %s", v)
+			continue
+		}
+		if n.State == NodeDraining {
+			c.setNode(n, NodeRestarting, "")
+		}
+		report := health.Collect(ctx, registry.HealthTargets(processes), health.DefaultTimeout)
+		if !report.Ready {
+			lastErr = fmt.Errorf("node is not ready")
+			for _, p := range report.Processes {
+				if p.Error != "" {
+					lastErr = fmt.Errorf("node is not ready: %s", p.Error)
+					break
+				}
+			}
+			continue
+		}
+		return nil
+	}
+}
+
+func (c *Coordinator) setNode(n *Node, state NodeState, err string) {
+	n.State = state
+	n.Error = err
+	n.UpdatedAt = time.Now()
+	c.update()
+}
+
+func (c *Coordinator) update() error {
+	if c.Progress != nil {
+		c.Progress(c.status)
+	}
+	return c.status.save()
+}
+
+// listNodes lists the processes registered by each node, indexed by hostname
+func listNodes() map[string]map[string]*registry.Process {
+	nodes := make(map[string]map[string]*registry.Process)
+	services, _ := defaults.Registry().ListServices()
+	for _, s := range services {
+		full, err := defaults.Registry().GetService(s.Name)
+		if err != nil {
+			continue
+		}
+		for _, srv := range full {
+			for _, node := range srv.Nodes {
+				p := registry.NewProcess(node)
+				if p == nil {
+					continue
+				}
+				host := p.Hostname
+				if host == "" {
+					host, _, _ = net.SplitHostPort(p.PeerAddress)
+				}
+				if _, ok := nodes[host]; !ok {
+					nodes[host] = make(map[string]*registry.Process)
+				}
+				nodes[host][p.Id] = p
+			}
+		}
+	}
+	return nodes
+}
+
+// lowestVersion finds the oldest version run by the processes, an empty string if one of them is unknown
+func lowestVersion(processes map[string]*registry.Process) string {
+	var lowest *version.Version
+	for _, p := range processes {
+		v, e := version.NewVersion(p.Version)
+		if e != nil {
+			return ""
+		}
+		if lowest == nil || v.LessThan(lowest) {
+			lowest = v
+		}
+	}
+	if lowest == nil {
+		return ""
+	}
+	return lowest.String()
+}
@@ -0,0 +1,143 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package rolling
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/micro/go-micro/broker"
+	"go.uber.org/zap"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/config"
+	"github.com/pydio/cells/common/log"
+	defaults "github.com/pydio/cells/common/micro"
+	proto "github.com/pydio/cells/common/proto/update"
+	"github.com/pydio/cells/common/service/health"
+	"github.com/pydio/cells/discovery/update"
+)
+
+// RestartExitCode is the status of a node exiting to be restarted with a new version, so that supervisors
+// only restarting failed processes (e.g. systemd with Restart=on-failure) start it again.
+const RestartExitCode = 75
+
+// Command asks a node to restart with the target version
+type Command struct {
+	Id       string `json:"id"`
+	Hostname string `json:"hostname"`
+	Version  string `json:"version"`
+	// Download the binary from the update server before restarting, otherwise it is expected
+	// to be already installed on the node.
+	Download bool `json:"download"`
+	// Drain is the time left to the node to complete running requests, while it is reported as not ready
+	Drain time.Duration `json:"drain"`
+}
+
+// Publish sends a command on the broker
+func Publish(c *Command) error {
+	data, e := json.Marshal(c)
+	if e != nil {
+		return e
+	}
+	return defaults.Broker().Publish(common.TopicClusterUpgrade, &broker.Message{Body: data})
+}
+
+// Listen subscribes the current process to the commands targeting this node. Once the new binary is installed,
+// the process is reported as not ready on the health routes during the drain period, then restart is called:
+// it must stop all services gracefully and exit with RestartExitCode, the process being restarted by its
+// supervisor (systemd, docker, kubernetes, etc.) with the new binary.
+func Listen(ctx context.Context, restart func()) error {
+	hostname, _ := os.Hostname()
+	_, e := defaults.Broker().Subscribe(common.TopicClusterUpgrade, func(p broker.Publication) error {
+		var c Command
+		if e := json.Unmarshal(p.Message().Body, &c); e != nil {
+			return e
+		}
+		if c.Hostname != hostname || c.Version == common.Version().String() {
+			return nil
+		}
+		go func() {
+			logger := log.Logger(ctx)
+			if c.Download {
+				logger.Info("Downloading binary for cluster upgrade", zap.String("version", c.Version))
+				if e := download(ctx, c.Version); e != nil {
+					logger.Error("Cannot download binary for cluster upgrade", zap.String("version", c.Version), zap.Error(e))
+					return
+				}
+			}
+			if c.Drain > 0 {
+				logger.Info("Draining node for cluster upgrade", zap.Duration("drain", c.Drain))
+				health.SetDraining(true)
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(c.Drain):
+				}
+			}
+			logger.Info("Restarting node for cluster upgrade", zap.String("version", c.Version))
+			restart()
+		}()
+		return nil
+	})
+	return e
+}
+
+// download replaces the current binary by the one published on the update server for version v
+func download(ctx context.Context, v string) error {
+	configs := config.GetUpdatesConfigs()
+	binaries, e := update.LoadUpdates(ctx, configs, &proto.UpdateRequest{})
+	if e != nil {
+		return e
+	}
+	var pkg *proto.Package
+	for _, b := range binaries {
+		if b.Version == v {
+			pkg = b
+			break
+		}
+	}
+	if pkg == nil {
+		return fmt.Errorf("version %s is not available on the update server", v)
+	}
+
+	pgChan := make(chan float64)
+	doneChan := make(chan bool)
+	errorChan := make(chan error, 1)
+	go update.ApplyUpdate(ctx, pkg, configs, false, pgChan, doneChan, errorChan)
+	for {
+		select {
+		case <-pgChan:
+		case e := <-errorChan:
+			return e
+		case <-doneChan:
+			select {
+			case e := <-errorChan:
+				return e
+			default:
+				return nil
+			}
+		}
+	}
+}
@@ -0,0 +1,108 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+// Package rolling upgrades the nodes of a cluster one by one, keeping the other nodes serving requests.
+package rolling
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/config"
+)
+
+// Phase of a cluster upgrade
+type Phase string
+
+const (
+	PhaseChecking  Phase = "checking"
+	PhaseMigrating Phase = "migrating"
+	PhaseRolling   Phase = "rolling"
+	PhaseDone      Phase = "done"
+	PhaseFailed    Phase = "failed"
+)
+
+// NodeState is the upgrade state of a node
+type NodeState string
+
+const (
+	NodePending    NodeState = "pending"
+	NodeDraining   NodeState = "draining"
+	NodeRestarting NodeState = "restarting"
+	NodeUpgraded   NodeState = "upgraded"
+	NodeFailed     NodeState = "failed"
+)
+
+var statusPath = []string{"cluster", "upgrade"}
+
+// Node reports the progress of the upgrade of one node of the cluster
+type Node struct {
+	Hostname  string    `json:"hostname"`
+	From      string    `json:"from"`
+	Processes int       `json:"processes"`
+	State     NodeState `json:"state"`
+	Error     string    `json:"error,omitempty"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// Status reports the progress of a cluster upgrade. It is stored in the configuration so that
+// it can be read from any node.
+type Status struct {
+	Id        string    `json:"id"`
+	Version   string    `json:"version"`
+	Phase     Phase     `json:"phase"`
+	Migrated  bool      `json:"migrated"`
+	Error     string    `json:"error,omitempty"`
+	StartedAt time.Time `json:"startedAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+	Nodes     []*Node   `json:"nodes"`
+}
+
+// Running tells if the upgrade is still in progress
+func (s *Status) Running() bool {
+	return s.Phase != PhaseDone && s.Phase != PhaseFailed
+}
+
+// LoadStatus reads the status of the last cluster upgrade, nil if no upgrade was ever run.
+func LoadStatus() (*Status, error) {
+	var s *Status
+	if e := config.Get(statusPath...).Scan(&s); e != nil {
+		return nil, e
+	}
+	return s, nil
+}
+
+// save stores the status in the configuration
+func (s *Status) save() error {
+	s.UpdatedAt = time.Now()
+	data, e := json.Marshal(s)
+	if e != nil {
+		return e
+	}
+	var m map[string]interface{}
+	if e := json.Unmarshal(data, &m); e != nil {
+		return e
+	}
+	if e := config.Set(m, statusPath...); e != nil {
+		return e
+	}
+	return config.Save(common.PydioSystemUsername, "Cluster upgrade to "+s.Version+": "+string(s.Phase))
+}
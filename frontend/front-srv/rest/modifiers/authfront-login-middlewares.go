@@ -29,6 +29,7 @@ import (
 	"github.com/pydio/cells/common/proto/rest"
 	"github.com/pydio/cells/common/service/frontend"
 	"github.com/pydio/cells/common/utils/permissions"
+	"github.com/pydio/cells/idm/oauth/guard"
 )
 
 // LoginSuccessWrapper wraps functionalities after user was successfully logged in
@@ -163,6 +164,10 @@ func LoginFailedWrapper(middleware frontend.AuthMiddleware) frontend.AuthMiddlew
 		if err == nil {
 			return nil
 		}
+		// Attempts refused by the login guard are not counted, and tell the user when to retry
+		if guard.IsThrottled(err) {
+			return err
+		}
 
 		//fmt.Println("Login failed with ", err)
 
@@ -10,6 +10,7 @@ import (
 	"github.com/pydio/cells/common/proto/idm"
 	"github.com/pydio/cells/common/proto/rest"
 	"github.com/pydio/cells/common/service/frontend"
+	"github.com/pydio/cells/common/utils/permissions"
 )
 
 func LogoutAuth(middleware frontend.AuthMiddleware) frontend.AuthMiddleware {
@@ -46,6 +47,15 @@ func LogoutAuth(middleware frontend.AuthMiddleware) frontend.AuthMiddleware {
 			return err
 		}
 
+		// Users authenticated by a SAML IdP are redirected to its single logout endpoint
+		if sc, ok := auth.GetSamlConnector(cl.AuthSource); ok && cl.AuthSource != "" {
+			if u, e := permissions.SearchUniqueUser(ctx, cl.Name, ""); e == nil {
+				if logoutURL, e := sc.UserLogoutURL(u, ""); e == nil && logoutURL != "" {
+					out.RedirectTo = logoutURL
+				}
+			}
+		}
+
 		// TODO - need to properly logout in hydra
 		session.Values = make(map[interface{}]interface{})
 		session.Options.MaxAge = 0
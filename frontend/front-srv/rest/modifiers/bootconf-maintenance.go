@@ -0,0 +1,67 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package modifiers
+
+import (
+	"sort"
+	"time"
+
+	"github.com/pydio/cells/common/config"
+	"github.com/pydio/cells/common/service/frontend"
+)
+
+// maintenanceNotice is how long before it opens a maintenance window is announced to users
+const maintenanceNotice = 24 * time.Hour
+
+// MaintenanceBootConfModifier exposes datasources maintenance windows that are opened or about to open to the
+// web interface, which displays them as a banner.
+func MaintenanceBootConfModifier(bootConf *frontend.BootConf) error {
+
+	now := time.Now()
+	var banners []map[string]interface{}
+	for dsName, windows := range config.ListDataSourcesMaintenance() {
+		for _, w := range windows {
+			if !w.Active(now) && !w.Active(now.Add(maintenanceNotice)) {
+				continue
+			}
+			banners = append(banners, map[string]interface{}{
+				"DataSource": dsName,
+				"Mode":       w.Mode,
+				"Active":     w.Active(now),
+				"Start":      w.Start,
+				"End":        w.End,
+				"Message":    w.Message,
+			})
+		}
+	}
+	if len(banners) == 0 {
+		return nil
+	}
+	sort.Slice(banners, func(i, j int) bool {
+		return banners[i]["Start"].(time.Time).Before(banners[j]["Start"].(time.Time))
+	})
+	if bootConf.Other == nil {
+		bootConf.Other = make(map[string]interface{})
+	}
+	bootConf.Other["maintenance"] = banners
+
+	return nil
+}
@@ -43,6 +43,7 @@ var BasePluginsBox = frontend.PluginBox{
 		"access.gateway",
 		"access.homepage",
 		"access.settings",
+		"action.antivirus",
 		"action.avatar",
 		"action.compression",
 		"action.migration",
@@ -93,6 +94,7 @@ func init() {
 
 		frontend.RegisterRegModifier(modifiers.MetaUserRegModifier)
 		frontend.RegisterPluginModifier(modifiers.MobileRegModifier)
+		frontend.RegisterBootConfModifier(modifiers.MaintenanceBootConfModifier)
 
 		frontend.WrapAuthMiddleware(modifiers.LogoutAuth)
 		frontend.WrapAuthMiddleware(modifiers.RefreshAuth)
@@ -9,6 +9,7 @@ import (
 	"github.com/micro/go-micro/metadata"
 	"github.com/micro/go-micro/server"
 	"github.com/spf13/viper"
+	"google.golang.org/grpc/peer"
 
 	"github.com/pydio/cells/common"
 	"github.com/pydio/cells/common/auth"
@@ -192,7 +193,6 @@ func ctxRequestInfoToMetadata(ctx context.Context) context.Context {
 		translate := map[string]string{
 			"user-agent":      servicecontext.HttpMetaUserAgent,
 			"content-type":    servicecontext.HttpMetaContentType,
-			"x-forwarded-for": servicecontext.HttpMetaRemoteAddress,
 			"x-pydio-span-id": servicecontext.SpanMetadataId,
 		}
 		for k, v := range existing {
@@ -206,6 +206,13 @@ func ctxRequestInfoToMetadata(ctx context.Context) context.Context {
 		if ua, ok := existing["x-pydio-grpc-user-agent"]; ok {
 			meta[servicecontext.HttpMetaUserAgent] = ua
 		}
+		var remote string
+		if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+			remote = p.Addr.String()
+		}
+		if addr := servicecontext.ResolveClientAddress(remote, existing["x-real-ip"], existing["x-forwarded-for"]); addr != "" {
+			meta[servicecontext.HttpMetaRemoteAddress] = addr
+		}
 	}
 	meta[servicecontext.HttpMetaExtracted] = servicecontext.HttpMetaExtracted
 	layout := "2006-01-02T15:04-0700"
@@ -127,6 +127,18 @@ var (
 		header_downstream Content-Security-Policy "script-src 'none'"
 		header_downstream X-Content-Security-Policy "sandbox"
 	}
+	pydioproxy /tus {{$.TusService}} {
+		fail_timeout 20s
+		header_upstream Host {{if $ExternalHost}}{{$ExternalHost}}{{else}}{host}{{end}}
+		header_upstream X-Real-IP {remote}
+		header_upstream X-Forwarded-Proto {scheme}
+	}
+	pydioproxy /scim {{$.ScimService}} {
+		fail_timeout 20s
+		header_upstream Host {{if $ExternalHost}}{{$ExternalHost}}{{else}}{host}{{end}}
+		header_upstream X-Real-IP {remote}
+		header_upstream X-Forwarded-Proto {scheme}
+	}
 	
 {{if $.FrontReady}}
 	pydioproxy /plug/ {{$.FrontendService}} {
@@ -219,6 +231,8 @@ var (
 		if {path} not_starts_with "/ws/"
 		if {path} not_starts_with "/plug/"
 		if {path} not_starts_with "/dav"
+		if {path} not_starts_with "/tus"
+		if {path} not_starts_with "/scim"
 		{{range $.PluginPathes}}
 		if {path} not_starts_with "{{.}}"
 		{{end}}
@@ -257,6 +271,8 @@ var (
 		WebSocketService string
 		FrontendService  string
 		WebDAVService    string
+		TusService       string
+		ScimService      string
 		GrpcService      string
 		// Custom webroot - Generally pointing to a non-existing folder
 		WebRoot string
@@ -276,6 +292,8 @@ var (
 		WebSocketService: common.ServiceGatewayNamespace_ + common.ServiceWebSocket,
 		FrontendService:  common.ServiceWebNamespace_ + common.ServiceFrontStatics,
 		WebDAVService:    common.ServiceGatewayDav,
+		TusService:       common.ServiceGatewayTus,
+		ScimService:      common.ServiceWebNamespace_ + common.ServiceScim,
 		GrpcService:      common.ServiceGatewayGrpc,
 	}
 )
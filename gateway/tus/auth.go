@@ -0,0 +1,57 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package tus
+
+import (
+	"net/http"
+	"strings"
+
+	commonauth "github.com/pydio/cells/common/auth"
+	"github.com/pydio/cells/common/log"
+)
+
+// auth validates the JWT passed as a Bearer token, or as access_token query parameter.
+// OPTIONS requests are served without authentication for capabilities discovery.
+func auth(inner http.Handler) http.Handler {
+
+	jwtVerifier := commonauth.DefaultJWTVerifier()
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		if r.Method == http.MethodOptions {
+			inner.ServeHTTP(w, r)
+			return
+		}
+		ctx := r.Context()
+		bearer := r.URL.Query().Get("access_token")
+		if h := r.Header.Get("Authorization"); strings.HasPrefix(h, "Bearer ") {
+			bearer = strings.TrimPrefix(h, "Bearer ")
+		}
+		if len(bearer) > 0 {
+			if c, claims, err := jwtVerifier.Verify(ctx, bearer); err == nil && claims.Name != "" {
+				inner.ServeHTTP(w, r.WithContext(c))
+				return
+			}
+		}
+		log.Logger(ctx).Debug("JWT token validation failed, cannot process tus request")
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+}
@@ -0,0 +1,612 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package tus
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/micro/go-micro/errors"
+	"github.com/pborman/uuid"
+	"github.com/pydio/minio-go"
+	"go.uber.org/zap"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/auth/claim"
+	"github.com/pydio/cells/common/log"
+	"github.com/pydio/cells/common/proto/tree"
+	context2 "github.com/pydio/cells/common/utils/context"
+	"github.com/pydio/cells/common/views"
+	"github.com/pydio/cells/common/views/models"
+)
+
+const (
+	// TusVersion is the only protocol version supported
+	TusVersion = "1.0.0"
+	// TusExtensions lists supported protocol extensions
+	TusExtensions = "creation,termination,checksum,expiration"
+	// TusChecksumAlgorithms lists algorithms supported by the checksum extension
+	TusChecksumAlgorithms = "md5,sha1,sha256"
+
+	// DefaultPartSize is the size of the parts sent to the storage. It must be bigger than the S3 minimum of 5MB.
+	DefaultPartSize = int64(10 * 1024 * 1024)
+	// DefaultExpiration is the time after which an unfinished upload is discarded
+	DefaultExpiration = 24 * time.Hour
+
+	statusChecksumMismatch = 460
+	offsetContentType      = "application/offset+octet-stream"
+)
+
+// Handler serves the tus.io resumable upload protocol on top of the views multipart methods.
+// Received data is buffered on disk until a full part can be sent, so that clients can use any chunk size.
+type Handler struct {
+	// Router is used for all uploads operations on behalf of the authenticated user
+	Router views.Handler
+	// AdminRouter is used to abort expired uploads
+	AdminRouter views.Handler
+	Store       Store
+	BufferDir   string
+	PartSize    int64
+	Expiration  time.Duration
+	// Prefix is the URL path on which the handler is mounted, e.g. /tus/
+	Prefix string
+
+	busyLock sync.Mutex
+	busy     map[string]bool
+}
+
+// NewHandler creates a handler with default part size and expiration.
+func NewHandler(router, adminRouter views.Handler, store Store, bufferDir string) *Handler {
+	return &Handler{
+		Router:      router,
+		AdminRouter: adminRouter,
+		Store:       store,
+		BufferDir:   bufferDir,
+		PartSize:    DefaultPartSize,
+		Expiration:  DefaultExpiration,
+		Prefix:      "/tus/",
+		busy:        make(map[string]bool),
+	}
+}
+
+// ServeHTTP dispatches requests to the protocol methods.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+
+	w.Header().Set("Tus-Resumable", TusVersion)
+	id := strings.Trim(strings.TrimPrefix(r.URL.Path, strings.TrimRight(h.Prefix, "/")), "/")
+
+	if r.Method == http.MethodOptions {
+		h.options(w, r)
+		return
+	}
+	if r.Header.Get("Tus-Resumable") != TusVersion {
+		w.Header().Set("Tus-Version", TusVersion)
+		w.WriteHeader(http.StatusPreconditionFailed)
+		return
+	}
+	switch {
+	case r.Method == http.MethodPost && id == "":
+		h.create(w, r)
+	case r.Method == http.MethodHead && id != "":
+		h.head(w, r, id)
+	case r.Method == http.MethodPatch && id != "":
+		h.patch(w, r, id)
+	case r.Method == http.MethodDelete && id != "":
+		h.terminate(w, r, id)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+
+}
+
+// options describes the server capabilities
+func (h *Handler) options(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Tus-Version", TusVersion)
+	w.Header().Set("Tus-Extension", TusExtensions)
+	w.Header().Set("Tus-Checksum-Algorithm", TusChecksumAlgorithms)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// create registers a new upload and starts the underlying multipart upload.
+func (h *Handler) create(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+	owner := h.userName(ctx)
+	if owner == "" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	length, e := strconv.ParseInt(r.Header.Get("Upload-Length"), 10, 64)
+	if e != nil || length < 0 {
+		http.Error(w, "Missing or invalid Upload-Length header", http.StatusBadRequest)
+		return
+	}
+	meta, e := ParseMetadata(r.Header.Get("Upload-Metadata"))
+	if e != nil {
+		http.Error(w, "Invalid Upload-Metadata header", http.StatusBadRequest)
+		return
+	}
+	targetPath := TargetPath(meta)
+	if targetPath == "" {
+		http.Error(w, "Upload-Metadata must provide a path, or a folder and a filename", http.StatusBadRequest)
+		return
+	}
+
+	now := time.Now()
+	upload := &Upload{
+		ID:       strings.Replace(uuid.New(), "-", "", -1),
+		Owner:    owner,
+		Path:     targetPath,
+		Length:   length,
+		Metadata: meta,
+		Created:  now.Unix(),
+		Expires:  now.Add(h.Expiration).Unix(),
+	}
+	node := &tree.Node{Path: targetPath}
+	if length == 0 {
+		if _, e := h.Router.PutObject(ctx, node, strings.NewReader(""), &models.PutRequestData{Size: 0, Metadata: h.contentMeta(meta)}); e != nil {
+			writeError(w, e)
+			return
+		}
+		upload.Completed = true
+	} else {
+		reqMeta := h.contentMeta(meta)
+		reqMeta[common.XAmzMetaClearSize] = fmt.Sprintf("%d", length)
+		multipartID, e := h.Router.MultipartCreate(ctx, node, &models.MultipartRequestData{Metadata: reqMeta})
+		if e != nil {
+			writeError(w, e)
+			return
+		}
+		upload.MultipartID = multipartID
+		if resp, er := h.Router.ReadNode(ctx, &tree.ReadNodeRequest{Node: &tree.Node{Path: targetPath}}); er == nil {
+			if ds := resp.GetNode().GetStringMeta(common.MetaNamespaceDatasourceName); ds != "" {
+				upload.AdminPath = path.Join(ds, resp.GetNode().GetStringMeta(common.MetaNamespaceDatasourcePath))
+			}
+		}
+	}
+	if e := h.Store.PutUpload(upload); e != nil {
+		writeError(w, e)
+		return
+	}
+	log.Logger(ctx).Debug("Created tus upload", zap.String("id", upload.ID), zap.String("path", targetPath), zap.Int64("length", length))
+
+	w.Header().Set("Location", h.Prefix+upload.ID)
+	h.expiresHeader(w, upload)
+	w.WriteHeader(http.StatusCreated)
+
+}
+
+// head returns the current offset of an upload
+func (h *Handler) head(w http.ResponseWriter, r *http.Request, id string) {
+
+	upload, ok := h.loadUpload(w, r, id)
+	if !ok {
+		return
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Upload-Offset", fmt.Sprintf("%d", upload.Offset))
+	w.Header().Set("Upload-Length", fmt.Sprintf("%d", upload.Length))
+	if len(upload.Metadata) > 0 {
+		w.Header().Set("Upload-Metadata", FormatMetadata(upload.Metadata))
+	}
+	h.expiresHeader(w, upload)
+	w.WriteHeader(http.StatusOK)
+
+}
+
+// patch appends data to the upload, sends full parts to the storage and completes the upload on last chunk.
+func (h *Handler) patch(w http.ResponseWriter, r *http.Request, id string) {
+
+	if r.Header.Get("Content-Type") != offsetContentType {
+		w.WriteHeader(http.StatusUnsupportedMediaType)
+		return
+	}
+	offset, e := strconv.ParseInt(r.Header.Get("Upload-Offset"), 10, 64)
+	if e != nil || offset < 0 {
+		http.Error(w, "Missing or invalid Upload-Offset header", http.StatusBadRequest)
+		return
+	}
+	var hasher hash.Hash
+	var expectedSum []byte
+	if c := r.Header.Get("Upload-Checksum"); c != "" {
+		if hasher, expectedSum, e = parseChecksum(c); e != nil {
+			http.Error(w, e.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	if !h.lock(id) {
+		http.Error(w, "Upload is already being modified", http.StatusLocked)
+		return
+	}
+	defer h.unlock(id)
+
+	upload, ok := h.loadUpload(w, r, id)
+	if !ok {
+		return
+	}
+	if offset != upload.Offset {
+		w.WriteHeader(http.StatusConflict)
+		return
+	}
+	ctx := r.Context()
+	if !upload.Completed && upload.Offset < upload.Length {
+		written, er := h.appendBuffer(upload, r.Body, hasher, expectedSum)
+		if er == errChecksumMismatch {
+			w.WriteHeader(statusChecksumMismatch)
+			return
+		}
+		upload.Offset += written
+		upload.Buffered += written
+		if er != nil {
+			// Keep received data, client will resume from the new offset
+			log.Logger(ctx).Error("Interrupted tus chunk", zap.String("id", id), zap.Int64("received", written), zap.Error(er))
+			if e := h.Store.PutUpload(upload); e != nil {
+				log.Logger(ctx).Error("Cannot store tus upload state", zap.Error(e))
+			}
+			writeError(w, er)
+			return
+		}
+	}
+
+	err := h.flush(ctx, upload)
+	if e := h.Store.PutUpload(upload); e != nil && err == nil {
+		err = e
+	}
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Upload-Offset", fmt.Sprintf("%d", upload.Offset))
+	h.expiresHeader(w, upload)
+	w.WriteHeader(http.StatusNoContent)
+
+}
+
+// terminate aborts an upload and frees all associated resources.
+func (h *Handler) terminate(w http.ResponseWriter, r *http.Request, id string) {
+
+	if !h.lock(id) {
+		http.Error(w, "Upload is already being modified", http.StatusLocked)
+		return
+	}
+	defer h.unlock(id)
+
+	upload, ok := h.loadUpload(w, r, id)
+	if !ok {
+		return
+	}
+	if !upload.Completed {
+		if e := h.Router.MultipartAbort(r.Context(), &tree.Node{Path: upload.Path}, upload.MultipartID, &models.MultipartRequestData{}); e != nil {
+			writeError(w, e)
+			return
+		}
+	}
+	h.removeUpload(upload)
+	w.WriteHeader(http.StatusNoContent)
+
+}
+
+// CleanExpired aborts unfinished uploads whose expiration date is passed, and forgets finished ones.
+func (h *Handler) CleanExpired(ctx context.Context) error {
+
+	expired, e := h.Store.ListExpired(time.Now())
+	if e != nil {
+		return e
+	}
+	sysCtx := context2.WithUserNameMetadata(ctx, common.PydioSystemUsername)
+	for _, upload := range expired {
+		if !upload.Completed && upload.AdminPath != "" && h.AdminRouter != nil {
+			if er := h.AdminRouter.MultipartAbort(sysCtx, &tree.Node{Path: upload.AdminPath}, upload.MultipartID, &models.MultipartRequestData{}); er != nil {
+				log.Logger(ctx).Warn("Cannot abort expired tus upload", zap.String("id", upload.ID), zap.String("path", upload.AdminPath), zap.Error(er))
+			}
+		}
+		h.removeUpload(upload)
+		log.Logger(ctx).Debug("Removed expired tus upload", zap.String("id", upload.ID))
+	}
+	return nil
+
+}
+
+// flush sends all full parts to the storage, and the remaining data as a last part when
+// the whole content is received. The multipart upload is then completed.
+func (h *Handler) flush(ctx context.Context, upload *Upload) error {
+
+	final := upload.Offset == upload.Length
+	if upload.Completed || (upload.Buffered < h.PartSize && !final) {
+		return nil
+	}
+	node := &tree.Node{Path: upload.Path}
+	if upload.Buffered > 0 {
+		f, e := os.Open(h.bufferFile(upload.ID))
+		if e != nil {
+			return e
+		}
+		var sent int64
+		var err error
+		for upload.Buffered-sent >= h.PartSize || (final && upload.Buffered-sent > 0) {
+			size := upload.Buffered - sent
+			if size > h.PartSize {
+				size = h.PartSize
+			}
+			partNumber := len(upload.Parts) + 1
+			part, er := h.Router.MultipartPutObjectPart(ctx, node, upload.MultipartID, partNumber, io.NewSectionReader(f, sent, size), &models.PutRequestData{
+				Size:              size,
+				MultipartUploadID: upload.MultipartID,
+				MultipartPartID:   partNumber,
+			})
+			if er != nil {
+				err = er
+				break
+			}
+			upload.Parts = append(upload.Parts, minio.CompletePart{PartNumber: partNumber, ETag: part.ETag})
+			sent += size
+		}
+		if sent > 0 {
+			if er := h.compactBuffer(f, upload, sent); er != nil && err == nil {
+				err = er
+			}
+		}
+		f.Close()
+		if err != nil {
+			return err
+		}
+	}
+	if !final {
+		return nil
+	}
+	if _, e := h.Router.MultipartComplete(ctx, node, upload.MultipartID, upload.Parts); e != nil {
+		return e
+	}
+	upload.Completed = true
+	os.Remove(h.bufferFile(upload.ID))
+	log.Logger(ctx).Info(fmt.Sprintf("Resumable upload of %s finished (%d parts for a total of %d bytes)", upload.Path, len(upload.Parts), upload.Length))
+	return nil
+
+}
+
+var errChecksumMismatch = fmt.Errorf("checksum mismatch")
+
+// appendBuffer writes the request body at the end of the upload buffer file. If a checksum is provided
+// and does not match, received data is discarded.
+func (h *Handler) appendBuffer(upload *Upload, body io.Reader, hasher hash.Hash, expected []byte) (int64, error) {
+
+	f, e := os.OpenFile(h.bufferFile(upload.ID), os.O_CREATE|os.O_WRONLY, 0600)
+	if e != nil {
+		return 0, e
+	}
+	defer f.Close()
+	// Drop any data written by a request that crashed before its state was saved
+	if e := f.Truncate(upload.Buffered); e != nil {
+		return 0, e
+	}
+	if _, e := f.Seek(upload.Buffered, io.SeekStart); e != nil {
+		return 0, e
+	}
+	var writer io.Writer = f
+	if hasher != nil {
+		writer = io.MultiWriter(f, hasher)
+	}
+	written, e := io.Copy(writer, io.LimitReader(body, upload.Length-upload.Offset))
+	if hasher != nil {
+		if e != nil || string(hasher.Sum(nil)) != string(expected) {
+			f.Truncate(upload.Buffered)
+			if e != nil {
+				return 0, e
+			}
+			return 0, errChecksumMismatch
+		}
+	}
+	return written, e
+
+}
+
+// compactBuffer moves data that was not sent yet at the beginning of the buffer file
+func (h *Handler) compactBuffer(f *os.File, upload *Upload, sent int64) error {
+
+	remaining := upload.Buffered - sent
+	tmpName := h.bufferFile(upload.ID) + ".tmp"
+	tmp, e := os.OpenFile(tmpName, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if e != nil {
+		return e
+	}
+	if _, e := io.Copy(tmp, io.NewSectionReader(f, sent, remaining)); e != nil {
+		tmp.Close()
+		return e
+	}
+	if e := tmp.Close(); e != nil {
+		return e
+	}
+	if e := os.Rename(tmpName, h.bufferFile(upload.ID)); e != nil {
+		return e
+	}
+	upload.Buffered = remaining
+	return nil
+
+}
+
+// loadUpload finds an upload and checks it belongs to the current user. It writes the response in case of error.
+func (h *Handler) loadUpload(w http.ResponseWriter, r *http.Request, id string) (*Upload, bool) {
+	upload, e := h.Store.GetUpload(id)
+	if e != nil || upload.Owner != h.userName(r.Context()) {
+		w.WriteHeader(http.StatusNotFound)
+		return nil, false
+	}
+	if upload.Expired(time.Now()) {
+		w.WriteHeader(http.StatusGone)
+		return nil, false
+	}
+	return upload, true
+}
+
+func (h *Handler) removeUpload(upload *Upload) {
+	os.Remove(h.bufferFile(upload.ID))
+	h.Store.DeleteUpload(upload.ID)
+}
+
+func (h *Handler) bufferFile(id string) string {
+	return filepath.Join(h.BufferDir, id)
+}
+
+func (h *Handler) expiresHeader(w http.ResponseWriter, upload *Upload) {
+	if !upload.Completed && upload.Expires > 0 {
+		w.Header().Set("Upload-Expires", time.Unix(upload.Expires, 0).UTC().Format(http.TimeFormat))
+	}
+}
+
+func (h *Handler) contentMeta(meta map[string]string) map[string]string {
+	m := make(map[string]string)
+	if t, ok := meta["filetype"]; ok && t != "" {
+		m["Content-Type"] = t
+	}
+	return m
+}
+
+func (h *Handler) userName(ctx context.Context) string {
+	if claims, ok := ctx.Value(claim.ContextKey).(claim.Claims); ok {
+		return claims.Name
+	}
+	return ""
+}
+
+func (h *Handler) lock(id string) bool {
+	h.busyLock.Lock()
+	defer h.busyLock.Unlock()
+	if h.busy[id] {
+		return false
+	}
+	h.busy[id] = true
+	return true
+}
+
+func (h *Handler) unlock(id string) {
+	h.busyLock.Lock()
+	defer h.busyLock.Unlock()
+	delete(h.busy, id)
+}
+
+// ParseMetadata decodes an Upload-Metadata header: comma-separated pairs of key and base64 encoded value.
+func ParseMetadata(header string) (map[string]string, error) {
+	meta := make(map[string]string)
+	for _, pair := range strings.Split(header, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		parts := strings.SplitN(pair, " ", 2)
+		var value string
+		if len(parts) == 2 {
+			v, e := base64.StdEncoding.DecodeString(strings.TrimSpace(parts[1]))
+			if e != nil {
+				return nil, e
+			}
+			value = string(v)
+		}
+		meta[parts[0]] = value
+	}
+	return meta, nil
+}
+
+// FormatMetadata encodes metadata as an Upload-Metadata header
+func FormatMetadata(meta map[string]string) string {
+	var keys []string
+	for k := range meta {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var pairs []string
+	for _, k := range keys {
+		if meta[k] == "" {
+			pairs = append(pairs, k)
+		} else {
+			pairs = append(pairs, k+" "+base64.StdEncoding.EncodeToString([]byte(meta[k])))
+		}
+	}
+	return strings.Join(pairs, ",")
+}
+
+// TargetPath computes the upload destination from metadata: either a full "path", or a "folder" and a "filename".
+func TargetPath(meta map[string]string) string {
+	p := meta["path"]
+	if p == "" && meta["filename"] != "" {
+		p = path.Join(meta["folder"], path.Base(meta["filename"]))
+	}
+	if p == "" {
+		return ""
+	}
+	p = strings.Trim(path.Clean("/"+p), "/")
+	if p == "" || p == "." {
+		return ""
+	}
+	return p
+}
+
+// parseChecksum reads an Upload-Checksum header like "sha1 base64sum"
+func parseChecksum(header string) (hash.Hash, []byte, error) {
+	parts := strings.SplitN(strings.TrimSpace(header), " ", 2)
+	if len(parts) != 2 {
+		return nil, nil, fmt.Errorf("invalid Upload-Checksum header")
+	}
+	sum, e := base64.StdEncoding.DecodeString(parts[1])
+	if e != nil {
+		return nil, nil, fmt.Errorf("invalid Upload-Checksum header")
+	}
+	switch parts[0] {
+	case "md5":
+		return md5.New(), sum, nil
+	case "sha1":
+		return sha1.New(), sum, nil
+	case "sha256":
+		return sha256.New(), sum, nil
+	}
+	return nil, nil, fmt.Errorf("unsupported checksum algorithm %s", parts[0])
+}
+
+// writeError converts a micro error to an HTTP status
+func writeError(w http.ResponseWriter, e error) {
+	parsed := errors.Parse(e.Error())
+	code := int(parsed.Code)
+	if parsed.Id == "max.upload.limit" || parsed.Id == "quota.exceeded" {
+		code = http.StatusRequestEntityTooLarge
+	}
+	if code < 400 || code > 599 {
+		code = http.StatusInternalServerError
+	}
+	msg := parsed.Detail
+	if msg == "" {
+		msg = e.Error()
+	}
+	http.Error(w, msg, code)
+}
@@ -0,0 +1,246 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package tus
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/base64"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pydio/minio-go"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/pydio/cells/common/auth/claim"
+	"github.com/pydio/cells/common/proto/tree"
+	"github.com/pydio/cells/common/views"
+	"github.com/pydio/cells/common/views/models"
+)
+
+// multipartMock keeps parts in memory and assembles them on completion
+type multipartMock struct {
+	*views.HandlerMock
+	parts     map[int]string
+	completed map[string]string
+	aborted   []string
+}
+
+func newMultipartMock() *multipartMock {
+	return &multipartMock{HandlerMock: views.NewHandlerMock(), parts: map[int]string{}, completed: map[string]string{}}
+}
+
+func (m *multipartMock) MultipartCreate(ctx context.Context, target *tree.Node, requestData *models.MultipartRequestData) (string, error) {
+	m.parts = map[int]string{}
+	return "multipart-id", nil
+}
+
+func (m *multipartMock) MultipartPutObjectPart(ctx context.Context, target *tree.Node, uploadID string, partNumber int, reader io.Reader, requestData *models.PutRequestData) (minio.ObjectPart, error) {
+	data, e := ioutil.ReadAll(reader)
+	if e != nil {
+		return minio.ObjectPart{}, e
+	}
+	m.parts[partNumber] = string(data)
+	return minio.ObjectPart{PartNumber: partNumber, ETag: "etag", Size: int64(len(data))}, nil
+}
+
+func (m *multipartMock) MultipartComplete(ctx context.Context, target *tree.Node, uploadID string, uploadedParts []minio.CompletePart) (minio.ObjectInfo, error) {
+	content := ""
+	for _, p := range uploadedParts {
+		content += m.parts[p.PartNumber]
+	}
+	m.completed[target.Path] = content
+	return minio.ObjectInfo{Size: int64(len(content))}, nil
+}
+
+func (m *multipartMock) MultipartAbort(ctx context.Context, target *tree.Node, uploadID string, requestData *models.MultipartRequestData) error {
+	m.aborted = append(m.aborted, target.Path)
+	return nil
+}
+
+func (m *multipartMock) PutObject(ctx context.Context, node *tree.Node, reader io.Reader, requestData *models.PutRequestData) (int64, error) {
+	m.completed[node.Path] = ""
+	return 0, nil
+}
+
+func TestMetadata(t *testing.T) {
+	Convey("Test Upload-Metadata parsing", t, func() {
+		meta, e := ParseMetadata("filename d29ybGRfZG9taW5hdGlvbl9wbGFuLnBkZg==,is_confidential, folder bXktZmlsZXMvc3Vi")
+		So(e, ShouldBeNil)
+		So(meta["filename"], ShouldEqual, "world_domination_plan.pdf")
+		So(meta, ShouldContainKey, "is_confidential")
+		So(TargetPath(meta), ShouldEqual, "my-files/sub/world_domination_plan.pdf")
+		So(FormatMetadata(meta), ShouldEqual, "filename d29ybGRfZG9taW5hdGlvbl9wbGFuLnBkZg==,folder bXktZmlsZXMvc3Vi,is_confidential")
+
+		So(TargetPath(map[string]string{"path": "/my-files/../a.txt"}), ShouldEqual, "a.txt")
+		So(TargetPath(map[string]string{"folder": "my-files"}), ShouldEqual, "")
+
+		_, e = ParseMetadata("filename %%%")
+		So(e, ShouldNotBeNil)
+	})
+}
+
+func TestHandler(t *testing.T) {
+
+	Convey("Test tus protocol", t, func() {
+
+		tmpDir, e := ioutil.TempDir("", "tus")
+		So(e, ShouldBeNil)
+		defer os.RemoveAll(tmpDir)
+		store, e := NewBoltStore(filepath.Join(tmpDir, "uploads.db"))
+		So(e, ShouldBeNil)
+		defer store.Close()
+
+		router := newMultipartMock()
+		h := NewHandler(router, router, store, tmpDir)
+		h.PartSize = 10
+
+		do := func(method, url string, headers map[string]string, body string, user string) *httptest.ResponseRecorder {
+			req := httptest.NewRequest(method, url, strings.NewReader(body))
+			req.Header.Set("Tus-Resumable", TusVersion)
+			for k, v := range headers {
+				req.Header.Set(k, v)
+			}
+			if user != "" {
+				req = req.WithContext(context.WithValue(req.Context(), claim.ContextKey, claim.Claims{Name: user}))
+			}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+			return rec
+		}
+		create := func(length string, path string) string {
+			rec := do(http.MethodPost, "/tus/", map[string]string{
+				"Upload-Length":   length,
+				"Upload-Metadata": "path " + base64.StdEncoding.EncodeToString([]byte(path)),
+			}, "", "john")
+			So(rec.Code, ShouldEqual, http.StatusCreated)
+			if length != "0" {
+				So(rec.Header().Get("Upload-Expires"), ShouldNotBeEmpty)
+			}
+			return rec.Header().Get("Location")
+		}
+		patch := func(location string, offset string, body string, checksum string) *httptest.ResponseRecorder {
+			headers := map[string]string{"Content-Type": offsetContentType, "Upload-Offset": offset}
+			if checksum != "" {
+				headers["Upload-Checksum"] = checksum
+			}
+			return do(http.MethodPatch, location, headers, body, "john")
+		}
+
+		Convey("Discovery and version checks", func() {
+			req := httptest.NewRequest(http.MethodOptions, "/tus/", nil)
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+			So(rec.Code, ShouldEqual, http.StatusNoContent)
+			So(rec.Header().Get("Tus-Extension"), ShouldEqual, TusExtensions)
+
+			rec = do(http.MethodPost, "/tus/", map[string]string{"Tus-Resumable": "0.2.0"}, "", "john")
+			So(rec.Code, ShouldEqual, http.StatusPreconditionFailed)
+
+			rec = do(http.MethodPost, "/tus/", map[string]string{"Upload-Length": "10"}, "", "john")
+			So(rec.Code, ShouldEqual, http.StatusBadRequest)
+
+			rec = do(http.MethodPost, "/tus/", map[string]string{"Upload-Length": "10", "Upload-Metadata": "filename YS50eHQ="}, "", "")
+			So(rec.Code, ShouldEqual, http.StatusUnauthorized)
+		})
+
+		Convey("Upload in several chunks", func() {
+			location := create("25", "personal-files/file.txt")
+
+			rec := do(http.MethodHead, location, nil, "", "john")
+			So(rec.Code, ShouldEqual, http.StatusOK)
+			So(rec.Header().Get("Upload-Offset"), ShouldEqual, "0")
+			So(rec.Header().Get("Upload-Length"), ShouldEqual, "25")
+
+			// Other users cannot see the upload
+			So(do(http.MethodHead, location, nil, "", "jane").Code, ShouldEqual, http.StatusNotFound)
+
+			rec = patch(location, "0", "0123456", "")
+			So(rec.Code, ShouldEqual, http.StatusNoContent)
+			So(rec.Header().Get("Upload-Offset"), ShouldEqual, "7")
+			So(router.parts, ShouldBeEmpty)
+
+			// Wrong offset
+			So(patch(location, "3", "abc", "").Code, ShouldEqual, http.StatusConflict)
+
+			// Checksum mismatch discards the chunk
+			wrongSum := sha1.Sum([]byte("other"))
+			So(patch(location, "7", "789abcdef", "sha1 "+base64.StdEncoding.EncodeToString(wrongSum[:])).Code, ShouldEqual, statusChecksumMismatch)
+			So(do(http.MethodHead, location, nil, "", "john").Header().Get("Upload-Offset"), ShouldEqual, "7")
+
+			sum := sha1.Sum([]byte("789abcdef"))
+			rec = patch(location, "7", "789abcdef", "sha1 "+base64.StdEncoding.EncodeToString(sum[:]))
+			So(rec.Code, ShouldEqual, http.StatusNoContent)
+			So(rec.Header().Get("Upload-Offset"), ShouldEqual, "16")
+			So(router.parts, ShouldHaveLength, 1)
+			So(router.parts[1], ShouldEqual, "0123456789")
+
+			// Simulate a restart with a new handler on the same store
+			h = NewHandler(router, router, store, tmpDir)
+			h.PartSize = 10
+			rec = patch(location, "16", "ghijklmnoXXXX", "")
+			So(rec.Code, ShouldEqual, http.StatusNoContent)
+			So(rec.Header().Get("Upload-Offset"), ShouldEqual, "25")
+			So(rec.Header().Get("Upload-Expires"), ShouldBeEmpty)
+			So(router.completed["personal-files/file.txt"], ShouldEqual, "0123456789abcdefghijklmno")
+
+			_, e := os.Stat(filepath.Join(tmpDir, strings.TrimPrefix(location, "/tus/")))
+			So(os.IsNotExist(e), ShouldBeTrue)
+		})
+
+		Convey("Empty upload", func() {
+			location := create("0", "personal-files/empty.txt")
+			So(router.completed, ShouldContainKey, "personal-files/empty.txt")
+			So(do(http.MethodHead, location, nil, "", "john").Header().Get("Upload-Offset"), ShouldEqual, "0")
+		})
+
+		Convey("Termination and expiration", func() {
+			location := create("100", "personal-files/big.bin")
+			So(patch(location, "0", "0123", "").Code, ShouldEqual, http.StatusNoContent)
+			So(do(http.MethodDelete, location, nil, "", "john").Code, ShouldEqual, http.StatusNoContent)
+			So(router.aborted, ShouldResemble, []string{"personal-files/big.bin"})
+			So(do(http.MethodHead, location, nil, "", "john").Code, ShouldEqual, http.StatusNotFound)
+
+			location = create("100", "personal-files/big.bin")
+			id := strings.TrimPrefix(location, "/tus/")
+			upload, e := store.GetUpload(id)
+			So(e, ShouldBeNil)
+			upload.AdminPath = "pydiods1/big.bin"
+			upload.Expires = time.Now().Add(-time.Minute).Unix()
+			So(store.PutUpload(upload), ShouldBeNil)
+			So(do(http.MethodHead, location, nil, "", "john").Code, ShouldEqual, http.StatusGone)
+
+			So(h.CleanExpired(context.Background()), ShouldBeNil)
+			So(router.aborted, ShouldContain, "pydiods1/big.bin")
+			_, e = store.GetUpload(id)
+			So(e, ShouldNotBeNil)
+		})
+
+	})
+
+}
@@ -0,0 +1,95 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+// Package tus implements the tus.io resumable upload protocol, mapped onto multipart uploads.
+package tus
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/config"
+	"github.com/pydio/cells/common/log"
+	"github.com/pydio/cells/common/plugins"
+	"github.com/pydio/cells/common/service"
+	servicecontext "github.com/pydio/cells/common/service/context"
+	"github.com/pydio/cells/common/views"
+)
+
+func init() {
+	plugins.Register("main", func(ctx context.Context) {
+		service.NewService(
+			service.Name(common.ServiceGatewayTus),
+			service.Context(ctx),
+			service.Tag(common.ServiceTagGateway),
+			service.RouterDependencies(),
+			service.Description("Resumable uploads gateway implementing the tus.io protocol"),
+			service.WithHTTP(func() http.Handler {
+				tCtx := servicecontext.WithServiceName(ctx, common.ServiceGatewayTus)
+				serviceDir, e := config.ServiceDataDir(common.ServiceGatewayTus)
+				if e != nil {
+					return unavailable(tCtx, e)
+				}
+				bufferDir := filepath.Join(serviceDir, "buffers")
+				if e := os.MkdirAll(bufferDir, 0755); e != nil {
+					return unavailable(tCtx, e)
+				}
+				store, e := NewBoltStore(filepath.Join(serviceDir, "uploads.db"))
+				if e != nil {
+					return unavailable(tCtx, e)
+				}
+
+				router := views.NewStandardRouter(views.RouterOptions{WatchRegistry: true, AuditEvent: true})
+				adminRouter := views.NewStandardRouter(views.RouterOptions{AdminView: true, WatchRegistry: true})
+				handler := NewHandler(router, adminRouter, store, bufferDir)
+				conf := config.Get("services", common.ServiceGatewayTus)
+				if s := conf.Val("partSize").Default(DefaultPartSize).Int64(); s >= 5*1024*1024 {
+					handler.PartSize = s
+				}
+				if d := conf.Val("expiration").Default(DefaultExpiration.String()).Duration(); d > 0 {
+					handler.Expiration = d
+				}
+
+				go func() {
+					for range time.Tick(time.Hour) {
+						if e := handler.CleanExpired(tCtx); e != nil {
+							log.Logger(tCtx).Error("Cannot clean expired tus uploads", zap.Error(e))
+						}
+					}
+				}()
+
+				return servicecontext.HttpMetaExtractorWrapper(auth(handler))
+			}),
+		)
+	})
+}
+
+func unavailable(ctx context.Context, e error) http.Handler {
+	log.Logger(ctx).Error("Cannot initialize tus uploads store", zap.Error(e))
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+}
@@ -0,0 +1,154 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package tus
+
+import (
+	"encoding/json"
+	"time"
+
+	bolt "github.com/etcd-io/bbolt"
+	"github.com/micro/go-micro/errors"
+	"github.com/pydio/minio-go"
+
+	"github.com/pydio/cells/common"
+)
+
+var (
+	uploadsBucketKey = []byte("uploads")
+)
+
+// Upload keeps track of a resumable upload between requests and restarts
+type Upload struct {
+	ID string
+	// Owner is the login of the user who created the upload
+	Owner string
+	// Path is the target path as seen by the owner
+	Path string
+	// AdminPath is the target path as datasource/path, used for cleaning expired uploads
+	AdminPath string
+	Length    int64
+	Offset    int64
+	Metadata  map[string]string `json:",omitempty"`
+	// MultipartID is the identifier of the underlying multipart upload
+	MultipartID string
+	Parts       []minio.CompletePart `json:",omitempty"`
+	// Buffered is the number of bytes received but not yet sent as a part
+	Buffered  int64
+	Created   int64
+	Expires   int64
+	Completed bool
+}
+
+// Expired checks if upload expiration date is passed
+func (u *Upload) Expired(now time.Time) bool {
+	return u.Expires > 0 && now.Unix() > u.Expires
+}
+
+// Store persists uploads states
+type Store interface {
+	PutUpload(upload *Upload) error
+	GetUpload(id string) (*Upload, error)
+	DeleteUpload(id string) error
+	ListExpired(now time.Time) ([]*Upload, error)
+	Close()
+}
+
+// BoltStore implements Store with a bolt database
+type BoltStore struct {
+	db *bolt.DB
+}
+
+// NewBoltStore opens the database and creates the uploads bucket if necessary.
+func NewBoltStore(fileName string) (*BoltStore, error) {
+
+	options := bolt.DefaultOptions
+	options.Timeout = 5 * time.Second
+	db, err := bolt.Open(fileName, 0644, options)
+	if err != nil {
+		return nil, err
+	}
+	er := db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(uploadsBucketKey)
+		return err
+	})
+	if er != nil {
+		db.Close()
+		return nil, er
+	}
+	return &BoltStore{db: db}, nil
+
+}
+
+// Close closes the database
+func (b *BoltStore) Close() {
+	b.db.Close()
+}
+
+// PutUpload creates or updates an upload
+func (b *BoltStore) PutUpload(upload *Upload) error {
+	return b.db.Update(func(tx *bolt.Tx) error {
+		data, err := json.Marshal(upload)
+		if err != nil {
+			return err
+		}
+		return tx.Bucket(uploadsBucketKey).Put([]byte(upload.ID), data)
+	})
+}
+
+// GetUpload loads an upload by its ID
+func (b *BoltStore) GetUpload(id string) (*Upload, error) {
+	upload := &Upload{}
+	e := b.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(uploadsBucketKey).Get([]byte(id))
+		if data == nil {
+			return errors.NotFound(common.ServiceGatewayTus, "Upload not found")
+		}
+		return json.Unmarshal(data, upload)
+	})
+	if e != nil {
+		return nil, e
+	}
+	return upload, nil
+}
+
+// DeleteUpload removes an upload
+func (b *BoltStore) DeleteUpload(id string) error {
+	return b.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(uploadsBucketKey).Delete([]byte(id))
+	})
+}
+
+// ListExpired lists all uploads whose expiration date is passed
+func (b *BoltStore) ListExpired(now time.Time) (uploads []*Upload, e error) {
+	e = b.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(uploadsBucketKey).ForEach(func(k, v []byte) error {
+			upload := &Upload{}
+			if er := json.Unmarshal(v, upload); er != nil {
+				return er
+			}
+			if upload.Expired(now) {
+				uploads = append(uploads, upload)
+			}
+			return nil
+		})
+	})
+	return
+}
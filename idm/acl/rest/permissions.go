@@ -42,7 +42,37 @@ import (
 	"github.com/pydio/cells/common/views"
 )
 
-// WriteAllowed returns an error if the Write permission is not present in an acl
+var (
+	// loadRole finds a role by its UUID
+	loadRole = func(ctx context.Context, roleID string) (*idm.Role, error) {
+		cli := idm.NewRoleServiceClient(common.ServiceGrpcNamespace_+common.ServiceRole, defaults.NewClient())
+		q, _ := ptypes.MarshalAny(&idm.RoleSingleQuery{Uuid: []string{roleID}})
+		stream, err := cli.SearchRole(ctx, &idm.SearchRoleRequest{Query: &service.Query{SubQueries: []*any.Any{q}}})
+		if err != nil {
+			return nil, err
+		}
+		defer stream.Close()
+		for {
+			resp, e := stream.Recv()
+			if e != nil {
+				if e == io.EOF {
+					break
+				}
+				return nil, e
+			}
+			if resp != nil && resp.Role != nil {
+				return resp.Role, nil
+			}
+		}
+		return nil, errors.NotFound(common.ServiceAcl, "Role not found!")
+	}
+	// delegatedAdminAllowedOnRole checks that a role belongs to a user or a group administrated by the context user
+	delegatedAdminAllowedOnRole = permissions.DelegatedAdminAllowedOnRole
+)
+
+// WriteAllowed returns an error if the context user cannot write this acl. Admins can write any acl,
+// other users must administrate the user or group owning the acl role. ACLs on nodes additionally
+// require the read or write permission on the node.
 func (a *Handler) WriteAllowed(ctx context.Context, acl *idm.ACL) error {
 
 	if claims, ok := ctx.Value(claim.ContextKey).(claim.Claims); ok {
@@ -51,51 +81,32 @@ func (a *Handler) WriteAllowed(ctx context.Context, acl *idm.ACL) error {
 		}
 	}
 
-	if acl.NodeID == "" && acl.RoleID != "" {
-		// This is a global ACL just used for role
-		// Check resource policies associated to this role
-		log.Logger(ctx).Debug("Checking acl write on role", zap.Any("acl", acl))
-		return a.CheckRole(ctx, acl.RoleID)
+	if acl.RoleID == "" {
+		log.Logger(ctx).Error("Cannot check acl right for a request without role", zap.Any("acl", acl))
+		return errors.Forbidden(common.ServiceAcl, "You are not allowed to edit ACLs without a role")
+	}
+	log.Logger(ctx).Debug("Checking acl write on role", zap.Any("acl", acl))
+	if e := a.CheckRole(ctx, acl.RoleID); e != nil {
+		return e
+	}
 
-	} else if acl.NodeID != "" && acl.Action != nil && (acl.Action.Name == permissions.AclRead.Name || acl.Action.Name == permissions.AclWrite.Name) {
-		// Do not bother about roles here, just verify
-		// that this node is belonging to an authorized path: use accessList for that
+	if acl.NodeID != "" && acl.Action != nil && (acl.Action.Name == permissions.AclRead.Name || acl.Action.Name == permissions.AclWrite.Name) {
+		// Verify that this node is belonging to an authorized path: use accessList for that
 		return a.CheckNode(ctx, acl.NodeID, acl.Action)
-
-	} else {
-		log.Logger(ctx).Error("Cannot check acl right for this request - probably a workspace wide acl delete query - letting it through", zap.Any("acl", acl))
 	}
 
 	return nil
 }
 
-// CheckRole loads a role and its Policies to check wether this role can be edited
-// in the current context
+// CheckRole loads a role and checks that it belongs to a user or a group administrated
+// by the context user
 func (a *Handler) CheckRole(ctx context.Context, roleID string) error {
 
-	cli := idm.NewRoleServiceClient(common.ServiceGrpcNamespace_+common.ServiceRole, defaults.NewClient())
-	q, _ := ptypes.MarshalAny(&idm.RoleSingleQuery{Uuid: []string{roleID}})
-	stream, err := cli.SearchRole(ctx, &idm.SearchRoleRequest{Query: &service.Query{SubQueries: []*any.Any{q}}})
+	role, err := loadRole(ctx, roleID)
 	if err != nil {
 		return err
 	}
-	defer stream.Close()
-	var role *idm.Role
-	for {
-		resp, e := stream.Recv()
-		if e != nil {
-			break
-		}
-		if resp == nil {
-			continue
-		}
-		role = resp.Role
-		break
-	}
-	if role == nil {
-		return errors.NotFound(common.ServiceAcl, "Role not found!")
-	}
-	if !a.MatchPolicies(ctx, role.Uuid, role.Policies, service.ResourcePolicyAction_WRITE) {
+	if !delegatedAdminAllowedOnRole(ctx, role) {
 		subjects, _ := auth.SubjectsForResourcePolicyQuery(ctx, nil)
 		log.Logger(ctx).Error("Error while checking role from ACL rest : ", zap.Any("role", role), log.DangerouslyZapSmallSlice("subjects", subjects))
 		return errors.Forbidden(common.ServiceAcl, "You are not allowed to edit this role ACLs")
@@ -0,0 +1,69 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package rest
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/auth/claim"
+	"github.com/pydio/cells/common/proto/idm"
+)
+
+func TestWriteAllowed(t *testing.T) {
+
+	roles := map[string]*idm.Role{
+		"inside":  {Uuid: "inside", GroupRole: true},
+		"outside": {Uuid: "outside", GroupRole: true},
+	}
+	loadRole = func(ctx context.Context, roleID string) (*idm.Role, error) {
+		return roles[roleID], nil
+	}
+	// The group admin only administrates the group owning the "inside" role
+	delegatedAdminAllowedOnRole = func(ctx context.Context, role *idm.Role) bool {
+		return role != nil && role.Uuid == "inside"
+	}
+
+	h := &Handler{}
+	groupAdmin := context.WithValue(context.Background(), claim.ContextKey, claim.Claims{Name: "manager", Profile: common.PydioProfileStandard, GroupPath: "/sales"})
+	admin := context.WithValue(context.Background(), claim.ContextKey, claim.Claims{Name: "admin", Profile: common.PydioProfileAdmin})
+	param := &idm.ACLAction{Name: "parameter:core.conf:lang", Value: "fr"}
+
+	Convey("Test group admin writes ACLs", t, func() {
+		So(h.WriteAllowed(groupAdmin, &idm.ACL{RoleID: "inside", Action: param}), ShouldBeNil)
+		So(h.WriteAllowed(groupAdmin, &idm.ACL{RoleID: "inside", NodeID: "PYDIO_REPO_SCOPE_ALL", WorkspaceID: "PYDIO_REPO_SCOPE_ALL", Action: param}), ShouldBeNil)
+	})
+
+	Convey("Test group admin cannot write ACLs outside of its subtree", t, func() {
+		So(h.WriteAllowed(groupAdmin, &idm.ACL{RoleID: "outside", Action: param}), ShouldNotBeNil)
+		So(h.WriteAllowed(groupAdmin, &idm.ACL{RoleID: "outside", NodeID: "PYDIO_REPO_SCOPE_ALL", WorkspaceID: "PYDIO_REPO_SCOPE_ALL", Action: param}), ShouldNotBeNil)
+		So(h.WriteAllowed(groupAdmin, &idm.ACL{WorkspaceID: "ws", Action: param}), ShouldNotBeNil)
+	})
+
+	Convey("Test admin writes any ACL", t, func() {
+		So(h.WriteAllowed(admin, &idm.ACL{RoleID: "outside", Action: param}), ShouldBeNil)
+		So(h.WriteAllowed(admin, &idm.ACL{WorkspaceID: "ws"}), ShouldBeNil)
+	})
+
+}
@@ -47,6 +47,7 @@ import (
 	pauth "github.com/pydio/cells/common/proto/auth"
 	servicecontext "github.com/pydio/cells/common/service/context"
 	"github.com/pydio/cells/common/sql"
+	"github.com/pydio/cells/idm/oauth/guard"
 )
 
 // Handler for the plugin
@@ -138,6 +139,10 @@ func (h *Handler) CreateLogin(ctx context.Context, in *pauth.CreateLoginRequest,
 
 func (h *Handler) AcceptLogin(ctx context.Context, in *pauth.AcceptLoginRequest, out *pauth.AcceptLoginResponse) error {
 
+	if err := guard.Default().Succeeded(ctx, in.Subject); err != nil {
+		return err
+	}
+
 	var p consent.HandledLoginRequest
 	p.Subject = in.Subject
 	p.Challenge = in.Challenge
@@ -23,8 +23,11 @@ package grpc
 
 import (
 	"context"
+	"encoding/json"
 	"log"
 
+	"github.com/golang/protobuf/jsonpb"
+	structpb "github.com/golang/protobuf/ptypes/struct"
 	"github.com/micro/go-micro"
 
 	"github.com/pydio/cells/common"
@@ -93,9 +96,10 @@ func init() {
 
 		auth.OnConfigurationInit(func(scanner common.Scanner) {
 			var m []struct {
-				ID   string
-				Name string
-				Type string
+				ID     string
+				Name   string
+				Type   string
+				Config map[string]interface{}
 			}
 
 			if err := scanner.Scan(&m); err != nil {
@@ -106,7 +110,21 @@ func init() {
 				if mm.Type == "pydio" {
 					// Registering the first connector
 					auth.RegisterConnector(mm.ID, mm.Name, mm.Type, nil)
+					continue
 				}
+
+				// Other connectors receive their config as a generic struct
+				data, err := json.Marshal(mm.Config)
+				if err != nil {
+					log.Println("Cannot read config for connector "+mm.ID, err)
+					continue
+				}
+				st := &structpb.Struct{}
+				if err := jsonpb.UnmarshalString(string(data), st); err != nil {
+					log.Println("Cannot read config for connector "+mm.ID, err)
+					continue
+				}
+				auth.RegisterConnector(mm.ID, mm.Name, mm.Type, st)
 			}
 		})
 
@@ -0,0 +1,135 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package guard
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Anomaly is a suspicious property of a successful login.
+type Anomaly string
+
+const (
+	AnomalyNewDevice        Anomaly = "new-device"
+	AnomalyNewCountry       Anomaly = "new-country"
+	AnomalyImpossibleTravel Anomaly = "impossible-travel"
+)
+
+// LoginEvent describes a successful login.
+type LoginEvent struct {
+	Time      int64
+	IP        string
+	Device    string
+	UserAgent string
+	Location  *Location `json:",omitempty"`
+}
+
+// Profile is the login history kept for each user.
+type Profile struct {
+	UserUuid string
+	// Devices maps device fingerprints to the last time they were seen
+	Devices   map[string]int64
+	Countries []string
+	Last      *LoginEvent `json:",omitempty"`
+}
+
+// Fingerprint computes a device identifier from a user agent.
+func Fingerprint(userAgent string) string {
+	ua := strings.ToLower(strings.TrimSpace(userAgent))
+	if ua == "" {
+		return ""
+	}
+	h := sha1.Sum([]byte(ua))
+	return hex.EncodeToString(h[:8])
+}
+
+// Detect compares a login with the history of the user. The first login of a user is never reported.
+func Detect(conf Config, p *Profile, ev *LoginEvent) (anomalies []Anomaly) {
+	if p == nil {
+		return
+	}
+	if ev.Device != "" && len(p.Devices) > 0 {
+		if _, known := p.Devices[ev.Device]; !known {
+			anomalies = append(anomalies, AnomalyNewDevice)
+		}
+	}
+	if ev.Location != nil && len(p.Countries) > 0 && !p.hasCountry(ev.Location.Country) {
+		anomalies = append(anomalies, AnomalyNewCountry)
+	}
+	if last := p.Last; last != nil && last.Location != nil && last.Location.HasCoords && ev.Location != nil && ev.Location.HasCoords && conf.MaxTravelSpeed > 0 {
+		distance := Distance(last.Location, ev.Location)
+		if distance > conf.MinTravelDistance {
+			hours := time.Unix(ev.Time, 0).Sub(time.Unix(last.Time, 0)).Hours()
+			if hours <= 0 || distance/hours > conf.MaxTravelSpeed {
+				anomalies = append(anomalies, AnomalyImpossibleTravel)
+			}
+		}
+	}
+	return
+}
+
+// Record adds a login to the profile, keeping at most conf.MaxDevices devices.
+func (p *Profile) Record(conf Config, ev *LoginEvent) {
+	if p.Devices == nil {
+		p.Devices = make(map[string]int64)
+	}
+	if ev.Device != "" {
+		p.Devices[ev.Device] = ev.Time
+	}
+	if conf.MaxDevices > 0 && len(p.Devices) > conf.MaxDevices {
+		var ids []string
+		for id := range p.Devices {
+			ids = append(ids, id)
+		}
+		sort.Slice(ids, func(i, j int) bool {
+			return p.Devices[ids[i]] < p.Devices[ids[j]]
+		})
+		for _, id := range ids[:len(ids)-conf.MaxDevices] {
+			delete(p.Devices, id)
+		}
+	}
+	if ev.Location != nil && !p.hasCountry(ev.Location.Country) {
+		p.Countries = append(p.Countries, ev.Location.Country)
+	}
+	p.Last = ev
+}
+
+func (p *Profile) hasCountry(code string) bool {
+	for _, c := range p.Countries {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+// JoinAnomalies formats anomalies as a comma-separated list.
+func JoinAnomalies(anomalies []Anomaly) string {
+	var s []string
+	for _, a := range anomalies {
+		s = append(s, string(a))
+	}
+	return strings.Join(s, ",")
+}
@@ -0,0 +1,171 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package guard
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Location is the result of a GeoIP lookup.
+type Location struct {
+	Country     string
+	CountryName string
+	City        string
+	Latitude    float64
+	Longitude   float64
+	HasCoords   bool
+}
+
+type ipRange struct {
+	from, to net.IP
+	loc      *Location
+}
+
+// GeoDB resolves IP addresses using ranges loaded from a CSV file.
+type GeoDB struct {
+	ranges []ipRange
+}
+
+// OpenGeoDB loads a GeoIP database from a CSV file.
+func OpenGeoDB(path string) (*GeoDB, error) {
+	f, e := os.Open(path)
+	if e != nil {
+		return nil, e
+	}
+	defer f.Close()
+	return ReadGeoDB(f)
+}
+
+// ReadGeoDB parses a GeoIP database in CSV format, one range per line using the IP2Location LITE layout:
+// ip_from, ip_to, country_code, country_name, and optionally region, city, latitude and longitude.
+// Range bounds may be decimal numbers (IPv4 or IPv6) or IP addresses.
+func ReadGeoDB(r io.Reader) (*GeoDB, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+	db := &GeoDB{}
+	line := 0
+	for {
+		record, e := reader.Read()
+		if e == io.EOF {
+			break
+		} else if e != nil {
+			return nil, e
+		}
+		line++
+		if len(record) < 3 {
+			return nil, fmt.Errorf("line %d: expected at least 3 columns", line)
+		}
+		from, to := parseBound(record[0]), parseBound(record[1])
+		if from == nil || to == nil {
+			if line == 1 {
+				// Header line
+				continue
+			}
+			return nil, fmt.Errorf("line %d: invalid range %s-%s", line, record[0], record[1])
+		}
+		code := strings.TrimSpace(record[2])
+		if code == "" || code == "-" {
+			continue
+		}
+		loc := &Location{Country: strings.ToUpper(code)}
+		if len(record) > 3 {
+			loc.CountryName = strings.TrimSpace(record[3])
+		}
+		if len(record) > 5 {
+			loc.City = strings.TrimSpace(record[5])
+		}
+		if len(record) > 7 {
+			lat, e1 := strconv.ParseFloat(strings.TrimSpace(record[6]), 64)
+			lon, e2 := strconv.ParseFloat(strings.TrimSpace(record[7]), 64)
+			if e1 == nil && e2 == nil && (lat != 0 || lon != 0) {
+				loc.Latitude, loc.Longitude, loc.HasCoords = lat, lon, true
+			}
+		}
+		db.ranges = append(db.ranges, ipRange{from: from, to: to, loc: loc})
+	}
+	sort.Slice(db.ranges, func(i, j int) bool {
+		return bytes.Compare(db.ranges[i].from, db.ranges[j].from) < 0
+	})
+	return db, nil
+}
+
+// Lookup finds the location of an IP address, it returns nil if the address is unknown.
+func (g *GeoDB) Lookup(address string) *Location {
+	if g == nil {
+		return nil
+	}
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return nil
+	}
+	ip = ip.To16()
+	i := sort.Search(len(g.ranges), func(i int) bool {
+		return bytes.Compare(g.ranges[i].from, ip) > 0
+	})
+	if i == 0 {
+		return nil
+	}
+	if rg := g.ranges[i-1]; bytes.Compare(ip, rg.to) <= 0 {
+		return rg.loc
+	}
+	return nil
+}
+
+// parseBound reads a range bound as a 16-bytes IP. Decimal values fitting in 32 bits are IPv4 addresses.
+func parseBound(s string) net.IP {
+	s = strings.TrimSpace(s)
+	if ip := net.ParseIP(s); ip != nil {
+		return ip.To16()
+	}
+	n, ok := new(big.Int).SetString(s, 10)
+	if !ok || n.Sign() < 0 || n.BitLen() > 128 {
+		return nil
+	}
+	if n.BitLen() <= 32 {
+		v := n.Uint64()
+		return net.IPv4(byte(v>>24), byte(v>>16), byte(v>>8), byte(v)).To16()
+	}
+	ip := make(net.IP, net.IPv6len)
+	b := n.Bytes()
+	copy(ip[net.IPv6len-len(b):], b)
+	return ip
+}
+
+// Distance computes the great-circle distance in kilometers between two locations.
+func Distance(a, b *Location) float64 {
+	const earthRadius = 6371.0
+	rad := func(d float64) float64 { return d * math.Pi / 180 }
+	dLat := rad(b.Latitude - a.Latitude)
+	dLon := rad(b.Longitude - a.Longitude)
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(rad(a.Latitude))*math.Cos(rad(b.Latitude))*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadius * math.Asin(math.Min(1, math.Sqrt(h)))
+}
@@ -0,0 +1,74 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package guard
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestGeoDB(t *testing.T) {
+
+	Convey("Test IPv4 decimal ranges", t, func() {
+		data := `"ip_from","ip_to","country_code","country_name","region_name","city_name","latitude","longitude"
+"0","16777215","-","-","-","-","0","0"
+"16777216","16777471","AU","Australia","Queensland","Brisbane","-27.46","153.02"
+"3232235520","3232301055","FR","France","Ile-de-France","Paris","48.85","2.35"
+`
+		db, e := ReadGeoDB(strings.NewReader(data))
+		So(e, ShouldBeNil)
+		So(db.Lookup("0.0.0.1"), ShouldBeNil)
+		So(db.Lookup("1.0.0.12").Country, ShouldEqual, "AU")
+		loc := db.Lookup("192.168.10.1")
+		So(loc, ShouldNotBeNil)
+		So(loc.City, ShouldEqual, "Paris")
+		So(loc.HasCoords, ShouldBeTrue)
+		So(db.Lookup("192.169.0.1"), ShouldBeNil)
+		So(db.Lookup("not an ip"), ShouldBeNil)
+	})
+
+	Convey("Test IPv6 and address ranges", t, func() {
+		data := `281470681743360,281470698520575,US,United States
+2001:db8::,2001:db8::ffff,DE,Germany
+`
+		db, e := ReadGeoDB(strings.NewReader(data))
+		So(e, ShouldBeNil)
+		So(db.Lookup("0.0.1.1").Country, ShouldEqual, "US")
+		So(db.Lookup("2001:db8::12").Country, ShouldEqual, "DE")
+		So(db.Lookup("2001:db8::1:0"), ShouldBeNil)
+		So(db.Lookup("2001:db8::12").HasCoords, ShouldBeFalse)
+	})
+
+	Convey("Test invalid lines", t, func() {
+		_, e := ReadGeoDB(strings.NewReader("1,2,FR\nfoo,bar,FR\n"))
+		So(e, ShouldNotBeNil)
+	})
+
+	Convey("Test distance", t, func() {
+		paris := &Location{Latitude: 48.85, Longitude: 2.35}
+		london := &Location{Latitude: 51.51, Longitude: -0.13}
+		So(Distance(paris, london), ShouldAlmostEqual, 343, 5)
+		So(Distance(paris, paris), ShouldEqual, 0)
+	})
+
+}
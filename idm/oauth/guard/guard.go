@@ -0,0 +1,392 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+// Package guard tracks login attempts on the OAuth password grants.
+//
+// Failed attempts are counted per login and per source IP: after a few failures, the next attempts for a login
+// are refused until a progressively longer delay is over, and an IP sending too many failures is blocked for a while.
+// Counters are kept in the docstore, as they are updated by the password grants and reset by the login challenges.
+// Successful logins are compared to the known devices and countries of the user (countries are resolved with a local
+// GeoIP CSV database) to detect new devices, new countries and impossible travels. Anomalies are reported in the
+// audit log and by mail, and are passed to the "oidc" policies in the LoginAnomalies and LoginCountry context keys,
+// so that administrators can refuse such logins with a policy.
+package guard
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/micro/go-micro/errors"
+	"github.com/patrickmn/go-cache"
+	"go.uber.org/zap"
+
+	"github.com/pydio/cells/common/log"
+	"github.com/pydio/cells/x/configx"
+)
+
+const (
+	// PolicyLoginAnomalies is the policy context key holding the comma-separated anomalies detected on a login
+	PolicyLoginAnomalies = "LoginAnomalies"
+	// PolicyLoginCountry is the policy context key holding the ISO code of the country the login comes from
+	PolicyLoginCountry = "LoginCountry"
+)
+
+// Config holds the settings read from the "guard" section of the OAuth service configuration.
+type Config struct {
+	Enabled bool
+	// DelayAfter is the number of failures for a login before delays are applied
+	DelayAfter int
+	// BaseDelay is the first delay, doubled after each new failure up to MaxDelay
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+	// ResetAfter forgets the failures of a login after this period without attempts
+	ResetAfter time.Duration
+	// IPMaxFailures failures from the same IP within IPWindow block this IP for IPBlock
+	IPMaxFailures int
+	IPWindow      time.Duration
+	IPBlock       time.Duration
+	// GeoIPFile is the path to a CSV GeoIP database, country detection is disabled if empty
+	GeoIPFile string
+	// MaxTravelSpeed in km/h above which two successive logins are considered an impossible travel
+	MaxTravelSpeed float64
+	// MinTravelDistance in km under which travels are ignored, as GeoIP locations are approximate
+	MinTravelDistance float64
+	MaxDevices        int
+	NotifyUser        bool
+	// AlertEmail receives a copy of all anomaly alerts if not empty
+	AlertEmail string
+}
+
+// DefaultConfig returns the settings used when nothing is configured.
+func DefaultConfig() Config {
+	return Config{
+		Enabled:           true,
+		DelayAfter:        3,
+		BaseDelay:         time.Second,
+		MaxDelay:          5 * time.Minute,
+		ResetAfter:        time.Hour,
+		IPMaxFailures:     50,
+		IPWindow:          15 * time.Minute,
+		IPBlock:           15 * time.Minute,
+		MaxTravelSpeed:    900,
+		MinTravelDistance: 200,
+		MaxDevices:        20,
+		NotifyUser:        true,
+	}
+}
+
+// LoadConfig reads the settings from the configuration values, using defaults for missing keys.
+func LoadConfig(values configx.Values) Config {
+	d := DefaultConfig()
+	return Config{
+		Enabled:           values.Val("enabled").Default(d.Enabled).Bool(),
+		DelayAfter:        values.Val("delayAfter").Default(d.DelayAfter).Int(),
+		BaseDelay:         values.Val("baseDelay").Default(d.BaseDelay.String()).Duration(),
+		MaxDelay:          values.Val("maxDelay").Default(d.MaxDelay.String()).Duration(),
+		ResetAfter:        values.Val("resetAfter").Default(d.ResetAfter.String()).Duration(),
+		IPMaxFailures:     values.Val("ipMaxFailures").Default(d.IPMaxFailures).Int(),
+		IPWindow:          values.Val("ipWindow").Default(d.IPWindow.String()).Duration(),
+		IPBlock:           values.Val("ipBlock").Default(d.IPBlock.String()).Duration(),
+		GeoIPFile:         values.Val("geoIPFile").String(),
+		MaxTravelSpeed:    float64(values.Val("maxTravelSpeed").Default(int(d.MaxTravelSpeed)).Int()),
+		MinTravelDistance: float64(values.Val("minTravelDistance").Default(int(d.MinTravelDistance)).Int()),
+		MaxDevices:        values.Val("maxDevices").Default(d.MaxDevices).Int(),
+		NotifyUser:        values.Val("notifyUser").Default(d.NotifyUser).Bool(),
+		AlertEmail:        values.Val("alertEmail").String(),
+	}
+}
+
+// LoginCounter holds the failed attempts of a login, and the attempts allowed but not settled yet.
+type LoginCounter struct {
+	Failures int
+	Pending  int `json:",omitempty"`
+	Last     time.Time
+}
+
+// IPCounter holds the recent failed attempts from an IP, the attempts allowed but not settled yet,
+// and the end of its blocking period if any.
+type IPCounter struct {
+	Failures     []time.Time `json:",omitempty"`
+	Pending      int         `json:",omitempty"`
+	Last         time.Time
+	BlockedUntil time.Time
+}
+
+// pendingTimeout forgets the pending attempts of a counter without new attempt for this period, in case
+// an attempt was never settled (e.g. the process stopped while checking the credentials).
+const pendingTimeout = time.Minute
+
+// Counters persists the failed attempts. As password grants and login challenges are not handled by the same
+// service, counters must be shared by all processes (see NewDocCounters).
+type Counters interface {
+	GetLogin(ctx context.Context, login string) (*LoginCounter, error)
+	PutLogin(ctx context.Context, login string, c *LoginCounter, ttl time.Duration) error
+	DeleteLogin(ctx context.Context, login string) error
+	GetIP(ctx context.Context, ip string) (*IPCounter, error)
+	PutIP(ctx context.Context, ip string, c *IPCounter, ttl time.Duration) error
+}
+
+// Tracker counts the failed attempts per login and per IP. It is safe for concurrent use.
+type Tracker struct {
+	sync.Mutex
+	counters Counters
+}
+
+// NewTracker creates a Tracker on top of the given counters, or of in-memory counters if nil.
+func NewTracker(counters Counters) *Tracker {
+	if counters == nil {
+		counters = NewMemoryCounters()
+	}
+	return &Tracker{counters: counters}
+}
+
+// Check returns an error if an attempt for this login from this IP must be refused at the given time.
+// Otherwise the attempt is reserved as pending, so that concurrent attempts are delayed as if it had
+// already failed: it must then be settled with Fail or Release.
+func (t *Tracker) Check(ctx context.Context, conf Config, login, ip string, now time.Time) error {
+	t.Lock()
+	defer t.Unlock()
+
+	var ipc *IPCounter
+	if ip != "" {
+		if c, e := t.counters.GetIP(ctx, ip); e == nil && c != nil {
+			if until := c.BlockedUntil; now.Before(until) {
+				return throttledError(until.Sub(now))
+			}
+			ipc = c
+		} else {
+			ipc = &IPCounter{}
+		}
+		if now.Sub(ipc.Last) > pendingTimeout {
+			ipc.Pending = 0
+		}
+		if conf.IPMaxFailures > 0 {
+			recent := recentFailures(conf, ipc.Failures, now)
+			if len(recent)+ipc.Pending >= conf.IPMaxFailures {
+				wait := conf.IPWindow
+				if len(recent) > 0 {
+					wait = recent[0].Add(conf.IPWindow).Sub(now)
+				}
+				return throttledError(wait)
+			}
+		}
+	}
+
+	key := normalizeLogin(login)
+	counter := &LoginCounter{}
+	if c, e := t.counters.GetLogin(ctx, key); e == nil && c != nil && now.Sub(c.Last) < conf.ResetAfter {
+		counter = c
+	}
+	if now.Sub(counter.Last) > pendingTimeout {
+		counter.Pending = 0
+	}
+	if wait := conf.delay(counter.Failures + counter.Pending); wait > 0 {
+		if next := counter.Last.Add(wait); now.Before(next) {
+			return throttledError(next.Sub(now))
+		}
+	}
+
+	counter.Pending++
+	counter.Last = now
+	if e := t.counters.PutLogin(ctx, key, counter, conf.ResetAfter); e != nil {
+		log.Logger(ctx).Error("Cannot store login attempt", zap.Error(e))
+	}
+	if ipc != nil {
+		ipc.Pending++
+		ipc.Last = now
+		if e := t.counters.PutIP(ctx, ip, ipc, conf.IPWindow+conf.IPBlock); e != nil {
+			log.Logger(ctx).Error("Cannot store login attempt", zap.Error(e))
+		}
+	}
+	return nil
+}
+
+// Fail settles an attempt reserved by Check as failed, and returns true if it caused the IP to be blocked.
+func (t *Tracker) Fail(ctx context.Context, conf Config, login, ip string, now time.Time) (ipBlocked bool) {
+	t.Lock()
+	defer t.Unlock()
+
+	key := normalizeLogin(login)
+	counter := &LoginCounter{}
+	if c, e := t.counters.GetLogin(ctx, key); e == nil && c != nil && now.Sub(c.Last) < conf.ResetAfter {
+		counter = c
+	}
+	counter.settle()
+	counter.Failures++
+	counter.Last = now
+	if e := t.counters.PutLogin(ctx, key, counter, conf.ResetAfter); e != nil {
+		log.Logger(ctx).Error("Cannot store failed login attempt", zap.Error(e))
+	}
+
+	if ip == "" {
+		return false
+	}
+	ipc := &IPCounter{}
+	if c, e := t.counters.GetIP(ctx, ip); e == nil && c != nil {
+		ipc = c
+	}
+	ipc.settle()
+	if conf.IPMaxFailures > 0 {
+		ipc.Failures = append(recentFailures(conf, ipc.Failures, now), now)
+		if len(ipc.Failures) >= conf.IPMaxFailures {
+			ipc.Failures = nil
+			ipc.BlockedUntil = now.Add(conf.IPBlock)
+			ipBlocked = true
+		}
+	}
+	if e := t.counters.PutIP(ctx, ip, ipc, conf.IPWindow+conf.IPBlock); e != nil {
+		log.Logger(ctx).Error("Cannot store failed login attempt", zap.Error(e))
+	}
+	return
+}
+
+// Release settles an attempt reserved by Check that did not fail.
+func (t *Tracker) Release(ctx context.Context, conf Config, login, ip string) {
+	t.Lock()
+	defer t.Unlock()
+
+	key := normalizeLogin(login)
+	if c, e := t.counters.GetLogin(ctx, key); e == nil && c != nil && c.Pending > 0 {
+		c.settle()
+		if e := t.counters.PutLogin(ctx, key, c, conf.ResetAfter); e != nil {
+			log.Logger(ctx).Error("Cannot release login attempt", zap.Error(e))
+		}
+	}
+	if ip == "" {
+		return
+	}
+	if c, e := t.counters.GetIP(ctx, ip); e == nil && c != nil && c.Pending > 0 {
+		c.settle()
+		if e := t.counters.PutIP(ctx, ip, c, conf.IPWindow+conf.IPBlock); e != nil {
+			log.Logger(ctx).Error("Cannot release login attempt", zap.Error(e))
+		}
+	}
+}
+
+// Reset forgets the failures of a login, after it successfully logged in. IP counters are kept, so that
+// an attacker owning one valid account cannot use it to reset its IP.
+func (t *Tracker) Reset(ctx context.Context, login string) {
+	t.Lock()
+	defer t.Unlock()
+	if e := t.counters.DeleteLogin(ctx, normalizeLogin(login)); e != nil {
+		log.Logger(ctx).Error("Cannot reset failed login attempts", zap.Error(e))
+	}
+}
+
+func (c *LoginCounter) settle() {
+	if c.Pending > 0 {
+		c.Pending--
+	}
+}
+
+func (c *IPCounter) settle() {
+	if c.Pending > 0 {
+		c.Pending--
+	}
+}
+
+// recentFailures filters out the failures older than the IP window.
+func recentFailures(conf Config, failures []time.Time, now time.Time) (recent []time.Time) {
+	for _, f := range failures {
+		if now.Sub(f) < conf.IPWindow {
+			recent = append(recent, f)
+		}
+	}
+	return
+}
+
+type memoryCounters struct {
+	logins *cache.Cache
+	ips    *cache.Cache
+}
+
+// NewMemoryCounters keeps the counters in memory, they are not shared with other processes.
+func NewMemoryCounters() Counters {
+	return &memoryCounters{
+		logins: cache.New(time.Hour, 10*time.Minute),
+		ips:    cache.New(time.Hour, 10*time.Minute),
+	}
+}
+
+func (m *memoryCounters) GetLogin(ctx context.Context, login string) (*LoginCounter, error) {
+	if c, ok := m.logins.Get(login); ok {
+		counter := *c.(*LoginCounter)
+		return &counter, nil
+	}
+	return nil, nil
+}
+
+func (m *memoryCounters) PutLogin(ctx context.Context, login string, c *LoginCounter, ttl time.Duration) error {
+	m.logins.Set(login, c, ttl)
+	return nil
+}
+
+func (m *memoryCounters) DeleteLogin(ctx context.Context, login string) error {
+	m.logins.Delete(login)
+	return nil
+}
+
+func (m *memoryCounters) GetIP(ctx context.Context, ip string) (*IPCounter, error) {
+	if c, ok := m.ips.Get(ip); ok {
+		counter := *c.(*IPCounter)
+		return &counter, nil
+	}
+	return nil, nil
+}
+
+func (m *memoryCounters) PutIP(ctx context.Context, ip string, c *IPCounter, ttl time.Duration) error {
+	m.ips.Set(ip, c, ttl)
+	return nil
+}
+
+// delay computes the waiting time required after the given number of failures.
+func (c Config) delay(failures int) time.Duration {
+	if failures < c.DelayAfter || c.BaseDelay <= 0 {
+		return 0
+	}
+	exp := failures - c.DelayAfter
+	if exp > 30 {
+		exp = 30
+	}
+	d := time.Duration(float64(c.BaseDelay) * math.Pow(2, float64(exp)))
+	if c.MaxDelay > 0 && d > c.MaxDelay {
+		d = c.MaxDelay
+	}
+	return d
+}
+
+func throttledError(wait time.Duration) error {
+	seconds := int(math.Ceil(wait.Seconds()))
+	return errors.New("login.throttled", fmt.Sprintf("Too many failed attempts, please retry in %d seconds", seconds), http.StatusTooManyRequests)
+}
+
+// IsThrottled tells if the error was returned because the attempt was refused by the guard.
+func IsThrottled(e error) bool {
+	return e != nil && errors.Parse(e.Error()).Id == "login.throttled"
+}
+
+func normalizeLogin(login string) string {
+	return strings.ToLower(strings.TrimSpace(login))
+}
@@ -0,0 +1,178 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package guard
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestTracker(t *testing.T) {
+
+	conf := DefaultConfig()
+	conf.IPMaxFailures = 5
+	now := time.Now()
+	ctx := context.Background()
+
+	Convey("Test progressive delays", t, func() {
+		tr := NewTracker(nil)
+		for i := 0; i < conf.DelayAfter; i++ {
+			So(tr.Check(ctx, conf, "john", "", now), ShouldBeNil)
+			tr.Fail(ctx, conf, "John", "", now)
+		}
+		e := tr.Check(ctx, conf, "john", "", now)
+		So(e, ShouldNotBeNil)
+		So(IsThrottled(e), ShouldBeTrue)
+		So(tr.Check(ctx, conf, "john", "", now.Add(conf.BaseDelay)), ShouldBeNil)
+		So(tr.Check(ctx, conf, "jane", "", now), ShouldBeNil)
+
+		tr.Fail(ctx, conf, "john", "", now)
+		So(tr.Check(ctx, conf, "john", "", now.Add(conf.BaseDelay)), ShouldNotBeNil)
+		So(tr.Check(ctx, conf, "john", "", now.Add(2*conf.BaseDelay)), ShouldBeNil)
+
+		tr.Reset(ctx, "john")
+		So(tr.Check(ctx, conf, "john", "", now), ShouldBeNil)
+	})
+
+	Convey("Test delays are capped", t, func() {
+		So(conf.delay(conf.DelayAfter-1), ShouldEqual, 0)
+		So(conf.delay(conf.DelayAfter), ShouldEqual, conf.BaseDelay)
+		So(conf.delay(conf.DelayAfter+3), ShouldEqual, 8*conf.BaseDelay)
+		So(conf.delay(1000), ShouldEqual, conf.MaxDelay)
+	})
+
+	Convey("Test IP throttling", t, func() {
+		tr := NewTracker(nil)
+		for i := 0; i < conf.IPMaxFailures-1; i++ {
+			So(tr.Fail(ctx, conf, "user"+string(rune('a'+i)), "10.0.0.1", now), ShouldBeFalse)
+		}
+		// Failures out of the window are forgotten
+		So(tr.Fail(ctx, conf, "other", "10.0.0.1", now.Add(conf.IPWindow)), ShouldBeFalse)
+		So(tr.Check(ctx, conf, "other", "10.0.0.1", now.Add(conf.IPWindow)), ShouldBeNil)
+
+		for i := 0; i < conf.IPMaxFailures-2; i++ {
+			tr.Fail(ctx, conf, "user"+string(rune('a'+i)), "10.0.0.1", now.Add(conf.IPWindow))
+		}
+		So(tr.Fail(ctx, conf, "last", "10.0.0.1", now.Add(conf.IPWindow)), ShouldBeTrue)
+		So(IsThrottled(tr.Check(ctx, conf, "someone", "10.0.0.1", now.Add(conf.IPWindow))), ShouldBeTrue)
+		So(tr.Check(ctx, conf, "someone", "10.0.0.2", now.Add(conf.IPWindow)), ShouldBeNil)
+		So(tr.Check(ctx, conf, "someone", "10.0.0.1", now.Add(conf.IPWindow+conf.IPBlock)), ShouldBeNil)
+	})
+
+	Convey("Test concurrent attempts are reserved", t, func() {
+		tr := NewTracker(nil)
+		var wg sync.WaitGroup
+		var allowed int32
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if tr.Check(ctx, conf, "john", "10.0.0.1", now) == nil {
+					atomic.AddInt32(&allowed, 1)
+				}
+			}()
+		}
+		wg.Wait()
+		So(allowed, ShouldEqual, conf.DelayAfter)
+
+		// Released attempts do not count as failures
+		for i := 0; i < conf.DelayAfter; i++ {
+			tr.Release(ctx, conf, "john", "10.0.0.1")
+		}
+		So(tr.Check(ctx, conf, "john", "10.0.0.1", now), ShouldBeNil)
+		tr.Release(ctx, conf, "john", "10.0.0.1")
+
+		// Pending attempts count for the IP limit
+		for i := 0; i < conf.IPMaxFailures; i++ {
+			So(tr.Check(ctx, conf, "user"+string(rune('a'+i)), "10.0.0.2", now), ShouldBeNil)
+		}
+		So(IsThrottled(tr.Check(ctx, conf, "other", "10.0.0.2", now)), ShouldBeTrue)
+
+		// Attempts never settled are forgotten
+		So(tr.Check(ctx, conf, "other", "10.0.0.2", now.Add(2*pendingTimeout)), ShouldBeNil)
+	})
+
+	Convey("Test trackers sharing counters", t, func() {
+		counters := NewMemoryCounters()
+		grants, challenges := NewTracker(counters), NewTracker(counters)
+		for i := 0; i < conf.DelayAfter; i++ {
+			grants.Fail(ctx, conf, "john", "", now)
+		}
+		So(grants.Check(ctx, conf, "john", "", now), ShouldNotBeNil)
+		challenges.Reset(ctx, "john")
+		So(grants.Check(ctx, conf, "john", "", now), ShouldBeNil)
+	})
+
+}
+
+func TestDetect(t *testing.T) {
+
+	conf := DefaultConfig()
+	paris := &Location{Country: "FR", CountryName: "France", City: "Paris", Latitude: 48.85, Longitude: 2.35, HasCoords: true}
+	lyon := &Location{Country: "FR", CountryName: "France", City: "Lyon", Latitude: 45.76, Longitude: 4.83, HasCoords: true}
+	versailles := &Location{Country: "FR", CountryName: "France", City: "Versailles", Latitude: 48.80, Longitude: 2.13, HasCoords: true}
+	tokyo := &Location{Country: "JP", CountryName: "Japan", City: "Tokyo", Latitude: 35.68, Longitude: 139.69, HasCoords: true}
+	now := time.Now()
+
+	Convey("Test first login is never reported", t, func() {
+		p := &Profile{UserUuid: "u"}
+		ev := &LoginEvent{Time: now.Unix(), Device: Fingerprint("Firefox"), Location: paris}
+		So(Detect(conf, p, ev), ShouldBeEmpty)
+		p.Record(conf, ev)
+		So(p.Devices, ShouldHaveLength, 1)
+		So(p.Countries, ShouldResemble, []string{"FR"})
+	})
+
+	Convey("Test new device and country", t, func() {
+		p := &Profile{UserUuid: "u"}
+		p.Record(conf, &LoginEvent{Time: now.Unix(), Device: Fingerprint("Firefox"), Location: paris})
+		So(Detect(conf, p, &LoginEvent{Time: now.Add(time.Hour).Unix(), Device: Fingerprint(" firefox"), Location: lyon}), ShouldBeEmpty)
+		So(Detect(conf, p, &LoginEvent{Time: now.Add(time.Hour).Unix(), Device: Fingerprint("Chrome"), Location: lyon}), ShouldResemble, []Anomaly{AnomalyNewDevice})
+		So(Detect(conf, p, &LoginEvent{Time: now.Add(48 * time.Hour).Unix(), Device: Fingerprint("Firefox"), Location: tokyo}), ShouldResemble, []Anomaly{AnomalyNewCountry})
+	})
+
+	Convey("Test impossible travel", t, func() {
+		p := &Profile{UserUuid: "u"}
+		p.Record(conf, &LoginEvent{Time: now.Unix(), Device: Fingerprint("Firefox"), Location: paris})
+		anomalies := Detect(conf, p, &LoginEvent{Time: now.Add(2 * time.Hour).Unix(), Device: Fingerprint("Firefox"), Location: tokyo})
+		So(anomalies, ShouldResemble, []Anomaly{AnomalyNewCountry, AnomalyImpossibleTravel})
+		So(JoinAnomalies(anomalies), ShouldEqual, "new-country,impossible-travel")
+		// Paris-Lyon in 5 minutes is too fast, Paris-Versailles stays under the minimal distance
+		So(Detect(conf, p, &LoginEvent{Time: now.Add(5 * time.Minute).Unix(), Device: Fingerprint("Firefox"), Location: lyon}), ShouldResemble, []Anomaly{AnomalyImpossibleTravel})
+		So(Detect(conf, p, &LoginEvent{Time: now.Add(5 * time.Minute).Unix(), Device: Fingerprint("Firefox"), Location: versailles}), ShouldBeEmpty)
+	})
+
+	Convey("Test devices are capped", t, func() {
+		conf := DefaultConfig()
+		conf.MaxDevices = 2
+		p := &Profile{UserUuid: "u"}
+		p.Record(conf, &LoginEvent{Time: 1, Device: "a"})
+		p.Record(conf, &LoginEvent{Time: 2, Device: "b"})
+		p.Record(conf, &LoginEvent{Time: 3, Device: "c"})
+		So(p.Devices, ShouldResemble, map[string]int64{"b": 2, "c": 3})
+	})
+
+}
@@ -0,0 +1,286 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package guard
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/micro/go-micro/errors"
+	"go.uber.org/zap"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/auth"
+	"github.com/pydio/cells/common/config"
+	"github.com/pydio/cells/common/log"
+	"github.com/pydio/cells/common/proto/idm"
+	"github.com/pydio/cells/common/proto/mailer"
+	"github.com/pydio/cells/common/registry"
+	servicecontext "github.com/pydio/cells/common/service/context"
+	"github.com/pydio/cells/common/utils/permissions"
+)
+
+// Guard implements auth.LoginGuard on top of a Tracker, and analyzes the successful logins.
+type Guard struct {
+	tracker *Tracker
+	store   Store
+	conf    func() Config
+
+	geoLock sync.Mutex
+	geoPath string
+	geo     *GeoDB
+}
+
+var defaultGuard *Guard
+
+func init() {
+	defaultGuard = NewGuard(NewDocStore(), NewDocCounters(), func() Config {
+		return LoadConfig(config.Get("services", common.ServiceGrpcNamespace_+common.ServiceOAuth, "guard"))
+	})
+	auth.RegisterLoginGuard(defaultGuard)
+}
+
+// Default returns the Guard registered on the password grants.
+func Default() *Guard {
+	return defaultGuard
+}
+
+// NewGuard creates a Guard reading its settings with the conf function each time it is used.
+func NewGuard(store Store, counters Counters, conf func() Config) *Guard {
+	return &Guard{
+		tracker: NewTracker(counters),
+		store:   store,
+		conf:    conf,
+	}
+}
+
+// Allow refuses attempts from a blocked IP, or for a login that must wait after its last failure.
+func (g *Guard) Allow(ctx context.Context, login string) error {
+	conf := g.conf()
+	if !conf.Enabled {
+		return nil
+	}
+	ip := remoteIP(ctx)
+	if e := g.tracker.Check(ctx, conf, login, ip, time.Now()); e != nil {
+		log.Auditer(ctx).Warn(
+			"Refused login attempt for ["+login+"] from "+ip+": "+errors.Parse(e.Error()).Detail,
+			log.GetAuditId(common.AuditLoginThrottled),
+			zap.String(common.KeyUsername, login),
+			zap.String(servicecontext.HttpMetaRemoteAddress, ip),
+		)
+		return e
+	}
+	return nil
+}
+
+// Failed records a failed attempt, and blocks the source IP if it sent too many of them.
+func (g *Guard) Failed(ctx context.Context, login string) {
+	conf := g.conf()
+	if !conf.Enabled {
+		return
+	}
+	ip := remoteIP(ctx)
+	if g.tracker.Fail(ctx, conf, login, ip, time.Now()) {
+		msg := fmt.Sprintf("Blocked logins from %s for %s after %d failed attempts", ip, conf.IPBlock, conf.IPMaxFailures)
+		log.Logger(ctx).Warn(msg)
+		log.Auditer(ctx).Error(
+			msg,
+			log.GetAuditId(common.AuditLoginThrottled),
+			zap.String(servicecontext.HttpMetaRemoteAddress, ip),
+		)
+	}
+}
+
+// Released settles an attempt allowed by Allow that did not fail.
+func (g *Guard) Released(ctx context.Context, login string) {
+	conf := g.conf()
+	if !conf.Enabled {
+		return
+	}
+	g.tracker.Release(ctx, conf, login, remoteIP(ctx))
+}
+
+// Succeeded is called when a user is accepted on a login challenge. It resets the failures of the user,
+// detects anomalies against its login history, reports them and checks the "oidc" policies with the result.
+// An error means that the login must be refused.
+func (g *Guard) Succeeded(ctx context.Context, userUuid string) error {
+	conf := g.conf()
+	if !conf.Enabled {
+		return nil
+	}
+	user, e := permissions.SearchUniqueUser(ctx, "", userUuid)
+	if e != nil || user == nil {
+		return nil
+	}
+	g.tracker.Reset(ctx, user.Login)
+
+	ip := remoteIP(ctx)
+	ua, _ := servicecontext.HttpMetaFromGrpcContext(ctx, servicecontext.HttpMetaUserAgent)
+	ev := &LoginEvent{
+		Time:      time.Now().Unix(),
+		IP:        ip,
+		UserAgent: ua,
+		Device:    Fingerprint(ua),
+	}
+	if geo := g.geoDB(ctx, conf); geo != nil {
+		ev.Location = geo.Lookup(ip)
+	}
+
+	profile, e := g.store.GetProfile(ctx, user.Uuid)
+	if e != nil {
+		log.Logger(ctx).Error("Cannot load login profile", user.ZapLogin(), zap.Error(e))
+		profile = &Profile{UserUuid: user.Uuid}
+	}
+	previous := profile.Last
+	anomalies := Detect(conf, profile, ev)
+
+	policyContext := map[string]string{PolicyLoginAnomalies: JoinAnomalies(anomalies)}
+	if ev.Location != nil {
+		policyContext[PolicyLoginCountry] = ev.Location.Country
+	}
+	denied := auth.CheckOIDCPoliciesWithContext(ctx, user, policyContext) != nil
+	if len(anomalies) > 0 {
+		g.alert(ctx, conf, user, ev, previous, anomalies, denied)
+	}
+	if denied {
+		return errors.Unauthorized(common.ServiceOAuth, "User %s is not authorized to log in", user.Login)
+	}
+
+	// Only record allowed logins, otherwise a refused device would be known at the next attempt
+	profile.Record(conf, ev)
+	if e := g.store.PutProfile(ctx, profile); e != nil {
+		log.Logger(ctx).Error("Cannot store login profile", user.ZapLogin(), zap.Error(e))
+	}
+	return nil
+}
+
+// alert reports anomalies in the audit log and sends them by mail to the user and the alert address.
+func (g *Guard) alert(ctx context.Context, conf Config, user *idm.User, ev, previous *LoginEvent, anomalies []Anomaly, denied bool) {
+	details := describe(ev, previous, anomalies)
+	msg := fmt.Sprintf("Unusual login for [%s] from %s: %s", user.Login, ev.IP, details)
+	if denied {
+		msg += " - login refused by policy"
+	}
+	log.Auditer(ctx).Warn(
+		msg,
+		log.GetAuditId(common.AuditLoginAnomaly),
+		user.ZapLogin(),
+		zap.String(common.KeyUserUuid, user.Uuid),
+		zap.String(servicecontext.HttpMetaRemoteAddress, ev.IP),
+		zap.String(PolicyLoginAnomalies, JoinAnomalies(anomalies)),
+	)
+
+	var to []*mailer.User
+	if email := user.Attributes[idm.UserAttrEmail]; conf.NotifyUser && email != "" {
+		name := user.Attributes[idm.UserAttrDisplayName]
+		if name == "" {
+			name = user.Login
+		}
+		to = append(to, &mailer.User{Uuid: user.Uuid, Name: name, Address: email})
+	}
+	if conf.AlertEmail != "" {
+		to = append(to, &mailer.User{Address: conf.AlertEmail})
+	}
+	location := "unknown location"
+	if ev.Location != nil {
+		location = ev.Location.label()
+	}
+	mailCli := mailer.NewMailerServiceClient(registry.GetClient(common.ServiceMailer))
+	for _, u := range to {
+		if _, e := mailCli.SendMail(ctx, &mailer.SendMailRequest{
+			InQueue: true,
+			Mail: &mailer.Mail{
+				To:         []*mailer.User{u},
+				TemplateId: "LoginAnomaly",
+				TemplateData: map[string]string{
+					"Login":     user.Login,
+					"Anomalies": details,
+					"Ip":        ev.IP,
+					"Location":  location,
+					"Device":    ev.UserAgent,
+					"Date":      time.Unix(ev.Time, 0).Format("2006-01-02 15:04 MST"),
+				},
+			},
+		}); e != nil {
+			log.Logger(ctx).Error("Cannot send login anomaly alert", user.ZapLogin(), zap.Error(e))
+		}
+	}
+}
+
+// describe builds a human readable list of the anomalies.
+func describe(ev, previous *LoginEvent, anomalies []Anomaly) string {
+	var parts []string
+	for _, a := range anomalies {
+		switch a {
+		case AnomalyNewDevice:
+			parts = append(parts, "new device")
+		case AnomalyNewCountry:
+			parts = append(parts, "new country ("+ev.Location.label()+")")
+		case AnomalyImpossibleTravel:
+			elapsed := time.Unix(ev.Time, 0).Sub(time.Unix(previous.Time, 0)).Round(time.Minute)
+			parts = append(parts, fmt.Sprintf("impossible travel of %.0f km in %s since the previous login from %s", Distance(previous.Location, ev.Location), elapsed, previous.Location.label()))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+func (l *Location) label() string {
+	name := l.CountryName
+	if name == "" {
+		name = l.Country
+	}
+	if l.City != "" && l.City != "-" {
+		return l.City + ", " + name
+	}
+	return name
+}
+
+// geoDB loads the GeoIP file the first time it is required, and again when its path changes.
+func (g *Guard) geoDB(ctx context.Context, conf Config) *GeoDB {
+	g.geoLock.Lock()
+	defer g.geoLock.Unlock()
+	if conf.GeoIPFile == "" {
+		return nil
+	}
+	if conf.GeoIPFile != g.geoPath {
+		g.geoPath = conf.GeoIPFile
+		g.geo = nil
+		if db, e := OpenGeoDB(conf.GeoIPFile); e != nil {
+			log.Logger(ctx).Error("Cannot load GeoIP database "+conf.GeoIPFile, zap.Error(e))
+		} else {
+			g.geo = db
+		}
+	}
+	return g.geo
+}
+
+func remoteIP(ctx context.Context) string {
+	addr, _ := servicecontext.HttpMetaFromGrpcContext(ctx, servicecontext.HttpMetaRemoteAddress)
+	addr = strings.TrimSpace(addr)
+	if host, _, e := net.SplitHostPort(addr); e == nil {
+		addr = host
+	}
+	return addr
+}
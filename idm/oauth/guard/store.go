@@ -0,0 +1,156 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package guard
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/micro/go-micro/client"
+
+	"github.com/pydio/cells/common"
+	defaults "github.com/pydio/cells/common/micro"
+	"github.com/pydio/cells/common/proto/docstore"
+)
+
+// Store persists the login profiles.
+type Store interface {
+	GetProfile(ctx context.Context, userUuid string) (*Profile, error)
+	PutProfile(ctx context.Context, p *Profile) error
+}
+
+type docStore struct {
+	cl client.Client
+}
+
+// NewDocStore creates a Store saving each profile as a JSON document in the docstore service.
+func NewDocStore() Store {
+	return &docStore{cl: defaults.NewClient()}
+}
+
+// NewDocCounters creates Counters saving the failed attempts in the docstore service, so that they are
+// shared by all the processes running the password grants and the login challenges.
+func NewDocCounters() Counters {
+	return &docStore{cl: defaults.NewClient()}
+}
+
+func (d *docStore) docs() docstore.DocStoreClient {
+	return docstore.NewDocStoreClient(common.ServiceGrpcNamespace_+common.ServiceDocStore, d.cl)
+}
+
+// GetProfile returns an empty profile if none is stored yet for this user.
+func (d *docStore) GetProfile(ctx context.Context, userUuid string) (*Profile, error) {
+	p := &Profile{UserUuid: userUuid}
+	resp, e := d.docs().GetDocument(ctx, &docstore.GetDocumentRequest{
+		StoreID:    common.DocStoreIdLoginProfiles,
+		DocumentID: userUuid,
+	})
+	if e != nil || resp.Document == nil || resp.Document.Data == "" {
+		return p, nil
+	}
+	if e := json.Unmarshal([]byte(resp.Document.Data), p); e != nil {
+		return nil, e
+	}
+	return p, nil
+}
+
+func (d *docStore) PutProfile(ctx context.Context, p *Profile) error {
+	data, e := json.Marshal(p)
+	if e != nil {
+		return e
+	}
+	_, e = d.docs().PutDocument(ctx, &docstore.PutDocumentRequest{
+		StoreID:    common.DocStoreIdLoginProfiles,
+		DocumentID: p.UserUuid,
+		Document: &docstore.Document{
+			ID:    p.UserUuid,
+			Owner: p.UserUuid,
+			Type:  docstore.DocumentType_JSON,
+			Data:  string(data),
+		},
+	})
+	return e
+}
+
+func (d *docStore) GetLogin(ctx context.Context, login string) (*LoginCounter, error) {
+	c := &LoginCounter{}
+	if found, e := d.getAttempts(ctx, "login:"+login, c); e != nil || !found {
+		return nil, e
+	}
+	return c, nil
+}
+
+func (d *docStore) PutLogin(ctx context.Context, login string, c *LoginCounter, ttl time.Duration) error {
+	return d.putAttempts(ctx, "login:"+login, c)
+}
+
+func (d *docStore) DeleteLogin(ctx context.Context, login string) error {
+	_, e := d.docs().DeleteDocuments(ctx, &docstore.DeleteDocumentsRequest{
+		StoreID:    common.DocStoreIdLoginAttempts,
+		DocumentID: "login:" + login,
+	})
+	return e
+}
+
+func (d *docStore) GetIP(ctx context.Context, ip string) (*IPCounter, error) {
+	c := &IPCounter{}
+	if found, e := d.getAttempts(ctx, "ip:"+ip, c); e != nil || !found {
+		return nil, e
+	}
+	return c, nil
+}
+
+func (d *docStore) PutIP(ctx context.Context, ip string, c *IPCounter, ttl time.Duration) error {
+	return d.putAttempts(ctx, "ip:"+ip, c)
+}
+
+// getAttempts reads a counter document. Documents have no TTL: expired counters are ignored by the Tracker.
+func (d *docStore) getAttempts(ctx context.Context, id string, target interface{}) (bool, error) {
+	resp, e := d.docs().GetDocument(ctx, &docstore.GetDocumentRequest{
+		StoreID:    common.DocStoreIdLoginAttempts,
+		DocumentID: id,
+	})
+	if e != nil || resp.Document == nil || resp.Document.Data == "" {
+		return false, nil
+	}
+	if e := json.Unmarshal([]byte(resp.Document.Data), target); e != nil {
+		return false, e
+	}
+	return true, nil
+}
+
+func (d *docStore) putAttempts(ctx context.Context, id string, counter interface{}) error {
+	data, e := json.Marshal(counter)
+	if e != nil {
+		return e
+	}
+	_, e = d.docs().PutDocument(ctx, &docstore.PutDocumentRequest{
+		StoreID:    common.DocStoreIdLoginAttempts,
+		DocumentID: id,
+		Document: &docstore.Document{
+			ID:   id,
+			Type: docstore.DocumentType_JSON,
+			Data: string(data),
+		},
+	})
+	return e
+}
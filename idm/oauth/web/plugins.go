@@ -54,6 +54,7 @@ func init() {
 			service.WithStorage(oauth.NewDAO, "idm_oauth_"),
 			service.WithHTTP(func() http.Handler {
 				router := mux.NewRouter()
+				saml := servicecontext.HttpMetaExtractorWrapper(newSamlRouter())
 
 				hh := config.GetSitesAllowedURLs()
 				for _, u := range hh {
@@ -85,6 +86,7 @@ func init() {
 						r.PathPrefix("/oidc-admin/").Handler(http.StripPrefix("/oidc-admin", servicecontext.HttpMetaExtractorWrapper(admin)))
 					}
 
+					r.PathPrefix("/oidc/saml/").Handler(http.StripPrefix("/oidc/saml", saml))
 					r.PathPrefix("/oidc/").Handler(http.StripPrefix("/oidc", servicecontext.HttpMetaExtractorWrapper(public)))
 				}
 
@@ -0,0 +1,395 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package web
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"html/template"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/gorilla/mux"
+	"github.com/micro/go-micro/client"
+	"github.com/ory/fosite"
+	"go.uber.org/zap"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/auth"
+	"github.com/pydio/cells/common/auth/claim"
+	"github.com/pydio/cells/common/auth/hydra"
+	"github.com/pydio/cells/common/log"
+	defaults "github.com/pydio/cells/common/micro"
+	"github.com/pydio/cells/common/proto/idm"
+	service "github.com/pydio/cells/common/service/proto"
+)
+
+var samlPostForm = template.Must(template.New("saml").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>Redirecting...</title></head>
+<body onload="document.forms[0].submit()">
+<form method="post" action="{{.URL}}">
+<input type="hidden" name="SAMLRequest" value="{{.Request}}"/>
+<input type="hidden" name="RelayState" value="{{.RelayState}}"/>
+<noscript><input type="submit" value="Continue"/></noscript>
+</form>
+</body>
+</html>`))
+
+// samlHandler serves the SAML Service Provider endpoints of the connectors of type saml,
+// mounted under /oidc/saml/{id}/.
+type samlHandler struct{}
+
+func newSamlRouter() http.Handler {
+	h := &samlHandler{}
+	r := mux.NewRouter()
+	r.HandleFunc("/{id}/metadata", h.metadata).Methods(http.MethodGet)
+	r.HandleFunc("/{id}/login", h.login).Methods(http.MethodGet)
+	r.HandleFunc("/{id}/acs", h.acs).Methods(http.MethodPost)
+	r.HandleFunc("/{id}/slo", h.slo).Methods(http.MethodGet)
+	return r
+}
+
+func (h *samlHandler) connector(w http.ResponseWriter, r *http.Request) (*auth.SamlConnector, bool) {
+	c, ok := auth.GetSamlConnector(mux.Vars(r)["id"])
+	if !ok {
+		http.Error(w, "Unknown SAML connector", http.StatusNotFound)
+	}
+	return c, ok
+}
+
+// metadata serves the SP metadata to be registered on the IdP.
+func (h *samlHandler) metadata(w http.ResponseWriter, r *http.Request) {
+	c, ok := h.connector(w, r)
+	if !ok {
+		return
+	}
+	data, err := c.Metadata()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/samlmetadata+xml")
+	_, _ = w.Write(data)
+}
+
+// login starts the SP-initiated flow for a hydra login challenge. The challenge is sent as RelayState,
+// and the AuthnRequest ID is derived from it so that the ACS can check the response without server-side state.
+func (h *samlHandler) login(w http.ResponseWriter, r *http.Request) {
+	c, ok := h.connector(w, r)
+	if !ok {
+		return
+	}
+	challenge := r.URL.Query().Get("login_challenge")
+	if challenge == "" {
+		http.Error(w, "Missing login_challenge parameter", http.StatusBadRequest)
+		return
+	}
+
+	ssoURL, samlRequest, err := c.POSTData(auth.Scopes{}, samlRequestID(r, challenge))
+	if err != nil {
+		log.Logger(r.Context()).Error("Cannot build SAML request", zap.Error(err))
+		http.Error(w, "Cannot build SAML request", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	_ = samlPostForm.Execute(w, map[string]string{
+		"URL":        ssoURL,
+		"Request":    samlRequest,
+		"RelayState": challenge,
+	})
+}
+
+// acs validates the IdP response, provisions the user and accepts the login challenge.
+func (h *samlHandler) acs(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	c, ok := h.connector(w, r)
+	if !ok {
+		return
+	}
+	challenge := r.PostFormValue("RelayState")
+	if challenge == "" {
+		http.Error(w, "Missing RelayState", http.StatusBadRequest)
+		return
+	}
+
+	ident, err := c.HandlePOST(auth.Scopes{}, r.PostFormValue("SAMLResponse"), samlRequestID(r, challenge))
+	if err != nil {
+		log.Logger(ctx).Error("Invalid SAML response", zap.String("connector", c.ID()), zap.Error(err))
+		http.Error(w, "Invalid SAML response", http.StatusUnauthorized)
+		return
+	}
+
+	user, err := h.provision(ctx, c, c.MapIdentity(ident))
+	if err != nil {
+		log.Logger(ctx).Error("Cannot provision SAML user", zap.String("login", ident.Username), zap.Error(err))
+		http.Error(w, "Cannot provision user", http.StatusForbidden)
+		return
+	}
+
+	code, err := auth.DefaultJWTVerifier().LoginChallengeCode(ctx, claim.Claims{
+		Subject: user.Uuid,
+		Name:    user.Login,
+		Email:   user.Attributes[idm.UserAttrEmail],
+	}, auth.SetChallenge(challenge))
+	if err != nil {
+		log.Logger(ctx).Error("Cannot accept login challenge", zap.Error(err))
+		http.Error(w, "Cannot accept login challenge", http.StatusInternalServerError)
+		return
+	}
+
+	login, err := hydra.GetLogin(ctx, challenge)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	requestURL, err := url.Parse(login.GetRequestURL())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	requestURLValues := requestURL.Query()
+	redirectURL, err := fosite.GetRedirectURIFromRequestValues(requestURLValues)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	log.Auditer(ctx).Info(
+		fmt.Sprintf("User %s logged in through SAML connector %s", user.Login, c.ID()),
+		log.GetAuditId(common.AuditLoginSucceed),
+		user.ZapUuid(),
+	)
+
+	http.Redirect(w, r, redirectURL+"?code="+code+"&state="+url.QueryEscape(requestURLValues.Get("state")), http.StatusFound)
+}
+
+// slo handles both the IdP answer to a LogoutRequest sent at logout, and IdP-initiated logouts.
+func (h *samlHandler) slo(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	c, ok := h.connector(w, r)
+	if !ok {
+		return
+	}
+	query := r.URL.Query()
+
+	if query.Get("SAMLResponse") != "" {
+		target := "/"
+		if rs := query.Get("RelayState"); strings.HasPrefix(rs, "/") && !strings.HasPrefix(rs, "//") {
+			target = rs
+		}
+		http.Redirect(w, r, target, http.StatusFound)
+		return
+	}
+
+	lr, err := c.ParseLogoutRequest(r.URL.RawQuery)
+	if err != nil {
+		log.Logger(ctx).Error("Invalid SAML logout request", zap.String("connector", c.ID()), zap.Error(err))
+		http.Error(w, "Invalid SAML logout request", http.StatusBadRequest)
+		return
+	}
+
+	users, err := searchUsers(ctx, &idm.UserSingleQuery{AttributeName: auth.SamlAttrNameID, AttributeValue: lr.NameID})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	for _, u := range users {
+		if u.Attributes[idm.UserAttrAuthSource] != c.ID() {
+			continue
+		}
+		if err := auth.GetRegistry().ConsentManager().RevokeSubjectLoginSession(ctx, u.Uuid); err != nil {
+			log.Logger(ctx).Warn("Cannot revoke login sessions", u.ZapLogin(), zap.Error(err))
+		}
+		if err := auth.GetRegistry().ConsentManager().RevokeSubjectConsentSession(ctx, u.Uuid); err != nil {
+			log.Logger(ctx).Warn("Cannot revoke consent sessions", u.ZapLogin(), zap.Error(err))
+		}
+		client.Publish(ctx, client.NewPublication(common.TopicIdmEvent, &idm.ChangeEvent{
+			Type: idm.ChangeEventType_LOGOUT,
+			User: &idm.User{Login: u.Login},
+		}))
+	}
+
+	target, err := c.LogoutResponseURL(lr.ID, query.Get("RelayState"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	http.Redirect(w, r, target, http.StatusFound)
+}
+
+// provision creates the user at first login, or refreshes its mapped attributes and roles.
+// New users are created in the group path computed by the connector, existing users are not moved.
+func (h *samlHandler) provision(ctx context.Context, c *auth.SamlConnector, su *auth.SamlUser) (*idm.User, error) {
+	users, err := searchUsers(ctx, &idm.UserSingleQuery{Login: su.Login})
+	if err != nil {
+		return nil, err
+	}
+
+	var user *idm.User
+	var previous []string
+	if len(users) > 0 {
+		user = users[0]
+		if src := user.Attributes[idm.UserAttrAuthSource]; src != c.ID() {
+			return nil, fmt.Errorf("user %s already exists and is not managed by connector %s", su.Login, c.ID())
+		}
+		if prev := user.Attributes[auth.SamlAttrRoles]; prev != "" {
+			previous = strings.Split(prev, ",")
+		}
+	} else {
+		user = &idm.User{
+			Login:      su.Login,
+			GroupPath:  su.GroupPath,
+			Attributes: map[string]string{},
+		}
+	}
+	for k, v := range su.Attributes {
+		user.Attributes[k] = v
+	}
+
+	// Keep roles set by administrators, replace the ones granted at previous login
+	var roles []*idm.Role
+	for _, r := range user.Roles {
+		if r.GroupRole || r.UserRole || len(r.AutoApplies) > 0 || contains(previous, r.Uuid) || contains(su.Roles, r.Uuid) {
+			continue
+		}
+		roles = append(roles, r)
+	}
+	if err := ensureRoles(ctx, su.Roles); err != nil {
+		return nil, err
+	}
+	for _, id := range su.Roles {
+		roles = append(roles, &idm.Role{Uuid: id})
+	}
+	user.Roles = roles
+	user.Attributes[auth.SamlAttrRoles] = strings.Join(su.Roles, ",")
+
+	userClient := idm.NewUserServiceClient(common.ServiceGrpcNamespace_+common.ServiceUser, defaults.NewClient())
+	resp, err := userClient.CreateUser(ctx, &idm.CreateUserRequest{User: user})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(users) == 0 {
+		roleClient := idm.NewRoleServiceClient(common.ServiceGrpcNamespace_+common.ServiceRole, defaults.NewClient())
+		if _, err := roleClient.CreateRole(ctx, &idm.CreateRoleRequest{Role: &idm.Role{
+			Uuid:     resp.User.Uuid,
+			UserRole: true,
+			Label:    "User " + resp.User.Login,
+			Policies: []*service.ResourcePolicy{
+				{Subject: "profile:standard", Action: service.ResourcePolicyAction_READ, Effect: service.ResourcePolicy_allow},
+				{Subject: "user:" + resp.User.Login, Action: service.ResourcePolicyAction_WRITE, Effect: service.ResourcePolicy_allow},
+				{Subject: "profile:admin", Action: service.ResourcePolicyAction_WRITE, Effect: service.ResourcePolicy_allow},
+			},
+		}}); err != nil {
+			return nil, err
+		}
+		log.Auditer(ctx).Info(
+			fmt.Sprintf("Created user %s from SAML connector %s", resp.User.Login, c.ID()),
+			log.GetAuditId(common.AuditUserCreate),
+			resp.User.ZapUuid(),
+		)
+	}
+
+	return resp.User, nil
+}
+
+// ensureRoles creates the mapped roles that do not exist yet.
+func ensureRoles(ctx context.Context, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	roleClient := idm.NewRoleServiceClient(common.ServiceGrpcNamespace_+common.ServiceRole, defaults.NewClient())
+	q, _ := ptypes.MarshalAny(&idm.RoleSingleQuery{Uuid: ids})
+	stream, err := roleClient.SearchRole(ctx, &idm.SearchRoleRequest{Query: &service.Query{SubQueries: []*any.Any{q}}})
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+	found := make(map[string]bool)
+	for {
+		resp, er := stream.Recv()
+		if er == io.EOF {
+			break
+		} else if er != nil {
+			return er
+		}
+		if resp != nil && resp.Role != nil {
+			found[resp.Role.Uuid] = true
+		}
+	}
+	for _, id := range ids {
+		if found[id] {
+			continue
+		}
+		if _, err := roleClient.CreateRole(ctx, &idm.CreateRoleRequest{Role: &idm.Role{Uuid: id, Label: id}}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func searchUsers(ctx context.Context, query *idm.UserSingleQuery) (users []*idm.User, e error) {
+	q, _ := ptypes.MarshalAny(query)
+	userClient := idm.NewUserServiceClient(common.ServiceGrpcNamespace_+common.ServiceUser, defaults.NewClient())
+	stream, e := userClient.SearchUser(ctx, &idm.SearchUserRequest{Query: &service.Query{SubQueries: []*any.Any{q}}})
+	if e != nil {
+		return nil, e
+	}
+	defer stream.Close()
+	for {
+		resp, er := stream.Recv()
+		if er == io.EOF {
+			break
+		} else if er != nil {
+			return nil, er
+		}
+		if resp != nil && resp.User != nil {
+			users = append(users, resp.User)
+		}
+	}
+	return users, nil
+}
+
+// samlRequestID derives the AuthnRequest ID from the login challenge and the system secret.
+func samlRequestID(r *http.Request, challenge string) string {
+	mac := hmac.New(sha256.New, auth.GetConfigurationProvider(r.Host).GetSystemSecret())
+	mac.Write([]byte("saml:" + challenge))
+	return "_" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func contains(list []string, s string) bool {
+	for _, l := range list {
+		if l == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,75 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package conditions
+
+import (
+	"path"
+	"strings"
+
+	"github.com/ory/ladon"
+)
+
+const (
+	// SubjectGroupPathKey is the request context key holding the group path of the user performing the request
+	SubjectGroupPathKey = "SubjectGroupPath"
+)
+
+// GroupSubtreeCondition is a condition which is fulfilled if the given group path is inside a subtree.
+// The subtree is the Subtree option if set, or else the group path of the user performing the request,
+// as passed in the request context.
+type GroupSubtreeCondition struct {
+	Subtree string `json:"subtree"`
+}
+
+// Fulfills returns true if the given value is a group path located inside the subtree.
+func (c *GroupSubtreeCondition) Fulfills(value interface{}, r *ladon.Request) bool {
+
+	s, ok := value.(string)
+	if !ok || s == "" {
+		return false
+	}
+	subtree := c.Subtree
+	if subtree == "" && r != nil {
+		if v, o := r.Context[SubjectGroupPathKey].(string); o {
+			subtree = v
+		}
+	}
+	if subtree == "" {
+		return false
+	}
+	return IsInGroupSubtree(s, subtree)
+
+}
+
+// GetName returns the condition's name.
+func (c *GroupSubtreeCondition) GetName() string {
+	return "GroupSubtreeCondition"
+}
+
+// IsInGroupSubtree checks if groupPath is equal to or a descendant of subtree.
+func IsInGroupSubtree(groupPath, subtree string) bool {
+	groupPath = path.Clean("/" + groupPath)
+	subtree = path.Clean("/" + subtree)
+	if subtree == "/" {
+		return true
+	}
+	return groupPath == subtree || strings.HasPrefix(groupPath, subtree+"/")
+}
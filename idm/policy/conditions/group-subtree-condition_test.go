@@ -0,0 +1,95 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package conditions
+
+import (
+	"testing"
+
+	"github.com/ory/ladon"
+	"github.com/ory/ladon/manager/memory"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestGroupSubtreeCondition(t *testing.T) {
+
+	Convey("Canonical subtree tests", t, func() {
+
+		for _, c := range []struct {
+			subtree string
+			subject string
+			value   interface{}
+			pass    bool
+		}{
+			{subtree: "/sales", value: "/sales", pass: true},
+			{subtree: "/sales", value: "/sales/emea", pass: true},
+			{subtree: "/sales/", value: "/sales/emea/", pass: true},
+			{subtree: "/sales", value: "/salesforce", pass: false},
+			{subtree: "/sales", value: "/", pass: false},
+			{subtree: "/", value: "/it", pass: true},
+			{subject: "/sales", value: "/sales/emea", pass: true},
+			{subject: "/sales", value: "/it", pass: false},
+			{subtree: "/it", subject: "/sales", value: "/sales/emea", pass: false},
+			{value: "/sales", pass: false},
+			{subtree: "/", value: "", pass: false},
+			{subtree: "/", value: 12, pass: false},
+		} {
+			condition := &GroupSubtreeCondition{Subtree: c.subtree}
+			req := &ladon.Request{Context: ladon.Context{}}
+			if c.subject != "" {
+				req.Context[SubjectGroupPathKey] = c.subject
+			}
+			So(condition.Fulfills(c.value, req), ShouldEqual, c.pass)
+		}
+	})
+}
+
+func TestGroupSubtreePolicy(t *testing.T) {
+
+	Convey("Test delegated administration policy", t, func() {
+
+		warden := &ladon.Ladon{Manager: memory.NewMemoryManager()}
+		So(warden.Manager.Create(&ladon.DefaultPolicy{
+			ID:        "delegated",
+			Subjects:  []string{"role:GROUP_ADMINS"},
+			Resources: []string{"delegation:user"},
+			Actions:   []string{"write"},
+			Effect:    ladon.AllowAccess,
+			Conditions: ladon.Conditions{
+				"TargetGroupPath": &GroupSubtreeCondition{},
+			},
+		}), ShouldBeNil)
+
+		request := func(subject, target string) *ladon.Request {
+			return &ladon.Request{
+				Subject:  "role:GROUP_ADMINS",
+				Resource: "delegation:user",
+				Action:   "write",
+				Context: ladon.Context{
+					SubjectGroupPathKey: subject,
+					"TargetGroupPath":   target,
+				},
+			}
+		}
+		So(warden.IsAllowed(request("/sales", "/sales/emea")), ShouldBeNil)
+		So(warden.IsAllowed(request("/sales", "/marketing")), ShouldNotBeNil)
+		So(warden.IsAllowed(&ladon.Request{Subject: "role:GROUP_ADMINS", Resource: "delegation:user", Action: "write"}), ShouldNotBeNil)
+	})
+}
@@ -148,8 +148,11 @@ var (
 						"rest:/tree/selection",
 						"rest:/tree/stat/<.+>",
 						"rest:/tree/stats",
+						"rest:/tree/versions/diff",
 						"rest:/templates",
 						"rest:/auth/token/document",
+						"rest:/access-review/tasks",
+						"rest:/access-review/tasks/<.+>",
 					},
 					Actions: []string{"GET", "POST", "DELETE", "PUT", "PATCH"},
 					Effect:  ladon.AllowAccess,
@@ -202,6 +205,9 @@ var (
 			},
 		},
 
+		// Delegated administration of a group subtree
+		DelegatedAdminPolicyGroup,
+
 		{
 			Uuid:          "oidc-actions-policies",
 			Name:          "PolicyGroup.OIDC.Title",
@@ -381,6 +387,54 @@ var (
 	}
 )
 
+// DelegatedAdminPolicyGroup lets members of the GROUP_ADMINS role manage the users and groups located
+// inside their own group, and assign them the roles matched by the delegated-admin-roles rule.
+var DelegatedAdminPolicyGroup = &idm.PolicyGroup{
+	Uuid:          "delegated-admin-policies",
+	Name:          "PolicyGroup.DelegatedAdmin.Title",
+	Description:   "PolicyGroup.DelegatedAdmin.Description",
+	ResourceGroup: idm.PolicyResourceGroup_rest,
+	Policies: []*idm.Policy{
+		converter.LadonToProtoPolicy(&ladon.DefaultPolicy{
+			ID:          "delegated-admin-rest",
+			Description: "PolicyGroup.DelegatedAdmin.Rule1",
+			Subjects:    []string{"role:GROUP_ADMINS"},
+			Resources: []string{
+				"rest:/acl",
+				"rest:/acl/<.+>",
+				"rest:/log/sys",
+			},
+			Actions: []string{"POST", "PUT", "DELETE"},
+			Effect:  ladon.AllowAccess,
+		}),
+		converter.LadonToProtoPolicy(&ladon.DefaultPolicy{
+			ID:          "delegated-admin-users",
+			Description: "PolicyGroup.DelegatedAdmin.Rule2",
+			Subjects:    []string{"role:GROUP_ADMINS"},
+			Resources:   []string{permissions.DelegationResourceUser},
+			Actions:     []string{permissions.DelegationActionWrite},
+			Effect:      ladon.AllowAccess,
+			Conditions: ladon.Conditions{
+				permissions.PolicyTargetGroupPath: &conditions.GroupSubtreeCondition{},
+			},
+		}),
+		converter.LadonToProtoPolicy(&ladon.DefaultPolicy{
+			ID:          "delegated-admin-roles",
+			Description: "PolicyGroup.DelegatedAdmin.Rule3",
+			Subjects:    []string{"role:GROUP_ADMINS"},
+			Resources:   []string{permissions.DelegationResourceRole},
+			Actions:     []string{permissions.DelegationActionAssign},
+			Effect:      ladon.AllowAccess,
+			Conditions: ladon.Conditions{
+				// Whitelist of assignable roles UUIDs, none by default
+				permissions.PolicyRoleId: &ladon.StringMatchCondition{
+					Matches: "^$",
+				},
+			},
+		}),
+	},
+}
+
 // InitDefaults is called once at first launch to create default policy groups.
 func InitDefaults(ctx context.Context) error {
 
@@ -773,3 +827,80 @@ func Upgrade227(ctx context.Context) error {
 	}
 	return nil
 }
+
+// Upgrade301 adds resources introduced in v3.0.1 to the default user policy.
+func Upgrade301(ctx context.Context) error {
+	dao := servicecontext.GetDAO(ctx).(DAO)
+	if dao == nil {
+		return fmt.Errorf("cannot find DAO for policies initialization")
+	}
+	groups, e := dao.ListPolicyGroups(ctx)
+	if e != nil {
+		return e
+	}
+	for _, group := range groups {
+		if group.Uuid == "rest-apis-default-accesses" {
+			for _, p := range group.Policies {
+				if p.Id == "user-default-policy" {
+					p.Resources = append(p.Resources, "rest:/tree/versions/diff")
+				}
+			}
+			if _, er := dao.StorePolicyGroup(ctx, group); er != nil {
+				log.Logger(ctx).Error("could not update policy group "+group.Uuid, zap.Error(er))
+			} else {
+				log.Logger(ctx).Info("Updated policy group " + group.Uuid)
+			}
+		}
+	}
+	return nil
+}
+
+// Upgrade302 adds the delegated administration policies introduced in v3.0.2.
+func Upgrade302(ctx context.Context) error {
+	dao := servicecontext.GetDAO(ctx).(DAO)
+	if dao == nil {
+		return fmt.Errorf("cannot find DAO for policies initialization")
+	}
+	groups, e := dao.ListPolicyGroups(ctx)
+	if e != nil {
+		return e
+	}
+	for _, group := range groups {
+		if group.Uuid == DelegatedAdminPolicyGroup.Uuid {
+			return nil
+		}
+	}
+	if _, er := dao.StorePolicyGroup(ctx, DelegatedAdminPolicyGroup); er != nil {
+		log.Logger(ctx).Error("could not store policy group "+DelegatedAdminPolicyGroup.Uuid, zap.Error(er))
+		return er
+	}
+	log.Logger(ctx).Info("Inserted policy group " + DelegatedAdminPolicyGroup.Uuid)
+	return nil
+}
+
+// Upgrade303 gives users access to their access review tasks, introduced in v3.0.3.
+func Upgrade303(ctx context.Context) error {
+	dao := servicecontext.GetDAO(ctx).(DAO)
+	if dao == nil {
+		return fmt.Errorf("cannot find DAO for policies initialization")
+	}
+	groups, e := dao.ListPolicyGroups(ctx)
+	if e != nil {
+		return e
+	}
+	for _, group := range groups {
+		if group.Uuid == "rest-apis-default-accesses" {
+			for _, p := range group.Policies {
+				if p.Id == "user-default-policy" {
+					p.Resources = append(p.Resources, "rest:/access-review/tasks", "rest:/access-review/tasks/<.+>")
+				}
+			}
+			if _, er := dao.StorePolicyGroup(ctx, group); er != nil {
+				log.Logger(ctx).Error("could not update policy group "+group.Uuid, zap.Error(er))
+			} else {
+				log.Logger(ctx).Info("Updated policy group " + group.Uuid)
+			}
+		}
+	}
+	return nil
+}
@@ -82,6 +82,18 @@ func init() {
 					TargetVersion: service.ValidVersion("2.2.7"),
 					Up:            policy.Upgrade227,
 				},
+				{
+					TargetVersion: service.ValidVersion("3.0.1"),
+					Up:            policy.Upgrade301,
+				},
+				{
+					TargetVersion: service.ValidVersion("3.0.2"),
+					Up:            policy.Upgrade302,
+				},
+				{
+					TargetVersion: service.ValidVersion("3.0.3"),
+					Up:            policy.Upgrade303,
+				},
 			}),
 			service.WithMicro(func(m micro.Service) error {
 				handler := new(Handler)
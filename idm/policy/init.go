@@ -50,4 +50,8 @@ func init() {
 		return new(conditions.DateAfterCondition)
 	}
 
+	ladon.ConditionFactories[new(conditions.GroupSubtreeCondition).GetName()] = func() ladon.Condition {
+		return new(conditions.GroupSubtreeCondition)
+	}
+
 }
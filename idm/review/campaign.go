@@ -0,0 +1,287 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+// Package review implements access review campaigns: resources owners are periodically asked
+// to confirm or revoke each access granted on workspaces, cells, public links or to the users of a group.
+package review
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/micro/go-micro/errors"
+
+	"github.com/pydio/cells/common"
+)
+
+// Scope defines which kind of grants are reviewed by a campaign.
+type Scope string
+
+const (
+	// ScopeWorkspaces reviews the accesses to the admin-defined workspaces
+	ScopeWorkspaces Scope = "workspaces"
+	// ScopeCells reviews the members of the cells
+	ScopeCells Scope = "cells"
+	// ScopeLinks reviews the public links
+	ScopeLinks Scope = "links"
+	// ScopeGroup reviews the workspaces accesses of the users of a group
+	ScopeGroup Scope = "group"
+)
+
+// Status is the lifecycle status of a campaign.
+type Status string
+
+const (
+	// StatusDraft is a campaign that is defined but not started yet
+	StatusDraft Status = "draft"
+	// StatusActive is a campaign that has sent its tasks to the reviewers
+	StatusActive Status = "active"
+	// StatusClosed is a campaign whose decisions have been applied
+	StatusClosed Status = "closed"
+)
+
+// Decision is the outcome of a review task.
+type Decision string
+
+const (
+	// DecisionPending is a task that has not been reviewed yet
+	DecisionPending Decision = ""
+	// DecisionApprove keeps the access
+	DecisionApprove Decision = "approve"
+	// DecisionRevoke removes the access at the end of the campaign
+	DecisionRevoke Decision = "revoke"
+)
+
+// GrantKind tells how a grant is stored, thus how it must be revoked.
+type GrantKind string
+
+const (
+	// GrantAcl is a set of ACLs given to a role on a workspace
+	GrantAcl GrantKind = "acl"
+	// GrantLink is a public link, revoking it deletes the link
+	GrantLink GrantKind = "link"
+)
+
+// Campaign is an access review: a set of tasks sent to reviewers, that must be completed before the deadline.
+type Campaign struct {
+	Uuid        string
+	Label       string
+	Description string `json:",omitempty"`
+	Scope       Scope
+	// WorkspaceUuids optionally restricts the workspaces, cells or links scopes
+	WorkspaceUuids []string `json:",omitempty"`
+	// GroupPath is the group reviewed by the ScopeGroup scope
+	GroupPath string `json:",omitempty"`
+	// Reviewer is the uuid of the user reviewing the grants that have no owner (e.g. admin workspaces)
+	Reviewer string `json:",omitempty"`
+	// DefaultDecision is applied to the tasks still pending at the deadline, approve by default
+	DefaultDecision Decision `json:",omitempty"`
+	Deadline        int64
+	Status          Status
+	Creator         string
+	Created         int64
+	Started         int64   `json:",omitempty"`
+	Closed          int64   `json:",omitempty"`
+	Tasks           []*Task `json:",omitempty"`
+}
+
+// Task asks a reviewer whether a grant must be kept or revoked.
+type Task struct {
+	Uuid           string
+	Kind           GrantKind
+	WorkspaceUuid  string
+	WorkspaceLabel string
+	// RoleId is the role holding the ACLs, or the hidden user of a public link
+	RoleId       string
+	SubjectLabel string
+	Rights       string
+	ReviewerUuid string
+	Reviewer     string
+	Decision     Decision `json:",omitempty"`
+	Comment      string   `json:",omitempty"`
+	DecidedBy    string   `json:",omitempty"`
+	DecidedAt    int64    `json:",omitempty"`
+	// Applied is set once a revocation has been applied, ApplyError if it failed
+	Applied    bool   `json:",omitempty"`
+	ApplyError string `json:",omitempty"`
+}
+
+// Progress counts the tasks of a campaign by decision.
+type Progress struct {
+	Total    int
+	Pending  int
+	Approved int
+	Revoked  int
+}
+
+// Revoker removes the access described by a task.
+type Revoker interface {
+	Revoke(ctx context.Context, task *Task) error
+}
+
+// Validate checks the campaign definition before it is stored.
+func (c *Campaign) Validate() error {
+	if strings.TrimSpace(c.Label) == "" {
+		return errors.BadRequest(common.ServiceReview, "please provide a label for the campaign")
+	}
+	switch c.Scope {
+	case ScopeWorkspaces, ScopeCells, ScopeLinks:
+	case ScopeGroup:
+		if c.GroupPath == "" {
+			return errors.BadRequest(common.ServiceReview, "please provide the group to review")
+		}
+	default:
+		return errors.BadRequest(common.ServiceReview, "unsupported campaign scope %s", c.Scope)
+	}
+	if c.DefaultDecision != DecisionPending && c.DefaultDecision != DecisionApprove && c.DefaultDecision != DecisionRevoke {
+		return errors.BadRequest(common.ServiceReview, "default decision must be either approve or revoke")
+	}
+	if c.Deadline == 0 {
+		return errors.BadRequest(common.ServiceReview, "please provide a deadline for the campaign")
+	}
+	return nil
+}
+
+// Start attaches the tasks to the campaign and makes them visible to the reviewers.
+func (c *Campaign) Start(tasks []*Task, now time.Time) error {
+	if c.Status != StatusDraft {
+		return errors.BadRequest(common.ServiceReview, "campaign is already %s", c.Status)
+	}
+	if c.Deadline <= now.Unix() {
+		return errors.BadRequest(common.ServiceReview, "campaign deadline is already over")
+	}
+	c.Tasks = tasks
+	c.Status = StatusActive
+	c.Started = now.Unix()
+	return nil
+}
+
+// Decide records the decision of a reviewer on one task. Administrators can decide on any task.
+func (c *Campaign) Decide(taskId string, userUuid string, userLogin string, admin bool, decision Decision, comment string, now time.Time) (*Task, error) {
+	if c.Status != StatusActive {
+		return nil, errors.BadRequest(common.ServiceReview, "campaign is not active")
+	}
+	if decision != DecisionApprove && decision != DecisionRevoke {
+		return nil, errors.BadRequest(common.ServiceReview, "decision must be either approve or revoke")
+	}
+	for _, t := range c.Tasks {
+		if t.Uuid != taskId {
+			continue
+		}
+		if !admin && t.ReviewerUuid != userUuid {
+			return nil, errors.Forbidden(common.ServiceReview, "you are not the reviewer of this task")
+		}
+		t.Decision = decision
+		t.Comment = comment
+		t.DecidedBy = userLogin
+		t.DecidedAt = now.Unix()
+		return t, nil
+	}
+	return nil, errors.NotFound(common.ServiceReview, "cannot find task %s", taskId)
+}
+
+// IsOverdue tells whether an active campaign has reached its deadline.
+func (c *Campaign) IsOverdue(now time.Time) bool {
+	return c.Status == StatusActive && now.Unix() >= c.Deadline
+}
+
+// Effective returns the decision applied to the task when the campaign is closed.
+func (c *Campaign) Effective(t *Task) Decision {
+	if t.Decision != DecisionPending {
+		return t.Decision
+	}
+	if c.DefaultDecision == DecisionRevoke {
+		return DecisionRevoke
+	}
+	return DecisionApprove
+}
+
+// TasksFor lists the tasks assigned to a reviewer.
+func (c *Campaign) TasksFor(reviewerUuid string) (tasks []*Task) {
+	for _, t := range c.Tasks {
+		if t.ReviewerUuid == reviewerUuid {
+			tasks = append(tasks, t)
+		}
+	}
+	return
+}
+
+// Progress counts the tasks by decision.
+func (c *Campaign) Progress() Progress {
+	p := Progress{Total: len(c.Tasks)}
+	for _, t := range c.Tasks {
+		switch t.Decision {
+		case DecisionApprove:
+			p.Approved++
+		case DecisionRevoke:
+			p.Revoked++
+		default:
+			p.Pending++
+		}
+	}
+	return p
+}
+
+// Close applies the revocations of an active campaign and marks it as closed.
+// Failed revocations are recorded on their task and do not stop the others.
+func (c *Campaign) Close(ctx context.Context, revoker Revoker, now time.Time) (revoked int, failed int, e error) {
+	if c.Status != StatusActive {
+		return 0, 0, fmt.Errorf("campaign %s is not active", c.Uuid)
+	}
+	for _, t := range c.Tasks {
+		if c.Effective(t) != DecisionRevoke || t.Applied {
+			continue
+		}
+		if er := revoker.Revoke(ctx, t); er != nil {
+			t.ApplyError = er.Error()
+			failed++
+			continue
+		}
+		t.Applied = true
+		t.ApplyError = ""
+		revoked++
+	}
+	c.Status = StatusClosed
+	c.Closed = now.Unix()
+	return
+}
+
+// CloseOverdue closes all the active campaigns whose deadline is over, and returns the closed campaigns.
+func CloseOverdue(ctx context.Context, store Store, revoker Revoker, now time.Time) (closed []*Campaign, e error) {
+	campaigns, e := store.ListCampaigns(ctx)
+	if e != nil {
+		return nil, e
+	}
+	for _, c := range campaigns {
+		if !c.IsOverdue(now) {
+			continue
+		}
+		if _, _, er := c.Close(ctx, revoker, now); er != nil {
+			return closed, er
+		}
+		if er := store.PutCampaign(ctx, c); er != nil {
+			return closed, er
+		}
+		closed = append(closed, c)
+	}
+	return closed, nil
+}
@@ -0,0 +1,216 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package review
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/pydio/cells/common/proto/idm"
+	service "github.com/pydio/cells/common/service/proto"
+	"github.com/pydio/cells/common/utils/permissions"
+)
+
+type fakeRevoker struct {
+	revoked []string
+	fail    map[string]bool
+}
+
+func (f *fakeRevoker) Revoke(ctx context.Context, task *Task) error {
+	if f.fail[task.Uuid] {
+		return fmt.Errorf("cannot revoke")
+	}
+	f.revoked = append(f.revoked, task.Uuid)
+	return nil
+}
+
+func testCampaign(now time.Time) *Campaign {
+	return &Campaign{
+		Uuid:     "campaign",
+		Label:    "Quarterly review",
+		Scope:    ScopeCells,
+		Reviewer: "admin-uuid",
+		Deadline: now.Add(24 * time.Hour).Unix(),
+		Status:   StatusDraft,
+	}
+}
+
+func TestCampaign_Lifecycle(t *testing.T) {
+
+	now := time.Now()
+
+	Convey("Test campaign validation", t, func() {
+		c := testCampaign(now)
+		So(c.Validate(), ShouldBeNil)
+		c.Scope = "everything"
+		So(c.Validate(), ShouldNotBeNil)
+		c.Scope = ScopeGroup
+		So(c.Validate(), ShouldNotBeNil)
+		c.GroupPath = "/sales"
+		So(c.Validate(), ShouldBeNil)
+		c.DefaultDecision = "maybe"
+		So(c.Validate(), ShouldNotBeNil)
+		c.DefaultDecision = DecisionRevoke
+		c.Deadline = 0
+		So(c.Validate(), ShouldNotBeNil)
+	})
+
+	Convey("Test start and decisions", t, func() {
+		c := testCampaign(now)
+		So(c.Start([]*Task{
+			{Uuid: "t1", ReviewerUuid: "owner1"},
+			{Uuid: "t2", ReviewerUuid: "owner2"},
+		}, now), ShouldBeNil)
+		So(c.Status, ShouldEqual, StatusActive)
+		So(c.Start(nil, now), ShouldNotBeNil)
+		So(c.TasksFor("owner1"), ShouldHaveLength, 1)
+
+		_, e := c.Decide("t1", "owner2", "owner2", false, DecisionRevoke, "", now)
+		So(e, ShouldNotBeNil)
+		_, e = c.Decide("t1", "owner1", "owner1", false, "delete", "", now)
+		So(e, ShouldNotBeNil)
+		_, e = c.Decide("unknown", "owner1", "owner1", false, DecisionRevoke, "", now)
+		So(e, ShouldNotBeNil)
+		task, e := c.Decide("t1", "owner1", "owner1", false, DecisionRevoke, "left the company", now)
+		So(e, ShouldBeNil)
+		So(task.DecidedBy, ShouldEqual, "owner1")
+		_, e = c.Decide("t2", "admin-uuid", "admin", true, DecisionApprove, "", now)
+		So(e, ShouldBeNil)
+		So(c.Progress(), ShouldResemble, Progress{Total: 2, Approved: 1, Revoked: 1})
+	})
+
+	Convey("Test start after deadline", t, func() {
+		c := testCampaign(now)
+		So(c.Start(nil, now.Add(48*time.Hour)), ShouldNotBeNil)
+	})
+
+	Convey("Test close applies revocations", t, func() {
+		c := testCampaign(now)
+		So(c.Start([]*Task{
+			{Uuid: "approved", Decision: DecisionApprove},
+			{Uuid: "revoked", Decision: DecisionRevoke},
+			{Uuid: "failing", Decision: DecisionRevoke},
+			{Uuid: "pending"},
+		}, now), ShouldBeNil)
+		So(c.IsOverdue(now), ShouldBeFalse)
+		So(c.IsOverdue(now.Add(25*time.Hour)), ShouldBeTrue)
+
+		revoker := &fakeRevoker{fail: map[string]bool{"failing": true}}
+		revoked, failed, e := c.Close(context.Background(), revoker, now)
+		So(e, ShouldBeNil)
+		So(revoked, ShouldEqual, 1)
+		So(failed, ShouldEqual, 1)
+		So(revoker.revoked, ShouldResemble, []string{"revoked"})
+		So(c.Status, ShouldEqual, StatusClosed)
+		So(c.Tasks[2].ApplyError, ShouldNotBeEmpty)
+		So(c.IsOverdue(now.Add(25*time.Hour)), ShouldBeFalse)
+		_, _, e = c.Close(context.Background(), revoker, now)
+		So(e, ShouldNotBeNil)
+	})
+
+	Convey("Test default decision revokes pending tasks", t, func() {
+		c := testCampaign(now)
+		c.DefaultDecision = DecisionRevoke
+		So(c.Start([]*Task{{Uuid: "pending"}, {Uuid: "approved", Decision: DecisionApprove}}, now), ShouldBeNil)
+		revoker := &fakeRevoker{}
+		revoked, _, _ := c.Close(context.Background(), revoker, now)
+		So(revoked, ShouldEqual, 1)
+		So(revoker.revoked, ShouldResemble, []string{"pending"})
+	})
+
+}
+
+func TestGroupGrants(t *testing.T) {
+
+	Convey("Test grants are grouped by workspace and role", t, func() {
+		c := &Campaign{Scope: ScopeCells, Reviewer: "admin-uuid"}
+		workspaces := map[string]*idm.Workspace{
+			"cell": {UUID: "cell", Label: "Cell", Scope: idm.WorkspaceScope_ROOM, Policies: []*service.ResourcePolicy{
+				{Subject: "owner-uuid", Action: service.ResourcePolicyAction_OWNER, Effect: service.ResourcePolicy_allow},
+			}},
+			"link": {UUID: "link", Label: "Link", Scope: idm.WorkspaceScope_LINK, Policies: []*service.ResourcePolicy{
+				{Subject: "owner-uuid", Action: service.ResourcePolicyAction_OWNER, Effect: service.ResourcePolicy_allow},
+			}},
+			"common": {UUID: "common", Label: "Common Files", Scope: idm.WorkspaceScope_ADMIN},
+		}
+		acls := []*idm.ACL{
+			{WorkspaceID: "cell", RoleID: "owner-uuid", NodeID: "n", Action: permissions.AclRead},
+			{WorkspaceID: "cell", RoleID: "member", NodeID: "n", Action: permissions.AclRead},
+			{WorkspaceID: "cell", RoleID: "member", NodeID: "n", Action: permissions.AclWrite},
+			{WorkspaceID: "link", RoleID: "hidden", NodeID: "n", Action: permissions.AclRead},
+			{WorkspaceID: "common", RoleID: "ROOT_GROUP", NodeID: "n", Action: permissions.AclRead},
+			{WorkspaceID: "unknown", RoleID: "member", NodeID: "n", Action: permissions.AclRead},
+			{RoleID: "member", NodeID: "n", Action: permissions.AclRead},
+		}
+		tasks := GroupGrants(c, workspaces, acls)
+		So(tasks, ShouldHaveLength, 3)
+
+		So(tasks[0].WorkspaceUuid, ShouldEqual, "cell")
+		So(tasks[0].RoleId, ShouldEqual, "member")
+		So(tasks[0].Rights, ShouldEqual, "read,write")
+		So(tasks[0].Kind, ShouldEqual, GrantAcl)
+		So(tasks[0].ReviewerUuid, ShouldEqual, "owner-uuid")
+
+		So(tasks[1].WorkspaceUuid, ShouldEqual, "common")
+		So(tasks[1].ReviewerUuid, ShouldEqual, "admin-uuid")
+
+		So(tasks[2].WorkspaceUuid, ShouldEqual, "link")
+		So(tasks[2].Kind, ShouldEqual, GrantLink)
+		So(tasks[2].ReviewerUuid, ShouldEqual, "owner-uuid")
+
+		c.Scope = ScopeGroup
+		for _, t := range GroupGrants(c, workspaces, acls) {
+			So(t.ReviewerUuid, ShouldEqual, "admin-uuid")
+		}
+	})
+
+}
+
+func TestWriteReport(t *testing.T) {
+
+	Convey("Test CSV report", t, func() {
+		now := time.Now()
+		c := testCampaign(now)
+		So(c.Start([]*Task{
+			{Uuid: "t1", Kind: GrantAcl, WorkspaceLabel: "Cell", SubjectLabel: "john", Rights: "read", Decision: DecisionRevoke, DecidedBy: "owner", DecidedAt: now.Unix()},
+			{Uuid: "t2", Kind: GrantLink, WorkspaceLabel: "Link", SubjectLabel: "Public link", Rights: "read"},
+		}, now), ShouldBeNil)
+		c.Close(context.Background(), &fakeRevoker{}, now)
+
+		buf := &bytes.Buffer{}
+		So(WriteReport(buf, c), ShouldBeNil)
+		records, e := csv.NewReader(buf).ReadAll()
+		So(e, ShouldBeNil)
+		So(records, ShouldHaveLength, 3)
+		So(records[0], ShouldResemble, reportHeader)
+		So(records[1][9], ShouldEqual, "revoke")
+		So(records[1][13], ShouldEqual, "revoked")
+		So(records[2][9], ShouldEqual, "approve (default)")
+		So(records[2][13], ShouldEqual, "kept")
+	})
+
+}
@@ -0,0 +1,308 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package review
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/pborman/uuid"
+
+	"github.com/pydio/cells/common"
+	defaults "github.com/pydio/cells/common/micro"
+	"github.com/pydio/cells/common/proto/idm"
+	service "github.com/pydio/cells/common/service/proto"
+	"github.com/pydio/cells/common/utils/permissions"
+)
+
+// Collect lists the grants currently matching the campaign scope and turns them into review tasks.
+// Grants on shares are assigned to the share owner, other grants to the campaign reviewer.
+func Collect(ctx context.Context, c *Campaign) ([]*Task, error) {
+
+	var workspaces map[string]*idm.Workspace
+	var acls []*idm.ACL
+	var e error
+
+	if c.Scope == ScopeGroup {
+		members, er := searchUsers(ctx, &idm.UserSingleQuery{GroupPath: c.GroupPath, Recursive: true, NodeType: idm.NodeType_USER})
+		if er != nil {
+			return nil, er
+		}
+		if len(members) == 0 {
+			return nil, nil
+		}
+		var roleIds []string
+		for _, u := range members {
+			roleIds = append(roleIds, u.Uuid)
+		}
+		if acls, e = searchACLs(ctx, &idm.ACLSingleQuery{RoleIDs: roleIds, Actions: []*idm.ACLAction{permissions.AclRead, permissions.AclWrite}}); e != nil {
+			return nil, e
+		}
+		var wsIds []string
+		for _, a := range acls {
+			if a.WorkspaceID != "" {
+				wsIds = append(wsIds, a.WorkspaceID)
+			}
+		}
+		if len(wsIds) == 0 {
+			return nil, nil
+		}
+		if workspaces, e = searchWorkspaces(ctx, idm.WorkspaceScope_ANY, wsIds); e != nil {
+			return nil, e
+		}
+	} else {
+		scope := idm.WorkspaceScope_ADMIN
+		if c.Scope == ScopeCells {
+			scope = idm.WorkspaceScope_ROOM
+		} else if c.Scope == ScopeLinks {
+			scope = idm.WorkspaceScope_LINK
+		}
+		if workspaces, e = searchWorkspaces(ctx, scope, c.WorkspaceUuids); e != nil {
+			return nil, e
+		}
+		if len(workspaces) == 0 {
+			return nil, nil
+		}
+		var wsIds []string
+		for id := range workspaces {
+			wsIds = append(wsIds, id)
+		}
+		if acls, e = permissions.GetACLsForWorkspace(ctx, wsIds, permissions.AclRead, permissions.AclWrite); e != nil {
+			return nil, e
+		}
+	}
+
+	grants := GroupGrants(c, workspaces, acls)
+	if len(grants) == 0 {
+		return nil, nil
+	}
+
+	// Resolve subjects and reviewers labels
+	var roleIds, userIds []string
+	for _, t := range grants {
+		roleIds = append(roleIds, t.RoleId)
+		userIds = append(userIds, t.ReviewerUuid)
+	}
+	roles, e := searchRoles(ctx, roleIds)
+	if e != nil {
+		return nil, e
+	}
+	for _, r := range roles {
+		if r.UserRole {
+			userIds = append(userIds, r.Uuid)
+		}
+	}
+	users := make(map[string]*idm.User)
+	if len(userIds) > 0 {
+		var qs []*idm.UserSingleQuery
+		for _, id := range userIds {
+			if id != "" {
+				qs = append(qs, &idm.UserSingleQuery{Uuid: id})
+			}
+		}
+		found, er := searchUsers(ctx, qs...)
+		if er != nil {
+			return nil, er
+		}
+		for _, u := range found {
+			users[u.Uuid] = u
+		}
+	}
+	for _, t := range grants {
+		if t.Kind == GrantLink {
+			t.SubjectLabel = "Public link"
+		} else if u, ok := users[t.RoleId]; ok {
+			t.SubjectLabel = u.Login
+		} else if r, ok := roles[t.RoleId]; ok {
+			t.SubjectLabel = r.Label
+		} else {
+			t.SubjectLabel = t.RoleId
+		}
+		if u, ok := users[t.ReviewerUuid]; ok {
+			t.Reviewer = u.Login
+		}
+	}
+	return grants, nil
+}
+
+// GroupGrants merges the read/write ACLs of each role on each workspace into a single task.
+// Public links produce one task per link, and the owner of a cell is not asked to review itself.
+// Shares are reviewed by their owner, except for group campaigns that are fully assigned to the campaign reviewer.
+func GroupGrants(c *Campaign, workspaces map[string]*idm.Workspace, acls []*idm.ACL) (tasks []*Task) {
+	byKey := make(map[string]*Task)
+	rights := make(map[string]map[string]bool)
+	var keys []string
+	for _, a := range acls {
+		if a.WorkspaceID == "" || a.RoleID == "" || a.Action == nil {
+			continue
+		}
+		ws, ok := workspaces[a.WorkspaceID]
+		if !ok {
+			continue
+		}
+		owner := ownerUuid(ws)
+		if ws.Scope == idm.WorkspaceScope_ROOM && a.RoleID == owner {
+			continue
+		}
+		key := a.WorkspaceID + "/" + a.RoleID
+		t, ok := byKey[key]
+		if !ok {
+			t = &Task{
+				Uuid:           uuid.New(),
+				Kind:           GrantAcl,
+				WorkspaceUuid:  ws.UUID,
+				WorkspaceLabel: ws.Label,
+				RoleId:         a.RoleID,
+				ReviewerUuid:   c.Reviewer,
+			}
+			if ws.Scope == idm.WorkspaceScope_LINK {
+				t.Kind = GrantLink
+			}
+			if c.Scope != ScopeGroup && ws.Scope != idm.WorkspaceScope_ADMIN && owner != "" {
+				t.ReviewerUuid = owner
+			}
+			byKey[key] = t
+			rights[key] = make(map[string]bool)
+			keys = append(keys, key)
+		}
+		rights[key][a.Action.Name] = true
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		var rr []string
+		for r := range rights[k] {
+			rr = append(rr, r)
+		}
+		sort.Strings(rr)
+		byKey[k].Rights = strings.Join(rr, ",")
+		tasks = append(tasks, byKey[k])
+	}
+	return
+}
+
+// ownerUuid finds the user owning a share in the workspace policies.
+func ownerUuid(ws *idm.Workspace) string {
+	for _, p := range ws.Policies {
+		if p.Action == service.ResourcePolicyAction_OWNER && p.Effect == service.ResourcePolicy_allow {
+			return p.Subject
+		}
+	}
+	return ""
+}
+
+func searchWorkspaces(ctx context.Context, scope idm.WorkspaceScope, uuids []string) (map[string]*idm.Workspace, error) {
+	var qs []*any.Any
+	if len(uuids) > 0 {
+		for _, id := range uuids {
+			q, _ := ptypes.MarshalAny(&idm.WorkspaceSingleQuery{Uuid: id, Scope: scope})
+			qs = append(qs, q)
+		}
+	} else {
+		q, _ := ptypes.MarshalAny(&idm.WorkspaceSingleQuery{Scope: scope})
+		qs = append(qs, q)
+	}
+	cl := idm.NewWorkspaceServiceClient(common.ServiceGrpcNamespace_+common.ServiceWorkspace, defaults.NewClient())
+	stream, e := cl.SearchWorkspace(ctx, &idm.SearchWorkspaceRequest{Query: &service.Query{SubQueries: qs, Operation: service.OperationType_OR}})
+	if e != nil {
+		return nil, e
+	}
+	defer stream.Close()
+	workspaces := make(map[string]*idm.Workspace)
+	for {
+		resp, er := stream.Recv()
+		if er != nil {
+			break
+		}
+		if ws := resp.GetWorkspace(); ws != nil {
+			workspaces[ws.UUID] = ws
+		}
+	}
+	return workspaces, nil
+}
+
+func searchACLs(ctx context.Context, query *idm.ACLSingleQuery) (acls []*idm.ACL, e error) {
+	q, _ := ptypes.MarshalAny(query)
+	cl := idm.NewACLServiceClient(common.ServiceGrpcNamespace_+common.ServiceAcl, defaults.NewClient())
+	stream, e := cl.SearchACL(ctx, &idm.SearchACLRequest{Query: &service.Query{SubQueries: []*any.Any{q}}})
+	if e != nil {
+		return nil, e
+	}
+	defer stream.Close()
+	for {
+		resp, er := stream.Recv()
+		if er != nil {
+			break
+		}
+		acls = append(acls, resp.GetACL())
+	}
+	return acls, nil
+}
+
+func searchRoles(ctx context.Context, uuids []string) (map[string]*idm.Role, error) {
+	q, _ := ptypes.MarshalAny(&idm.RoleSingleQuery{Uuid: uuids})
+	cl := idm.NewRoleServiceClient(common.ServiceGrpcNamespace_+common.ServiceRole, defaults.NewClient())
+	stream, e := cl.SearchRole(ctx, &idm.SearchRoleRequest{Query: &service.Query{SubQueries: []*any.Any{q}}})
+	if e != nil {
+		return nil, e
+	}
+	defer stream.Close()
+	roles := make(map[string]*idm.Role)
+	for {
+		resp, er := stream.Recv()
+		if er != nil {
+			break
+		}
+		if r := resp.GetRole(); r != nil {
+			roles[r.Uuid] = r
+		}
+	}
+	return roles, nil
+}
+
+func searchUsers(ctx context.Context, queries ...*idm.UserSingleQuery) (users []*idm.User, e error) {
+	if len(queries) == 0 {
+		return nil, nil
+	}
+	var qs []*any.Any
+	for _, query := range queries {
+		q, _ := ptypes.MarshalAny(query)
+		qs = append(qs, q)
+	}
+	cl := idm.NewUserServiceClient(common.ServiceGrpcNamespace_+common.ServiceUser, defaults.NewClient())
+	stream, e := cl.SearchUser(ctx, &idm.SearchUserRequest{Query: &service.Query{SubQueries: qs, Operation: service.OperationType_OR}})
+	if e != nil {
+		return nil, e
+	}
+	defer stream.Close()
+	for {
+		resp, er := stream.Recv()
+		if er != nil {
+			break
+		}
+		if u := resp.GetUser(); u != nil {
+			users = append(users, u)
+		}
+	}
+	return users, nil
+}
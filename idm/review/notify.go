@@ -0,0 +1,80 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package review
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/log"
+	"github.com/pydio/cells/common/proto/idm"
+	"github.com/pydio/cells/common/proto/mailer"
+	"github.com/pydio/cells/common/registry"
+)
+
+// NotifyReviewers sends an email to each reviewer of the campaign, with the number of tasks waiting for them.
+func NotifyReviewers(ctx context.Context, c *Campaign) {
+	counts := make(map[string]int)
+	var qs []*idm.UserSingleQuery
+	for _, t := range c.Tasks {
+		if t.ReviewerUuid == "" {
+			continue
+		}
+		if _, ok := counts[t.ReviewerUuid]; !ok {
+			qs = append(qs, &idm.UserSingleQuery{Uuid: t.ReviewerUuid})
+		}
+		counts[t.ReviewerUuid]++
+	}
+	users, e := searchUsers(ctx, qs...)
+	if e != nil {
+		log.Logger(ctx).Error("Cannot load access review reviewers", zap.Error(e))
+		return
+	}
+	mailCli := mailer.NewMailerServiceClient(registry.GetClient(common.ServiceMailer))
+	for _, u := range users {
+		email := u.Attributes[idm.UserAttrEmail]
+		if email == "" {
+			continue
+		}
+		name := u.Attributes[idm.UserAttrDisplayName]
+		if name == "" {
+			name = u.Login
+		}
+		if _, e := mailCli.SendMail(ctx, &mailer.SendMailRequest{
+			InQueue: true,
+			Mail: &mailer.Mail{
+				To:         []*mailer.User{{Uuid: u.Uuid, Name: name, Address: email}},
+				TemplateId: "AccessReview",
+				TemplateData: map[string]string{
+					"Campaign": c.Label,
+					"Tasks":    strconv.Itoa(counts[u.Uuid]),
+					"Deadline": time.Unix(c.Deadline, 0).Format("2006-01-02"),
+				},
+			},
+		}); e != nil {
+			log.Logger(ctx).Error("Cannot send access review notification", u.ZapLogin(), zap.Error(e))
+		}
+	}
+}
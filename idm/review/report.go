@@ -0,0 +1,98 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package review
+
+import (
+	"encoding/csv"
+	"io"
+	"time"
+)
+
+var reportHeader = []string{
+	"Campaign",
+	"Scope",
+	"Kind",
+	"Workspace",
+	"WorkspaceUuid",
+	"Subject",
+	"RoleId",
+	"Rights",
+	"Reviewer",
+	"Decision",
+	"DecidedBy",
+	"DecidedAt",
+	"Comment",
+	"Status",
+}
+
+// WriteReport exports the tasks of a campaign as CSV, one line per grant. The Decision column shows
+// the effective decision (the default one for tasks left pending), Status shows whether it was applied.
+func WriteReport(w io.Writer, c *Campaign) error {
+	cw := csv.NewWriter(w)
+	if e := cw.Write(reportHeader); e != nil {
+		return e
+	}
+	for _, t := range c.Tasks {
+		decision := string(c.Effective(t))
+		if t.Decision == DecisionPending {
+			decision += " (default)"
+		}
+		var decidedAt string
+		if t.DecidedAt > 0 {
+			decidedAt = time.Unix(t.DecidedAt, 0).UTC().Format(time.RFC3339)
+		}
+		if e := cw.Write([]string{
+			c.Label,
+			string(c.Scope),
+			string(t.Kind),
+			t.WorkspaceLabel,
+			t.WorkspaceUuid,
+			t.SubjectLabel,
+			t.RoleId,
+			t.Rights,
+			t.Reviewer,
+			decision,
+			t.DecidedBy,
+			decidedAt,
+			t.Comment,
+			taskStatus(c, t),
+		}); e != nil {
+			return e
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func taskStatus(c *Campaign, t *Task) string {
+	switch {
+	case t.ApplyError != "":
+		return "error: " + t.ApplyError
+	case t.Applied:
+		return "revoked"
+	case c.Status != StatusClosed:
+		return "open"
+	case c.Effective(t) == DecisionRevoke:
+		return "not applied"
+	default:
+		return "kept"
+	}
+}
@@ -0,0 +1,363 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package rest
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/emicklei/go-restful"
+	"github.com/micro/go-micro/errors"
+	"github.com/pborman/uuid"
+	"go.uber.org/zap"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/log"
+	"github.com/pydio/cells/common/proto/rest"
+	"github.com/pydio/cells/common/service"
+	"github.com/pydio/cells/common/utils/permissions"
+	"github.com/pydio/cells/idm/review"
+)
+
+// campaignToRest converts a campaign to its REST representation, along with the progress of its tasks.
+func campaignToRest(c *review.Campaign, withTasks bool) *rest.Campaign {
+	p := c.Progress()
+	r := &rest.Campaign{
+		Uuid:            c.Uuid,
+		Label:           c.Label,
+		Description:     c.Description,
+		Scope:           string(c.Scope),
+		WorkspaceUuids:  c.WorkspaceUuids,
+		GroupPath:       c.GroupPath,
+		Reviewer:        c.Reviewer,
+		DefaultDecision: string(c.DefaultDecision),
+		Deadline:        c.Deadline,
+		Status:          string(c.Status),
+		Creator:         c.Creator,
+		Created:         c.Created,
+		Started:         c.Started,
+		Closed:          c.Closed,
+		Progress: &rest.CampaignProgress{
+			Total:    int32(p.Total),
+			Pending:  int32(p.Pending),
+			Approved: int32(p.Approved),
+			Revoked:  int32(p.Revoked),
+		},
+	}
+	if withTasks {
+		for _, t := range c.Tasks {
+			r.Tasks = append(r.Tasks, taskToRest(t, nil))
+		}
+	}
+	return r
+}
+
+// campaignFromRest reads the definition of a campaign, tasks and lifecycle fields are ignored.
+func campaignFromRest(r *rest.Campaign) *review.Campaign {
+	return &review.Campaign{
+		Uuid:            r.Uuid,
+		Label:           r.Label,
+		Description:     r.Description,
+		Scope:           review.Scope(r.Scope),
+		WorkspaceUuids:  r.WorkspaceUuids,
+		GroupPath:       r.GroupPath,
+		Reviewer:        r.Reviewer,
+		DefaultDecision: review.Decision(r.DefaultDecision),
+		Deadline:        r.Deadline,
+	}
+}
+
+// taskToRest converts a task, adding the campaign information if c is not nil.
+func taskToRest(t *review.Task, c *review.Campaign) *rest.ReviewTask {
+	r := &rest.ReviewTask{
+		Uuid:           t.Uuid,
+		Kind:           string(t.Kind),
+		WorkspaceUuid:  t.WorkspaceUuid,
+		WorkspaceLabel: t.WorkspaceLabel,
+		RoleId:         t.RoleId,
+		SubjectLabel:   t.SubjectLabel,
+		Rights:         t.Rights,
+		ReviewerUuid:   t.ReviewerUuid,
+		Reviewer:       t.Reviewer,
+		Decision:       string(t.Decision),
+		Comment:        t.Comment,
+		DecidedBy:      t.DecidedBy,
+		DecidedAt:      t.DecidedAt,
+		Applied:        t.Applied,
+		ApplyError:     t.ApplyError,
+	}
+	if c != nil {
+		r.CampaignUuid = c.Uuid
+		r.CampaignLabel = c.Label
+		r.Deadline = c.Deadline
+	}
+	return r
+}
+
+// Handler implements the access review REST API.
+type Handler struct {
+	store   review.Store
+	revoker review.Revoker
+	// decisions are read-modify-write operations on the campaign document
+	lock sync.Mutex
+}
+
+// NewHandler creates a new Handler.
+func NewHandler(store review.Store, revoker review.Revoker) *Handler {
+	return &Handler{store: store, revoker: revoker}
+}
+
+// SwaggerTags list the names of the service tags declared in the swagger json implemented by this service.
+func (h *Handler) SwaggerTags() []string {
+	return []string{"AccessReviewService"}
+}
+
+// Filter returns a function to filter the swagger path.
+func (h *Handler) Filter() func(string) string {
+	return nil
+}
+
+// ListCampaigns lists all campaigns, without their tasks.
+func (h *Handler) ListCampaigns(req *restful.Request, rsp *restful.Response) {
+	campaigns, e := h.store.ListCampaigns(req.Request.Context())
+	if e != nil {
+		service.RestErrorDetect(req, rsp, e)
+		return
+	}
+	response := &rest.CampaignCollection{}
+	for _, c := range campaigns {
+		response.Campaigns = append(response.Campaigns, campaignToRest(c, false))
+	}
+	rsp.WriteEntity(response)
+}
+
+// PutCampaign creates a new draft campaign, or updates the definition of a draft campaign.
+func (h *Handler) PutCampaign(req *restful.Request, rsp *restful.Response) {
+
+	var input rest.Campaign
+	if e := req.ReadEntity(&input); e != nil {
+		service.RestError500(req, rsp, e)
+		return
+	}
+	ctx := req.Request.Context()
+	login, claims := permissions.FindUserNameInContext(ctx)
+	c := campaignFromRest(&input)
+	if input.Uuid == "" {
+		c.Uuid = uuid.New()
+		c.Creator = login
+		c.Created = time.Now().Unix()
+	} else {
+		stored, e := h.store.GetCampaign(ctx, input.Uuid)
+		if e != nil {
+			service.RestErrorDetect(req, rsp, e)
+			return
+		}
+		if stored.Status != review.StatusDraft {
+			service.RestErrorDetect(req, rsp, errors.BadRequest(common.ServiceReview, "only draft campaigns can be modified"))
+			return
+		}
+		c.Creator = stored.Creator
+		c.Created = stored.Created
+	}
+	c.Status = review.StatusDraft
+	if c.Reviewer == "" {
+		c.Reviewer = claims.Subject
+	}
+	if e := c.Validate(); e != nil {
+		service.RestErrorDetect(req, rsp, e)
+		return
+	}
+	if e := h.store.PutCampaign(ctx, c); e != nil {
+		service.RestError500(req, rsp, e)
+		return
+	}
+	rsp.WriteEntity(campaignToRest(c, true))
+
+}
+
+// GetCampaign loads a campaign with all its tasks.
+func (h *Handler) GetCampaign(req *restful.Request, rsp *restful.Response) {
+	c, e := h.store.GetCampaign(req.Request.Context(), req.PathParameter("Uuid"))
+	if e != nil {
+		service.RestErrorDetect(req, rsp, e)
+		return
+	}
+	rsp.WriteEntity(campaignToRest(c, true))
+}
+
+// DeleteCampaign removes a campaign. Decisions of an active campaign are discarded.
+func (h *Handler) DeleteCampaign(req *restful.Request, rsp *restful.Response) {
+	ctx := req.Request.Context()
+	c, e := h.store.GetCampaign(ctx, req.PathParameter("Uuid"))
+	if e != nil {
+		service.RestErrorDetect(req, rsp, e)
+		return
+	}
+	if e := h.store.DeleteCampaign(ctx, c.Uuid); e != nil {
+		service.RestError500(req, rsp, e)
+		return
+	}
+	log.Auditer(ctx).Info(fmt.Sprintf("Deleted access review campaign [%s]", c.Label))
+	rsp.WriteEntity(campaignToRest(c, true))
+}
+
+// StartCampaign collects the grants matching the campaign scope, creates the tasks and notifies the reviewers.
+func (h *Handler) StartCampaign(req *restful.Request, rsp *restful.Response) {
+
+	ctx := req.Request.Context()
+	c, e := h.store.GetCampaign(ctx, req.PathParameter("Uuid"))
+	if e != nil {
+		service.RestErrorDetect(req, rsp, e)
+		return
+	}
+	if c.Status != review.StatusDraft {
+		service.RestErrorDetect(req, rsp, errors.BadRequest(common.ServiceReview, "campaign is already %s", c.Status))
+		return
+	}
+	tasks, e := review.Collect(ctx, c)
+	if e != nil {
+		service.RestErrorDetect(req, rsp, e)
+		return
+	}
+	if e := c.Start(tasks, time.Now()); e != nil {
+		service.RestErrorDetect(req, rsp, e)
+		return
+	}
+	if e := h.store.PutCampaign(ctx, c); e != nil {
+		service.RestError500(req, rsp, e)
+		return
+	}
+	log.Auditer(ctx).Info(fmt.Sprintf("Started access review campaign [%s] with %d tasks", c.Label, len(c.Tasks)))
+	review.NotifyReviewers(ctx, c)
+	rsp.WriteEntity(campaignToRest(c, true))
+
+}
+
+// CloseCampaign closes an active campaign right away and applies its revocations.
+func (h *Handler) CloseCampaign(req *restful.Request, rsp *restful.Response) {
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	ctx := req.Request.Context()
+	c, e := h.store.GetCampaign(ctx, req.PathParameter("Uuid"))
+	if e != nil {
+		service.RestErrorDetect(req, rsp, e)
+		return
+	}
+	if c.Status != review.StatusActive {
+		service.RestErrorDetect(req, rsp, errors.BadRequest(common.ServiceReview, "campaign is not active"))
+		return
+	}
+	revoked, failed, _ := c.Close(ctx, h.revoker, time.Now())
+	if e := h.store.PutCampaign(ctx, c); e != nil {
+		service.RestError500(req, rsp, e)
+		return
+	}
+	if failed > 0 {
+		log.Logger(ctx).Error("Some access review revocations could not be applied", zap.String("campaign", c.Uuid), zap.Int("failed", failed))
+	}
+	log.Auditer(ctx).Info(fmt.Sprintf("Closed access review campaign [%s], %d accesses revoked", c.Label, revoked))
+	rsp.WriteEntity(campaignToRest(c, true))
+
+}
+
+// CampaignReport streams the campaign tasks and decisions as a CSV file.
+func (h *Handler) CampaignReport(req *restful.Request, rsp *restful.Response) {
+
+	ctx := req.Request.Context()
+	c, e := h.store.GetCampaign(ctx, req.PathParameter("Uuid"))
+	if e != nil {
+		service.RestErrorDetect(req, rsp, e)
+		return
+	}
+	rsp.AddHeader("Content-Type", "text/csv; charset=utf-8")
+	rsp.AddHeader("Content-Disposition", fmt.Sprintf("attachment; filename=\"access-review-%s.csv\"", c.Uuid))
+	rsp.WriteHeader(200)
+	if e := review.WriteReport(rsp, c); e != nil {
+		log.Logger(ctx).Error("Cannot write access review report", zap.Error(e))
+	}
+
+}
+
+// ListReviewTasks lists the tasks assigned to the current user on active campaigns.
+func (h *Handler) ListReviewTasks(req *restful.Request, rsp *restful.Response) {
+
+	ctx := req.Request.Context()
+	_, claims := permissions.FindUserNameInContext(ctx)
+	if claims.Subject == "" {
+		service.RestError401(req, rsp, errors.Unauthorized(common.ServiceReview, "please log in to review accesses"))
+		return
+	}
+	campaigns, e := h.store.ListCampaigns(ctx)
+	if e != nil {
+		service.RestErrorDetect(req, rsp, e)
+		return
+	}
+	response := &rest.ReviewTaskCollection{}
+	for _, c := range campaigns {
+		if c.Status != review.StatusActive {
+			continue
+		}
+		for _, t := range c.TasksFor(claims.Subject) {
+			response.Tasks = append(response.Tasks, taskToRest(t, c))
+		}
+	}
+	rsp.WriteEntity(response)
+
+}
+
+// DecideReviewTask records the decision of the current user on one of its tasks.
+func (h *Handler) DecideReviewTask(req *restful.Request, rsp *restful.Response) {
+
+	var input rest.DecideTaskRequest
+	if e := req.ReadEntity(&input); e != nil {
+		service.RestError500(req, rsp, e)
+		return
+	}
+	ctx := req.Request.Context()
+	login, claims := permissions.FindUserNameInContext(ctx)
+	if claims.Subject == "" {
+		service.RestError401(req, rsp, errors.Unauthorized(common.ServiceReview, "please log in to review accesses"))
+		return
+	}
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	c, e := h.store.GetCampaign(ctx, input.CampaignUuid)
+	if e != nil {
+		service.RestErrorDetect(req, rsp, e)
+		return
+	}
+	admin := claims.Profile == common.PydioProfileAdmin
+	t, e := c.Decide(input.TaskUuid, claims.Subject, login, admin, review.Decision(input.Decision), input.Comment, time.Now())
+	if e != nil {
+		service.RestErrorDetect(req, rsp, e)
+		return
+	}
+	if e := h.store.PutCampaign(ctx, c); e != nil {
+		service.RestError500(req, rsp, e)
+		return
+	}
+	log.Auditer(ctx).Info(fmt.Sprintf("User [%s] decided to %s access of [%s] on [%s]", login, t.Decision, t.SubjectLabel, t.WorkspaceLabel))
+	rsp.WriteEntity(taskToRest(t, c))
+
+}
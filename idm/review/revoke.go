@@ -0,0 +1,128 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package review
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	"go.uber.org/zap"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/log"
+	defaults "github.com/pydio/cells/common/micro"
+	"github.com/pydio/cells/common/proto/idm"
+	"github.com/pydio/cells/common/proto/rest"
+	service "github.com/pydio/cells/common/service/proto"
+	"github.com/pydio/cells/common/utils/permissions"
+	"github.com/pydio/cells/idm/share"
+)
+
+type grpcRevoker struct{}
+
+// NewGrpcRevoker creates a Revoker removing ACLs and public links through the idm services.
+func NewGrpcRevoker() Revoker {
+	return &grpcRevoker{}
+}
+
+// Revoke removes the grant described by the task. A grant that does not exist anymore is not an error.
+func (g *grpcRevoker) Revoke(ctx context.Context, task *Task) error {
+	workspaces, e := searchWorkspaces(ctx, idm.WorkspaceScope_ANY, []string{task.WorkspaceUuid})
+	if e != nil {
+		return e
+	}
+	ws, ok := workspaces[task.WorkspaceUuid]
+	if !ok {
+		return nil
+	}
+	if task.Kind == GrantLink {
+		return g.revokeLink(ctx, ws)
+	}
+	return g.revokeAcls(ctx, ws, task.RoleId)
+}
+
+// revokeAcls deletes the read/write ACLs of the role on the workspace. For cells, the role is also
+// removed from the workspace policies, like when a member is removed from the cell.
+func (g *grpcRevoker) revokeAcls(ctx context.Context, ws *idm.Workspace, roleId string) error {
+	var initial []*idm.ACL
+	if ws.Scope == idm.WorkspaceScope_ROOM {
+		var e error
+		if initial, _, e = share.CommonAclsForWorkspace(ctx, ws.UUID); e != nil {
+			return e
+		}
+	}
+	q, _ := ptypes.MarshalAny(&idm.ACLSingleQuery{
+		RoleIDs:      []string{roleId},
+		WorkspaceIDs: []string{ws.UUID},
+		Actions:      []*idm.ACLAction{permissions.AclRead, permissions.AclWrite},
+	})
+	aclClient := idm.NewACLServiceClient(common.ServiceGrpcNamespace_+common.ServiceAcl, defaults.NewClient())
+	resp, e := aclClient.DeleteACL(ctx, &idm.DeleteACLRequest{Query: &service.Query{SubQueries: []*any.Any{q}}})
+	if e != nil {
+		return e
+	}
+	log.Auditer(ctx).Info(
+		fmt.Sprintf("Access review revoked access of role [%s] on workspace [%s]", roleId, ws.Label),
+		ws.ZapUuid(),
+		zap.Int64("acls", resp.RowsDeleted),
+	)
+	if ws.Scope != idm.WorkspaceScope_ROOM {
+		return nil
+	}
+	var target []*idm.ACL
+	for _, a := range initial {
+		if a.RoleID != roleId {
+			target = append(target, a)
+		}
+	}
+	share.UpdatePoliciesFromAcls(ctx, ws, initial, target)
+	wsClient := idm.NewWorkspaceServiceClient(common.ServiceGrpcNamespace_+common.ServiceWorkspace, defaults.NewClient())
+	_, e = wsClient.CreateWorkspace(ctx, &idm.CreateWorkspaceRequest{Workspace: ws})
+	return e
+}
+
+// revokeLink deletes a public link with its hash document and hidden user.
+func (g *grpcRevoker) revokeLink(ctx context.Context, ws *idm.Workspace) error {
+	storedLink := &rest.ShareLink{Uuid: ws.UUID}
+	if e := share.LoadHashDocumentData(ctx, storedLink, []*idm.ACL{}); e != nil {
+		return e
+	}
+	q, _ := ptypes.MarshalAny(&idm.WorkspaceSingleQuery{Uuid: ws.UUID})
+	wsClient := idm.NewWorkspaceServiceClient(common.ServiceGrpcNamespace_+common.ServiceWorkspace, defaults.NewClient())
+	if _, e := wsClient.DeleteWorkspace(ctx, &idm.DeleteWorkspaceRequest{Query: &service.Query{SubQueries: []*any.Any{q}}}); e != nil {
+		return e
+	}
+	if e := share.DeleteHashDocument(ctx, ws.UUID); e != nil {
+		log.Logger(ctx).Warn("Could not delete hash document for revoked link", ws.ZapUuid(), zap.Error(e))
+	}
+	if e := share.DeleteHiddenUser(ctx, storedLink); e != nil {
+		return e
+	}
+	log.Auditer(ctx).Info(
+		fmt.Sprintf("Access review removed share link [%s]", ws.Label),
+		log.GetAuditId(common.AuditLinkUpdate),
+		zap.String(common.KeyLinkUuid, ws.UUID),
+		zap.String(common.KeyWorkspaceUuid, ws.UUID),
+	)
+	return nil
+}
@@ -0,0 +1,116 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package review
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/micro/go-micro/client"
+	"github.com/micro/go-micro/errors"
+
+	"github.com/pydio/cells/common"
+	defaults "github.com/pydio/cells/common/micro"
+	"github.com/pydio/cells/common/proto/docstore"
+)
+
+// Store persists the campaigns.
+type Store interface {
+	PutCampaign(ctx context.Context, c *Campaign) error
+	GetCampaign(ctx context.Context, uuid string) (*Campaign, error)
+	ListCampaigns(ctx context.Context) ([]*Campaign, error)
+	DeleteCampaign(ctx context.Context, uuid string) error
+}
+
+type docStore struct {
+	cl client.Client
+}
+
+// NewDocStore creates a Store saving each campaign as a JSON document in the docstore service.
+func NewDocStore() Store {
+	return &docStore{cl: defaults.NewClient()}
+}
+
+func (d *docStore) docs() docstore.DocStoreClient {
+	return docstore.NewDocStoreClient(common.ServiceGrpcNamespace_+common.ServiceDocStore, d.cl)
+}
+
+func (d *docStore) PutCampaign(ctx context.Context, c *Campaign) error {
+	data, e := json.Marshal(c)
+	if e != nil {
+		return e
+	}
+	meta, _ := json.Marshal(map[string]string{"Status": string(c.Status), "Scope": string(c.Scope)})
+	_, e = d.docs().PutDocument(ctx, &docstore.PutDocumentRequest{
+		StoreID:    common.DocStoreIdAccessReviews,
+		DocumentID: c.Uuid,
+		Document: &docstore.Document{
+			ID:            c.Uuid,
+			Owner:         c.Creator,
+			Type:          docstore.DocumentType_JSON,
+			Data:          string(data),
+			IndexableMeta: string(meta),
+		},
+	})
+	return e
+}
+
+func (d *docStore) GetCampaign(ctx context.Context, uuid string) (*Campaign, error) {
+	resp, e := d.docs().GetDocument(ctx, &docstore.GetDocumentRequest{
+		StoreID:    common.DocStoreIdAccessReviews,
+		DocumentID: uuid,
+	})
+	if e != nil || resp.Document == nil || resp.Document.Data == "" {
+		return nil, errors.NotFound(common.ServiceReview, "cannot find campaign %s", uuid)
+	}
+	var c *Campaign
+	if e := json.Unmarshal([]byte(resp.Document.Data), &c); e != nil {
+		return nil, e
+	}
+	return c, nil
+}
+
+func (d *docStore) ListCampaigns(ctx context.Context) (campaigns []*Campaign, e error) {
+	stream, e := d.docs().ListDocuments(ctx, &docstore.ListDocumentsRequest{StoreID: common.DocStoreIdAccessReviews})
+	if e != nil {
+		return nil, e
+	}
+	defer stream.Close()
+	for {
+		resp, er := stream.Recv()
+		if er != nil {
+			break
+		}
+		var c *Campaign
+		if er := json.Unmarshal([]byte(resp.GetDocument().GetData()), &c); er == nil {
+			campaigns = append(campaigns, c)
+		}
+	}
+	return campaigns, nil
+}
+
+func (d *docStore) DeleteCampaign(ctx context.Context, uuid string) error {
+	_, e := d.docs().DeleteDocuments(ctx, &docstore.DeleteDocumentsRequest{
+		StoreID:    common.DocStoreIdAccessReviews,
+		DocumentID: uuid,
+	})
+	return e
+}
@@ -57,6 +57,13 @@ var (
 			Effect:  service.ResourcePolicy_allow,
 		},
 	}
+	// groupAdminsRole members administrate the users of their own group, as defined
+	// by the delegated-admin-policies policy group.
+	groupAdminsRole = &idm.Role{
+		Uuid:     "GROUP_ADMINS",
+		Label:    "Group Administrators",
+		Policies: rootPolicies,
+	}
 	externalPolicies = []*service.ResourcePolicy{
 		{
 			Action:  service.ResourcePolicyAction_READ,
@@ -144,6 +151,9 @@ func InitRoles(ctx context.Context) error {
 				{RoleID: "MINISITE_NODOWNLOAD", Action: &idm.ACLAction{Name: "action:access.gateway:download_folder", Value: "false"}, WorkspaceID: scopeShared},
 			},
 		},
+		{
+			Role: groupAdminsRole,
+		},
 	}
 
 	var e error
@@ -241,3 +251,16 @@ func UpgradeTo12(ctx context.Context) error {
 
 	return e
 }
+
+// UpgradeTo302 creates the GROUP_ADMINS role used for delegated administration.
+func UpgradeTo302(ctx context.Context) error {
+
+	dao := servicecontext.GetDAO(ctx).(role.DAO)
+	_, update, e := dao.Add(groupAdminsRole)
+	if e != nil || update {
+		return e
+	}
+	log.Logger(ctx).Info(fmt.Sprintf("Created role %s", groupAdminsRole.Label))
+	return dao.AddPolicies(false, groupAdminsRole.Uuid, groupAdminsRole.Policies)
+
+}
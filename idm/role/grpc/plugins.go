@@ -49,6 +49,9 @@ func init() {
 				}, {
 					TargetVersion: service.ValidVersion("1.2.0"),
 					Up:            UpgradeTo12,
+				}, {
+					TargetVersion: service.ValidVersion("3.0.2"),
+					Up:            UpgradeTo302,
 				},
 			}),
 			service.WithStorage(role.NewDAO, "idm_role"),
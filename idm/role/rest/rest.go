@@ -38,6 +38,7 @@ import (
 	"github.com/pydio/cells/common/service"
 	serviceproto "github.com/pydio/cells/common/service/proto"
 	"github.com/pydio/cells/common/service/resources"
+	"github.com/pydio/cells/common/utils/permissions"
 )
 
 // NewRoleHandler creates and configure a new RoleHandler
@@ -172,8 +173,10 @@ func (s *RoleHandler) DeleteRole(req *restful.Request, rsp *restful.Response) {
 
 	cl := idm.NewRoleServiceClient(common.ServiceGrpcNamespace_+common.ServiceRole, defaults.NewClient())
 	if checkError := s.IsAllowed(ctx, uuid, serviceproto.ResourcePolicyAction_WRITE, cl); checkError != nil {
-		service.RestError403(req, rsp, checkError)
-		return
+		if _, ok := s.delegatedRole(ctx, uuid, cl); !ok {
+			service.RestError403(req, rsp, checkError)
+			return
+		}
 	}
 
 	// Now delete role
@@ -210,8 +213,15 @@ func (s *RoleHandler) SetRole(req *restful.Request, rsp *restful.Response) {
 	log.Logger(ctx).Debug("Received Role.Set", zap.Any("r", inputRole))
 
 	if checkError := s.IsAllowed(ctx, inputRole.Uuid, serviceproto.ResourcePolicyAction_WRITE, cl); checkError != nil && errors.Parse(checkError.Error()).Code != 404 {
-		service.RestError403(req, rsp, checkError)
-		return
+		existing, ok := s.delegatedRole(ctx, inputRole.Uuid, cl)
+		if !ok {
+			service.RestError403(req, rsp, checkError)
+			return
+		}
+		// Delegated administrators cannot change the nature nor the policies of the role
+		inputRole.UserRole = existing.UserRole
+		inputRole.GroupRole = existing.GroupRole
+		inputRole.Policies = existing.Policies
 	}
 	// in fact create or update
 	resp, er := cl.CreateRole(ctx, &idm.CreateRoleRequest{
@@ -229,6 +239,30 @@ func (s *RoleHandler) SetRole(req *restful.Request, rsp *restful.Response) {
 	}
 }
 
+// delegatedRole loads a role and checks if it belongs to a user or a group administrated
+// by the context user through delegation.
+func (s *RoleHandler) delegatedRole(ctx context.Context, roleId string, cl idm.RoleServiceClient) (*idm.Role, bool) {
+	query, _ := ptypes.MarshalAny(&idm.RoleSingleQuery{
+		Uuid: []string{roleId},
+	})
+	stream, e := cl.SearchRole(ctx, &idm.SearchRoleRequest{Query: &serviceproto.Query{SubQueries: []*any.Any{query}}})
+	if e != nil {
+		return nil, false
+	}
+	defer stream.Close()
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			break
+		}
+		if resp == nil {
+			continue
+		}
+		return resp.Role, permissions.DelegatedAdminAllowedOnRole(ctx, resp.Role)
+	}
+	return nil, false
+}
+
 // PoliciesForRole retrieves Policies bound to a role given its UUID
 func (s *RoleHandler) PoliciesForRole(ctx context.Context, resourceId string, resourceClient interface{}) (policies []*serviceproto.ResourcePolicy, e error) {
 
@@ -0,0 +1,57 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package scim
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/pydio/cells/common"
+	commonauth "github.com/pydio/cells/common/auth"
+	"github.com/pydio/cells/common/log"
+)
+
+// auth validates the Bearer token, usually a personal access token, and only lets administrators in.
+func auth(inner http.Handler) http.Handler {
+
+	jwtVerifier := commonauth.DefaultJWTVerifier()
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		ctx := r.Context()
+		h := r.Header.Get("Authorization")
+		if !strings.HasPrefix(h, "Bearer ") {
+			writeError(w, http.StatusUnauthorized, "", "Missing bearer token")
+			return
+		}
+		c, claims, err := jwtVerifier.Verify(ctx, strings.TrimPrefix(h, "Bearer "))
+		if err != nil || claims.Name == "" {
+			log.Logger(ctx).Debug("Token validation failed, cannot process SCIM request")
+			writeError(w, http.StatusUnauthorized, "", "Invalid token")
+			return
+		}
+		if claims.Profile != common.PydioProfileAdmin {
+			writeError(w, http.StatusForbidden, "", "SCIM provisioning requires an administrator token")
+			return
+		}
+		inner.ServeHTTP(w, r.WithContext(c))
+	})
+}
@@ -0,0 +1,164 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package scim
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// schemaAttribute describes an attribute in the /Schemas endpoint
+type schemaAttribute struct {
+	Name          string             `json:"name"`
+	Type          string             `json:"type"`
+	MultiValued   bool               `json:"multiValued"`
+	Required      bool               `json:"required"`
+	CaseExact     bool               `json:"caseExact"`
+	Mutability    string             `json:"mutability"`
+	Returned      string             `json:"returned"`
+	Uniqueness    string             `json:"uniqueness"`
+	SubAttributes []*schemaAttribute `json:"subAttributes,omitempty"`
+}
+
+func attr(name, typ, mutability string) *schemaAttribute {
+	return &schemaAttribute{Name: name, Type: typ, Mutability: mutability, Returned: "default", Uniqueness: "none"}
+}
+
+func (a *schemaAttribute) required() *schemaAttribute {
+	a.Required = true
+	return a
+}
+
+func (a *schemaAttribute) multi(sub ...*schemaAttribute) *schemaAttribute {
+	a.MultiValued = true
+	a.SubAttributes = sub
+	return a
+}
+
+var (
+	userSchema = map[string]interface{}{
+		"schemas":     []string{SchemaSchema},
+		"id":          SchemaUser,
+		"name":        "User",
+		"description": "Cells user",
+		"attributes": []*schemaAttribute{
+			func() *schemaAttribute {
+				a := attr("userName", "string", "readWrite").required()
+				a.Uniqueness = "server"
+				return a
+			}(),
+			{Name: "name", Type: "complex", Mutability: "readWrite", Returned: "default", Uniqueness: "none", SubAttributes: []*schemaAttribute{
+				attr("formatted", "string", "readWrite"),
+				attr("familyName", "string", "readWrite"),
+				attr("givenName", "string", "readWrite"),
+			}},
+			attr("displayName", "string", "readWrite"),
+			attr("userType", "string", "readWrite"),
+			attr("active", "boolean", "readWrite"),
+			func() *schemaAttribute {
+				a := attr("password", "string", "writeOnly")
+				a.Returned = "never"
+				return a
+			}(),
+			attr("emails", "complex", "readWrite").multi(
+				attr("value", "string", "readWrite"),
+				attr("type", "string", "readWrite"),
+				attr("primary", "boolean", "readWrite"),
+			),
+			attr("groups", "complex", "readOnly").multi(
+				attr("value", "string", "readOnly"),
+				attr("$ref", "reference", "readOnly"),
+				attr("display", "string", "readOnly"),
+			),
+		},
+		"meta": map[string]string{"resourceType": "Schema", "location": "/Schemas/" + SchemaUser},
+	}
+	groupSchema = map[string]interface{}{
+		"schemas":     []string{SchemaSchema},
+		"id":          SchemaGroup,
+		"name":        "Group",
+		"description": "Cells group",
+		"attributes": []*schemaAttribute{
+			attr("displayName", "string", "readWrite").required(),
+			attr("members", "complex", "readWrite").multi(
+				attr("value", "string", "immutable"),
+				attr("$ref", "reference", "immutable"),
+				attr("display", "string", "readOnly"),
+			),
+		},
+		"meta": map[string]string{"resourceType": "Schema", "location": "/Schemas/" + SchemaGroup},
+	}
+)
+
+func (h *Handler) serviceProviderConfig(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"schemas":        []string{SchemaServiceProviderConfig},
+		"patch":          map[string]bool{"supported": true},
+		"bulk":           map[string]interface{}{"supported": false, "maxOperations": 0, "maxPayloadSize": 0},
+		"filter":         map[string]interface{}{"supported": true, "maxResults": h.MaxResults},
+		"changePassword": map[string]bool{"supported": true},
+		"sort":           map[string]bool{"supported": false},
+		"etag":           map[string]bool{"supported": false},
+		"authenticationSchemes": []map[string]interface{}{{
+			"type":        "oauthbearertoken",
+			"name":        "OAuth Bearer Token",
+			"description": "Authentication with a personal access token of an administrator",
+			"primary":     true,
+		}},
+		"meta": map[string]string{"resourceType": "ServiceProviderConfig", "location": baseURL(r) + "/ServiceProviderConfig"},
+	})
+}
+
+func (h *Handler) resourceTypes(w http.ResponseWriter, r *http.Request) {
+	types := []interface{}{
+		map[string]interface{}{
+			"schemas":  []string{SchemaResourceType},
+			"id":       "User",
+			"name":     "User",
+			"endpoint": "/Users",
+			"schema":   SchemaUser,
+			"meta":     map[string]string{"resourceType": "ResourceType", "location": baseURL(r) + "/ResourceTypes/User"},
+		},
+		map[string]interface{}{
+			"schemas":  []string{SchemaResourceType},
+			"id":       "Group",
+			"name":     "Group",
+			"endpoint": "/Groups",
+			"schema":   SchemaGroup,
+			"meta":     map[string]string{"resourceType": "ResourceType", "location": baseURL(r) + "/ResourceTypes/Group"},
+		},
+	}
+	h.writeList(w, r, types)
+}
+
+func (h *Handler) schemas(w http.ResponseWriter, r *http.Request) {
+	switch mux.Vars(r)["id"] {
+	case "":
+		h.writeList(w, r, []interface{}{userSchema, groupSchema})
+	case SchemaUser:
+		writeJSON(w, http.StatusOK, userSchema)
+	case SchemaGroup:
+		writeJSON(w, http.StatusOK, groupSchema)
+	default:
+		writeError(w, http.StatusNotFound, "", "Unknown schema")
+	}
+}
@@ -0,0 +1,448 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package scim
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Filter is a parsed SCIM filter expression (RFC 7644 section 3.4.2.2), evaluated
+// against the generic JSON representation of a resource.
+type Filter interface {
+	Match(resource map[string]interface{}) bool
+}
+
+type logicalFilter struct {
+	or          bool
+	left, right Filter
+}
+
+func (l *logicalFilter) Match(resource map[string]interface{}) bool {
+	if l.or {
+		return l.left.Match(resource) || l.right.Match(resource)
+	}
+	return l.left.Match(resource) && l.right.Match(resource)
+}
+
+type notFilter struct {
+	inner Filter
+}
+
+func (n *notFilter) Match(resource map[string]interface{}) bool {
+	return !n.inner.Match(resource)
+}
+
+// compareFilter is an "attrPath op value" or "attrPath pr" expression
+type compareFilter struct {
+	path  []string
+	op    string
+	value interface{}
+}
+
+func (c *compareFilter) Match(resource map[string]interface{}) bool {
+	values := resolve(resource, c.path)
+	switch c.op {
+	case "pr":
+		for _, v := range values {
+			if v != nil && v != "" {
+				return true
+			}
+		}
+		return false
+	case "ne":
+		for _, v := range values {
+			if compare(v, "eq", c.value) {
+				return false
+			}
+		}
+		return c.value != nil || len(values) > 0
+	case "eq":
+		if c.value == nil {
+			return len(values) == 0
+		}
+	}
+	for _, v := range values {
+		if compare(v, c.op, c.value) {
+			return true
+		}
+	}
+	return false
+}
+
+// valuePathFilter is an "attr[filter]" expression, matching if any value of a multi-valued attribute matches the inner filter
+type valuePathFilter struct {
+	path  []string
+	inner Filter
+}
+
+func (p *valuePathFilter) Match(resource map[string]interface{}) bool {
+	for _, v := range resolveRaw(resource, p.path) {
+		if m, ok := v.(map[string]interface{}); ok && p.inner.Match(m) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseFilter parses a SCIM filter expression.
+func ParseFilter(expression string) (Filter, error) {
+	tokens, e := tokenize(expression)
+	if e != nil {
+		return nil, e
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("empty filter")
+	}
+	p := &filterParser{tokens: tokens}
+	f, e := p.parseOr()
+	if e != nil {
+		return nil, e
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected token %s in filter", p.tokens[p.pos].text)
+	}
+	return f, nil
+}
+
+// EqualityOn returns the value if the filter is a simple "attr eq value" expression on the given attribute.
+// It is used to push the most common lookups down to the users service.
+func EqualityOn(f Filter, attribute string) (string, bool) {
+	c, ok := f.(*compareFilter)
+	if !ok || c.op != "eq" || len(c.path) != 1 || !strings.EqualFold(c.path[0], attribute) {
+		return "", false
+	}
+	s, ok := c.value.(string)
+	return s, ok
+}
+
+type tokenKind int
+
+const (
+	tokenWord tokenKind = iota
+	tokenString
+	tokenOpen
+	tokenClose
+	tokenOpenBracket
+	tokenCloseBracket
+)
+
+type token struct {
+	kind tokenKind
+	text string
+}
+
+func tokenize(s string) (tokens []token, e error) {
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(':
+			tokens = append(tokens, token{kind: tokenOpen, text: "("})
+			i++
+		case c == ')':
+			tokens = append(tokens, token{kind: tokenClose, text: ")"})
+			i++
+		case c == '[':
+			tokens = append(tokens, token{kind: tokenOpenBracket, text: "["})
+			i++
+		case c == ']':
+			tokens = append(tokens, token{kind: tokenCloseBracket, text: "]"})
+			i++
+		case c == '"':
+			j := i + 1
+			for ; j < len(s) && s[j] != '"'; j++ {
+				if s[j] == '\\' {
+					j++
+				}
+			}
+			if j >= len(s) {
+				return nil, fmt.Errorf("unterminated string in filter")
+			}
+			unquoted, er := strconv.Unquote(s[i : j+1])
+			if er != nil {
+				return nil, fmt.Errorf("invalid string %s in filter", s[i:j+1])
+			}
+			tokens = append(tokens, token{kind: tokenString, text: unquoted})
+			i = j + 1
+		default:
+			j := i
+			for ; j < len(s) && !strings.ContainsRune(" \t\n\r()[]\"", rune(s[j])); j++ {
+			}
+			tokens = append(tokens, token{kind: tokenWord, text: s[i:j]})
+			i = j
+		}
+	}
+	return
+}
+
+type filterParser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *filterParser) peekWord(words ...string) bool {
+	if p.pos >= len(p.tokens) || p.tokens[p.pos].kind != tokenWord {
+		return false
+	}
+	for _, w := range words {
+		if strings.EqualFold(p.tokens[p.pos].text, w) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *filterParser) expect(kind tokenKind, text string) error {
+	if p.pos >= len(p.tokens) || p.tokens[p.pos].kind != kind {
+		return fmt.Errorf("expected %s in filter", text)
+	}
+	p.pos++
+	return nil
+}
+
+func (p *filterParser) parseOr() (Filter, error) {
+	left, e := p.parseAnd()
+	if e != nil {
+		return nil, e
+	}
+	for p.peekWord("or") {
+		p.pos++
+		right, e := p.parseAnd()
+		if e != nil {
+			return nil, e
+		}
+		left = &logicalFilter{or: true, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseAnd() (Filter, error) {
+	left, e := p.parseNot()
+	if e != nil {
+		return nil, e
+	}
+	for p.peekWord("and") {
+		p.pos++
+		right, e := p.parseNot()
+		if e != nil {
+			return nil, e
+		}
+		left = &logicalFilter{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseNot() (Filter, error) {
+	if !p.peekWord("not") {
+		return p.parseAtom()
+	}
+	p.pos++
+	if e := p.expect(tokenOpen, "("); e != nil {
+		return nil, e
+	}
+	inner, e := p.parseOr()
+	if e != nil {
+		return nil, e
+	}
+	if e := p.expect(tokenClose, ")"); e != nil {
+		return nil, e
+	}
+	return &notFilter{inner: inner}, nil
+}
+
+func (p *filterParser) parseAtom() (Filter, error) {
+	if p.pos >= len(p.tokens) {
+		return nil, fmt.Errorf("unexpected end of filter")
+	}
+	t := p.tokens[p.pos]
+	if t.kind == tokenOpen {
+		p.pos++
+		inner, e := p.parseOr()
+		if e != nil {
+			return nil, e
+		}
+		if e := p.expect(tokenClose, ")"); e != nil {
+			return nil, e
+		}
+		return inner, nil
+	}
+	if t.kind != tokenWord {
+		return nil, fmt.Errorf("expected attribute name, got %s", t.text)
+	}
+	p.pos++
+	path := splitAttrPath(t.text)
+	if p.pos < len(p.tokens) && p.tokens[p.pos].kind == tokenOpenBracket {
+		p.pos++
+		inner, e := p.parseOr()
+		if e != nil {
+			return nil, e
+		}
+		if e := p.expect(tokenCloseBracket, "]"); e != nil {
+			return nil, e
+		}
+		return &valuePathFilter{path: path, inner: inner}, nil
+	}
+	if !p.peekWord("eq", "ne", "co", "sw", "ew", "gt", "ge", "lt", "le", "pr") {
+		return nil, fmt.Errorf("expected operator after %s", t.text)
+	}
+	op := strings.ToLower(p.tokens[p.pos].text)
+	p.pos++
+	if op == "pr" {
+		return &compareFilter{path: path, op: op}, nil
+	}
+	if p.pos >= len(p.tokens) {
+		return nil, fmt.Errorf("missing value after %s %s", t.text, op)
+	}
+	v := p.tokens[p.pos]
+	p.pos++
+	c := &compareFilter{path: path, op: op}
+	switch {
+	case v.kind == tokenString:
+		c.value = v.text
+	case v.kind != tokenWord:
+		return nil, fmt.Errorf("invalid value %s in filter", v.text)
+	case strings.EqualFold(v.text, "true"), strings.EqualFold(v.text, "false"):
+		c.value = strings.EqualFold(v.text, "true")
+	case strings.EqualFold(v.text, "null"):
+		c.value = nil
+	default:
+		n, e := strconv.ParseFloat(v.text, 64)
+		if e != nil {
+			return nil, fmt.Errorf("invalid value %s in filter", v.text)
+		}
+		c.value = n
+	}
+	return c, nil
+}
+
+// splitAttrPath removes an optional schema URN prefix and splits sub-attributes
+func splitAttrPath(attr string) []string {
+	if strings.HasPrefix(strings.ToLower(attr), "urn:") {
+		attr = attr[strings.LastIndex(attr, ":")+1:]
+	}
+	return strings.Split(attr, ".")
+}
+
+// resolveRaw walks the resource along path, flattening multi-valued attributes
+func resolveRaw(value interface{}, path []string) []interface{} {
+	if arr, ok := value.([]interface{}); ok {
+		var out []interface{}
+		for _, v := range arr {
+			out = append(out, resolveRaw(v, path)...)
+		}
+		return out
+	}
+	if len(path) == 0 {
+		if value == nil {
+			return nil
+		}
+		return []interface{}{value}
+	}
+	m, ok := value.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	if _, v, found := lookupKey(m, path[0]); found {
+		return resolveRaw(v, path[1:])
+	}
+	return nil
+}
+
+// resolve returns comparable values, using the "value" sub-attribute of complex multi-valued attributes
+func resolve(resource map[string]interface{}, path []string) []interface{} {
+	var out []interface{}
+	for _, v := range resolveRaw(resource, path) {
+		if m, ok := v.(map[string]interface{}); ok {
+			if _, inner, found := lookupKey(m, "value"); found && inner != nil {
+				out = append(out, inner)
+			}
+			continue
+		}
+		out = append(out, v)
+	}
+	return out
+}
+
+// lookupKey finds a key case-insensitively, as SCIM attribute names are case-insensitive
+func lookupKey(m map[string]interface{}, key string) (string, interface{}, bool) {
+	if v, ok := m[key]; ok {
+		return key, v, true
+	}
+	for k, v := range m {
+		if strings.EqualFold(k, key) {
+			return k, v, true
+		}
+	}
+	return "", nil, false
+}
+
+func compare(actual interface{}, op string, expected interface{}) bool {
+	switch a := actual.(type) {
+	case string:
+		e, ok := expected.(string)
+		if !ok {
+			return false
+		}
+		a, e = strings.ToLower(a), strings.ToLower(e)
+		switch op {
+		case "eq":
+			return a == e
+		case "co":
+			return strings.Contains(a, e)
+		case "sw":
+			return strings.HasPrefix(a, e)
+		case "ew":
+			return strings.HasSuffix(a, e)
+		case "gt":
+			return a > e
+		case "ge":
+			return a >= e
+		case "lt":
+			return a < e
+		case "le":
+			return a <= e
+		}
+	case bool:
+		e, ok := expected.(bool)
+		return ok && op == "eq" && a == e
+	case float64:
+		e, ok := expected.(float64)
+		if !ok {
+			return false
+		}
+		switch op {
+		case "eq":
+			return a == e
+		case "gt":
+			return a > e
+		case "ge":
+			return a >= e
+		case "lt":
+			return a < e
+		case "le":
+			return a <= e
+		}
+	}
+	return false
+}
@@ -0,0 +1,126 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package scim
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestFilter(t *testing.T) {
+
+	user := map[string]interface{}{
+		"userName":    "John",
+		"displayName": "John Doe",
+		"active":      true,
+		"name":        map[string]interface{}{"givenName": "John", "familyName": "Doe"},
+		"emails": []interface{}{
+			map[string]interface{}{"value": "john@example.com", "type": "work", "primary": true},
+			map[string]interface{}{"value": "john@home.org", "type": "home"},
+		},
+	}
+
+	Convey("Test filter evaluation", t, func() {
+		cases := map[string]bool{
+			`userName eq "john"`:   true,
+			`userName ne "john"`:   false,
+			`UserName sw "jo"`:     true,
+			`userName ew "hn"`:     true,
+			`displayName co "n D"`: true,
+			`urn:ietf:params:scim:schemas:core:2.0:User:userName eq "John"`: true,
+			`name.familyName eq "Doe"`:                                      true,
+			`emails co "home.org"`:                                          true,
+			`emails[type eq "work" and value ew "example.com"]`:             true,
+			`emails[type eq "other"]`:                                       false,
+			`active eq true`:                                                true,
+			`active eq false or userName eq "jane"`:                         false,
+			`not (userName eq "jane") and (active eq true)`:                 true,
+			`externalId pr`:                                                 false,
+			`externalId eq null`:                                            true,
+			`displayName pr and userName gt "a"`:                            true,
+		}
+		for expression, expected := range cases {
+			f, e := ParseFilter(expression)
+			So(e, ShouldBeNil)
+			So(f.Match(user), ShouldEqual, expected)
+		}
+	})
+
+	Convey("Test invalid filters", t, func() {
+		for _, expression := range []string{``, `userName`, `userName eq`, `userName eq "x`, `(userName eq "x"`, `userName foo "x"`, `userName eq "x" extra`} {
+			_, e := ParseFilter(expression)
+			So(e, ShouldNotBeNil)
+		}
+	})
+
+	Convey("Test equality extraction", t, func() {
+		f, _ := ParseFilter(`userName eq "john"`)
+		v, ok := EqualityOn(f, "username")
+		So(ok, ShouldBeTrue)
+		So(v, ShouldEqual, "john")
+		f, _ = ParseFilter(`userName eq "john" and active eq true`)
+		_, ok = EqualityOn(f, "userName")
+		So(ok, ShouldBeFalse)
+	})
+}
+
+func TestPatch(t *testing.T) {
+
+	Convey("Test patch operations", t, func() {
+		group := map[string]interface{}{
+			"displayName": "Engineering",
+			"members": []interface{}{
+				map[string]interface{}{"value": "u1"},
+				map[string]interface{}{"value": "u2"},
+			},
+		}
+		e := ApplyPatch(group, []*PatchOperation{
+			{Op: "add", Path: "members", Value: []interface{}{map[string]interface{}{"value": "u3"}}},
+			{Op: "remove", Path: `members[value eq "u1"]`},
+			{Op: "Remove", Path: "members", Value: []interface{}{map[string]interface{}{"value": "u2"}}},
+			{Op: "Replace", Value: map[string]interface{}{"displayName": "R&D"}},
+		})
+		So(e, ShouldBeNil)
+		So(group["displayName"], ShouldEqual, "R&D")
+		So(group["members"], ShouldResemble, []interface{}{map[string]interface{}{"value": "u3"}})
+
+		user := map[string]interface{}{
+			"userName": "john",
+			"emails":   []interface{}{map[string]interface{}{"value": "john@example.com", "type": "work"}},
+		}
+		e = ApplyPatch(user, []*PatchOperation{
+			{Op: "replace", Path: `emails[type eq "work"].value`, Value: "jdoe@example.com"},
+			{Op: "add", Path: "name.givenName", Value: "John"},
+			{Op: "replace", Path: "active", Value: "False"},
+		})
+		So(e, ShouldBeNil)
+		var res User
+		So(fromMap(user, &res), ShouldBeNil)
+		So(res.Emails[0].Value, ShouldEqual, "jdoe@example.com")
+		So(res.Name.GivenName, ShouldEqual, "John")
+		So(*res.Active, ShouldBeFalse)
+
+		So(ApplyPatch(user, []*PatchOperation{{Op: "remove"}}), ShouldNotBeNil)
+		So(ApplyPatch(user, []*PatchOperation{{Op: "replace", Path: `emails[type eq "home"].value`, Value: "x"}}), ShouldNotBeNil)
+		So(ApplyPatch(user, []*PatchOperation{{Op: "move", Path: "userName"}}), ShouldNotBeNil)
+	})
+}
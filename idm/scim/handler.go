@@ -0,0 +1,729 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/log"
+	"github.com/pydio/cells/common/proto/idm"
+	service "github.com/pydio/cells/common/service/proto"
+)
+
+const (
+	// BasePath is the prefix of all SCIM endpoints
+	BasePath = "/scim/v2"
+	// DefaultMaxResults is the default maximum number of resources returned by a query
+	DefaultMaxResults = 200
+)
+
+// Handler serves the SCIM 2.0 protocol for users and groups
+type Handler struct {
+	store  Store
+	router *mux.Router
+
+	// GroupsPath is the parent path of the groups created by SCIM clients
+	GroupsPath string
+	// DefaultProfile is applied to users created without userType
+	DefaultProfile string
+	// MaxResults caps the number of resources returned by a query
+	MaxResults int
+}
+
+// NewHandler creates a SCIM handler on top of the given Store
+func NewHandler(store Store) *Handler {
+	h := &Handler{
+		store:          store,
+		GroupsPath:     "/",
+		DefaultProfile: common.PydioProfileStandard,
+		MaxResults:     DefaultMaxResults,
+	}
+	r := mux.NewRouter()
+	s := r.PathPrefix(BasePath).Subrouter()
+	s.Methods("GET").Path("/ServiceProviderConfig").HandlerFunc(h.serviceProviderConfig)
+	s.Methods("GET").Path("/ResourceTypes").HandlerFunc(h.resourceTypes)
+	s.Methods("GET").Path("/Schemas").HandlerFunc(h.schemas)
+	s.Methods("GET").Path("/Schemas/{id}").HandlerFunc(h.schemas)
+	s.Methods("GET").Path("/Users").HandlerFunc(h.listUsers)
+	s.Methods("POST").Path("/Users").HandlerFunc(h.createUser)
+	s.Methods("GET").Path("/Users/{id}").HandlerFunc(h.getUser)
+	s.Methods("PUT").Path("/Users/{id}").HandlerFunc(h.replaceUser)
+	s.Methods("PATCH").Path("/Users/{id}").HandlerFunc(h.patchUser)
+	s.Methods("DELETE").Path("/Users/{id}").HandlerFunc(h.deleteUser)
+	s.Methods("GET").Path("/Groups").HandlerFunc(h.listGroups)
+	s.Methods("POST").Path("/Groups").HandlerFunc(h.createGroup)
+	s.Methods("GET").Path("/Groups/{id}").HandlerFunc(h.getGroup)
+	s.Methods("PUT").Path("/Groups/{id}").HandlerFunc(h.replaceGroup)
+	s.Methods("PATCH").Path("/Groups/{id}").HandlerFunc(h.patchGroup)
+	s.Methods("DELETE").Path("/Groups/{id}").HandlerFunc(h.deleteGroup)
+	r.NotFoundHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, "", "Unknown endpoint "+r.URL.Path)
+	})
+	h.router = r
+	return h
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.router.ServeHTTP(w, r)
+}
+
+func (h *Handler) listUsers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	f, ok := filterParam(w, r)
+	if !ok {
+		return
+	}
+	q := &idm.UserSingleQuery{GroupPath: "/", Recursive: true, NodeType: idm.NodeType_USER}
+	if v, ok := EqualityOn(f, "userName"); ok {
+		q = &idm.UserSingleQuery{Login: v, NodeType: idm.NodeType_USER}
+	} else if v, ok := EqualityOn(f, "id"); ok {
+		q = &idm.UserSingleQuery{Uuid: v, NodeType: idm.NodeType_USER}
+	} else if v, ok := EqualityOn(f, "externalId"); ok {
+		q = &idm.UserSingleQuery{AttributeName: AttrExternalId, AttributeValue: v, NodeType: idm.NodeType_USER}
+	}
+	users, e := h.store.SearchUsers(ctx, q)
+	if e != nil {
+		h.internalError(ctx, w, e)
+		return
+	}
+	groups, e := h.groupsIndex(ctx)
+	if e != nil {
+		h.internalError(ctx, w, e)
+		return
+	}
+	var resources []interface{}
+	for _, u := range users {
+		if !visible(u) {
+			continue
+		}
+		res := UserToResource(u, groups, baseURL(r))
+		if f != nil {
+			if m, er := toMap(res); er != nil || !f.Match(m) {
+				continue
+			}
+		}
+		resources = append(resources, res)
+	}
+	h.writeList(w, r, resources)
+}
+
+func (h *Handler) getUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	u, ok := h.loadUser(w, r)
+	if !ok {
+		return
+	}
+	groups, e := h.groupsIndex(ctx)
+	if e != nil {
+		h.internalError(ctx, w, e)
+		return
+	}
+	writeJSON(w, http.StatusOK, UserToResource(u, groups, baseURL(r)))
+}
+
+func (h *Handler) createUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var res User
+	if e := readResource(r, &res); e != nil {
+		writeError(w, http.StatusBadRequest, "invalidSyntax", e.Error())
+		return
+	}
+	if existing, e := h.store.SearchUsers(ctx, &idm.UserSingleQuery{Login: res.UserName, NodeType: idm.NodeType_USER}); e != nil {
+		h.internalError(ctx, w, e)
+		return
+	} else if len(existing) > 0 {
+		writeError(w, http.StatusConflict, "uniqueness", "A user with the same userName already exists")
+		return
+	}
+	u := &idm.User{GroupPath: "/", Attributes: map[string]string{idm.UserAttrOrigin: OriginScim}}
+	if e := ApplyUserResource(&res, u); e != nil {
+		writeError(w, http.StatusBadRequest, "invalidValue", e.Error())
+		return
+	}
+	if u.Attributes[idm.UserAttrProfile] == "" {
+		u.Attributes[idm.UserAttrProfile] = h.DefaultProfile
+	}
+	created, e := h.store.PutUser(ctx, u)
+	if e != nil {
+		h.internalError(ctx, w, e)
+		return
+	}
+	if e := h.store.CreateRole(ctx, &idm.Role{
+		Uuid:     created.Uuid,
+		UserRole: true,
+		Label:    "User " + created.Login,
+		Policies: []*service.ResourcePolicy{
+			{Subject: "profile:standard", Action: service.ResourcePolicyAction_READ, Effect: service.ResourcePolicy_allow},
+			{Subject: "user:" + created.Login, Action: service.ResourcePolicyAction_WRITE, Effect: service.ResourcePolicy_allow},
+			{Subject: "profile:admin", Action: service.ResourcePolicyAction_WRITE, Effect: service.ResourcePolicy_allow},
+		},
+	}); e != nil {
+		h.internalError(ctx, w, e)
+		return
+	}
+	out := UserToResource(created, nil, baseURL(r))
+	w.Header().Set("Location", out.Meta.Location)
+	writeJSON(w, http.StatusCreated, out)
+}
+
+func (h *Handler) replaceUser(w http.ResponseWriter, r *http.Request) {
+	u, ok := h.loadUser(w, r)
+	if !ok {
+		return
+	}
+	var res User
+	if e := readResource(r, &res); e != nil {
+		writeError(w, http.StatusBadRequest, "invalidSyntax", e.Error())
+		return
+	}
+	h.updateUser(w, r, u, &res)
+}
+
+func (h *Handler) patchUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	u, ok := h.loadUser(w, r)
+	if !ok {
+		return
+	}
+	groups, e := h.groupsIndex(ctx)
+	if e != nil {
+		h.internalError(ctx, w, e)
+		return
+	}
+	var patched User
+	if !patchResource(w, r, UserToResource(u, groups, baseURL(r)), &patched) {
+		return
+	}
+	h.updateUser(w, r, u, &patched)
+}
+
+func (h *Handler) updateUser(w http.ResponseWriter, r *http.Request, u *idm.User, res *User) {
+	ctx := r.Context()
+	if !strings.EqualFold(res.UserName, u.Login) {
+		if existing, e := h.store.SearchUsers(ctx, &idm.UserSingleQuery{Login: res.UserName, NodeType: idm.NodeType_USER}); e != nil {
+			h.internalError(ctx, w, e)
+			return
+		} else if len(existing) > 0 {
+			writeError(w, http.StatusConflict, "uniqueness", "A user with the same userName already exists")
+			return
+		}
+	}
+	if e := ApplyUserResource(res, u); e != nil {
+		writeError(w, http.StatusBadRequest, "invalidValue", e.Error())
+		return
+	}
+	updated, e := h.store.PutUser(ctx, u)
+	if e != nil {
+		h.internalError(ctx, w, e)
+		return
+	}
+	groups, e := h.groupsIndex(ctx)
+	if e != nil {
+		h.internalError(ctx, w, e)
+		return
+	}
+	writeJSON(w, http.StatusOK, UserToResource(updated, groups, baseURL(r)))
+}
+
+func (h *Handler) deleteUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	n, e := h.store.DeleteUsers(ctx, &idm.UserSingleQuery{Uuid: mux.Vars(r)["id"], NodeType: idm.NodeType_USER})
+	if e != nil {
+		h.internalError(ctx, w, e)
+		return
+	}
+	if n == 0 {
+		writeError(w, http.StatusNotFound, "", "User not found")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) listGroups(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	f, ok := filterParam(w, r)
+	if !ok {
+		return
+	}
+	q := &idm.UserSingleQuery{GroupPath: "/", Recursive: true, NodeType: idm.NodeType_GROUP}
+	if v, ok := EqualityOn(f, "id"); ok {
+		q = &idm.UserSingleQuery{Uuid: v, NodeType: idm.NodeType_GROUP}
+	}
+	groups, e := h.store.SearchUsers(ctx, q)
+	if e != nil {
+		h.internalError(ctx, w, e)
+		return
+	}
+	excludeMembers := excludesMembers(r)
+	var resources []interface{}
+	for _, g := range groups {
+		if g.GroupLabel == "" {
+			continue
+		}
+		var members []*idm.User
+		if !excludeMembers || f != nil {
+			if members, e = h.groupMembers(ctx, g); e != nil {
+				h.internalError(ctx, w, e)
+				return
+			}
+		}
+		res := GroupToResource(g, members, baseURL(r))
+		if f != nil {
+			if m, er := toMap(res); er != nil || !f.Match(m) {
+				continue
+			}
+		}
+		if excludeMembers {
+			res.Members = nil
+		}
+		resources = append(resources, res)
+	}
+	h.writeList(w, r, resources)
+}
+
+func (h *Handler) getGroup(w http.ResponseWriter, r *http.Request) {
+	g, ok := h.loadGroup(w, r)
+	if !ok {
+		return
+	}
+	h.writeGroup(w, r, http.StatusOK, g)
+}
+
+func (h *Handler) createGroup(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var res Group
+	if e := readResource(r, &res); e != nil {
+		writeError(w, http.StatusBadRequest, "invalidSyntax", e.Error())
+		return
+	}
+	if e := validGroupName(res.DisplayName); e != nil {
+		writeError(w, http.StatusBadRequest, "invalidValue", e.Error())
+		return
+	}
+	fullPath := path.Join("/", h.GroupsPath, res.DisplayName)
+	if conflict, e := h.groupExists(ctx, fullPath); e != nil {
+		h.internalError(ctx, w, e)
+		return
+	} else if conflict {
+		writeError(w, http.StatusConflict, "uniqueness", "A group with the same displayName already exists")
+		return
+	}
+	g := &idm.User{IsGroup: true, GroupLabel: res.DisplayName, GroupPath: fullPath, Attributes: map[string]string{}}
+	setOrDelete(g.Attributes, AttrExternalId, res.ExternalId)
+	created, e := h.store.PutUser(ctx, g)
+	if e != nil {
+		h.internalError(ctx, w, e)
+		return
+	}
+	if e := h.store.CreateRole(ctx, &idm.Role{Uuid: created.Uuid, GroupRole: true, Label: "Group " + res.DisplayName}); e != nil {
+		h.internalError(ctx, w, e)
+		return
+	}
+	if status, e := h.syncMembers(ctx, fullPath, nil, res.Members); e != nil {
+		writeError(w, status, "invalidValue", e.Error())
+		return
+	}
+	reloaded, e := h.findOne(ctx, &idm.UserSingleQuery{Uuid: created.Uuid, NodeType: idm.NodeType_GROUP})
+	if e != nil || reloaded == nil {
+		h.internalError(ctx, w, fmt.Errorf("cannot reload group %s: %v", created.Uuid, e))
+		return
+	}
+	w.Header().Set("Location", baseURL(r)+"/Groups/"+created.Uuid)
+	h.writeGroup(w, r, http.StatusCreated, reloaded)
+}
+
+func (h *Handler) replaceGroup(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	g, ok := h.loadGroup(w, r)
+	if !ok {
+		return
+	}
+	var res Group
+	if e := readResource(r, &res); e != nil {
+		writeError(w, http.StatusBadRequest, "invalidSyntax", e.Error())
+		return
+	}
+	members, e := h.groupMembers(ctx, g)
+	if e != nil {
+		h.internalError(ctx, w, e)
+		return
+	}
+	h.updateGroup(w, r, g, members, &res)
+}
+
+func (h *Handler) patchGroup(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	g, ok := h.loadGroup(w, r)
+	if !ok {
+		return
+	}
+	members, e := h.groupMembers(ctx, g)
+	if e != nil {
+		h.internalError(ctx, w, e)
+		return
+	}
+	var patched Group
+	if !patchResource(w, r, GroupToResource(g, members, baseURL(r)), &patched) {
+		return
+	}
+	h.updateGroup(w, r, g, members, &patched)
+}
+
+// updateGroup renames the group if required, then moves users in or out of it to match the requested members
+func (h *Handler) updateGroup(w http.ResponseWriter, r *http.Request, g *idm.User, members []*idm.User, res *Group) {
+	ctx := r.Context()
+	if e := validGroupName(res.DisplayName); e != nil {
+		writeError(w, http.StatusBadRequest, "invalidValue", e.Error())
+		return
+	}
+	fullPath := GroupFullPath(g)
+	if res.DisplayName != g.GroupLabel || res.ExternalId != g.Attributes[AttrExternalId] {
+		newPath := path.Join("/", g.GroupPath, res.DisplayName)
+		if newPath != fullPath {
+			if conflict, e := h.groupExists(ctx, newPath); e != nil {
+				h.internalError(ctx, w, e)
+				return
+			} else if conflict {
+				writeError(w, http.StatusConflict, "uniqueness", "A group with the same displayName already exists")
+				return
+			}
+		}
+		if g.Attributes == nil {
+			g.Attributes = map[string]string{}
+		}
+		setOrDelete(g.Attributes, AttrExternalId, res.ExternalId)
+		g.GroupLabel = res.DisplayName
+		g.GroupPath = newPath
+		if _, e := h.store.PutUser(ctx, g); e != nil {
+			h.internalError(ctx, w, e)
+			return
+		}
+		fullPath = newPath
+	}
+	if status, e := h.syncMembers(ctx, fullPath, members, res.Members); e != nil {
+		writeError(w, status, "invalidValue", e.Error())
+		return
+	}
+	reloaded, e := h.findOne(ctx, &idm.UserSingleQuery{Uuid: g.Uuid, NodeType: idm.NodeType_GROUP})
+	if e != nil || reloaded == nil {
+		h.internalError(ctx, w, fmt.Errorf("cannot reload group %s: %v", g.Uuid, e))
+		return
+	}
+	h.writeGroup(w, r, http.StatusOK, reloaded)
+}
+
+// deleteGroup moves the members back to the root before deleting the group, so that
+// deprovisioning a group never deletes users. Groups with sub-groups are not deleted.
+func (h *Handler) deleteGroup(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	g, ok := h.loadGroup(w, r)
+	if !ok {
+		return
+	}
+	children, e := h.store.SearchUsers(ctx, &idm.UserSingleQuery{GroupPath: GroupFullPath(g), NodeType: idm.NodeType_GROUP})
+	if e != nil {
+		h.internalError(ctx, w, e)
+		return
+	}
+	if len(children) > 0 {
+		writeError(w, http.StatusConflict, "mutability", "Group contains sub-groups and cannot be deleted")
+		return
+	}
+	members, e := h.groupMembers(ctx, g)
+	if e != nil {
+		h.internalError(ctx, w, e)
+		return
+	}
+	if status, e := h.syncMembers(ctx, GroupFullPath(g), members, nil); e != nil {
+		writeError(w, status, "", e.Error())
+		return
+	}
+	if _, e := h.store.DeleteUsers(ctx, &idm.UserSingleQuery{Uuid: g.Uuid, NodeType: idm.NodeType_GROUP}); e != nil {
+		h.internalError(ctx, w, e)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// syncMembers moves users into groupPath or back to the root so that the group direct members match the wanted list
+func (h *Handler) syncMembers(ctx context.Context, groupPath string, current []*idm.User, wanted []MultiValue) (int, error) {
+	wantedIds := make(map[string]bool, len(wanted))
+	for _, m := range wanted {
+		wantedIds[m.Value] = true
+	}
+	currentIds := make(map[string]bool, len(current))
+	for _, u := range current {
+		currentIds[u.Uuid] = true
+		if !wantedIds[u.Uuid] {
+			if e := h.moveUser(ctx, u, "/"); e != nil {
+				return http.StatusInternalServerError, e
+			}
+		}
+	}
+	for _, m := range wanted {
+		if currentIds[m.Value] {
+			continue
+		}
+		u, e := h.findOne(ctx, &idm.UserSingleQuery{Uuid: m.Value, NodeType: idm.NodeType_USER})
+		if e != nil {
+			return http.StatusInternalServerError, e
+		}
+		if u == nil {
+			return http.StatusBadRequest, fmt.Errorf("unknown member %s", m.Value)
+		}
+		if e := h.moveUser(ctx, u, groupPath); e != nil {
+			return http.StatusInternalServerError, e
+		}
+	}
+	return 0, nil
+}
+
+func (h *Handler) moveUser(ctx context.Context, u *idm.User, groupPath string) error {
+	log.Logger(ctx).Debug("Moving user to group", u.ZapLogin(), zap.String("groupPath", groupPath))
+	u.GroupPath = groupPath
+	u.Password = ""
+	_, e := h.store.PutUser(ctx, u)
+	return e
+}
+
+func (h *Handler) groupMembers(ctx context.Context, g *idm.User) (members []*idm.User, e error) {
+	users, e := h.store.SearchUsers(ctx, &idm.UserSingleQuery{GroupPath: GroupFullPath(g), NodeType: idm.NodeType_USER})
+	if e != nil {
+		return nil, e
+	}
+	for _, u := range users {
+		if visible(u) {
+			members = append(members, u)
+		}
+	}
+	return
+}
+
+// groupsIndex loads all groups, indexed by their full path
+func (h *Handler) groupsIndex(ctx context.Context) (map[string]*idm.User, error) {
+	groups, e := h.store.SearchUsers(ctx, &idm.UserSingleQuery{GroupPath: "/", Recursive: true, NodeType: idm.NodeType_GROUP})
+	if e != nil {
+		return nil, e
+	}
+	index := make(map[string]*idm.User, len(groups))
+	for _, g := range groups {
+		if g.GroupLabel != "" {
+			index[GroupFullPath(g)] = g
+		}
+	}
+	return index, nil
+}
+
+func (h *Handler) groupExists(ctx context.Context, fullPath string) (bool, error) {
+	index, e := h.groupsIndex(ctx)
+	if e != nil {
+		return false, e
+	}
+	_, ok := index[fullPath]
+	return ok, nil
+}
+
+func (h *Handler) findOne(ctx context.Context, q *idm.UserSingleQuery) (*idm.User, error) {
+	users, e := h.store.SearchUsers(ctx, q)
+	if e != nil || len(users) == 0 {
+		return nil, e
+	}
+	return users[0], nil
+}
+
+func (h *Handler) loadUser(w http.ResponseWriter, r *http.Request) (*idm.User, bool) {
+	u, e := h.findOne(r.Context(), &idm.UserSingleQuery{Uuid: mux.Vars(r)["id"], NodeType: idm.NodeType_USER})
+	if e != nil {
+		h.internalError(r.Context(), w, e)
+		return nil, false
+	}
+	if u == nil || !visible(u) {
+		writeError(w, http.StatusNotFound, "", "User not found")
+		return nil, false
+	}
+	u.Password = ""
+	return u, true
+}
+
+func (h *Handler) loadGroup(w http.ResponseWriter, r *http.Request) (*idm.User, bool) {
+	g, e := h.findOne(r.Context(), &idm.UserSingleQuery{Uuid: mux.Vars(r)["id"], NodeType: idm.NodeType_GROUP})
+	if e != nil {
+		h.internalError(r.Context(), w, e)
+		return nil, false
+	}
+	if g == nil || g.GroupLabel == "" {
+		writeError(w, http.StatusNotFound, "", "Group not found")
+		return nil, false
+	}
+	return g, true
+}
+
+func (h *Handler) writeGroup(w http.ResponseWriter, r *http.Request, status int, g *idm.User) {
+	var members []*idm.User
+	if !excludesMembers(r) {
+		var e error
+		if members, e = h.groupMembers(r.Context(), g); e != nil {
+			h.internalError(r.Context(), w, e)
+			return
+		}
+	}
+	writeJSON(w, status, GroupToResource(g, members, baseURL(r)))
+}
+
+// writeList paginates resources using the startIndex (1-based) and count parameters
+func (h *Handler) writeList(w http.ResponseWriter, r *http.Request, resources []interface{}) {
+	start := 1
+	if s, e := strconv.Atoi(r.URL.Query().Get("startIndex")); e == nil && s > 1 {
+		start = s
+	}
+	count := h.MaxResults
+	if c, e := strconv.Atoi(r.URL.Query().Get("count")); e == nil && c >= 0 && c < count {
+		count = c
+	}
+	page := []interface{}{}
+	if start <= len(resources) {
+		end := start - 1 + count
+		if end > len(resources) {
+			end = len(resources)
+		}
+		page = resources[start-1 : end]
+	}
+	writeJSON(w, http.StatusOK, &ListResponse{
+		Schemas:      []string{SchemaListResponse},
+		TotalResults: len(resources),
+		StartIndex:   start,
+		ItemsPerPage: len(page),
+		Resources:    page,
+	})
+}
+
+func (h *Handler) internalError(ctx context.Context, w http.ResponseWriter, e error) {
+	log.Logger(ctx).Error("SCIM request failed", zap.Error(e))
+	writeError(w, http.StatusInternalServerError, "", e.Error())
+}
+
+// patchResource applies a PatchOp request on the resource and decodes the result into patched
+func patchResource(w http.ResponseWriter, r *http.Request, resource interface{}, patched interface{}) bool {
+	var req PatchRequest
+	if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
+		writeError(w, http.StatusBadRequest, "invalidSyntax", e.Error())
+		return false
+	}
+	if len(req.Operations) == 0 {
+		writeError(w, http.StatusBadRequest, "invalidSyntax", "No operations provided")
+		return false
+	}
+	m, e := toMap(resource)
+	if e != nil {
+		writeError(w, http.StatusInternalServerError, "", e.Error())
+		return false
+	}
+	if e := ApplyPatch(m, req.Operations); e != nil {
+		writeError(w, http.StatusBadRequest, "invalidPath", e.Error())
+		return false
+	}
+	if e := fromMap(m, patched); e != nil {
+		writeError(w, http.StatusBadRequest, "invalidValue", e.Error())
+		return false
+	}
+	return true
+}
+
+func filterParam(w http.ResponseWriter, r *http.Request) (Filter, bool) {
+	expression := r.URL.Query().Get("filter")
+	if expression == "" {
+		return nil, true
+	}
+	f, e := ParseFilter(expression)
+	if e != nil {
+		writeError(w, http.StatusBadRequest, "invalidFilter", e.Error())
+		return nil, false
+	}
+	return f, true
+}
+
+func excludesMembers(r *http.Request) bool {
+	for _, a := range strings.Split(r.URL.Query().Get("excludedAttributes"), ",") {
+		if strings.EqualFold(strings.TrimSpace(a), "members") {
+			return true
+		}
+	}
+	return false
+}
+
+func validGroupName(name string) error {
+	if strings.TrimSpace(name) == "" {
+		return fmt.Errorf("displayName is required")
+	}
+	if strings.Contains(name, "/") {
+		return fmt.Errorf("displayName cannot contain a slash")
+	}
+	return nil
+}
+
+// visible excludes hidden users (used for public links) and the anonymous user from SCIM
+func visible(u *idm.User) bool {
+	return !u.IsHidden() && u.Login != common.PydioS3AnonUsername
+}
+
+func readResource(r *http.Request, resource interface{}) error {
+	m := make(map[string]interface{})
+	if e := json.NewDecoder(r.Body).Decode(&m); e != nil {
+		return e
+	}
+	return fromMap(m, resource)
+}
+
+func baseURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	if p := r.Header.Get("X-Forwarded-Proto"); p != "" {
+		scheme = p
+	}
+	return scheme + "://" + r.Host + BasePath
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/scim+json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, scimType, detail string) {
+	writeJSON(w, status, &Error{
+		Schemas:  []string{SchemaError},
+		Status:   strconv.Itoa(status),
+		ScimType: scimType,
+		Detail:   detail,
+	})
+}
@@ -0,0 +1,237 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/pydio/cells/common/proto/idm"
+)
+
+// memStore emulates the users service: groups are written with their full path in GroupPath
+// and read with their parent path.
+type memStore struct {
+	objects []*idm.User
+	roles   []*idm.Role
+	seq     int
+}
+
+func (m *memStore) SearchUsers(ctx context.Context, q *idm.UserSingleQuery) (users []*idm.User, e error) {
+	for _, u := range m.objects {
+		if q.Uuid != "" && u.Uuid != q.Uuid || q.Login != "" && u.Login != q.Login {
+			continue
+		}
+		if q.NodeType == idm.NodeType_USER && u.IsGroup || q.NodeType == idm.NodeType_GROUP && !u.IsGroup {
+			continue
+		}
+		if q.AttributeName != "" && u.Attributes[q.AttributeName] != q.AttributeValue {
+			continue
+		}
+		if q.GroupPath != "" {
+			parent := u.GroupPath
+			if q.Recursive && !strings.HasPrefix(parent, q.GroupPath) || !q.Recursive && parent != q.GroupPath {
+				continue
+			}
+		}
+		users = append(users, proto.Clone(u).(*idm.User))
+	}
+	return
+}
+
+func (m *memStore) PutUser(ctx context.Context, user *idm.User) (*idm.User, error) {
+	stored := proto.Clone(user).(*idm.User)
+	if stored.IsGroup {
+		stored.GroupPath = path.Dir(user.GroupPath)
+	}
+	if stored.Uuid == "" {
+		m.seq++
+		stored.Uuid = fmt.Sprintf("uuid-%d", m.seq)
+	}
+	for i, u := range m.objects {
+		if u.Uuid == stored.Uuid {
+			if u.IsGroup && GroupFullPath(u) != user.GroupPath {
+				for _, child := range m.objects {
+					if !child.IsGroup && child.GroupPath == GroupFullPath(u) {
+						child.GroupPath = user.GroupPath
+					}
+				}
+			}
+			m.objects[i] = stored
+			return proto.Clone(stored).(*idm.User), nil
+		}
+	}
+	m.objects = append(m.objects, stored)
+	return proto.Clone(stored).(*idm.User), nil
+}
+
+func (m *memStore) DeleteUsers(ctx context.Context, q *idm.UserSingleQuery) (n int64, e error) {
+	var kept []*idm.User
+	for _, u := range m.objects {
+		if u.Uuid == q.Uuid {
+			n++
+		} else {
+			kept = append(kept, u)
+		}
+	}
+	m.objects = kept
+	return
+}
+
+func (m *memStore) CreateRole(ctx context.Context, role *idm.Role) error {
+	m.roles = append(m.roles, role)
+	return nil
+}
+
+func TestHandler(t *testing.T) {
+
+	Convey("Test SCIM users and groups", t, func() {
+
+		store := &memStore{}
+		h := NewHandler(store)
+
+		do := func(method, target string, body interface{}, out interface{}) int {
+			var reader *strings.Reader
+			if s, ok := body.(string); ok {
+				reader = strings.NewReader(s)
+			} else {
+				data, _ := json.Marshal(body)
+				reader = strings.NewReader(string(data))
+			}
+			req := httptest.NewRequest(method, target, reader)
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+			if out != nil {
+				// Reset target so that omitted attributes do not keep previous values
+				switch o := out.(type) {
+				case *User:
+					*o = User{}
+				case *Group:
+					*o = Group{}
+				case *ListResponse:
+					*o = ListResponse{}
+				}
+				So(json.Unmarshal(rec.Body.Bytes(), out), ShouldBeNil)
+			}
+			return rec.Code
+		}
+
+		var john User
+		So(do(http.MethodPost, "/scim/v2/Users", `{
+			"schemas":["urn:ietf:params:scim:schemas:core:2.0:User"],
+			"userName":"john","externalId":"ext-1","active":"True",
+			"name":{"givenName":"John","familyName":"Doe"},
+			"emails":[{"value":"john@example.com","primary":true}],
+			"password":"secret"
+		}`, &john), ShouldEqual, http.StatusCreated)
+		So(john.Id, ShouldNotBeEmpty)
+		So(john.DisplayName, ShouldEqual, "John Doe")
+		So(john.UserType, ShouldEqual, "standard")
+		So(john.Password, ShouldBeEmpty)
+		So(store.roles, ShouldHaveLength, 1)
+		So(store.roles[0].UserRole, ShouldBeTrue)
+		So(store.objects[0].Password, ShouldEqual, "secret")
+		So(store.objects[0].Attributes[idm.UserAttrOrigin], ShouldEqual, OriginScim)
+
+		So(do(http.MethodPost, "/scim/v2/Users", `{"userName":"john"}`, nil), ShouldEqual, http.StatusConflict)
+		So(do(http.MethodPost, "/scim/v2/Users", `{"userName":"jane","userType":"superuser"}`, nil), ShouldEqual, http.StatusBadRequest)
+		var jane User
+		So(do(http.MethodPost, "/scim/v2/Users", `{"userName":"jane","displayName":"Jane"}`, &jane), ShouldEqual, http.StatusCreated)
+
+		var list ListResponse
+		So(do(http.MethodGet, "/scim/v2/Users?filter="+url.QueryEscape(`userName eq "john"`), "", &list), ShouldEqual, http.StatusOK)
+		So(list.TotalResults, ShouldEqual, 1)
+		So(do(http.MethodGet, "/scim/v2/Users?filter="+url.QueryEscape(`emails co "example.com" or displayName sw "ja"`), "", &list), ShouldEqual, http.StatusOK)
+		So(list.TotalResults, ShouldEqual, 2)
+		So(do(http.MethodGet, "/scim/v2/Users?startIndex=2&count=5", "", &list), ShouldEqual, http.StatusOK)
+		So(list.TotalResults, ShouldEqual, 2)
+		So(list.ItemsPerPage, ShouldEqual, 1)
+		So(do(http.MethodGet, "/scim/v2/Users?filter=userName+eq", "", nil), ShouldEqual, http.StatusBadRequest)
+
+		// Deactivate through PATCH
+		var patched User
+		So(do(http.MethodPatch, "/scim/v2/Users/"+john.Id, `{
+			"schemas":["urn:ietf:params:scim:api:messages:2.0:PatchOp"],
+			"Operations":[{"op":"Replace","path":"active","value":"False"},{"op":"replace","path":"displayName","value":"Johnny"}]
+		}`, &patched), ShouldEqual, http.StatusOK)
+		So(*patched.Active, ShouldBeFalse)
+		So(patched.DisplayName, ShouldEqual, "Johnny")
+		So(patched.ExternalId, ShouldEqual, "ext-1")
+		So(store.objects[0].Attributes["locks"], ShouldEqual, `["logout"]`)
+
+		// Groups and membership
+		var group Group
+		So(do(http.MethodPost, "/scim/v2/Groups", map[string]interface{}{
+			"displayName": "Engineering",
+			"members":     []interface{}{map[string]string{"value": john.Id}},
+		}, &group), ShouldEqual, http.StatusCreated)
+		So(group.Members, ShouldHaveLength, 1)
+		So(store.roles[len(store.roles)-1].GroupRole, ShouldBeTrue)
+		So(do(http.MethodPost, "/scim/v2/Groups", `{"displayName":"Engineering"}`, nil), ShouldEqual, http.StatusConflict)
+
+		var fetched User
+		So(do(http.MethodGet, "/scim/v2/Users/"+john.Id, "", &fetched), ShouldEqual, http.StatusOK)
+		So(fetched.Groups, ShouldHaveLength, 1)
+		So(fetched.Groups[0].Value, ShouldEqual, group.Id)
+
+		So(do(http.MethodPatch, "/scim/v2/Groups/"+group.Id, map[string]interface{}{
+			"schemas": []string{SchemaPatchOp},
+			"Operations": []interface{}{
+				map[string]interface{}{"op": "add", "path": "members", "value": []interface{}{map[string]string{"value": jane.Id}}},
+				map[string]interface{}{"op": "remove", "path": `members[value eq "` + john.Id + `"]`},
+				map[string]interface{}{"op": "replace", "path": "displayName", "value": "R&D"},
+			},
+		}, &group), ShouldEqual, http.StatusOK)
+		So(group.DisplayName, ShouldEqual, "R&D")
+		So(group.Members, ShouldHaveLength, 1)
+		So(group.Members[0].Value, ShouldEqual, jane.Id)
+		So(do(http.MethodGet, "/scim/v2/Users/"+john.Id, "", &fetched), ShouldEqual, http.StatusOK)
+		So(fetched.Groups, ShouldBeEmpty)
+
+		So(do(http.MethodGet, "/scim/v2/Groups?excludedAttributes=members&filter="+url.QueryEscape(`members[value eq "`+jane.Id+`"]`), "", &list), ShouldEqual, http.StatusOK)
+		So(list.TotalResults, ShouldEqual, 1)
+		So(list.Resources[0].(map[string]interface{})["members"], ShouldBeNil)
+
+		// Deleting the group keeps its members
+		So(do(http.MethodDelete, "/scim/v2/Groups/"+group.Id, "", nil), ShouldEqual, http.StatusNoContent)
+		So(do(http.MethodGet, "/scim/v2/Users/"+jane.Id, "", &fetched), ShouldEqual, http.StatusOK)
+		So(fetched.Groups, ShouldBeEmpty)
+
+		So(do(http.MethodDelete, "/scim/v2/Users/"+john.Id, "", nil), ShouldEqual, http.StatusNoContent)
+		So(do(http.MethodGet, "/scim/v2/Users/"+john.Id, "", nil), ShouldEqual, http.StatusNotFound)
+		So(do(http.MethodDelete, "/scim/v2/Users/"+john.Id, "", nil), ShouldEqual, http.StatusNotFound)
+
+		So(do(http.MethodGet, "/scim/v2/ServiceProviderConfig", "", nil), ShouldEqual, http.StatusOK)
+		So(do(http.MethodGet, "/scim/v2/Schemas", "", &list), ShouldEqual, http.StatusOK)
+		So(list.TotalResults, ShouldEqual, 2)
+		So(do(http.MethodGet, "/scim/v2/Schemas/"+SchemaGroup, "", nil), ShouldEqual, http.StatusOK)
+	})
+}
@@ -0,0 +1,260 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package scim
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PatchOperation is a single operation of a PatchOp request (RFC 7644 section 3.5.2)
+type PatchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path,omitempty"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// PatchRequest is the body of a PATCH request
+type PatchRequest struct {
+	Schemas    []string          `json:"schemas"`
+	Operations []*PatchOperation `json:"Operations"`
+}
+
+// patchPath is a parsed "attr[filter].subAttr" path
+type patchPath struct {
+	attr   string
+	filter Filter
+	sub    string
+}
+
+func parsePatchPath(p string) (*patchPath, error) {
+	out := &patchPath{}
+	if open := strings.Index(p, "["); open > -1 {
+		end := strings.LastIndex(p, "]")
+		if end < open {
+			return nil, fmt.Errorf("invalid path %s", p)
+		}
+		f, e := ParseFilter(p[open+1 : end])
+		if e != nil {
+			return nil, e
+		}
+		out.filter = f
+		out.sub = strings.TrimPrefix(p[end+1:], ".")
+		p = p[:open]
+	}
+	parts := splitAttrPath(p)
+	out.attr = parts[0]
+	if len(parts) > 1 {
+		if out.filter != nil {
+			return nil, fmt.Errorf("invalid path %s", p)
+		}
+		out.sub = strings.Join(parts[1:], ".")
+	}
+	if out.attr == "" {
+		return nil, fmt.Errorf("invalid path %s", p)
+	}
+	return out, nil
+}
+
+// ApplyPatch applies the operations to the generic representation of a resource.
+func ApplyPatch(resource map[string]interface{}, operations []*PatchOperation) error {
+	for _, op := range operations {
+		var e error
+		switch strings.ToLower(op.Op) {
+		case "add":
+			e = patchSet(resource, op, true)
+		case "replace":
+			e = patchSet(resource, op, false)
+		case "remove":
+			e = patchRemove(resource, op)
+		default:
+			e = fmt.Errorf("unsupported operation %s", op.Op)
+		}
+		if e != nil {
+			return e
+		}
+	}
+	return nil
+}
+
+func patchSet(resource map[string]interface{}, op *PatchOperation, add bool) error {
+	if op.Path == "" {
+		values, ok := op.Value.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("operation %s without path requires an object value", op.Op)
+		}
+		for k, v := range values {
+			p, e := parsePatchPath(k)
+			if e != nil {
+				return e
+			}
+			setAttr(resource, p, v, add)
+		}
+		return nil
+	}
+	p, e := parsePatchPath(op.Path)
+	if e != nil {
+		return e
+	}
+	if p.filter == nil {
+		setAttr(resource, p, op.Value, add)
+		return nil
+	}
+	key, current, _ := lookupKey(resource, p.attr)
+	if key == "" {
+		key = p.attr
+	}
+	items, _ := current.([]interface{})
+	var matched bool
+	for i, item := range items {
+		m, ok := item.(map[string]interface{})
+		if !ok || !p.filter.Match(m) {
+			continue
+		}
+		matched = true
+		if p.sub != "" {
+			setAttr(m, &patchPath{attr: p.sub}, op.Value, add)
+		} else if values, ok := op.Value.(map[string]interface{}); ok {
+			for k, v := range values {
+				setAttr(m, &patchPath{attr: k}, v, false)
+			}
+		} else {
+			items[i] = op.Value
+		}
+	}
+	if !matched {
+		return fmt.Errorf("no value matches path %s", op.Path)
+	}
+	resource[key] = items
+	return nil
+}
+
+// setAttr sets or merges a value on a possibly nested attribute. Add appends to multi-valued attributes.
+func setAttr(resource map[string]interface{}, p *patchPath, value interface{}, add bool) {
+	if p.sub == "" && strings.Contains(p.attr, ".") {
+		parts := splitAttrPath(p.attr)
+		p = &patchPath{attr: parts[0], sub: strings.Join(parts[1:], ".")}
+	}
+	key, current, found := lookupKey(resource, p.attr)
+	if !found {
+		key = p.attr
+	}
+	if p.sub != "" {
+		m, ok := current.(map[string]interface{})
+		if !ok {
+			m = map[string]interface{}{}
+		}
+		setAttr(m, &patchPath{attr: p.sub}, value, add)
+		resource[key] = m
+		return
+	}
+	if existing, ok := current.([]interface{}); ok && add {
+		if values, ok := value.([]interface{}); ok {
+			resource[key] = append(existing, values...)
+		} else {
+			resource[key] = append(existing, value)
+		}
+		return
+	}
+	if existing, ok := current.(map[string]interface{}); ok {
+		if values, ok := value.(map[string]interface{}); ok {
+			for k, v := range values {
+				setAttr(existing, &patchPath{attr: k}, v, add)
+			}
+			return
+		}
+	}
+	resource[key] = value
+}
+
+func patchRemove(resource map[string]interface{}, op *PatchOperation) error {
+	if op.Path == "" {
+		return fmt.Errorf("remove operation requires a path")
+	}
+	p, e := parsePatchPath(op.Path)
+	if e != nil {
+		return e
+	}
+	key, current, found := lookupKey(resource, p.attr)
+	if !found {
+		return nil
+	}
+	if p.filter == nil && p.sub != "" {
+		if m, ok := current.(map[string]interface{}); ok {
+			if k, _, ok := lookupKey(m, p.sub); ok {
+				delete(m, k)
+			}
+		}
+		return nil
+	}
+	items, isArray := current.([]interface{})
+	if p.filter == nil && (!isArray || op.Value == nil) {
+		delete(resource, key)
+		return nil
+	}
+	var kept []interface{}
+	for _, item := range items {
+		m, _ := item.(map[string]interface{})
+		var match bool
+		if p.filter != nil {
+			match = m != nil && p.filter.Match(m)
+		} else {
+			// Non-standard but widely used form: remove the listed values, e.g. {"path":"members","value":[{"value":"id"}]}
+			match = containsValue(op.Value, item)
+		}
+		if !match {
+			kept = append(kept, item)
+		} else if p.sub != "" {
+			if k, _, ok := lookupKey(m, p.sub); ok {
+				delete(m, k)
+			}
+			kept = append(kept, item)
+		}
+	}
+	if len(kept) == 0 {
+		delete(resource, key)
+	} else {
+		resource[key] = kept
+	}
+	return nil
+}
+
+func containsValue(values interface{}, item interface{}) bool {
+	list, ok := values.([]interface{})
+	if !ok {
+		list = []interface{}{values}
+	}
+	for _, v := range list {
+		if valueString(v) == valueString(item) && valueString(v) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// valueString returns the "value" sub-attribute of a complex value, or the value itself if it is a string
+func valueString(v interface{}) string {
+	if m, ok := v.(map[string]interface{}); ok {
+		_, v, _ = lookupKey(m, "value")
+	}
+	s, _ := v.(string)
+	return s
+}
@@ -0,0 +1,61 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+// Package scim exposes users and groups through the SCIM 2.0 provisioning protocol.
+package scim
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/config"
+	"github.com/pydio/cells/common/plugins"
+	"github.com/pydio/cells/common/service"
+	servicecontext "github.com/pydio/cells/common/service/context"
+)
+
+var (
+	// ServiceName is the SCIM service name
+	ServiceName = common.ServiceWebNamespace_ + common.ServiceScim
+)
+
+func init() {
+	plugins.Register("main", func(ctx context.Context) {
+		service.NewService(
+			service.Name(ServiceName),
+			service.Context(ctx),
+			service.Tag(common.ServiceTagIdm),
+			service.Description("SCIM 2.0 provisioning of users and groups"),
+			service.Dependency(common.ServiceGrpcNamespace_+common.ServiceUser, []string{}),
+			service.Dependency(common.ServiceGrpcNamespace_+common.ServiceRole, []string{}),
+			service.WithHTTP(func() http.Handler {
+				handler := NewHandler(NewGrpcStore())
+				conf := config.Get("services", ServiceName)
+				handler.GroupsPath = conf.Val("groupsPath").Default("/").String()
+				handler.DefaultProfile = conf.Val("defaultProfile").Default(common.PydioProfileStandard).String()
+				if m := conf.Val("maxResults").Default(DefaultMaxResults).Int(); m > 0 {
+					handler.MaxResults = m
+				}
+				return servicecontext.HttpMetaExtractorWrapper(auth(handler))
+			}),
+		)
+	})
+}
@@ -0,0 +1,294 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package scim
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/proto/idm"
+)
+
+const (
+	SchemaUser                  = "urn:ietf:params:scim:schemas:core:2.0:User"
+	SchemaGroup                 = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	SchemaServiceProviderConfig = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+	SchemaResourceType          = "urn:ietf:params:scim:schemas:core:2.0:ResourceType"
+	SchemaSchema                = "urn:ietf:params:scim:schemas:core:2.0:Schema"
+	SchemaListResponse          = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	SchemaPatchOp               = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	SchemaError                 = "urn:ietf:params:scim:api:messages:2.0:Error"
+
+	// AttrExternalId stores the identifier of the user or group in the provisioning client
+	AttrExternalId = idm.UserAttrPrivatePrefix + "scimExternalId"
+	// AttrGivenName and AttrFamilyName store the name components sent by the provisioning client
+	AttrGivenName  = idm.UserAttrPrivatePrefix + "scimGivenName"
+	AttrFamilyName = idm.UserAttrPrivatePrefix + "scimFamilyName"
+	// OriginScim is the value of the origin attribute for users created by this service
+	OriginScim = "scim"
+
+	lockLogout = "logout"
+)
+
+// Meta is the common meta attribute of resources
+type Meta struct {
+	ResourceType string `json:"resourceType"`
+	Location     string `json:"location,omitempty"`
+}
+
+// Name is the components of a user real name
+type Name struct {
+	Formatted  string `json:"formatted,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+	GivenName  string `json:"givenName,omitempty"`
+}
+
+// MultiValue is an item of a multi-valued attribute like emails, groups or members
+type MultiValue struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+	Ref     string `json:"$ref,omitempty"`
+}
+
+// User is the SCIM representation of a Cells user
+type User struct {
+	Schemas     []string     `json:"schemas"`
+	Id          string       `json:"id,omitempty"`
+	ExternalId  string       `json:"externalId,omitempty"`
+	UserName    string       `json:"userName"`
+	Name        *Name        `json:"name,omitempty"`
+	DisplayName string       `json:"displayName,omitempty"`
+	UserType    string       `json:"userType,omitempty"`
+	Active      *bool        `json:"active,omitempty"`
+	Password    string       `json:"password,omitempty"`
+	Emails      []MultiValue `json:"emails,omitempty"`
+	Groups      []MultiValue `json:"groups,omitempty"`
+	Meta        *Meta        `json:"meta,omitempty"`
+}
+
+// Group is the SCIM representation of a Cells group. Members are the users directly
+// inside the group: as a Cells user belongs to exactly one group, adding a member moves
+// the user into the group and removing it moves the user back to the root.
+type Group struct {
+	Schemas     []string     `json:"schemas"`
+	Id          string       `json:"id,omitempty"`
+	ExternalId  string       `json:"externalId,omitempty"`
+	DisplayName string       `json:"displayName"`
+	Members     []MultiValue `json:"members,omitempty"`
+	Meta        *Meta        `json:"meta,omitempty"`
+}
+
+// ListResponse is the response of queries on resources
+type ListResponse struct {
+	Schemas      []string      `json:"schemas"`
+	TotalResults int           `json:"totalResults"`
+	StartIndex   int           `json:"startIndex"`
+	ItemsPerPage int           `json:"itemsPerPage"`
+	Resources    []interface{} `json:"Resources"`
+}
+
+// Error is the SCIM error response
+type Error struct {
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status"`
+	ScimType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail,omitempty"`
+}
+
+// GroupFullPath returns the path of a group as used in its members GroupPath.
+// Groups read from the users service carry their parent path in GroupPath.
+func GroupFullPath(g *idm.User) string {
+	return path.Join("/", g.GroupPath, g.GroupLabel)
+}
+
+// UserGroupPath normalizes the GroupPath of a user
+func UserGroupPath(u *idm.User) string {
+	return path.Join("/", u.GroupPath)
+}
+
+// UserToResource builds the SCIM representation of a user. Groups are indexed by their full path.
+func UserToResource(u *idm.User, groups map[string]*idm.User, baseURL string) *User {
+	att := u.Attributes
+	res := &User{
+		Schemas:     []string{SchemaUser},
+		Id:          u.Uuid,
+		ExternalId:  att[AttrExternalId],
+		UserName:    u.Login,
+		DisplayName: att[idm.UserAttrDisplayName],
+		UserType:    att[idm.UserAttrProfile],
+		Meta:        &Meta{ResourceType: "User", Location: baseURL + "/Users/" + u.Uuid},
+	}
+	active := !IsLocked(u)
+	res.Active = &active
+	if att[AttrGivenName] != "" || att[AttrFamilyName] != "" || res.DisplayName != "" {
+		res.Name = &Name{Formatted: res.DisplayName, GivenName: att[AttrGivenName], FamilyName: att[AttrFamilyName]}
+	}
+	if email := att[idm.UserAttrEmail]; email != "" {
+		res.Emails = []MultiValue{{Value: email, Type: "work", Primary: true}}
+	}
+	if g, ok := groups[UserGroupPath(u)]; ok {
+		res.Groups = []MultiValue{{Value: g.Uuid, Display: g.GroupLabel, Ref: baseURL + "/Groups/" + g.Uuid}}
+	}
+	return res
+}
+
+// ApplyUserResource writes the resource attributes on the target user, replacing the attributes managed by SCIM.
+func ApplyUserResource(res *User, target *idm.User) error {
+	if strings.TrimSpace(res.UserName) == "" {
+		return fmt.Errorf("userName is required")
+	}
+	if target.Attributes == nil {
+		target.Attributes = map[string]string{}
+	}
+	att := target.Attributes
+	target.Login = res.UserName
+	target.Password = res.Password
+
+	displayName := res.DisplayName
+	var given, family string
+	if res.Name != nil {
+		given, family = res.Name.GivenName, res.Name.FamilyName
+		if displayName == "" {
+			displayName = res.Name.Formatted
+		}
+		if displayName == "" {
+			displayName = strings.TrimSpace(given + " " + family)
+		}
+	}
+	setOrDelete(att, idm.UserAttrDisplayName, displayName)
+	setOrDelete(att, AttrGivenName, given)
+	setOrDelete(att, AttrFamilyName, family)
+	setOrDelete(att, AttrExternalId, res.ExternalId)
+
+	var email string
+	for _, e := range res.Emails {
+		if email == "" || e.Primary {
+			email = e.Value
+		}
+	}
+	setOrDelete(att, idm.UserAttrEmail, email)
+
+	if res.UserType != "" {
+		switch res.UserType {
+		case common.PydioProfileStandard, common.PydioProfileShared, common.PydioProfileAdmin:
+			att[idm.UserAttrProfile] = res.UserType
+		default:
+			return fmt.Errorf("invalid userType %s, use one of %s, %s or %s", res.UserType, common.PydioProfileStandard, common.PydioProfileShared, common.PydioProfileAdmin)
+		}
+	}
+	if res.Active != nil {
+		SetLocked(target, !*res.Active)
+	}
+	return nil
+}
+
+// GroupToResource builds the SCIM representation of a group. Members may be nil if they were not loaded.
+func GroupToResource(g *idm.User, members []*idm.User, baseURL string) *Group {
+	res := &Group{
+		Schemas:     []string{SchemaGroup},
+		Id:          g.Uuid,
+		ExternalId:  g.Attributes[AttrExternalId],
+		DisplayName: g.GroupLabel,
+		Meta:        &Meta{ResourceType: "Group", Location: baseURL + "/Groups/" + g.Uuid},
+	}
+	for _, m := range members {
+		display := m.Attributes[idm.UserAttrDisplayName]
+		if display == "" {
+			display = m.Login
+		}
+		res.Members = append(res.Members, MultiValue{Value: m.Uuid, Display: display, Ref: baseURL + "/Users/" + m.Uuid})
+	}
+	return res
+}
+
+// IsLocked checks if the user has a logout lock
+func IsLocked(u *idm.User) bool {
+	for _, l := range userLocks(u) {
+		if l == lockLogout {
+			return true
+		}
+	}
+	return false
+}
+
+// SetLocked adds or removes the logout lock, keeping other locks untouched
+func SetLocked(u *idm.User, locked bool) {
+	var locks []string
+	for _, l := range userLocks(u) {
+		if l != lockLogout {
+			locks = append(locks, l)
+		}
+	}
+	if locked {
+		locks = append(locks, lockLogout)
+	}
+	if len(locks) == 0 {
+		delete(u.Attributes, "locks")
+		return
+	}
+	data, _ := json.Marshal(locks)
+	u.Attributes["locks"] = string(data)
+}
+
+func userLocks(u *idm.User) (locks []string) {
+	if l, ok := u.Attributes["locks"]; ok {
+		json.Unmarshal([]byte(l), &locks)
+	}
+	return
+}
+
+func setOrDelete(att map[string]string, key, value string) {
+	if value == "" {
+		delete(att, key)
+	} else {
+		att[key] = value
+	}
+}
+
+// toMap converts a resource to its generic JSON representation, used for filtering and patching
+func toMap(resource interface{}) (map[string]interface{}, error) {
+	data, e := json.Marshal(resource)
+	if e != nil {
+		return nil, e
+	}
+	m := make(map[string]interface{})
+	return m, json.Unmarshal(data, &m)
+}
+
+// fromMap converts a generic representation back to a resource.
+// Some clients send booleans as strings, they are converted before decoding.
+func fromMap(m map[string]interface{}, resource interface{}) error {
+	if k, v, ok := lookupKey(m, "active"); ok {
+		if s, isString := v.(string); isString {
+			m[k] = strings.EqualFold(s, "true")
+		}
+	}
+	data, e := json.Marshal(m)
+	if e != nil {
+		return e
+	}
+	return json.Unmarshal(data, resource)
+}
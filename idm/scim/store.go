@@ -0,0 +1,100 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package scim
+
+import (
+	"context"
+	"io"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/micro/go-micro/client"
+
+	"github.com/pydio/cells/common"
+	defaults "github.com/pydio/cells/common/micro"
+	"github.com/pydio/cells/common/proto/idm"
+	service "github.com/pydio/cells/common/service/proto"
+)
+
+// Store gives access to users, groups and roles. The default implementation goes through
+// the grpc services, so that the usual idm.ChangeEvent are published on every change.
+type Store interface {
+	SearchUsers(ctx context.Context, query *idm.UserSingleQuery) ([]*idm.User, error)
+	PutUser(ctx context.Context, user *idm.User) (*idm.User, error)
+	DeleteUsers(ctx context.Context, query *idm.UserSingleQuery) (int64, error)
+	CreateRole(ctx context.Context, role *idm.Role) error
+}
+
+type grpcStore struct {
+	cl client.Client
+}
+
+// NewGrpcStore creates a Store using the users and roles grpc services
+func NewGrpcStore() Store {
+	return &grpcStore{cl: defaults.NewClient()}
+}
+
+func (g *grpcStore) users() idm.UserServiceClient {
+	return idm.NewUserServiceClient(common.ServiceGrpcNamespace_+common.ServiceUser, g.cl)
+}
+
+func (g *grpcStore) SearchUsers(ctx context.Context, query *idm.UserSingleQuery) (users []*idm.User, e error) {
+	q, _ := ptypes.MarshalAny(query)
+	stream, e := g.users().SearchUser(ctx, &idm.SearchUserRequest{Query: &service.Query{SubQueries: []*any.Any{q}}})
+	if e != nil {
+		return nil, e
+	}
+	defer stream.Close()
+	for {
+		resp, er := stream.Recv()
+		if er == io.EOF {
+			break
+		} else if er != nil {
+			return nil, er
+		}
+		if resp != nil && resp.User != nil {
+			users = append(users, resp.User)
+		}
+	}
+	return users, nil
+}
+
+func (g *grpcStore) PutUser(ctx context.Context, user *idm.User) (*idm.User, error) {
+	resp, e := g.users().CreateUser(ctx, &idm.CreateUserRequest{User: user})
+	if e != nil {
+		return nil, e
+	}
+	return resp.User, nil
+}
+
+func (g *grpcStore) DeleteUsers(ctx context.Context, query *idm.UserSingleQuery) (int64, error) {
+	q, _ := ptypes.MarshalAny(query)
+	resp, e := g.users().DeleteUser(ctx, &idm.DeleteUserRequest{Query: &service.Query{SubQueries: []*any.Any{q}}})
+	if e != nil {
+		return 0, e
+	}
+	return resp.RowsDeleted, nil
+}
+
+func (g *grpcStore) CreateRole(ctx context.Context, role *idm.Role) error {
+	_, e := idm.NewRoleServiceClient(common.ServiceGrpcNamespace_+common.ServiceRole, g.cl).CreateRole(ctx, &idm.CreateRoleRequest{Role: role})
+	return e
+}
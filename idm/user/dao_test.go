@@ -14,6 +14,8 @@ import (
 	"github.com/pydio/cells/common/proto/idm"
 	service "github.com/pydio/cells/common/service/proto"
 	"github.com/pydio/cells/common/sql"
+	"github.com/pydio/cells/common/utils/mtree"
+	"github.com/pydio/cells/common/utils/permissions"
 	"github.com/pydio/cells/x/configx"
 
 	. "github.com/smartystreets/goconvey/convey"
@@ -288,6 +290,7 @@ func TestQueryBuilder(t *testing.T) {
 			So(group.GroupLabel, ShouldEqual, "group")
 			So(group.GroupPath, ShouldEqual, "/path/to/")
 			So(group.IsGroup, ShouldBeTrue)
+			So(permissions.DelegationParentPath(group), ShouldEqual, "/path/to")
 
 		}
 
@@ -614,3 +617,16 @@ func TestQueryBuilder(t *testing.T) {
 		//So(s, ShouldEqual, "((t.uuid = n.uuid and (n.name='user1' and n.leaf = 1)) OR (t.uuid = n.uuid and (n.name='user2' and n.leaf = 1))) AND (t.uuid = n.uuid and (n.name='user3' and n.leaf = 1))")
 	})
 }
+
+func TestGroupDelegationPath(t *testing.T) {
+	Convey("Test parent path of a group loaded by Search", t, func() {
+		// Search rebuilds the group path before setting the node name, then converts the node
+		node := mtree.NewTreeNode()
+		node.Path = "/path/to/"
+		node.SetMeta("name", "group")
+		group := nodeToGroup(node)
+		So(group.GroupPath, ShouldEqual, "/path/to/")
+		So(group.GroupLabel, ShouldEqual, "group")
+		So(permissions.DelegationParentPath(group), ShouldEqual, "/path/to")
+	})
+}
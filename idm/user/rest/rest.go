@@ -24,6 +24,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"strconv"
 	"strings"
 	"time"
 
@@ -36,6 +37,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/auth/claim"
 	"github.com/pydio/cells/common/config"
 	"github.com/pydio/cells/common/log"
 	defaults "github.com/pydio/cells/common/micro"
@@ -49,6 +51,7 @@ import (
 	service2 "github.com/pydio/cells/common/service/proto"
 	"github.com/pydio/cells/common/service/resources"
 	"github.com/pydio/cells/common/utils/permissions"
+	"github.com/pydio/cells/common/views"
 	"github.com/pydio/cells/idm/user/grpc"
 )
 
@@ -127,6 +130,9 @@ func (s *UserHandler) GetUser(req *restful.Request, rsp *restful.Response) {
 	}
 
 	if result != nil {
+		if result.PoliciesContextEditable {
+			s.appendQuotaAttributes(ctx, result)
+		}
 		rsp.WriteEntity(result)
 	} else {
 		service.RestError404(req, rsp, errors.NotFound("user.notfound", "cannot find user with login %s", login))
@@ -134,6 +140,33 @@ func (s *UserHandler) GetUser(req *restful.Request, rsp *restful.Response) {
 
 }
 
+// appendQuotaAttributes adds the storage quotas of the user and their current usage to its attributes.
+// For group quotas, only the most used one is exposed.
+func (s *UserHandler) appendQuotaAttributes(ctx context.Context, u *idm.User) {
+	q, e := views.UserQuotaFor(ctx, u.Login, u.GroupPath, u.Roles)
+	if e != nil {
+		log.Logger(ctx).Error("Cannot compute quota for user", zap.String("login", u.Login), zap.Error(e))
+		return
+	}
+	if u.Attributes == nil {
+		u.Attributes = make(map[string]string)
+	}
+	if q.Quota > 0 {
+		u.Attributes[idm.UserAttrQuota] = strconv.FormatInt(q.Quota, 10)
+		u.Attributes[idm.UserAttrQuotaUsage] = strconv.FormatInt(q.Usage, 10)
+	}
+	var group *views.GroupQuota
+	for _, g := range q.Groups {
+		if group == nil || g.Usage*group.Quota > group.Usage*g.Quota {
+			group = g
+		}
+	}
+	if group != nil {
+		u.Attributes[idm.UserAttrGroupQuota] = strconv.FormatInt(group.Quota, 10)
+		u.Attributes[idm.UserAttrGroupQuotaUsage] = strconv.FormatInt(group.Usage, 10)
+	}
+}
+
 // SearchUsers performs a paginated query to the user repository.
 // Warning: in the returned result, users and groups are stored in two distinct arrays.
 func (s *UserHandler) SearchUsers(req *restful.Request, rsp *restful.Response) {
@@ -271,7 +304,7 @@ func (s *UserHandler) DeleteUser(req *restful.Request, rsp *restful.Response) {
 		if response == nil {
 			continue
 		}
-		if !s.MatchPolicies(ctx, response.User.Uuid, response.User.Policies, service2.ResourcePolicyAction_WRITE) {
+		if !s.MatchPolicies(ctx, response.User.Uuid, response.User.Policies, service2.ResourcePolicyAction_WRITE) && !s.delegatedAdmin(ctx, claims, response.User) {
 			log.Auditer(ctx).Error(
 				fmt.Sprintf("Forbidden action: could not delete user [%s]", response.User.Login),
 				log.GetAuditId(common.AuditUserDelete),
@@ -285,6 +318,11 @@ func (s *UserHandler) DeleteUser(req *restful.Request, rsp *restful.Response) {
 
 	if singleQ.GroupPath != "" {
 
+		// Non-admins must administrate the parent of the deleted group
+		if claims.Profile != common.PydioProfileAdmin && !permissions.DelegatedAdminAllowed(ctx, permissions.DelegationParentPath(&idm.User{IsGroup: true, GroupPath: login})) {
+			service.RestError403(req, rsp, errors.Forbidden(common.ServiceUser, "You are not allowed to delete this group"))
+			return
+		}
 		uName, _ := permissions.FindUserNameInContext(ctx)
 		// This is a group deletion - send it in background
 		jobUuid := uuid.New()
@@ -361,8 +399,9 @@ func (s *UserHandler) PutUser(req *restful.Request, rsp *restful.Response) {
 	var existingAcls []*idm.ACL
 	ctxLogin, ctxClaims := permissions.FindUserNameInContext(ctx)
 	if update != nil {
-		// Check User Policies
-		if !s.MatchPolicies(ctx, update.Uuid, update.Policies, service2.ResourcePolicyAction_WRITE) {
+		// Check User Policies, or delegated administration of both the current and the target groups
+		if !s.MatchPolicies(ctx, update.Uuid, update.Policies, service2.ResourcePolicyAction_WRITE) &&
+			(!s.delegatedAdmin(ctx, ctxClaims, update) || !permissions.DelegatedAdminAllowed(ctx, inputUser.GroupPath)) {
 			log.Auditer(ctx).Error(
 				fmt.Sprintf("Forbidden action: could not edit user [%s]", update.GetLogin()),
 				log.GetAuditId(common.AuditUserUpdate),
@@ -417,6 +456,13 @@ func (s *UserHandler) PutUser(req *restful.Request, rsp *restful.Response) {
 		service.RestError403(req, rsp, fmt.Errorf("you are not allowed to create users"))
 		return
 	}
+	// Users created by non-admins go to the root group, to the creator own group, or to a group it administrates
+	if update == nil && !inputUser.IsGroup && ctxClaims.Profile != common.PydioProfileAdmin {
+		if gp := strings.TrimSuffix(inputUser.GroupPath, "/"); gp != "" && gp != strings.TrimSuffix(ctxClaims.GroupPath, "/") && !permissions.DelegatedAdminAllowed(ctx, gp) {
+			service.RestError403(req, rsp, fmt.Errorf("you are not allowed to create users in this group"))
+			return
+		}
+	}
 	if update == nil && ctxClaims.Profile == common.PydioProfileShared {
 		// Recheck that crtUser is not hidden
 		crtUser, e := permissions.SearchUniqueUser(ctx, ctxLogin, "")
@@ -431,7 +477,7 @@ func (s *UserHandler) PutUser(req *restful.Request, rsp *restful.Response) {
 	}
 
 	if inputUser.IsGroup {
-		if ctxClaims.Profile != common.PydioProfileAdmin {
+		if ctxClaims.Profile != common.PydioProfileAdmin && !permissions.DelegatedAdminAllowed(ctx, inputUser.GroupPath) {
 			service.RestError403(req, rsp, fmt.Errorf("you are not allowed to create groups"))
 			return
 		}
@@ -455,6 +501,8 @@ func (s *UserHandler) PutUser(req *restful.Request, rsp *restful.Response) {
 	var acls []*idm.ACL
 	var deleteAclActions []string
 	var sendEmail bool
+	var rolesValidity string
+	var hasRolesValidity bool
 	cleanAttributes := map[string]string{}
 	for k, v := range inputUser.Attributes {
 		if k == "send_email" {
@@ -477,9 +525,26 @@ func (s *UserHandler) PutUser(req *restful.Request, rsp *restful.Response) {
 			acls = append(acls, acl)
 			continue
 		}
+		if k == idm.UserAttrRolesValidityPublic {
+			rolesValidity, hasRolesValidity = v, true
+			continue
+		}
+		if k == idm.UserAttrDeletionDatePublic {
+			// Read-only, managed by the self-service deletion endpoints
+			continue
+		}
 		cleanAttributes[k] = v
 	}
 	inputUser.Attributes = cleanAttributes
+	// Only admins can set the validity windows of role memberships, others keep the stored value
+	if hasRolesValidity && ctxClaims.Profile == common.PydioProfileAdmin {
+		validity, e := idm.ParseRolesValidity(rolesValidity)
+		if e != nil {
+			service.RestError400(req, rsp, fmt.Errorf("invalid value for %s: %v", idm.UserAttrRolesValidityPublic, e))
+			return
+		}
+		inputUser.SetRolesValidity(validity)
+	}
 
 	response, er := cli.CreateUser(ctx, &idm.CreateUserRequest{
 		User: &inputUser,
@@ -750,7 +815,7 @@ func (s *UserHandler) checkCanAssignRoles(ctx context.Context, roles []*idm.Role
 		if rsp == nil {
 			continue
 		}
-		if !s.MatchPolicies(ctx, rsp.Role.Uuid, rsp.Role.Policies, service2.ResourcePolicyAction_WRITE) {
+		if !s.MatchPolicies(ctx, rsp.Role.Uuid, rsp.Role.Policies, service2.ResourcePolicyAction_WRITE) && !permissions.DelegatedRoleAllowed(ctx, rsp.Role.Uuid) {
 			log.Logger(ctx).Error("trying to assign a role that is not writeable in the context", zap.Any("r", rsp.Role))
 			return errors.Forbidden(common.ServiceUser, "You are not allowed to assign this role "+rsp.Role.Uuid)
 		}
@@ -758,6 +823,18 @@ func (s *UserHandler) checkCanAssignRoles(ctx context.Context, roles []*idm.Role
 	return nil
 }
 
+// delegatedAdmin checks if the context user administrates the target user or group on behalf of the
+// administrators. Users having a higher profile than the context user are never delegated.
+func (s *UserHandler) delegatedAdmin(ctx context.Context, ctxClaims claim.Claims, target *idm.User) bool {
+	if ctxClaims.Profile == common.PydioProfileAdmin {
+		return false
+	}
+	if !target.IsGroup && profilesLevel[target.GetAttributes()[idm.UserAttrProfile]] > profilesLevel[ctxClaims.Profile] {
+		return false
+	}
+	return permissions.DelegatedAdminAllowed(ctx, permissions.DelegationParentPath(target))
+}
+
 // Diff two slices of roles.
 func (s *UserHandler) diffRoles(as []*idm.Role, bs []*idm.Role) (diff []*idm.Role) {
 
@@ -0,0 +1,324 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package rest
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/emicklei/go-restful"
+	"github.com/micro/go-micro/errors"
+	"github.com/pborman/uuid"
+	"go.uber.org/zap"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/config"
+	"github.com/pydio/cells/common/log"
+	defaults "github.com/pydio/cells/common/micro"
+	"github.com/pydio/cells/common/proto/idm"
+	"github.com/pydio/cells/common/proto/jobs"
+	"github.com/pydio/cells/common/proto/mailer"
+	"github.com/pydio/cells/common/proto/rest"
+	"github.com/pydio/cells/common/registry"
+	"github.com/pydio/cells/common/service"
+	"github.com/pydio/cells/common/utils/i18n"
+	"github.com/pydio/cells/common/utils/permissions"
+	"github.com/pydio/cells/scheduler/lang"
+)
+
+const (
+	// defaultDeletionGraceDays is the number of days between the confirmation of an account deletion and the actual deletion
+	defaultDeletionGraceDays = 30
+	// deletionTokenValidity is the time given to the user to confirm a deletion request
+	deletionTokenValidity = 24 * time.Hour
+	// dataExportsFolder is the folder created in the personal files to store the exports archives
+	dataExportsFolder = "data-exports"
+	// personalFilesVirtualNode is the virtual node used as root of the personal workspace
+	personalFilesVirtualNode = "my-files"
+)
+
+// UserRequestDataExport starts a job gathering the current user personal files, profile, activities,
+// share links and chat messages in an archive stored in its personal folder. A link is mailed once done.
+func (s *UserHandler) UserRequestDataExport(req *restful.Request, rsp *restful.Response) {
+
+	ctx := req.Request.Context()
+	login, _ := permissions.FindUserNameInContext(ctx)
+	if login == "" || login == common.PydioS3AnonUsername {
+		service.RestError401(req, rsp, errors.Unauthorized(common.ServiceUser, "please log in to export your data"))
+		return
+	}
+	personal, e := personalWorkspaceSlug(ctx)
+	if e != nil {
+		service.RestErrorDetect(req, rsp, e)
+		return
+	}
+	name := "export-" + time.Now().Format("20060102-150405")
+	folder := path.Join(personal, dataExportsFolder, name)
+	T := lang.Bundle().GetTranslationFunc(i18n.UserLanguagesFromRestRequest(req, config.Get())...)
+	jobUuid := "data-export-" + uuid.New()
+	job := &jobs.Job{
+		ID:             jobUuid,
+		Owner:          login,
+		Label:          T("Jobs.User.DataExport"),
+		MaxConcurrency: 1,
+		AutoStart:      true,
+		AutoClean:      true,
+		Actions: []*jobs.Action{
+			{
+				ID: "actions.idm.data-export",
+				Parameters: map[string]string{
+					"personalFolder": personal,
+					"target":         folder,
+				},
+				ChainedActions: []*jobs.Action{
+					{
+						ID: "actions.archive.compress",
+						Parameters: map[string]string{
+							"scope":  "owner",
+							"format": "zip",
+							"target": folder + ".zip",
+						},
+						ChainedActions: []*jobs.Action{
+							{
+								ID:         "actions.idm.data-export.notify",
+								Parameters: map[string]string{"cleanFolder": folder},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	cli := jobs.NewJobServiceClient(registry.GetClient(common.ServiceJobs))
+	if _, e := cli.PutJob(ctx, &jobs.PutJobRequest{Job: job}); e != nil {
+		service.RestError500(req, rsp, e)
+		return
+	}
+	log.Auditer(ctx).Info(fmt.Sprintf("User [%s] requested an export of their data", login))
+	rsp.WriteEntity(&rest.UserSelfServiceResponse{Success: true, JobUuid: jobUuid})
+
+}
+
+// UserRequestDeletion mails a confirmation code to the current user, required to confirm the deletion of its account.
+func (s *UserHandler) UserRequestDeletion(req *restful.Request, rsp *restful.Response) {
+
+	ctx := req.Request.Context()
+	cli := idm.NewUserServiceClient(common.ServiceGrpcNamespace_+common.ServiceUser, defaults.NewClient())
+	u, e := s.selfUser(ctx, cli)
+	if e != nil {
+		service.RestErrorDetect(req, rsp, e)
+		return
+	}
+	email := u.Attributes[idm.UserAttrEmail]
+	if email == "" {
+		service.RestErrorDetect(req, rsp, errors.BadRequest(common.ServiceUser, "an email address is required to confirm the deletion, please contact your administrator"))
+		return
+	}
+	token, e := newDeletionToken()
+	if e != nil {
+		service.RestError500(req, rsp, e)
+		return
+	}
+	u.Attributes[idm.UserAttrDeletionToken] = hashDeletionToken(token, time.Now().Add(deletionTokenValidity))
+	if _, e := cli.CreateUser(ctx, &idm.CreateUserRequest{User: u}); e != nil {
+		service.RestError500(req, rsp, e)
+		return
+	}
+	name := u.Attributes[idm.UserAttrDisplayName]
+	if name == "" {
+		name = u.Login
+	}
+	mailCli := mailer.NewMailerServiceClient(registry.GetClient(common.ServiceMailer))
+	if _, e := mailCli.SendMail(ctx, &mailer.SendMailRequest{
+		InQueue: false,
+		Mail: &mailer.Mail{
+			To:         []*mailer.User{{Name: name, Address: email}},
+			TemplateId: "AccountDeletion",
+			TemplateData: map[string]string{
+				"Token": token,
+				"Days":  strconv.Itoa(deletionGraceDays()),
+			},
+		},
+	}); e != nil {
+		service.RestError500(req, rsp, e)
+		return
+	}
+	rsp.WriteEntity(&rest.UserSelfServiceResponse{Success: true, Message: "A confirmation code was sent to your email address"})
+
+}
+
+// UserConfirmDeletion checks the confirmation code and schedules the deletion of the current user
+// account at the end of the grace period.
+func (s *UserHandler) UserConfirmDeletion(req *restful.Request, rsp *restful.Response) {
+
+	var input rest.UserConfirmDeletionRequest
+	if e := req.ReadEntity(&input); e != nil {
+		service.RestError500(req, rsp, e)
+		return
+	}
+	ctx := req.Request.Context()
+	cli := idm.NewUserServiceClient(common.ServiceGrpcNamespace_+common.ServiceUser, defaults.NewClient())
+	u, e := s.selfUser(ctx, cli)
+	if e != nil {
+		service.RestErrorDetect(req, rsp, e)
+		return
+	}
+	now := time.Now()
+	if !checkDeletionToken(u.Attributes[idm.UserAttrDeletionToken], input.Token, now) {
+		service.RestErrorDetect(req, rsp, errors.BadRequest(common.ServiceUser, "invalid or expired confirmation code"))
+		return
+	}
+	deletionDate := now.Add(time.Duration(deletionGraceDays()) * 24 * time.Hour)
+	delete(u.Attributes, idm.UserAttrDeletionToken)
+	u.Attributes[idm.UserAttrDeletionDate] = strconv.FormatInt(deletionDate.Unix(), 10)
+	if _, e := cli.CreateUser(ctx, &idm.CreateUserRequest{User: u}); e != nil {
+		service.RestError500(req, rsp, e)
+		return
+	}
+	log.Auditer(ctx).Info(
+		fmt.Sprintf("User [%s] confirmed the deletion of their account, scheduled on %s", u.Login, deletionDate.Format(time.RFC3339)),
+		u.ZapUuid(),
+	)
+	if email := u.Attributes[idm.UserAttrEmail]; email != "" {
+		mailCli := mailer.NewMailerServiceClient(registry.GetClient(common.ServiceMailer))
+		if _, e := mailCli.SendMail(ctx, &mailer.SendMailRequest{
+			InQueue: true,
+			Mail: &mailer.Mail{
+				To:           []*mailer.User{{Name: u.Attributes[idm.UserAttrDisplayName], Address: email}},
+				TemplateId:   "AccountDeletionScheduled",
+				TemplateData: map[string]string{"Date": deletionDate.Format("2006-01-02")},
+			},
+		}); e != nil {
+			log.Logger(ctx).Error("Cannot send deletion notification", u.ZapLogin(), zap.Error(e))
+		}
+	}
+	rsp.WriteEntity(&rest.UserSelfServiceResponse{Success: true, DeletionDate: deletionDate.Unix()})
+
+}
+
+// UserCancelDeletion removes any pending deletion request for the current user.
+func (s *UserHandler) UserCancelDeletion(req *restful.Request, rsp *restful.Response) {
+
+	ctx := req.Request.Context()
+	cli := idm.NewUserServiceClient(common.ServiceGrpcNamespace_+common.ServiceUser, defaults.NewClient())
+	u, e := s.selfUser(ctx, cli)
+	if e != nil {
+		service.RestErrorDetect(req, rsp, e)
+		return
+	}
+	_, hasToken := u.Attributes[idm.UserAttrDeletionToken]
+	_, hasDate := u.Attributes[idm.UserAttrDeletionDate]
+	if !hasToken && !hasDate {
+		rsp.WriteEntity(&rest.UserSelfServiceResponse{Success: true, Message: "No pending deletion"})
+		return
+	}
+	delete(u.Attributes, idm.UserAttrDeletionToken)
+	delete(u.Attributes, idm.UserAttrDeletionDate)
+	if _, e := cli.CreateUser(ctx, &idm.CreateUserRequest{User: u}); e != nil {
+		service.RestError500(req, rsp, e)
+		return
+	}
+	log.Auditer(ctx).Info(fmt.Sprintf("User [%s] cancelled the deletion of their account", u.Login), u.ZapUuid())
+	rsp.WriteEntity(&rest.UserSelfServiceResponse{Success: true})
+
+}
+
+// selfUser loads the user found in context. Administrators and technical users cannot delete their own account.
+func (s *UserHandler) selfUser(ctx context.Context, cli idm.UserServiceClient) (*idm.User, error) {
+	_, claims := permissions.FindUserNameInContext(ctx)
+	if claims.Subject == "" {
+		return nil, errors.Unauthorized(common.ServiceUser, "please log in to manage your account")
+	}
+	if claims.Profile == common.PydioProfileAdmin {
+		return nil, errors.Forbidden(common.ServiceUser, "administrators accounts must be deleted by another administrator")
+	}
+	u, ok := s.userById(ctx, claims.Subject, cli)
+	if !ok {
+		return nil, errors.NotFound(common.ServiceUser, "cannot find current user")
+	}
+	if u.IsHidden() {
+		return nil, errors.Forbidden(common.ServiceUser, "this account cannot be deleted")
+	}
+	if u.Attributes == nil {
+		u.Attributes = make(map[string]string)
+	}
+	return u, nil
+}
+
+// personalWorkspaceSlug finds the slug of the workspace pointing to the personal files of the context user.
+func personalWorkspaceSlug(ctx context.Context) (string, error) {
+	accessList, e := permissions.AccessListFromContextClaims(ctx)
+	if e != nil {
+		return "", e
+	}
+	for _, ws := range accessList.Workspaces {
+		for _, r := range ws.RootUUIDs {
+			if r == personalFilesVirtualNode {
+				return ws.Slug, nil
+			}
+		}
+	}
+	return "", errors.BadRequest(common.ServiceUser, "cannot find your personal files to store the export")
+}
+
+func deletionGraceDays() int {
+	return config.Get("services", common.ServiceRestNamespace_+common.ServiceUser, "selfDeletionGraceDays").Default(defaultDeletionGraceDays).Int()
+}
+
+// newDeletionToken generates a short random code, easy to type from the email.
+func newDeletionToken() (string, error) {
+	const alphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+	b := make([]byte, 8)
+	if _, e := rand.Read(b); e != nil {
+		return "", e
+	}
+	for i := range b {
+		b[i] = alphabet[int(b[i])%len(alphabet)]
+	}
+	return string(b), nil
+}
+
+// hashDeletionToken produces the value stored on the user: the hashed token and its expiration date.
+func hashDeletionToken(token string, expire time.Time) string {
+	h := sha256.Sum256([]byte(strings.ToUpper(strings.TrimSpace(token))))
+	return hex.EncodeToString(h[:]) + ":" + strconv.FormatInt(expire.Unix(), 10)
+}
+
+// checkDeletionToken compares a token to the value stored on the user.
+func checkDeletionToken(stored, token string, now time.Time) bool {
+	parts := strings.Split(stored, ":")
+	if len(parts) != 2 || token == "" {
+		return false
+	}
+	expire, e := strconv.ParseInt(parts[1], 10, 64)
+	if e != nil || now.Unix() > expire {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(hashDeletionToken(token, time.Unix(expire, 0))), []byte(stored)) == 1
+}
@@ -0,0 +1,29 @@
+package rest
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestDeletionToken(t *testing.T) {
+	Convey("Test deletion confirmation codes", t, func() {
+		token, e := newDeletionToken()
+		So(e, ShouldBeNil)
+		So(token, ShouldHaveLength, 8)
+		other, _ := newDeletionToken()
+		So(other, ShouldNotEqual, token)
+
+		now := time.Now()
+		stored := hashDeletionToken(token, now.Add(deletionTokenValidity))
+		So(stored, ShouldNotContainSubstring, token)
+		So(checkDeletionToken(stored, token, now), ShouldBeTrue)
+		So(checkDeletionToken(stored, " "+token+" ", now), ShouldBeTrue)
+		So(checkDeletionToken(stored, other, now), ShouldBeFalse)
+		So(checkDeletionToken(stored, "", now), ShouldBeFalse)
+		So(checkDeletionToken(stored, token, now.Add(2*deletionTokenValidity)), ShouldBeFalse)
+		So(checkDeletionToken("", token, now), ShouldBeFalse)
+		So(checkDeletionToken("invalid", token, now), ShouldBeFalse)
+	})
+}
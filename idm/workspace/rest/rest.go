@@ -106,26 +106,11 @@ func (h *WorkspaceHandler) PutWorkspace(req *restful.Request, rsp *restful.Respo
 		h.deduplicateSlug(ctx, &inputWorkspace, cli)
 	}
 
-	defaultRights, quotaValue := h.extractDefaultRights(ctx, &inputWorkspace)
-
-	response, er := cli.CreateWorkspace(req.Request.Context(), &idm.CreateWorkspaceRequest{
-		Workspace: &inputWorkspace,
-	})
+	u, er := h.StoreWorkspace(ctx, &inputWorkspace, update)
 	if er != nil {
 		service2.RestError500(req, rsp, er)
 		return
 	}
-	if e := h.storeRootNodesAsACLs(ctx, &inputWorkspace, update); e != nil {
-		service2.RestError500(req, rsp, e)
-		return
-	}
-	if e := h.manageDefaultRights(ctx, &inputWorkspace, false, defaultRights, quotaValue); e != nil {
-		service2.RestError500(req, rsp, e)
-		return
-	}
-
-	u := response.Workspace
-	h.manageDefaultRights(ctx, u, true, "", "")
 	rsp.WriteEntity(u)
 	if update {
 		log.Auditer(ctx).Info(
@@ -143,6 +128,31 @@ func (h *WorkspaceHandler) PutWorkspace(req *restful.Request, rsp *restful.Respo
 	}
 }
 
+// StoreWorkspace creates or updates a workspace along with its root nodes and default rights, and returns
+// the stored workspace. Slug and policies must be prepared and permissions checked by the caller.
+func (h *WorkspaceHandler) StoreWorkspace(ctx context.Context, workspace *idm.Workspace, update bool) (*idm.Workspace, error) {
+
+	cli := idm.NewWorkspaceServiceClient(common.ServiceGrpcNamespace_+common.ServiceWorkspace, defaults.NewClient())
+	defaultRights, quotaValue := h.extractDefaultRights(ctx, workspace)
+
+	response, er := cli.CreateWorkspace(ctx, &idm.CreateWorkspaceRequest{
+		Workspace: workspace,
+	})
+	if er != nil {
+		return nil, er
+	}
+	if e := h.storeRootNodesAsACLs(ctx, workspace, update); e != nil {
+		return nil, e
+	}
+	if e := h.manageDefaultRights(ctx, workspace, false, defaultRights, quotaValue); e != nil {
+		return nil, e
+	}
+
+	u := response.Workspace
+	h.manageDefaultRights(ctx, u, true, "", "")
+	return u, nil
+}
+
 func (h *WorkspaceHandler) DeleteWorkspace(req *restful.Request, rsp *restful.Response) {
 
 	slug := req.PathParameter("Slug")
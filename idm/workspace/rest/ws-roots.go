@@ -19,6 +19,21 @@ import (
 	"github.com/pydio/cells/common/views"
 )
 
+// LoadWorkspacesDetails fills the root nodes and the default rights of the workspaces, indexed by their UUID.
+func (h *WorkspaceHandler) LoadWorkspacesDetails(ctx context.Context, wss map[string]*idm.Workspace) error {
+	var uuids []string
+	for id := range wss {
+		uuids = append(uuids, id)
+	}
+	if len(uuids) == 0 {
+		return nil
+	}
+	if e := h.loadRootNodesForWorkspaces(ctx, uuids, wss); e != nil {
+		return e
+	}
+	return h.bulkReadDefaultRights(ctx, uuids, wss)
+}
+
 func (h *WorkspaceHandler) loadRootNodesForWorkspaces(ctx context.Context, wsUUIDs []string, wss map[string]*idm.Workspace) error {
 
 	acls, err := permissions.GetACLsForWorkspace(ctx, wsUUIDs, &idm.ACLAction{Name: permissions.AclWsrootActionName})
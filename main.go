@@ -68,6 +68,7 @@ import (
 	_ "github.com/pydio/cells/gateway/grpc"
 	_ "github.com/pydio/cells/gateway/micro"
 	_ "github.com/pydio/cells/gateway/proxy"
+	_ "github.com/pydio/cells/gateway/tus"
 	_ "github.com/pydio/cells/gateway/websocket/api"
 	_ "github.com/pydio/cells/gateway/wopi"
 
@@ -85,8 +86,10 @@ import (
 	_ "github.com/pydio/cells/idm/oauth/web"
 	_ "github.com/pydio/cells/idm/policy/grpc"
 	_ "github.com/pydio/cells/idm/policy/rest"
+	_ "github.com/pydio/cells/idm/review/rest"
 	_ "github.com/pydio/cells/idm/role/grpc"
 	_ "github.com/pydio/cells/idm/role/rest"
+	_ "github.com/pydio/cells/idm/scim"
 	_ "github.com/pydio/cells/idm/share/rest"
 	_ "github.com/pydio/cells/idm/user/grpc"
 	_ "github.com/pydio/cells/idm/user/rest"
@@ -99,6 +102,7 @@ import (
 
 	// All Actions for scheduler
 	_ "github.com/pydio/cells/broker/activity/actions"
+	_ "github.com/pydio/cells/scheduler/actions/antivirus"
 	_ "github.com/pydio/cells/scheduler/actions/archive"
 	_ "github.com/pydio/cells/scheduler/actions/changes"
 	_ "github.com/pydio/cells/scheduler/actions/cmd"
@@ -110,6 +114,7 @@ import (
 	// ETL Actions and stores
 	_ "github.com/pydio/cells/common/etl/actions"
 	_ "github.com/pydio/cells/common/etl/stores/cells/local"
+	_ "github.com/pydio/cells/common/etl/stores/file"
 	_ "github.com/pydio/cells/common/etl/stores/pydio8"
 
 	"github.com/pydio/cells/common"
@@ -0,0 +1,48 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+// Package antivirus provides a scheduler action scanning files with a clamd daemon.
+package antivirus
+
+import (
+	"github.com/pydio/cells/common/views"
+	"github.com/pydio/cells/scheduler/actions"
+)
+
+var (
+	router *views.Router
+)
+
+// init auto registers the antivirus scan action.
+func init() {
+
+	actions.GetActionsManager().Register(scanActionName, func() actions.ConcreteAction {
+		return &ScanAction{}
+	})
+
+}
+
+// getRouter provides a singleton-initialized StandardRouter in AdminView.
+func getRouter() *views.Router {
+	if router == nil {
+		router = views.NewStandardRouter(views.RouterOptions{AdminView: true, WatchRegistry: true})
+	}
+	return router
+}
@@ -0,0 +1,161 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package antivirus
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/micro/go-micro/client"
+	"go.uber.org/zap"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/forms"
+	"github.com/pydio/cells/common/log"
+	"github.com/pydio/cells/common/proto/jobs"
+	"github.com/pydio/cells/common/proto/tree"
+	"github.com/pydio/cells/common/utils/clamd"
+	context2 "github.com/pydio/cells/common/utils/context"
+	"github.com/pydio/cells/common/utils/permissions"
+	"github.com/pydio/cells/common/views"
+	"github.com/pydio/cells/common/views/models"
+	"github.com/pydio/cells/scheduler/actions"
+)
+
+var (
+	scanActionName = "actions.antivirus.scan"
+)
+
+// ScanAction sends a file to clamd. Infected files are moved to the quarantine folder
+// of their datasource, clean files are tagged with the scan result.
+type ScanAction struct {
+	Router     views.Handler
+	metaClient tree.NodeReceiverClient
+	config     *views.AntivirusConfig
+}
+
+func (s *ScanAction) GetDescription(lang ...string) actions.ActionDescription {
+	return actions.ActionDescription{
+		ID:                scanActionName,
+		Label:             "Antivirus Scan",
+		Icon:              "shield-bug",
+		Description:       "Scan file contents with ClamAV and move infected files to quarantine. Requires the Antivirus plugin to be enabled.",
+		SummaryTemplate:   "",
+		HasForm:           false,
+		Category:          actions.ActionCategoryContents,
+		InputDescription:  "Single-selection of file. Temporary, zero-bytes and already quarantined files will be ignored",
+		OutputDescription: "Input file with scan result metadata, or its new location in quarantine",
+	}
+}
+
+func (s *ScanAction) GetParametersForm() *forms.Form {
+	return nil
+}
+
+// GetName returns this action unique identifier
+func (s *ScanAction) GetName() string {
+	return scanActionName
+}
+
+// Init passes parameters to the action
+func (s *ScanAction) Init(job *jobs.Job, cl client.Client, action *jobs.Action) error {
+	s.metaClient = tree.NewNodeReceiverClient(common.ServiceGrpcNamespace_+common.ServiceMeta, cl)
+	return nil
+}
+
+// Run the actual action code
+func (s *ScanAction) Run(ctx context.Context, channels *actions.RunnableChannels, input jobs.ActionMessage) (jobs.ActionMessage, error) {
+
+	if len(input.Nodes) == 0 || input.Nodes[0].Size <= 0 || input.Nodes[0].Etag == common.NodeFlagEtagTemporary {
+		return input.WithIgnore(), nil
+	}
+	conf := s.config
+	if conf == nil {
+		conf = views.LoadAntivirusConfig()
+	}
+	node := input.Nodes[0]
+	if conf == nil || conf.Skip(node.Size) || conf.IsQuarantined(node.GetPath()) {
+		return input.WithIgnore(), nil
+	}
+	router := s.Router
+	if router == nil {
+		router = getRouter()
+	}
+
+	reader, e := router.GetObject(ctx, proto.Clone(node).(*tree.Node), &models.GetRequestData{Length: -1})
+	if e != nil {
+		return input.WithError(e), e
+	}
+	result, e := conf.Scan(ctx, reader)
+	io.Copy(ioutil.Discard, reader)
+	reader.Close()
+	if e != nil {
+		return input.WithError(e), e
+	}
+	if result.Status == clamd.StatusError {
+		e = fmt.Errorf("clamd could not scan %s: %s", node.GetPath(), result.Raw)
+		return input.WithError(e), e
+	}
+
+	output := input
+	if result.Infected() {
+		uploader := uploaderLogin(ctx, node)
+		sysCtx := context2.WithUserNameMetadata(ctx, common.PydioSystemUsername)
+		quarantined, er := views.QuarantineNode(sysCtx, router, node, result, uploader, conf)
+		if er != nil {
+			return input.WithError(er), er
+		}
+		log.TasksLogger(ctx).Warn("Infected file "+node.GetPath()+" moved to quarantine ("+result.Signature+")", node.ZapPath())
+		output.Nodes[0] = quarantined
+	} else {
+		node.SetMeta(common.MetaNamespaceAntivirus, &views.AntivirusReport{
+			Status:   result.Status,
+			ScanDate: time.Now().Unix(),
+		})
+		if _, er := s.metaClient.UpdateNode(ctx, &tree.UpdateNodeRequest{From: node, To: node}); er != nil {
+			log.Logger(ctx).Error("Cannot store antivirus scan result", node.ZapPath(), zap.Error(er))
+		}
+		log.TasksLogger(ctx).Info("No virus found in "+node.GetPath(), node.ZapPath())
+		output.Nodes[0] = node
+	}
+	output.AppendOutput(&jobs.ActionOutput{
+		Success:    true,
+		StringBody: result.Raw,
+	})
+
+	return output, nil
+}
+
+// uploaderLogin finds the user who uploaded the file, or falls back to the user who triggered the event.
+func uploaderLogin(ctx context.Context, node *tree.Node) string {
+	var owner views.NodeOwner
+	if node.GetMeta(common.MetaNamespaceOwner, &owner) == nil && owner.Login != "" {
+		return owner.Login
+	}
+	if u, _ := permissions.FindUserNameInContext(ctx); u != common.PydioSystemUsername {
+		return u
+	}
+	return ""
+}
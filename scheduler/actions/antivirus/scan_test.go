@@ -0,0 +1,93 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package antivirus
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/proto/jobs"
+	"github.com/pydio/cells/common/proto/tree"
+	"github.com/pydio/cells/common/utils/clamd"
+	"github.com/pydio/cells/common/views"
+	"github.com/pydio/cells/scheduler/actions"
+)
+
+func init() {
+	// Ignore client pool for unit tests
+	views.IsUnitTestEnv = true
+}
+
+func TestScanAction_Run(t *testing.T) {
+
+	Convey("Test antivirus scan action", t, func() {
+
+		srv, e := clamd.NewFakeServer("EICAR", 64)
+		So(e, ShouldBeNil)
+		defer srv.Close()
+
+		mock := views.NewHandlerMock()
+		action := &ScanAction{Router: mock}
+		So(action.Init(&jobs.Job{}, nil, &jobs.Action{}), ShouldBeNil)
+		action.metaClient = mock
+		action.config = &views.AntivirusConfig{Enabled: true, Address: srv.Addr(), QuarantineFolder: "quarantine", MaxSize: 1024}
+		channels := &actions.RunnableChannels{StatusMsg: make(chan string, 10), Progress: make(chan float32, 10)}
+
+		Convey("Ignored nodes", func() {
+			out, e := action.Run(context.Background(), channels, jobs.ActionMessage{Nodes: []*tree.Node{{Path: "ds/empty.txt", Size: 0}}})
+			So(e, ShouldBeNil)
+			So(out.OutputChain, ShouldHaveLength, 1)
+			So(out.OutputChain[0].Ignored, ShouldBeTrue)
+
+			out, e = action.Run(context.Background(), channels, jobs.ActionMessage{Nodes: []*tree.Node{{Path: "ds/quarantine/file.txt", Size: 10}}})
+			So(e, ShouldBeNil)
+			So(out.OutputChain[0].Ignored, ShouldBeTrue)
+
+			out, e = action.Run(context.Background(), channels, jobs.ActionMessage{Nodes: []*tree.Node{{Path: "ds/big.bin", Size: 2048}}})
+			So(e, ShouldBeNil)
+			So(out.OutputChain[0].Ignored, ShouldBeTrue)
+		})
+
+		Convey("Clean file is tagged", func() {
+			node := &tree.Node{Path: "ds/clean.txt", Uuid: "clean-uuid", Size: 20, Type: tree.NodeType_LEAF}
+			mock.Nodes[node.Path] = node
+			out, e := action.Run(context.Background(), channels, jobs.ActionMessage{Nodes: []*tree.Node{node}})
+			So(e, ShouldBeNil)
+			So(out.OutputChain, ShouldHaveLength, 1)
+			So(out.OutputChain[0].Success, ShouldBeTrue)
+			var report views.AntivirusReport
+			So(mock.Nodes["to"].GetMeta(common.MetaNamespaceAntivirus, &report), ShouldBeNil)
+			So(report.Status, ShouldEqual, clamd.StatusClean)
+		})
+
+		Convey("Scan errors are reported", func() {
+			node := &tree.Node{Path: "ds/long-name-to-exceed-the-fake-server-limit-of-sixty-four-bytes.txt", Size: 20, Type: tree.NodeType_LEAF}
+			mock.Nodes[node.Path] = node
+			_, e := action.Run(context.Background(), channels, jobs.ActionMessage{Nodes: []*tree.Node{node}})
+			So(e, ShouldNotBeNil)
+		})
+
+	})
+
+}
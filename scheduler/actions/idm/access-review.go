@@ -0,0 +1,97 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package idm
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/micro/go-micro/client"
+
+	"github.com/pydio/cells/common/forms"
+	"github.com/pydio/cells/common/log"
+	"github.com/pydio/cells/common/proto/jobs"
+	"github.com/pydio/cells/idm/review"
+	"github.com/pydio/cells/scheduler/actions"
+)
+
+var (
+	accessReviewName = "actions.idm.access-review.close"
+)
+
+// AccessReviewAction closes the access review campaigns that reached their deadline and applies their revocations.
+type AccessReviewAction struct {
+	store   review.Store
+	revoker review.Revoker
+}
+
+func (c *AccessReviewAction) GetDescription(lang ...string) actions.ActionDescription {
+	return actions.ActionDescription{
+		ID:              accessReviewName,
+		IsInternal:      true,
+		Label:           "Close access reviews",
+		Icon:            "account-check",
+		Description:     "Close the access review campaigns whose deadline is over and revoke the accesses that were not approved.",
+		Category:        actions.ActionCategoryIDM,
+		SummaryTemplate: "",
+		HasForm:         false,
+	}
+}
+
+func (c *AccessReviewAction) GetParametersForm() *forms.Form {
+	return nil
+}
+
+func (c *AccessReviewAction) GetName() string {
+	return accessReviewName
+}
+
+func (c *AccessReviewAction) Init(job *jobs.Job, cl client.Client, action *jobs.Action) error {
+	if c.store == nil {
+		c.store = review.NewDocStore()
+	}
+	if c.revoker == nil {
+		c.revoker = review.NewGrpcRevoker()
+	}
+	return nil
+}
+
+func (c *AccessReviewAction) Run(ctx context.Context, channels *actions.RunnableChannels, input jobs.ActionMessage) (jobs.ActionMessage, error) {
+
+	closed, e := review.CloseOverdue(ctx, c.store, c.revoker, time.Now())
+	for _, campaign := range closed {
+		var revoked, failed int
+		for _, t := range campaign.Tasks {
+			if t.Applied {
+				revoked++
+			} else if t.ApplyError != "" {
+				failed++
+			}
+		}
+		log.TasksLogger(ctx).Info(fmt.Sprintf("Closed access review %s: %d accesses revoked, %d errors", campaign.Label, revoked, failed))
+		log.Auditer(ctx).Info(fmt.Sprintf("Access review campaign [%s] reached its deadline, %d accesses revoked", campaign.Label, revoked))
+	}
+	if e != nil {
+		return input.WithError(e), e
+	}
+	return input, nil
+}
@@ -0,0 +1,397 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package idm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/micro/go-micro/client"
+	"go.uber.org/zap"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/forms"
+	"github.com/pydio/cells/common/log"
+	defaults "github.com/pydio/cells/common/micro"
+	"github.com/pydio/cells/common/proto/activity"
+	"github.com/pydio/cells/common/proto/chat"
+	"github.com/pydio/cells/common/proto/docstore"
+	"github.com/pydio/cells/common/proto/idm"
+	"github.com/pydio/cells/common/proto/jobs"
+	"github.com/pydio/cells/common/proto/mailer"
+	"github.com/pydio/cells/common/proto/tree"
+	"github.com/pydio/cells/common/registry"
+	"github.com/pydio/cells/common/utils/permissions"
+	"github.com/pydio/cells/common/views"
+	"github.com/pydio/cells/common/views/models"
+	"github.com/pydio/cells/scheduler/actions"
+	"github.com/pydio/cells/scheduler/actions/tools"
+)
+
+var (
+	dataExportName       = "actions.idm.data-export"
+	dataExportNotifyName = "actions.idm.data-export.notify"
+)
+
+// dataExportFiles lists the documents written by the DataExportAction, in their writing order.
+var dataExportFiles = []string{"profile.json", "activities.json", "shares.json", "chats.json"}
+
+// DataExportAction gathers all data attached to the job owner (profile, activity history, share links
+// and chat messages) as JSON documents in a folder of its personal files. It outputs the content of the
+// personal folder along with this new folder, ready to be archived by actions.archive.compress.
+type DataExportAction struct {
+	tools.ScopedRouterConsumer
+	owner          string
+	personalFolder string
+	target         string
+}
+
+func (c *DataExportAction) GetDescription(lang ...string) actions.ActionDescription {
+	return actions.ActionDescription{
+		ID:                dataExportName,
+		IsInternal:        true,
+		Label:             "Export user data",
+		Icon:              "database-export",
+		Description:       "Gather the job owner profile, activities, share links and chat messages as JSON files, and list them with the personal files for archiving.",
+		Category:          actions.ActionCategoryIDM,
+		OutputDescription: "Content of the personal folder and folder containing the exported documents.",
+		SummaryTemplate:   "",
+		HasForm:           true,
+	}
+}
+
+func (c *DataExportAction) GetParametersForm() *forms.Form {
+	return &forms.Form{Groups: []*forms.Group{
+		{
+			Fields: []forms.Field{
+				&forms.FormField{
+					Name:        "personalFolder",
+					Type:        forms.ParamString,
+					Label:       "Personal folder",
+					Description: "Path to the personal folder of the user, as seen by the user",
+					Mandatory:   true,
+					Editable:    true,
+				},
+				&forms.FormField{
+					Name:        "target",
+					Type:        forms.ParamString,
+					Label:       "Target folder",
+					Description: "Folder where the JSON documents are written, as seen by the user",
+					Mandatory:   true,
+					Editable:    true,
+				},
+			},
+		},
+	}}
+}
+
+func (c *DataExportAction) GetName() string {
+	return dataExportName
+}
+
+func (c *DataExportAction) Init(job *jobs.Job, cl client.Client, action *jobs.Action) error {
+	if job.Owner == "" || job.Owner == common.PydioSystemUsername {
+		return fmt.Errorf("data export must be run by the user owning the data")
+	}
+	c.owner = job.Owner
+	c.personalFolder = action.Parameters["personalFolder"]
+	c.target = action.Parameters["target"]
+	if c.personalFolder == "" || c.target == "" {
+		return fmt.Errorf("missing parameters personalFolder or target")
+	}
+	// Always read data as the owner
+	c.ParseScope(job.Owner, map[string]string{"scope": "owner"})
+	return nil
+}
+
+func (c *DataExportAction) Run(ctx context.Context, channels *actions.RunnableChannels, input jobs.ActionMessage) (jobs.ActionMessage, error) {
+
+	ctx, router, e := c.GetHandler(ctx)
+	if e != nil {
+		return input.WithError(e), e
+	}
+	u, e := permissions.SearchUniqueUser(ctx, c.owner, "")
+	if e != nil {
+		return input.WithError(e), e
+	}
+	personal := jobs.EvaluateFieldStr(ctx, input, c.personalFolder)
+	target := jobs.EvaluateFieldStr(ctx, input, c.target)
+
+	documents := map[string]func(context.Context, *idm.User) (interface{}, error){
+		"profile.json":    exportProfile,
+		"activities.json": exportActivities,
+		"shares.json":     exportShareLinks,
+		"chats.json":      exportChatMessages,
+	}
+	for _, name := range dataExportFiles {
+		channels.StatusMsg <- "Exporting " + strings.TrimSuffix(name, ".json")
+		data, er := documents[name](ctx, u)
+		if er != nil {
+			log.TasksLogger(ctx).Error("Cannot export "+name, zap.Error(er))
+			return input.WithError(er), er
+		}
+		if er := writeJSONDocument(ctx, router, path.Join(target, name), data); er != nil {
+			return input.WithError(er), er
+		}
+	}
+
+	output := input.WithNode(nil)
+	folder, e := router.ReadNode(ctx, &tree.ReadNodeRequest{Node: &tree.Node{Path: target}})
+	if e != nil {
+		return input.WithError(e), e
+	}
+	// Personal files, except previous exports
+	stream, e := router.ListNodes(ctx, &tree.ListNodesRequest{Node: &tree.Node{Path: personal}})
+	if e != nil {
+		return input.WithError(e), e
+	}
+	defer stream.Close()
+	for {
+		resp, er := stream.Recv()
+		if er != nil {
+			break
+		}
+		n := resp.GetNode()
+		if n == nil || path.Base(n.Path) == common.PydioSyncHiddenFile || isParentOrSelf(n.Path, target) {
+			continue
+		}
+		output = output.WithNodes(n)
+	}
+	output = output.WithNodes(folder.GetNode())
+	log.TasksLogger(ctx).Info(fmt.Sprintf("Exported data for user %s to %s", u.Login, target))
+
+	return output, nil
+}
+
+// DataExportNotifyAction sends the link to the archive built from a data export to the job owner,
+// and removes the folder containing the exported documents.
+type DataExportNotifyAction struct {
+	tools.ScopedRouterConsumer
+	owner       string
+	cleanFolder string
+}
+
+func (c *DataExportNotifyAction) GetDescription(lang ...string) actions.ActionDescription {
+	return actions.ActionDescription{
+		ID:               dataExportNotifyName,
+		IsInternal:       true,
+		Label:            "Notify user data export",
+		Icon:             "email",
+		Description:      "Mail the link to the archive of a data export to the job owner.",
+		Category:         actions.ActionCategoryIDM,
+		InputDescription: "Archive node, as created by actions.archive.compress",
+		SummaryTemplate:  "",
+		HasForm:          true,
+	}
+}
+
+func (c *DataExportNotifyAction) GetParametersForm() *forms.Form {
+	return &forms.Form{Groups: []*forms.Group{
+		{
+			Fields: []forms.Field{
+				&forms.FormField{
+					Name:        "cleanFolder",
+					Type:        forms.ParamString,
+					Label:       "Temporary folder",
+					Description: "Folder containing the exported documents, removed once archived",
+					Mandatory:   false,
+					Editable:    true,
+				},
+			},
+		},
+	}}
+}
+
+func (c *DataExportNotifyAction) GetName() string {
+	return dataExportNotifyName
+}
+
+func (c *DataExportNotifyAction) Init(job *jobs.Job, cl client.Client, action *jobs.Action) error {
+	c.owner = job.Owner
+	c.cleanFolder = action.Parameters["cleanFolder"]
+	c.ParseScope(job.Owner, map[string]string{"scope": "owner"})
+	return nil
+}
+
+func (c *DataExportNotifyAction) Run(ctx context.Context, channels *actions.RunnableChannels, input jobs.ActionMessage) (jobs.ActionMessage, error) {
+
+	if len(input.Nodes) == 0 {
+		return input.WithIgnore(), nil
+	}
+	archive := input.Nodes[0]
+	ctx, router, e := c.GetHandler(ctx)
+	if e != nil {
+		return input.WithError(e), e
+	}
+	if c.cleanFolder != "" {
+		folder := jobs.EvaluateFieldStr(ctx, input, c.cleanFolder)
+		for _, name := range append(dataExportFiles, common.PydioSyncHiddenFile, "") {
+			if _, er := router.DeleteNode(ctx, &tree.DeleteNodeRequest{Node: &tree.Node{Path: path.Join(folder, name)}}); er != nil {
+				log.Logger(ctx).Debug("Cannot remove export document", zap.String("name", name), zap.Error(er))
+			}
+		}
+	}
+
+	u, e := permissions.SearchUniqueUser(ctx, c.owner, "")
+	if e != nil {
+		return input.WithError(e), e
+	}
+	email := u.Attributes[idm.UserAttrEmail]
+	if email == "" {
+		log.TasksLogger(ctx).Info("User has no email, the archive is available in " + archive.Path)
+		return input, nil
+	}
+	name := u.Attributes[idm.UserAttrDisplayName]
+	if name == "" {
+		name = u.Login
+	}
+	mailCli := mailer.NewMailerServiceClient(registry.GetClient(common.ServiceMailer))
+	if _, e := mailCli.SendMail(ctx, &mailer.SendMailRequest{
+		InQueue: false,
+		Mail: &mailer.Mail{
+			To:         []*mailer.User{{Name: name, Address: email}},
+			TemplateId: "DataExport",
+			TemplateData: map[string]string{
+				"File":     path.Base(archive.Path),
+				"LinkPath": "/ws-" + strings.TrimLeft(archive.Path, "/"),
+			},
+		},
+	}); e != nil {
+		log.TasksLogger(ctx).Error("Could not send data export email", u.ZapLogin(), zap.Error(e))
+		return input.WithError(e), e
+	}
+	log.TasksLogger(ctx).Info("Sent data export link to " + u.Login)
+
+	return input, nil
+}
+
+// writeJSONDocument stores data as an indented JSON file.
+func writeJSONDocument(ctx context.Context, router views.Handler, filePath string, data interface{}) error {
+	content, e := json.MarshalIndent(data, "", "  ")
+	if e != nil {
+		return e
+	}
+	_, e = router.PutObject(ctx, &tree.Node{Path: filePath}, bytes.NewReader(content), &models.PutRequestData{Size: int64(len(content))})
+	return e
+}
+
+// isParentOrSelf checks if p is equal to or a parent of target.
+func isParentOrSelf(p, target string) bool {
+	p = strings.Trim(p, "/")
+	target = strings.Trim(target, "/")
+	return p == target || strings.HasPrefix(target, p+"/")
+}
+
+func exportProfile(ctx context.Context, u *idm.User) (interface{}, error) {
+	profile := u.WithPublicData(ctx, true)
+	profile.Password = ""
+	profile.Policies = nil
+	var roles []*idm.Role
+	for _, r := range profile.Roles {
+		if !r.UserRole && !r.GroupRole {
+			roles = append(roles, &idm.Role{Uuid: r.Uuid, Label: r.Label})
+		}
+	}
+	profile.Roles = roles
+	return profile, nil
+}
+
+func exportActivities(ctx context.Context, u *idm.User) (interface{}, error) {
+	cli := activity.NewActivityServiceClient(registry.GetClient(common.ServiceActivity))
+	stream, e := cli.StreamActivities(ctx, &activity.StreamActivitiesRequest{
+		Context:     activity.StreamContext_USER_ID,
+		ContextData: u.Login,
+		BoxName:     "outbox",
+	})
+	if e != nil {
+		return nil, e
+	}
+	defer stream.Close()
+	activities := []*activity.Object{}
+	for {
+		resp, er := stream.Recv()
+		if er != nil {
+			break
+		}
+		if resp.GetActivity() != nil {
+			activities = append(activities, resp.GetActivity())
+		}
+	}
+	return activities, nil
+}
+
+// exportedShareLink is a share link document along with its public hash.
+type exportedShareLink struct {
+	Hash string
+	*docstore.ShareDocument
+}
+
+func exportShareLinks(ctx context.Context, u *idm.User) (interface{}, error) {
+	store := docstore.NewDocStoreClient(registry.GetClient(common.ServiceDocStore))
+	stream, e := store.ListDocuments(ctx, &docstore.ListDocumentsRequest{StoreID: common.DocStoreIdShares, Query: &docstore.DocumentQuery{
+		MetaQuery: "+OWNER_ID:\"" + u.Login + "\" +SHARE_TYPE:minisite",
+	}})
+	if e != nil {
+		return nil, e
+	}
+	defer stream.Close()
+	links := []*exportedShareLink{}
+	for {
+		resp, er := stream.Recv()
+		if er != nil {
+			break
+		}
+		doc := resp.GetDocument()
+		if doc == nil {
+			continue
+		}
+		var shareDoc docstore.ShareDocument
+		if er := json.Unmarshal([]byte(doc.Data), &shareDoc); er != nil || shareDoc.OwnerId != u.Login {
+			continue
+		}
+		links = append(links, &exportedShareLink{Hash: doc.ID, ShareDocument: &shareDoc})
+	}
+	return links, nil
+}
+
+func exportChatMessages(ctx context.Context, u *idm.User) (interface{}, error) {
+	cli := chat.NewChatServiceClient(common.ServiceGrpcNamespace_+common.ServiceChat, defaults.NewClient())
+	stream, e := cli.ListMessages(ctx, &chat.ListMessagesRequest{ByAuthor: u.Login})
+	if e != nil {
+		return nil, e
+	}
+	defer stream.Close()
+	messages := []*chat.ChatMessage{}
+	for {
+		resp, er := stream.Recv()
+		if er != nil {
+			break
+		}
+		if m := resp.GetMessage(); m != nil {
+			messages = append(messages, m)
+		}
+	}
+	return messages, nil
+}
@@ -0,0 +1,218 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package idm
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/micro/go-micro/client"
+	"go.uber.org/zap"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/forms"
+	"github.com/pydio/cells/common/log"
+	defaults "github.com/pydio/cells/common/micro"
+	"github.com/pydio/cells/common/proto/idm"
+	"github.com/pydio/cells/common/proto/jobs"
+	"github.com/pydio/cells/common/proto/mailer"
+	"github.com/pydio/cells/common/registry"
+	"github.com/pydio/cells/common/service/proto"
+	"github.com/pydio/cells/scheduler/actions"
+)
+
+var (
+	expireRolesName = "actions.idm.roles.expire"
+)
+
+// ExpireRolesAction removes roles from users once their membership validity is over,
+// and warns users before their memberships expire.
+type ExpireRolesAction struct {
+	notifyDays int
+}
+
+func (c *ExpireRolesAction) GetDescription(lang ...string) actions.ActionDescription {
+	return actions.ActionDescription{
+		ID:              expireRolesName,
+		IsInternal:      true,
+		Label:           "Expire roles memberships",
+		Icon:            "account-clock",
+		Description:     "Remove roles from users when their validity window is over, and notify users before expiration.",
+		Category:        actions.ActionCategoryIDM,
+		SummaryTemplate: "",
+		HasForm:         true,
+	}
+}
+
+func (c *ExpireRolesAction) GetParametersForm() *forms.Form {
+	return &forms.Form{Groups: []*forms.Group{
+		{
+			Fields: []forms.Field{
+				&forms.FormField{
+					Name:        "notifyDays",
+					Type:        forms.ParamInteger,
+					Label:       "Notify before (days)",
+					Description: "Send an email to the user this number of days before a role expires (0 to disable)",
+					Default:     7,
+					Mandatory:   false,
+					Editable:    true,
+				},
+			},
+		},
+	}}
+}
+
+func (c *ExpireRolesAction) GetName() string {
+	return expireRolesName
+}
+
+func (c *ExpireRolesAction) Init(job *jobs.Job, cl client.Client, action *jobs.Action) error {
+	c.notifyDays = 7
+	if d, o := action.Parameters["notifyDays"]; o && d != "" {
+		i, e := strconv.Atoi(d)
+		if e != nil {
+			return fmt.Errorf("invalid value for notifyDays: %v", e)
+		}
+		c.notifyDays = i
+	}
+	return nil
+}
+
+func (c *ExpireRolesAction) Run(ctx context.Context, channels *actions.RunnableChannels, input jobs.ActionMessage) (jobs.ActionMessage, error) {
+
+	userClient := idm.NewUserServiceClient(common.ServiceGrpcNamespace_+common.ServiceUser, defaults.NewClient())
+	q, _ := ptypes.MarshalAny(&idm.UserSingleQuery{AttributeName: idm.UserAttrRolesValidity, AttributeAnyValue: true})
+	stream, e := userClient.SearchUser(ctx, &idm.SearchUserRequest{Query: &service.Query{SubQueries: []*any.Any{q}}})
+	if e != nil {
+		return input.WithError(e), e
+	}
+	var users []*idm.User
+	for {
+		resp, er := stream.Recv()
+		if er != nil {
+			break
+		}
+		users = append(users, resp.GetUser())
+	}
+	stream.Close()
+
+	now := time.Now()
+	notifyBefore := time.Duration(c.notifyDays) * 24 * time.Hour
+	var expiredCount int
+	for _, u := range users {
+		expired, notify, changed := applyRolesValidity(u, now, notifyBefore)
+		if !changed {
+			continue
+		}
+		if _, e := userClient.CreateUser(ctx, &idm.CreateUserRequest{User: u}); e != nil {
+			log.TasksLogger(ctx).Error("Cannot update roles for user", u.ZapLogin(), zap.Error(e))
+			continue
+		}
+		for _, r := range expired {
+			log.TasksLogger(ctx).Info(fmt.Sprintf("Role %s expired for user %s", r.Label, u.Login))
+			client.Publish(ctx, client.NewPublication(common.TopicIdmEvent, &idm.ChangeEvent{
+				Type: idm.ChangeEventType_UPDATE,
+				User: u,
+				Role: r,
+				Attributes: map[string]string{
+					"type": "role-expired",
+				},
+			}))
+		}
+		expiredCount += len(expired)
+		for _, r := range notify {
+			c.sendExpirationWarning(ctx, u, r, now)
+		}
+	}
+	log.TasksLogger(ctx).Info(fmt.Sprintf("Checked %d users, removed %d expired roles", len(users), expiredCount))
+
+	return input, nil
+}
+
+// sendExpirationWarning emails the user about a role that will soon be removed.
+func (c *ExpireRolesAction) sendExpirationWarning(ctx context.Context, u *idm.User, r *idm.Role, now time.Time) {
+	email := u.Attributes[idm.UserAttrEmail]
+	if email == "" {
+		return
+	}
+	name := u.Attributes[idm.UserAttrDisplayName]
+	if name == "" {
+		name = u.Login
+	}
+	end := time.Unix(u.RolesValidity()[r.Uuid].End, 0)
+	mailCli := mailer.NewMailerServiceClient(registry.GetClient(common.ServiceMailer))
+	if _, e := mailCli.SendMail(ctx, &mailer.SendMailRequest{
+		InQueue: false,
+		Mail: &mailer.Mail{
+			To:         []*mailer.User{{Name: name, Address: email}},
+			TemplateId: "RoleExpiration",
+			TemplateData: map[string]string{
+				"Role": r.Label,
+				"Date": end.Format("2006-01-02 15:04"),
+				"Days": strconv.Itoa(int(end.Sub(now).Hours()/24) + 1),
+			},
+		},
+	}); e != nil {
+		log.TasksLogger(ctx).Error("Could not send role expiration email", u.ZapLogin(), zap.Error(e))
+	}
+}
+
+// applyRolesValidity removes expired roles from the user and marks the roles that must be notified.
+// It returns the expired roles, the roles to notify and whether the user must be saved.
+func applyRolesValidity(u *idm.User, now time.Time, notifyBefore time.Duration) (expired []*idm.Role, notify []*idm.Role, changed bool) {
+	validity := u.RolesValidity()
+	present := make(map[string]bool, len(u.Roles))
+	var roles []*idm.Role
+	for _, r := range u.Roles {
+		v, ok := validity[r.Uuid]
+		if ok && !r.GroupRole && !r.UserRole {
+			if v.IsExpired(now) {
+				expired = append(expired, r)
+				delete(validity, r.Uuid)
+				changed = true
+				continue
+			}
+			if notifyBefore > 0 && v.End > 0 && !v.Notified && now.Add(notifyBefore).Unix() >= v.End {
+				notify = append(notify, r)
+				v.Notified = true
+				changed = true
+			}
+		}
+		present[r.Uuid] = true
+		roles = append(roles, r)
+	}
+	// Drop windows of roles that are not attached to the user anymore
+	for id := range validity {
+		if !present[id] {
+			delete(validity, id)
+			changed = true
+		}
+	}
+	if changed {
+		u.Roles = roles
+		u.SetRolesValidity(validity)
+	}
+	return
+}
@@ -0,0 +1,109 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package idm
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pydio/cells/common/proto/idm"
+	"github.com/pydio/cells/common/proto/jobs"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestExpireRolesAction_Init(t *testing.T) {
+	Convey("Test Init", t, func() {
+		action := &ExpireRolesAction{}
+		So(action.GetName(), ShouldEqual, expireRolesName)
+		So(action.Init(&jobs.Job{}, nil, &jobs.Action{}), ShouldBeNil)
+		So(action.notifyDays, ShouldEqual, 7)
+		So(action.Init(&jobs.Job{}, nil, &jobs.Action{Parameters: map[string]string{"notifyDays": "3"}}), ShouldBeNil)
+		So(action.notifyDays, ShouldEqual, 3)
+		So(action.Init(&jobs.Job{}, nil, &jobs.Action{Parameters: map[string]string{"notifyDays": "abc"}}), ShouldNotBeNil)
+	})
+}
+
+func TestApplyRolesValidity(t *testing.T) {
+
+	now := time.Now()
+	week := 7 * 24 * time.Hour
+
+	Convey("Test expired and soon expiring roles", t, func() {
+		u := &idm.User{
+			Login: "user",
+			Roles: []*idm.Role{
+				{Uuid: "ROOT_GROUP", GroupRole: true},
+				{Uuid: "expired"},
+				{Uuid: "soon"},
+				{Uuid: "later"},
+				{Uuid: "user-uuid", UserRole: true},
+			},
+		}
+		u.SetRolesValidity(map[string]*idm.RoleValidity{
+			"expired": {End: now.Add(-time.Hour).Unix()},
+			"soon":    {End: now.Add(48 * time.Hour).Unix()},
+			"later":   {Start: now.Add(-time.Hour).Unix(), End: now.Add(30 * 24 * time.Hour).Unix()},
+			"removed": {End: now.Add(time.Hour).Unix()},
+		})
+
+		expired, notify, changed := applyRolesValidity(u, now, week)
+		So(changed, ShouldBeTrue)
+		So(expired, ShouldHaveLength, 1)
+		So(expired[0].Uuid, ShouldEqual, "expired")
+		So(notify, ShouldHaveLength, 1)
+		So(notify[0].Uuid, ShouldEqual, "soon")
+		So(u.Roles, ShouldHaveLength, 4)
+
+		validity := u.RolesValidity()
+		So(validity, ShouldHaveLength, 2)
+		So(validity["soon"].Notified, ShouldBeTrue)
+		So(validity["later"].Notified, ShouldBeFalse)
+
+		Convey("Second run does nothing", func() {
+			expired, notify, changed := applyRolesValidity(u, now, week)
+			So(changed, ShouldBeFalse)
+			So(expired, ShouldBeEmpty)
+			So(notify, ShouldBeEmpty)
+		})
+	})
+
+	Convey("Test notifications disabled and attribute cleared", t, func() {
+		u := &idm.User{Login: "user", Roles: []*idm.Role{{Uuid: "expired"}}}
+		u.SetRolesValidity(map[string]*idm.RoleValidity{"expired": {End: now.Unix()}})
+		expired, notify, changed := applyRolesValidity(u, now, 0)
+		So(changed, ShouldBeTrue)
+		So(expired, ShouldHaveLength, 1)
+		So(notify, ShouldBeEmpty)
+		So(u.Roles, ShouldBeEmpty)
+		So(u.Attributes, ShouldNotContainKey, idm.UserAttrRolesValidity)
+	})
+
+	Convey("Test active roles", t, func() {
+		u := &idm.User{Login: "user", Roles: []*idm.Role{{Uuid: "future"}, {Uuid: "past"}, {Uuid: "free"}}}
+		u.SetRolesValidity(map[string]*idm.RoleValidity{
+			"future": {Start: now.Add(time.Hour).Unix()},
+			"past":   {End: now.Add(-time.Hour).Unix()},
+		})
+		active := u.ActiveRoles(now)
+		So(active, ShouldHaveLength, 1)
+		So(active[0].Uuid, ShouldEqual, "free")
+	})
+}
@@ -28,5 +28,20 @@ func init() {
 	manager.Register(cleanUserDataName, func() actions.ConcreteAction {
 		return &CleanUserDataAction{}
 	})
+	manager.Register(expireRolesName, func() actions.ConcreteAction {
+		return &ExpireRolesAction{}
+	})
+	manager.Register(dataExportName, func() actions.ConcreteAction {
+		return &DataExportAction{}
+	})
+	manager.Register(dataExportNotifyName, func() actions.ConcreteAction {
+		return &DataExportNotifyAction{}
+	})
+	manager.Register(selfDeletionName, func() actions.ConcreteAction {
+		return &SelfDeletionAction{}
+	})
+	manager.Register(accessReviewName, func() actions.ConcreteAction {
+		return &AccessReviewAction{}
+	})
 
 }
@@ -0,0 +1,125 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package idm
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/micro/go-micro/client"
+	"go.uber.org/zap"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/forms"
+	"github.com/pydio/cells/common/log"
+	defaults "github.com/pydio/cells/common/micro"
+	"github.com/pydio/cells/common/proto/idm"
+	"github.com/pydio/cells/common/proto/jobs"
+	"github.com/pydio/cells/common/service/proto"
+	"github.com/pydio/cells/scheduler/actions"
+)
+
+var (
+	selfDeletionName = "actions.idm.self-deletion"
+)
+
+// SelfDeletionAction deletes the users that confirmed the deletion of their own account, once their
+// grace period is over. Their data is then handled by the actions.idm.clean-user-data action, triggered
+// by the deletion event.
+type SelfDeletionAction struct{}
+
+func (c *SelfDeletionAction) GetDescription(lang ...string) actions.ActionDescription {
+	return actions.ActionDescription{
+		ID:              selfDeletionName,
+		IsInternal:      true,
+		Label:           "Delete accounts on request",
+		Icon:            "account-remove",
+		Description:     "Delete users that requested the deletion of their account, once the grace period is over.",
+		Category:        actions.ActionCategoryIDM,
+		SummaryTemplate: "",
+		HasForm:         false,
+	}
+}
+
+func (c *SelfDeletionAction) GetParametersForm() *forms.Form {
+	return nil
+}
+
+func (c *SelfDeletionAction) GetName() string {
+	return selfDeletionName
+}
+
+func (c *SelfDeletionAction) Init(job *jobs.Job, cl client.Client, action *jobs.Action) error {
+	return nil
+}
+
+func (c *SelfDeletionAction) Run(ctx context.Context, channels *actions.RunnableChannels, input jobs.ActionMessage) (jobs.ActionMessage, error) {
+
+	userClient := idm.NewUserServiceClient(common.ServiceGrpcNamespace_+common.ServiceUser, defaults.NewClient())
+	q, _ := ptypes.MarshalAny(&idm.UserSingleQuery{AttributeName: idm.UserAttrDeletionDate, AttributeAnyValue: true})
+	stream, e := userClient.SearchUser(ctx, &idm.SearchUserRequest{Query: &service.Query{SubQueries: []*any.Any{q}}})
+	if e != nil {
+		return input.WithError(e), e
+	}
+	var users []*idm.User
+	for {
+		resp, er := stream.Recv()
+		if er != nil {
+			break
+		}
+		users = append(users, resp.GetUser())
+	}
+	stream.Close()
+
+	var deleted int
+	for _, u := range dueForDeletion(users, time.Now()) {
+		uq, _ := ptypes.MarshalAny(&idm.UserSingleQuery{Uuid: u.Uuid})
+		if _, e := userClient.DeleteUser(ctx, &idm.DeleteUserRequest{Query: &service.Query{SubQueries: []*any.Any{uq}}}); e != nil {
+			log.TasksLogger(ctx).Error("Cannot delete user", u.ZapLogin(), zap.Error(e))
+			continue
+		}
+		log.Auditer(ctx).Info(
+			fmt.Sprintf("Deleted user [%s] on their own request", u.Login),
+			log.GetAuditId(common.AuditUserDelete),
+			u.ZapUuid(),
+		)
+		deleted++
+	}
+	log.TasksLogger(ctx).Info(fmt.Sprintf("Found %d pending deletion request(s), deleted %d user(s)", len(users), deleted))
+
+	return input, nil
+}
+
+// dueForDeletion filters the users whose grace period is over.
+func dueForDeletion(users []*idm.User, now time.Time) (due []*idm.User) {
+	for _, u := range users {
+		if u.IsGroup {
+			continue
+		}
+		if d, ok := u.ScheduledDeletion(); ok && !now.Before(d) {
+			due = append(due, u)
+		}
+	}
+	return
+}
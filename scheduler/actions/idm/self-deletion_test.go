@@ -0,0 +1,50 @@
+package idm
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/pydio/cells/common/proto/idm"
+	"github.com/pydio/cells/common/proto/jobs"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestDueForDeletion(t *testing.T) {
+	Convey("Test users whose grace period is over", t, func() {
+		now := time.Now()
+		at := func(d time.Duration) map[string]string {
+			return map[string]string{idm.UserAttrDeletionDate: strconv.FormatInt(now.Add(d).Unix(), 10)}
+		}
+		users := []*idm.User{
+			{Login: "past", Attributes: at(-time.Hour)},
+			{Login: "future", Attributes: at(time.Hour)},
+			{Login: "invalid", Attributes: map[string]string{idm.UserAttrDeletionDate: "tomorrow"}},
+			{Login: "none"},
+			{GroupPath: "/group", IsGroup: true, Attributes: at(-time.Hour)},
+		}
+		due := dueForDeletion(users, now)
+		So(due, ShouldHaveLength, 1)
+		So(due[0].Login, ShouldEqual, "past")
+		So(dueForDeletion(users, now.Add(2*time.Hour)), ShouldHaveLength, 2)
+	})
+}
+
+func TestDataExportAction_Init(t *testing.T) {
+	Convey("Test Init", t, func() {
+		action := &DataExportAction{}
+		So(action.GetName(), ShouldEqual, dataExportName)
+		params := map[string]string{"personalFolder": "personal-files", "target": "personal-files/data-exports/export"}
+		So(action.Init(&jobs.Job{Owner: "user"}, nil, &jobs.Action{Parameters: params}), ShouldBeNil)
+		So(action.Init(&jobs.Job{Owner: "user"}, nil, &jobs.Action{}), ShouldNotBeNil)
+		So(action.Init(&jobs.Job{Owner: "pydio.system.user"}, nil, &jobs.Action{Parameters: params}), ShouldNotBeNil)
+	})
+
+	Convey("Test personal files filtering", t, func() {
+		target := "personal-files/data-exports/export"
+		So(isParentOrSelf("personal-files/data-exports", target), ShouldBeTrue)
+		So(isParentOrSelf("/personal-files/data-exports/export/", target), ShouldBeTrue)
+		So(isParentOrSelf("personal-files/data", target), ShouldBeFalse)
+		So(isParentOrSelf("personal-files/documents", target), ShouldBeFalse)
+	})
+}
@@ -140,10 +140,87 @@ func getDefaultJobs() []*jobs.Job {
 		},
 	}
 
+	expireRolesJob := &jobs.Job{
+		ID:             "expire-roles-job",
+		Owner:          common.PydioSystemUsername,
+		Label:          "Jobs.Default.ExpireRoles",
+		MaxConcurrency: 1,
+		Schedule: &jobs.Schedule{
+			Iso8601Schedule: "R/2012-06-04T19:25:16.828696-07:03/PT1H",
+		},
+		Actions: []*jobs.Action{
+			{
+				ID:         "actions.idm.roles.expire",
+				Parameters: map[string]string{"notifyDays": "7"},
+			},
+		},
+	}
+
+	selfDeletionJob := &jobs.Job{
+		ID:             "self-deletion-job",
+		Owner:          common.PydioSystemUsername,
+		Label:          "Jobs.Default.SelfDeletion",
+		MaxConcurrency: 1,
+		Schedule: &jobs.Schedule{
+			Iso8601Schedule: "R/2012-06-04T19:25:16.828696-07:03/PT1H",
+		},
+		Actions: []*jobs.Action{
+			{
+				ID: "actions.idm.self-deletion",
+			},
+		},
+	}
+
+	accessReviewJob := &jobs.Job{
+		ID:             "access-review-job",
+		Owner:          common.PydioSystemUsername,
+		Label:          "Jobs.Default.AccessReview",
+		MaxConcurrency: 1,
+		Schedule: &jobs.Schedule{
+			Iso8601Schedule: "R/2012-06-04T19:25:16.828696-07:03/PT1H",
+		},
+		Actions: []*jobs.Action{
+			{
+				ID: "actions.idm.access-review.close",
+			},
+		},
+	}
+
+	antivirusJob := &jobs.Job{
+		ID:                "antivirus-scan-job",
+		Owner:             common.PydioSystemUsername,
+		Label:             "Jobs.Default.Antivirus",
+		Inactive:          true,
+		MaxConcurrency:    5,
+		TasksSilentUpdate: true,
+		EventNames: []string{
+			jobs.NodeChangeEventName(tree.NodeChangeEvent_CREATE),
+			jobs.NodeChangeEventName(tree.NodeChangeEvent_UPDATE_CONTENT),
+		},
+		NodeEventFilter: &jobs.NodesSelector{
+			Label: "Files Only",
+			Query: &service.Query{
+				SubQueries: []*any.Any{jobs.MustMarshalAny(&tree.Query{
+					Type:    tree.NodeType_LEAF,
+					MinSize: 1,
+				})},
+			},
+		},
+		Actions: []*jobs.Action{
+			{
+				ID: "actions.antivirus.scan",
+			},
+		},
+	}
+
 	defJobs := []*jobs.Job{
 		thumbnailsJob,
 		stuckTasksJob,
 		cleanUserDataJob,
+		expireRolesJob,
+		selfDeletionJob,
+		accessReviewJob,
+		antivirusJob,
 	}
 
 	return defJobs
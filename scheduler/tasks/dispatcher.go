@@ -21,6 +21,8 @@
 package tasks
 
 import (
+	"sync/atomic"
+
 	"github.com/pydio/cells/common/service/metrics"
 )
 
@@ -37,6 +39,7 @@ type Dispatcher struct {
 	maxWorker  int
 	tags       map[string]string
 	active     int
+	queued     int64
 	activeChan chan int
 	quit       chan bool
 }
@@ -68,6 +71,7 @@ func (d *Dispatcher) Run() {
 	}
 
 	g := metrics.GetMetrics().Tagged(d.tags).Gauge("activeWorkers")
+	q := metrics.GetMetrics().Tagged(d.tags).Gauge("queuedJobs")
 
 	go func() {
 		for {
@@ -77,10 +81,12 @@ func (d *Dispatcher) Run() {
 				g.Update(float64(d.active))
 			case jobImpl := <-d.JobQueue:
 				// a jobs request has been received
+				q.Update(float64(atomic.AddInt64(&d.queued, 1)))
 				go func(job Runnable) {
 					// try to obtain a worker job channel that is available.
 					// this will block until a worker is idle
 					jobChannel := <-d.WorkerPool
+					q.Update(float64(atomic.AddInt64(&d.queued, -1)))
 
 					// dispatch the job to the worker job channel
 					jobChannel <- job
@@ -36,6 +36,7 @@ import (
 	"github.com/pydio/cells/common/log"
 	"github.com/pydio/cells/common/proto/jobs"
 	servicecontext "github.com/pydio/cells/common/service/context"
+	"github.com/pydio/cells/common/service/metrics"
 	context2 "github.com/pydio/cells/common/utils/context"
 	"github.com/pydio/cells/scheduler/actions"
 )
@@ -140,6 +141,14 @@ func (r *Runnable) Dispatch(parentPath string, input jobs.ActionMessage, aa []*j
 	}
 }
 
+// reportOutcome counts the actions run by the scheduler, by action type and result
+func (r *Runnable) reportOutcome(outcome string) {
+	metrics.GetMetrics().Tagged(map[string]string{
+		"action":  r.Action.ID,
+		"outcome": outcome,
+	}).Counter("task_outcomes").Inc(1)
+}
+
 // RunAction creates an action and calls Dispatch
 func (r *Runnable) RunAction(Queue chan Runnable) error {
 
@@ -156,6 +165,7 @@ func (r *Runnable) RunAction(Queue chan Runnable) error {
 	defer func() {
 		if re := recover(); re != nil {
 			r.Task.SetStatus(jobs.TaskStatus_Error, "Panic inside task")
+			r.reportOutcome("panic")
 			if e, ok := re.(error); ok {
 				log.TasksLogger(r.Context).Error("Recovered scheduler task", zap.Any("task", r.Task), zap.Error(e))
 				log.Logger(r.Context).Error("Recovered scheduler task", zap.Any("task", r.Task), zap.Error(e))
@@ -192,8 +202,10 @@ func (r *Runnable) RunAction(Queue chan Runnable) error {
 		r.Task.SetStatus(jobs.TaskStatus_Error, "Error: "+err.Error())
 		r.Task.SetEndTime(time.Now())
 		r.Task.Save()
+		r.reportOutcome("error")
 		return err
 	}
+	r.reportOutcome("success")
 	r.Task.AppendLog(r.Action, r.Message, outputMessage)
 
 	if !r.Action.BreakAfter {
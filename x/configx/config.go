@@ -210,6 +210,9 @@ func (v *config) Set(data interface{}) error {
 		data = d.v
 	}
 
+	// Never replace a secret reference by the value it resolves to
+	data = keepReferences(v.v, data)
+
 	if len(v.k) == 0 {
 		v.v = data
 		return nil
@@ -304,7 +307,7 @@ func (v *config) Scan(val interface{}) error {
 		return nil
 	}
 
-	jsonStr, err := json.Marshal(v.v)
+	jsonStr, err := json.Marshal(resolve(v.v))
 	if err != nil {
 		return err
 	}
@@ -320,7 +323,7 @@ func (v *config) Scan(val interface{}) error {
 }
 
 func (c *config) Bool() bool {
-	v := c.get()
+	v := resolve(c.get())
 	if v == nil {
 		return false
 	}
@@ -345,24 +348,24 @@ func (c *config) Bytes() []byte {
 			c.v = nil
 		}
 	}
-	return []byte(cast.ToString(v))
+	return []byte(cast.ToString(resolve(v)))
 }
 func (c *config) Int() int {
-	v := c.get()
+	v := resolve(c.get())
 	if v == nil {
 		return 0
 	}
 	return cast.ToInt(v)
 }
 func (c *config) Int64() int64 {
-	v := c.get()
+	v := resolve(c.get())
 	if v == nil {
 		return 0
 	}
 	return cast.ToInt64(v)
 }
 func (c *config) Duration() time.Duration {
-	v := c.get()
+	v := resolve(c.get())
 	if v == nil {
 		return 0 * time.Second
 	}
@@ -386,10 +389,10 @@ func (c *config) String() string {
 		}
 	}
 
-	return cast.ToString(v)
+	return cast.ToString(resolve(v))
 }
 func (c *config) StringMap() map[string]string {
-	v := c.get()
+	v := resolve(c.get())
 	if v == nil {
 		return map[string]string{}
 	}
@@ -400,7 +403,7 @@ func (c *config) StringArray() []string {
 	if v == nil {
 		return []string{}
 	}
-	return cast.ToStringSlice(c.get())
+	return cast.ToStringSlice(resolve(v))
 }
 func (c *config) Slice() []interface{} {
 	v := c.get()
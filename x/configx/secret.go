@@ -0,0 +1,220 @@
+/*
+ * Copyright (c) 2018-2021. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package configx
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"regexp"
+	"strings"
+
+	json "github.com/pydio/cells/x/jsonx"
+)
+
+var secretRefRegexp = regexp.MustCompile(`^\$\{(file|env):(.+)\}$`)
+
+// SecretReference builds a reference to a secret stored in a file or in an environment variable.
+// kind is either "file" or "env".
+func SecretReference(kind, name string) string {
+	return "${" + kind + ":" + name + "}"
+}
+
+// IsReference checks if a value is a reference to a secret, like ${file:/run/secrets/x} or ${env:NAME}
+func IsReference(v interface{}) bool {
+	s, ok := v.(string)
+	return ok && secretRefRegexp.MatchString(s)
+}
+
+// ResolveReference reads the secret a reference points to
+func ResolveReference(s string) (string, error) {
+	parts := secretRefRegexp.FindStringSubmatch(s)
+	if len(parts) != 3 {
+		return "", fmt.Errorf("%s is not a secret reference", s)
+	}
+
+	switch parts[1] {
+	case "env":
+		val, ok := os.LookupEnv(parts[2])
+		if !ok {
+			return "", fmt.Errorf("environment variable %s is not set", parts[2])
+		}
+		return val, nil
+	default:
+		data, err := ioutil.ReadFile(parts[2])
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimRight(string(data), "\r\n"), nil
+	}
+}
+
+// HasReference checks if a value or any of its children is a secret reference
+func HasReference(v interface{}) bool {
+	switch vv := v.(type) {
+	case string:
+		return IsReference(vv)
+	case map[string]interface{}:
+		for _, c := range vv {
+			if HasReference(c) {
+				return true
+			}
+		}
+	case map[string]string:
+		for _, c := range vv {
+			if IsReference(c) {
+				return true
+			}
+		}
+	case []interface{}:
+		for _, c := range vv {
+			if HasReference(c) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// KeepReferences returns data where the values equal to the resolution of a secret reference currently
+// stored in current are replaced by the reference itself, so that resolved secrets are never written back.
+func KeepReferences(current Value, data interface{}) interface{} {
+	c, ok := current.(*config)
+	if !ok || c == nil {
+		return data
+	}
+	return keepReferences(c.v, data)
+}
+
+// resolve replaces all secret references found in v by their value. Secrets that cannot be read
+// resolve to an empty string.
+func resolve(v interface{}) interface{} {
+	switch vv := v.(type) {
+	case string:
+		if !IsReference(vv) {
+			return vv
+		}
+		s, _ := ResolveReference(vv)
+		return s
+	case map[string]interface{}:
+		if !HasReference(vv) {
+			return vv
+		}
+		m := make(map[string]interface{}, len(vv))
+		for k, c := range vv {
+			m[k] = resolve(c)
+		}
+		return m
+	case map[string]string:
+		if !HasReference(vv) {
+			return vv
+		}
+		m := make(map[string]string, len(vv))
+		for k, c := range vv {
+			m[k] = resolve(c).(string)
+		}
+		return m
+	case []interface{}:
+		if !HasReference(vv) {
+			return vv
+		}
+		s := make([]interface{}, len(vv))
+		for i, c := range vv {
+			s[i] = resolve(c)
+		}
+		return s
+	}
+	return v
+}
+
+func keepReferences(old, data interface{}) interface{} {
+	if !HasReference(old) {
+		return data
+	}
+
+	switch o := old.(type) {
+	case string:
+		if s, ok := data.(string); ok && s != o && s == resolve(o) {
+			return o
+		}
+	case map[string]interface{}:
+		d := toMap(data)
+		if d == nil {
+			return data
+		}
+		m := make(map[string]interface{}, len(d))
+		for k, c := range d {
+			if oc, ok := o[k]; ok {
+				c = keepReferences(oc, c)
+			}
+			m[k] = c
+		}
+		return m
+	case map[string]string:
+		om := make(map[string]interface{}, len(o))
+		for k, c := range o {
+			om[k] = c
+		}
+		return keepReferences(om, data)
+	case []interface{}:
+		d, ok := data.([]interface{})
+		if !ok || len(d) != len(o) {
+			return data
+		}
+		s := make([]interface{}, len(d))
+		for i, c := range d {
+			s[i] = keepReferences(o[i], c)
+		}
+		return s
+	}
+
+	return data
+}
+
+func toMap(data interface{}) map[string]interface{} {
+	switch d := data.(type) {
+	case map[string]interface{}:
+		return d
+	case map[string]string:
+		m := make(map[string]interface{}, len(d))
+		for k, c := range d {
+			m[k] = c
+		}
+		return m
+	}
+	if data != nil && reflect.TypeOf(data).Kind() == reflect.Map && reflect.TypeOf(data).Key().Kind() == reflect.String {
+		m := make(map[string]interface{})
+		iter := reflect.ValueOf(data).MapRange()
+		for iter.Next() {
+			m[iter.Key().String()] = iter.Value().Interface()
+		}
+		return m
+	}
+	if v := reflect.ValueOf(data); v.Kind() == reflect.Struct || v.Kind() == reflect.Ptr && !v.IsNil() && v.Elem().Kind() == reflect.Struct {
+		// Structs are stored as their JSON representation, compare them on the same keys
+		var m map[string]interface{}
+		if b, e := json.Marshal(data); e == nil && json.Unmarshal(b, &m) == nil {
+			return m
+		}
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package configx
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSecretReferences(t *testing.T) {
+	Convey("Testing secret references", t, func() {
+		dir, err := ioutil.TempDir("", "configx")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(dir)
+
+		file := filepath.Join(dir, "password")
+		So(ioutil.WriteFile(file, []byte("from-file\n"), 0600), ShouldBeNil)
+		os.Setenv("CONFIGX_TEST_SECRET", "from-env")
+		defer os.Unsetenv("CONFIGX_TEST_SECRET")
+
+		c := New()
+		So(c.Set(map[string]interface{}{
+			"sender": map[string]interface{}{
+				"user":     "name",
+				"password": SecretReference("file", file),
+			},
+			"databases": map[string]interface{}{
+				"default": map[string]interface{}{
+					"driver": "mysql",
+					"dsn":    "${env:CONFIGX_TEST_SECRET}",
+				},
+			},
+			"missing": "${env:CONFIGX_TEST_MISSING}",
+		}), ShouldBeNil)
+
+		So(c.Val("sender/password").String(), ShouldEqual, "from-file")
+		So(c.Val("databases/default").StringMap()["dsn"], ShouldEqual, "from-env")
+		So(c.Val("missing").String(), ShouldEqual, "")
+
+		var sender struct{ User, Password string }
+		So(c.Val("sender").Scan(&sender), ShouldBeNil)
+		So(sender.Password, ShouldEqual, "from-file")
+
+		// Raw accessors keep the references
+		So(c.Val("sender").Map()["password"], ShouldEqual, "${file:"+file+"}")
+
+		// Writing back the resolved values does not replace the references
+		So(c.Val("sender").Set(map[string]interface{}{"user": "other", "password": "from-file"}), ShouldBeNil)
+		So(c.Val("sender").Map()["password"], ShouldEqual, "${file:"+file+"}")
+		So(c.Val("sender/user").String(), ShouldEqual, "other")
+		So(c.Val("databases/default/dsn").Set("from-env"), ShouldBeNil)
+		So(c.Val("databases/default").Map()["dsn"], ShouldEqual, "${env:CONFIGX_TEST_SECRET}")
+
+		// Structs holding the resolved values do not replace the references either
+		type senderConf struct {
+			User     string `json:"user"`
+			Password string `json:"password"`
+		}
+		So(c.Val("sender").Set(&senderConf{User: "struct", Password: "from-file"}), ShouldBeNil)
+		So(c.Val("sender").Map()["password"], ShouldEqual, "${file:"+file+"}")
+		So(c.Val("sender/user").String(), ShouldEqual, "struct")
+		So(c.Val("databases/default").Set(struct {
+			Driver string `json:"driver"`
+			Dsn    string `json:"dsn"`
+		}{Driver: "mysql", Dsn: "from-env"}), ShouldBeNil)
+		So(c.Val("databases/default").Map()["dsn"], ShouldEqual, "${env:CONFIGX_TEST_SECRET}")
+
+		// New values replace the references
+		So(c.Val("sender/password").Set("changed"), ShouldBeNil)
+		So(c.Val("sender").Map()["password"], ShouldEqual, "changed")
+	})
+}